@@ -0,0 +1,317 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"sigs.k8s.io/release-utils/command"
+
+	"sigs.k8s.io/bom/pkg/license"
+	"sigs.k8s.io/bom/pkg/objectstore"
+	"sigs.k8s.io/bom/pkg/serialize"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// defaultCIOutput is the SBOM file written when the configuration
+// does not set one
+const defaultCIOutput = "sbom.spdx.json"
+
+// ciConfiguration is the YAML file driving the bom ci pipeline
+type ciConfiguration struct {
+	Generate struct {
+		Config        string   `yaml:"config"` // SBOM YAML configuration
+		Images        []string `yaml:"images"`
+		ImageArchives []string `yaml:"image-archives"`
+		Directories   []string `yaml:"directories"`
+		Files         []string `yaml:"files"`
+		Name          string   `yaml:"name"`
+		Namespace     string   `yaml:"namespace"`
+		Format        string   `yaml:"format"`
+		Output        string   `yaml:"output"`
+		Strict        bool     `yaml:"strict"`
+		FailOn        []string `yaml:"fail-on"`
+	} `yaml:"generate"`
+
+	Validate struct {
+		Graph bool `yaml:"graph"`
+	} `yaml:"validate"`
+
+	// Provenance is the path to write the in-toto provenance statement
+	Provenance string `yaml:"provenance"`
+
+	Sign struct {
+		Enabled bool   `yaml:"enabled"`
+		Key     string `yaml:"key"`    // Keyless signing when empty
+		Bundle  string `yaml:"bundle"` // Defaults to the output path + .bundle
+	} `yaml:"sign"`
+
+	Attach struct {
+		Image string `yaml:"image"` // Image to attest with the SBOM
+	} `yaml:"attach"`
+
+	Upload struct {
+		Targets  []string `yaml:"targets"` // kind=url
+		Project  string   `yaml:"project"`
+		Version  string   `yaml:"version"`
+		TokenEnv string   `yaml:"token-env"`
+	} `yaml:"upload"`
+}
+
+type ciOptions struct {
+	configFile string
+	dryRun     bool
+}
+
+func AddCI(parent *cobra.Command) {
+	ciOpts := &ciOptions{}
+	ciCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom ci → Generate, validate, sign and publish an SBOM in one step",
+		Long: `bom ci → Generate, validate, sign and publish an SBOM in one step
+
+The ci subcommand runs the usual SBOM pipeline of a build job from a
+single YAML file:
+
+  1. Generate the SBOM of the built images, archives or directories
+  2. Validate the relationship graph of the document
+  3. Write the in-toto provenance statement
+  4. Sign the SBOM with cosign (sign-blob)
+  5. Attest the image with the SBOM and push the attestation (cosign attest)
+  6. Upload the SBOM to the configured targets
+
+Steps not present in the configuration are skipped. Signing and
+attaching run the cosign binary, which has to be in the PATH. Without a
+key, cosign signs keyless using the ambient OIDC credentials of the CI
+system.
+
+Example configuration:
+
+  generate:
+    images:
+      - registry.example.com/app@sha256:...
+    format: json
+    output: sbom.spdx.json
+    strict: true
+  validate:
+    graph: true
+  provenance: sbom.intoto.json
+  sign:
+    enabled: true
+  attach:
+    image: registry.example.com/app@sha256:...
+  upload:
+    targets:
+      - dependency-track=https://dtrack.example.com
+    token-env: DTRACK_API_KEY
+
+`,
+		Use:           "ci",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if ciOpts.configFile == "" {
+				cmd.Help() //nolint:errcheck
+				return errors.New("a pipeline configuration file is required")
+			}
+			conf, err := readCIConfiguration(ciOpts.configFile)
+			if err != nil {
+				return err
+			}
+			return runCI(cmd.Context(), ciOpts, conf)
+		},
+	}
+
+	ciCmd.PersistentFlags().StringVarP(
+		&ciOpts.configFile,
+		"config",
+		"c",
+		"",
+		"path to the YAML file configuring the pipeline",
+	)
+
+	ciCmd.PersistentFlags().BoolVar(
+		&ciOpts.dryRun,
+		"dry-run",
+		false,
+		"generate and validate the SBOM but only print the cosign commands and skip the upload",
+	)
+
+	if err := ciCmd.MarkPersistentFlagFilename("config"); err != nil {
+		logrus.Error("error marking flag as file")
+	}
+
+	parent.AddCommand(ciCmd)
+}
+
+// readCIConfiguration parses the pipeline configuration and fills
+// the defaults
+func readCIConfiguration(path string) (*ciConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading pipeline configuration: %w", err)
+	}
+	conf := &ciConfiguration{}
+	if err := yaml.UnmarshalStrict(data, conf); err != nil {
+		return nil, fmt.Errorf("unmarshalling pipeline configuration: %w", err)
+	}
+
+	if conf.Generate.Format == "" {
+		conf.Generate.Format = spdx.FormatJSON
+	}
+	if conf.Generate.Output == "" {
+		conf.Generate.Output = defaultCIOutput
+	}
+	if conf.Sign.Bundle == "" {
+		conf.Sign.Bundle = conf.Generate.Output + ".bundle"
+	}
+	if conf.Upload.TokenEnv == "" {
+		conf.Upload.TokenEnv = "BOM_UPLOAD_TOKEN"
+	}
+	return conf, nil
+}
+
+// generateOptions returns the options of the generation step. The
+// upload runs after signing, so the targets are left out.
+func (conf *ciConfiguration) generateOptions() *generateOptions {
+	return &generateOptions{
+		configFile:     conf.Generate.Config,
+		images:         conf.Generate.Images,
+		imageArchives:  conf.Generate.ImageArchives,
+		directories:    conf.Generate.Directories,
+		files:          conf.Generate.Files,
+		name:           conf.Generate.Name,
+		namespace:      conf.Generate.Namespace,
+		format:         conf.Generate.Format,
+		outputFile:     conf.Generate.Output,
+		strict:         conf.Generate.Strict,
+		failOn:         conf.Generate.FailOn,
+		provenancePath: conf.Provenance,
+		licenseListVer: license.DefaultCatalogOpts.Version,
+		scanImages:     true,
+		ignorePatterns: []string{},
+		uploadProject:  conf.Upload.Project,
+		uploadVersion:  conf.Upload.Version,
+		uploadTokenEnv: conf.Upload.TokenEnv,
+	}
+}
+
+// cosignCommands returns the argument lists of the cosign invocations
+// to sign the SBOM and attach it to the image
+func (conf *ciConfiguration) cosignCommands() [][]string {
+	cmds := [][]string{}
+	if conf.Sign.Enabled {
+		args := []string{"sign-blob", "--yes", "--bundle", conf.Sign.Bundle}
+		if conf.Sign.Key != "" {
+			args = append(args, "--key", conf.Sign.Key)
+		}
+		cmds = append(cmds, append(args, conf.Generate.Output))
+	}
+
+	if conf.Attach.Image != "" {
+		predicateType := "spdx"
+		if conf.Generate.Format == spdx.FormatJSON {
+			predicateType = "spdxjson"
+		}
+		args := []string{
+			"attest", "--yes", "--type", predicateType,
+			"--predicate", conf.Generate.Output,
+		}
+		if conf.Sign.Key != "" {
+			args = append(args, "--key", conf.Sign.Key)
+		}
+		cmds = append(cmds, append(args, conf.Attach.Image))
+	}
+	return cmds
+}
+
+func runCI(ctx context.Context, ciOpts *ciOptions, conf *ciConfiguration) error {
+	genOpts := conf.generateOptions()
+	if err := genOpts.Validate(); err != nil {
+		return fmt.Errorf("checking generate configuration: %w", err)
+	}
+	if objectstore.IsURL(genOpts.outputFile) {
+		return errors.New("the pipeline output has to be a local file to be signed")
+	}
+	for _, spec := range conf.Upload.Targets {
+		if _, err := objectstore.ParseUploadTarget(spec); err != nil {
+			return fmt.Errorf("checking upload target: %w", err)
+		}
+	}
+
+	// Look for cosign before spending time on the SBOM
+	cosignCmds := conf.cosignCommands()
+	cosign := "cosign"
+	if len(cosignCmds) > 0 && !ciOpts.dryRun {
+		path, err := exec.LookPath(cosign)
+		if err != nil {
+			return errors.New("cosign executable not found, it is required to sign and attach the SBOM")
+		}
+		cosign = path
+	}
+
+	logrus.Info("CI pipeline: generating SBOM")
+	if err := generateBOM(ctx, genOpts); err != nil {
+		return err
+	}
+
+	doc, err := spdx.OpenDoc(genOpts.outputFile)
+	if err != nil {
+		return fmt.Errorf("opening generated SBOM: %w", err)
+	}
+
+	if conf.Validate.Graph {
+		logrus.Info("CI pipeline: validating the document graph")
+		issues := doc.ValidateGraph()
+		for _, issue := range issues {
+			logrus.Error(issue.String())
+		}
+		if len(issues) > 0 {
+			return fmt.Errorf("found %d problems in the document graph", len(issues))
+		}
+	}
+
+	for _, args := range cosignCmds {
+		if ciOpts.dryRun {
+			logrus.Infof("CI pipeline: would run %s", command.New(cosign, args...).String())
+			continue
+		}
+		logrus.Infof("CI pipeline: running cosign %s", args[0])
+		if err := command.New(cosign, args...).RunSuccess(); err != nil {
+			return fmt.Errorf("running cosign %s: %w", args[0], err)
+		}
+	}
+
+	if ciOpts.dryRun || len(conf.Upload.Targets) == 0 {
+		return nil
+	}
+	logrus.Info("CI pipeline: uploading SBOM")
+	genOpts.uploads = conf.Upload.Targets
+	var renderer serialize.Serializer = &serialize.TagValue{}
+	if genOpts.format == spdx.FormatJSON {
+		renderer = &serialize.JSON{}
+	}
+	return uploadDocument(ctx, genOpts, doc, renderer)
+}
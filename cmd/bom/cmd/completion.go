@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// documentExtensions are the extensions suggested when completing the
+// path to an SPDX document.
+var documentExtensions = []string{"spdx", "json"}
+
+// completeDocument completes the first argument of the document
+// subcommands with the SPDX files in the current directory.
+func completeDocument(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return documentExtensions, cobra.ShellCompDirectiveFilterFileExt
+}
+
+// completeDocumentAndID completes the path to the document in the first
+// argument and the SPDX identifiers of its elements in the second one.
+func completeDocumentAndID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 1 {
+		return completeDocument(cmd, args, toComplete)
+	}
+	return completionElements(args[0], "", toComplete, false), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRename completes the old ID of the --rename flag of
+// bom document edit with the identifiers in the document.
+func completeRename(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 || strings.Contains(toComplete, "=") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ids := completionElements(args[0], "", toComplete, false)
+	for i := range ids {
+		id, desc, _ := strings.Cut(ids[i], "\t")
+		ids[i] = id + "=\t" + desc
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeQuery completes the documents and the values of the purl:,
+// rdeps: and conflicts: filters of bom document query, reading purls and
+// IDs from the first document in the arguments.
+func completeQuery(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// The query expression is quoted, only its last filter is completed
+	prefix := ""
+	if i := strings.LastIndex(toComplete, " "); i != -1 {
+		prefix, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+	filter, value, ok := strings.Cut(toComplete, ":")
+	if ok && filter == "conflicts" {
+		kinds := []string{}
+		for _, kind := range spdx.PurlConflictKinds {
+			if strings.HasPrefix(string(kind), value) {
+				kinds = append(kinds, prefix+filter+":"+string(kind))
+			}
+		}
+		return kinds, cobra.ShellCompDirectiveNoFileComp
+	}
+	if !ok || (filter != "purl" && filter != "rdeps") {
+		if prefix != "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return documentExtensions, cobra.ShellCompDirectiveFilterFileExt
+	}
+	for _, arg := range args {
+		if !isCompletableDocument(arg) {
+			continue
+		}
+		return completionElements(arg, prefix+filter+":", value, filter == "purl"), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// isCompletableDocument returns true if path is a local file that can be
+// read to complete the identifiers of its elements. Documents read from
+// STDIN or from URLs are never read while completing.
+func isCompletableDocument(path string) bool {
+	if path == "" || path == "-" || strings.Contains(path, "://") {
+		return false
+	}
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// completionElements reads the document in path and returns the SPDX IDs
+// (or purls when purls is set) of its elements starting with toComplete,
+// each one with its prefix and, after a tab, its description for the
+// shells that support it.
+func completionElements(path, prefix, toComplete string, purls bool) []string {
+	if !isCompletableDocument(path) {
+		return nil
+	}
+	doc, err := spdx.OpenDocWithOptions(path, &spdx.ReadOptions{FileStubs: true})
+	if err != nil {
+		cobra.CompDebugln("reading "+path+": "+err.Error(), true)
+		return nil
+	}
+
+	seen := map[string]struct{}{}
+	suggestions := []string{}
+	doc.Walk(func(o spdx.Object, _ []spdx.Object) error { //nolint:errcheck // The walk func never fails
+		value, desc := o.SPDXID(), ""
+		switch e := o.(type) {
+		case *spdx.Package:
+			desc = e.Name
+			if e.Version != "" {
+				desc += "@" + e.Version
+			}
+			if purls {
+				value, desc = "", e.Name
+				if p := e.Purl(); p != nil {
+					value = p.ToString()
+				}
+			}
+		case *spdx.File:
+			if purls {
+				return nil
+			}
+			desc = e.Name
+		}
+		if value == "" || !strings.HasPrefix(value, toComplete) {
+			return nil
+		}
+		if _, ok := seen[value]; ok {
+			return nil
+		}
+		seen[value] = struct{}{}
+		suggestions = append(suggestions, prefix+value+"\t"+desc)
+		return nil
+	})
+	sort.Strings(suggestions)
+	return suggestions
+}
@@ -17,9 +17,18 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/spdx"
 )
 
+// documentVerifyOpts are the signature checks of the documents read by
+// the document subcommands
+var documentVerifyOpts = &spdx.VerifyOptions{}
+
 func AddDocument(parent *cobra.Command) {
 	documentCmd := &cobra.Command{
 		Short:             "bom document → Work with SPDX documents",
@@ -29,7 +38,90 @@ func AddDocument(parent *cobra.Command) {
 		PersistentPreRunE: initLogging,
 	}
 
+	addVerifyFlags(documentCmd, documentVerifyOpts)
+
 	AddOutline(documentCmd)
 	AddQuery(documentCmd)
+	AddGrep(documentCmd)
+	AddRender(documentCmd)
+	AddEdit(documentCmd)
+	AddPath(documentCmd)
+	AddRedact(documentCmd)
+	AddDocumentValidate(documentCmd)
+	AddPolicy(documentCmd)
 	parent.AddCommand(documentCmd)
 }
+
+// readProgress returns a function that prints the progress of reading
+// a document to stderr every time it advances one percent.
+func readProgress() func(read, total int64) {
+	last := -1
+	return func(read, total int64) {
+		if total <= 0 {
+			return
+		}
+		pct := int(read * 100 / total)
+		if pct == last {
+			return
+		}
+		last = pct
+		fmt.Fprintf(os.Stderr, "\rReading document: %3d%% (%d of %d MiB)", pct, read>>20, total>>20)
+		if read >= total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
+
+// addVerifyFlags adds the flags to verify signed documents to cmd
+func addVerifyFlags(cmd *cobra.Command, opts *spdx.VerifyOptions) {
+	cmd.PersistentFlags().StringVar(
+		&opts.KeyPath,
+		"verify-key",
+		"",
+		"only read documents in a DSSE envelope or sigstore bundle signed with this PEM public key",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&opts.CertificateIdentity,
+		"certificate-identity",
+		"",
+		"only read documents in a sigstore bundle signed keyless by this identity (email or URI), needs --certificate-oidc-issuer",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&opts.CertificateOIDCIssuer,
+		"certificate-oidc-issuer",
+		"",
+		"OIDC issuer that must have certified the signing identity, eg https://token.actions.githubusercontent.com",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&opts.TrustedRoot,
+		"trusted-root",
+		"",
+		"sigstore trusted_root.json with the certificate authorities and transparency logs to trust (defaults to the public sigstore root, fetched with TUF)",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&opts.RekorURL,
+		"rekor-url",
+		"",
+		fmt.Sprintf(
+			"look up the transparency log entry of DSSE envelopes in this Rekor log (eg %s), needs --verify-key or --certificate-identity",
+			spdx.DefaultRekorURL,
+		),
+	)
+}
+
+// openDocument opens a document for the document subcommands. When
+// verification flags are set, only signed documents that check out
+// are read.
+func openDocument(path string, readOpts *spdx.ReadOptions) (*spdx.Document, error) {
+	if readOpts == nil {
+		readOpts = &spdx.ReadOptions{}
+	}
+	if documentVerifyOpts.Enabled() {
+		readOpts.Verify = documentVerifyOpts
+	}
+	return spdx.OpenDocWithOptions(path, readOpts)
+}
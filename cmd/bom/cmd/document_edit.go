@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/serialize"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+type editOptions struct {
+	renames    []string
+	format     string
+	outputFile string
+}
+
+func AddEdit(parent *cobra.Command) {
+	editOpts := &editOptions{}
+	editCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document edit → Modify an SPDX document",
+		Long: `bom document edit → Modify an SPDX document
+
+The edit subcommand applies changes to an SBOM and writes the resulting
+document. Use it to adapt documents before merging them, for example to
+follow organization specific conventions for element identifiers.
+
+Elements are renamed with --rename OLD_ID=NEW_ID. All the relationships,
+snippets and described elements referencing them are updated:
+
+  bom document edit sbom.spdx.json \
+     --rename SPDXRef-Package-app=SPDXRef-Package-acme-app \
+     -o sbom-renamed.spdx.json
+
+`,
+		Use:               "edit SPDX_FILE|URL",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = append(args, "")
+			}
+			if len(editOpts.renames) == 0 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("no changes to the document were specified")
+			}
+			if editOpts.format != "" && editOpts.format != spdx.FormatTagValue && editOpts.format != spdx.FormatJSON {
+				return fmt.Errorf("unknown format provided, must be one of [%s, %s]: %s",
+					spdx.FormatTagValue, spdx.FormatJSON, editOpts.format)
+			}
+
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			for _, spec := range editOpts.renames {
+				oldID, newID, err := spdx.ParseRename(spec)
+				if err != nil {
+					return err
+				}
+				if err := doc.RenameElement(oldID, newID); err != nil {
+					return fmt.Errorf("renaming %s: %w", oldID, err)
+				}
+			}
+
+			format := editOpts.format
+			if format == "" {
+				format = documentFormat(args[0])
+			}
+			var renderer serialize.Serializer = &serialize.TagValue{}
+			if format == spdx.FormatJSON {
+				renderer = &serialize.JSON{}
+			}
+
+			if editOpts.outputFile == "" {
+				return serialize.SerializeTo(renderer, doc, os.Stdout)
+			}
+			return writeDocumentFile(editOpts.outputFile, doc, renderer)
+		},
+	}
+
+	editCmd.PersistentFlags().StringArrayVar(
+		&editOpts.renames,
+		"rename",
+		[]string{},
+		"change the ID of an element, specified as OLD_ID=NEW_ID (can be repeated)",
+	)
+	editCmd.RegisterFlagCompletionFunc("rename", completeRename) //nolint:errcheck
+
+	editCmd.PersistentFlags().StringVar(
+		&editOpts.format,
+		"format",
+		"",
+		fmt.Sprintf("format of the output document (%s, %s), defaults to the format of the input", spdx.FormatTagValue, spdx.FormatJSON),
+	)
+
+	editCmd.PersistentFlags().StringVarP(
+		&editOpts.outputFile,
+		"output",
+		"o",
+		"",
+		"path to the file where the document will be written (defaults to STDOUT)",
+	)
+
+	parent.AddCommand(editCmd)
+}
+
+// documentFormat returns the format of a local document, documents that
+// cannot be read are assumed to be in tag-value. Imported scanner reports
+// in JSON are written back as SPDX JSON.
+func documentFormat(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return spdx.FormatTagValue
+	}
+	defer f.Close()
+	if encoding, err := spdx.DetectSBOMEncoding(f); err == nil && strings.HasSuffix(encoding, "+json") {
+		return spdx.FormatJSON
+	}
+	return spdx.FormatTagValue
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+type grepOptions struct {
+	spdxIDs bool
+}
+
+func AddGrep(parent *cobra.Command) {
+	grepOpts := &grepOptions{}
+	grepCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document grep → Find the packages containing a file",
+		Long: `bom document grep → Find the packages containing a file
+
+The grep subcommand looks for files in an SBOM by their path or hash
+and prints the chain of packages that contain them, from the top
+level of the document down to the file. This answers the question of
+where a file found on a system came from.
+
+Files can be specified by:
+
+  - Their checksum, optionally prefixed with the algorithm:
+      bom document grep sbom.spdx.json sha256:ba7816bf8f01cfea...
+
+  - Their path as recorded in the SBOM, or a glob pattern:
+      bom document grep sbom.spdx.json usr/lib/libssl.so.3
+      bom document grep sbom.spdx.json 'usr/lib/*.so*'
+
+Packages are found by their checksums too, which looks up container
+images and layers by their digest:
+
+  bom document grep sbom.spdx.json sha256:4ff1a5e7...
+
+`,
+		Use:               "grep SPDX_FILE|URL HASH|PATH",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("document and file hash or path are required")
+			}
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			paths := doc.ContainmentPaths(args[1])
+			if len(paths) == 0 {
+				logrus.Warning("No files in the SBOM match the specified hash or path")
+				return nil
+			}
+
+			for _, p := range paths {
+				if grepOpts.spdxIDs {
+					ids := []string{}
+					for _, o := range p {
+						ids = append(ids, o.SPDXID())
+					}
+					fmt.Println(strings.Join(ids, " → "))
+					continue
+				}
+				fmt.Println(p.String())
+			}
+			return nil
+		},
+	}
+
+	grepCmd.PersistentFlags().BoolVar(
+		&grepOpts.spdxIDs,
+		"spdx-ids",
+		false,
+		"print the SPDX identifiers of the elements instead of their names",
+	)
+
+	parent.AddCommand(grepCmd)
+}
@@ -26,6 +26,7 @@ import (
 
 func AddOutline(parent *cobra.Command) {
 	outlineOpts := &spdx.DrawingOptions{}
+	var progress bool
 	outlineCmd := &cobra.Command{
 		PersistentPreRunE: initLogging,
 		Short:             "bom document outline → Draw structure of a SPDX document",
@@ -43,14 +44,20 @@ bom will try to add useful information to the oultine but, if needed, you can
 set the --spdx-ids to only output the IDs of the entities.
 
 `,
-		Use:           "outline SPDX_FILE|URL",
-		SilenceUsage:  true,
-		SilenceErrors: true,
+		Use:               "outline SPDX_FILE|URL",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
 		RunE: func(_ *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				args = append(args, "")
 			}
-			doc, err := spdx.OpenDoc(args[0])
+			// The outline only needs the file names, load them as stubs
+			readOpts := &spdx.ReadOptions{FileStubs: true}
+			if progress {
+				readOpts.Progress = readProgress()
+			}
+			doc, err := openDocument(args[0], readOpts)
 			if err != nil {
 				return fmt.Errorf("opening doc: %w", err)
 			}
@@ -89,6 +96,12 @@ set the --spdx-ids to only output the IDs of the entities.
 		false,
 		"show package urls instead of name@version",
 	)
+	outlineCmd.PersistentFlags().BoolVar(
+		&progress,
+		"progress",
+		false,
+		"print the progress of reading the document to stderr",
+	)
 
 	parent.AddCommand(outlineCmd)
 }
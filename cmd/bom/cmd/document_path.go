@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+type pathOptions struct {
+	spdxIDs bool
+}
+
+func AddPath(parent *cobra.Command) {
+	pathOpts := &pathOptions{}
+	pathCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document path → Find the elements with an SPDX ID",
+		Long: `bom document path → Find the elements with an SPDX ID
+
+The path subcommand looks for every element in an SBOM with an SPDX ID
+and prints the chains of relationships leading to it, from the top level
+of the document down to the element.
+
+Documents should not reuse IDs, but merging documents or editing them by
+hand can leave more than one element with the same ID. In that case, the
+path subcommand lists all of them with their paths:
+
+  bom document path sbom.spdx.json SPDXRef-Package-golang.org-x-net
+
+`,
+		Use:               "path SPDX_FILE|URL SPDXID",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocumentAndID,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("document and SPDX ID are required")
+			}
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			elements := doc.GetElementsByID(args[1])
+			if len(elements) == 0 {
+				logrus.Warningf("No elements in the SBOM have the ID %s", args[1])
+				return nil
+			}
+			if len(elements) > 1 {
+				logrus.Warningf("%d elements in the SBOM have the ID %s", len(elements), args[1])
+			}
+
+			paths := doc.ElementPaths(args[1])
+			for i, el := range elements {
+				fmt.Printf("Element %d of %d: %s\n", i+1, len(elements), spdx.ElementPath{el})
+				for _, p := range paths {
+					if p.Element() != el {
+						continue
+					}
+					if pathOpts.spdxIDs {
+						fmt.Println("  " + p.IDs())
+						continue
+					}
+					fmt.Println("  " + p.String())
+				}
+			}
+			return nil
+		},
+	}
+
+	pathCmd.PersistentFlags().BoolVar(
+		&pathOpts.spdxIDs,
+		"spdx-ids",
+		false,
+		"print the SPDX identifiers of the elements instead of their names",
+	)
+
+	parent.AddCommand(pathCmd)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+type policyEvalOptions struct {
+	rego  []string
+	cue   []string
+	query string
+}
+
+func AddPolicy(parent *cobra.Command) {
+	policyCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document policy → Check SPDX documents against organizational policies",
+		Use:               "policy",
+		SilenceUsage:      false,
+		SilenceErrors:     true,
+	}
+
+	addPolicyEval(policyCmd)
+	addPolicyInput(policyCmd)
+	parent.AddCommand(policyCmd)
+}
+
+func addPolicyEval(parent *cobra.Command) {
+	evalOpts := &policyEvalOptions{}
+	evalCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document policy eval → Evaluate policies against an SBOM",
+		Long: `bom document policy eval → Evaluate policies against an SBOM
+
+The eval subcommand checks an SBOM against rules written in Rego or CUE,
+to enforce organizational requirements (mandatory suppliers, no packages
+older than a date, banned licenses) without bespoke flags.
+
+Policies receive the document in the SPDX 2.3 JSON format as their input,
+including its packages, files and relationships. Run bom document policy
+input to print it.
+
+Rego policies are evaluated with opa. Violations are the results of the
+--query, by default the deny set of the bom package:
+
+  package bom
+
+  import rego.v1
+
+  deny contains msg if {
+    some pkg in input.packages
+    not pkg.supplier
+    msg := sprintf("package %s has no supplier", [pkg.name])
+  }
+
+  bom document policy eval --rego policy.rego sbom.spdx.json
+
+CUE policies are constraints the document has to unify with, checked
+with cue vet. Each conflict is a violation:
+
+  packages: [...{supplier: string}]
+
+  bom document policy eval --cue policy.cue sbom.spdx.json
+
+The command prints the violations and exits with an error if there are
+any. bom does not embed the policy engines: the opa and cue executables
+have to be installed in the PATH to evaluate rego and CUE policies.
+
+`,
+		Use:               "eval SPDX_FILE|URL",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = append(args, "")
+			}
+			if len(evalOpts.rego) == 0 && len(evalOpts.cue) == 0 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("no policies were specified, use --rego or --cue")
+			}
+
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			violations, err := doc.EvaluatePolicies(&spdx.PolicyOptions{
+				Rego:  evalOpts.rego,
+				CUE:   evalOpts.cue,
+				Query: evalOpts.query,
+			})
+			if err != nil {
+				return err
+			}
+			for _, v := range violations {
+				fmt.Println(v)
+			}
+			if len(violations) > 0 {
+				return fmt.Errorf("found %d policy violations", len(violations))
+			}
+			return nil
+		},
+	}
+
+	evalCmd.PersistentFlags().StringSliceVar(
+		&evalOpts.rego,
+		"rego",
+		[]string{},
+		"rego policy files or directories to evaluate with opa",
+	)
+
+	evalCmd.PersistentFlags().StringSliceVar(
+		&evalOpts.cue,
+		"cue",
+		[]string{},
+		"CUE files the document has to satisfy, checked with cue vet",
+	)
+
+	evalCmd.PersistentFlags().StringVar(
+		&evalOpts.query,
+		"query",
+		spdx.DefaultPolicyQuery,
+		"rego query returning the policy violations",
+	)
+
+	parent.AddCommand(evalCmd)
+}
+
+func addPolicyInput(parent *cobra.Command) {
+	inputCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document policy input → Print the input policies receive",
+		Long: `bom document policy input → Print the input policies receive
+
+The input subcommand prints the data policies evaluate for an SBOM, to
+write and test them with opa or cue directly:
+
+  bom document policy input sbom.spdx > input.json
+  opa eval --input input.json --data policy.rego data.bom.deny
+
+`,
+		Use:               "input SPDX_FILE|URL",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = append(args, "")
+			}
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+			data, err := doc.PolicyInput()
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(os.Stdout, string(data))
+			return err
+		},
+	}
+
+	parent.AddCommand(inputCmd)
+}
@@ -19,19 +19,25 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/bom/pkg/query"
+	"sigs.k8s.io/bom/pkg/spdx"
 )
 
 type queryOptions struct {
-	purl   bool
-	format string
-	fields []string
+	purl     bool
+	progress bool
+	format   string
+	fields   []string
+	globs    []string // Patterns of documents to query
 }
 
 func AddQuery(parent *cobra.Command) {
@@ -69,6 +75,30 @@ over time. The following filters are available:
 
                 bom document query sbom.spdx.json 'purl:pkg:/oci/*'
 
+  rdeps:target  Matches all elements that depend on the target directly
+                or transitively, including the packages containing it.
+                The target can be an SPDX identifier or a purl pattern.
+                For example, to find everything that pulls in log4j:
+
+                bom document query sbom.spdx.json 'rdeps:pkg:maven/*/log4j-core'
+
+  conflicts:kind
+                Matches the packages sharing a purl with others found at
+                a different version (conflicts:version) or declared with
+                a different license (conflicts:license). Omit the kind
+                to match both. For example, to find the packages to
+                clean up after merging several documents:
+
+                bom document query merged.spdx.json 'conflicts:'
+
+Several documents can be searched at once by listing them before the
+query expression or matching them with one or more --glob patterns.
+When querying more than one document, the output includes the document
+where each element was found:
+
+    bom document query a.spdx b.spdx.json 'name:openssl'
+    bom document query --glob 'sboms/*.json' 'name:openssl'
+
 You can query files piped on STDIN by specifying the path as a dash (-) or
 omitting it completely. These are equivalent:
 
@@ -81,51 +111,36 @@ Example:
   bom document query sbom.spdx "depth:2 name:log4j"
 
 `,
-		Use:           "query sbom.spdx.json \"query expression\" ",
-		SilenceUsage:  true,
-		SilenceErrors: true,
+		Use:               "query sbom.spdx.json [sbom.spdx.json...] \"query expression\" ",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeQuery,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var path string
-			var queryString string
-			if len(args) < 2 {
-				if len(args) == 1 {
-					fi, err := os.Stdin.Stat()
-					if err != nil {
-						return fmt.Errorf("checking stdin for data: %w", err)
-					}
-					if (fi.Mode() & os.ModeCharDevice) != 0 {
-						cmd.Help() //nolint:errcheck
-						return errors.New("document path not specified")
-					}
-
-					path = "-"
-					queryString = strings.Join(args, " ")
-				}
-
-				if path == "" {
-					cmd.Help() //nolint:errcheck
-					return errors.New("no file or query specified")
-				}
-			} else {
-				path = args[0]
-				queryString = strings.Join(args[1:], " ")
+			if len(args) == 0 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("no file or query specified")
 			}
 
-			q := query.New()
-			if err := q.Open(path); err != nil {
-				return fmt.Errorf("opening document %s: %w", args[0], err)
-			}
-			fp, err := q.Query(queryString)
+			paths, queryString, err := queryTargets(args, queryOpts.globs)
 			if err != nil {
-				return fmt.Errorf("querying document: %w", err)
+				return err
 			}
 
-			if fp.Error != nil {
-				return fmt.Errorf("filter query returned an error: %w", fp.Error)
+			if len(paths) == 0 {
+				fi, err := os.Stdin.Stat()
+				if err != nil {
+					return fmt.Errorf("checking stdin for data: %w", err)
+				}
+				if (fi.Mode() & os.ModeCharDevice) != 0 {
+					cmd.Help() //nolint:errcheck
+					return errors.New("document path not specified")
+				}
+				paths = []string{"-"}
 			}
 
-			if len(fp.Objects) == 0 {
-				logrus.Warning("No objects in the SBOM match the query")
+			// When searching several documents, note where results come from
+			if len(paths) > 1 && !slices.Contains(queryOpts.fields, "document") {
+				queryOpts.fields = append([]string{"document"}, queryOpts.fields...)
 			}
 
 			var p Printer
@@ -141,7 +156,40 @@ Example:
 				return errors.New("unrecognized output format, must be text, csv or json")
 			}
 
-			return p.PrintObjectList(queryOpts, fp.Objects, os.Stdout)
+			// Files are loaded as stubs unless their license is printed
+			readOpts := &spdx.ReadOptions{FileStubs: !slices.Contains(queryOpts.fields, "license")}
+			if queryOpts.progress {
+				readOpts.Progress = readProgress()
+			}
+			if documentVerifyOpts.Enabled() {
+				readOpts.Verify = documentVerifyOpts
+			}
+
+			results := []queryResult{}
+			for _, path := range paths {
+				q := query.New()
+				if err := q.OpenWithOptions(path, readOpts); err != nil {
+					return fmt.Errorf("opening document %s: %w", path, err)
+				}
+				fp, err := q.Query(queryString)
+				if err != nil {
+					return fmt.Errorf("querying document: %w", err)
+				}
+
+				if fp.Error != nil {
+					return fmt.Errorf("filter query returned an error: %w", fp.Error)
+				}
+
+				for _, o := range fp.Objects {
+					results = append(results, queryResult{Document: path, Object: o})
+				}
+			}
+
+			if len(results) == 0 {
+				logrus.Warning("No objects in the SBOM match the query")
+			}
+
+			return p.PrintObjectList(queryOpts, results, os.Stdout)
 		},
 	}
 	queryCmd.PersistentFlags().BoolVar(
@@ -151,6 +199,13 @@ Example:
 		"output package urls instead of name@version",
 	)
 
+	queryCmd.PersistentFlags().BoolVar(
+		&queryOpts.progress,
+		"progress",
+		false,
+		"print the progress of reading the document to stderr",
+	)
+
 	queryCmd.PersistentFlags().StringVar(
 		&queryOpts.format,
 		"format",
@@ -162,7 +217,57 @@ Example:
 		&queryOpts.fields,
 		"fields",
 		[]string{"name"},
-		"fields to include in output, separated by commas: name,version,license,supplier,originator,url,",
+		"fields to include in output, separated by commas: name,version,license,supplier,originator,url,document",
+	)
+
+	queryCmd.PersistentFlags().StringArrayVar(
+		&queryOpts.globs,
+		"glob",
+		[]string{},
+		"glob pattern of documents to query, can be repeated",
 	)
 	parent.AddCommand(queryCmd)
 }
+
+// queryTargets splits the command arguments into the documents to query
+// and the query expression. Leading arguments naming STDIN (-), URLs or
+// existing files are documents and the rest form the expression. The
+// documents matching the glob patterns are queried too.
+func queryTargets(args, globs []string) (paths []string, expression string, err error) {
+	for _, pattern := range globs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, "", fmt.Errorf("expanding document glob %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			logrus.Warnf("No documents match %s", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+
+	// The last argument is always part of the expression
+	i := 0
+	for ; i < len(args)-1 && isDocumentArg(args[i]); i++ {
+		paths = append(paths, args[i])
+	}
+
+	// Without other documents, the first of two or more arguments is the
+	// document path, even if it does not exist
+	if len(paths) == 0 && len(args) > 1 {
+		paths = append(paths, args[0])
+		i = 1
+	}
+	return paths, strings.Join(args[i:], " "), nil
+}
+
+// isDocumentArg returns true if a query argument points to a document.
+func isDocumentArg(arg string) bool {
+	if arg == "-" {
+		return true
+	}
+	if u, err := url.Parse(arg); err == nil && u.Scheme != "" && u.Host != "" {
+		return true
+	}
+	info, err := os.Stat(arg)
+	return err == nil && !info.IsDir()
+}
@@ -26,19 +26,26 @@ import (
 	"sigs.k8s.io/bom/pkg/spdx"
 )
 
-// Printer is an interface that takes a list of SPDX objects and
+// queryResult is an SPDX object matched by a query and the path of the
+// document where it was found.
+type queryResult struct {
+	Document string
+	Object   spdx.Object
+}
+
+// Printer is an interface that takes a list of query results and
 // prints to a writer a representation of it.
 type Printer interface {
-	PrintObjectList(queryOptions, map[string]spdx.Object, io.Writer) error
+	PrintObjectList(queryOptions, []queryResult, io.Writer) error
 }
 
 type LinePrinter struct{}
 
-func (p *LinePrinter) PrintObjectList(opts queryOptions, objects map[string]spdx.Object, w io.Writer) error {
-	for _, o := range objects {
+func (p *LinePrinter) PrintObjectList(opts queryOptions, results []queryResult, w io.Writer) error {
+	for _, r := range results {
 		fields := []string{}
 		for _, field := range opts.fields {
-			val, err := getObjectField(opts, o, field)
+			val, err := getResultField(opts, r, field)
 			if err != nil {
 				return fmt.Errorf("getting value for field %s: %w", field, err)
 			}
@@ -54,12 +61,12 @@ func (p *LinePrinter) PrintObjectList(opts queryOptions, objects map[string]spdx
 
 type CSVPrinter struct{}
 
-func (p *CSVPrinter) PrintObjectList(opts queryOptions, objects map[string]spdx.Object, w io.Writer) error {
+func (p *CSVPrinter) PrintObjectList(opts queryOptions, results []queryResult, w io.Writer) error {
 	csvw := csv.NewWriter(w)
-	for _, o := range objects {
+	for _, r := range results {
 		fields := []string{}
 		for _, field := range opts.fields {
-			value, err := getObjectField(opts, o, field)
+			value, err := getResultField(opts, r, field)
 			if err != nil {
 				return fmt.Errorf("getting value for field %s", field)
 			}
@@ -75,8 +82,9 @@ func (p *CSVPrinter) PrintObjectList(opts queryOptions, objects map[string]spdx.
 
 type JSONPrinter struct{}
 
-func (p *JSONPrinter) PrintObjectList(opts queryOptions, objects map[string]spdx.Object, w io.Writer) error {
+func (p *JSONPrinter) PrintObjectList(opts queryOptions, results []queryResult, w io.Writer) error {
 	type resultEntry struct {
+		Document   string `json:"document,omitempty"`
 		Name       string `json:"name,omitempty"`
 		Version    string `json:"version,omitempty"`
 		License    string `json:"license,omitempty"`
@@ -86,16 +94,18 @@ func (p *JSONPrinter) PrintObjectList(opts queryOptions, objects map[string]spdx
 	}
 
 	out := []resultEntry{}
-	for _, o := range objects {
+	for _, r := range results {
 		fields := resultEntry{}
 
 		for _, field := range opts.fields {
-			fieldValue, err := getObjectField(opts, o, field)
+			fieldValue, err := getResultField(opts, r, field)
 			if err != nil {
 				return fmt.Errorf("getting value for field %s: %w", field, err)
 			}
 
 			switch field {
+			case "document":
+				fields.Document = fieldValue
 			case "name":
 				fields.Name = fieldValue
 			case "version":
@@ -141,6 +151,14 @@ func displayQueryResult(opts queryOptions, o spdx.Object) string {
 	return s
 }
 
+// getResultField returns the value of a field of a query result.
+func getResultField(opts queryOptions, r queryResult, field string) (string, error) {
+	if field == "document" {
+		return r.Document, nil
+	}
+	return getObjectField(opts, r.Object, field)
+}
+
 func getObjectField(opts queryOptions, o spdx.Object, field string) (string, error) {
 	switch field {
 	case "name":
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/serialize"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+type redactOptions struct {
+	redact      spdx.RedactOptions
+	mappingFile string
+	format      string
+	outputFile  string
+}
+
+func AddRedact(parent *cobra.Command) {
+	redactOpts := &redactOptions{}
+	redactCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document redact → Remove sensitive data from an SPDX document",
+		Long: `bom document redact → Remove sensitive data from an SPDX document
+
+The redact subcommand replaces data that should not leave the
+organization with placeholders before publishing an SBOM. The data to
+redact is selected with the command flags:
+
+  --path       Absolute build paths, eg the CI workspace (can be repeated)
+  --registry   Hostnames of internal registries (can be repeated)
+  --usernames  User names in home directory paths
+  --emails     Email addresses
+
+Every value gets the same placeholder everywhere in the document. Use
+--mapping to write the values replaced and their placeholders to a file
+that can be kept internally to trace the published document back:
+
+  bom document redact sbom.spdx.json \
+     --path /builds/ci --registry registry.corp.example.com --emails \
+     --mapping redactions.json -o sbom-public.spdx.json
+
+`,
+		Use:               "redact SPDX_FILE|URL",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = append(args, "")
+			}
+			if len(redactOpts.redact.Paths) == 0 && len(redactOpts.redact.Registries) == 0 &&
+				!redactOpts.redact.Usernames && !redactOpts.redact.Emails {
+				cmd.Help() //nolint:errcheck
+				return errors.New("no data to redact was selected")
+			}
+			if redactOpts.format != "" && redactOpts.format != spdx.FormatTagValue && redactOpts.format != spdx.FormatJSON {
+				return fmt.Errorf("unknown format provided, must be one of [%s, %s]: %s",
+					spdx.FormatTagValue, spdx.FormatJSON, redactOpts.format)
+			}
+
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			redactions, err := doc.Redact(&redactOpts.redact)
+			if err != nil {
+				return fmt.Errorf("redacting document: %w", err)
+			}
+			logrus.Infof("Redacted %d values from the document", len(redactions))
+
+			if redactOpts.mappingFile != "" {
+				data, err := json.MarshalIndent(redactions, "", "  ")
+				if err != nil {
+					return fmt.Errorf("marshaling redaction mapping: %w", err)
+				}
+				if err := os.WriteFile(redactOpts.mappingFile, append(data, '\n'), 0o600); err != nil {
+					return fmt.Errorf("writing redaction mapping: %w", err)
+				}
+			}
+
+			format := redactOpts.format
+			if format == "" {
+				format = documentFormat(args[0])
+			}
+			var renderer serialize.Serializer = &serialize.TagValue{}
+			if format == spdx.FormatJSON {
+				renderer = &serialize.JSON{}
+			}
+
+			if redactOpts.outputFile == "" {
+				return serialize.SerializeTo(renderer, doc, os.Stdout)
+			}
+			return writeDocumentFile(redactOpts.outputFile, doc, renderer)
+		},
+	}
+
+	redactCmd.PersistentFlags().StringArrayVar(
+		&redactOpts.redact.Paths,
+		"path",
+		[]string{},
+		"absolute build path to replace (can be repeated)",
+	)
+
+	redactCmd.PersistentFlags().StringArrayVar(
+		&redactOpts.redact.Registries,
+		"registry",
+		[]string{},
+		"hostname of an internal registry to replace (can be repeated)",
+	)
+
+	redactCmd.PersistentFlags().BoolVar(
+		&redactOpts.redact.Usernames,
+		"usernames",
+		false,
+		"replace the user names in home directory paths",
+	)
+
+	redactCmd.PersistentFlags().BoolVar(
+		&redactOpts.redact.Emails,
+		"emails",
+		false,
+		"replace email addresses",
+	)
+
+	redactCmd.PersistentFlags().StringVar(
+		&redactOpts.mappingFile,
+		"mapping",
+		"",
+		"path to write the values replaced and their placeholders as JSON",
+	)
+
+	redactCmd.PersistentFlags().StringVar(
+		&redactOpts.format,
+		"format",
+		"",
+		fmt.Sprintf("format of the output document (%s, %s), defaults to the format of the input", spdx.FormatTagValue, spdx.FormatJSON),
+	)
+
+	redactCmd.PersistentFlags().StringVarP(
+		&redactOpts.outputFile,
+		"output",
+		"o",
+		"",
+		"path to the file where the document will be written (defaults to STDOUT)",
+	)
+
+	parent.AddCommand(redactCmd)
+}
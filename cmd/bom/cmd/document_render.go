@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+type renderOptions struct {
+	template   string
+	outputFile string
+}
+
+func AddRender(parent *cobra.Command) {
+	renderOpts := &renderOptions{}
+	renderCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document render → Render an SPDX document with a template",
+		Long: `bom document render → Render an SPDX document with a template
+
+The render subcommand executes a Go template over the document to
+produce custom artifacts such as NOTICE files or dependency lists,
+without writing any Go code.
+
+The document is the data passed to the template, its fields are
+available as {{ .Name }}, {{ .Namespace }}, {{ .Packages }}, etc.
+The following functions can be used in the template:
+
+  packages          all the packages in the document, sorted by name
+  files             all the files in the document, sorted by name
+  purl PKG          the package URL of a package
+  license ELEMENT   the concluded license or, if missing, the declared one
+  byLicense         the packages in the document indexed by license
+  extractedLicense  the text of a LicenseRef-* license in the document
+  join, lower, upper, trim, replace, hasPrefix, hasSuffix
+
+Example NOTICE template:
+
+  Third party software included in {{ .Name }}:
+  {{ range packages }}
+  {{ .Name }} {{ .Version }} ({{ license . }})
+  {{- range .AttributionTexts }}
+    {{ . }}
+  {{- end }}
+  {{- end }}
+
+`,
+		Use:               "render SPDX_FILE|URL --template TEMPLATE",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = append(args, "")
+			}
+			if renderOpts.template == "" {
+				cmd.Help() //nolint:errcheck
+				return errors.New("a template file is required")
+			}
+
+			text, err := os.ReadFile(renderOpts.template)
+			if err != nil {
+				return fmt.Errorf("reading template: %w", err)
+			}
+
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			name := filepath.Base(renderOpts.template)
+			if renderOpts.outputFile == "" {
+				return doc.RenderTemplate(os.Stdout, name, string(text))
+			}
+
+			f, err := os.Create(renderOpts.outputFile)
+			if err != nil {
+				return fmt.Errorf("creating output file: %w", err)
+			}
+			if err := doc.RenderTemplate(f, name, string(text)); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("closing output file: %w", err)
+			}
+			return nil
+		},
+	}
+
+	renderCmd.PersistentFlags().StringVarP(
+		&renderOpts.template,
+		"template",
+		"t",
+		"",
+		"path to the Go template to render",
+	)
+
+	renderCmd.PersistentFlags().StringVarP(
+		&renderOpts.outputFile,
+		"output",
+		"o",
+		"",
+		"path to the file where the output will be written (defaults to STDOUT)",
+	)
+
+	parent.AddCommand(renderCmd)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/release-utils/util"
+
+	"sigs.k8s.io/bom/pkg/serialize"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+type documentValidateOptions struct {
+	graph      bool
+	conflicts  bool
+	prune      bool
+	format     string
+	outputFile string
+	sarifFile  string // Path to write the problems found as SARIF
+}
+
+func AddDocumentValidate(parent *cobra.Command) {
+	valOpts := &documentValidateOptions{}
+	validateCmd := &cobra.Command{
+		PersistentPreRunE: initLogging,
+		Short:             "bom document validate → Check the integrity of an SPDX document",
+		Long: `bom document validate → Check the integrity of an SPDX document
+
+The validate subcommand checks an SBOM for structural problems. With
+--graph, it verifies the relationship graph of the document and reports:
+
+  - Relationships pointing to SPDX IDs not defined in the document
+  - Cycles formed by CONTAINS relationships
+  - Elements not reachable from the elements the document describes
+
+The command exits with an error when problems are found. Use --prune to
+remove the dangling relationships, the relationships closing the cycles
+and the orphaned elements, and write the fixed document:
+
+  bom document validate --graph --prune sbom.spdx.json -o fixed.spdx.json
+
+With --sarif, the problems are also written as a SARIF log to upload to
+GitHub code scanning or other SARIF consumers. The results point to the
+lines of the SBOM defining the affected elements:
+
+  bom document validate --graph --sarif bom.sarif sbom.spdx.json
+
+With --conflicts, the packages sharing a purl are checked for
+inconsistencies, common after merging documents:
+
+  - The same package found at different versions
+  - The same package version declared with different licenses
+
+Conflicts are reported but never pruned, the packages involved can be
+listed with the conflicts: filter of bom document query.
+
+`,
+		Use:               "validate SPDX_FILE|URL",
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		ValidArgsFunction: completeDocument,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = append(args, "")
+			}
+			if !valOpts.graph && !valOpts.conflicts {
+				cmd.Help() //nolint:errcheck
+				return errors.New("no checks were selected")
+			}
+			if valOpts.prune && !valOpts.graph {
+				return errors.New("--prune can only be used with --graph")
+			}
+			if valOpts.format != "" && valOpts.format != spdx.FormatTagValue && valOpts.format != spdx.FormatJSON {
+				return fmt.Errorf("unknown format provided, must be one of [%s, %s]: %s",
+					spdx.FormatTagValue, spdx.FormatJSON, valOpts.format)
+			}
+
+			if valOpts.sarifFile == "-" && (!valOpts.prune || valOpts.outputFile == "") {
+				return errors.New("--sarif can only write to STDOUT when the pruned document is written to a file")
+			}
+
+			doc, err := openDocument(args[0], nil)
+			if err != nil {
+				return fmt.Errorf("opening doc: %w", err)
+			}
+
+			conflicts := []spdx.PurlConflict{}
+			if valOpts.conflicts {
+				conflicts = doc.PurlConflicts()
+			}
+
+			if !valOpts.prune {
+				issues := []spdx.GraphIssue{}
+				if valOpts.graph {
+					issues = doc.ValidateGraph()
+				}
+				for _, issue := range issues {
+					fmt.Println(issue.String())
+				}
+				for _, c := range conflicts {
+					fmt.Println(c.String())
+				}
+				if err := writeGraphSARIF(valOpts.sarifFile, args[0], issues); err != nil {
+					return err
+				}
+				if len(issues)+len(conflicts) > 0 {
+					return fmt.Errorf("found %d problems in the document", len(issues)+len(conflicts))
+				}
+				return nil
+			}
+
+			// The pruned document goes to STDOUT, conflicts are logged
+			for _, c := range conflicts {
+				logrus.Warn(c.String())
+			}
+
+			issues := doc.PruneGraph()
+			for _, issue := range issues {
+				logrus.Infof("Pruned %s", issue)
+			}
+			if err := writeGraphSARIF(valOpts.sarifFile, args[0], issues); err != nil {
+				return err
+			}
+
+			format := valOpts.format
+			if format == "" {
+				format = documentFormat(args[0])
+			}
+			var renderer serialize.Serializer = &serialize.TagValue{}
+			if format == spdx.FormatJSON {
+				renderer = &serialize.JSON{}
+			}
+
+			if valOpts.outputFile == "" {
+				return serialize.SerializeTo(renderer, doc, os.Stdout)
+			}
+			return writeDocumentFile(valOpts.outputFile, doc, renderer)
+		},
+	}
+
+	validateCmd.PersistentFlags().BoolVar(
+		&valOpts.graph,
+		"graph",
+		false,
+		"check for dangling relationships, CONTAINS cycles and orphaned elements",
+	)
+
+	validateCmd.PersistentFlags().BoolVar(
+		&valOpts.conflicts,
+		"conflicts",
+		false,
+		"check for packages with the same purl at different versions or with different licenses",
+	)
+
+	validateCmd.PersistentFlags().BoolVar(
+		&valOpts.prune,
+		"prune",
+		false,
+		"remove the problems found in the graph and write the resulting document",
+	)
+
+	validateCmd.PersistentFlags().StringVar(
+		&valOpts.format,
+		"format",
+		"",
+		fmt.Sprintf("format of the pruned document (%s, %s), defaults to the format of the input", spdx.FormatTagValue, spdx.FormatJSON),
+	)
+
+	validateCmd.PersistentFlags().StringVarP(
+		&valOpts.outputFile,
+		"output",
+		"o",
+		"",
+		"path to the file where the pruned document will be written (defaults to STDOUT)",
+	)
+
+	validateCmd.PersistentFlags().StringVar(
+		&valOpts.sarifFile,
+		"sarif",
+		"",
+		"write the problems found to this file as SARIF (- writes to STDOUT)",
+	)
+
+	parent.AddCommand(validateCmd)
+}
+
+// writeGraphSARIF writes the graph issues of the document as SARIF
+func writeGraphSARIF(path, docPath string, issues []spdx.GraphIssue) error {
+	if path == "" {
+		return nil
+	}
+	report := newSARIFReport(docPath)
+	report.AddGraphIssues(issues)
+	return writeSARIF(path, report)
+}
+
+// newSARIFReport returns a SARIF report about the document. Local
+// documents are read to point the results to their lines.
+func newSARIFReport(docPath string) *spdx.SARIFReport {
+	var content []byte
+	if util.Exists(docPath) {
+		data, err := os.ReadFile(docPath)
+		if err != nil {
+			logrus.Warnf("Unable to read %s to locate SARIF results: %v", docPath, err)
+		}
+		content = data
+	}
+	return spdx.NewSARIFReport(filepath.ToSlash(docPath), content)
+}
+
+// writeSARIF writes the report to path, or STDOUT when path is -
+func writeSARIF(path string, report *spdx.SARIFReport) error {
+	if path == "-" {
+		return report.Log().Write(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating SARIF file: %w", err)
+	}
+	if err := report.Log().Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing SARIF file: %w", err)
+	}
+	logrus.Infof("Wrote %d SARIF results to %s", len(report.Log().Results()), path)
+	return nil
+}
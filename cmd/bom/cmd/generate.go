@@ -17,10 +17,18 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -29,30 +37,90 @@ import (
 	"sigs.k8s.io/release-utils/version"
 
 	"sigs.k8s.io/bom/pkg/license"
+	"sigs.k8s.io/bom/pkg/objectstore"
 	"sigs.k8s.io/bom/pkg/serialize"
 	"sigs.k8s.io/bom/pkg/spdx"
 )
 
+// splitByPackage writes a document per top level package
+const splitByPackage = "package"
+
 type generateOptions struct {
-	analyze        bool
-	noGitignore    bool
-	noGoModules    bool
-	noGoTransient  bool
-	scanImages     bool
-	name           string // Name to use in the document
-	namespace      string
-	format         string
-	outputFile     string
-	configFile     string
-	license        string
-	licenseListVer string
-	provenancePath string // Path to export the SBOM as provenance statement
-	images         []string
-	imageArchives  []string
-	archives       []string
-	files          []string
-	directories    []string
-	ignorePatterns []string
+	analyze          bool
+	noGitignore      bool
+	noGoModules      bool
+	noGoTransient    bool
+	excludeDevDeps   bool
+	goStdlib         bool
+	dedupe           bool
+	pathPrefixStrip  []string // Prefixes removed from file names
+	pathPrefixAdd    string   // Prefix added to file names
+	cpe              bool
+	persistentIDs    bool
+	strict           bool // Fail on any degradation of the document
+	sizeReport       bool
+	scanImages       bool
+	requireDigest    bool   // Refuse images not pinned by digest
+	discoverSigs     bool   // Record the cosign signatures of images
+	detectSecrets    bool   // Annotate files with keys, certificates and credentials
+	modelMetadata    bool   // Record the metadata of machine learning model files
+	checkRegistries  bool   // Look up yanked and deprecated packages in their registries
+	depsDev          bool   // Enrich packages with data from deps.dev
+	annotateEOL      bool   // Annotate images based on OS releases past their end of life
+	imageEnvValues   bool   // Record the values of the image environment variables
+	noBuildMetadata  bool   // Do not record the generation context in the document
+	name             string // Name to use in the document
+	namespace        string
+	format           string
+	canonical        bool // Write JSON documents in canonical form
+	outputFile       string
+	splitBy          string // Write a document per top level package (package)
+	configFile       string
+	license          string
+	licenseListVer   string
+	goOS             string
+	goArch           string
+	purpose          string // Primary purpose of the top level packages
+	provenancePath   string // Path to export the SBOM as provenance statement
+	omniborDir       string // Directory to write the OmniBOR graph
+	reportPath       string // Path to write the generation report
+	baseline         string // Previous document to reuse unchanged elements from
+	creatorPerson    string
+	creatorOrg       string
+	creatorComment   string
+	creatorTools     []string
+	images           []string
+	imageArchives    []string
+	archives         []string
+	rootfs           []string
+	files            []string
+	directories      []string
+	ignorePatterns   []string
+	addPackages      []string // Manual package definitions
+	purlLists        []string // Files listing the purls of packages to add
+	purlRegistryData bool     // Complete the packages from purl lists with their registry data
+	purlLicenses     bool     // Download the packages from purl lists to scan their licenses
+	clearlyDefined   bool     // Look up licenses in ClearlyDefined before scanning sources
+	localSources     bool     // Read package sources from the local go, cargo and pip caches
+	cargoVendorDirs  []string // Directories written by cargo vendor
+	licenseScanCache string   // Directory caching license scan results by purl
+	onlyLangs        []string // Language ecosystems to analyze
+	goBuildTags      []string
+	analyzerPlugins  []string // Go plugins with custom image analyzers
+	prune            []string // Kinds of elements to remove from the document
+	describes        []string // Top level elements the document describes
+	failOn           []string // Kinds of degradations that make the command fail
+	maxManifestDepth int      // Levels to search for nested projects
+	timeout          time.Duration
+	uploads          []string // Targets to upload the document to (kind=url)
+	uploadProject    string   // Dependency-Track project name
+	uploadVersion    string   // Dependency-Track project version
+	uploadTokenEnv   string   // Environment variable holding the upload token
+	githubRelease    string   // Release to attach the documents to (owner/repo@tag)
+	createRelease    bool     // Create the GitHub release if it does not exist
+
+	// Where the document is written without an output file, defaults to os.Stdout
+	stdout io.Writer
 }
 
 // Validate verify options consistency.
@@ -62,16 +130,69 @@ func (opts *generateOptions) Validate() error {
 		len(opts.files) == 0 &&
 		len(opts.imageArchives) == 0 &&
 		len(opts.archives) == 0 &&
-		len(opts.archives) == 0 &&
-		len(opts.directories) == 0 {
+		len(opts.rootfs) == 0 &&
+		len(opts.directories) == 0 &&
+		len(opts.addPackages) == 0 &&
+		len(opts.purlLists) == 0 {
 		return errors.New("to generate a SPDX BOM you have to provide at least one image or file")
 	}
 
+	if _, err := spdx.ParseLanguages(opts.onlyLangs); err != nil {
+		return fmt.Errorf("checking --only-lang: %w", err)
+	}
+
+	for _, spec := range opts.addPackages {
+		if _, err := spdx.ParseManualPackage(spec); err != nil {
+			return fmt.Errorf("checking --add-package: %w", err)
+		}
+	}
+
+	if _, err := spdx.ParseDegradationKinds(opts.failOn); err != nil {
+		return fmt.Errorf("checking --fail-on: %w", err)
+	}
+
+	if opts.timeout < 0 {
+		return errors.New("--timeout cannot be negative")
+	}
+
+	for _, spec := range opts.uploads {
+		if _, err := objectstore.ParseUploadTarget(spec); err != nil {
+			return fmt.Errorf("checking --upload: %w", err)
+		}
+	}
+
+	if opts.githubRelease != "" {
+		if _, err := objectstore.ParseGitHubRelease(opts.githubRelease); err != nil {
+			return fmt.Errorf("checking --publish-github-release: %w", err)
+		}
+	} else if opts.createRelease {
+		return errors.New("--create-github-release requires --publish-github-release")
+	}
+
+	if objectstore.IsURL(opts.outputFile) {
+		if _, err := objectstore.ParseURL(opts.outputFile); err != nil {
+			return fmt.Errorf("checking --output: %w", err)
+		}
+	}
+
+	if opts.splitBy != "" {
+		if opts.splitBy != splitByPackage {
+			return fmt.Errorf("unknown --split-by value %q, must be %s", opts.splitBy, splitByPackage)
+		}
+		if opts.outputFile == "" || objectstore.IsURL(opts.outputFile) {
+			return errors.New("--split-by requires --output to be a local file")
+		}
+	}
+
 	if opts.format != spdx.FormatTagValue && opts.format != spdx.FormatJSON {
 		return fmt.Errorf("unknown format provided, must be one of [%s, %s]: %s",
 			spdx.FormatTagValue, spdx.FormatJSON, opts.format)
 	}
 
+	if opts.canonical && opts.format != spdx.FormatJSON {
+		return fmt.Errorf("--canonical requires the %s format", spdx.FormatJSON)
+	}
+
 	// Check if specified local files exist
 	for _, col := range []struct {
 		Items []string
@@ -81,10 +202,12 @@ func (opts *generateOptions) Validate() error {
 		{opts.files, "file"},
 		{opts.directories, "directory"},
 		{opts.archives, "archive"},
+		{opts.rootfs, "root filesystem"},
+		{opts.analyzerPlugins, "analyzer plugin"},
 	} {
 		// Check if image archives exist
 		for i, iPath := range col.Items {
-			if !isGlob(iPath) && !util.Exists(iPath) {
+			if !spdx.IsPathPattern(iPath) && !util.Exists(iPath) {
 				return fmt.Errorf("%s #%d not found (%s)", col.Name, i+1, iPath)
 			}
 		}
@@ -92,10 +215,6 @@ func (opts *generateOptions) Validate() error {
 	return nil
 }
 
-func isGlob(pathPattern string) bool {
-	return strings.ContainsAny(pathPattern, "*?")
-}
-
 func AddGenerate(parent *cobra.Command) {
 	genOpts := &generateOptions{}
 
@@ -141,12 +260,21 @@ completed by a later stage in your CI/CD pipeline. See the
 				}
 			}
 
+			// "-" writes the document to stdout, the format follows the
+			// extension of the output file unless set explicitly
+			if genOpts.outputFile == "-" {
+				genOpts.outputFile = ""
+			}
+			if !cmd.Flags().Changed("format") {
+				genOpts.format = outputFormat(genOpts.outputFile)
+			}
+
 			if err := genOpts.Validate(); err != nil {
 				cmd.Help() //nolint:errcheck // We already errored
 				return fmt.Errorf("validating command line options: %w", err)
 			}
 
-			return generateBOM(genOpts)
+			return generateBOM(cmd.Context(), genOpts)
 		},
 	}
 
@@ -163,7 +291,7 @@ completed by a later stage in your CI/CD pipeline. See the
 		"file",
 		"f",
 		[]string{},
-		"list of files to include",
+		"list of files to include, patterns like dist/**/*.txt are expanded",
 	)
 
 	generateCmd.PersistentFlags().StringSliceVarP(
@@ -184,14 +312,77 @@ completed by a later stage in your CI/CD pipeline. See the
 		&genOpts.imageArchives,
 		"image-archive",
 		[]string{},
-		"list of docker archive tarballs to include in the manifest",
+		"list of docker archive tarballs to include in the manifest, patterns like dist/*.tar are expanded",
 	)
 
 	generateCmd.PersistentFlags().StringSliceVar(
 		&genOpts.archives,
 		"archive",
 		[]string{},
-		"list of archives to add as packages (supports tar, tar.gz)",
+		"list of archives to add as packages (supports tar, tar.gz, zip, jar, war, ear), patterns like dist/*.tar.gz are expanded",
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.rootfs,
+		"rootfs",
+		[]string{},
+		"list of directories to scan as system root filesystems (reads the dpkg, apk and rpm databases)",
+	)
+
+	generateCmd.PersistentFlags().StringArrayVar(
+		&genOpts.addPackages,
+		"add-package",
+		[]string{},
+		"add a package bom cannot detect, in the form name@version,license=...,purl=...,supplier=... with fields containing commas in double quotes (can be repeated)",
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.purlLists,
+		"from-purl-list",
+		[]string{},
+		"file listing the package URLs of packages to add to the SBOM, one per line",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.purlRegistryData,
+		"purl-registry-data",
+		false,
+		"look up the license, description and download of the packages from purl lists in their registries (PyPI, npm, crates.io)",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.purlLicenses,
+		"purl-scan-licenses",
+		false,
+		"download the packages from purl lists to conclude their licenses from their files",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.clearlyDefined,
+		"clearly-defined",
+		false,
+		"look up the licenses of go modules and purl list packages in ClearlyDefined and only download the sources of the unknown ones",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.localSources,
+		"local-sources",
+		false,
+		"scan the licenses of go modules, crates and python packages from the caches set in GOMODCACHE (or GOPATH), CARGO_HOME and PIP_CACHE_DIR instead of downloading them",
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.cargoVendorDirs,
+		"cargo-vendor-dir",
+		[]string{},
+		"directory written by cargo vendor to scan crate licenses from instead of downloading them (can be repeated)",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.licenseScanCache,
+		"license-scan-cache",
+		"",
+		"directory to cache the license scan results of go modules and purl list packages, versions found in it are not downloaded again",
 	)
 
 	generateCmd.PersistentFlags().StringSliceVarP(
@@ -199,7 +390,7 @@ completed by a later stage in your CI/CD pipeline. See the
 		"dirs",
 		"d",
 		[]string{},
-		"list of directories to include in the manifest as packages",
+		"list of directories to include in the manifest as packages, patterns like services/* are expanded",
 	)
 
 	generateCmd.PersistentFlags().StringSliceVar(
@@ -238,6 +429,195 @@ completed by a later stage in your CI/CD pipeline. See the
 		"don't include transient go dependencies, only direct deps from go.mod",
 	)
 
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.onlyLangs,
+		"only-lang",
+		[]string{},
+		fmt.Sprintf("only analyze dependencies of these language ecosystems (supports %v)", spdx.SupportedLanguages),
+	)
+
+	generateCmd.PersistentFlags().IntVar(
+		&genOpts.maxManifestDepth,
+		"max-manifest-depth",
+		0,
+		"levels to search for nested projects in directories, 0 only scans the top level, -1 is unlimited. The dependencies of nested go modules are resolved, node, python and maven projects are added as packages identified from their package.json, pyproject.toml or pom.xml",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.excludeDevDeps,
+		"exclude-dev-deps",
+		false,
+		"don't include development dependencies: go modules only required by tests or tool directives, npm devDependencies, python dev and test extras or groups, maven test scoped and cargo dev or build dependencies",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.goOS,
+		"goos",
+		"",
+		"GOOS of the target binary used to resolve go dependencies (defaults to the host)",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.goArch,
+		"goarch",
+		"",
+		"GOARCH of the target binary used to resolve go dependencies (defaults to the host)",
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.goBuildTags,
+		"go-tags",
+		[]string{},
+		"list of go build tags used to resolve go dependencies",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.goStdlib,
+		"go-stdlib",
+		false,
+		"add the go standard library as a dependency of go modules and of the go binaries found in images",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.dedupe,
+		"dedupe",
+		false,
+		"render identical packages found in more than one image or artifact only once",
+	)
+
+	generateCmd.PersistentFlags().StringArrayVar(
+		&genOpts.pathPrefixStrip,
+		"path-prefix-strip",
+		[]string{},
+		"prefix to remove from the names of the files in the document, eg the build directory (can be repeated)",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.pathPrefixAdd,
+		"path-prefix-add",
+		"",
+		"prefix to add to the names of the files in the document after stripping, eg ./",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.cpe,
+		"cpe",
+		false,
+		"add best-effort CPE 2.3 identifiers derived from the package purls",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.persistentIDs,
+		"persistent-ids",
+		false,
+		"add Software Heritage IDs and gitoids to the scanned directories, files and archives",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.omniborDir,
+		"omnibor",
+		"",
+		"write the OmniBOR artifact dependency graph of scanned directories and archives to this directory",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.reportPath,
+		"report",
+		"",
+		"write a JSON report of the scanned inputs, skipped data, step timings and license confidence to this path",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.strict,
+		"strict",
+		false,
+		"exit with an error if the document is incomplete for any reason (same as --fail-on with all kinds)",
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.failOn,
+		"fail-on",
+		[]string{},
+		fmt.Sprintf("exit with an error after writing the document if it has these kinds of degradations (%s)", degradationKindList()),
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.prune,
+		"prune",
+		[]string{},
+		fmt.Sprintf("kinds of elements to remove from the document to reduce its size (%s)", strings.Join(spdx.PruneTargets, ", ")),
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.purpose,
+		"purpose",
+		"",
+		fmt.Sprintf("primary purpose to set in the top level packages, overrides the inferred one (%s)", strings.Join(spdx.PackagePurposes, ", ")),
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.describes,
+		"describe",
+		[]string{},
+		"SPDX IDs or names of the top level elements the document describes (defaults to all of them)",
+	)
+
+	generateCmd.PersistentFlags().DurationVar(
+		&genOpts.timeout,
+		"timeout",
+		0,
+		"abort the generation if it takes longer than this (eg 10m), image pulls and package downloads are cancelled. 0 means no limit",
+	)
+
+	generateCmd.PersistentFlags().StringArrayVar(
+		&genOpts.uploads,
+		"upload",
+		[]string{},
+		"upload the document to a service, as kind=url. Supported kinds: dependency-track (converted to CycloneDX), guac (can be repeated)",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.uploadProject,
+		"upload-project",
+		"",
+		"Dependency-Track project to upload the document to, defaults to the document name",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.uploadVersion,
+		"upload-project-version",
+		"",
+		"version of the Dependency-Track project to upload the document to",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.uploadTokenEnv,
+		"upload-token-env",
+		"BOM_UPLOAD_TOKEN",
+		"environment variable holding the Dependency-Track API key or GUAC bearer token",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.sizeReport,
+		"size-report",
+		false,
+		"print a report of the number of elements in the document and its biggest subtrees to stderr",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.githubRelease,
+		"publish-github-release",
+		"",
+		"attach the documents and provenance statement to a GitHub release, as owner/repo@tag (token read from GITHUB_TOKEN)",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.createRelease,
+		"create-github-release",
+		false,
+		"create the release set in --publish-github-release if it does not exist",
+	)
+
 	generateCmd.PersistentFlags().StringVarP(
 		&genOpts.namespace,
 		"namespace",
@@ -254,12 +634,26 @@ completed by a later stage in your CI/CD pipeline. See the
 			spdx.FormatTagValue, spdx.FormatJSON),
 	)
 
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.canonical,
+		"canonical",
+		false,
+		"write the JSON document in canonical form (sorted keys and arrays, no whitespace) so its digest can be compared",
+	)
+
 	generateCmd.PersistentFlags().StringVarP(
 		&genOpts.outputFile,
 		"output",
 		"o",
 		"",
-		"path to the file where the document will be written (defaults to STDOUT)",
+		"path to the file where the document will be written, s3://bucket/key and gs://bucket/key URLs are written to object storage (defaults to STDOUT, also written with -). Files ending in .json are written in json unless --format is set. S3 credentials are read from the AWS environment variables, static keys in the shared config files, a web identity token, or the container and instance roles; Cloud Storage tokens from GOOGLE_OAUTH_ACCESS_TOKEN, user or service account key files (GOOGLE_APPLICATION_CREDENTIALS or gcloud application-default login) or the metadata server",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.splitBy,
+		"split-by",
+		"",
+		fmt.Sprintf("write a document per top level package (%s) next to --output, which becomes an index referencing them", splitByPackage),
 	)
 
 	generateCmd.PersistentFlags().BoolVarP(
@@ -270,6 +664,13 @@ completed by a later stage in your CI/CD pipeline. See the
 		"go deeper into images using the available analyzers",
 	)
 
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.analyzerPlugins,
+		"analyzer-plugin",
+		[]string{},
+		"go plugins registering custom image analyzers to load (requires a bom binary built with cgo)",
+	)
+
 	generateCmd.PersistentFlags().StringVarP(
 		&genOpts.configFile,
 		"config",
@@ -278,6 +679,13 @@ completed by a later stage in your CI/CD pipeline. See the
 		"path to yaml SBOM configuration file",
 	)
 
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.baseline,
+		"baseline",
+		"",
+		"previous SBOM to reuse unchanged images (by digest) and file license scans (by checksum) from",
+	)
+
 	generateCmd.PersistentFlags().StringVar(
 		&genOpts.provenancePath,
 		"provenance",
@@ -285,6 +693,62 @@ completed by a later stage in your CI/CD pipeline. See the
 		"path to export the SBOM as an in-toto provenance statement",
 	)
 
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.requireDigest,
+		"require-digest",
+		false,
+		"refuse to analyze images not pinned by digest (rules can be added in the image-policy section of the config file)",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.discoverSigs,
+		"discover-signatures",
+		false,
+		"look up the cosign signatures and attestations of images and record them as annotations",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.detectSecrets,
+		"detect-secrets",
+		false,
+		"annotate the files added to the document that contain private keys, certificates (with their expiry) or credentials",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.modelMetadata,
+		"model-metadata",
+		false,
+		"record the metadata embedded in the machine learning models (safetensors, GGUF, ONNX) found in directories",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.checkRegistries,
+		"check-registries",
+		false,
+		"look up the PyPI, npm and crates.io packages in their registries and flag the yanked or deprecated versions",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.depsDev,
+		"deps-dev",
+		false,
+		"look up the Go, npm, PyPI, Maven and Cargo packages in deps.dev to corroborate their licenses and record their home pages and OpenSSF Scorecards",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.annotateEOL,
+		"annotate-known-eol",
+		false,
+		"warn and annotate the document when an image is based on a Debian, Ubuntu, Alpine or CentOS release past its end of life",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.imageEnvValues,
+		"image-env-values",
+		false,
+		"record the values of the environment variables in the image config, by default only their names are recorded as they may hold secrets",
+	)
+
 	generateCmd.PersistentFlags().BoolVar(
 		&genOpts.scanImages,
 		"scan-images",
@@ -299,6 +763,41 @@ completed by a later stage in your CI/CD pipeline. See the
 		"name for the document, in contrast to URLs, intended for humans",
 	)
 
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.creatorPerson,
+		"creator-person",
+		"",
+		"person that created the SBOM, eg 'Jane Doe (jane@example.com)'",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.creatorOrg,
+		"creator-organization",
+		"",
+		"organization that created the SBOM",
+	)
+
+	generateCmd.PersistentFlags().StringSliceVar(
+		&genOpts.creatorTools,
+		"creator-tool",
+		[]string{},
+		"list of tools used to create the SBOM, recorded after the bom tool entry",
+	)
+
+	generateCmd.PersistentFlags().StringVar(
+		&genOpts.creatorComment,
+		"creator-comment",
+		"",
+		"free form comment to add to the SBOM creation information",
+	)
+
+	generateCmd.PersistentFlags().BoolVar(
+		&genOpts.noBuildMetadata,
+		"no-build-metadata",
+		false,
+		"do not record the bom version, command line, host and CI job in the creator comment (for reproducible documents)",
+	)
+
 	generateCmd.PersistentFlags().StringVar(
 		&genOpts.licenseListVer,
 		"license-list-version",
@@ -318,59 +817,145 @@ completed by a later stage in your CI/CD pipeline. See the
 	parent.AddCommand(generateCmd)
 }
 
-func generateBOM(opts *generateOptions) error {
+func generateBOM(ctx context.Context, opts *generateOptions) error {
+	// Only the document reaches stdout, logs go to stderr (see initLogging)
+	stdout := opts.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	// Interrupting bom or reaching the timeout cancels the network
+	// operations and removes their temporary files
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
 	logrus.Infof(
 		"bom %s: Generating SPDX Bill of Materials",
 		version.GetVersionInfo().GitVersion,
 	)
 
+	for _, p := range opts.analyzerPlugins {
+		if err := loadAnalyzerPlugin(p); err != nil {
+			return err
+		}
+	}
+
 	newDocBuilderOpts := []spdx.NewDocBuilderOption{spdx.WithFormat(spdx.Format(opts.format))}
 	builder := spdx.NewDocBuilder(newDocBuilderOpts...)
 	builderOpts := &spdx.DocGenerateOptions{
-		Tarballs:           opts.imageArchives,
-		Archives:           opts.archives,
-		Files:              opts.files,
-		Images:             opts.images,
-		Directories:        opts.directories,
-		Format:             opts.format,
-		OutputFile:         opts.outputFile,
-		Namespace:          opts.namespace,
-		AnalyseLayers:      opts.analyze,
-		ProcessGoModules:   !opts.noGoModules,
-		OnlyDirectDeps:     !opts.noGoTransient,
-		ConfigFile:         opts.configFile,
-		License:            opts.license,
-		LicenseListVersion: opts.licenseListVer,
-		ScanImages:         opts.scanImages,
-		Name:               opts.name,
+		Tarballs:            opts.imageArchives,
+		Archives:            opts.archives,
+		Rootfs:              opts.rootfs,
+		Files:               opts.files,
+		Images:              opts.images,
+		Directories:         opts.directories,
+		Format:              opts.format,
+		OutputFile:          opts.outputFile,
+		Namespace:           opts.namespace,
+		AnalyseLayers:       opts.analyze,
+		ProcessGoModules:    !opts.noGoModules,
+		OnlyDirectDeps:      !opts.noGoTransient,
+		ConfigFile:          opts.configFile,
+		License:             opts.license,
+		LicenseListVersion:  opts.licenseListVer,
+		ScanImages:          opts.scanImages,
+		DiscoverSignatures:  opts.discoverSigs,
+		DetectSecrets:       opts.detectSecrets,
+		ModelMetadata:       opts.modelMetadata,
+		CheckRegistries:     opts.checkRegistries,
+		DepsDev:             opts.depsDev,
+		AnnotateKnownEOL:    opts.annotateEOL,
+		ImageEnvValues:      opts.imageEnvValues,
+		Name:                opts.name,
+		CreatorPerson:       opts.creatorPerson,
+		CreatorOrganization: opts.creatorOrg,
+		CreatorTools:        opts.creatorTools,
+		CreatorComment:      opts.creatorComment,
+		OnlyLanguages:       opts.onlyLangs,
+		MaxManifestDepth:    opts.maxManifestDepth,
+		ExcludeDevDeps:      opts.excludeDevDeps,
+		GoOS:                opts.goOS,
+		GoArch:              opts.goArch,
+		GoBuildTags:         opts.goBuildTags,
+		GoStdlib:            opts.goStdlib,
+		DeduplicatePackages: opts.dedupe,
+		PathPrefixStrip:     opts.pathPrefixStrip,
+		PathPrefixAdd:       opts.pathPrefixAdd,
+		CPE:                 opts.cpe,
+		PersistentIDs:       opts.persistentIDs,
+		OmniBORDir:          opts.omniborDir,
+		Prune:               opts.prune,
+		PrimaryPurpose:      opts.purpose,
+		Describes:           opts.describes,
+		Baseline:            opts.baseline,
+		PurlLists:           opts.purlLists,
+		PurlRegistryData:    opts.purlRegistryData,
+		PurlScanLicenses:    opts.purlLicenses,
+		ClearlyDefined:      opts.clearlyDefined,
+		LocalSources:        opts.localSources,
+		CargoVendorDirs:     opts.cargoVendorDirs,
+		LicenseScanCacheDir: opts.licenseScanCache,
+	}
+
+	for _, spec := range opts.addPackages {
+		mp, err := spdx.ParseManualPackage(spec)
+		if err != nil {
+			return fmt.Errorf("parsing package definition: %w", err)
+		}
+		builderOpts.ManualPackages = append(builderOpts.ManualPackages, mp)
 	}
 
 	// We only replace the ignore patterns one or more where defined
 	if len(opts.ignorePatterns) > 0 {
 		builderOpts.IgnorePatterns = opts.ignorePatterns
 	}
-	doc, err := builder.Generate(builderOpts)
+
+	if opts.requireDigest {
+		builderOpts.ImagePolicy = &spdx.ImagePolicy{RequireDigest: true}
+	}
+
+	if !opts.noBuildMetadata {
+		builderOpts.BuildMetadata = spdx.NewBuildMetadata(os.Args)
+	}
+	doc, err := builder.GenerateContext(ctx, builderOpts)
 	if err != nil {
 		return fmt.Errorf("generating doc: %w", err)
 	}
 
 	var renderer serialize.Serializer
 	if opts.format == "json" {
-		renderer = &serialize.JSON{}
+		renderer = &serialize.JSON{Canonical: opts.canonical}
 	} else {
 		renderer = &serialize.TagValue{}
 	}
 
-	markup, err := renderer.Serialize(doc)
-	if err != nil {
-		return fmt.Errorf("serializing document: %w", err)
-	}
-	if opts.outputFile == "" {
-		fmt.Println(markup)
-	} else {
-		if err := os.WriteFile(opts.outputFile, []byte(markup), 0o664); err != nil { //nolint:gosec // G306: Expect WriteFile
-			return fmt.Errorf("writing SBOM: %w", err)
+	// Local files written, to publish them as release assets
+	written := []string{}
+	switch {
+	case opts.outputFile == "":
+		if err := serialize.SerializeTo(renderer, doc, stdout); err != nil {
+			return fmt.Errorf("serializing document: %w", err)
+		}
+	case objectstore.IsURL(opts.outputFile):
+		if err := writeObject(ctx, opts, doc, renderer); err != nil {
+			return err
 		}
+	case opts.splitBy == splitByPackage:
+		paths, err := writeSplitDocuments(opts, doc, renderer)
+		if err != nil {
+			return err
+		}
+		written = append(written, paths...)
+	default:
+		if err := writeDocumentFile(opts.outputFile, doc, renderer); err != nil {
+			return err
+		}
+		written = append(written, opts.outputFile)
 	}
 	// Export the SBOM as in-toto provenance
 	if opts.provenancePath != "" {
@@ -379,7 +964,255 @@ func generateBOM(opts *generateOptions) error {
 		); err != nil {
 			return fmt.Errorf("writing SBOM as provenance statement: %w", err)
 		}
+		written = append(written, opts.provenancePath)
 	}
 
+	if opts.sizeReport {
+		fmt.Fprint(os.Stderr, doc.SizeReport(10).String())
+	}
+
+	if opts.reportPath != "" {
+		if err := builder.Report().Write(opts.reportPath); err != nil {
+			return fmt.Errorf("writing generation report: %w", err)
+		}
+	}
+
+	// Incomplete documents are not uploaded
+	if err := checkDegradations(opts, builder.Report().Degraded); err != nil {
+		return err
+	}
+
+	if err := uploadDocument(ctx, opts, doc, renderer); err != nil {
+		return err
+	}
+
+	return publishGitHubRelease(ctx, opts, doc, renderer, written)
+}
+
+// writeSplitDocuments writes a document for each top level package in
+// the directory of the output file, and an index referencing them to
+// the output file. It returns the paths of the files written.
+func writeSplitDocuments(opts *generateOptions, doc *spdx.Document, renderer serialize.Serializer) ([]string, error) {
+	parts, err := doc.SplitByPackage()
+	if err != nil {
+		return nil, fmt.Errorf("splitting document: %w", err)
+	}
+
+	paths := []string{}
+	for i := range parts {
+		path := filepath.Join(filepath.Dir(opts.outputFile), parts[i].Name+documentExtension(opts.format))
+		if err := writeDocumentFile(path, parts[i].Document, renderer); err != nil {
+			return nil, err
+		}
+		if err := parts[i].Ref.ReadSourceFile(path); err != nil {
+			return nil, fmt.Errorf("hashing %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	index, err := doc.SplitIndex(parts)
+	if err != nil {
+		return nil, fmt.Errorf("building index document: %w", err)
+	}
+	if err := writeDocumentFile(opts.outputFile, index, renderer); err != nil {
+		return nil, err
+	}
+	return append(paths, opts.outputFile), nil
+}
+
+// writeDocumentFile serializes a document to a file.
+func writeDocumentFile(path string, doc *spdx.Document, renderer serialize.Serializer) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o664) //nolint:gosec // G302: Expect OpenFile
+	if err != nil {
+		return fmt.Errorf("opening SBOM file: %w", err)
+	}
+	if err := serialize.SerializeTo(renderer, doc, f); err != nil {
+		f.Close()
+		return fmt.Errorf("writing SBOM: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing SBOM file: %w", err)
+	}
+	return nil
+}
+
+// outputFormat returns the format of the documents written to a path:
+// json for files with a .json extension and tag-value otherwise, also
+// when writing to stdout.
+func outputFormat(path string) string {
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		return spdx.FormatJSON
+	}
+	return spdx.FormatTagValue
+}
+
+// documentContentType returns the media type of the generated document.
+func documentContentType(format string) string {
+	if format == spdx.FormatJSON {
+		return "application/spdx+json"
+	}
+	return "text/spdx"
+}
+
+// documentExtension returns the file extension of the generated document.
+func documentExtension(format string) string {
+	if format == spdx.FormatJSON {
+		return ".spdx.json"
+	}
+	return ".spdx"
+}
+
+// writeObject writes the document to an object storage bucket.
+func writeObject(ctx context.Context, opts *generateOptions, doc *spdx.Document, renderer serialize.Serializer) error {
+	obj, err := objectstore.ParseURL(opts.outputFile)
+	if err != nil {
+		return fmt.Errorf("parsing output URL: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := serialize.SerializeTo(renderer, doc, &buf); err != nil {
+		return fmt.Errorf("serializing document: %w", err)
+	}
+
+	if err := objectstore.Put(ctx, obj, buf.Bytes(), documentContentType(opts.format)); err != nil {
+		return fmt.Errorf("writing SBOM to object storage: %w", err)
+	}
+	logrus.Infof("SBOM written to %s", obj)
 	return nil
 }
+
+// uploadDocument publishes the document to the upload targets. GUAC
+// collectors get the document as written, Dependency-Track only ingests
+// CycloneDX so the document is converted for it.
+func uploadDocument(ctx context.Context, opts *generateOptions, doc *spdx.Document, renderer serialize.Serializer) error {
+	if len(opts.uploads) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := serialize.SerializeTo(renderer, doc, &buf); err != nil {
+		return fmt.Errorf("serializing document to upload: %w", err)
+	}
+
+	uploadOpts := &objectstore.UploadOptions{
+		Token:          os.Getenv(opts.uploadTokenEnv),
+		ContentType:    documentContentType(opts.format),
+		ProjectName:    opts.uploadProject,
+		ProjectVersion: opts.uploadVersion,
+	}
+	if uploadOpts.ProjectName == "" {
+		uploadOpts.ProjectName = doc.Name
+	}
+
+	var bom []byte
+	for _, spec := range opts.uploads {
+		target, err := objectstore.ParseUploadTarget(spec)
+		if err != nil {
+			return fmt.Errorf("parsing upload target: %w", err)
+		}
+		data := buf.Bytes()
+		if target.Kind == objectstore.TargetDependencyTrack {
+			if bom == nil {
+				var cdx bytes.Buffer
+				if err := (&serialize.CycloneDX{}).SerializeTo(doc, &cdx); err != nil {
+					return fmt.Errorf("converting document to CycloneDX: %w", err)
+				}
+				bom = cdx.Bytes()
+			}
+			data = bom
+		}
+		if err := objectstore.Upload(ctx, target, data, uploadOpts); err != nil {
+			return fmt.Errorf("uploading document: %w", err)
+		}
+	}
+	return nil
+}
+
+// publishGitHubRelease attaches the files written to the GitHub release.
+// When the document was not written to a local file, it is attached
+// with a name derived from the document name.
+func publishGitHubRelease(
+	ctx context.Context, opts *generateOptions, doc *spdx.Document,
+	renderer serialize.Serializer, written []string,
+) error {
+	if opts.githubRelease == "" {
+		return nil
+	}
+	rel, err := objectstore.ParseGitHubRelease(opts.githubRelease)
+	if err != nil {
+		return fmt.Errorf("parsing GitHub release: %w", err)
+	}
+
+	assets := []objectstore.Asset{}
+	if len(written) == 0 || written[0] == opts.provenancePath {
+		var buf bytes.Buffer
+		if err := serialize.SerializeTo(renderer, doc, &buf); err != nil {
+			return fmt.Errorf("serializing document to publish: %w", err)
+		}
+		name := strings.ReplaceAll(doc.Name, "/", "-")
+		if name == "" {
+			name = "sbom"
+		}
+		assets = append(assets, objectstore.Asset{
+			Name:        name + documentExtension(opts.format),
+			ContentType: documentContentType(opts.format),
+			Data:        buf.Bytes(),
+		})
+	}
+	for _, path := range written {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s to publish: %w", path, err)
+		}
+		contentType := documentContentType(opts.format)
+		if path == opts.provenancePath {
+			contentType = "application/json"
+		}
+		assets = append(assets, objectstore.Asset{
+			Name: filepath.Base(path), ContentType: contentType, Data: data,
+		})
+	}
+
+	return objectstore.PublishGitHubRelease(ctx, rel, assets, &objectstore.GitHubOptions{
+		Token:  os.Getenv("GITHUB_TOKEN"),
+		Create: opts.createRelease,
+	})
+}
+
+// checkDegradations returns an error if the generated document has
+// degradations of the kinds selected with --strict or --fail-on.
+func checkDegradations(opts *generateOptions, degradations []spdx.Degradation) error {
+	failOn, err := spdx.ParseDegradationKinds(opts.failOn)
+	if err != nil {
+		return fmt.Errorf("parsing --fail-on: %w", err)
+	}
+	if opts.strict {
+		failOn = spdx.DegradationKinds
+	}
+
+	counts := map[spdx.DegradationKind]int{}
+	for _, dg := range degradations {
+		if slices.Contains(failOn, dg.Kind) {
+			counts[dg.Kind]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	summary := []string{}
+	for _, kind := range spdx.DegradationKinds {
+		if counts[kind] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[kind], kind))
+		}
+	}
+	return fmt.Errorf("generated document is incomplete: %s", strings.Join(summary, ", "))
+}
+
+// degradationKindList returns the kinds of degradations for the flag help
+func degradationKindList() string {
+	kinds := []string{}
+	for _, kind := range spdx.DegradationKinds {
+		kinds = append(kinds, string(kind))
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/sirupsen/logrus"
+)
+
+// loadAnalyzerPlugin opens a go plugin (built with -buildmode=plugin)
+// containing custom analyzers. The plugin is expected to register its
+// analyzers calling spdx.RegisterLayerAnalyzer or spdx.RegisterImageAnalyzer
+// from its init functions, which run when the plugin is opened.
+//
+// Plugins need to be built with the same toolchain and dependency
+// versions as the bom binary loading them, and loading them requires
+// a bom built with cgo enabled. Compiling the analyzers into a custom
+// bom binary avoids these limitations.
+func loadAnalyzerPlugin(pluginPath string) error {
+	if _, err := plugin.Open(pluginPath); err != nil {
+		return fmt.Errorf("loading analyzer plugin %s: %w", pluginPath, err)
+	}
+	logrus.Infof("Loaded analyzer plugin %s", pluginPath)
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/index"
+)
+
+type indexOptions struct {
+	path   string
+	format string
+}
+
+func AddIndex(parent *cobra.Command) {
+	indexOpts := &indexOptions{}
+	indexCmd := &cobra.Command{
+		Short: "bom index → Build and search an inventory of SBOMs",
+		Long: `bom index → Build and search an inventory of SBOMs
+
+The index subcommands record the packages and files described by a
+collection of SBOMs in a local database. Searching the index finds
+which documents list a package without parsing all of them again, for
+example to look for a vulnerable library during an incident:
+
+  bom index build ./sboms/ -o index.db
+  bom index search -i index.db 'purl:pkg:maven/org.apache.logging.log4j/log4j-core'
+
+`,
+		Use:               "index",
+		SilenceUsage:      false,
+		SilenceErrors:     true,
+		PersistentPreRunE: initLogging,
+	}
+
+	buildCmd := &cobra.Command{
+		Short: "bom index build → Index the SBOMs in files or directories",
+		Long: `bom index build → Index the SBOMs in files or directories
+
+Reads the SPDX documents (tag-value or JSON) in the paths and records
+their packages and files in the index, creating it if needed. Directories
+are scanned recursively for files with the .spdx and .json extensions,
+files that are not SPDX documents are skipped. Indexing a document again
+replaces its previous data.
+
+`,
+		Use:           "build PATH...",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("no documents or directories to index specified")
+			}
+			idx, err := index.Open(indexOpts.path)
+			if err != nil {
+				return err
+			}
+			defer idx.Close()
+
+			total := 0
+			for _, path := range args {
+				n, err := idx.AddPath(path)
+				if err != nil {
+					return fmt.Errorf("indexing %s: %w", path, err)
+				}
+				total += n
+			}
+			logrus.Infof("Indexed %d documents in %s", total, indexOpts.path)
+			return nil
+		},
+	}
+
+	buildCmd.PersistentFlags().StringVarP(
+		&indexOpts.path,
+		"output",
+		"o",
+		"index.db",
+		"path to the index database",
+	)
+
+	searchCmd := &cobra.Command{
+		Short: "bom index search → Find packages and files in the index",
+		Long: `bom index search → Find packages and files in the index
+
+Searches the index for the elements matching all the terms of an
+expression. The following terms are supported:
+
+  purl:pattern     Package URL, a purl without wildcards matches as a prefix
+  name:pattern     Name of packages and files
+  version:pattern  Version of packages
+  license:pattern  License expression
+  hash:value       Checksum value of packages and files, in any algorithm
+
+Patterns are case sensitive globs supporting the * and ? wildcards:
+
+  bom index search 'purl:pkg:golang/golang.org/x/net@v0.1*'
+  bom index search 'name:openssl version:3.0.*'
+
+`,
+		Use:           "search EXPRESSION",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("no search expression specified")
+			}
+			if _, err := os.Stat(indexOpts.path); err != nil {
+				return fmt.Errorf("checking index: %w", err)
+			}
+			idx, err := index.Open(indexOpts.path)
+			if err != nil {
+				return err
+			}
+			defer idx.Close()
+
+			entries, err := idx.Search(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+
+			switch indexOpts.format {
+			case "table":
+				if len(entries) == 0 {
+					logrus.Warning("No elements in the index match the search")
+					return nil
+				}
+				table := tablewriter.NewWriter(os.Stdout)
+				table.SetHeader([]string{"Document", "Kind", "Name", "Version", "Purl", "License"})
+				for _, e := range entries {
+					table.Append([]string{e.Document, e.Kind, e.Name, e.Version, e.Purl, e.License})
+				}
+				table.Render()
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "    ")
+				if err := enc.Encode(entries); err != nil {
+					return fmt.Errorf("encoding results: %w", err)
+				}
+			default:
+				return errors.New("unrecognized output format, must be table or json")
+			}
+			return nil
+		},
+	}
+
+	searchCmd.PersistentFlags().StringVarP(
+		&indexOpts.path,
+		"index",
+		"i",
+		"index.db",
+		"path to the index database",
+	)
+
+	searchCmd.PersistentFlags().StringVar(
+		&indexOpts.format,
+		"format",
+		"table",
+		"format of output, one of: table or json",
+	)
+
+	indexCmd.AddCommand(buildCmd)
+	indexCmd.AddCommand(searchCmd)
+	parent.AddCommand(indexCmd)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/license"
+)
+
+type licenseOptions struct {
+	listVersion string
+	offline     bool
+	deprecated  bool
+}
+
+func AddLicense(parent *cobra.Command) {
+	licenseOpts := &licenseOptions{}
+	licenseCmd := &cobra.Command{
+		Short: "bom license → Work with the SPDX license list",
+		Long: `bom license → Work with the SPDX license list
+
+The license subcommands query the SPDX license list bom uses to
+classify licenses. By default they use the list embedded in bom,
+other versions can be selected with --license-list-version.
+
+  bom license list             List the valid license identifiers
+  bom license show Apache-2.0  Print a license and its full text
+  bom license search "BSD"     Find licenses by identifier or name
+  bom license classify PATH    Detect the licenses in a file, dir or tarball
+
+`,
+		Use:               "license",
+		SilenceUsage:      false,
+		SilenceErrors:     true,
+		PersistentPreRunE: initLogging,
+	}
+
+	licenseCmd.PersistentFlags().StringVar(
+		&licenseOpts.listVersion,
+		"license-list-version",
+		license.DefaultCatalogOpts.Version,
+		"version of the SPDX license list to use",
+	)
+
+	licenseCmd.PersistentFlags().BoolVar(
+		&licenseOpts.offline,
+		"offline",
+		false,
+		"do not download license lists, use the one embedded in bom",
+	)
+
+	listCmd := &cobra.Command{
+		Short:         "bom license list → List the SPDX license identifiers",
+		Use:           "list",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			catalog, err := licenseOpts.catalog()
+			if err != nil {
+				return err
+			}
+			licenses := []*license.License{}
+			for _, id := range catalog.LicenseIDs(licenseOpts.deprecated) {
+				licenses = append(licenses, catalog.List.Licenses[id])
+			}
+			printLicenseTable(licenses)
+			return nil
+		},
+	}
+
+	listCmd.PersistentFlags().BoolVar(
+		&licenseOpts.deprecated,
+		"deprecated",
+		false,
+		"include deprecated license identifiers",
+	)
+
+	showCmd := &cobra.Command{
+		Short:         "bom license show → Print a license and its text",
+		Use:           "show LICENSE_ID",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("a license identifier is required")
+			}
+			catalog, err := licenseOpts.catalog()
+			if err != nil {
+				return err
+			}
+			l, ok := catalog.List.Licenses[args[0]]
+			if !ok {
+				return fmt.Errorf("%s is not a license identifier in the SPDX license list %s", args[0], catalog.List.Version)
+			}
+			fmt.Printf("ID:           %s\n", l.LicenseID)
+			fmt.Printf("Name:         %s\n", l.Name)
+			fmt.Printf("OSI Approved: %t\n", l.IsOsiApproved)
+			fmt.Printf("FSF Libre:    %t\n", l.IsFsfLibre)
+			fmt.Printf("Deprecated:   %t\n", l.IsDeprecatedLicenseID)
+			if len(l.SeeAlso) > 0 {
+				fmt.Printf("See Also:     %s\n", strings.Join(l.SeeAlso, "\n              "))
+			}
+			fmt.Println()
+			fmt.Println(l.LicenseText)
+			return nil
+		},
+	}
+
+	searchCmd := &cobra.Command{
+		Short:         "bom license search → Find licenses by identifier or name",
+		Use:           "search TEXT",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("a search term is required")
+			}
+			catalog, err := licenseOpts.catalog()
+			if err != nil {
+				return err
+			}
+			licenses := catalog.Search(args[0])
+			if len(licenses) == 0 {
+				return fmt.Errorf("no licenses match %q", args[0])
+			}
+			printLicenseTable(licenses)
+			return nil
+		},
+	}
+
+	licenseCmd.AddCommand(listCmd, showCmd, searchCmd)
+	AddLicenseClassify(licenseCmd, licenseOpts)
+	parent.AddCommand(licenseCmd)
+}
+
+// listTag returns the license-list-data tag of the selected list version.
+func (opts *licenseOptions) listTag() string {
+	if tag := license.ListVersionTag(opts.listVersion); tag != "" {
+		return tag
+	}
+	return license.DefaultCatalogOpts.Version
+}
+
+// catalog loads the license catalog selected in the options.
+func (opts *licenseOptions) catalog() (*license.Catalog, error) {
+	catalogOpts := license.DefaultCatalogOpts
+	catalogOpts.Version = opts.listTag()
+	catalogOpts.Offline = opts.offline
+	catalog, err := license.NewCatalogWithOptions(catalogOpts)
+	if err != nil {
+		return nil, fmt.Errorf("creating license catalog: %w", err)
+	}
+	if err := catalog.LoadLicenses(); err != nil {
+		return nil, fmt.Errorf("loading license list: %w", err)
+	}
+	return catalog, nil
+}
+
+func printLicenseTable(licenses []*license.License) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "OSI Approved", "Deprecated"})
+	for _, l := range licenses {
+		table.Append([]string{
+			l.LicenseID, l.Name,
+			strconv.FormatBool(l.IsOsiApproved),
+			strconv.FormatBool(l.IsDeprecatedLicenseID),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/bom/pkg/license"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// classifyArchiveExtensions are the file extensions of the inputs that
+// are extracted before looking for licenses in them.
+var classifyArchiveExtensions = []string{
+	".tar", ".tar.gz", ".tgz", ".zip", ".jar", ".war", ".ear", ".whl",
+}
+
+func AddLicenseClassify(parent *cobra.Command, licenseOpts *licenseOptions) {
+	classifyCmd := &cobra.Command{
+		Short: "bom license classify → Detect the licenses in files, directories or archives",
+		Long: `bom license classify → Detect the licenses in files, directories or archives
+
+classify runs its inputs through the same license classifier bom uses
+when generating SBOMs and prints the licenses detected along with the
+confidence of the classifier. This is useful to debug why a package
+ended up with a NOASSERTION license.
+
+Inputs can be:
+
+  - Files, which are classified directly:
+      bom license classify LICENSE
+
+  - Directories, where bom looks for license files:
+      bom license classify ./vendor/github.com/example/module
+
+  - Tarballs and zip archives, which are extracted before searching
+    them for license files:
+      bom license classify release.tar.gz
+
+For directories and archives, classify also prints the license bom
+concludes for the whole package.
+
+`,
+		Use:           "classify PATH|TARBALL [PATH|TARBALL...]",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				cmd.Help() //nolint:errcheck
+				return errors.New("at least one path to classify is required")
+			}
+
+			reader, err := license.NewReaderWithOptions(&license.ReaderOptions{
+				ConfidenceThreshold: license.DefaultReaderOptions.ConfidenceThreshold,
+				LicenseListVersion:  licenseOpts.listTag(),
+				Offline:             licenseOpts.offline,
+			})
+			if err != nil {
+				return fmt.Errorf("creating license reader: %w", err)
+			}
+
+			for _, path := range args {
+				if err := classifyPath(reader, path); err != nil {
+					return fmt.Errorf("classifying %s: %w", path, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	parent.AddCommand(classifyCmd)
+}
+
+// isClassifyArchive returns true if path looks like an archive to extract.
+func isClassifyArchive(path string) bool {
+	lower := strings.ToLower(path)
+	for _, ext := range classifyArchiveExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyPath prints the licenses found in a file, directory or archive.
+func classifyPath(reader *license.Reader, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("checking path: %w", err)
+	}
+
+	dir := ""
+	switch {
+	case info.IsDir():
+		dir = path
+	case isClassifyArchive(path):
+		dir, err = spdx.NewSPDX().ExtractTarballTmp(path)
+		if dir != "" {
+			defer os.RemoveAll(dir)
+		}
+		if err != nil {
+			return fmt.Errorf("extracting archive: %w", err)
+		}
+	}
+
+	var results []*license.ClassifyResult
+	var unknown []string
+	if dir == "" {
+		results, unknown, err = reader.ClassifyLicenseFiles([]string{path})
+	} else {
+		results, unknown, err = reader.ReadLicenses(dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Paths inside extracted archives are printed relative to the archive
+	displayPath := func(p string) string {
+		if dir == "" || dir == path {
+			return p
+		}
+		if rel, err := filepath.Rel(dir, p); err == nil {
+			return filepath.Join(path, rel)
+		}
+		return p
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"File", "License", "Confidence"})
+	for _, res := range results {
+		table.Append([]string{
+			displayPath(res.File), res.License.LicenseID, fmt.Sprintf("%.2f", res.Confidence),
+		})
+	}
+	for _, p := range unknown {
+		table.Append([]string{displayPath(p), spdx.NOASSERTION, "-"})
+	}
+	if len(results)+len(unknown) == 0 {
+		logrus.Warnf("No license files found in %s", path)
+		return nil
+	}
+	table.Render()
+
+	if dir == "" {
+		return nil
+	}
+	top, err := reader.ReadTopLicense(dir)
+	if err != nil {
+		return fmt.Errorf("reading top license: %w", err)
+	}
+	if top == nil {
+		fmt.Printf("Concluded license for %s: %s\n", path, spdx.NOASSERTION)
+		return nil
+	}
+	fmt.Printf("Concluded license for %s: %s (from %s)\n", path, top.License.LicenseID, displayPath(top.File))
+	return nil
+}
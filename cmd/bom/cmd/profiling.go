@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/sirupsen/logrus"
+)
+
+// cpuProfile is the file receiving the CPU profile while it runs.
+var cpuProfile *os.File
+
+// startProfiling starts writing a CPU profile if the option is set.
+func startProfiling() error {
+	if commandLineOpts.cpuProfile == "" || cpuProfile != nil {
+		return nil
+	}
+	f, err := os.Create(commandLineOpts.cpuProfile)
+	if err != nil {
+		return fmt.Errorf("creating CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("starting CPU profile: %w", err)
+	}
+	cpuProfile = f
+	return nil
+}
+
+// stopProfiling finishes the CPU profile and writes the memory profile
+// if the options are set. Errors are only logged as this runs when the
+// command is done.
+func stopProfiling() {
+	if cpuProfile != nil {
+		pprof.StopCPUProfile()
+		if err := cpuProfile.Close(); err != nil {
+			logrus.Errorf("closing CPU profile: %v", err)
+		}
+		cpuProfile = nil
+	}
+
+	if commandLineOpts.memProfile == "" {
+		return
+	}
+	f, err := os.Create(commandLineOpts.memProfile)
+	if err != nil {
+		logrus.Errorf("creating memory profile: %v", err)
+		return
+	}
+	defer f.Close()
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		logrus.Errorf("writing memory profile: %v", err)
+	}
+}
@@ -17,11 +17,12 @@ limitations under the License.
 package cmd
 
 import (
+	"os"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/release-utils/log"
-	"sigs.k8s.io/release-utils/version"
 )
 
 var rootCmd = &cobra.Command{
@@ -45,7 +46,10 @@ projects to use.
 }
 
 type commandLineOptions struct {
-	logLevel string
+	logLevel   string
+	quiet      bool // Only log errors
+	cpuProfile string
+	memProfile string
 }
 
 var commandLineOpts = &commandLineOptions{}
@@ -58,19 +62,59 @@ func init() {
 		"the logging verbosity, either "+log.LevelNames(),
 	)
 
+	rootCmd.PersistentFlags().BoolVarP(
+		&commandLineOpts.quiet,
+		"quiet",
+		"q",
+		false,
+		"only log errors, overrides --log-level",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&commandLineOpts.cpuProfile,
+		"cpuprofile",
+		"",
+		"write a CPU profile of the command to this file",
+	)
+
+	rootCmd.PersistentFlags().StringVar(
+		&commandLineOpts.memProfile,
+		"memprofile",
+		"",
+		"write a memory profile to this file when the command finishes",
+	)
+	rootCmd.PersistentFlags().MarkHidden("cpuprofile") //nolint:errcheck
+	rootCmd.PersistentFlags().MarkHidden("memprofile") //nolint:errcheck
+
 	AddGenerate(rootCmd)
 	AddDocument(rootCmd)
 	AddValidate(rootCmd)
-	rootCmd.AddCommand(version.WithFont("doom"))
+	AddCI(rootCmd)
+	AddLicense(rootCmd)
+	AddIndex(rootCmd)
+	AddVersion(rootCmd)
 }
 
 // Execute builds the command.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	stopProfiling()
+	if err != nil {
 		logrus.Fatal(err)
 	}
 }
 
+// initLogging runs before all commands, it sets up the logger and
+// starts profiling if requested. Logs always go to stderr, leaving stdout
+// to the output of the commands.
 func initLogging(*cobra.Command, []string) error {
-	return log.SetupGlobalLogger(commandLineOpts.logLevel)
+	level := commandLineOpts.logLevel
+	if commandLineOpts.quiet {
+		level = logrus.ErrorLevel.String()
+	}
+	if err := log.SetupGlobalLogger(level); err != nil {
+		return err
+	}
+	logrus.SetOutput(os.Stderr)
+	return startProfiling()
 }
@@ -44,8 +44,15 @@ func AddValidate(parent *cobra.Command) {
 validate is the bom subcommand to check artifacts against SPDX
 manifests.
 
-This is an experimental command. The first iteration has support
-for checking files.
+This is an experimental command. It supports checking files,
+archive packages against tarballs on disk (--archives), image
+packages against the digests in their registries (--images) and
+package license identifiers against the SPDX license list version
+declared in the document (--licenses).
+
+Signed SBOMs (DSSE envelopes or cosign attestations) are verified
+before being checked when --verify-key or --certificate-identity is
+set.
 
 `,
 		Use:               "validate",
@@ -92,6 +99,41 @@ for checking files.
 		"list of files to verify",
 	)
 
+	cmd.PersistentFlags().StringSliceVar(
+		&valOpts.archives,
+		"archives",
+		[]string{},
+		"list of archives to verify against the packages in the sbom",
+	)
+
+	cmd.PersistentFlags().StringSliceVar(
+		&valOpts.images,
+		"images",
+		[]string{},
+		"list of image references to verify against the registry digests",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&valOpts.licenses,
+		"licenses",
+		false,
+		"check the package license identifiers against the license list declared in the sbom",
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&valOpts.offline,
+		"offline",
+		false,
+		"do not download license lists, use the one embedded in bom",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&valOpts.sarif,
+		"sarif",
+		"",
+		"write the failed checks to this file as SARIF, for GitHub code scanning",
+	)
+
 	cmd.PersistentFlags().StringVarP(
 		&valOpts.dir,
 		"dir",
@@ -108,6 +150,8 @@ for checking files.
 		"when true, bom will exit with exit code 1 if invalid artifacts are found",
 	)
 
+	addVerifyFlags(cmd, &valOpts.verify)
+
 	parent.AddCommand(cmd)
 }
 
@@ -115,13 +159,23 @@ type validateOptions struct {
 	exitCode bool
 	sbomPath string
 	files    []string
+	archives []string
+	images   []string
 	dir      string
+	licenses bool
+	offline  bool
+	sarif    string // Path to write the failed checks as SARIF
+	verify   spdx.VerifyOptions
 }
 
 // Validate verify options consistency.
 func (opts *validateOptions) Validate() error {
-	if len(opts.files) == 0 && opts.dir == "" {
-		return errors.New("please provide at least one artifact file or directory to validate")
+	if len(opts.files) == 0 && len(opts.archives) == 0 && len(opts.images) == 0 && opts.dir == "" && !opts.licenses {
+		return errors.New("please provide at least one artifact file, archive, image, directory or --licenses to validate")
+	}
+
+	if opts.sarif == "-" {
+		return errors.New("--sarif has to be a file, the results table is written to STDOUT")
 	}
 
 	return nil
@@ -135,11 +189,52 @@ func validateArtifacts(opts validateOptions) error {
 	if !opts.exitCode {
 		logrus.Info("Checking files against SPDX Bill of Materials")
 	}
-	doc, err := spdx.OpenDoc(opts.sbomPath)
+	readOpts := &spdx.ReadOptions{}
+	if opts.verify.Enabled() {
+		readOpts.Verify = &opts.verify
+	}
+	doc, err := spdx.OpenDocWithOptions(opts.sbomPath, readOpts)
 	if err != nil {
 		return fmt.Errorf("opening doc: %w", err)
 	}
 
+	// The report reads the document before --dir changes the working
+	// directory
+	var report *spdx.SARIFReport
+	sarifPath := opts.sarif
+	if opts.sarif != "" {
+		report = newSARIFReport(opts.sbomPath)
+		if sarifPath, err = filepath.Abs(opts.sarif); err != nil {
+			return fmt.Errorf("resolving SARIF path: %w", err)
+		}
+	}
+
+	res := []spdx.ValidationResults{}
+	licenseRes := []spdx.ValidationResults{}
+	if len(opts.archives) > 0 {
+		archiveRes, err := doc.ValidateArchives(opts.archives)
+		if err != nil {
+			return fmt.Errorf("validating archives: %w", err)
+		}
+		res = append(res, archiveRes...)
+	}
+
+	if len(opts.images) > 0 {
+		imageRes, err := doc.ValidateImages(opts.images)
+		if err != nil {
+			return fmt.Errorf("validating images: %w", err)
+		}
+		res = append(res, imageRes...)
+	}
+
+	if opts.licenses {
+		catalog, err := doc.LicenseCatalog(opts.offline)
+		if err != nil {
+			return fmt.Errorf("loading document license list: %w", err)
+		}
+		licenseRes = doc.ValidateLicenses(catalog)
+	}
+
 	files := []string{}
 	if opts.dir != "" {
 		if err := os.Chdir(opts.dir); err != nil {
@@ -165,11 +260,23 @@ func validateArtifacts(opts validateOptions) error {
 	}
 	files = append(files, opts.files...)
 
-	res, err := doc.ValidateFiles(files)
-	if err != nil {
-		return fmt.Errorf("validating files: %w", err)
+	if len(files) > 0 {
+		fileRes, err := doc.ValidateFiles(files)
+		if err != nil {
+			return fmt.Errorf("validating files: %w", err)
+		}
+		res = append(res, fileRes...)
 	}
 
+	if report != nil {
+		report.AddValidationResults(res)
+		report.AddLicenseResults(licenseRes)
+		if err := writeSARIF(sarifPath, report); err != nil {
+			return err
+		}
+	}
+	res = append(res, licenseRes...)
+
 	data := [][]string{}
 	errored := false
 	for _, res := range res {
@@ -179,21 +286,25 @@ func validateArtifacts(opts validateOptions) error {
 		}
 		resRow := []string{
 			res.FileName,
+			"-",
 			"FAIL",
 			res.Message,
 			"-",
 		}
 		if res.Message == spdx.MessageHashMismatch && len(res.FailedAlgorithms) > 0 {
-			resRow[3] = strings.Join(res.FailedAlgorithms, " ")
+			resRow[4] = strings.Join(res.FailedAlgorithms, " ")
 		}
 		if res.Success {
-			resRow[1] = "OK"
+			resRow[2] = "OK"
+		}
+		if res.PackageID != "" {
+			resRow[1] = res.PackageID
 		}
 		data = append(data, resRow)
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"FileName", "Valid", "Message", "Invalid Hashes"})
+	table.SetHeader([]string{"FileName", "Package", "Valid", "Message", "Invalid Hashes"})
 
 	for _, v := range data {
 		table.Append(v)
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"sigs.k8s.io/bom/pkg/license"
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// versionFont is the figlet font used to print the name of bom.
+const versionFont = "doom"
+
+// reportedModules are the dependencies whose versions change the contents
+// of the generated SBOMs, their versions are reported with the bom version.
+var reportedModules = []string{
+	"github.com/google/go-containerregistry",
+	"github.com/google/licenseclassifier/v2",
+	"sigs.k8s.io/release-utils",
+}
+
+// versionInfo extends the build information with the data that determines
+// the contents of the SBOMs bom produces.
+type versionInfo struct {
+	version.Info
+	LicenseListVersion string            `json:"licenseListVersion"`
+	SPDXVersions       []string          `json:"spdxVersions"`
+	Scanners           []string          `json:"scanners"`
+	LayerAnalyzers     []string          `json:"layerAnalyzers"`
+	ImageAnalyzers     []string          `json:"imageAnalyzers"`
+	Dependencies       map[string]string `json:"dependencies,omitempty"`
+}
+
+func AddVersion(parent *cobra.Command) {
+	var outputJSON bool
+	versionCmd := &cobra.Command{
+		Short: "Prints the version",
+		Long: `bom version → Prints the version
+
+Prints the version and build information of bom along with the embedded
+SPDX license list version, the SPDX specification versions it supports and
+the scanners and image analyzers it runs. When comparing SBOMs produced by
+different bom builds, this information explains most of the differences.
+
+`,
+		Use:           "version",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			v := getVersionInfo()
+			v.Name = cmd.Root().Name()
+			v.Description = cmd.Root().Short
+			if v.CheckFontName(versionFont) {
+				v.FontName = versionFont
+			}
+
+			if outputJSON {
+				out, err := json.MarshalIndent(v, "", "  ")
+				if err != nil {
+					return fmt.Errorf("unable to generate JSON from version info: %w", err)
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(out))
+				return nil
+			}
+			fmt.Fprint(cmd.OutOrStdout(), v.String())
+			return nil
+		},
+	}
+
+	versionCmd.Flags().BoolVar(&outputJSON, "json", false, "print JSON instead of text")
+
+	parent.AddCommand(versionCmd)
+}
+
+// getVersionInfo collects the version information of the running binary.
+func getVersionInfo() *versionInfo {
+	v := &versionInfo{
+		Info:               version.GetVersionInfo(),
+		LicenseListVersion: license.DefaultCatalogOpts.Version,
+		SPDXVersions:       spdx.SupportedSPDXVersions,
+		Scanners:           []string{},
+	}
+	for _, lang := range spdx.SupportedLanguages {
+		v.Scanners = append(v.Scanners, string(lang))
+	}
+	v.LayerAnalyzers, v.ImageAnalyzers = spdx.RegisteredAnalyzers()
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		v.Dependencies = map[string]string{}
+		for _, dep := range bi.Deps {
+			for _, mod := range reportedModules {
+				if dep.Path != mod {
+					continue
+				}
+				if dep.Replace != nil {
+					dep = dep.Replace
+				}
+				v.Dependencies[mod] = dep.Version
+			}
+		}
+	}
+	return v
+}
+
+// String returns the version information formatted as text.
+func (v *versionInfo) String() string {
+	b := strings.Builder{}
+	b.WriteString(v.Info.String())
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "LicenseListVersion:\t%s\n", v.LicenseListVersion)
+	fmt.Fprintf(w, "SPDXVersions:\t%s\n", strings.Join(v.SPDXVersions, ", "))
+	fmt.Fprintf(w, "Scanners:\t%s\n", strings.Join(v.Scanners, ", "))
+	fmt.Fprintf(w, "LayerAnalyzers:\t%s\n", strings.Join(v.LayerAnalyzers, ", "))
+	fmt.Fprintf(w, "ImageAnalyzers:\t%s\n", strings.Join(v.ImageAnalyzers, ", "))
+	for _, mod := range reportedModules {
+		if ver, ok := v.Dependencies[mod]; ok {
+			fmt.Fprintf(w, "%s:\t%s\n", mod, ver)
+		}
+	}
+	w.Flush() //nolint:errcheck
+	return b.String()
+}
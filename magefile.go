@@ -72,6 +72,11 @@ func Test() error {
 	return nil
 }
 
+// Bench runs the Go benchmarks to catch performance regressions
+func Bench() error {
+	return sh.RunV("go", "test", "-run=^$", "-bench=.", "-benchmem", "./...")
+}
+
 // Verify runs repository verification scripts
 func Verify() error {
 	fmt.Println("Ensuring mage is available...")
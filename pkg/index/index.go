@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package index builds a searchable inventory of the elements described
+// by a collection of SBOMs. The index is stored in a sqlite database to
+// look up packages across many documents without parsing them again.
+package index
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// Kinds of indexed elements
+const (
+	KindPackage = "package"
+	KindFile    = "file"
+)
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS documents (
+		id INTEGER PRIMARY KEY,
+		path TEXT NOT NULL UNIQUE,
+		name TEXT NOT NULL,
+		namespace TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS elements (
+		id INTEGER PRIMARY KEY,
+		document INTEGER NOT NULL,
+		spdxid TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		version TEXT NOT NULL,
+		purl TEXT NOT NULL,
+		license TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS hashes (
+		element INTEGER NOT NULL,
+		algorithm TEXT NOT NULL,
+		value TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS elements_document ON elements(document)`,
+	`CREATE INDEX IF NOT EXISTS elements_purl ON elements(purl)`,
+	`CREATE INDEX IF NOT EXISTS elements_name ON elements(name)`,
+	`CREATE INDEX IF NOT EXISTS hashes_element ON hashes(element)`,
+	`CREATE INDEX IF NOT EXISTS hashes_value ON hashes(value)`,
+}
+
+// Index is an inventory of the packages and files of a set of documents.
+type Index struct {
+	db *sql.DB
+}
+
+// Entry is an element found in the index.
+type Entry struct {
+	Document string `json:"document"` // Path of the document describing the element
+	ID       string `json:"id"`       // SPDX identifier of the element
+	Kind     string `json:"kind"`     // package | file
+	Name     string `json:"name"`
+	Version  string `json:"version,omitempty"`
+	Purl     string `json:"purl,omitempty"`
+	License  string `json:"license,omitempty"`
+}
+
+// Open opens the index stored at path, creating it if it does not exist.
+func Open(path string) (*Index, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening index database: %w", err)
+	}
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("creating index schema: %w", err)
+		}
+	}
+	return &Index{db: db}, nil
+}
+
+// Close closes the index database.
+func (idx *Index) Close() error {
+	return idx.db.Close()
+}
+
+// AddPath indexes the document at path or, if path is a directory, all
+// the documents found in it. Files with the .spdx and .json extensions
+// are read, those that can't be parsed as SPDX are skipped. Returns the
+// number of documents indexed.
+func (idx *Index) AddPath(path string) (docs int, err error) {
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if p != path && filepath.Ext(p) != ".spdx" && filepath.Ext(p) != ".json" {
+			return nil
+		}
+		doc, err := spdx.OpenDoc(p)
+		if err != nil {
+			logrus.Warnf("Skipping %s: %v", p, err)
+			return nil
+		}
+		n, err := idx.AddDocument(p, doc)
+		if err != nil {
+			return fmt.Errorf("indexing %s: %w", p, err)
+		}
+		logrus.Infof("Indexed %d elements from %s", n, p)
+		docs++
+		return nil
+	})
+	return docs, err
+}
+
+// AddDocument indexes the packages and files of a document, replacing
+// any previous data indexed from the same path. Returns the number of
+// elements indexed.
+func (idx *Index) AddDocument(path string, doc *spdx.Document) (elements int, err error) {
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint:errcheck
+		}
+	}()
+
+	path = filepath.Clean(path)
+	for _, stmt := range []string{
+		"DELETE FROM hashes WHERE element IN (SELECT e.id FROM elements e JOIN documents d ON d.id = e.document WHERE d.path = ?)",
+		"DELETE FROM elements WHERE document IN (SELECT id FROM documents WHERE path = ?)",
+		"DELETE FROM documents WHERE path = ?",
+	} {
+		if _, err := tx.Exec(stmt, path); err != nil {
+			return 0, fmt.Errorf("removing previous document data: %w", err)
+		}
+	}
+
+	res, err := tx.Exec("INSERT INTO documents (path, name, namespace) VALUES (?, ?, ?)", path, doc.Name, doc.Namespace)
+	if err != nil {
+		return 0, fmt.Errorf("inserting document: %w", err)
+	}
+	docID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("reading document id: %w", err)
+	}
+
+	err = doc.Walk(func(o spdx.Object, _ []spdx.Object) error {
+		var entry Entry
+		var checksums map[string]string
+		switch e := o.(type) {
+		case *spdx.Package:
+			entry = Entry{ID: e.ID, Kind: KindPackage, Name: e.Name, Version: e.Version, License: e.LicenseDeclared}
+			if entry.License == "" || entry.License == spdx.NOASSERTION {
+				entry.License = e.LicenseConcluded
+			}
+			if p := e.Purl(); p != nil {
+				entry.Purl = p.String()
+			}
+			checksums = e.Checksum
+		case *spdx.File:
+			entry = Entry{ID: e.ID, Kind: KindFile, Name: e.Name, License: e.LicenseConcluded}
+			checksums = e.Checksum
+		default:
+			return nil
+		}
+		if entry.License == spdx.NOASSERTION {
+			entry.License = ""
+		}
+
+		res, err := tx.Exec(
+			"INSERT INTO elements (document, spdxid, kind, name, version, purl, license) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			docID, entry.ID, entry.Kind, entry.Name, entry.Version, entry.Purl, entry.License,
+		)
+		if err != nil {
+			return fmt.Errorf("inserting element %s: %w", entry.ID, err)
+		}
+		elementID, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("reading element id: %w", err)
+		}
+		for algo, value := range checksums {
+			if _, err := tx.Exec(
+				"INSERT INTO hashes (element, algorithm, value) VALUES (?, ?, ?)",
+				elementID, algo, strings.ToLower(value),
+			); err != nil {
+				return fmt.Errorf("inserting %s hash: %w", entry.ID, err)
+			}
+		}
+		elements++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing document data: %w", err)
+	}
+	return elements, nil
+}
+
+// Search returns the elements matching a search expression. Expressions
+// are made of space separated field:pattern terms, elements have to
+// match all of them:
+//
+//	purl:pattern     Package URL glob, a purl without wildcards matches as a prefix
+//	name:pattern     Name glob of packages and files
+//	version:pattern  Version glob of packages
+//	license:pattern  License expression glob
+//	hash:value       Checksum value in any algorithm
+//
+// Globs are case sensitive and support the * and ? wildcards.
+func (idx *Index) Search(expression string) ([]Entry, error) {
+	conditions := []string{}
+	args := []any{}
+	for _, term := range strings.Fields(expression) {
+		field, pattern, ok := strings.Cut(term, ":")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid search term %q, must be field:pattern", term)
+		}
+		switch field {
+		case "purl":
+			if !strings.ContainsAny(pattern, "*?[") {
+				pattern += "*"
+			}
+			conditions = append(conditions, "e.purl GLOB ?")
+		case "name", "version", "license":
+			conditions = append(conditions, "e."+field+" GLOB ?")
+		case "hash":
+			conditions = append(conditions, "e.id IN (SELECT element FROM hashes WHERE value = ?)")
+			pattern = strings.ToLower(pattern)
+		default:
+			return nil, fmt.Errorf("unknown search field %q", field)
+		}
+		args = append(args, pattern)
+	}
+	if len(conditions) == 0 {
+		return nil, errors.New("search expression is empty")
+	}
+
+	rows, err := idx.db.Query(
+		"SELECT d.path, e.spdxid, e.kind, e.name, e.version, e.purl, e.license "+
+			"FROM elements e JOIN documents d ON d.id = e.document WHERE "+
+			strings.Join(conditions, " AND ")+" ORDER BY d.path, e.spdxid",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("searching index: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		e := Entry{}
+		if err := rows.Scan(&e.Document, &e.ID, &e.Kind, &e.Name, &e.Version, &e.Purl, &e.License); err != nil {
+			return nil, fmt.Errorf("reading search result: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading search results: %w", err)
+	}
+	return entries, nil
+}
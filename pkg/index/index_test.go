@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	// Copy the documents to index next to a json file that is no SBOM
+	dir := t.TempDir()
+	for _, name := range []string{"images.spdx.json", "full-fields.spdx.json"} {
+		data, err := os.ReadFile(filepath.Join("..", "spdx", "testdata", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, os.FileMode(0o644)))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"key": "value"}`), os.FileMode(0o644)))
+
+	idx, err := Open(filepath.Join(t.TempDir(), "index.db"))
+	require.NoError(t, err)
+	defer idx.Close()
+
+	docs, err := idx.AddPath(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, docs)
+
+	// Indexing again replaces the previous data
+	docs, err = idx.AddPath(dir)
+	require.NoError(t, err)
+	require.Equal(t, 2, docs)
+
+	entries, err := idx.Search("purl:pkg:apk/alpine/musl")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, filepath.Join(dir, "images.spdx.json"), entries[0].Document)
+	require.Equal(t, KindPackage, entries[0].Kind)
+	require.Equal(t, "musl", entries[0].Name)
+	require.Equal(t, "1.2.3-r0", entries[0].Version)
+
+	entries, err = idx.Search("name:app version:1.*")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, Entry{
+		Document: filepath.Join(dir, "full-fields.spdx.json"),
+		ID:       "SPDXRef-Package-app",
+		Kind:     KindPackage,
+		Name:     "app",
+		Version:  "1.0.0",
+		License:  "MIT",
+	}, entries[0])
+
+	entries, err = idx.Search("hash:2C26B46B68FFC68FF99B453C1D30413413422D706483BFA0F98A5E886266E7AE")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "SPDXRef-Package-app", entries[0].ID)
+
+	// Results are sorted by document, four alpine packages follow the
+	// package and file in full-fields
+	entries, err = idx.Search("license:MIT")
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+	require.Equal(t, "SPDXRef-File-main", entries[0].ID)
+	require.Equal(t, KindFile, entries[0].Kind)
+
+	entries, err = idx.Search("name:openssl")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	for _, expression := range []string{"", "openssl", "size:10", "name:"} {
+		_, err := idx.Search(expression)
+		require.Error(t, err, expression)
+	}
+}
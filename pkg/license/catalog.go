@@ -21,7 +21,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
@@ -32,6 +35,7 @@ import (
 type CatalogOptions struct {
 	CacheDir string // Directrory to catch the license we download from SPDX.org
 	Version  string // Version of the licenses to download  (eg v3.19) or blank for latest
+	Offline  bool   // Never download license data, fall back to the embedded list
 }
 
 // DefaultCatalogOpts are the predetermined settings. License and cache directories
@@ -49,10 +53,11 @@ var DefaultCatalogOpts = CatalogOptions{
 // NewCatalogWithOptions returns a SPDX object with the specified options.
 func NewCatalogWithOptions(opts CatalogOptions) (catalog *Catalog, err error) {
 	// Create the license downloader
-	doptions := DefaultDownloaderOpts
+	doptions := *DefaultDownloaderOpts
 	doptions.Version = opts.Version
 	doptions.CacheDir = opts.CacheDir
-	downloader, err := NewDownloaderWithOptions(doptions)
+	doptions.Offline = opts.Offline
+	downloader, err := NewDownloaderWithOptions(&doptions)
 	if err != nil {
 		return nil, fmt.Errorf("creating downloader: %w", err)
 	}
@@ -64,6 +69,31 @@ func NewCatalogWithOptions(opts CatalogOptions) (catalog *Catalog, err error) {
 	return catalog, nil
 }
 
+// ListVersionTag returns the tag of the SPDX license-list-data release
+// matching a license list version as declared in SPDX documents (eg 3.22).
+// Versions matching the embedded list return its tag. Returns an empty
+// string when version is empty.
+func ListVersionTag(version string) string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return ""
+	}
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return "v" + version
+	}
+	if embedded, err := semver.ParseTolerant(DefaultCatalogOpts.Version); err == nil &&
+		embedded.Major == v.Major && embedded.Minor == v.Minor {
+		return DefaultCatalogOpts.Version
+	}
+	// Starting with 3.24, license-list-data releases are tagged with
+	// a patch number.
+	if v.Patch != 0 || v.Major > 3 || (v.Major == 3 && v.Minor >= 24) {
+		return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	}
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
 // Options returns  a pointer to the catlog options.
 func (catalog *Catalog) Options() CatalogOptions {
 	return catalog.opts
@@ -124,6 +154,39 @@ func (catalog *Catalog) WriteLicensesAsText(targetDir string) error {
 	return nil
 }
 
+// LicenseIDs returns the sorted SPDX identifiers of the licenses in the
+// catalog. Deprecated identifiers are only included if includeDeprecated
+// is set.
+func (catalog *Catalog) LicenseIDs(includeDeprecated bool) []string {
+	ids := []string{}
+	if catalog.List == nil {
+		return ids
+	}
+	for id, l := range catalog.List.Licenses {
+		if l.IsDeprecatedLicenseID && !includeDeprecated {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Search returns the licenses whose SPDX identifier or name contain
+// text, ignoring case. The results are sorted by identifier.
+func (catalog *Catalog) Search(text string) []*License {
+	res := []*License{}
+	text = strings.ToLower(text)
+	for _, id := range catalog.LicenseIDs(true) {
+		l := catalog.List.Licenses[id]
+		if strings.Contains(strings.ToLower(id), text) ||
+			strings.Contains(strings.ToLower(l.Name), text) {
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
 // GetLicense returns a license struct from its SPDX ID label.
 func (catalog *Catalog) GetLicense(label string) *License {
 	if lic, ok := catalog.List.Licenses[label]; ok {
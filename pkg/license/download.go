@@ -31,7 +31,6 @@ import (
 
 	"github.com/sirupsen/logrus"
 
-	"sigs.k8s.io/release-utils/http"
 	"sigs.k8s.io/release-utils/util"
 )
 
@@ -72,6 +71,10 @@ type DownloaderOptions struct {
 	CacheDir          string // Directory where data will be cached, defaults to temporary dir
 	parallelDownloads int    // Number of license downloads we'll do at once
 	Version           string // Version of the licenses to download  (eg v3.19) or blank for latest
+	Offline           bool   // Never download, fall back to the embedded license list
+	// CacheMaxAge is how long cached data of URLs that can change (like
+	// the latest release) is used before revalidating it with the server
+	CacheMaxAge time.Duration
 }
 
 // Validate Checks the downloader options.
@@ -100,6 +103,10 @@ func (do *DownloaderOptions) Validate() error {
 	return nil
 }
 
+// ErrOffline is returned when license data needs to be downloaded but
+// downloads are disabled in the options.
+var ErrOffline = errors.New("license data not available offline")
+
 // Downloader handles downloading f license data.
 type Downloader struct {
 	impl DownloaderImplementation
@@ -141,6 +148,7 @@ var DefaultDownloaderOpts = &DownloaderOptions{
 	EnableCache:       true,
 	CacheDir:          "",
 	parallelDownloads: 5,
+	CacheMaxAge:       time.Hour,
 }
 
 // DefaultDownloaderImpl is the default implementation that gets licenses.
@@ -160,24 +168,14 @@ func (ddi *DefaultDownloaderImpl) SetOptions(opts *DownloaderOptions) {
 
 // GetLatestTag gets the latest version of the license list from github.
 func (ddi *DefaultDownloaderImpl) GetLatestTag() (string, error) {
-	var data []byte
-	var err error
-	if ddi.Options.EnableCache {
-		data, err = ddi.getCachedData(LatestReleaseURL)
-		if err != nil {
-			return "", fmt.Errorf("getting latest version from cache: %w", err)
-		}
+	if ddi.Options.Offline {
+		logrus.Infof("Offline mode, using embedded %s license list as latest", DefaultCatalogOpts.Version)
+		return DefaultCatalogOpts.Version, nil
 	}
 
-	if data == nil {
-		data, err = http.NewAgent().Get(LatestReleaseURL)
-		if err != nil {
-			return "", err
-		}
-
-		if err := ddi.cacheData(LatestReleaseURL, data); err != nil {
-			return "", fmt.Errorf("caching latest version: %w", err)
-		}
+	data, err := ddi.fetch(LatestReleaseURL, false)
+	if err != nil {
+		return "", fmt.Errorf("getting latest release: %w", err)
 	}
 	type GHReleaseResp struct {
 		TagName string `json:"tag_name"`
@@ -251,23 +249,10 @@ func (ddi *DefaultDownloaderImpl) DownloadLicenseArchive(tag string) (zipData []
 		return f.ReadFile(fmt.Sprintf("data/license-list-%s.zip", tag))
 	}
 
-	link := BaseReleaseURL + tag + ".zip"
-	if ddi.Options.EnableCache {
-		zipData, err = ddi.getCachedData(link)
-		if err != nil {
-			return nil, fmt.Errorf("getting cached data: %w", err)
-		}
-	}
-
-	// No cached data available
-	if zipData == nil {
-		zipData, err = http.NewAgent().WithTimeout(time.Hour).Get(link)
-		if err != nil {
-			return nil, fmt.Errorf("downloading license tarball: %w", err)
-		}
-		if err := ddi.cacheData(link, zipData); err != nil {
-			return nil, fmt.Errorf("caching license list: %w", err)
-		}
+	// Release archives never change once published
+	zipData, err = ddi.fetch(BaseReleaseURL+tag+".zip", true)
+	if err != nil {
+		return nil, fmt.Errorf("license list %s: %w", tag, err)
 	}
 	return zipData, nil
 }
@@ -275,6 +260,14 @@ func (ddi *DefaultDownloaderImpl) DownloadLicenseArchive(tag string) (zipData []
 // GetLicenses downloads the main json file listing all SPDX supported licenses.
 func (ddi *DefaultDownloaderImpl) GetLicenses(tag string) (licenses *List, err error) {
 	zipData, err := ddi.DownloadLicenseArchive(tag)
+	if errors.Is(err, ErrOffline) {
+		logrus.Warnf(
+			"License list %s is not cached and downloads are disabled, using embedded %s list",
+			tag, DefaultCatalogOpts.Version,
+		)
+		tag = DefaultCatalogOpts.Version
+		zipData, err = ddi.DownloadLicenseArchive(tag)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("downloading licenses: %w", err)
 	}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package license
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// cacheMetadata is stored next to each cached download. It records the
+// HTTP validators used to revalidate the data and its checksum to detect
+// corrupt cache files. An empty checksum means the download was not
+// completed and a partial file may be resumed.
+type cacheMetadata struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	SHA256       string    `json:"sha256,omitempty"`
+	Checked      time.Time `json:"checked"`
+}
+
+// metadataFileName returns the path of the metadata file of a cached URL.
+func (ddi *DefaultDownloaderImpl) metadataFileName(url string) string {
+	return ddi.cacheFileName(url) + ".meta"
+}
+
+// partialFileName returns the path where an URL is downloaded to before
+// being moved into the cache.
+func (ddi *DefaultDownloaderImpl) partialFileName(url string) string {
+	return ddi.cacheFileName(url) + ".partial"
+}
+
+// readCacheMetadata returns the metadata of a cached URL, nil if there is none.
+func (ddi *DefaultDownloaderImpl) readCacheMetadata(url string) (*cacheMetadata, error) {
+	data, err := os.ReadFile(ddi.metadataFileName(url))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cache metadata: %w", err)
+	}
+	md := &cacheMetadata{}
+	if err := json.Unmarshal(data, md); err != nil {
+		logrus.Warnf("Ignoring invalid cache metadata for %s: %v", url, err)
+		return nil, nil
+	}
+	return md, nil
+}
+
+// writeCacheMetadata stores the metadata of a cached URL.
+func (ddi *DefaultDownloaderImpl) writeCacheMetadata(md *cacheMetadata) error {
+	data, err := json.Marshal(md)
+	if err != nil {
+		return fmt.Errorf("marshalling cache metadata: %w", err)
+	}
+	if err := os.WriteFile(ddi.metadataFileName(md.URL), data, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing cache metadata: %w", err)
+	}
+	return nil
+}
+
+// verifiedCachedData returns the cached data of an URL and its metadata.
+// Cached data not matching the checksum in its metadata is removed.
+func (ddi *DefaultDownloaderImpl) verifiedCachedData(url string) ([]byte, *cacheMetadata, error) {
+	md, err := ddi.readCacheMetadata(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := ddi.getCachedData(url)
+	if err != nil || data == nil {
+		return nil, md, err
+	}
+	// Data cached by older versions has no checksum, it is trusted as is
+	if md == nil || md.SHA256 == "" {
+		return data, md, nil
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != md.SHA256 {
+		logrus.Warnf("Checksum of cached data for %s does not match, discarding it", url)
+		if err := os.Remove(ddi.cacheFileName(url)); err != nil {
+			return nil, nil, fmt.Errorf("removing corrupt cached file: %w", err)
+		}
+		return nil, &cacheMetadata{URL: url}, nil
+	}
+	return data, md, nil
+}
+
+// fetch returns the contents of an URL, using the cache when enabled.
+//
+// Immutable URLs are served from the cache as long as the cached data
+// checksum is valid. Other URLs are revalidated using their ETag and
+// Last-Modified headers once the cached copy is older than CacheMaxAge.
+// Interrupted downloads are resumed from their partial file.
+func (ddi *DefaultDownloaderImpl) fetch(url string, immutable bool) ([]byte, error) {
+	if !ddi.Options.EnableCache {
+		if ddi.Options.Offline {
+			return nil, fmt.Errorf("%s: %w", url, ErrOffline)
+		}
+		return ddi.download(url, nil)
+	}
+
+	data, md, err := ddi.verifiedCachedData(url)
+	if err != nil {
+		return nil, fmt.Errorf("getting cached data: %w", err)
+	}
+
+	if data != nil {
+		if immutable || ddi.Options.Offline ||
+			(md != nil && time.Since(md.Checked) < ddi.Options.CacheMaxAge) {
+			return data, nil
+		}
+	} else if ddi.Options.Offline {
+		return nil, fmt.Errorf("%s: %w", url, ErrOffline)
+	}
+
+	switch {
+	case md == nil:
+		md = &cacheMetadata{URL: url}
+	case data == nil:
+		// Without cached data, only a partial download can be reused
+		md.SHA256 = ""
+	}
+	fresh, err := ddi.download(url, md)
+	if err != nil {
+		if data != nil {
+			logrus.Warnf("Unable to revalidate %s, using cached data: %v", url, err)
+			return data, nil
+		}
+		return nil, err
+	}
+	md.Checked = time.Now()
+
+	// A nil response means the cached data is still valid
+	if fresh == nil {
+		logrus.Debugf("Cached data for %s is up to date", url)
+		return data, ddi.writeCacheMetadata(md)
+	}
+
+	if err := ddi.cacheData(url, fresh); err != nil {
+		return nil, fmt.Errorf("caching data: %w", err)
+	}
+	sum := sha256.Sum256(fresh)
+	md.SHA256 = hex.EncodeToString(sum[:])
+	if err := ddi.writeCacheMetadata(md); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(ddi.partialFileName(url)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("removing partial download: %w", err)
+	}
+	return fresh, nil
+}
+
+// download gets the contents of an URL. When cache metadata is passed, the
+// request is made conditional on the recorded validators, returning nil
+// data if the server reports the cached copy is still valid, and the body
+// is written to a partial file that later calls resume from.
+func (ddi *DefaultDownloaderImpl) download(url string, md *cacheMetadata) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	var partial *os.File
+	if md != nil {
+		partial, err = os.OpenFile(ddi.partialFileName(url), os.O_CREATE|os.O_RDWR, os.FileMode(0o644))
+		if err != nil {
+			return nil, fmt.Errorf("opening partial download file: %w", err)
+		}
+		defer partial.Close()
+
+		offset, err := partial.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, fmt.Errorf("seeking partial download: %w", err)
+		}
+
+		switch {
+		case md.SHA256 == "" && offset > 0 && md.ETag != "":
+			// Resume the interrupted download of the same object
+			logrus.Infof("Resuming download of %s from byte %d", url, offset)
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.Header.Set("If-Range", md.ETag)
+		case md.SHA256 != "":
+			if md.ETag != "" {
+				req.Header.Set("If-None-Match", md.ETag)
+			}
+			if md.LastModified != "" {
+				req.Header.Set("If-Modified-Since", md.LastModified)
+			}
+		}
+	}
+
+	resp, err := (&http.Client{Timeout: time.Hour}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if md != nil && md.SHA256 != "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected HTTP status %d from %s", resp.StatusCode, url)
+	case http.StatusOK:
+		if partial != nil {
+			if err := partial.Truncate(0); err != nil {
+				return nil, fmt.Errorf("truncating partial download: %w", err)
+			}
+			if _, err := partial.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("rewinding partial download: %w", err)
+			}
+		}
+	case http.StatusPartialContent:
+		if partial == nil {
+			return nil, fmt.Errorf("unexpected HTTP status %d from %s", resp.StatusCode, url)
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		if partial == nil {
+			return nil, fmt.Errorf("unexpected HTTP status %d from %s", resp.StatusCode, url)
+		}
+		// The partial file does not match the object anymore, start over
+		logrus.Warnf("Unable to resume download of %s, restarting it", url)
+		if err := partial.Truncate(0); err != nil {
+			return nil, fmt.Errorf("truncating partial download: %w", err)
+		}
+		md.ETag = ""
+		return ddi.download(url, md)
+	default:
+		return nil, fmt.Errorf("HTTP status %d getting %s", resp.StatusCode, url)
+	}
+
+	if partial == nil {
+		return io.ReadAll(resp.Body)
+	}
+
+	// Record the validators before reading the body so an interrupted
+	// download can be resumed.
+	if resp.StatusCode == http.StatusOK {
+		md.ETag = resp.Header.Get("ETag")
+		md.LastModified = resp.Header.Get("Last-Modified")
+		md.SHA256 = ""
+		if err := ddi.writeCacheMetadata(md); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := io.Copy(partial, resp.Body); err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	if _, err := partial.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewinding partial download: %w", err)
+	}
+	return io.ReadAll(partial)
+}
@@ -35,9 +35,16 @@ type ReaderDefaultImpl struct {
 
 // ClassifyFile takes a file path and returns the most probable license tag.
 func (d *ReaderDefaultImpl) ClassifyFile(path string) (licenseTag string, moreTags []string, err error) {
+	licenseTag, moreTags, _, err = d.classifyFile(path)
+	return licenseTag, moreTags, err
+}
+
+// classifyFile returns the most probable license tag of a file and the
+// confidence of the classifier in the match.
+func (d *ReaderDefaultImpl) classifyFile(path string) (licenseTag string, moreTags []string, highestConf float64, err error) {
 	file, err := os.Open(path)
 	if err != nil {
-		return licenseTag, nil, fmt.Errorf("opening file for analysis: %w", err)
+		return licenseTag, nil, 0, fmt.Errorf("opening file for analysis: %w", err)
 	}
 	defer file.Close()
 
@@ -45,9 +52,8 @@ func (d *ReaderDefaultImpl) ClassifyFile(path string) (licenseTag string, moreTa
 	res, err := d.Classifier().MatchFrom(file)
 	if res.Matches.Len() == 0 {
 		logrus.Debugf("File does not match a known license: %s", path)
-		return "", moreTags, nil
+		return "", moreTags, 0, nil
 	}
-	var highestConf float64
 	moreTags = []string{}
 	allTags := map[string]struct{}{}
 	for _, match := range res.Matches {
@@ -70,7 +76,7 @@ func (d *ReaderDefaultImpl) ClassifyFile(path string) (licenseTag string, moreTa
 			moreTags = append(moreTags, t)
 		}
 	}
-	return licenseTag, moreTags, nil
+	return licenseTag, moreTags, highestConf, nil
 }
 
 // ClassifyLicenseFiles takes a list of paths and tries to find return all licenses found in it.
@@ -80,7 +86,7 @@ func (d *ReaderDefaultImpl) ClassifyLicenseFiles(paths []string) (
 	licenseList = []*ClassifyResult{}
 	// Run the files through the clasifier
 	for _, f := range paths {
-		label, _, err := d.ClassifyFile(f)
+		label, _, confidence, err := d.classifyFile(f)
 		if err != nil {
 			return nil, unrecognizedPaths, fmt.Errorf("classifying file: %w", err)
 		}
@@ -100,7 +106,9 @@ func (d *ReaderDefaultImpl) ClassifyLicenseFiles(paths []string) (
 			return nil, nil, fmt.Errorf("reading license text: %w", err)
 		}
 		// Apend to the return results
-		licenseList = append(licenseList, &ClassifyResult{f, string(licenseText), license})
+		licenseList = append(licenseList, &ClassifyResult{
+			File: f, Text: string(licenseText), License: license, Confidence: confidence,
+		})
 	}
 	if len(paths) != len(licenseList) {
 		logrus.Debugf(
@@ -182,6 +190,7 @@ func (d *ReaderDefaultImpl) Initialize(opts *ReaderOptions) error {
 	catalogOpts := DefaultCatalogOpts
 	catalogOpts.CacheDir = opts.CachePath()
 	catalogOpts.Version = opts.LicenseListVersion
+	catalogOpts.Offline = opts.Offline
 
 	catalog, err := NewCatalogWithOptions(catalogOpts)
 	if err != nil {
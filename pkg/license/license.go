@@ -106,6 +106,7 @@ type ReaderOptions struct {
 	CacheDir            string  // Optional directory where the reader will store its downloads cache
 	LicenseDir          string  // Optional dir to store and read the SPDX licenses from
 	LicenseListVersion  string  // Version of the SPDX license list to use
+	Offline             bool    // Never download license lists, use the embedded one
 }
 
 // Validate checks the options to verify the are sane.
@@ -248,11 +249,25 @@ func (r *Reader) ReadLicenses(path string) (
 	return licenseList, unknownPaths, nil
 }
 
+// ClassifyLicenseFiles runs a list of files through the classifier. It
+// returns the results of the files recognized as licenses and the paths
+// of those that were not.
+func (r *Reader) ClassifyLicenseFiles(paths []string) (
+	licenseList []*ClassifyResult, unknownPaths []string, err error,
+) {
+	licenseList, unknownPaths, err = r.impl.ClassifyLicenseFiles(paths)
+	if err != nil {
+		return nil, nil, fmt.Errorf("classifying license files: %w", err)
+	}
+	return licenseList, unknownPaths, nil
+}
+
 // ClassifyResult abstracts the data resulting from a file classification.
 type ClassifyResult struct {
-	File    string
-	Text    string
-	License *License
+	File       string
+	Text       string
+	License    *License
+	Confidence float64 // Confidence of the classifier in the match, from 0 to 1
 }
 
 //counterfeiter:generate . ReaderImplementation
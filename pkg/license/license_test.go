@@ -127,6 +127,33 @@ func TestUSPDXGetLicense(t *testing.T) {
 	require.Nil(t, testTicense)
 }
 
+func TestUSPDXCatalogSearch(t *testing.T) {
+	catalog, err := license.NewCatalogWithOptions(license.CatalogOptions{})
+	require.NoError(t, err)
+	catalog.List = &license.List{
+		Licenses: map[string]*license.License{
+			"MIT":          {LicenseID: "MIT", Name: "MIT License"},
+			"BSD-3-Clause": {LicenseID: "BSD-3-Clause", Name: `BSD 3-Clause "New" or "Revised" License`},
+			"BSD-2-Clause": {LicenseID: "BSD-2-Clause", Name: `BSD 2-Clause "Simplified" License`},
+			"eCos-2.0":     {LicenseID: "eCos-2.0", Name: "eCos license version 2.0", IsDeprecatedLicenseID: true},
+		},
+	}
+
+	require.Equal(t, []string{"BSD-2-Clause", "BSD-3-Clause", "MIT"}, catalog.LicenseIDs(false))
+	require.Len(t, catalog.LicenseIDs(true), 4)
+
+	res := catalog.Search("bsd")
+	require.Len(t, res, 2)
+	require.Equal(t, "BSD-2-Clause", res[0].LicenseID)
+
+	res = catalog.Search("revised")
+	require.Len(t, res, 1)
+	require.Equal(t, "BSD-3-Clause", res[0].LicenseID)
+
+	require.Len(t, catalog.Search("ecos"), 1)
+	require.Empty(t, catalog.Search("GPL"))
+}
+
 func TestUSPDXLicenseListAdd(t *testing.T) {
 	// Create a sample license
 	licenseList := &license.List{}
@@ -17,9 +17,14 @@ limitations under the License.
 package license
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -95,3 +100,103 @@ func TestFindLicenseFiles(t *testing.T) {
 	require.NotContains(t, res, filepath.Join(tempdir, "license.go"))
 	require.NotContains(t, res, filepath.Join(tempdir, "README.md"))
 }
+
+func TestListVersionTag(t *testing.T) {
+	embedded := strings.Join(strings.Split(DefaultCatalogOpts.Version[1:], ".")[:2], ".")
+	for _, tc := range []struct {
+		version  string
+		expected string
+	}{
+		{"", ""},
+		{"3.22", "v3.22"},
+		{"v3.22", "v3.22"},
+		{"3.24", "v3.24.0"},
+		{"3.25.1", "v3.25.1"},
+		{"3.19.2", "v3.19.2"},
+		{embedded, DefaultCatalogOpts.Version},
+		{"invalid", "vinvalid"},
+	} {
+		require.Equal(t, tc.expected, ListVersionTag(tc.version), tc.version)
+	}
+}
+
+func TestGetLicensesOffline(t *testing.T) {
+	opts := *DefaultDownloaderOpts
+	opts.EnableCache = false
+	opts.Offline = true
+	impl := DefaultDownloaderImpl{Options: &opts}
+
+	tag, err := impl.GetLatestTag()
+	require.NoError(t, err)
+	require.Equal(t, DefaultCatalogOpts.Version, tag)
+
+	_, err = impl.DownloadLicenseArchive("v3.10")
+	require.ErrorIs(t, err, ErrOffline)
+
+	// Lists not available offline fall back to the embedded one
+	list, err := impl.GetLicenses("v3.10")
+	require.NoError(t, err)
+	require.NotEmpty(t, list.Licenses)
+}
+
+func TestFetchCache(t *testing.T) {
+	content := []byte("SPDX license list data")
+	requests := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Get("If-None-Match")+"|"+r.Header.Get("Range"))
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	opts := *DefaultDownloaderOpts
+	opts.CacheDir = t.TempDir()
+	impl := DefaultDownloaderImpl{Options: &opts}
+	url := srv.URL + "/latest"
+
+	// The first download caches the data
+	data, err := impl.fetch(url, false)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.Len(t, requests, 1)
+
+	// Fresh data is served from the cache
+	data, err = impl.fetch(url, false)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.Len(t, requests, 1)
+
+	// Stale data is revalidated with its ETag
+	opts.CacheMaxAge = 0
+	data, err = impl.fetch(url, false)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.Equal(t, []string{"|", `"v1"|`}, requests)
+
+	// Corrupt cached data is downloaded again
+	require.NoError(t, os.WriteFile(impl.cacheFileName(url), []byte("corrupt"), 0o644))
+	data, err = impl.fetch(url, true)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.Len(t, requests, 3)
+	require.Equal(t, "|", requests[2])
+
+	// Interrupted downloads are resumed
+	require.NoError(t, os.Remove(impl.cacheFileName(url)))
+	require.NoError(t, os.WriteFile(impl.partialFileName(url), content[:4], 0o644))
+	data, err = impl.fetch(url, true)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	require.Len(t, requests, 4)
+	require.Equal(t, "|bytes=4-", requests[3])
+	require.NoFileExists(t, impl.partialFileName(url))
+
+	// Offline, only cached data is available
+	opts.Offline = true
+	data, err = impl.fetch(url, false)
+	require.NoError(t, err)
+	require.Equal(t, content, data)
+	_, err = impl.fetch(srv.URL+"/other", true)
+	require.ErrorIs(t, err, ErrOffline)
+	require.Len(t, requests, 4)
+}
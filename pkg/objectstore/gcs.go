@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// gcsEndpoint returns the base URL of the Cloud Storage API. When the
+// STORAGE_EMULATOR_HOST variable is set, the emulator is used instead.
+func gcsEndpoint() (endpoint string, emulated bool) {
+	if host := os.Getenv("STORAGE_EMULATOR_HOST"); host != "" {
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+		return strings.TrimSuffix(host, "/"), true
+	}
+	return "https://storage.googleapis.com", false
+}
+
+// putGCS writes an object to Cloud Storage with a media upload.
+func putGCS(ctx context.Context, obj *Object, data []byte, contentType string) error {
+	endpoint, emulated := gcsEndpoint()
+	uploadURL := fmt.Sprintf(
+		"%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		endpoint, url.PathEscape(obj.Bucket), url.QueryEscape(obj.Key),
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("creating Cloud Storage request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	client := http.DefaultClient
+	if !emulated {
+		ts, err := gcsTokenSource(ctx)
+		if err != nil {
+			return err
+		}
+		client = oauth2.NewClient(ctx, ts)
+	}
+
+	if _, err := fetch(client, req); err != nil {
+		return fmt.Errorf("writing %s: %w", obj, err)
+	}
+	return nil
+}
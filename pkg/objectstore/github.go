@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultGitHubAPI is the endpoint of the GitHub REST API
+const DefaultGitHubAPI = "https://api.github.com"
+
+// GitHubRelease identifies a release of a GitHub repository
+type GitHubRelease struct {
+	Owner string
+	Repo  string
+	Tag   string
+}
+
+// GitHubOptions control how assets are published to a release.
+type GitHubOptions struct {
+	Token  string       // GitHub token with write access to the repository
+	APIURL string       // Defaults to DefaultGitHubAPI, set for GitHub Enterprise
+	Create bool         // Create the release if it does not exist
+	Client *http.Client // Client to send the requests, defaults to http.DefaultClient
+}
+
+// Asset is a file to attach to a release
+type Asset struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// githubReleaseData is the subset of the release API object bom uses
+type githubReleaseData struct {
+	ID        int64  `json:"id"`
+	UploadURL string `json:"upload_url"`
+	Assets    []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	} `json:"assets"`
+}
+
+// ParseGitHubRelease parses a release in the form owner/repo@tag
+func ParseGitHubRelease(spec string) (*GitHubRelease, error) {
+	repo, tag, ok := strings.Cut(spec, "@")
+	if !ok || tag == "" {
+		return nil, fmt.Errorf("invalid GitHub release %q, must be owner/repo@tag", spec)
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid GitHub repository %q, must be owner/repo", repo)
+	}
+	return &GitHubRelease{Owner: owner, Repo: name, Tag: tag}, nil
+}
+
+func (rel *GitHubRelease) String() string {
+	return fmt.Sprintf("%s/%s@%s", rel.Owner, rel.Repo, rel.Tag)
+}
+
+// PublishGitHubRelease uploads the assets to the release, replacing any
+// asset with the same name already attached to it.
+func PublishGitHubRelease(ctx context.Context, rel *GitHubRelease, assets []Asset, opts *GitHubOptions) error {
+	gh := &githubClient{opts: opts, api: strings.TrimSuffix(opts.APIURL, "/")}
+	if gh.api == "" {
+		gh.api = DefaultGitHubAPI
+	}
+	gh.client = opts.Client
+	if gh.client == nil {
+		gh.client = http.DefaultClient
+	}
+
+	release, err := gh.release(ctx, rel)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		for _, existing := range release.Assets {
+			if existing.Name != asset.Name {
+				continue
+			}
+			logrus.Infof("Replacing asset %s of release %s", asset.Name, rel)
+			if _, err := gh.do(ctx, http.MethodDelete, fmt.Sprintf(
+				"%s/repos/%s/%s/releases/assets/%d", gh.api, rel.Owner, rel.Repo, existing.ID,
+			), "", nil); err != nil {
+				return fmt.Errorf("deleting asset %s: %w", asset.Name, err)
+			}
+		}
+
+		// The upload URL is a template ending in {?name,label}
+		uploadURL, _, _ := strings.Cut(release.UploadURL, "{")
+		contentType := asset.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		if _, err := gh.do(
+			ctx, http.MethodPost, uploadURL+"?name="+url.QueryEscape(asset.Name), contentType, asset.Data,
+		); err != nil {
+			return fmt.Errorf("uploading asset %s: %w", asset.Name, err)
+		}
+		logrus.Infof("Uploaded %s to release %s", asset.Name, rel)
+	}
+	return nil
+}
+
+// githubClient sends the requests to the GitHub API
+type githubClient struct {
+	opts   *GitHubOptions
+	api    string
+	client *http.Client
+}
+
+// errGitHubNotFound is returned when the API responds with a 404
+var errGitHubNotFound = errors.New("not found")
+
+// release looks up the release of the tag, creating it when
+// the options allow it.
+func (gh *githubClient) release(ctx context.Context, rel *GitHubRelease) (*githubReleaseData, error) {
+	body, err := gh.do(ctx, http.MethodGet, fmt.Sprintf(
+		"%s/repos/%s/%s/releases/tags/%s", gh.api, rel.Owner, rel.Repo, url.PathEscape(rel.Tag),
+	), "", nil)
+	switch {
+	case errors.Is(err, errGitHubNotFound) && gh.opts.Create:
+		logrus.Infof("Creating GitHub release %s", rel)
+		payload, err := json.Marshal(map[string]string{"tag_name": rel.Tag, "name": rel.Tag})
+		if err != nil {
+			return nil, fmt.Errorf("encoding release: %w", err)
+		}
+		body, err = gh.do(ctx, http.MethodPost, fmt.Sprintf(
+			"%s/repos/%s/%s/releases", gh.api, rel.Owner, rel.Repo,
+		), "application/json", payload)
+		if err != nil {
+			return nil, fmt.Errorf("creating release %s: %w", rel, err)
+		}
+	case errors.Is(err, errGitHubNotFound):
+		return nil, fmt.Errorf("release %s does not exist", rel)
+	case err != nil:
+		return nil, fmt.Errorf("looking up release %s: %w", rel, err)
+	}
+
+	release := &githubReleaseData{}
+	if err := json.Unmarshal(body, release); err != nil {
+		return nil, fmt.Errorf("decoding release %s: %w", rel, err)
+	}
+	if release.UploadURL == "" {
+		return nil, fmt.Errorf("release %s has no upload URL", rel)
+	}
+	return release, nil
+}
+
+// do sends a request to the API and returns the response body
+func (gh *githubClient) do(ctx context.Context, method, u, contentType string, data []byte) ([]byte, error) {
+	var payload io.Reader
+	if data != nil {
+		payload = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, payload)
+	if err != nil {
+		return nil, fmt.Errorf("creating GitHub request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if gh.opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+gh.opts.Token)
+	}
+
+	resp, err := gh.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request to GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading GitHub response: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errGitHubNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GitHub returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitHubRelease(t *testing.T) {
+	for _, tc := range []struct {
+		spec      string
+		expected  *GitHubRelease
+		shouldErr bool
+	}{
+		{"kubernetes-sigs/bom@v0.7.0", &GitHubRelease{Owner: "kubernetes-sigs", Repo: "bom", Tag: "v0.7.0"}, false},
+		{"kubernetes/kubernetes@v1.32.0-rc.1", &GitHubRelease{Owner: "kubernetes", Repo: "kubernetes", Tag: "v1.32.0-rc.1"}, false},
+		{"kubernetes-sigs/bom", nil, true},
+		{"kubernetes-sigs/bom@", nil, true},
+		{"bom@v0.7.0", nil, true},
+		{"/bom@v0.7.0", nil, true},
+		{"kubernetes-sigs/bom/extra@v0.7.0", nil, true},
+	} {
+		rel, err := ParseGitHubRelease(tc.spec)
+		if tc.shouldErr {
+			require.Error(t, err, tc.spec)
+			continue
+		}
+		require.NoError(t, err, tc.spec)
+		require.Equal(t, tc.expected, rel)
+		require.Equal(t, tc.spec, rel.String())
+	}
+}
+
+func TestPublishGitHubRelease(t *testing.T) {
+	var srv *httptest.Server
+	calls := []string{}
+	uploaded := map[string]string{}
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		release := fmt.Sprintf(
+			`{"id": 1, "upload_url": "%s/uploads/repos/o/r/releases/1/assets{?name,label}", "assets": [{"id": 7, "name": "sbom.spdx.json"}]}`,
+			srv.URL,
+		)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/o/r/releases/tags/v1.0.0":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST /repos/o/r/releases":
+			payload := map[string]string{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			require.Equal(t, "v1.0.0", payload["tag_name"])
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(release)) //nolint:errcheck
+		case "DELETE /repos/o/r/releases/assets/7":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /uploads/repos/o/r/releases/1/assets":
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			uploaded[r.URL.Query().Get("name")] = r.Header.Get("Content-Type") + " " + string(data)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	rel := &GitHubRelease{Owner: "o", Repo: "r", Tag: "v1.0.0"}
+	assets := []Asset{
+		{Name: "sbom.spdx.json", ContentType: "application/spdx+json", Data: []byte("{}")},
+		{Name: "sbom.intoto.json", Data: []byte("[]")},
+	}
+
+	// Without Create, a missing release is an error
+	err := PublishGitHubRelease(context.Background(), rel, assets, &GitHubOptions{Token: "secret", APIURL: srv.URL})
+	require.Error(t, err)
+	require.Empty(t, uploaded)
+
+	calls = []string{}
+	require.NoError(t, PublishGitHubRelease(
+		context.Background(), rel, assets, &GitHubOptions{Token: "secret", APIURL: srv.URL + "/", Create: true},
+	))
+	require.Equal(t, []string{
+		"GET /repos/o/r/releases/tags/v1.0.0",
+		"POST /repos/o/r/releases",
+		"DELETE /repos/o/r/releases/assets/7",
+		"POST /uploads/repos/o/r/releases/1/assets",
+		"POST /uploads/repos/o/r/releases/1/assets",
+	}, calls)
+	require.Equal(t, map[string]string{
+		"sbom.spdx.json":   "application/spdx+json {}",
+		"sbom.intoto.json": "application/octet-stream []",
+	}, uploaded)
+}
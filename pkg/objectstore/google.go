@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// gcsScope is the OAuth scope requested to write objects
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// gcsTokenSource returns the source of the OAuth tokens to write to
+// Cloud Storage. The token in GOOGLE_OAUTH_ACCESS_TOKEN (eg the output of
+// gcloud auth print-access-token) is used when set, otherwise the
+// application default credentials are looked up.
+func gcsTokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}), nil
+	}
+	creds, err := google.FindDefaultCredentials(ctx, gcsScope)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"no Google credentials found (set GOOGLE_APPLICATION_CREDENTIALS or GOOGLE_OAUTH_ACCESS_TOKEN outside of Google Cloud): %w", err,
+		)
+	}
+	return creds.TokenSource, nil
+}
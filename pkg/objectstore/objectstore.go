@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectstore publishes generated documents: it writes them to
+// cloud storage buckets using the credentials available in the
+// environment, uploads them to SBOM management services and attaches
+// them to GitHub releases, so pipelines don't need a separate uploader.
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Supported object storage schemes
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+)
+
+// Object is the location of an object in a bucket.
+type Object struct {
+	Scheme string // s3 | gs
+	Bucket string
+	Key    string
+}
+
+// String returns the URL of the object.
+func (o *Object) String() string {
+	return o.Scheme + "://" + o.Bucket + "/" + o.Key
+}
+
+// IsURL returns true if path is an object storage URL.
+func IsURL(path string) bool {
+	return strings.HasPrefix(path, SchemeS3+"://") || strings.HasPrefix(path, SchemeGCS+"://")
+}
+
+// ParseURL parses an object URL in the form s3://bucket/key or
+// gs://bucket/key.
+func ParseURL(objectURL string) (*Object, error) {
+	u, err := url.Parse(objectURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing object URL: %w", err)
+	}
+	if u.Scheme != SchemeS3 && u.Scheme != SchemeGCS {
+		return nil, fmt.Errorf("unsupported object storage scheme %q, must be s3 or gs", u.Scheme)
+	}
+	obj := &Object{Scheme: u.Scheme, Bucket: u.Host, Key: strings.TrimPrefix(u.Path, "/")}
+	if obj.Bucket == "" || obj.Key == "" || strings.HasSuffix(obj.Key, "/") {
+		return nil, fmt.Errorf("object URL %q must include a bucket and an object name", objectURL)
+	}
+	return obj, nil
+}
+
+// Put writes data to the object, recording its content type.
+func Put(ctx context.Context, obj *Object, data []byte, contentType string) error {
+	switch obj.Scheme {
+	case SchemeS3:
+		return putS3(ctx, obj, data, contentType)
+	case SchemeGCS:
+		return putGCS(ctx, obj, data, contentType)
+	}
+	return fmt.Errorf("unsupported object storage scheme %q", obj.Scheme)
+}
+
+// fetch sends a request and returns the body of a successful response.
+func fetch(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096)) //nolint:errcheck
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return body, nil
+}
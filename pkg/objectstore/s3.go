@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3DefaultRegion is used when no region is configured in the
+// environment or the active profile
+const s3DefaultRegion = "us-east-1"
+
+// s3Client returns an S3 client configured like the AWS CLI: the
+// credentials, region and endpoint are read from the environment, the
+// shared config files, web identity tokens and the container and
+// instance metadata. Custom endpoints (eg MinIO) set in
+// AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL use path style URLs.
+func s3Client(ctx context.Context) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = s3DefaultRegion
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = os.Getenv("AWS_ENDPOINT_URL_S3") != "" || os.Getenv("AWS_ENDPOINT_URL") != ""
+	}), nil
+}
+
+// putS3 writes an object to S3.
+func putS3(ctx context.Context, obj *Object, data []byte, contentType string) error {
+	client, err := s3Client(ctx)
+	if err != nil {
+		return err
+	}
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(obj.Bucket),
+		Key:    aws.String(obj.Key),
+		Body:   bytes.NewReader(data),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	if _, err := client.PutObject(ctx, input); err != nil {
+		return fmt.Errorf("writing %s: %w", obj, err)
+	}
+	return nil
+}
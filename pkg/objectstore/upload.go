@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Kinds of upload targets
+const (
+	TargetDependencyTrack = "dependency-track"
+	TargetGUAC            = "guac"
+)
+
+// UploadTargetKinds lists the supported kinds of targets
+var UploadTargetKinds = []string{TargetDependencyTrack, TargetGUAC}
+
+// UploadTarget is a service where documents are published.
+type UploadTarget struct {
+	Kind string // dependency-track | guac
+	URL  string // Dependency-Track server or GUAC collector endpoint
+}
+
+// UploadOptions control how documents are uploaded.
+type UploadOptions struct {
+	Token          string       // Dependency-Track API key or GUAC bearer token
+	ContentType    string       // Media type of the document
+	ProjectName    string       // Dependency-Track project, created if it does not exist
+	ProjectVersion string       // Version of the Dependency-Track project
+	Client         *http.Client // Client to send the requests, defaults to http.DefaultClient
+}
+
+// ParseUploadTarget parses a target definition in the form kind=url, for
+// example dependency-track=https://dtrack.example.com
+func ParseUploadTarget(spec string) (*UploadTarget, error) {
+	kind, u, ok := strings.Cut(spec, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid upload target %q, must be kind=url", spec)
+	}
+	if !slices.Contains(UploadTargetKinds, kind) {
+		return nil, fmt.Errorf("unknown upload target kind %q, valid values are: %v", kind, UploadTargetKinds)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("parsing upload target URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("upload target URL %q must be http or https", u)
+	}
+	return &UploadTarget{Kind: kind, URL: u}, nil
+}
+
+// Upload publishes a serialized document to the target. Documents
+// uploaded to Dependency-Track must be CycloneDX JSON BOMs, GUAC
+// collectors take documents in any format.
+func Upload(ctx context.Context, target *UploadTarget, data []byte, opts *UploadOptions) error {
+	var req *http.Request
+	var err error
+	switch target.Kind {
+	case TargetDependencyTrack:
+		req, err = dependencyTrackRequest(ctx, target, data, opts)
+	case TargetGUAC:
+		req, err = guacRequest(ctx, target, data, opts)
+	default:
+		return fmt.Errorf("unknown upload target kind %q", target.Kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("uploading document to %s: %w", target.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return fmt.Errorf("reading %s response: %w", target.Kind, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf(
+			"%s rejected the document: %s: %s",
+			target.Kind, resp.Status, strings.TrimSpace(string(body)),
+		)
+	}
+
+	if target.Kind == TargetDependencyTrack {
+		// Dependency-Track processes the documents asynchronously
+		res := struct {
+			Token string `json:"token"`
+		}{}
+		if err := json.Unmarshal(body, &res); err == nil && res.Token != "" {
+			logrus.Infof("Dependency-Track is processing the document, token %s", res.Token)
+		}
+	}
+	logrus.Infof("Uploaded document to %s", target.URL)
+	return nil
+}
+
+// dependencyTrackRequest builds a request to upload a document to the
+// BOM endpoint of the Dependency-Track API. Dependency-Track only ingests
+// CycloneDX BOMs, other documents are rejected before uploading them.
+func dependencyTrackRequest(
+	ctx context.Context, target *UploadTarget, data []byte, opts *UploadOptions,
+) (*http.Request, error) {
+	if opts.ProjectName == "" {
+		return nil, errors.New("uploading to Dependency-Track requires a project name")
+	}
+	bom := struct {
+		BOMFormat string `json:"bomFormat"`
+	}{}
+	if err := json.Unmarshal(data, &bom); err != nil || bom.BOMFormat != "CycloneDX" {
+		return nil, errors.New("only CycloneDX JSON documents can be uploaded to Dependency-Track")
+	}
+	payload, err := json.Marshal(struct {
+		ProjectName    string `json:"projectName"`
+		ProjectVersion string `json:"projectVersion,omitempty"`
+		AutoCreate     bool   `json:"autoCreate"`
+		BOM            string `json:"bom"`
+	}{
+		ProjectName:    opts.ProjectName,
+		ProjectVersion: opts.ProjectVersion,
+		AutoCreate:     true,
+		BOM:            base64.StdEncoding.EncodeToString(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding Dependency-Track payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPut, strings.TrimSuffix(target.URL, "/")+"/api/v1/bom", bytes.NewReader(payload),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating Dependency-Track request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if opts.Token != "" {
+		req.Header.Set("X-Api-Key", opts.Token)
+	}
+	return req, nil
+}
+
+// guacRequest builds a request to post a document to a GUAC collector.
+func guacRequest(ctx context.Context, target *UploadTarget, data []byte, opts *UploadOptions) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("creating GUAC request: %w", err)
+	}
+	if opts.ContentType != "" {
+		req.Header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+	return req, nil
+}
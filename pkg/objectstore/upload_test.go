@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	for _, tc := range []struct {
+		spec      string
+		expected  *UploadTarget
+		shouldErr bool
+	}{
+		{"dependency-track=https://dtrack.example.com", &UploadTarget{Kind: TargetDependencyTrack, URL: "https://dtrack.example.com"}, false},
+		{"guac=http://localhost:8080/collect", &UploadTarget{Kind: TargetGUAC, URL: "http://localhost:8080/collect"}, false},
+		{"https://dtrack.example.com", nil, true},
+		{"artifactory=https://example.com", nil, true},
+		{"guac=ftp://example.com", nil, true},
+		{"guac=", nil, true},
+	} {
+		target, err := ParseUploadTarget(tc.spec)
+		if tc.shouldErr {
+			require.Error(t, err, tc.spec)
+			continue
+		}
+		require.NoError(t, err, tc.spec)
+		require.Equal(t, tc.expected, target)
+	}
+}
+
+func TestUploadDependencyTrack(t *testing.T) {
+	document := []byte(`{"bomFormat": "CycloneDX", "specVersion": "1.5", "version": 1}`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/api/v1/bom", r.URL.Path)
+		require.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+		payload := struct {
+			ProjectName    string `json:"projectName"`
+			ProjectVersion string `json:"projectVersion"`
+			AutoCreate     bool   `json:"autoCreate"`
+			BOM            string `json:"bom"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.Equal(t, "my-project", payload.ProjectName)
+		require.Equal(t, "v1.0.0", payload.ProjectVersion)
+		require.True(t, payload.AutoCreate)
+		data, err := base64.StdEncoding.DecodeString(payload.BOM)
+		require.NoError(t, err)
+		// Dependency-Track rejects the documents it cannot ingest
+		bom := struct {
+			BOMFormat string `json:"bomFormat"`
+		}{}
+		if err := json.Unmarshal(data, &bom); err != nil || bom.BOMFormat != "CycloneDX" {
+			http.Error(w, "The uploaded BOM is invalid", http.StatusBadRequest)
+			return
+		}
+		require.Equal(t, document, data)
+
+		w.Write([]byte(`{"token": "1234"}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	opts := &UploadOptions{Token: "secret", ProjectName: "my-project", ProjectVersion: "v1.0.0"}
+	target := &UploadTarget{Kind: TargetDependencyTrack, URL: srv.URL + "/"}
+	require.NoError(t, Upload(context.Background(), target, document, opts))
+
+	// A project is required
+	require.Error(t, Upload(context.Background(), target, document, &UploadOptions{}))
+
+	// SPDX documents are not uploaded
+	err := Upload(context.Background(), target, []byte(`{"spdxVersion": "SPDX-2.3"}`), opts)
+	require.ErrorContains(t, err, "CycloneDX")
+}
+
+func TestUploadGUAC(t *testing.T) {
+	document := []byte("SPDXVersion: SPDX-2.3\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/collect", r.URL.Path)
+		require.Equal(t, "text/spdx", r.Header.Get("Content-Type"))
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, document, data)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	target := &UploadTarget{Kind: TargetGUAC, URL: srv.URL + "/collect"}
+	require.NoError(t, Upload(context.Background(), target, document, &UploadOptions{Token: "secret", ContentType: "text/spdx"}))
+
+	err := Upload(context.Background(), target, document, &UploadOptions{Token: "wrong"})
+	require.ErrorContains(t, err, "invalid token")
+}
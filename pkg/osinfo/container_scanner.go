@@ -18,10 +18,13 @@ package osinfo
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	purl "github.com/package-url/packageurl-go"
 	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/release-utils/util"
 )
 
 const (
@@ -49,20 +52,15 @@ func ReadOSPackages(layers []string) (
 	ls := newLayerScanner()
 
 	// First, let's try to determine which OS the container is based on
-	osKind := OSType("")
-	osInfoLayerNum := 0
-	for i, lp := range layers {
-		exists, err := ls.FileExistsInTar(lp, OsReleasePath, AltOSReleasePath)
-		if err != nil {
-			return 0, nil, fmt.Errorf("checking if file exists in layer: %w", err)
-		}
-		if exists {
-			logrus.Debugf(" > found os-release in layer %d", i)
-			osInfoLayerNum = i
-		}
+	osInfoLayerNum, err := osReleaseLayer(ls, layers)
+	if err != nil {
+		return 0, nil, err
+	}
+	if osInfoLayerNum < 0 {
+		return 0, nil, nil
 	}
 
-	osKind, err = ls.OSType(layers[osInfoLayerNum])
+	osKind, err := ls.OSType(layers[osInfoLayerNum])
 	if err != nil {
 		return 0, nil, fmt.Errorf("reading os type from layer: %w", err)
 	}
@@ -85,6 +83,93 @@ func ReadOSPackages(layers []string) (
 	return layerNum, packages, err
 }
 
+// osReleaseLayer returns the index of the last layer that writes the
+// os-release file, or -1 if none of the layers has it.
+func osReleaseLayer(ls layerScanner, layers []string) (int, error) {
+	layerNum := -1
+	for i, lp := range layers {
+		exists, err := ls.FileExistsInTar(lp, OsReleasePath, AltOSReleasePath)
+		if err != nil {
+			return 0, fmt.Errorf("checking if file exists in layer: %w", err)
+		}
+		if exists {
+			logrus.Debugf(" > found os-release in layer %d", i)
+			layerNum = i
+		}
+	}
+	return layerNum, nil
+}
+
+// ReadAppPackages reads the flatpak and snap applications installed in
+// a set of image layers. It returns the packages found and the last
+// layer where their metadata was modified. If no applications are found,
+// it returns a nil pointer.
+func ReadAppPackages(layers []string) (
+	layerNum int, packages *[]PackageDBEntry, err error,
+) {
+	tmpDir, err := os.MkdirTemp("", "app-metadata-")
+	if err != nil {
+		return 0, nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ls := newLayerScanner()
+	found := false
+	for i, lp := range layers {
+		extracted, err := ls.ExtractMatchingFromTar(lp, func(filePath string) bool {
+			return filePath == snapStatePath || isFlatpakMetadataPath(filePath)
+		}, tmpDir)
+		if err != nil {
+			return 0, nil, fmt.Errorf("extracting application metadata from layer: %w", err)
+		}
+		if extracted {
+			logrus.Debugf(" > found application metadata in layer %d", i)
+			layerNum = i
+			found = true
+		}
+	}
+	if !found {
+		return 0, nil, nil
+	}
+
+	packages, err = readAppPackages(
+		filepath.Join(tmpDir, filepath.FromSlash(flatpakDir)),
+		filepath.Join(tmpDir, filepath.FromSlash(snapStatePath)), "",
+	)
+	return layerNum, packages, err
+}
+
+// readAppPackages reads the applications from a flatpak installation
+// directory and a snapd state file. Snaps mounted under mountDir get
+// their metadata read from their snap.yaml. Returns nil if no
+// applications are found.
+func readAppPackages(flatpakPath, statePath, mountDir string) (*[]PackageDBEntry, error) {
+	packages := []PackageDBEntry{}
+
+	flatpaks, err := parseFlatpakInstallation(flatpakPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading flatpak installation: %w", err)
+	}
+	if flatpaks != nil {
+		packages = append(packages, *flatpaks...)
+	}
+
+	if util.Exists(statePath) {
+		snaps, err := parseSnapState(statePath, mountDir)
+		if err != nil {
+			return nil, fmt.Errorf("reading snaps: %w", err)
+		}
+		if snaps != nil {
+			packages = append(packages, *snaps...)
+		}
+	}
+
+	if len(packages) == 0 {
+		return nil, nil
+	}
+	return &packages, nil
+}
+
 // setPurlData stamps al found packages with the purl type and NS.
 func setPurlData(ptype, pnamespace string, packages *[]PackageDBEntry) {
 	if packages == nil {
@@ -39,3 +39,15 @@ func TestReadOSPackages(t *testing.T) {
 	_, _, err = ReadOSPackages([]string{"testdata/nonexistent"})
 	require.Error(t, err)
 }
+
+func BenchmarkReadOSPackages(b *testing.B) {
+	layers := []string{
+		"testdata/link-with-no-dots.tar.gz",
+		"testdata/dpkg-layer1.tar.gz",
+	}
+	for range b.N {
+		if _, _, err := ReadOSPackages(layers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
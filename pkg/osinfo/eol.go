@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OSRelease holds the identification fields of an os-release file
+type OSRelease struct {
+	ID         string // Lowercase OS identifier (debian, ubuntu, alpine)
+	Name       string // Name of the OS (Debian GNU/Linux)
+	VersionID  string // Version of the release (12, 22.04, 3.19.1)
+	PrettyName string // Full name of the release for display
+}
+
+// endOfLifeDates lists the dates when releases stop receiving security
+// updates, keyed by os-release ID and release version. Debian dates are
+// the end of its LTS support, Ubuntu dates the end of standard support.
+var endOfLifeDates = map[string]map[string]string{
+	"debian": {
+		"6":  "2016-02-29",
+		"7":  "2018-05-31",
+		"8":  "2020-06-30",
+		"9":  "2022-06-30",
+		"10": "2024-06-30",
+		"11": "2026-08-31",
+		"12": "2028-06-30",
+		"13": "2030-06-30",
+	},
+	"ubuntu": {
+		"14.04": "2019-04-25",
+		"16.04": "2021-04-30",
+		"18.04": "2023-05-31",
+		"20.04": "2025-05-29",
+		"22.04": "2027-06-01",
+		"23.04": "2024-01-25",
+		"23.10": "2024-07-11",
+		"24.04": "2029-05-31",
+		"24.10": "2025-07-10",
+		"25.04": "2026-01-15",
+	},
+	"alpine": {
+		"3.7":  "2019-11-01",
+		"3.8":  "2020-05-01",
+		"3.9":  "2020-11-01",
+		"3.10": "2021-05-01",
+		"3.11": "2021-11-01",
+		"3.12": "2022-05-01",
+		"3.13": "2022-11-01",
+		"3.14": "2023-05-01",
+		"3.15": "2023-11-01",
+		"3.16": "2024-05-23",
+		"3.17": "2024-11-22",
+		"3.18": "2025-05-09",
+		"3.19": "2025-11-01",
+		"3.20": "2026-04-01",
+		"3.21": "2026-11-01",
+		"3.22": "2027-05-01",
+	},
+	"centos": {
+		"6": "2020-11-30",
+		"7": "2024-06-30",
+		"8": "2021-12-31",
+	},
+	"centos-stream": {
+		"8": "2024-05-31",
+		"9": "2027-05-31",
+	},
+}
+
+// ParseOSRelease reads the identification fields from the contents of
+// an os-release file.
+func ParseOSRelease(data string) *OSRelease {
+	r := &OSRelease{}
+	s := bufio.NewScanner(strings.NewReader(data))
+	for s.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(s.Text()), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			r.ID = value
+		case "NAME":
+			r.Name = value
+		case "VERSION_ID":
+			r.VersionID = value
+		case "PRETTY_NAME":
+			r.PrettyName = value
+		}
+	}
+	return r
+}
+
+// String returns the name of the release
+func (r *OSRelease) String() string {
+	if r.PrettyName != "" {
+		return r.PrettyName
+	}
+	return strings.TrimSpace(r.Name + " " + r.VersionID)
+}
+
+// EndOfLife returns the date when the release stops receiving security
+// updates. The boolean is false when the release is not in the table.
+func (r *OSRelease) EndOfLife() (time.Time, bool) {
+	id := r.ID
+	version := r.VersionID
+	switch id {
+	case "alpine":
+		// Alpine point releases share the support of their branch
+		if parts := strings.SplitN(version, ".", 3); len(parts) > 1 {
+			version = parts[0] + "." + parts[1]
+		}
+	case "centos":
+		if strings.Contains(r.Name, "Stream") {
+			id = "centos-stream"
+		}
+	}
+	date, ok := endOfLifeDates[id][version]
+	if !ok {
+		return time.Time{}, false
+	}
+	eol, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return eol, true
+}
+
+// ReadOSRelease reads the os-release file of the OS in a set of image
+// layers. It returns nil if no layer has the file.
+func ReadOSRelease(layers []string) (*OSRelease, error) {
+	ls := newLayerScanner()
+	layerNum, err := osReleaseLayer(ls, layers)
+	if err != nil {
+		return nil, err
+	}
+	if layerNum < 0 {
+		return nil, nil
+	}
+	data, err := ls.OSReleaseData(layers[layerNum])
+	if err != nil {
+		return nil, fmt.Errorf("reading os release: %w", err)
+	}
+	return ParseOSRelease(data), nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSReleaseEndOfLife(t *testing.T) {
+	for _, tc := range []struct {
+		osrelease string
+		name      string
+		eol       string
+	}{
+		{
+			osrelease: "PRETTY_NAME=\"Debian GNU/Linux 11 (bullseye)\"\nNAME=\"Debian GNU/Linux\"\nVERSION_ID=\"11\"\nID=debian\n",
+			name:      "Debian GNU/Linux 11 (bullseye)",
+			eol:       "2026-08-31",
+		},
+		{
+			osrelease: "NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID=3.18.4\n",
+			name:      "Alpine Linux 3.18.4",
+			eol:       "2025-05-09",
+		},
+		{
+			osrelease: "NAME=\"CentOS Stream\"\nVERSION_ID=\"8\"\nID=\"centos\"\n",
+			name:      "CentOS Stream 8",
+			eol:       "2024-05-31",
+		},
+		{
+			osrelease: "NAME=\"CentOS Linux\"\nVERSION_ID=\"8\"\nID=\"centos\"\n",
+			name:      "CentOS Linux 8",
+			eol:       "2021-12-31",
+		},
+		{
+			osrelease: "NAME=\"Wolfi\"\nID=wolfi\nVERSION_ID=20230201\n",
+			name:      "Wolfi 20230201",
+		},
+	} {
+		r := ParseOSRelease(tc.osrelease)
+		require.Equal(t, tc.name, r.String())
+		eol, ok := r.EndOfLife()
+		if tc.eol == "" {
+			require.False(t, ok)
+			continue
+		}
+		require.True(t, ok)
+		require.Equal(t, tc.eol, eol.Format(time.DateOnly))
+	}
+}
+
+func TestReadOSRelease(t *testing.T) {
+	r, err := ReadOSRelease([]string{
+		"testdata/link-with-no-dots.tar.gz",
+		"testdata/dpkg-layer1.tar.gz",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "debian", r.ID)
+	require.Equal(t, "10", r.VersionID)
+
+	r, err = ReadOSRelease([]string{"testdata/dpkg-layer1.tar.gz"})
+	require.NoError(t, err)
+	require.Nil(t, r)
+}
@@ -52,6 +52,7 @@ type layerScanner interface {
 	ExtractFileFromTar(tarPath, filePath, destPath string) error
 	FileExistsInTar(tarPath, filePath string, moreFiles ...string) (bool, error)
 	ExtractDirectoryFromTar(tarPath, dirName, destPath string) error
+	ExtractMatchingFromTar(tarPath string, match func(filePath string) bool, destPath string) (bool, error)
 }
 
 // newLayerScanner returns a LayerScanner.
@@ -73,8 +74,14 @@ func (loss *layerOSScanner) OSType(layerPath string) (ostype OSType, err error)
 		return "", fmt.Errorf("reading os release: %w", err)
 	}
 
+	return osTypeFromRelease(osrelease), nil
+}
+
+// osTypeFromRelease determines the OS type from the contents of an
+// os-release file. Returns an empty string if the OS is not recognized.
+func osTypeFromRelease(osrelease string) OSType {
 	if osrelease == "" {
-		return "", nil
+		return ""
 	}
 
 	logrus.Debugf("OS Info Contents:\n%s", osrelease)
@@ -83,43 +90,43 @@ func (loss *layerOSScanner) OSType(layerPath string) (ostype OSType, err error)
 	// the distroless moniker before reading the name.
 	if strings.Contains(osrelease, "PRETTY_NAME=\"Distroless") {
 		logrus.Infof("Scan of container layers found %s base image", OSDistroless)
-		return OSDistroless, nil
+		return OSDistroless
 	}
 
 	if strings.Contains(osrelease, "NAME=\"Debian GNU") {
 		logrus.Infof("Scan of container layers found %s base image", OSDebian)
-		return OSDebian, nil
+		return OSDebian
 	}
 
 	if strings.Contains(osrelease, "NAME=\"Ubuntu\"") {
-		return OSUbuntu, nil
+		return OSUbuntu
 	}
 
 	if strings.Contains(osrelease, "NAME=\"Fedora Linux\"") {
-		return OSFedora, nil
+		return OSFedora
 	}
 
 	if strings.Contains(osrelease, "NAME=\"CentOS Linux\"") {
-		return OSCentos, nil
+		return OSCentos
 	}
 
 	if strings.Contains(osrelease, "NAME=\"Red Hat Enterprise Linux\"") {
-		return OSRHEL, nil
+		return OSRHEL
 	}
 
 	if strings.Contains(osrelease, "NAME=\"Alpine Linux\"") {
-		return OSAlpine, nil
+		return OSAlpine
 	}
 
 	if strings.Contains(osrelease, "NAME=\"Wolfi\"") {
-		return OSWolfi, nil
+		return OSWolfi
 	}
 
 	if strings.Contains(osrelease, `NAME="Amazon Linux"`) {
-		return OSAmazonLinux, nil
+		return OSAmazonLinux
 	}
 
-	return "", nil
+	return ""
 }
 
 // OSReleaseData extracts the OS release file and returns it as a string.
@@ -392,3 +399,68 @@ func (loss *layerOSScanner) ExtractDirectoryFromTar(tarPath, dirName, destPath s
 		}
 	}
 }
+
+// ExtractMatchingFromTar extracts the regular files in a tarball whose
+// path is accepted by the match function into destPath. Returns true if
+// any files were extracted.
+func (loss *layerOSScanner) ExtractMatchingFromTar(
+	tarPath string, match func(filePath string) bool, destPath string,
+) (bool, error) {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return false, fmt.Errorf("opening tarball: %w", err)
+	}
+	defer f.Close()
+
+	tr, err := getTarReader(f)
+	if err != nil {
+		return false, fmt.Errorf("building tar reader: %w", err)
+	}
+
+	found := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return found, nil
+		}
+		if err != nil {
+			return found, fmt.Errorf("reading tarfile: %w", err)
+		}
+
+		if !hdr.FileInfo().Mode().IsRegular() {
+			continue
+		}
+
+		filePath := strings.TrimPrefix(hdr.Name, dotSlash)
+		if !filepath.IsLocal(filePath) || !match(filePath) {
+			continue
+		}
+		found = true
+
+		realPath := filepath.Join(destPath, filePath)
+		if err := os.MkdirAll(filepath.Dir(realPath), os.FileMode(0o755)); err != nil {
+			return found, fmt.Errorf("creating extraction directory for %s: %w", filePath, err)
+		}
+		if err := writeTarEntry(tr, realPath); err != nil {
+			return found, fmt.Errorf("extracting %s: %w", filePath, err)
+		}
+	}
+}
+
+// writeTarEntry copies the current entry of a tar reader to destPath.
+func writeTarEntry(tr *tar.Reader, destPath string) error {
+	destPointer, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("opening destination file: %w", err)
+	}
+	defer destPointer.Close()
+
+	for {
+		if _, err := io.CopyN(destPointer, tr, 1024); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("writing data to %s: %w", destPath, err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxSymlinkHops limits the symlinks followed when resolving a path
+const maxSymlinkHops = 40
+
+// resolveInRoot resolves a path relative to a root filesystem directory,
+// following symlinks as if root was the system root. Absolute symlinks
+// are resolved inside root instead of pointing to the host filesystem.
+func resolveInRoot(root, relPath string) string {
+	resolved := ""
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	hops := 0
+	for len(parts) > 0 {
+		part := parts[0]
+		parts = parts[1:]
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			resolved = path.Dir(resolved)
+			if resolved == "." {
+				resolved = ""
+			}
+			continue
+		}
+
+		next := path.Join(resolved, part)
+		fi, err := os.Lstat(filepath.Join(root, filepath.FromSlash(next)))
+		if err != nil || fi.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		hops++
+		target, err := os.Readlink(filepath.Join(root, filepath.FromSlash(next)))
+		if err != nil || hops > maxSymlinkHops {
+			resolved = next
+			continue
+		}
+		if path.IsAbs(target) {
+			resolved = ""
+		}
+		parts = append(strings.Split(target, "/"), parts...)
+	}
+	return filepath.Join(root, filepath.FromSlash(resolved))
+}
+
+// rootfsFileExists checks if a path exists in a root filesystem.
+func rootfsFileExists(root, relPath string) bool {
+	_, err := os.Stat(resolveInRoot(root, relPath))
+	return err == nil
+}
+
+// ReadRootfsPackages reads the OS package data from a directory holding
+// a system root filesystem, such as a chroot, a mounted VM disk or an
+// unpacked image. It returns the OS type detected and the packages found
+// in its package database. If the OS is not supported or no database is
+// found, it returns a nil packages pointer.
+func ReadRootfsPackages(root string) (osKind OSType, packages *[]PackageDBEntry, err error) {
+	fi, err := os.Stat(root)
+	if err != nil {
+		return "", nil, fmt.Errorf("checking root filesystem: %w", err)
+	}
+	if !fi.IsDir() {
+		return "", nil, fmt.Errorf("root filesystem %s is not a directory", root)
+	}
+
+	osrelease := ""
+	for _, p := range []string{OsReleasePath, AltOSReleasePath} {
+		data, err := os.ReadFile(resolveInRoot(root, p))
+		if err == nil {
+			osrelease = string(data)
+			break
+		}
+	}
+	if osrelease == "" {
+		logrus.Warnf("No os-release file found in %s", root)
+		return "", nil, nil
+	}
+
+	osKind = osTypeFromRelease(osrelease)
+	var cs containerOSScanner
+	dbPath := ""
+	switch osKind {
+	case OSDebian, OSUbuntu:
+		cs = newDebianScanner()
+		dbPath = "var/lib/dpkg/status"
+	case OSAlpine, OSWolfi:
+		cs = newAlpineScanner()
+		dbPath = apkDBPath
+	case OSAmazonLinux, OSFedora, OSRHEL:
+		cs = newRPMScanner()
+		for _, dbname := range rpmDBFiles {
+			if rootfsFileExists(root, path.Join(rpmDBDir, dbname)) {
+				dbPath = path.Join(rpmDBDir, dbname)
+				break
+			}
+		}
+	case OSDistroless:
+		cs = newDistrolessScanner()
+		dbPath = distrolessDebianPkgDir
+	default:
+		return osKind, nil, nil
+	}
+
+	if dbPath == "" || !rootfsFileExists(root, dbPath) {
+		logrus.Infof("No package database found in %s root filesystem", osKind)
+		return osKind, nil, nil
+	}
+
+	packages, err = cs.ParseDB(resolveInRoot(root, dbPath))
+	if err != nil {
+		return osKind, nil, fmt.Errorf("parsing %s package database: %w", osKind, err)
+	}
+	setPurlData(cs.PURLType(), string(osKind), packages)
+	return osKind, packages, nil
+}
+
+// ReadRootfsAppPackages reads the flatpak and snap applications installed
+// in a root filesystem directory. Returns nil if none are found.
+func ReadRootfsAppPackages(root string) (*[]PackageDBEntry, error) {
+	packages, err := readAppPackages(
+		resolveInRoot(root, flatpakDir),
+		resolveInRoot(root, snapStatePath),
+		resolveInRoot(root, snapMountDir),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reading applications from root filesystem: %w", err)
+	}
+	return packages, nil
+}
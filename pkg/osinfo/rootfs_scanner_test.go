@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDpkgStatus = `Package: base-files
+Status: install ok installed
+Architecture: amd64
+Version: 12.4+deb12u5
+Maintainer: Santiago Vila <sanvila@debian.org>
+
+Package: libc6
+Status: install ok installed
+Architecture: amd64
+Version: 2.36-9+deb12u4
+Maintainer: GNU Libc Maintainers <debian-glibc@lists.debian.org>
+Homepage: https://www.gnu.org/software/libc/libc.html
+`
+
+func TestReadRootfsPackages(t *testing.T) {
+	root := t.TempDir()
+	for path, data := range map[string]string{
+		"usr/lib/os-release":  "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nNAME=\"Debian GNU/Linux\"\n",
+		"var/lib/dpkg/status": testDpkgStatus,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(data), os.FileMode(0o644)))
+	}
+
+	// An absolute symlink must be resolved inside the root. Creating
+	// symlinks on windows needs privileges the CI runners lack.
+	if runtime.GOOS != "windows" {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), os.FileMode(0o755)))
+		require.NoError(t, os.Symlink("/usr/lib/os-release", filepath.Join(root, "etc", "os-release")))
+		require.Equal(t, filepath.Join(root, "usr/lib/os-release"), resolveInRoot(root, OsReleasePath))
+	}
+
+	osKind, packages, err := ReadRootfsPackages(root)
+	require.NoError(t, err)
+	require.Equal(t, OSDebian, osKind)
+	require.NotNil(t, packages)
+	require.Len(t, *packages, 2)
+	require.Equal(t, "libc6", (*packages)[1].Package)
+	require.Equal(t, "deb", (*packages)[1].Type)
+	require.Equal(t, "debian", (*packages)[1].Namespace)
+
+	// Unknown systems return no packages
+	empty := t.TempDir()
+	osKind, packages, err = ReadRootfsPackages(empty)
+	require.NoError(t, err)
+	require.Empty(t, osKind)
+	require.Nil(t, packages)
+
+	// The root must be a directory
+	_, _, err = ReadRootfsPackages(filepath.Join(root, "usr/lib/os-release"))
+	require.Error(t, err)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	flatpakDir    = "var/lib/flatpak"
+	flatpakActive = "active"
+)
+
+// flatpakKinds are the directories holding the deployed flatpak refs
+var flatpakKinds = []string{"app", "runtime"}
+
+// flatpakMetainfo captures the fields we read from the AppStream
+// metadata shipped with flatpak applications.
+type flatpakMetainfo struct {
+	ID        string `xml:"id"`
+	License   string `xml:"project_license"`
+	Developer string `xml:"developer_name"`
+	URLs      []struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"url"`
+	Releases []struct {
+		Version string `xml:"version,attr"`
+	} `xml:"releases>release"`
+}
+
+// isFlatpakMetadataPath returns true if a path (relative to the system
+// root) is one of the flatpak metadata files read by the scanner. It
+// is used to avoid extracting whole flatpak deployments from layers.
+func isFlatpakMetadataPath(filePath string) bool {
+	rel, ok := strings.CutPrefix(strings.TrimPrefix(filePath, dotSlash), flatpakDir+"/")
+	if !ok {
+		return false
+	}
+	// Path parts are kind/id/arch/branch/commit/...
+	parts := strings.Split(rel, "/")
+	switch len(parts) {
+	case 6:
+		return parts[5] == "metadata"
+	case 9:
+		return parts[5] == "files" && parts[6] == "share" &&
+			(parts[7] == "metainfo" || parts[7] == "appdata") &&
+			strings.HasSuffix(parts[8], ".xml")
+	}
+	return false
+}
+
+// parseFlatpakInstallation reads the applications and runtimes deployed
+// in a flatpak installation directory (usually /var/lib/flatpak).
+func parseFlatpakInstallation(dir string) (*[]PackageDBEntry, error) {
+	packages := []PackageDBEntry{}
+	for _, kind := range flatpakKinds {
+		// Deployments live in kind/<id>/<arch>/<branch>/<commit>
+		branches, err := filepath.Glob(filepath.Join(dir, kind, "*", "*", "*"))
+		if err != nil {
+			return nil, fmt.Errorf("searching flatpak %s deployments: %w", kind, err)
+		}
+		sort.Strings(branches)
+		for _, branchDir := range branches {
+			// Skip the current symlink pointing to the default arch/branch
+			if fi, err := os.Lstat(filepath.Dir(branchDir)); err != nil || !fi.IsDir() {
+				continue
+			}
+			commit, err := flatpakActiveCommit(branchDir)
+			if err != nil {
+				return nil, fmt.Errorf("reading flatpak deployment: %w", err)
+			}
+			if commit == "" {
+				continue
+			}
+			entry, err := parseFlatpakDeployment(filepath.Join(branchDir, commit))
+			if err != nil {
+				return nil, fmt.Errorf("parsing flatpak deployment %s: %w", branchDir, err)
+			}
+			if entry == nil {
+				continue
+			}
+			entry.Architecture = filepath.Base(filepath.Dir(branchDir))
+			if entry.Version == "" {
+				// Without release data, the branch is the best version we have
+				entry.Version = filepath.Base(branchDir)
+			}
+			packages = append(packages, *entry)
+		}
+	}
+	if len(packages) == 0 {
+		return nil, nil
+	}
+	return &packages, nil
+}
+
+// flatpakActiveCommit returns the name of the active deployment directory
+// in a flatpak branch directory. If the active symlink is missing, as
+// happens when reading the data from image layers, the deployment is
+// looked up in the branch directory.
+func flatpakActiveCommit(branchDir string) (string, error) {
+	if target, err := os.Readlink(filepath.Join(branchDir, flatpakActive)); err == nil {
+		return filepath.Base(target), nil
+	}
+
+	entries, err := os.ReadDir(branchDir)
+	if err != nil {
+		return "", fmt.Errorf("reading branch directory: %w", err)
+	}
+	commits := []string{}
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != flatpakActive && !strings.HasPrefix(e.Name(), ".") {
+			commits = append(commits, e.Name())
+		}
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	if len(commits) > 1 {
+		logrus.Warnf("Found %d deployments in %s, using %s", len(commits), branchDir, commits[0])
+	}
+	return commits[0], nil
+}
+
+// parseFlatpakDeployment reads the metadata of a deployed flatpak ref.
+// Returns nil if the directory does not have a metadata file.
+func parseFlatpakDeployment(deployDir string) (*PackageDBEntry, error) {
+	f, err := os.Open(filepath.Join(deployDir, "metadata"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening flatpak metadata: %w", err)
+	}
+	defer f.Close()
+
+	// The metadata is a keyfile, the ref name is recorded in the
+	// [Application] or [Runtime] group
+	name := ""
+	group := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			group = strings.Trim(line, "[]")
+			continue
+		}
+		if group != "Application" && group != "Runtime" {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, "="); ok && strings.TrimSpace(k) == "name" {
+			name = strings.TrimSpace(v)
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading flatpak metadata: %w", err)
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	entry := &PackageDBEntry{
+		Package:   name,
+		Type:      "generic",
+		Namespace: "flatpak",
+	}
+
+	info, err := readFlatpakMetainfo(deployDir, name)
+	if err != nil {
+		logrus.Warnf("Unable to read AppStream data of %s: %v", name, err)
+	}
+	if info != nil {
+		entry.License = info.License
+		entry.MaintainerName = info.Developer
+		if len(info.Releases) > 0 {
+			entry.Version = info.Releases[0].Version
+		}
+		for _, u := range info.URLs {
+			if u.Type == "homepage" {
+				entry.HomePage = strings.TrimSpace(u.Value)
+				break
+			}
+		}
+	}
+	return entry, nil
+}
+
+// readFlatpakMetainfo parses the AppStream metadata of a flatpak ref.
+// Returns nil if the ref does not ship the file.
+func readFlatpakMetainfo(deployDir, name string) (*flatpakMetainfo, error) {
+	for _, p := range []string{
+		path.Join("files/share/metainfo", name+".metainfo.xml"),
+		path.Join("files/share/metainfo", name+".appdata.xml"),
+		path.Join("files/share/appdata", name+".appdata.xml"),
+	} {
+		data, err := os.ReadFile(filepath.Join(deployDir, filepath.FromSlash(p)))
+		if err != nil {
+			continue
+		}
+		info := &flatpakMetainfo{}
+		if err := xml.Unmarshal(data, info); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", p, err)
+		}
+		return info, nil
+	}
+	return nil, nil
+}
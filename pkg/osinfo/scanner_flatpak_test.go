@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testFlatpakMetainfo = `<?xml version="1.0" encoding="UTF-8"?>
+<component type="desktop-application">
+  <id>org.gnome.Calculator</id>
+  <project_license>GPL-3.0-or-later</project_license>
+  <developer_name>The GNOME Project</developer_name>
+  <url type="bugtracker">https://gitlab.gnome.org/GNOME/gnome-calculator/issues</url>
+  <url type="homepage">https://apps.gnome.org/Calculator/</url>
+  <releases>
+    <release version="46.1" date="2024-04-20"/>
+    <release version="46.0" date="2024-03-16"/>
+  </releases>
+</component>
+`
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for path, data := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(data), os.FileMode(0o644)))
+	}
+}
+
+func TestParseFlatpakInstallation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs privileges on windows")
+	}
+	dir := t.TempDir()
+	app := "app/org.gnome.Calculator/x86_64/stable/"
+	runtime := "runtime/org.gnome.Platform/x86_64/46/"
+	writeTestFiles(t, dir, map[string]string{
+		app + "1234abcd/metadata": "[Application]\nname=org.gnome.Calculator\nruntime=org.gnome.Platform/x86_64/46\n",
+		app + "1234abcd/files/share/metainfo/org.gnome.Calculator.metainfo.xml": testFlatpakMetainfo,
+		app + "0000aaaa/metadata":     "[Application]\nname=org.gnome.Calculator\n",
+		runtime + "5678ef90/metadata": "[Runtime]\nname=org.gnome.Platform\n",
+	})
+	require.NoError(t, os.Symlink("1234abcd", filepath.Join(dir, app, flatpakActive)))
+	require.NoError(t, os.Symlink("x86_64/stable", filepath.Join(dir, "app/org.gnome.Calculator/current")))
+
+	pk, err := parseFlatpakInstallation(dir)
+	require.NoError(t, err)
+	require.NotNil(t, pk)
+	require.Len(t, *pk, 2)
+
+	require.Equal(t, "org.gnome.Calculator", (*pk)[0].Package)
+	require.Equal(t, "46.1", (*pk)[0].Version)
+	require.Equal(t, "x86_64", (*pk)[0].Architecture)
+	require.Equal(t, "GPL-3.0-or-later", (*pk)[0].License)
+	require.Equal(t, "The GNOME Project", (*pk)[0].MaintainerName)
+	require.Equal(t, "https://apps.gnome.org/Calculator/", (*pk)[0].HomePage)
+	require.Equal(t, "pkg:generic/flatpak/org.gnome.Calculator@46.1?arch=x86_64", (*pk)[0].PackageURL())
+
+	// Without AppStream data, the branch is used as the version
+	require.Equal(t, "org.gnome.Platform", (*pk)[1].Package)
+	require.Equal(t, "46", (*pk)[1].Version)
+
+	// Empty installations return nil
+	pk, err = parseFlatpakInstallation(t.TempDir())
+	require.NoError(t, err)
+	require.Nil(t, pk)
+}
+
+func TestIsFlatpakMetadataPath(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"var/lib/flatpak/app/org.example.App/x86_64/stable/abcd/metadata":                                          true,
+		"./var/lib/flatpak/runtime/org.example.Platform/x86_64/1/abcd/metadata":                                    true,
+		"var/lib/flatpak/app/org.example.App/x86_64/stable/abcd/files/share/metainfo/org.example.App.metainfo.xml": true,
+		"var/lib/flatpak/app/org.example.App/x86_64/stable/abcd/files/bin/app":                                     false,
+		"var/lib/flatpak/repo/objects/ab/cdef.file":                                                                false,
+		"etc/os-release": false,
+	} {
+		require.Equal(t, expected, isFlatpakMetadataPath(path), path)
+	}
+}
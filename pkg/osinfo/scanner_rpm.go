@@ -37,6 +37,16 @@ func newRPMScanner() containerOSScanner {
 	}
 }
 
+// rpmDBDir is the directory holding the rpm database
+const rpmDBDir = "var/lib/rpm"
+
+// rpmDBFiles are the rpm database files, newest format first
+var rpmDBFiles = []string{
+	"rpmdb.sqlite", // sqlite
+	"Packages.db",  // ndb
+	"Packages",     // BerkleyDB
+}
+
 func (ct *rpmScanner) PURLType() string {
 	return "rpm"
 }
@@ -49,12 +59,6 @@ func (ct *rpmScanner) OSType() OSType {
 func (ct *rpmScanner) ReadOSPackages(layers []string) (layer int, pk *[]PackageDBEntry, err error) {
 	rpmDatabase := ""
 
-	rpmDBFiles := []string{
-		"rpmdb.sqlite", // sqlite
-		"Packages.db",  // ndb
-		"Packages",     // BerkleyDB
-	}
-
 	for i, lp := range layers {
 		tmpDBdir, err := os.MkdirTemp("", "rmpdb")
 		defer os.RemoveAll(tmpDBdir)
@@ -63,7 +67,7 @@ func (ct *rpmScanner) ReadOSPackages(layers []string) (layer int, pk *[]PackageD
 		}
 		for _, dbname := range rpmDBFiles {
 			tmpDBPath := filepath.Join(tmpDBdir, dbname)
-			rpmdbpath := filepath.Join("var/lib/rpm", dbname)
+			rpmdbpath := filepath.Join(rpmDBDir, dbname)
 			exists, err := ct.ls.FileExistsInTar(lp, rpmdbpath)
 			if err != nil {
 				return 0, pk, fmt.Errorf("extracting rpm database: %w", err)
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	snapStatePath = "var/lib/snapd/state.json"
+	snapMountDir  = "snap"
+)
+
+// snapSideInfo is the store information of a snap revision as recorded
+// in the snapd state file.
+type snapSideInfo struct {
+	Name     string `json:"name"`
+	SnapID   string `json:"snap-id"`
+	Revision string `json:"revision"`
+	Channel  string `json:"channel"`
+	Website  string `json:"website"`
+}
+
+// snapState captures the parts of the snapd state we read.
+type snapState struct {
+	Data struct {
+		Snaps map[string]struct {
+			Type     string          `json:"type"`
+			Current  string          `json:"current"`
+			Sequence json.RawMessage `json:"sequence"`
+		} `json:"snaps"`
+	} `json:"data"`
+}
+
+// snapYaml holds the fields read from the snap metadata file.
+type snapYaml struct {
+	Name          string   `yaml:"name"`
+	Version       string   `yaml:"version"`
+	License       string   `yaml:"license"`
+	Website       string   `yaml:"website"`
+	Architectures []string `yaml:"architectures"`
+}
+
+// snapSequence returns the revisions of a snap from its sequence field.
+// Older snapd versions store the sequence as a list of side infos, newer
+// ones wrap each revision in a snap field.
+func snapSequence(data json.RawMessage) ([]snapSideInfo, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	seq := []snapSideInfo{}
+	if err := json.Unmarshal(data, &seq); err == nil {
+		return seq, nil
+	}
+	wrapped := struct {
+		Revisions []struct {
+			Snap snapSideInfo `json:"snap"`
+		} `json:"revisions"`
+	}{}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("parsing snap sequence: %w", err)
+	}
+	for _, r := range wrapped.Revisions {
+		seq = append(seq, r.Snap)
+	}
+	return seq, nil
+}
+
+// parseSnapState reads the snaps installed in a system from the snapd
+// state file. If the snaps are mounted under mountDir, the version and
+// license are read from their metadata, otherwise the store revision is
+// used as the version.
+func parseSnapState(statePath, mountDir string) (*[]PackageDBEntry, error) {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapd state: %w", err)
+	}
+	state := snapState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing snapd state: %w", err)
+	}
+
+	names := []string{}
+	for name := range state.Data.Snaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	packages := []PackageDBEntry{}
+	for _, name := range names {
+		snap := state.Data.Snaps[name]
+		seq, err := snapSequence(snap.Sequence)
+		if err != nil {
+			return nil, fmt.Errorf("reading snap %s: %w", name, err)
+		}
+
+		// Only the current revision is installed, the rest of the
+		// sequence are revisions kept to revert
+		var current *snapSideInfo
+		for i := range seq {
+			if seq[i].Revision == snap.Current {
+				current = &seq[i]
+			}
+		}
+		if current == nil {
+			continue
+		}
+
+		entry := PackageDBEntry{
+			Package:   name,
+			Version:   current.Revision,
+			HomePage:  current.Website,
+			Type:      "generic",
+			Namespace: "snap",
+		}
+
+		if mountDir != "" {
+			meta, err := readSnapYaml(filepath.Join(mountDir, name, current.Revision, "meta", "snap.yaml"))
+			if err != nil {
+				return nil, fmt.Errorf("reading snap %s metadata: %w", name, err)
+			}
+			if meta != nil {
+				if meta.Version != "" {
+					entry.Version = meta.Version
+				}
+				entry.License = meta.License
+				if meta.Website != "" {
+					entry.HomePage = meta.Website
+				}
+				if len(meta.Architectures) == 1 {
+					entry.Architecture = meta.Architectures[0]
+				}
+			}
+		}
+		packages = append(packages, entry)
+	}
+	if len(packages) == 0 {
+		return nil, nil
+	}
+	return &packages, nil
+}
+
+// readSnapYaml parses the metadata file of a mounted snap. Returns nil
+// if the snap is not mounted.
+func readSnapYaml(metaPath string) (*snapYaml, error) {
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading snap.yaml: %w", err)
+	}
+	meta := &snapYaml{}
+	if err := yaml.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("parsing snap.yaml: %w", err)
+	}
+	return meta, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osinfo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSnapState = `{
+  "data": {
+    "snaps": {
+      "hello": {
+        "type": "app",
+        "sequence": [
+          {"name": "hello", "snap-id": "buPKUD3TKqCOgLEjjHx5kSiCpIs5cMuQ", "revision": "38"},
+          {"name": "hello", "snap-id": "buPKUD3TKqCOgLEjjHx5kSiCpIs5cMuQ", "revision": "42"}
+        ],
+        "current": "42"
+      },
+      "core22": {
+        "type": "base",
+        "sequence": {"revisions": [
+          {"snap": {"name": "core22", "snap-id": "amcUKQILKXHHTlmSa7NMdnXSx02dNeeT", "revision": "1380"}}
+        ]},
+        "current": "1380"
+      }
+    }
+  }
+}
+`
+
+func TestParseSnapState(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		snapStatePath:                  testSnapState,
+		"snap/hello/42/meta/snap.yaml": "name: hello\nversion: '2.10'\nlicense: GPL-3.0\narchitectures:\n  - amd64\n",
+	})
+
+	pk, err := parseSnapState(filepath.Join(root, snapStatePath), filepath.Join(root, snapMountDir))
+	require.NoError(t, err)
+	require.NotNil(t, pk)
+	require.Len(t, *pk, 2)
+
+	// Snaps are sorted by name. Unmounted snaps use their revision as version
+	require.Equal(t, "core22", (*pk)[0].Package)
+	require.Equal(t, "1380", (*pk)[0].Version)
+
+	require.Equal(t, "hello", (*pk)[1].Package)
+	require.Equal(t, "2.10", (*pk)[1].Version)
+	require.Equal(t, "GPL-3.0", (*pk)[1].License)
+	require.Equal(t, "amd64", (*pk)[1].Architecture)
+	require.Equal(t, "pkg:generic/snap/hello@2.10?arch=amd64", (*pk)[1].PackageURL())
+
+	apps, err := ReadRootfsAppPackages(root)
+	require.NoError(t, err)
+	require.Equal(t, pk, apps)
+}
@@ -37,7 +37,13 @@ func New() *Engine {
 
 // Open reads a document from the specified path.
 func (e *Engine) Open(path string) error {
-	doc, err := spdx.OpenDoc(path)
+	return e.OpenWithOptions(path, &spdx.ReadOptions{})
+}
+
+// OpenWithOptions reads a document from the specified path as defined
+// in the read options.
+func (e *Engine) OpenWithOptions(path string, opts *spdx.ReadOptions) error {
+	doc, err := spdx.OpenDocWithOptions(path, opts)
 	if err != nil {
 		return fmt.Errorf("opening doc: %w", err)
 	}
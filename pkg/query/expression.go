@@ -73,6 +73,10 @@ func parseExpression(expString string) (*Expression, error) {
 			})
 		case "purl":
 			exp.Filters = append(exp.Filters, &PurlFilter{Pattern: data})
+		case "rdeps":
+			exp.Filters = append(exp.Filters, &ReverseDependenciesFilter{Target: data})
+		case "conflicts":
+			exp.Filters = append(exp.Filters, &ConflictsFilter{Kind: data})
 		default:
 			return nil, fmt.Errorf("unknown filter: %s", label)
 		}
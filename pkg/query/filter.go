@@ -17,8 +17,11 @@ limitations under the License.
 package query
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 
 	purl "github.com/package-url/packageurl-go"
 	"github.com/sirupsen/logrus"
@@ -156,6 +159,88 @@ func (f *PurlFilter) Apply(objects map[string]spdx.Object) (map[string]spdx.Obje
 	}), nil
 }
 
+// ReverseDependenciesFilter matches all elements that depend, directly
+// or transitively, on the target. The target can be an SPDX identifier
+// or a purl pattern. Containment is considered a dependency, so the
+// packages containing the target are matched too.
+type ReverseDependenciesFilter struct {
+	Target string
+}
+
+func (f *ReverseDependenciesFilter) Apply(objects map[string]spdx.Object) (map[string]spdx.Object, error) {
+	if f.Target == "" {
+		return nil, errors.New("reverse dependencies filter needs a target")
+	}
+
+	var matchTarget MatcherFunction
+	if strings.HasPrefix(f.Target, "pkg:") {
+		patternPurl, err := purl.FromString(f.Target)
+		if err != nil {
+			return nil, fmt.Errorf("parsing purl: %w", err)
+		}
+		for _, part := range []*string{
+			&patternPurl.Type, &patternPurl.Namespace, &patternPurl.Name, &patternPurl.Version,
+		} {
+			if *part == "" {
+				*part = "*"
+			}
+		}
+		matchTarget = func(o spdx.Object) bool {
+			p, ok := o.(*spdx.Package)
+			if !ok || p.Purl() == nil {
+				return false
+			}
+			return p.PurlMatches(&patternPurl)
+		}
+	} else {
+		matchTarget = func(o spdx.Object) bool {
+			return o.SPDXID() == f.Target
+		}
+	}
+
+	roots := []spdx.Object{}
+	for _, o := range objects {
+		roots = append(roots, o)
+	}
+	res := map[string]spdx.Object{}
+	for _, o := range spdx.ReverseDependencies(roots, matchTarget) {
+		if o.SPDXID() != "" {
+			res[o.SPDXID()] = o
+		}
+	}
+	return res, nil
+}
+
+// ConflictsFilter matches the packages sharing a purl with others found
+// at a different version or declared with a different license. Kind
+// limits the results to one kind of conflict, all are matched when empty.
+type ConflictsFilter struct {
+	Kind string
+}
+
+func (f *ConflictsFilter) Apply(objects map[string]spdx.Object) (map[string]spdx.Object, error) {
+	if f.Kind != "" && !slices.Contains(spdx.PurlConflictKinds, spdx.PurlConflictKind(f.Kind)) {
+		return nil, fmt.Errorf("unknown conflict kind %q, must be one of %v", f.Kind, spdx.PurlConflictKinds)
+	}
+
+	roots := []spdx.Object{}
+	for _, o := range objects {
+		roots = append(roots, o)
+	}
+	res := map[string]spdx.Object{}
+	for _, c := range spdx.FindPurlConflicts(roots) {
+		if f.Kind != "" && string(c.Kind) != f.Kind {
+			continue
+		}
+		for _, p := range c.Packages {
+			if p.SPDXID() != "" {
+				res[p.SPDXID()] = p
+			}
+		}
+	}
+	return res, nil
+}
+
 type MatcherFunction func(spdx.Object) bool
 
 type ObjectCycler struct{}
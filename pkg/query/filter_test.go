@@ -18,6 +18,7 @@ package query
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -146,3 +147,156 @@ func TestPurl(t *testing.T) {
 		require.Len(t, newResults.Objects, tc.num)
 	}
 }
+
+func TestReverseDependencies(t *testing.T) {
+	// image CONTAINS layer, layer CONTAINS app, app DEPENDS_ON lib and
+	// tool is declared as a DEV_DEPENDENCY_OF lib
+	newPkg := func(id, purlString string) *spdx.Package {
+		p := spdx.NewPackage()
+		p.ID = id
+		p.Name = id
+		if purlString != "" {
+			p.ExternalRefs = []spdx.ExternalRef{{Category: spdx.CatPackageManager, Type: "purl", Locator: purlString}}
+		}
+		return p
+	}
+	image := newPkg("image", "")
+	layer := newPkg("layer", "")
+	app := newPkg("app", "")
+	lib := newPkg("lib", "pkg:golang/example.com/lib@v1.0.0")
+	tool := newPkg("tool", "")
+	other := newPkg("other", "")
+	image.AddRelationship(&spdx.Relationship{Type: spdx.CONTAINS, Peer: layer})
+	layer.AddRelationship(&spdx.Relationship{Type: spdx.CONTAINS, Peer: app})
+	app.AddRelationship(&spdx.Relationship{Type: spdx.DEPENDS_ON, Peer: lib})
+	lib.AddRelationship(&spdx.Relationship{Type: spdx.DEV_DEPENDENCY_OF, Peer: tool})
+	image.AddRelationship(&spdx.Relationship{Type: spdx.CONTAINS, Peer: other})
+
+	for _, tc := range []struct {
+		target   string
+		expected []string
+		mustErr  bool
+	}{
+		{"lib", []string{"app", "image", "layer", "tool"}, false},
+		{"pkg:golang/example.com/lib", []string{"app", "image", "layer", "tool"}, false},
+		{"app", []string{"image", "layer"}, false},
+		{"image", []string{}, false},
+		{"other", []string{"image"}, false},
+		{"", nil, true},
+	} {
+		fr := FilterResults{Objects: map[string]spdx.Object{"image": image}}
+		newResults := fr.Apply(&ReverseDependenciesFilter{Target: tc.target})
+		if tc.mustErr {
+			require.Error(t, newResults.Error)
+			continue
+		}
+		require.NoError(t, newResults.Error)
+		ids := []string{}
+		for id := range newResults.Objects {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		require.Equal(t, tc.expected, ids, tc.target)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	newPkg := func(id, purlString, license string) *spdx.Package {
+		p := spdx.NewPackage()
+		p.ID = id
+		p.Name = id
+		p.LicenseDeclared = license
+		p.ExternalRefs = []spdx.ExternalRef{{Category: spdx.CatPackageManager, Type: "purl", Locator: purlString}}
+		return p
+	}
+	root := spdx.NewPackage()
+	root.ID = "root"
+	for _, p := range []*spdx.Package{
+		newPkg("zlib-1", "pkg:apk/alpine/zlib@1.3.1-r0", "Zlib"),
+		newPkg("zlib-2", "pkg:apk/alpine/zlib@1.2.13-r1", "Zlib"),
+		newPkg("openssl-1", "pkg:apk/alpine/openssl@3.1.4-r5", "Apache-2.0"),
+		newPkg("openssl-2", "pkg:apk/alpine/openssl@3.1.4-r5", "OpenSSL"),
+		newPkg("musl", "pkg:apk/alpine/musl@1.2.4-r2", "MIT"),
+	} {
+		root.AddRelationship(&spdx.Relationship{Type: spdx.CONTAINS, Peer: p})
+	}
+
+	for _, tc := range []struct {
+		kind     string
+		expected []string
+		mustErr  bool
+	}{
+		{"", []string{"openssl-1", "openssl-2", "zlib-1", "zlib-2"}, false},
+		{"version", []string{"zlib-1", "zlib-2"}, false},
+		{"license", []string{"openssl-1", "openssl-2"}, false},
+		{"checksum", nil, true},
+	} {
+		fr := FilterResults{Objects: map[string]spdx.Object{"root": root}}
+		newResults := fr.Apply(&ConflictsFilter{Kind: tc.kind})
+		if tc.mustErr {
+			require.Error(t, newResults.Error)
+			continue
+		}
+		require.NoError(t, newResults.Error)
+		ids := []string{}
+		for id := range newResults.Objects {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		require.Equal(t, tc.expected, ids, tc.kind)
+	}
+}
+
+// benchmarkFilterResults returns a result set with 100 top level
+// packages, each containing 100 packages with 10 files.
+func benchmarkFilterResults() FilterResults {
+	fr := FilterResults{Objects: map[string]spdx.Object{}}
+	for i := range 100 {
+		top := spdx.NewPackage()
+		top.ID = fmt.Sprintf("top-%d", i)
+		top.Name = top.ID
+		for j := range 100 {
+			p := spdx.NewPackage()
+			p.ID = fmt.Sprintf("package-%d-%d", i, j)
+			p.Name = fmt.Sprintf("lib%d", j)
+			p.ExternalRefs = []spdx.ExternalRef{{
+				Category: spdx.CatPackageManager,
+				Type:     "purl",
+				Locator:  fmt.Sprintf("pkg:golang/example.com/lib%d@v1.0.%d", j, i),
+			}}
+			for k := range 10 {
+				f := spdx.NewFile()
+				f.ID = fmt.Sprintf("file-%d-%d-%d", i, j, k)
+				f.FileName = fmt.Sprintf("src/file%d.go", k)
+				p.AddRelationship(&spdx.Relationship{Type: spdx.CONTAINS, Peer: f})
+			}
+			top.AddRelationship(&spdx.Relationship{Type: spdx.DEPENDS_ON, Peer: p})
+		}
+		fr.Objects[top.ID] = top
+	}
+	return fr
+}
+
+func BenchmarkFilters(b *testing.B) {
+	for _, tc := range []struct {
+		name   string
+		filter func() Filter
+	}{
+		{"all", func() Filter { return &AllFilter{} }},
+		{"depth", func() Filter { return &DepthFilter{TargetDepth: 2} }},
+		{"name", func() Filter { return &NameFilter{Pattern: "lib4.*"} }},
+		{"purl", func() Filter { return &PurlFilter{Pattern: "pkg:golang/example.com/lib42"} }},
+		{"rdeps", func() Filter { return &ReverseDependenciesFilter{Target: "pkg:golang/example.com/lib42"} }},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				fr := benchmarkFilterResults()
+				b.StartTimer()
+				if res := fr.Apply(tc.filter()); res.Error != nil {
+					b.Fatal(res.Error)
+				}
+			}
+		})
+	}
+}
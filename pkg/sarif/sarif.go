@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sarif writes findings in the Static Analysis Results
+// Interchange Format (SARIF) 2.1.0, so they can be loaded in GitHub
+// code scanning and other SARIF consumers.
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Level is the severity of a result
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+// Log is the top level SARIF object
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is the output of a single invocation of a tool
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver describes the tool and the rules it checks
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule is a kind of problem the tool reports
+type Rule struct {
+	ID                   string         `json:"id"`
+	ShortDescription     Message        `json:"shortDescription"`
+	DefaultConfiguration *Configuration `json:"defaultConfiguration,omitempty"`
+}
+
+type Configuration struct {
+	Level Level `json:"level"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is a finding of a rule
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	RuleIndex int        `json:"ruleIndex"`
+	Level     Level      `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+type Location struct {
+	PhysicalLocation *PhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// LogicalLocation names an element that is not a line in a file, such
+// as an SPDX element
+type LogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// New returns a log with a single run of the tool
+func New(name, version, informationURI string) *Log {
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{{
+			Tool: Tool{Driver: Driver{
+				Name: name, Version: version, InformationURI: informationURI, Rules: []Rule{},
+			}},
+			Results: []Result{},
+		}},
+	}
+}
+
+// AddRule registers a rule in the run. Rules already registered are
+// not added again.
+func (l *Log) AddRule(rule Rule) {
+	if l.ruleIndex(rule.ID) != -1 {
+		return
+	}
+	l.Runs[0].Tool.Driver.Rules = append(l.Runs[0].Tool.Driver.Rules, rule)
+}
+
+// AddResult records a finding of a registered rule
+func (l *Log) AddResult(result Result) error {
+	idx := l.ruleIndex(result.RuleID)
+	if idx == -1 {
+		return fmt.Errorf("rule %q is not registered", result.RuleID)
+	}
+	result.RuleIndex = idx
+	if result.Level == "" {
+		result.Level = LevelWarning
+		if conf := l.Runs[0].Tool.Driver.Rules[idx].DefaultConfiguration; conf != nil {
+			result.Level = conf.Level
+		}
+	}
+	l.Runs[0].Results = append(l.Runs[0].Results, result)
+	return nil
+}
+
+// Results returns the findings recorded in the log
+func (l *Log) Results() []Result {
+	return l.Runs[0].Results
+}
+
+// Write encodes the log as indented JSON
+func (l *Log) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(l); err != nil {
+		return fmt.Errorf("encoding SARIF log: %w", err)
+	}
+	return nil
+}
+
+func (l *Log) ruleIndex(id string) int {
+	for i, rule := range l.Runs[0].Tool.Driver.Rules {
+		if rule.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog(t *testing.T) {
+	log := New("bom", "v1.0.0", "https://github.com/kubernetes-sigs/bom")
+	log.AddRule(Rule{ID: "a", DefaultConfiguration: &Configuration{Level: LevelError}})
+	log.AddRule(Rule{ID: "b"})
+	log.AddRule(Rule{ID: "a"})
+	require.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+
+	require.Error(t, log.AddResult(Result{RuleID: "c"}))
+	require.NoError(t, log.AddResult(Result{RuleID: "a", Message: Message{Text: "first"}}))
+	require.NoError(t, log.AddResult(Result{RuleID: "b", Message: Message{Text: "second"}}))
+	require.NoError(t, log.AddResult(Result{RuleID: "a", Level: LevelNote}))
+
+	results := log.Results()
+	require.Len(t, results, 3)
+	require.Equal(t, LevelError, results[0].Level)
+	require.Equal(t, 0, results[0].RuleIndex)
+	require.Equal(t, LevelWarning, results[1].Level)
+	require.Equal(t, 1, results[1].RuleIndex)
+	require.Equal(t, LevelNote, results[2].Level)
+
+	var buf bytes.Buffer
+	require.NoError(t, log.Write(&buf))
+	decoded := map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, Version, decoded["version"])
+	require.Equal(t, Schema, decoded["$schema"])
+}
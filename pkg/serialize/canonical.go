@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serialize
+
+import (
+	"bytes"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Canonicalize rewrites JSON data in its canonical form, following the
+// JSON Canonicalization Scheme (RFC 8785): no whitespace between tokens,
+// object keys sorted by their UTF-16 code units, numbers and strings
+// written in their shortest form. All the arrays in an SPDX document are
+// unordered collections, so array items are also sorted by their
+// canonical form. Documents with the same contents produce the same bytes,
+// regardless of the tool that wrote them.
+func Canonicalize(data []byte) ([]byte, error) {
+	dec := gojson.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decoding json: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the json value")
+	}
+	return canonicalValue(v)
+}
+
+// canonicalValue writes a decoded JSON value in canonical form
+func canonicalValue(v any) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return []byte("null"), nil
+	case bool:
+		return strconv.AppendBool(nil, val), nil
+	case gojson.Number:
+		return canonicalNumber(val)
+	case string:
+		return canonicalString(val), nil
+	case []any:
+		items := make([][]byte, 0, len(val))
+		for _, item := range val {
+			b, err := canonicalValue(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, b)
+		}
+		slices.SortFunc(items, bytes.Compare)
+		return append(append([]byte{'['}, bytes.Join(items, []byte{','})...), ']'), nil
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		slices.SortFunc(keys, func(a, b string) int {
+			return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+		})
+		buf := []byte{'{'}
+		for i, k := range keys {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			b, err := canonicalValue(val[k])
+			if err != nil {
+				return nil, err
+			}
+			buf = append(append(append(buf, canonicalString(k)...), ':'), b...)
+		}
+		return append(buf, '}'), nil
+	default:
+		return nil, fmt.Errorf("unexpected json value of type %T", v)
+	}
+}
+
+// canonicalNumber writes a number as an ECMAScript double would be
+// printed: integers without fraction or exponent, and the exponent
+// notation only for very large or small numbers.
+func canonicalNumber(n gojson.Number) ([]byte, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing number %s: %w", n, err)
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, fmt.Errorf("number %s cannot be represented", n)
+	}
+	if f == 0 {
+		return []byte("0"), nil
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.AppendFloat(nil, f, 'f', -1, 64), nil
+	}
+	// Go pads the exponent to two digits (1e-07), ECMAScript does not
+	mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	sign, digits := exp[:1], strings.TrimLeft(exp[1:], "0")
+	return []byte(mantissa + "e" + sign + digits), nil
+}
+
+// canonicalString quotes a string escaping only the characters JSON
+// requires to be escaped.
+func canonicalString(s string) []byte {
+	buf := []byte{'"'}
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf = append(buf, '\\', '"')
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		case '\b':
+			buf = append(buf, '\\', 'b')
+		case '\f':
+			buf = append(buf, '\\', 'f')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		default:
+			if r < 0x20 {
+				buf = append(buf, fmt.Sprintf(`\u%04x`, r)...)
+				continue
+			}
+			buf = append(buf, string(r)...)
+		}
+	}
+	return append(buf, '"')
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serialize
+
+import (
+	"bytes"
+	gojson "encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+func TestCanonicalize(t *testing.T) {
+	for _, tc := range []struct {
+		data     string
+		expected string
+	}{
+		{
+			// RFC 8785 sorting example
+			data:     `{"€": "Euro Sign", "\r": "Carriage Return", "😀": "Smiley", "1": "One", "\u0080": "Control", "ö": "Latin Small Letter O With Diaeresis"}`,
+			expected: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Smiley\"}",
+		},
+		{
+			data:     `{"string": "€$\u000F\u000aA'B\"\\\\\"\/<&>"}`,
+			expected: `{"string":"€$\u000f\nA'B\"\\\\\"/<&>"}`,
+		},
+		{
+			data:     `[333333333.33333329, 1E30, 4.50, 2e-3, 1e-7, -0, 10]`,
+			expected: `[0,0.002,10,1e+30,1e-7,333333333.3333333,4.5]`,
+		},
+		{
+			data:     `{"b": [{"x": 2}, {"x": 1}, null, true], "a": {}}`,
+			expected: `{"a":{},"b":[null,true,{"x":1},{"x":2}]}`,
+		},
+	} {
+		out, err := Canonicalize([]byte(tc.data))
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, string(out))
+	}
+
+	_, err := Canonicalize([]byte(`{} {}`))
+	require.Error(t, err)
+	_, err = Canonicalize([]byte(`{"a":`))
+	require.Error(t, err)
+}
+
+func TestSerializeCanonical(t *testing.T) {
+	doc := spdx.NewDocument()
+	doc.Name = "test"
+	doc.Namespace = "https://example.com/test"
+	for _, name := range []string{"b", "a"} {
+		p := spdx.NewPackage()
+		p.ID = "SPDXRef-Package-" + name
+		p.Name = name
+		require.NoError(t, doc.AddPackage(p))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, (&JSON{Canonical: true}).SerializeTo(doc, &buf))
+	require.NotContains(t, buf.String(), "\n")
+
+	// The canonical form does not change when canonicalized again
+	out, err := Canonicalize(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, buf.String(), string(out))
+
+	parsed := &spdx.Document{}
+	require.NoError(t, gojson.Unmarshal(buf.Bytes(), parsed))
+	require.Len(t, parsed.Packages, 2)
+}
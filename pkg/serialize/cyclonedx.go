@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serialize
+
+import (
+	gojson "encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// cycloneDXSpecVersion is the version of the CycloneDX specification of
+// the documents written by the CycloneDX serializer
+const cycloneDXSpecVersion = "1.5"
+
+// CycloneDX serializes documents as CycloneDX JSON BOMs, the format
+// services like Dependency-Track ingest. Only the packages are
+// converted: each one becomes a component and their DEPENDS_ON and
+// CONTAINS relationships make up the dependency graph. The document
+// itself is the component described in the BOM metadata, depending on
+// the packages it describes.
+type CycloneDX struct{}
+
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber,omitempty"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []*cdxComponent `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Timestamp string        `json:"timestamp,omitempty"`
+	Tools     *cdxTools     `json:"tools,omitempty"`
+	Component *cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []*cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type               string           `json:"type"`
+	BOMRef             string           `json:"bom-ref,omitempty"`
+	Supplier           *cdxEntity       `json:"supplier,omitempty"`
+	Author             string           `json:"author,omitempty"`
+	Name               string           `json:"name"`
+	Version            string           `json:"version,omitempty"`
+	Description        string           `json:"description,omitempty"`
+	Hashes             []cdxHash        `json:"hashes,omitempty"`
+	Licenses           []cdxLicense     `json:"licenses,omitempty"`
+	Copyright          string           `json:"copyright,omitempty"`
+	CPE                string           `json:"cpe,omitempty"`
+	Purl               string           `json:"purl,omitempty"`
+	ExternalReferences []cdxExternalRef `json:"externalReferences,omitempty"`
+}
+
+type cdxEntity struct {
+	Name string `json:"name"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cdxExternalRef struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// cdxComponentTypes maps the SPDX package purposes to CycloneDX
+// component types, the rest are libraries
+var cdxComponentTypes = map[string]string{
+	"APPLICATION":      "application",
+	"FRAMEWORK":        "framework",
+	"CONTAINER":        "container",
+	"OPERATING-SYSTEM": "operating-system",
+	"DEVICE":           "device",
+	"FIRMWARE":         "firmware",
+	"FILE":             "file",
+}
+
+// cdxHashAlgorithms maps the SPDX checksum algorithms to their CycloneDX
+// names. Algorithms CycloneDX does not define are not converted.
+var cdxHashAlgorithms = map[string]string{
+	"MD5":         "MD5",
+	"SHA1":        "SHA-1",
+	"SHA256":      "SHA-256",
+	"SHA384":      "SHA-384",
+	"SHA512":      "SHA-512",
+	"SHA3-256":    "SHA3-256",
+	"SHA3-384":    "SHA3-384",
+	"SHA3-512":    "SHA3-512",
+	"BLAKE2b-256": "BLAKE2b-256",
+	"BLAKE2b-384": "BLAKE2b-384",
+	"BLAKE2b-512": "BLAKE2b-512",
+	"BLAKE3":      "BLAKE3",
+}
+
+// Serialize converts the document into a CycloneDX JSON BOM.
+func (c *CycloneDX) Serialize(doc *spdx.Document) (string, error) {
+	var b strings.Builder
+	if err := c.SerializeTo(doc, &b); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// SerializeTo writes the document as an indented CycloneDX JSON BOM to w.
+func (c *CycloneDX) SerializeTo(doc *spdx.Document, w io.Writer) error {
+	bom, err := cycloneDXBOM(doc)
+	if err != nil {
+		return err
+	}
+	enc := gojson.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bom); err != nil {
+		return fmt.Errorf("marshaling cyclonedx document: %w", err)
+	}
+	return nil
+}
+
+// cycloneDXBOM converts a document into a CycloneDX BOM
+func cycloneDXBOM(doc *spdx.Document) (*cdxBOM, error) {
+	bom := &cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		Version:     1,
+		Metadata: cdxMetadata{
+			Component: &cdxComponent{Type: "application", BOMRef: doc.ID, Name: doc.Name},
+		},
+		Components:   []*cdxComponent{},
+		Dependencies: []cdxDependency{},
+	}
+	if doc.Namespace != "" {
+		// The serial number is derived from the namespace, so converting
+		// a document twice gives the same BOM
+		bom.SerialNumber = "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, []byte(doc.Namespace)).String()
+	}
+	if !doc.Created.IsZero() {
+		bom.Metadata.Timestamp = doc.Created.UTC().Format(time.RFC3339)
+	}
+	if len(doc.Creator.Tool) > 0 {
+		bom.Metadata.Tools = &cdxTools{Components: []*cdxComponent{}}
+		for _, tool := range doc.Creator.Tool {
+			bom.Metadata.Tools.Components = append(
+				bom.Metadata.Tools.Components, &cdxComponent{Type: "application", Name: tool},
+			)
+		}
+	}
+
+	// The document depends on the packages it describes
+	top := cdxDependency{Ref: doc.ID, DependsOn: []string{}}
+	for id, pkg := range doc.Packages {
+		if len(doc.Describes) == 0 || slices.Contains(doc.Describes, id) {
+			top.DependsOn = append(top.DependsOn, pkg.ID)
+		}
+	}
+	slices.Sort(top.DependsOn)
+	bom.Dependencies = append(bom.Dependencies, top)
+
+	err := doc.Walk(func(o spdx.Object, _ []spdx.Object) error {
+		pkg, ok := o.(*spdx.Package)
+		if !ok {
+			// Files are not components, nor the packages they relate to
+			return spdx.ErrSkipRelationships
+		}
+		bom.Components = append(bom.Components, cycloneDXComponent(pkg))
+		dep := cdxDependency{Ref: pkg.ID, DependsOn: []string{}}
+		for _, rel := range *pkg.GetRelationships() {
+			if rel.Type != spdx.DEPENDS_ON && rel.Type != spdx.CONTAINS {
+				continue
+			}
+			if peer, ok := rel.Peer.(*spdx.Package); ok && !slices.Contains(dep.DependsOn, peer.ID) {
+				dep.DependsOn = append(dep.DependsOn, peer.ID)
+			}
+		}
+		bom.Dependencies = append(bom.Dependencies, dep)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("converting document packages: %w", err)
+	}
+	return bom, nil
+}
+
+// cycloneDXComponent converts a package into a CycloneDX component
+func cycloneDXComponent(pkg *spdx.Package) *cdxComponent {
+	c := &cdxComponent{
+		Type:        "library",
+		BOMRef:      pkg.ID,
+		Name:        pkg.Name,
+		Version:     pkg.Version,
+		Description: pkg.Summary,
+		Copyright:   cdxAssertion(pkg.CopyrightText),
+	}
+	if t, ok := cdxComponentTypes[pkg.PrimaryPurpose]; ok {
+		c.Type = t
+	}
+	if c.Description == "" {
+		c.Description = pkg.Description
+	}
+	if supplier := pkg.Supplier.Organization; supplier != "" {
+		c.Supplier = &cdxEntity{Name: supplier}
+	} else if pkg.Supplier.Person != "" {
+		c.Supplier = &cdxEntity{Name: pkg.Supplier.Person}
+	}
+	if pkg.Originator.Person != "" {
+		c.Author = pkg.Originator.Person
+	} else {
+		c.Author = pkg.Originator.Organization
+	}
+
+	// The concluded license is preferred, the declared one is used
+	// when no license was concluded
+	if license := cdxAssertion(pkg.LicenseConcluded); license != "" {
+		c.Licenses = []cdxLicense{{Expression: license}}
+	} else if license := cdxAssertion(pkg.LicenseDeclared); license != "" {
+		c.Licenses = []cdxLicense{{Expression: license}}
+	}
+
+	algorithms := make([]string, 0, len(pkg.Checksum))
+	for algo := range pkg.Checksum {
+		algorithms = append(algorithms, algo)
+	}
+	slices.Sort(algorithms)
+	for _, algo := range algorithms {
+		if alg, ok := cdxHashAlgorithms[algo]; ok {
+			c.Hashes = append(c.Hashes, cdxHash{Alg: alg, Content: pkg.Checksum[algo]})
+		}
+	}
+
+	for _, ref := range pkg.ExternalRefs {
+		switch {
+		case ref.Type == "purl" && c.Purl == "":
+			c.Purl = ref.Locator
+		case ref.Type == "cpe23Type" && c.CPE == "":
+			c.CPE = ref.Locator
+		}
+	}
+	if homepage := cdxAssertion(pkg.HomePage); homepage != "" {
+		c.ExternalReferences = append(c.ExternalReferences, cdxExternalRef{Type: "website", URL: homepage})
+	}
+	if location := cdxAssertion(pkg.DownloadLocation); location != "" {
+		c.ExternalReferences = append(c.ExternalReferences, cdxExternalRef{Type: "distribution", URL: location})
+	}
+	return c
+}
+
+// cdxAssertion returns the value of an SPDX field, or an empty string
+// if it is NOASSERTION or NONE
+func cdxAssertion(value string) string {
+	if value == spdx.NOASSERTION || value == spdx.NONE {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serialize
+
+import (
+	gojson "encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+func TestCycloneDX(t *testing.T) {
+	doc := spdx.NewDocument()
+	doc.ID = "SPDXRef-DOCUMENT"
+	doc.Name = "example"
+	doc.Namespace = "https://example.com/example"
+	doc.Created = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc.Creator.Tool = []string{"bom-v0.7.0"}
+
+	app := spdx.NewPackage()
+	app.ID = "SPDXRef-Package-app"
+	app.Name = "app"
+	app.Version = "1.0.0"
+	app.PrimaryPurpose = "APPLICATION"
+	app.LicenseConcluded = spdx.NOASSERTION
+	app.LicenseDeclared = "Apache-2.0"
+	app.Supplier.Organization = "Example Inc."
+	app.Checksum = map[string]string{"SHA256": "abcd", "SHA1": "ef01", "ADLER32": "1234"}
+	app.DownloadLocation = spdx.NOASSERTION
+	app.HomePage = "https://example.com"
+
+	lib := spdx.NewPackage()
+	lib.ID = "SPDXRef-Package-lib"
+	lib.Name = "golang.org/x/text"
+	lib.Version = "v0.14.0"
+	lib.LicenseConcluded = "BSD-3-Clause"
+	lib.ExternalRefs = []spdx.ExternalRef{
+		{Category: spdx.CatPackageManager, Type: "purl", Locator: "pkg:golang/golang.org/x/text@v0.14.0"},
+		{Category: spdx.CatSecurity, Type: "cpe23Type", Locator: "cpe:2.3:a:golang:text:0.14.0:*:*:*:*:*:*:*"},
+	}
+	f := spdx.NewFile()
+	f.ID = "SPDXRef-File-main"
+	f.Name = "main.go"
+	require.NoError(t, app.AddFile(f))
+	require.NoError(t, app.AddDependency(lib))
+	require.NoError(t, doc.AddPackage(app))
+
+	serializer := &CycloneDX{}
+	out, err := serializer.Serialize(doc)
+	require.NoError(t, err)
+
+	bom := &cdxBOM{}
+	require.NoError(t, gojson.Unmarshal([]byte(out), bom))
+	require.Equal(t, "CycloneDX", bom.BOMFormat)
+	require.Equal(t, "1.5", bom.SpecVersion)
+	require.Regexp(t, `^urn:uuid:[0-9a-f-]{36}$`, bom.SerialNumber)
+	require.Equal(t, "2026-01-02T03:04:05Z", bom.Metadata.Timestamp)
+	require.Equal(t, "bom-v0.7.0", bom.Metadata.Tools.Components[0].Name)
+	require.Equal(t, &cdxComponent{Type: "application", BOMRef: "SPDXRef-DOCUMENT", Name: "example"}, bom.Metadata.Component)
+
+	require.Equal(t, []*cdxComponent{
+		{
+			Type: "application", BOMRef: "SPDXRef-Package-app", Name: "app", Version: "1.0.0",
+			Supplier:           &cdxEntity{Name: "Example Inc."},
+			Hashes:             []cdxHash{{Alg: "SHA-1", Content: "ef01"}, {Alg: "SHA-256", Content: "abcd"}},
+			Licenses:           []cdxLicense{{Expression: "Apache-2.0"}},
+			ExternalReferences: []cdxExternalRef{{Type: "website", URL: "https://example.com"}},
+		},
+		{
+			Type: "library", BOMRef: "SPDXRef-Package-lib", Name: "golang.org/x/text", Version: "v0.14.0",
+			Licenses: []cdxLicense{{Expression: "BSD-3-Clause"}},
+			Purl:     "pkg:golang/golang.org/x/text@v0.14.0",
+			CPE:      "cpe:2.3:a:golang:text:0.14.0:*:*:*:*:*:*:*",
+		},
+	}, bom.Components)
+	require.Equal(t, []cdxDependency{
+		{Ref: "SPDXRef-DOCUMENT", DependsOn: []string{"SPDXRef-Package-app"}},
+		{Ref: "SPDXRef-Package-app", DependsOn: []string{"SPDXRef-Package-lib"}},
+		{Ref: "SPDXRef-Package-lib", DependsOn: []string{}},
+	}, bom.Dependencies)
+
+	// Converting the document again gives the same BOM
+	again, err := serializer.Serialize(doc)
+	require.NoError(t, err)
+	require.Equal(t, out, again)
+}
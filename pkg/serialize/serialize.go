@@ -18,21 +18,39 @@ package serialize
 
 import (
 	gojson "encoding/json"
-	"errors"
 	"fmt"
-	"time"
+	"io"
+	"strings"
 
-	"sigs.k8s.io/release-utils/version"
-
-	"sigs.k8s.io/bom/pkg/query"
 	"sigs.k8s.io/bom/pkg/spdx"
-	spdxJSON "sigs.k8s.io/bom/pkg/spdx/json/v2.3"
 )
 
 type Serializer interface {
 	Serialize(*spdx.Document) (string, error)
 }
 
+// StreamSerializer is implemented by the serializers that write documents
+// to a writer without building them in memory first.
+type StreamSerializer interface {
+	SerializeTo(*spdx.Document, io.Writer) error
+}
+
+// SerializeTo writes the document to w with the serializer, streaming it
+// when the serializer is a StreamSerializer.
+func SerializeTo(s Serializer, doc *spdx.Document, w io.Writer) error {
+	if ss, ok := s.(StreamSerializer); ok {
+		return ss.SerializeTo(doc, w)
+	}
+	markup, err := s.Serialize(doc)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, markup); err != nil {
+		return fmt.Errorf("writing document: %w", err)
+	}
+	return nil
+}
+
 type TagValue struct{}
 
 // Serialize the documento into SPDX Tag-Value format. For now, the
@@ -42,225 +60,43 @@ func (tv *TagValue) Serialize(doc *spdx.Document) (string, error) {
 	return doc.Render()
 }
 
-type JSON struct{}
+type JSON struct {
+	// Canonical writes the document in the canonical form returned by
+	// Canonicalize instead of indented JSON, so its digest can be
+	// compared with other documents and signed.
+	Canonical bool
+}
 
 // Serialize serializes the document into a spdx JSON.
 func (json *JSON) Serialize(doc *spdx.Document) (string, error) {
-	// The old Render() method finalizes the sbom before serializing
-	// it. We still need to call it before building the JSON struct.
-	if _, err := doc.Render(); err != nil {
-		return "", fmt.Errorf("pre-rendering the document: %w", err)
-	}
-
-	jsonDoc := spdxJSON.Document{
-		ID:      doc.ID,
-		Name:    doc.Name,
-		Version: spdxJSON.Version,
-		CreationInfo: spdxJSON.CreationInfo{
-			Created: time.Now().UTC().Format("2006-01-02T15:04:05Z07:00"),
-			Creators: []string{
-				fmt.Sprintf("Tool: %s-%s", "bom", version.GetVersionInfo().GitVersion),
-			},
-			LicenseListVersion: doc.LicenseListVersion,
-		},
-		DataLicense:       doc.DataLicense,
-		Namespace:         doc.Namespace,
-		DocumentDescribes: []string{},
-		Packages:          []spdxJSON.Package{},
-		Relationships:     []spdxJSON.Relationship{},
-	}
-
-	// Generate the array for the cycler
-	for _, p := range doc.Packages {
-		jsonDoc.DocumentDescribes = append(jsonDoc.DocumentDescribes, p.SPDXID())
-	}
-
-	for _, p := range doc.Files {
-		jsonDoc.DocumentDescribes = append(jsonDoc.DocumentDescribes, p.SPDXID())
+	var b strings.Builder
+	if err := json.SerializeTo(doc, &b); err != nil {
+		return "", err
 	}
-
-	q := query.New()
-	q.Document = doc
-	fp, err := q.Query("all")
-	if err != nil {
-		return "", fmt.Errorf("querying document: %w", err)
-	}
-
-	for _, o := range fp.Objects {
-		if p, ok := o.(*spdx.Package); ok {
-			jsonPackage, err := json.buildJSONPackage(p)
-			if err != nil {
-				return "", fmt.Errorf("serializing json package: %w", err)
-			}
-			jsonDoc.Packages = append(jsonDoc.Packages, jsonPackage)
-
-			// Add the package's relationships to the doc
-			for _, r := range *p.GetRelationships() {
-				jsonDoc.Relationships = append(jsonDoc.Relationships, spdxJSON.Relationship{
-					Element: p.SPDXID(),
-					Type:    string(r.Type),
-					Related: r.Peer.SPDXID(),
-				})
-			}
-		}
-
-		if f, ok := o.(*spdx.File); ok {
-			jsonFile, err := json.buildJSONFile(f)
-			if err != nil {
-				return "", fmt.Errorf("serializing json package: %w", err)
-			}
-			jsonDoc.Files = append(jsonDoc.Files, jsonFile)
-
-			// Add the package's relationships to the doc
-			for _, r := range *f.GetRelationships() {
-				jsonDoc.Relationships = append(jsonDoc.Relationships, spdxJSON.Relationship{
-					Element: f.SPDXID(),
-					Type:    string(r.Type),
-					Related: r.Peer.SPDXID(),
-				})
-			}
-		}
-	}
-
-	output, err := gojson.MarshalIndent(jsonDoc, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("marshaling document json: %w", err)
-	}
-	return string(output), nil
+	return strings.TrimSuffix(b.String(), "\n"), nil
 }
 
-// buildJSONPackage converts a SPDX package struct to a json package
-// TODO(puerco): Validate package information to make sure its a valid package.
-func (json *JSON) buildJSONPackage(p *spdx.Package) (jsonPackage spdxJSON.Package, err error) {
-	// Update the Verification code
-	if err := p.ComputeVerificationCode(); err != nil {
-		return jsonPackage, fmt.Errorf("computing verification code: %w", err)
-	}
-
-	// Update the license list
-	if err := p.ComputeLicenseList(); err != nil {
-		return jsonPackage, fmt.Errorf("computing license list from files: %w", err)
-	}
-
-	externalRefs := make([]spdxJSON.ExternalRef, len(p.ExternalRefs))
-	for i, ref := range p.ExternalRefs {
-		externalRefs[i].Category = ref.Category
-		externalRefs[i].Locator = ref.Locator
-		externalRefs[i].Type = ref.Type
-	}
-	jsonPackage = spdxJSON.Package{
-		ID:                   p.SPDXID(),
-		Name:                 p.Name,
-		Version:              p.Version,
-		FilesAnalyzed:        p.FilesAnalyzed,
-		LicenseConcluded:     p.LicenseConcluded,
-		LicenseDeclared:      p.LicenseDeclared,
-		DownloadLocation:     p.DownloadLocation,
-		LicenseInfoFromFiles: p.LicenseInfoFromFiles,
-		PrimaryPurpose:       p.PrimaryPurpose,
-		CopyrightText:        p.CopyrightText,
-		HasFiles:             []string{},
-		Checksums:            []spdxJSON.Checksum{},
-		ExternalRefs:         externalRefs,
-	}
-
-	if p.Supplier.Organization != "" {
-		jsonPackage.Supplier = "Organization: " + p.Supplier.Organization
-	}
-
-	if p.Supplier.Person != "" {
-		jsonPackage.Supplier = "Person: " + p.Supplier.Person
-	}
-
-	if p.VerificationCode != "" {
-		jsonPackage.VerificationCode = &spdxJSON.PackageVerificationCode{
-			Value: p.VerificationCode,
-		}
-	}
-
-	if spdxJSON.Version == "SPDX-2.2" {
-		if jsonPackage.LicenseConcluded == "" {
-			jsonPackage.LicenseConcluded = spdx.NOASSERTION
-		}
-		if jsonPackage.LicenseDeclared == "" {
-			jsonPackage.LicenseDeclared = spdx.NOASSERTION
-		}
-	} else {
-		if jsonPackage.LicenseConcluded == spdx.NOASSERTION {
-			jsonPackage.LicenseConcluded = ""
-		}
-		if jsonPackage.LicenseDeclared == spdx.NOASSERTION {
-			jsonPackage.LicenseDeclared = ""
-		}
-	}
-
-	if jsonPackage.CopyrightText == "" {
-		jsonPackage.CopyrightText = spdx.NOASSERTION
-	}
-
-	if jsonPackage.DownloadLocation == "" {
-		jsonPackage.DownloadLocation = spdx.NONE
-	}
-
-	for algo, value := range p.Checksum {
-		jsonPackage.Checksums = append(jsonPackage.Checksums, spdxJSON.Checksum{
-			Algorithm: algo,
-			Value:     value,
-		})
-	}
-
-	// If the package has files, we need to add them top hasFiles
-	files := p.Files()
-	if len(files) > 0 {
-		for _, f := range files {
-			if f.SPDXID() == "" {
-				return jsonPackage, errors.New("unable to compute has files array, file missing SPDX ID")
-			}
-			jsonPackage.HasFiles = append(jsonPackage.HasFiles, f.SPDXID())
+// SerializeTo writes the document as indented SPDX JSON to w, or in its
+// canonical form when Canonical is set. The JSON conversion is
+// implemented by the document's MarshalJSON method.
+func (json *JSON) SerializeTo(doc *spdx.Document, w io.Writer) error {
+	if json.Canonical {
+		data, err := gojson.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("marshaling document json: %w", err)
 		}
-	}
-	return jsonPackage, nil
-}
-
-// buildJSONPackage converts a SPDX package struct to a json package
-// TODO(pueco): Validate file information , eg check checksums are
-// enum : [ "SHA256", "SHA1", "SHA384", "MD2", "MD4", "SHA512", "MD6", "MD5", "SHA224" ]
-// "required" : [ "SPDXID", "copyrightText", "fileName", "licenseConcluded" ],.
-func (json *JSON) buildJSONFile(f *spdx.File) (jsonFile spdxJSON.File, err error) {
-	if f.SPDXID() == "" {
-		return jsonFile, errors.New("unamble to serialzie file, it has no SPDX ID defined")
-	}
-	jsonFile = spdxJSON.File{
-		ID:            f.SPDXID(),
-		Name:          f.Name,
-		CopyrightText: f.CopyrightText,
-		// NoticeText:        f.C,
-		LicenseConcluded: f.LicenseConcluded,
-		// Description:       f.Description,
-		FileTypes:         f.FileType,
-		LicenseInfoInFile: []string{f.LicenseInfoInFile},
-		Checksums:         []spdxJSON.Checksum{},
-	}
-
-	if spdxJSON.Version == "SPDX-2.2" {
-		if jsonFile.LicenseConcluded == "" {
-			jsonFile.LicenseConcluded = spdx.NOASSERTION
+		if data, err = Canonicalize(data); err != nil {
+			return fmt.Errorf("canonicalizing document json: %w", err)
 		}
-	} else {
-		if jsonFile.LicenseConcluded == spdx.NOASSERTION {
-			jsonFile.LicenseConcluded = ""
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("writing json document: %w", err)
 		}
+		return nil
 	}
-
-	if jsonFile.CopyrightText == "" {
-		jsonFile.CopyrightText = spdx.NOASSERTION
-	}
-
-	for algo, value := range f.Checksum {
-		jsonFile.Checksums = append(jsonFile.Checksums, spdxJSON.Checksum{
-			Algorithm: algo,
-			Value:     value,
-		})
+	enc := gojson.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("marshaling document json: %w", err)
 	}
-	return jsonFile, nil
+	return nil
 }
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serialize
+
+import (
+	"bytes"
+	gojson "encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx"
+)
+
+// benchmarkDocument returns a document with 10 top level packages,
+// each containing 1000 packages with 10 files.
+func benchmarkDocument(b *testing.B) *spdx.Document {
+	b.Helper()
+	doc := spdx.NewDocument()
+	doc.Name = "benchmark"
+	doc.Namespace = "https://example.com/benchmark"
+	for i := range 10 {
+		top := spdx.NewPackage()
+		top.ID = fmt.Sprintf("SPDXRef-Package-top-%d", i)
+		top.Name = top.ID
+		for j := range 1000 {
+			p := spdx.NewPackage()
+			p.ID = fmt.Sprintf("SPDXRef-Package-%d-%d", i, j)
+			p.Name = fmt.Sprintf("lib%d", j)
+			p.Version = "v1.0.0"
+			p.ExternalRefs = []spdx.ExternalRef{{
+				Category: spdx.CatPackageManager,
+				Type:     "purl",
+				Locator:  fmt.Sprintf("pkg:golang/example.com/lib%d@v1.0.0", j),
+			}}
+			for k := range 10 {
+				f := spdx.NewFile()
+				f.ID = fmt.Sprintf("SPDXRef-File-%d-%d-%d", i, j, k)
+				f.Name = fmt.Sprintf("src/file%d.go", k)
+				f.FileName = f.Name
+				f.Checksum = map[string]string{"SHA256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}
+				if err := p.AddFile(f); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if err := top.AddPackage(p); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := doc.AddPackage(top); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return doc
+}
+
+func BenchmarkSerialize(b *testing.B) {
+	doc := benchmarkDocument(b)
+	for _, tc := range []struct {
+		name       string
+		serializer Serializer
+	}{
+		{"json", &JSON{}},
+		{"tag-value", &TagValue{}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			for range b.N {
+				if _, err := tc.serializer.Serialize(doc); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSerializeTo(t *testing.T) {
+	doc := spdx.NewDocument()
+	doc.Name = "test"
+	doc.Namespace = "https://example.com/test"
+	p := spdx.NewPackage()
+	p.ID = "SPDXRef-Package-test"
+	p.Name = "test"
+	p.Version = "v1.0.0"
+	require.NoError(t, doc.AddPackage(p))
+
+	var buf bytes.Buffer
+	require.NoError(t, SerializeTo(&JSON{}, doc, &buf))
+	parsed := &spdx.Document{}
+	require.NoError(t, gojson.Unmarshal(buf.Bytes(), parsed))
+	require.Equal(t, "test", parsed.Name)
+	require.Contains(t, parsed.Packages, "SPDXRef-Package-test")
+	require.Equal(t, "v1.0.0", parsed.Packages["SPDXRef-Package-test"].Version)
+
+	// Serializers without SerializeTo are written from their string
+	buf.Reset()
+	require.NoError(t, SerializeTo(&TagValue{}, doc, &buf))
+	markup, err := (&TagValue{}).Serialize(doc)
+	require.NoError(t, err)
+	require.Equal(t, markup, buf.String())
+}
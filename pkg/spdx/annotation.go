@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+// Annotation records a comment about an SPDX element made by a person,
+// organization or tool.
+type Annotation struct {
+	Annotator string // Person: Jane Doe (jane@example.com)
+	Date      string // 2010-01-29T18:30:22Z
+	Type      string // REVIEW | OTHER
+	Comment   string // Text of the annotation
+}
+
+// annotationsTemplate renders the annotations of an element. It is
+// included in the templates of the elements that support annotations.
+var annotationsTemplate = `{{ range .Annotations }}Annotator: {{ .Annotator }}
+AnnotationDate: {{ .Date }}
+AnnotationType: {{ .Type }}
+SPDXREF: {{ $.ID }}
+AnnotationComment: <text>{{ .Comment }}
+</text>
+{{ end }}`
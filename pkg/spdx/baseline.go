@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+// Baseline is a previously generated document whose elements are reused
+// when generating a new one. Container images are matched by the digest
+// in their purl and image archives by their checksum, they are taken
+// from the baseline instead of being pulled and analyzed again. Files in
+// scanned directories with the same checksum reuse the licenses found in
+// them. For the results to be the same as a full scan, the baseline has to
+// be generated with the same options.
+type Baseline struct {
+	mtx        sync.Mutex
+	containers map[string]*Package // Image packages by purl and SHA256 checksum
+	files      map[string]*File    // Files by SHA256 checksum
+	reused     int
+}
+
+// NewBaseline indexes the elements of a document to reuse them
+func NewBaseline(doc *Document) *Baseline {
+	b := &Baseline{
+		containers: map[string]*Package{},
+		files:      map[string]*File{},
+	}
+	doc.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The function never fails
+		switch e := o.(type) {
+		case *Package:
+			if e.PrimaryPurpose != "CONTAINER" {
+				return nil
+			}
+			if p := e.Purl(); p != nil && p.Type == "oci" {
+				b.containers["purl:"+p.ToString()] = e
+			}
+			if e.Checksum["SHA256"] != "" {
+				b.containers["sha256:"+e.Checksum["SHA256"]] = e
+			}
+		case *File:
+			if e.Checksum["SHA256"] != "" && e.LicenseInfoInFile != "" {
+				b.files[e.Checksum["SHA256"]] = e
+			}
+		}
+		return nil
+	})
+	return b
+}
+
+// OpenBaseline reads the document in path to use it as baseline
+func OpenBaseline(path string) (*Baseline, error) {
+	doc, err := OpenDoc(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening baseline document: %w", err)
+	}
+	return NewBaseline(doc), nil
+}
+
+// Reused returns the number of elements taken from the baseline
+func (b *Baseline) Reused() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.reused
+}
+
+// imagePackage returns the package of the image with the purl in the
+// baseline, or nil if it has none. Each package is returned only once,
+// so the same image requested again is analyzed again.
+func (b *Baseline) imagePackage(purlString string) *Package {
+	if b == nil || purlString == "" {
+		return nil
+	}
+	p, err := purl.FromString(purlString)
+	if err != nil {
+		return nil
+	}
+	return b.takeContainer("purl:" + p.ToString())
+}
+
+// imageArchivePackage returns the package of an image archive in the
+// baseline with the same checksum as the file in path.
+func (b *Baseline) imageArchivePackage(path string) (*Package, error) {
+	if b == nil {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening image archive: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hashing image archive: %w", err)
+	}
+	return b.takeContainer("sha256:" + hex.EncodeToString(h.Sum(nil))), nil
+}
+
+func (b *Baseline) takeContainer(key string) *Package {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	pkg, ok := b.containers[key]
+	if !ok {
+		return nil
+	}
+	for k, p := range b.containers {
+		if p == pkg {
+			delete(b.containers, k)
+		}
+	}
+	b.reused++
+	return pkg
+}
+
+// fileLicenses copies the licenses found in the baseline file with the
+// same SHA256 checksum as f. Files without licenses get defaultLicense
+// as their concluded license, as when scanning them. Returns false if the
+// baseline has no such file.
+func (b *Baseline) fileLicenses(f *File, defaultLicense string) bool {
+	if b == nil || f.Checksum["SHA256"] == "" {
+		return false
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	prev, ok := b.files[f.Checksum["SHA256"]]
+	if !ok {
+		return false
+	}
+	f.LicenseInfoInFile = prev.LicenseInfoInFile
+	f.LicenseConcluded = prev.LicenseInfoInFile
+	if prev.LicenseInfoInFile == NONE {
+		f.LicenseConcluded = defaultLicense
+	}
+	b.reused++
+	return true
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "image.tar")
+	require.NoError(t, os.WriteFile(archive, []byte("image archive"), 0o600))
+	archiveSum := sha256.Sum256([]byte("image archive"))
+
+	doc := NewDocument()
+	image := NewPackage()
+	image.ID = "SPDXRef-Package-image"
+	image.PrimaryPurpose = "CONTAINER"
+	image.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:oci/nginx@sha256%3A0123456789abcdef?repository_url=index.docker.io%2Flibrary",
+	}}
+	require.NoError(t, doc.AddPackage(image))
+
+	archivePkg := NewPackage()
+	archivePkg.ID = "SPDXRef-Package-archive"
+	archivePkg.PrimaryPurpose = "CONTAINER"
+	archivePkg.Checksum = map[string]string{"SHA256": hex.EncodeToString(archiveSum[:])}
+	require.NoError(t, doc.AddPackage(archivePkg))
+
+	src := NewPackage()
+	src.ID = "SPDXRef-Package-src"
+	for id, lic := range map[string]string{"licensed": "Apache-2.0", "unlicensed": NONE} {
+		f := NewFile()
+		f.ID = "SPDXRef-File-" + id
+		f.Name = id
+		f.LicenseInfoInFile = lic
+		f.LicenseConcluded = "MIT"
+		f.Checksum = map[string]string{"SHA256": id + "-sum"}
+		require.NoError(t, src.AddFile(f))
+	}
+	require.NoError(t, doc.AddPackage(src))
+
+	b := NewBaseline(doc)
+
+	// Images are matched by their purl, once
+	require.Nil(t, b.imagePackage("pkg:oci/nginx@sha256:fedcba?repository_url=index.docker.io/library"))
+	require.Equal(t, image, b.imagePackage("pkg:oci/nginx@sha256:0123456789abcdef?repository_url=index.docker.io/library"))
+	require.Nil(t, b.imagePackage("pkg:oci/nginx@sha256:0123456789abcdef?repository_url=index.docker.io/library"))
+
+	// Image archives by their checksum
+	p, err := b.imageArchivePackage(archive)
+	require.NoError(t, err)
+	require.Equal(t, archivePkg, p)
+
+	// Files reuse the licenses found in them
+	f := NewFile()
+	f.Checksum = map[string]string{"SHA256": "licensed-sum"}
+	require.True(t, b.fileLicenses(f, "BSD-3-Clause"))
+	require.Equal(t, "Apache-2.0", f.LicenseInfoInFile)
+	require.Equal(t, "Apache-2.0", f.LicenseConcluded)
+
+	f = NewFile()
+	f.Checksum = map[string]string{"SHA256": "unlicensed-sum"}
+	require.True(t, b.fileLicenses(f, "BSD-3-Clause"))
+	require.Equal(t, NONE, f.LicenseInfoInFile)
+	require.Equal(t, "BSD-3-Clause", f.LicenseConcluded)
+
+	f = NewFile()
+	f.Checksum = map[string]string{"SHA256": "changed-sum"}
+	require.False(t, b.fileLicenses(f, "BSD-3-Clause"))
+	require.Equal(t, 4, b.Reused())
+
+	// A nil baseline reuses nothing
+	var none *Baseline
+	require.Nil(t, none.imagePackage(image.ExternalRefs[0].Locator))
+	require.False(t, none.fileLicenses(f, ""))
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+const (
+	// BazelModuleFileName is the file declaring a bzlmod module
+	BazelModuleFileName = "MODULE.bazel"
+
+	// purlTypeBazel is the purl type of the modules in the bazel registry
+	purlTypeBazel = "bazel"
+)
+
+// bazelWorkspaceFiles are the files declaring repository rules in
+// workspaces not (fully) migrated to bzlmod
+var bazelWorkspaceFiles = []string{"WORKSPACE", "WORKSPACE.bazel"}
+
+// starlarkAttrRe matches the string and boolean arguments of a rule call
+var starlarkAttrRe = regexp.MustCompile(`(\w+)\s*=\s*(?:"([^"]*)"|'([^']*)'|(True|False))`)
+
+// BazelDependency is an external dependency declared in a bazel
+// workspace.
+type BazelDependency struct {
+	Name    string // Name of the dependency, eg: rules_go
+	Version string // Version of the dependency
+	Purl    string // Package URL of the dependency
+	SHA256  string // Checksum of the dependency artifact, when known
+	Source  string // Workspace file declaring the dependency
+	Dev     bool   // True if the dependency is only used for development
+}
+
+// ReadBazelDependencies reads the external dependencies declared in the
+// bazel workspace at dirPath. The files are read statically: bazel_dep
+// directives from MODULE.bazel, go_repository rules from the WORKSPACE
+// and the .bzl files at the top of the workspace and the maven artifacts
+// pinned in *_install.json lock files. When skipDev is true, development
+// dependencies are not returned. Files that can't be parsed are recorded
+// in the degradation log.
+func ReadBazelDependencies(dirPath string, skipDev bool, log *DegradationLog) ([]*BazelDependency, error) {
+	deps := []*BazelDependency{}
+	read := func(name string, parse func(string, []byte) ([]*BazelDependency, error)) error {
+		data, err := os.ReadFile(filepath.Join(dirPath, name))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+		found, err := parse(name, data)
+		if err != nil {
+			log.record(DegradationScannerFallback, "Unable to read bazel dependencies from %s: %v", name, err)
+			return nil
+		}
+		deps = append(deps, found...)
+		return nil
+	}
+
+	if err := read(BazelModuleFileName, parseBazelModule); err != nil {
+		return nil, err
+	}
+
+	bzlFiles, err := filepath.Glob(filepath.Join(dirPath, "*.bzl"))
+	if err != nil {
+		return nil, fmt.Errorf("searching bzl files: %w", err)
+	}
+	for _, name := range bazelWorkspaceFiles {
+		bzlFiles = append(bzlFiles, filepath.Join(dirPath, name))
+	}
+	for _, path := range bzlFiles {
+		if err := read(filepath.Base(path), parseGoRepositories); err != nil {
+			return nil, err
+		}
+	}
+
+	lockFiles, err := filepath.Glob(filepath.Join(dirPath, "*_install.json"))
+	if err != nil {
+		return nil, fmt.Errorf("searching maven lock files: %w", err)
+	}
+	for _, path := range lockFiles {
+		if err := read(filepath.Base(path), parseMavenInstall); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := map[string]struct{}{}
+	result := []*BazelDependency{}
+	for _, dep := range deps {
+		if skipDev && dep.Dev {
+			continue
+		}
+		key := dep.Purl
+		if key == "" {
+			key = dep.Name + "@" + dep.Version
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, dep)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Source != result[j].Source {
+			return result[i].Source < result[j].Source
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// parseBazelModule returns the modules required by bazel_dep directives
+// in a MODULE.bazel file.
+func parseBazelModule(source string, data []byte) ([]*BazelDependency, error) {
+	deps := []*BazelDependency{}
+	for _, attrs := range starlarkCalls(data, "bazel_dep") {
+		if attrs["name"] == "" {
+			continue
+		}
+		dep := &BazelDependency{
+			Name:    attrs["name"],
+			Version: attrs["version"],
+			Source:  source,
+			Dev:     attrs["dev_dependency"] == "True",
+		}
+		if dep.Version != "" {
+			dep.Purl = purl.NewPackageURL(purlTypeBazel, "", dep.Name, dep.Version, nil, "").ToString()
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// parseGoRepositories returns the go modules fetched by go_repository
+// rules in a WORKSPACE or .bzl file.
+func parseGoRepositories(source string, data []byte) ([]*BazelDependency, error) {
+	deps := []*BazelDependency{}
+	for _, attrs := range starlarkCalls(data, "go_repository") {
+		if attrs["importpath"] == "" {
+			continue
+		}
+		gopkg := &GoPackage{ImportPath: attrs["importpath"], Revision: attrs["version"]}
+		if gopkg.Revision == "" {
+			gopkg.Revision = attrs["commit"]
+		}
+		deps = append(deps, &BazelDependency{
+			Name:    gopkg.ImportPath,
+			Version: strings.TrimSuffix(gopkg.Revision, "+incompatible"),
+			Purl:    gopkg.PackageURL(),
+			Source:  source,
+		})
+	}
+	return deps, nil
+}
+
+// parseMavenInstall returns the maven artifacts pinned in a lock file
+// written by rules_jvm_external. Both the v1 (dependency_tree) and v2
+// (artifacts) lock file formats are supported.
+func parseMavenInstall(source string, data []byte) ([]*BazelDependency, error) {
+	lock := struct {
+		DependencyTree *struct {
+			Dependencies []struct {
+				Coord  string `json:"coord"`
+				SHA256 string `json:"sha256"`
+			} `json:"dependencies"`
+		} `json:"dependency_tree"`
+		Artifacts map[string]struct {
+			Shasums map[string]string `json:"shasums"`
+			Version string            `json:"version"`
+		} `json:"artifacts"`
+	}{}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("decoding lock file: %w", err)
+	}
+
+	deps := []*BazelDependency{}
+	add := func(group, artifact, version, sha256 string) {
+		c := &mavenCoordinates{GroupID: group, ArtifactID: artifact, Version: version}
+		deps = append(deps, &BazelDependency{
+			Name:    group + ":" + artifact,
+			Version: version,
+			Purl:    c.purl(),
+			SHA256:  sha256,
+			Source:  source,
+		})
+	}
+
+	switch {
+	case lock.DependencyTree != nil:
+		// v1 coordinates: group:artifact[:packaging[:classifier]]:version
+		for _, d := range lock.DependencyTree.Dependencies {
+			parts := strings.Split(d.Coord, ":")
+			if len(parts) < 3 {
+				continue
+			}
+			add(parts[0], parts[1], parts[len(parts)-1], d.SHA256)
+		}
+	case lock.Artifacts != nil:
+		// v2 keys: group:artifact[:packaging:classifier]
+		for key, a := range lock.Artifacts {
+			parts := strings.Split(key, ":")
+			if len(parts) < 2 || a.Version == "" {
+				continue
+			}
+			add(parts[0], parts[1], a.Version, a.Shasums["jar"])
+		}
+	default:
+		return nil, errors.New("file is not a rules_jvm_external lock file")
+	}
+	return deps, nil
+}
+
+// starlarkCalls finds the calls to a function in a starlark file and
+// returns their string and boolean keyword arguments. Commented lines
+// are ignored.
+func starlarkCalls(data []byte, fn string) []map[string]string {
+	lines := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines = append(lines, line)
+		}
+	}
+	text := strings.Join(lines, "\n")
+
+	callRe := regexp.MustCompile(`(?:^|[^\w.])` + regexp.QuoteMeta(fn) + `\s*\(`)
+	calls := []map[string]string{}
+	for _, loc := range callRe.FindAllStringIndex(text, -1) {
+		body := starlarkCallBody(text[loc[1]:])
+		attrs := map[string]string{}
+		for _, m := range starlarkAttrRe.FindAllStringSubmatch(body, -1) {
+			attrs[m[1]] = m[2] + m[3] + m[4]
+		}
+		calls = append(calls, attrs)
+	}
+	return calls
+}
+
+// starlarkCallBody returns the arguments of a call, up to the parenthesis
+// closing it. Parentheses in strings are ignored.
+func starlarkCallBody(s string) string {
+	depth := 1
+	var quote rune
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
+
+// ToSPDXPackage returns a package describing the dependency.
+func (dep *BazelDependency) ToSPDXPackage() *Package {
+	pkg := NewPackage()
+	pkg.Options().Prefix = "bazel"
+	pkg.Name = dep.Name
+	pkg.Version = dep.Version
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.Comment = "Bazel external dependency declared in " + dep.Source
+	pkg.BuildID(dep.Name, dep.Version)
+	if dep.SHA256 != "" {
+		pkg.Checksum = map[string]string{"SHA256": dep.SHA256}
+	}
+	if dep.Purl != "" {
+		pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  dep.Purl,
+		})
+	}
+	return pkg
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testBazelModule = `module(name = "example", version = "1.0.0")
+
+bazel_dep(name = "rules_go", version = "0.50.1")
+bazel_dep(
+    name = "gazelle",
+    version = "0.39.1",  # (keep)
+    repo_name = "bazel_gazelle",
+)
+bazel_dep(name = "rules_testing", version = "0.6.0", dev_dependency = True)
+# bazel_dep(name = "rules_disabled", version = "1.0")
+`
+
+const testBazelDeps = `load("@bazel_gazelle//:deps.bzl", "go_repository")
+
+def go_dependencies():
+    go_repository(
+        name = "com_github_pkg_errors",
+        importpath = "github.com/pkg/errors",
+        sum = "h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=",
+        version = "v0.9.1",
+    )
+    go_repository(
+        name = "org_golang_x_sys",
+        importpath = "golang.org/x/sys",
+        commit = "c0bba94af5f8",
+    )
+`
+
+const testMavenInstallV1 = `{
+  "dependency_tree": {
+    "dependencies": [
+      {"coord": "com.google.guava:guava:31.1-jre", "sha256": "a42edc9cab792e39fe39bb94f3fca655ed157ff87a8af78e1d6ba5b07c4a00ab"},
+      {"coord": "io.netty:netty-transport-native-epoll:jar:linux-x86_64:4.1.100.Final", "sha256": ""}
+    ]
+  }
+}`
+
+const testMavenInstallV2 = `{
+  "version": "2",
+  "artifacts": {
+    "junit:junit": {"shasums": {"jar": "8e495b634469d64fb8acfa3495a065cbacc8a0fff55ce1e31007be4c16dc57d3"}, "version": "4.13.2"}
+  }
+}`
+
+func TestReadBazelDependencies(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		BazelModuleFileName:     testBazelModule,
+		"go_deps.bzl":           testBazelDeps,
+		"maven_install.json":    testMavenInstallV1,
+		"test_install.json":     testMavenInstallV2,
+		"frontend_install.json": `{"name": "not a maven lock file"}`,
+		"WORKSPACE":             "",
+		"third_party/extra.bzl": testBazelDeps,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+	}
+
+	deps, err := ReadBazelDependencies(dir, false, nil)
+	require.NoError(t, err)
+	purls := []string{}
+	for _, dep := range deps {
+		purls = append(purls, dep.Purl)
+	}
+	require.Equal(t, []string{
+		"pkg:bazel/gazelle@0.39.1",
+		"pkg:bazel/rules_go@0.50.1",
+		"pkg:bazel/rules_testing@0.6.0",
+		"pkg:golang/github.com/pkg/errors@v0.9.1",
+		"pkg:golang/golang.org/x/sys@c0bba94af5f8",
+		"pkg:maven/com.google.guava/guava@31.1-jre",
+		"pkg:maven/io.netty/netty-transport-native-epoll@4.1.100.Final",
+		"pkg:maven/junit/junit@4.13.2",
+	}, purls)
+	require.Equal(t, "maven_install.json", deps[5].Source)
+	require.Equal(t, "a42edc9cab792e39fe39bb94f3fca655ed157ff87a8af78e1d6ba5b07c4a00ab", deps[5].SHA256)
+
+	deps, err = ReadBazelDependencies(dir, true, nil)
+	require.NoError(t, err)
+	require.Len(t, deps, 7)
+
+	pkg := deps[0].ToSPDXPackage()
+	require.Equal(t, "gazelle", pkg.Name)
+	require.Equal(t, "0.39.1", pkg.Version)
+	require.Equal(t, "pkg:bazel/gazelle@0.39.1", pkg.ExternalRefs[0].Locator)
+	require.Equal(t, "Bazel external dependency declared in MODULE.bazel", pkg.Comment)
+}
+
+func TestStarlarkCalls(t *testing.T) {
+	calls := starlarkCalls([]byte(`
+native.bazel_dep(name = "ignored")
+bazel_dep(name = "a", version = "1.0", patches = ["//:fix(1).patch"])
+bazel_dep(name = 'b')
+`), "bazel_dep")
+	require.Equal(t, []map[string]string{
+		{"name": "a", "version": "1.0"},
+		{"name": "b"},
+	}, calls)
+}
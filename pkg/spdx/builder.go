@@ -17,11 +17,16 @@ limitations under the License.
 package spdx
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/release-utils/util"
 )
@@ -38,11 +43,23 @@ type YamlBOMConfiguration struct {
 	License   string `yaml:"license"` // Document wide license
 	Name      string `yaml:"name"`
 	Creator   struct {
-		Person string `yaml:"person"`
-		Tool   string `yaml:"tool"`
+		Person       string   `yaml:"person"`
+		Organization string   `yaml:"organization"`
+		Tool         string   `yaml:"tool"`
+		Tools        []string `yaml:"tools"`
+		Comment      string   `yaml:"comment"`
 	} `yaml:"creator"`
-	ExternalDocRefs []ExternalDocumentRef `yaml:"external-docs"`
-	Artifacts       []*YamlBuildArtifact  `yaml:"artifacts"`
+	ExternalDocRefs  []ExternalDocumentRef `yaml:"external-docs"`
+	Artifacts        []*YamlBuildArtifact  `yaml:"artifacts"`
+	Packages         []*ManualPackage      `yaml:"packages"` // Packages bom cannot detect
+	LicenseOverrides []LicenseOverride     `yaml:"license-overrides"`
+	// Describes lists the IDs or names of the top level elements the
+	// document describes, all of them when empty
+	Describes []string `yaml:"describes"`
+	// LicenseListVersion pins the SPDX license list used by the project
+	LicenseListVersion string `yaml:"license-list-version"`
+	// ImagePolicy restricts the image references that can be analyzed
+	ImagePolicy *ImagePolicy `yaml:"image-policy"`
 }
 
 // NewDocBuilderOption is a function with operates on a newDocBuilderSettings object.
@@ -82,11 +99,29 @@ func NewDocBuilder(options ...NewDocBuilderOption) *DocBuilder {
 type DocBuilder struct {
 	options *DocBuilderOptions
 	impl    DocBuilderImplementation
+	report  *GenerationReport
+}
+
+// SetImplementation replaces the implementation driving the builder.
+func (db *DocBuilder) SetImplementation(impl DocBuilderImplementation) {
+	db.impl = impl
+}
+
+// Report returns the report of the last document generated.
+func (db *DocBuilder) Report() *GenerationReport {
+	return db.report
 }
 
 // Generate creates a new SPDX SBOM. The resulting document will describe the all
 // artifacts specified in the DocGenerateOptions struct passed.
 func (db *DocBuilder) Generate(genopts *DocGenerateOptions) (*Document, error) {
+	return db.GenerateContext(context.Background(), genopts)
+}
+
+// GenerateContext creates a new SPDX SBOM like Generate. Cancelling the
+// context aborts the image pulls and package downloads in progress and
+// stops the generation before its next step.
+func (db *DocBuilder) GenerateContext(ctx context.Context, genopts *DocGenerateOptions) (*Document, error) {
 	if err := db.impl.ReadYamlConfiguration(genopts.ConfigFile, genopts); err != nil {
 		return nil, fmt.Errorf("parsing configuration file: %w", err)
 	}
@@ -95,36 +130,81 @@ func (db *DocBuilder) Generate(genopts *DocGenerateOptions) (*Document, error) {
 		return nil, fmt.Errorf("checking build options: %w", err)
 	}
 
+	db.report = newGenerationReport(genopts)
+
 	spdx, err := db.impl.CreateSPDXClient(genopts, db.options)
 	if err != nil {
-		return nil, errors.New("generating spdx client")
+		return nil, fmt.Errorf("generating spdx client: %w", err)
 	}
 
+	// Degradations found from here on are recorded in the document
+	degradations, endOfLife := &DegradationLog{}, &EndOfLifeLog{}
+	spdx.Options().Degradations = degradations
+	spdx.Options().EndOfLife = endOfLife
+	spdx.Options().Report = db.report
+
 	doc, err := db.impl.CreateDocument(genopts, spdx)
 	if err != nil {
 		return nil, fmt.Errorf("creating spdx document: %w", err)
 	}
 
-	if err := db.impl.ScanDirectories(genopts, spdx, doc); err != nil {
-		return nil, fmt.Errorf("scanning directories: %w", err)
+	// Run the generation steps in order, timing them for the report
+	steps := []struct {
+		name   string
+		errMsg string
+		run    func() error
+	}{
+		{"directories", "scanning directories", func() error { return db.impl.ScanDirectories(ctx, genopts, spdx, doc) }},
+		{"images", "scanning images", func() error { return db.impl.ScanImages(ctx, genopts, spdx, doc) }},
+		{"image-archives", "scanning image archives", func() error { return db.impl.ScanImageArchives(genopts, spdx, doc) }},
+		{"archives", "scanning archives", func() error { return db.impl.ScanArchives(genopts, spdx, doc) }},
+		{"rootfs", "scanning root filesystems", func() error { return db.impl.ScanRootfs(genopts, spdx, doc) }},
+		{"files", "scanning files", func() error { return db.impl.ScanFiles(genopts, spdx, doc) }},
+		{"manual-packages", "adding manual packages", func() error { return db.impl.AddManualPackages(genopts, spdx, doc) }},
+		{"purl-packages", "adding packages from purl lists", func() error { return db.impl.AddPurlPackages(ctx, genopts, spdx, doc) }},
+		{"file-names", "normalizing file names", func() error { return db.impl.NormalizeFileNames(genopts, doc) }},
+		{"dedupe", "deduplicating packages", func() error { return db.impl.DeduplicatePackages(genopts, doc) }},
+		{"license-overrides", "applying license overrides", func() error { return db.impl.ApplyLicenseOverrides(genopts, doc) }},
+		{"purpose", "applying package purpose", func() error { return db.impl.ApplyPurposeOverride(genopts, doc) }},
+		{"cpe", "adding CPE identifiers", func() error { return db.impl.AddCPEs(genopts, doc) }},
+		{"registry-status", "checking registry status", func() error { return db.impl.CheckRegistryStatus(ctx, genopts, spdx, doc) }},
+		{"deps-dev", "querying deps.dev", func() error { return db.impl.EnrichFromDepsDev(ctx, genopts, spdx, doc) }},
+		{"persistent-ids", "adding persistent identifiers", func() error { return db.impl.AddPersistentIDs(genopts, doc) }},
+		{"omnibor", "writing OmniBOR graph", func() error { return db.impl.WriteOmniBOR(genopts, doc) }},
+		{"prune", "pruning document", func() error { return db.impl.PruneDocument(genopts, doc) }},
+		{"describes", "setting described elements", func() error { return db.impl.SetDescribedElements(genopts, doc) }},
 	}
-
-	if err := db.impl.ScanImages(genopts, spdx, doc); err != nil {
-		return nil, fmt.Errorf("scanning images: %w", err)
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w", step.errMsg, err)
+		}
+		start := time.Now()
+		err := step.run()
+		db.report.addStep(step.name, time.Since(start))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", step.errMsg, err)
+		}
 	}
 
-	if err := db.impl.ScanImageArchives(genopts, spdx, doc); err != nil {
-		return nil, fmt.Errorf("scanning image archives: %w", err)
+	if baseline := spdx.Options().Baseline; baseline != nil {
+		logrus.Infof("Reused %d images and file scans from the baseline document", baseline.Reused())
 	}
 
-	if err := db.impl.ScanArchives(genopts, spdx, doc); err != nil {
-		return nil, fmt.Errorf("scanning archives: %w", err)
+	// Names and IDs come from packages, images and paths found when
+	// scanning, they are sanitized once the document is complete
+	if n := doc.Sanitize(); n > 0 {
+		logrus.Infof("Sanitized the names or IDs of %d elements for SPDX compliance", n)
 	}
 
-	if err := db.impl.ScanFiles(genopts, spdx, doc); err != nil {
-		return nil, fmt.Errorf("scanning files: %w", err)
+	if genopts.BuildMetadata != nil {
+		genopts.BuildMetadata.Duration = time.Since(db.report.Started)
+		doc.addBuildMetadata(genopts.BuildMetadata)
 	}
 
+	doc.AddEndOfLifeAnnotations(endOfLife.Releases())
+	doc.AddDegradationAnnotations(degradations.Degradations())
+	db.report.finish(doc, degradations.Degradations())
+
 	return doc, nil
 }
 
@@ -133,23 +213,62 @@ type DocGenerateOptions struct {
 	NoGitignore         bool                  // Do not read exclusions from gitignore file
 	ProcessGoModules    bool                  // Analyze go.mod to include data about packages
 	OnlyDirectDeps      bool                  // Only include direct dependencies from go.mod
+	ExcludeDevDeps      bool                  // Do not include development and test dependencies
+	GoOS                string                // Target GOOS to resolve go dependencies
+	GoArch              string                // Target GOARCH to resolve go dependencies
+	GoBuildTags         []string              // Build tags to use when resolving go dependencies
+	GoStdlib            bool                  // Add the go standard library as a dependency
+	DeduplicatePackages bool                  // Render identical packages only once in the document
+	PathPrefixStrip     []string              // Prefixes removed from the names of the files
+	PathPrefixAdd       string                // Prefix added to the names of the files
+	Prune               []string              // Kinds of elements to remove from the document (files, relationships)
+	PrimaryPurpose      string                // Purpose to set in the top level packages, overrides the inferred one
+	Describes           []string              // IDs or names of the top level elements the document describes (defaults to all)
+	CPE                 bool                  // Add CPE identifiers derived from the package purls
+	PersistentIDs       bool                  // Add Software Heritage IDs and gitoids of the scanned sources
+	OmniBORDir          string                // Directory to write the OmniBOR artifact dependency graph
 	ScanLicenses        bool                  // Try to look into files to determine their license
 	ScanImages          bool                  // When true, scan images for OS information
+	DiscoverSignatures  bool                  // Record the cosign signatures and attestations of images
+	DetectSecrets       bool                  // Annotate files containing private keys, certificates and credentials
+	ModelMetadata       bool                  // Record the metadata embedded in machine learning model files
+	CheckRegistries     bool                  // Annotate the packages yanked or deprecated in their registries
+	DepsDev             bool                  // Corroborate licenses and add home pages and scorecards from deps.dev
+	AnnotateKnownEOL    bool                  // Annotate images based on OS releases past their end of life
+	ImageEnvValues      bool                  // Record the values of the image environment variables
 	ConfigFile          string                // Path to SBOM configuration file
 	Format              string                // Output format
 	OutputFile          string                // Output location
 	Name                string                // Name to use in the resulting document
 	Namespace           string                // Namespace for the document (a unique URI)
 	CreatorPerson       string                // Document creator information
+	CreatorOrganization string                // Organization that produced the document
+	CreatorTools        []string              // Tools used to create the document, replaces the bom default
+	CreatorComment      string                // Free form comment about the document creation
 	License             string                // Main license of the document
 	LicenseListVersion  string                // Version of the SPDX list to use
 	Tarballs            []string              // A slice of docker archives (tar)
 	Archives            []string              // A list of archive files to add as packages
+	Rootfs              []string              // Directories to scan as system root filesystems
 	Files               []string              // A slice of naked files to include in the bom
 	Images              []string              // A slice of docker images
 	Directories         []string              // A slice of directories to convert into packages
 	IgnorePatterns      []string              // A slice of regexp patterns to ignore when scanning dirs
+	ManualPackages      []*ManualPackage      // Packages to add to the document as defined by the user
+	LicenseOverrides    []LicenseOverride     // Corrected licenses to apply after scanning
+	OnlyLanguages       []string              // When set, only analyze these language ecosystems
+	MaxManifestDepth    int                   // Levels to search for nested projects in directories
 	ExternalDocumentRef []ExternalDocumentRef // List of external documents related to the bom
+	ImagePolicy         *ImagePolicy          // Rules the image references must satisfy to be analyzed
+	BuildMetadata       *BuildMetadata        // When set, the generation context is recorded in the creator comment
+	Baseline            string                // Previous document to reuse the unchanged images and file scans from
+	PurlLists           []string              // Files listing the package URLs of packages to add, one per line
+	PurlRegistryData    bool                  // Complete the packages from purl lists with their registry data
+	PurlScanLicenses    bool                  // Download the packages from purl lists to scan their licenses
+	ClearlyDefined      bool                  // Look up licenses in ClearlyDefined before scanning package sources
+	LocalSources        bool                  // Read package sources from the go, cargo and pip caches before downloading them
+	CargoVendorDirs     []string              // Directories written by cargo vendor to read crate sources from
+	LicenseScanCacheDir string                // Directory caching the license scan results of package versions
 }
 
 func (o *DocGenerateOptions) Validate() error {
@@ -157,9 +276,12 @@ func (o *DocGenerateOptions) Validate() error {
 		len(o.Files) == 0 &&
 		len(o.Images) == 0 &&
 		len(o.Directories) == 0 &&
-		len(o.Archives) == 0 {
+		len(o.Archives) == 0 &&
+		len(o.Rootfs) == 0 &&
+		len(o.ManualPackages) == 0 &&
+		len(o.PurlLists) == 0 {
 		return errors.New(
-			"to build a document at least an image, tarball, directory or a file has to be specified",
+			"to build a document at least an image, tarball, directory, rootfs, file, package or purl list has to be specified",
 		)
 	}
 
@@ -171,6 +293,38 @@ func (o *DocGenerateOptions) Validate() error {
 	if _, err := url.Parse(o.Namespace); err != nil {
 		return fmt.Errorf("parsing the namespace URL: %w", err)
 	}
+
+	if _, err := ParseLanguages(o.OnlyLanguages); err != nil {
+		return fmt.Errorf("checking language selection: %w", err)
+	}
+
+	for _, t := range o.Prune {
+		if !slices.Contains(PruneTargets, t) {
+			return fmt.Errorf("invalid prune target %q, valid values are: %v", t, PruneTargets)
+		}
+	}
+
+	if o.PrimaryPurpose != "" && !slices.Contains(PackagePurposes, o.PrimaryPurpose) {
+		return fmt.Errorf("invalid package purpose %q, valid values are: %v", o.PrimaryPurpose, PackagePurposes)
+	}
+
+	for i := range o.LicenseOverrides {
+		if err := o.LicenseOverrides[i].Validate(); err != nil {
+			return fmt.Errorf("checking license override #%d: %w", i, err)
+		}
+	}
+
+	if o.ImagePolicy != nil {
+		if err := o.ImagePolicy.Validate(); err != nil {
+			return fmt.Errorf("checking image policy: %w", err)
+		}
+		// Refuse the images before anything is analyzed
+		for _, i := range o.Images {
+			if err := o.ImagePolicy.Check(i); err != nil {
+				return fmt.Errorf("checking image policy: %w", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -181,12 +335,3 @@ type DocBuilderOptions struct {
 var defaultDocBuilderOpts = DocBuilderOptions{
 	WorkDir: filepath.Join(os.TempDir(), "spdx-docbuilder"),
 }
-
-// TODO: Move this to https://github.com/kubernetes-sigs/release-utils/blob/main/util/common.go#L485
-func pathIsOfFile(path string) (bool, error) {
-	fInfo, err := os.Stat(path)
-	if err != nil {
-		return false, err
-	}
-	return !fInfo.IsDir(), nil
-}
@@ -17,9 +17,10 @@ limitations under the License.
 package spdx
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -32,6 +33,10 @@ import (
 	"sigs.k8s.io/bom/pkg/license"
 )
 
+//counterfeiter:generate . DocBuilderImplementation
+
+// DocBuilderImplementation runs each of the steps of the document
+// generation in a DocBuilder.
 type DocBuilderImplementation interface {
 	WriteDoc(*Document, string) error
 	ReadYamlConfiguration(string, *DocGenerateOptions) error
@@ -40,11 +45,25 @@ type DocBuilderImplementation interface {
 
 	// Document generation functions
 	CreateDocument(*DocGenerateOptions, *SPDX) (*Document, error)
-	ScanDirectories(*DocGenerateOptions, *SPDX, *Document) error
-	ScanImages(*DocGenerateOptions, *SPDX, *Document) error
+	ScanDirectories(context.Context, *DocGenerateOptions, *SPDX, *Document) error
+	ScanImages(context.Context, *DocGenerateOptions, *SPDX, *Document) error
 	ScanImageArchives(*DocGenerateOptions, *SPDX, *Document) error
 	ScanArchives(*DocGenerateOptions, *SPDX, *Document) error
+	ScanRootfs(*DocGenerateOptions, *SPDX, *Document) error
 	ScanFiles(*DocGenerateOptions, *SPDX, *Document) error
+	AddManualPackages(*DocGenerateOptions, *SPDX, *Document) error
+	AddPurlPackages(context.Context, *DocGenerateOptions, *SPDX, *Document) error
+	NormalizeFileNames(*DocGenerateOptions, *Document) error
+	DeduplicatePackages(*DocGenerateOptions, *Document) error
+	ApplyLicenseOverrides(*DocGenerateOptions, *Document) error
+	ApplyPurposeOverride(*DocGenerateOptions, *Document) error
+	AddCPEs(*DocGenerateOptions, *Document) error
+	CheckRegistryStatus(context.Context, *DocGenerateOptions, *SPDX, *Document) error
+	EnrichFromDepsDev(context.Context, *DocGenerateOptions, *SPDX, *Document) error
+	AddPersistentIDs(*DocGenerateOptions, *Document) error
+	WriteOmniBOR(*DocGenerateOptions, *Document) error
+	PruneDocument(*DocGenerateOptions, *Document) error
+	SetDescribedElements(*DocGenerateOptions, *Document) error
 }
 
 // defaultDocBuilderImpl is the default implementation for the
@@ -65,7 +84,7 @@ func (builder *defaultDocBuilderImpl) CreateDocument(genopts *DocGenerateOptions
 	}
 
 	// Trim the patch part of the license version
-	v, err := semver.New(ver)
+	v, err := semver.ParseTolerant(ver)
 	if err != nil {
 		return nil, fmt.Errorf("parsing license list semver string %q: %w", ver, err)
 	}
@@ -79,8 +98,30 @@ func (builder *defaultDocBuilderImpl) CreateDocument(genopts *DocGenerateOptions
 		doc.Namespace = "https://spdx.org/spdxdocs/k8s-releng-bom-" + uuid.NewString()
 	}
 
+	// Only the creators configured are recorded, the tools are listed
+	// after the versioned bom entry
 	doc.Creator.Person = genopts.CreatorPerson
+	doc.Creator.Organization = genopts.CreatorOrganization
+	for _, tool := range genopts.CreatorTools {
+		if tool != "bom" && !slices.Contains(doc.Creator.Tool, tool) {
+			doc.Creator.Tool = append(doc.Creator.Tool, tool)
+		}
+	}
+	doc.CreatorComment = genopts.CreatorComment
 	doc.ExternalDocRefs = genopts.ExternalDocumentRef
+
+	// References configured without checksums get them from the
+	// documents they point to
+	for i := range doc.ExternalDocRefs {
+		ref := &doc.ExternalDocRefs[i]
+		if len(ref.Checksums) > 0 || ref.URI == "" {
+			continue
+		}
+		logrus.Infof("Computing checksum of external document %s", ref.URI)
+		if err := ref.ReadSourceFile(ref.URI); err != nil {
+			return nil, fmt.Errorf("computing checksum of external document %s: %w", ref.ID, err)
+		}
+	}
 	return doc, nil
 }
 
@@ -91,8 +132,70 @@ func (builder *defaultDocBuilderImpl) CreateSPDXClient(genopts *DocGenerateOptio
 	}
 	spdx.Options().AnalyzeLayers = genopts.AnalyseLayers
 	spdx.Options().ProcessGoModules = genopts.ProcessGoModules
+	spdx.Options().MaxManifestDepth = genopts.MaxManifestDepth
+	spdx.Options().ExcludeDevDeps = genopts.ExcludeDevDeps
+	spdx.Options().GoOS = genopts.GoOS
+	spdx.Options().GoArch = genopts.GoArch
+	spdx.Options().GoBuildTags = genopts.GoBuildTags
+	spdx.Options().GoStdlib = genopts.GoStdlib
+	spdx.Options().PersistentIDs = genopts.PersistentIDs
+	if len(genopts.OnlyLanguages) > 0 {
+		langs, err := ParseLanguages(genopts.OnlyLanguages)
+		if err != nil {
+			return nil, fmt.Errorf("parsing language selection: %w", err)
+		}
+		if !slices.Contains(langs, LangGo) {
+			spdx.Options().ProcessGoModules = false
+		}
+		if !slices.Contains(langs, LangBazel) {
+			spdx.Options().ProcessBazel = false
+		}
+		if !slices.Contains(langs, LangCMake) {
+			spdx.Options().ProcessCMake = false
+		}
+		if !slices.Contains(langs, LangPython) {
+			spdx.Options().ProcessPython = false
+		}
+		if !slices.Contains(langs, LangNode) {
+			spdx.Options().ProcessNode = false
+		}
+		if !slices.Contains(langs, LangJava) {
+			spdx.Options().ProcessJava = false
+		}
+		if !slices.Contains(langs, LangRust) {
+			spdx.Options().ProcessRust = false
+		}
+	}
 	spdx.Options().ScanImages = genopts.ScanImages
+	spdx.Options().DiscoverSignatures = genopts.DiscoverSignatures
+	spdx.Options().DetectSecrets = genopts.DetectSecrets
+	spdx.Options().ModelMetadata = genopts.ModelMetadata
+	spdx.Options().AnnotateKnownEOL = genopts.AnnotateKnownEOL
+	spdx.Options().ImageEnvValues = genopts.ImageEnvValues
 	spdx.Options().LicenseListVersion = genopts.LicenseListVersion
+	spdx.Options().PurlRegistryData = genopts.PurlRegistryData
+	spdx.Options().PurlScanLicenses = genopts.PurlScanLicenses
+	spdx.Options().ClearlyDefined = genopts.ClearlyDefined
+
+	if genopts.LocalSources || len(genopts.CargoVendorDirs) > 0 {
+		caches := &SourceCaches{}
+		if genopts.LocalSources {
+			caches = DefaultSourceCaches()
+		}
+		caches.CargoVendor = genopts.CargoVendorDirs
+		spdx.Options().SourceCaches = caches
+	}
+	if genopts.LicenseScanCacheDir != "" {
+		spdx.Options().LicenseScanCache = NewLicenseScanCache(genopts.LicenseScanCacheDir)
+	}
+
+	if genopts.Baseline != "" {
+		baseline, err := OpenBaseline(genopts.Baseline)
+		if err != nil {
+			return nil, err
+		}
+		spdx.Options().Baseline = baseline
+	}
 
 	if !util.Exists(opts.WorkDir) {
 		if err := os.MkdirAll(opts.WorkDir, os.FileMode(0o755)); err != nil {
@@ -102,40 +205,31 @@ func (builder *defaultDocBuilderImpl) CreateSPDXClient(genopts *DocGenerateOptio
 	return spdx, nil
 }
 
-func (builder *defaultDocBuilderImpl) ScanDirectories(genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
-	for _, dirPattern := range genopts.Directories {
-		matches, err := filepath.Glob(dirPattern)
+func (builder *defaultDocBuilderImpl) ScanDirectories(ctx context.Context, genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
+	dirs, err := expandInputPaths("directory", genopts.Directories, true)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		logrus.Infof("Processing directory %s", dir)
+		logrus.Infof("Ecosystems detected in %s: %s", dir, DetectLanguages(dir))
+		pkg, err := spdx.PackageFromDirectoryContext(ctx, dir)
 		if err != nil {
-			return fmt.Errorf("globbing directory pattern: %w", err)
+			return fmt.Errorf("generating package from directory: %w", err)
 		}
-		for _, dirMatch := range matches {
-			isFile, err := pathIsOfFile(dirMatch)
-			if err != nil {
-				return fmt.Errorf("stat dir: %w", err)
-			}
-			if isFile {
-				logrus.Debugf("Skipping %s because it's a file", dirMatch)
-				continue
-			}
-			logrus.Infof("Processing directory %s", dirMatch)
-			pkg, err := spdx.PackageFromDirectory(dirMatch)
-			if err != nil {
-				return fmt.Errorf("generating package from directory: %w", err)
-			}
-			doc.ensureUniqueElementID(pkg)
-			if err := doc.AddPackage(pkg); err != nil {
-				return fmt.Errorf("adding directory package to document: %w", err)
-			}
+		doc.ensureUniqueElementID(pkg)
+		if err := doc.AddPackage(pkg); err != nil {
+			return fmt.Errorf("adding directory package to document: %w", err)
 		}
 	}
 	return nil
 }
 
-func (builder *defaultDocBuilderImpl) ScanImages(genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
+func (builder *defaultDocBuilderImpl) ScanImages(ctx context.Context, genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
 	// Process all image references from registries
 	for _, i := range genopts.Images {
 		logrus.Infof("Processing image reference: %s", i)
-		p, err := spdx.ImageRefToPackage(i)
+		p, err := spdx.ImageRefToPackageContext(ctx, i)
 		if err != nil {
 			return fmt.Errorf("generating SPDX package from image ref %s: %w", i, err)
 		}
@@ -150,7 +244,11 @@ func (builder *defaultDocBuilderImpl) ScanImages(genopts *DocGenerateOptions, sp
 
 func (builder *defaultDocBuilderImpl) ScanImageArchives(genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
 	// Process OCI image archives
-	for _, tb := range genopts.Tarballs {
+	tarballs, err := expandInputPaths("image archive", genopts.Tarballs, false)
+	if err != nil {
+		return err
+	}
+	for _, tb := range tarballs {
 		logrus.Infof("Processing image archive %s", tb)
 		p, err := spdx.PackageFromImageTarball(tb)
 		if err != nil {
@@ -167,7 +265,11 @@ func (builder *defaultDocBuilderImpl) ScanImageArchives(genopts *DocGenerateOpti
 
 func (builder *defaultDocBuilderImpl) ScanArchives(genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
 	// Add archive files as packages
-	for _, tf := range genopts.Archives {
+	archives, err := expandInputPaths("archive", genopts.Archives, false)
+	if err != nil {
+		return err
+	}
+	for _, tf := range archives {
 		logrus.Infof("Adding archive file as package: %s", tf)
 		p, err := spdx.PackageFromArchive(tf)
 		if err != nil {
@@ -182,37 +284,219 @@ func (builder *defaultDocBuilderImpl) ScanArchives(genopts *DocGenerateOptions,
 	return nil
 }
 
+func (builder *defaultDocBuilderImpl) ScanRootfs(genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
+	// Add the system root filesystems as operating system packages
+	for _, root := range genopts.Rootfs {
+		logrus.Infof("Scanning root filesystem %s", root)
+		p, err := spdx.PackageFromRootfs(root)
+		if err != nil {
+			return fmt.Errorf("creating spdx package from root filesystem: %w", err)
+		}
+		doc.ensureUniqueElementID(p)
+		doc.ensureUniquePeerIDs(p.GetRelationships())
+		if err := doc.AddPackage(p); err != nil {
+			return fmt.Errorf("adding package to document: %w", err)
+		}
+	}
+	return nil
+}
+
 func (builder *defaultDocBuilderImpl) ScanFiles(genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
 	// Process single files, not part of a package
-	for _, filePattern := range genopts.Files {
-		matches, err := filepath.Glob(filePattern)
+	files, err := expandInputPaths("file", genopts.Files, false)
+	if err != nil {
+		return err
+	}
+	for _, filePath := range files {
+		f, err := spdx.FileFromPath(filePath)
 		if err != nil {
-			return fmt.Errorf("globing files from expression: %w", err)
+			return fmt.Errorf("creating SPDX file: %w", err)
 		}
-		if len(matches) == 0 {
-			logrus.Warnf("%s pattern didn't match any file", filePattern)
+		doc.ensureUniqueElementID(f)
+		if err := doc.AddFile(f); err != nil {
+			return fmt.Errorf("adding file to document: %w", err)
 		}
-		for _, filePath := range matches {
-			isFile, err := pathIsOfFile(filePath)
-			if err != nil {
-				return fmt.Errorf("stat file: %w", err)
-			}
-			if !isFile {
-				continue
-			}
-			f, err := spdx.FileFromPath(filePath)
-			if err != nil {
-				return fmt.Errorf("creating SPDX file: %w", err)
-			}
-			doc.ensureUniqueElementID(f)
-			if err := doc.AddFile(f); err != nil {
-				return fmt.Errorf("adding file to document: %w", err)
+	}
+	return nil
+}
+
+func (builder *defaultDocBuilderImpl) AddManualPackages(genopts *DocGenerateOptions, _ *SPDX, doc *Document) error {
+	// Add the packages defined by the user as top level packages
+	for _, mp := range genopts.ManualPackages {
+		logrus.Infof("Adding manually defined package %s", mp.Name)
+		p, err := mp.ToSPDXPackage()
+		if err != nil {
+			return fmt.Errorf("creating package %s: %w", mp.Name, err)
+		}
+		doc.ensureUniqueElementID(p)
+		if err := doc.AddPackage(p); err != nil {
+			return fmt.Errorf("adding package to document: %w", err)
+		}
+	}
+	return nil
+}
+
+// AddPurlPackages adds a top level package for each purl in the purl
+// lists of the options.
+func (builder *defaultDocBuilderImpl) AddPurlPackages(ctx context.Context, genopts *DocGenerateOptions, spdx *SPDX, doc *Document) error {
+	for _, list := range genopts.PurlLists {
+		pkgs, err := spdx.PackagesFromPurlList(ctx, list)
+		if err != nil {
+			return fmt.Errorf("reading purl list %s: %w", list, err)
+		}
+		logrus.Infof("Adding %d packages from purl list %s", len(pkgs), list)
+		for _, p := range pkgs {
+			doc.ensureUniqueElementID(p)
+			if err := doc.AddPackage(p); err != nil {
+				return fmt.Errorf("adding package to document: %w", err)
 			}
 		}
 	}
 	return nil
 }
 
+// NormalizeFileNames strips and adds the configured prefixes to the
+// names of the files in the document.
+func (builder *defaultDocBuilderImpl) NormalizeFileNames(genopts *DocGenerateOptions, doc *Document) error {
+	if len(genopts.PathPrefixStrip) == 0 && genopts.PathPrefixAdd == "" {
+		return nil
+	}
+	n := doc.NormalizeFileNames(genopts.PathPrefixStrip, genopts.PathPrefixAdd)
+	logrus.Infof("Normalized the names of %d files", n)
+	return nil
+}
+
+func (builder *defaultDocBuilderImpl) DeduplicatePackages(genopts *DocGenerateOptions, doc *Document) error {
+	if !genopts.DeduplicatePackages {
+		return nil
+	}
+	n := doc.DeduplicatePackages()
+	logrus.Infof("Removed %d duplicate packages from the document", n)
+	return nil
+}
+
+func (builder *defaultDocBuilderImpl) ApplyLicenseOverrides(genopts *DocGenerateOptions, doc *Document) error {
+	if len(genopts.LicenseOverrides) == 0 {
+		return nil
+	}
+	n, err := doc.ApplyLicenseOverrides(genopts.LicenseOverrides)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("License overrides modified %d elements in the document", n)
+	return nil
+}
+
+// ApplyPurposeOverride sets the primary purpose of the top level packages
+// in the document, replacing the purpose inferred when scanning them.
+func (builder *defaultDocBuilderImpl) ApplyPurposeOverride(genopts *DocGenerateOptions, doc *Document) error {
+	if genopts.PrimaryPurpose == "" {
+		return nil
+	}
+	for _, p := range doc.Packages {
+		p.PrimaryPurpose = genopts.PrimaryPurpose
+	}
+	logrus.Infof("Set the primary purpose of %d packages to %s", len(doc.Packages), genopts.PrimaryPurpose)
+	return nil
+}
+
+// AddCPEs adds best-effort CPE identifiers to the packages in the
+// document, for scanners matching vulnerabilities by CPE instead of purl.
+func (builder *defaultDocBuilderImpl) AddCPEs(genopts *DocGenerateOptions, doc *Document) error {
+	if !genopts.CPE {
+		return nil
+	}
+	n := doc.AddCPEs()
+	logrus.Infof("Added CPE identifiers to %d packages", n)
+	return nil
+}
+
+// CheckRegistryStatus annotates the packages whose versions were yanked
+// or deprecated in their registries.
+func (builder *defaultDocBuilderImpl) CheckRegistryStatus(
+	ctx context.Context, genopts *DocGenerateOptions, spdx *SPDX, doc *Document,
+) error {
+	if !genopts.CheckRegistries {
+		return nil
+	}
+	flagged, err := doc.CheckRegistryStatus(ctx, spdx.Options().Degradations)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Found %d yanked or deprecated package versions", len(flagged))
+	return nil
+}
+
+// EnrichFromDepsDev corroborates the licenses of the packages and adds
+// their home pages and OpenSSF Scorecards from deps.dev.
+func (builder *defaultDocBuilderImpl) EnrichFromDepsDev(
+	ctx context.Context, genopts *DocGenerateOptions, spdx *SPDX, doc *Document,
+) error {
+	if !genopts.DepsDev {
+		return nil
+	}
+	n, err := doc.EnrichFromDepsDev(ctx, spdx.Options().Degradations)
+	if err != nil {
+		return fmt.Errorf("querying deps.dev: %w", err)
+	}
+	logrus.Infof("Found %d package versions in deps.dev", n)
+	return nil
+}
+
+// AddPersistentIDs adds Software Heritage IDs and gitoids to the packages
+// and files read from local files. The IDs of directories are computed
+// when scanning them.
+func (builder *defaultDocBuilderImpl) AddPersistentIDs(genopts *DocGenerateOptions, doc *Document) error {
+	if !genopts.PersistentIDs {
+		return nil
+	}
+	n, err := doc.AddPersistentIDs()
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Added persistent identifiers to %d elements", n)
+	return nil
+}
+
+// WriteOmniBOR writes the input manifests of the scanned directories and
+// archives to an OmniBOR store, linking them from the document.
+func (builder *defaultDocBuilderImpl) WriteOmniBOR(genopts *DocGenerateOptions, doc *Document) error {
+	if genopts.OmniBORDir == "" {
+		return nil
+	}
+	n, err := doc.WriteOmniBOR(genopts.OmniBORDir)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Wrote %d OmniBOR input manifests to %s", n, genopts.OmniBORDir)
+	return nil
+}
+
+func (builder *defaultDocBuilderImpl) PruneDocument(genopts *DocGenerateOptions, doc *Document) error {
+	if len(genopts.Prune) == 0 {
+		return nil
+	}
+	n, err := doc.Prune(genopts.Prune)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("Pruned %d elements from the document", n)
+	return nil
+}
+
+// SetDescribedElements limits the elements described by the document to
+// those set in the options. By default it describes all top level elements.
+func (builder *defaultDocBuilderImpl) SetDescribedElements(genopts *DocGenerateOptions, doc *Document) error {
+	if len(genopts.Describes) == 0 {
+		return nil
+	}
+	if err := doc.SetDescribes(genopts.Describes...); err != nil {
+		return err
+	}
+	logrus.Infof("Document describes %d of %d top level elements", len(doc.Describes), len(doc.Packages)+len(doc.Files))
+	return nil
+}
+
 // ReadYamlConfiguration reads a yaml configuration and
 // set the values in an options struct.
 func (builder *defaultDocBuilderImpl) ReadYamlConfiguration(
@@ -245,11 +529,40 @@ func (builder *defaultDocBuilderImpl) ReadYamlConfiguration(
 		genopts.CreatorPerson = conf.Creator.Person
 	}
 
+	if conf.Creator.Organization != "" {
+		genopts.CreatorOrganization = conf.Creator.Organization
+	}
+
+	if conf.Creator.Tool != "" {
+		genopts.CreatorTools = append(genopts.CreatorTools, conf.Creator.Tool)
+	}
+	genopts.CreatorTools = append(genopts.CreatorTools, conf.Creator.Tools...)
+
+	if conf.Creator.Comment != "" {
+		genopts.CreatorComment = conf.Creator.Comment
+	}
+
 	if conf.License != "" {
 		genopts.License = conf.License
 	}
 
+	if conf.LicenseListVersion != "" {
+		genopts.LicenseListVersion = license.ListVersionTag(conf.LicenseListVersion)
+	}
+
 	genopts.ExternalDocumentRef = conf.ExternalDocRefs
+	genopts.ManualPackages = append(genopts.ManualPackages, conf.Packages...)
+	genopts.LicenseOverrides = append(genopts.LicenseOverrides, conf.LicenseOverrides...)
+	genopts.Describes = append(genopts.Describes, conf.Describes...)
+
+	// The image policy from the configuration keeps a digest requirement
+	// set from the command line
+	if conf.ImagePolicy != nil {
+		if genopts.ImagePolicy != nil && genopts.ImagePolicy.RequireDigest {
+			conf.ImagePolicy.RequireDigest = true
+		}
+		genopts.ImagePolicy = conf.ImagePolicy
+	}
 
 	// Add all the artifacts
 	for _, artifact := range conf.Artifacts {
@@ -265,6 +578,8 @@ func (builder *defaultDocBuilderImpl) ReadYamlConfiguration(
 			genopts.Files = append(genopts.Files, artifact.Source)
 		case "archive":
 			genopts.Archives = append(genopts.Archives, artifact.Source)
+		case "rootfs":
+			genopts.Rootfs = append(genopts.Rootfs, artifact.Source)
 		}
 	}
 
@@ -18,6 +18,7 @@ package spdx
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -29,7 +30,9 @@ license: Apache-2.0
 name: bom-test
 creator:
     person: Kubernetes Release Managers (release-managers@kubernetes.io)
+    organization: The Kubernetes Authors
     tool: bom
+    comment: Generated in the release pipeline
 artifacts:
     - type: directory
       source: .
@@ -41,10 +44,13 @@ artifacts:
       source: registry.k8s.io/kube-apiserver:v1.22.0-alpha.2
     - type: docker-archive
       source: tmp/sample-images/kube-apiserver.tar
+image-policy:
+    allow:
+      - registry.k8s.io
 `
 
 func TestYAMLParse(t *testing.T) {
-	opts := &DocGenerateOptions{}
+	opts := &DocGenerateOptions{ImagePolicy: &ImagePolicy{RequireDigest: true}}
 	impl := defaultDocBuilderImpl{}
 	f, err := os.CreateTemp("", "*.yaml")
 	require.NoError(t, err)
@@ -64,7 +70,54 @@ func TestYAMLParse(t *testing.T) {
 	require.Equal(t, "tmp/sample-images/kube-apiserver.tar", opts.Tarballs[0])
 
 	require.Equal(t, "Kubernetes Release Managers (release-managers@kubernetes.io)", opts.CreatorPerson)
+	require.Equal(t, "The Kubernetes Authors", opts.CreatorOrganization)
+	require.Equal(t, []string{"bom"}, opts.CreatorTools)
+	require.Equal(t, "Generated in the release pipeline", opts.CreatorComment)
 	require.Equal(t, "http://www.example.com/", opts.Namespace)
 	require.Equal(t, "bom-test", opts.Name)
 	require.Equal(t, "Apache-2.0", opts.License)
+
+	// The digest requirement set in the options is kept
+	require.Equal(t, &ImagePolicy{RequireDigest: true, Allow: []string{"registry.k8s.io"}}, opts.ImagePolicy)
+}
+
+func TestApplyPurposeOverride(t *testing.T) {
+	impl := defaultDocBuilderImpl{}
+	doc := NewDocument()
+	sub := &Package{Entity: Entity{ID: "SPDXRef-Package-sub", Name: "sub"}, PrimaryPurpose: "LIBRARY"}
+	top := &Package{Entity: Entity{ID: "SPDXRef-Package-top", Name: "top"}, PrimaryPurpose: "SOURCE"}
+	require.NoError(t, top.AddPackage(sub))
+	require.NoError(t, doc.AddPackage(top))
+
+	// No override leaves the inferred purpose
+	require.NoError(t, impl.ApplyPurposeOverride(&DocGenerateOptions{}, doc))
+	require.Equal(t, "SOURCE", top.PrimaryPurpose)
+
+	require.NoError(t, impl.ApplyPurposeOverride(&DocGenerateOptions{PrimaryPurpose: "APPLICATION"}, doc))
+	require.Equal(t, "APPLICATION", top.PrimaryPurpose)
+	require.Equal(t, "LIBRARY", sub.PrimaryPurpose)
+
+	opts := &DocGenerateOptions{Files: []string{"README.md"}, PrimaryPurpose: "BINARY"}
+	require.Error(t, opts.Validate())
+	opts.PrimaryPurpose = "CONTAINER"
+	require.NoError(t, opts.Validate())
+}
+
+func TestCreateDocumentCreators(t *testing.T) {
+	builder := &defaultDocBuilderImpl{}
+	doc, err := builder.CreateDocument(&DocGenerateOptions{}, nil)
+	require.NoError(t, err)
+	require.Empty(t, doc.Creator.Person)
+	require.Empty(t, doc.Creator.Organization)
+	require.Len(t, doc.Creator.Tool, 1)
+	bomTool := doc.Creator.Tool[0]
+	require.True(t, strings.HasPrefix(bomTool, "bom-"))
+
+	doc, err = builder.CreateDocument(&DocGenerateOptions{
+		CreatorOrganization: "Example Org",
+		CreatorTools:        []string{"bom", "syft-1.0.0"},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "Example Org", doc.Creator.Organization)
+	require.Equal(t, []string{bomTool, "syft-1.0.0"}, doc.Creator.Tool)
 }
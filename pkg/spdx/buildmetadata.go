@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// BuildMetadata describes the context where a document was generated. It
+// is recorded in the creator comment so readers can tell how an SBOM was
+// produced.
+type BuildMetadata struct {
+	BOMVersion string        // Version of bom generating the document
+	Command    []string      // Command line, without secrets
+	Host       string        // Hostname of the machine running bom
+	CIJobURL   string        // URL of the CI job running bom
+	Duration   time.Duration // Time taken to generate the document
+}
+
+// urlCredentialsRe matches the user information of URLs in arguments
+var urlCredentialsRe = regexp.MustCompile(`://[^/@\s]+@`)
+
+// ciJobURLFuncs read the URL of the running job from the environment of
+// the supported CI systems.
+var ciJobURLFuncs = []func() string{
+	// GitHub Actions
+	func() string {
+		if os.Getenv("GITHUB_RUN_ID") == "" || os.Getenv("GITHUB_REPOSITORY") == "" {
+			return ""
+		}
+		server := os.Getenv("GITHUB_SERVER_URL")
+		if server == "" {
+			server = "https://github.com"
+		}
+		return fmt.Sprintf("%s/%s/actions/runs/%s", server, os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"))
+	},
+	// GitLab CI
+	func() string { return os.Getenv("CI_JOB_URL") },
+	// Jenkins
+	func() string { return os.Getenv("BUILD_URL") },
+	// CircleCI
+	func() string { return os.Getenv("CIRCLE_BUILD_URL") },
+	// Buildkite
+	func() string { return os.Getenv("BUILDKITE_BUILD_URL") },
+}
+
+// NewBuildMetadata returns the metadata of the current environment for
+// a generation run with the specified command line (as in os.Args).
+// Credentials in URLs are removed from the arguments.
+func NewBuildMetadata(args []string) *BuildMetadata {
+	md := &BuildMetadata{
+		BOMVersion: version.GetVersionInfo().GitVersion,
+	}
+	for i, arg := range args {
+		if i == 0 {
+			arg = filepath.Base(arg)
+		}
+		md.Command = append(md.Command, urlCredentialsRe.ReplaceAllString(arg, "://"))
+	}
+	if host, err := os.Hostname(); err == nil {
+		md.Host = host
+	}
+	for _, fn := range ciJobURLFuncs {
+		if u := fn(); u != "" {
+			md.CIJobURL = u
+			break
+		}
+	}
+	return md
+}
+
+// String returns the metadata as text to add to a document comment.
+func (md *BuildMetadata) String() string {
+	lines := []string{"Build metadata:", "bom version: " + md.BOMVersion}
+	if len(md.Command) > 0 {
+		lines = append(lines, "Command: "+strings.Join(md.Command, " "))
+	}
+	if md.Host != "" {
+		lines = append(lines, "Host: "+md.Host)
+	}
+	if md.CIJobURL != "" {
+		lines = append(lines, "CI job: "+md.CIJobURL)
+	}
+	if md.Duration > 0 {
+		lines = append(lines, "Duration: "+md.Duration.Round(time.Millisecond).String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// addBuildMetadata appends the build metadata to the creator comment of
+// the document.
+func (d *Document) addBuildMetadata(md *BuildMetadata) {
+	if d.CreatorComment == "" {
+		d.CreatorComment = md.String()
+		return
+	}
+	d.CreatorComment = strings.TrimSpace(d.CreatorComment) + "\n\n" + md.String()
+}
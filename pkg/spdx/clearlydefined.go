@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// clearlyDefinedURL is the endpoint of the ClearlyDefined API
+var clearlyDefinedURL = "https://api.clearlydefined.io"
+
+// clearlyDefinedBatchSize is the number of coordinates requested at once
+const clearlyDefinedBatchSize = 500
+
+// clearlyDefinedProviders are the ClearlyDefined type and provider of
+// each purl type
+var clearlyDefinedProviders = map[string][2]string{
+	purl.TypeGolang:   {"go", "golang"},
+	purl.TypeNPM:      {"npm", "npmjs"},
+	purl.TypePyPi:     {"pypi", "pypi"},
+	purl.TypeMaven:    {"maven", "mavencentral"},
+	purl.TypeCargo:    {"crate", "cratesio"},
+	purl.TypeGem:      {"gem", "rubygems"},
+	purl.TypeNuget:    {"nuget", "nuget"},
+	purl.TypeComposer: {"composer", "packagist"},
+}
+
+// ClearlyDefinedLicense is the license data ClearlyDefined has about a
+// package version
+type ClearlyDefinedLicense struct {
+	License   string // License expression of the package
+	Copyright string // Copyright statements found in the package
+}
+
+// clearlyDefinedDefinition is the part of a ClearlyDefined definition
+// that bom reads
+type clearlyDefinedDefinition struct {
+	Licensed struct {
+		Declared string `json:"declared"`
+		Facets   struct {
+			Core struct {
+				Attribution struct {
+					Parties []string `json:"parties"`
+				} `json:"attribution"`
+				Discovered struct {
+					Expressions []string `json:"expressions"`
+				} `json:"discovered"`
+			} `json:"core"`
+		} `json:"facets"`
+	} `json:"licensed"`
+}
+
+// clearlyDefinedCoordinates returns the ClearlyDefined coordinates of a
+// package URL or an empty string if ClearlyDefined does not know its type
+func clearlyDefinedCoordinates(pu *purl.PackageURL) string {
+	provider, ok := clearlyDefinedProviders[pu.Type]
+	if !ok || pu.Name == "" || pu.Version == "" {
+		return ""
+	}
+	namespace := "-"
+	if pu.Namespace != "" {
+		// Slashes in go namespaces are part of the coordinate segment
+		namespace = strings.ReplaceAll(pu.Namespace, "/", "%2f")
+	}
+	return strings.Join([]string{provider[0], provider[1], namespace, pu.Name, pu.Version}, "/")
+}
+
+// ClearlyDefinedLicenses looks up package URLs in ClearlyDefined and
+// returns the license data found, indexed by purl. The declared license
+// of a package is preferred, when it has none the license discovered in
+// its files is used if there is only one. Packages unknown to
+// ClearlyDefined or without license data are not returned.
+func ClearlyDefinedLicenses(ctx context.Context, purls []string) (map[string]ClearlyDefinedLicense, error) {
+	coordinates := map[string][]string{}
+	batch := []string{}
+	for _, p := range purls {
+		pu, err := purl.FromString(p)
+		if err != nil {
+			continue
+		}
+		coords := clearlyDefinedCoordinates(&pu)
+		if coords == "" {
+			continue
+		}
+		if _, ok := coordinates[coords]; !ok {
+			batch = append(batch, coords)
+		}
+		coordinates[coords] = append(coordinates[coords], p)
+	}
+	logrus.Infof("Looking up the licenses of %d packages in ClearlyDefined", len(batch))
+
+	licenses := map[string]ClearlyDefinedLicense{}
+	for start := 0; start < len(batch); start += clearlyDefinedBatchSize {
+		definitions, err := clearlyDefinedDefinitions(
+			ctx, batch[start:min(start+clearlyDefinedBatchSize, len(batch))],
+		)
+		if err != nil {
+			return nil, err
+		}
+		for coords, def := range definitions {
+			license := def.Licensed.Declared
+			if !clearlyDefinedValidLicense(license) {
+				license = ""
+				if e := def.Licensed.Facets.Core.Discovered.Expressions; len(e) == 1 && clearlyDefinedValidLicense(e[0]) {
+					license = e[0]
+				}
+			}
+			if license == "" {
+				continue
+			}
+			for _, p := range coordinates[coords] {
+				licenses[p] = ClearlyDefinedLicense{
+					License:   license,
+					Copyright: strings.Join(def.Licensed.Facets.Core.Attribution.Parties, "\n"),
+				}
+			}
+		}
+	}
+	return licenses, nil
+}
+
+// clearlyDefinedValidLicense returns false for the placeholders
+// ClearlyDefined uses when it could not determine a license
+func clearlyDefinedValidLicense(license string) bool {
+	return license != "" && license != NOASSERTION && license != NONE &&
+		license != "OTHER" && !strings.Contains(license, NOASSERTION)
+}
+
+// clearlyDefinedDefinitions requests a batch of definitions to
+// ClearlyDefined
+func clearlyDefinedDefinitions(ctx context.Context, coordinates []string) (map[string]clearlyDefinedDefinition, error) {
+	body, err := json.Marshal(coordinates)
+	if err != nil {
+		return nil, fmt.Errorf("encoding coordinates: %w", err)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, clearlyDefinedURL+"/definitions", bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", "bom/"+version.GetVersionInfo().GitVersion)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying ClearlyDefined: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ClearlyDefined returned %s", resp.Status)
+	}
+	definitions := map[string]clearlyDefinedDefinition{}
+	if err := json.NewDecoder(resp.Body).Decode(&definitions); err != nil {
+		return nil, fmt.Errorf("decoding ClearlyDefined response: %w", err)
+	}
+	return definitions, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearlyDefinedCoordinates(t *testing.T) {
+	for _, tc := range []struct {
+		purl     string
+		expected string
+	}{
+		{"pkg:golang/github.com/sirupsen/logrus@v1.9.3", "go/golang/github.com%2fsirupsen/logrus/v1.9.3"},
+		{"pkg:npm/%40babel/core@7.0.0", "npm/npmjs/@babel/core/7.0.0"},
+		{"pkg:npm/lodash@4.17.21", "npm/npmjs/-/lodash/4.17.21"},
+		{"pkg:pypi/requests@2.32.0", "pypi/pypi/-/requests/2.32.0"},
+		{"pkg:maven/org.slf4j/slf4j-api@2.0.16", "maven/mavencentral/org.slf4j/slf4j-api/2.0.16"},
+		{"pkg:cargo/serde@1.0.0", "crate/cratesio/-/serde/1.0.0"},
+		{"pkg:pypi/requests", ""},
+		{"pkg:deb/debian/bash@5.2.15", ""},
+	} {
+		pu, err := purl.FromString(tc.purl)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, clearlyDefinedCoordinates(&pu), tc.purl)
+	}
+}
+
+func TestClearlyDefinedLicenses(t *testing.T) {
+	requested := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/definitions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&requested))
+		w.Write([]byte(`{
+			"go/golang/github.com%2fsirupsen/logrus/v1.9.3": {"licensed": {
+				"declared": "MIT",
+				"facets": {"core": {"attribution": {"parties": ["Copyright (c) 2014 Simon Eskildsen"]}}}
+			}},
+			"pypi/pypi/-/requests/2.32.0": {"licensed": {
+				"declared": "NOASSERTION",
+				"facets": {"core": {"discovered": {"expressions": ["Apache-2.0"]}}}
+			}},
+			"npm/npmjs/-/lodash/4.17.21": {"licensed": {
+				"declared": "OTHER",
+				"facets": {"core": {"discovered": {"expressions": ["MIT", "CC0-1.0"]}}}
+			}},
+			"crate/cratesio/-/serde/1.0.0": {}
+		}`)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	oldURL := clearlyDefinedURL
+	clearlyDefinedURL = srv.URL
+	defer func() { clearlyDefinedURL = oldURL }()
+
+	licenses, err := ClearlyDefinedLicenses(context.Background(), []string{
+		"pkg:golang/github.com/sirupsen/logrus@v1.9.3",
+		"pkg:pypi/requests@2.32.0",
+		"pkg:pypi/requests@2.32.0?file_name=requests-2.32.0.tar.gz",
+		"pkg:npm/lodash@4.17.21",
+		"pkg:cargo/serde@1.0.0",
+		"pkg:deb/debian/bash@5.2.15",
+	})
+	require.NoError(t, err)
+
+	// Purls sharing coordinates are only requested once
+	require.Equal(t, []string{
+		"go/golang/github.com%2fsirupsen/logrus/v1.9.3",
+		"pypi/pypi/-/requests/2.32.0",
+		"npm/npmjs/-/lodash/4.17.21",
+		"crate/cratesio/-/serde/1.0.0",
+	}, requested)
+	require.Equal(t, map[string]ClearlyDefinedLicense{
+		"pkg:golang/github.com/sirupsen/logrus@v1.9.3": {
+			License: "MIT", Copyright: "Copyright (c) 2014 Simon Eskildsen",
+		},
+		"pkg:pypi/requests@2.32.0":                                  {License: "Apache-2.0"},
+		"pkg:pypi/requests@2.32.0?file_name=requests-2.32.0.tar.gz": {License: "Apache-2.0"},
+	}, licenses)
+}
+
+func TestClearlyDefinedLicensesError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	oldURL := clearlyDefinedURL
+	clearlyDefinedURL = srv.URL
+	defer func() { clearlyDefinedURL = oldURL }()
+
+	_, err := ClearlyDefinedLicenses(context.Background(), []string{"pkg:npm/lodash@4.17.21"})
+	require.Error(t, err)
+
+	// Nothing is requested when no purl has coordinates
+	licenses, err := ClearlyDefinedLicenses(context.Background(), []string{"pkg:deb/debian/bash@5.2.15"})
+	require.NoError(t, err)
+	require.Empty(t, licenses)
+}
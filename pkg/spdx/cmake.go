@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+const (
+	// CMakeListsFileName is the file defining a CMake project
+	CMakeListsFileName = "CMakeLists.txt"
+
+	// gitModulesFileName lists the submodules of a git repository
+	gitModulesFileName = ".gitmodules"
+)
+
+var (
+	// cmakeFetchRe matches the commands declaring external content
+	cmakeFetchRe = regexp.MustCompile(`(?i)\b(FetchContent_Declare|ExternalProject_Add)\s*\(`)
+
+	// cmakeSetRe matches simple variable definitions: set(NAME value)
+	cmakeSetRe = regexp.MustCompile(`(?i)\bset\s*\(\s*(\w+)\s+"?([^\s")]+)"?\s*\)`)
+
+	// cmakeVarRe matches variable references: ${NAME}
+	cmakeVarRe = regexp.MustCompile(`\$\{(\w+)\}`)
+
+	// gitCommitRe matches a full git commit hash
+	gitCommitRe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// CMakeDependency is an external C/C++ dependency fetched by a CMake
+// project or checked out as a git submodule.
+type CMakeDependency struct {
+	Name     string // Name of the dependency, eg: googletest
+	Version  string // Git tag, branch or commit of the dependency
+	URL      string // Git repository or archive the dependency is fetched from
+	SHA256   string // Checksum of the fetched archive, when declared
+	Source   string // File declaring the dependency
+	Checkout bool   // True if the URL is a git repository
+}
+
+// ReadCMakeDependencies searches a CMake project for the external content
+// it fetches with FetchContent_Declare and ExternalProject_Add and for
+// the git submodules declared in its .gitmodules file. This is a best
+// effort scan: the CMake files are not evaluated, only the variables set
+// to literal values in the same file are expanded. The dependencies that
+// can't be located are recorded in the degradation log.
+func ReadCMakeDependencies(dirPath string, log *DegradationLog) ([]*CMakeDependency, error) {
+	deps := []*CMakeDependency{}
+	err := filepath.WalkDir(dirPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip hidden, vendored and build directories, the latter
+			// hold copies of the fetched projects
+			if p != dirPath && (strings.HasPrefix(d.Name(), ".") ||
+				slices.Contains(skipManifestDirs, d.Name()) || d.Name() == "_deps" ||
+				util.Exists(filepath.Join(p, "CMakeCache.txt"))) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != CMakeListsFileName && !strings.HasSuffix(d.Name(), ".cmake") {
+			return nil
+		}
+		rel, err := filepath.Rel(dirPath, p)
+		if err != nil {
+			return fmt.Errorf("computing relative path: %w", err)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", rel, err)
+		}
+		deps = append(deps, parseCMakeFetches(filepath.ToSlash(rel), string(data), log)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("searching cmake files: %w", err)
+	}
+
+	submodules, err := readGitSubmodules(dirPath)
+	if err != nil {
+		log.record(DegradationScannerFallback, "Unable to read git submodules: %v", err)
+	}
+	return append(deps, submodules...), nil
+}
+
+// parseCMakeFetches returns the external content declared in a CMake file
+func parseCMakeFetches(source, text string, log *DegradationLog) []*CMakeDependency {
+	// Drop the comments before looking at the commands
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if i := strings.Index(line, "#"); i != -1 {
+			line = line[:i]
+		}
+		lines = append(lines, line)
+	}
+	text = strings.Join(lines, "\n")
+
+	vars := map[string]string{}
+	for _, m := range cmakeSetRe.FindAllStringSubmatch(text, -1) {
+		vars[m[1]] = m[2]
+	}
+	expand := func(s string) string {
+		return cmakeVarRe.ReplaceAllStringFunc(s, func(ref string) string {
+			if v, ok := vars[cmakeVarRe.FindStringSubmatch(ref)[1]]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+
+	deps := []*CMakeDependency{}
+	for _, loc := range cmakeFetchRe.FindAllStringIndex(text, -1) {
+		args := cmakeArguments(text[loc[1]:])
+		if len(args) == 0 {
+			continue
+		}
+		dep := &CMakeDependency{Name: expand(args[0]), Source: source}
+		for i := 1; i < len(args)-1; i++ {
+			value := expand(args[i+1])
+			switch strings.ToUpper(args[i]) {
+			case "GIT_REPOSITORY":
+				dep.URL, dep.Checkout = value, true
+			case "GIT_TAG":
+				dep.Version = value
+			case "URL":
+				dep.URL = value
+			case "URL_HASH":
+				if algo, sum, ok := strings.Cut(value, "="); ok && strings.EqualFold(algo, "SHA256") {
+					dep.SHA256 = strings.ToLower(sum)
+				}
+			default:
+				continue
+			}
+			i++
+		}
+		if dep.URL == "" || strings.Contains(dep.URL, "${") {
+			log.record(
+				DegradationScannerFallback, "Unable to determine the location of %s declared in %s", dep.Name, source,
+			)
+			continue
+		}
+		deps = append(deps, dep)
+	}
+	return deps
+}
+
+// cmakeArguments splits the arguments of a CMake command, up to the
+// parenthesis closing it.
+func cmakeArguments(s string) []string {
+	args := []string{}
+	var current strings.Builder
+	inQuote := false
+	flush := func() {
+		if current.Len() > 0 {
+			args = append(args, current.String())
+			current.Reset()
+		}
+	}
+	for _, c := range s {
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+			current.WriteRune(c)
+		case c == ')':
+			flush()
+			return args
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			flush()
+		default:
+			current.WriteRune(c)
+		}
+	}
+	flush()
+	return args
+}
+
+// readGitSubmodules returns the submodules declared in the .gitmodules
+// file of a directory. The version of a submodule is the commit checked
+// out in it, when the submodule is initialized.
+func readGitSubmodules(dirPath string) ([]*CMakeDependency, error) {
+	f, err := os.Open(filepath.Join(dirPath, gitModulesFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", gitModulesFileName, err)
+	}
+	defer f.Close()
+
+	deps := []*CMakeDependency{}
+	var current *CMakeDependency
+	var subPath string
+	add := func() {
+		if current != nil && current.URL != "" {
+			current.Version = readSubmoduleCommit(dirPath, subPath)
+			deps = append(deps, current)
+		}
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[submodule") {
+			add()
+			subPath = ""
+			current = &CMakeDependency{Source: gitModulesFileName, Checkout: true}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if current == nil || !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "path":
+			subPath = value
+			current.Name = path.Base(value)
+		case "url":
+			current.URL = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", gitModulesFileName, err)
+	}
+	add()
+	return deps, nil
+}
+
+// readSubmoduleCommit returns the commit checked out in a submodule or an
+// empty string if it cannot be determined.
+func readSubmoduleCommit(dirPath, subPath string) string {
+	if subPath == "" {
+		return ""
+	}
+	// Initialized submodules have a .git file pointing to their git dir
+	gitFile := filepath.Join(dirPath, filepath.FromSlash(subPath), ".git")
+	data, err := os.ReadFile(gitFile)
+	if err != nil {
+		return ""
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(gitFile), gitDir)
+	}
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	if commit := strings.TrimSpace(string(head)); gitCommitRe.MatchString(commit) {
+		return commit
+	}
+	return ""
+}
+
+// ToSPDXPackage returns a package describing the dependency, with a
+// generic purl pointing to where it is fetched from.
+func (dep *CMakeDependency) ToSPDXPackage() *Package {
+	pkg := NewPackage()
+	pkg.Options().Prefix = "cmake"
+	pkg.Name = dep.Name
+	pkg.Version = dep.Version
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.Comment = "External C/C++ dependency declared in " + dep.Source
+	pkg.BuildID(dep.Source, dep.Name, dep.Version)
+
+	qualifiers := map[string]string{}
+	if dep.Checkout {
+		pkg.DownloadLocation = "git+" + dep.URL
+		if dep.Version != "" {
+			pkg.DownloadLocation += "@" + dep.Version
+		}
+		qualifiers["vcs_url"] = pkg.DownloadLocation
+	} else {
+		pkg.DownloadLocation = dep.URL
+		qualifiers["download_url"] = dep.URL
+	}
+	if dep.SHA256 != "" {
+		pkg.Checksum = map[string]string{"SHA256": dep.SHA256}
+		qualifiers["checksum"] = "sha256:" + dep.SHA256
+	}
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator: purl.NewPackageURL(
+			purl.TypeGeneric, "", dep.Name, dep.Version, purl.QualifiersFromMap(qualifiers), "",
+		).ToString(),
+	})
+	return pkg
+}
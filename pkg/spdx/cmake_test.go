@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCMakeLists = `cmake_minimum_required(VERSION 3.24)
+project(example CXX)
+
+include(FetchContent)
+set(FMT_VERSION 10.2.1)
+
+FetchContent_Declare(
+  googletest
+  GIT_REPOSITORY https://github.com/google/googletest.git
+  GIT_TAG        v1.14.0 # release
+)
+FetchContent_Declare(fmt
+  URL "https://github.com/fmtlib/fmt/archive/${FMT_VERSION}.tar.gz"
+  URL_HASH SHA256=1250E4CC58BF06EE631567523F48848DC4596133E163F02615C97F78BAB6C811
+)
+# FetchContent_Declare(disabled GIT_REPOSITORY https://example.com/disabled.git)
+ExternalProject_Add(unknown URL ${UNSET_URL})
+`
+
+const testCMakeModule = `ExternalProject_Add(zlib
+  GIT_REPOSITORY "https://github.com/madler/zlib.git"
+  GIT_TAG v1.3.1
+  CMAKE_ARGS -DBUILD_SHARED_LIBS=OFF)
+`
+
+const testGitModules = `[submodule "third_party/abseil-cpp"]
+	path = third_party/abseil-cpp
+	url = https://github.com/abseil/abseil-cpp.git
+[submodule "extern/json"]
+	path = extern/json
+	url = https://github.com/nlohmann/json.git
+`
+
+func TestReadCMakeDependencies(t *testing.T) {
+	dir := t.TempDir()
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	for name, content := range map[string]string{
+		CMakeListsFileName:                          testCMakeLists,
+		"cmake/deps.cmake":                          testCMakeModule,
+		"build/CMakeCache.txt":                      "",
+		"build/_deps/googletest-src/CMakeLists.txt": testCMakeModule,
+		gitModulesFileName:                          testGitModules,
+		"extern/json/.git":                          "gitdir: ../../.git/modules/extern/json\n",
+		".git/modules/extern/json/HEAD":             commit + "\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+	}
+
+	log := &DegradationLog{}
+	deps, err := ReadCMakeDependencies(dir, log)
+	require.NoError(t, err)
+	require.Equal(t, []*CMakeDependency{
+		{
+			Name: "googletest", Version: "v1.14.0", URL: "https://github.com/google/googletest.git",
+			Source: CMakeListsFileName, Checkout: true,
+		},
+		{
+			Name: "fmt", URL: "https://github.com/fmtlib/fmt/archive/10.2.1.tar.gz",
+			SHA256: "1250e4cc58bf06ee631567523f48848dc4596133e163f02615c97f78bab6c811", Source: CMakeListsFileName,
+		},
+		{
+			Name: "zlib", Version: "v1.3.1", URL: "https://github.com/madler/zlib.git",
+			Source: "cmake/deps.cmake", Checkout: true,
+		},
+		{
+			Name: "abseil-cpp", URL: "https://github.com/abseil/abseil-cpp.git",
+			Source: gitModulesFileName, Checkout: true,
+		},
+		{
+			Name: "json", Version: commit, URL: "https://github.com/nlohmann/json.git",
+			Source: gitModulesFileName, Checkout: true,
+		},
+	}, deps)
+
+	// Dependencies with unresolved locations are recorded as degradations
+	require.Len(t, log.Degradations(), 1)
+	require.Contains(t, log.Degradations()[0].Message, "unknown declared in CMakeLists.txt")
+
+	pkg := deps[0].ToSPDXPackage()
+	require.Equal(t, "git+https://github.com/google/googletest.git@v1.14.0", pkg.DownloadLocation)
+	require.Equal(t,
+		"pkg:generic/googletest@v1.14.0?vcs_url=git%2Bhttps%3A%2F%2Fgithub.com%2Fgoogle%2Fgoogletest.git%40v1.14.0",
+		pkg.ExternalRefs[0].Locator,
+	)
+
+	pkg = deps[1].ToSPDXPackage()
+	require.Equal(t, "https://github.com/fmtlib/fmt/archive/10.2.1.tar.gz", pkg.DownloadLocation)
+	require.Equal(t, "1250e4cc58bf06ee631567523f48848dc4596133e163f02615c97f78bab6c811", pkg.Checksum["SHA256"])
+}
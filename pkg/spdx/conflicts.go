@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+// PurlConflictKind classifies the inconsistencies found between packages
+// sharing a purl, usually left behind when documents are merged.
+type PurlConflictKind string
+
+const (
+	// PurlVersionConflict is a package found at more than one version.
+	PurlVersionConflict PurlConflictKind = "version"
+
+	// PurlLicenseConflict is a package version declared with different
+	// licenses.
+	PurlLicenseConflict PurlConflictKind = "license"
+)
+
+// PurlConflictKinds lists the kinds of conflicts that can be detected
+var PurlConflictKinds = []PurlConflictKind{PurlVersionConflict, PurlLicenseConflict}
+
+// PurlConflict is an inconsistency between the packages of a document
+// that share a purl.
+type PurlConflict struct {
+	Kind PurlConflictKind `json:"kind"`
+
+	// Purl of the conflicting packages. Version conflicts are reported
+	// on the purl without a version.
+	Purl string `json:"purl"`
+
+	// Values are the conflicting versions or licenses, sorted
+	Values []string `json:"values"`
+
+	// Packages with the conflicting values, sorted by SPDX ID
+	Packages []*Package `json:"-"`
+}
+
+// String returns the conflict as a readable line
+func (c PurlConflict) String() string {
+	ids := []string{}
+	for _, p := range c.Packages {
+		ids = append(ids, p.SPDXID())
+	}
+	return fmt.Sprintf(
+		"%s conflict: %s found with %s %s in %s",
+		c.Kind, c.Purl, c.Kind, strings.Join(c.Values, ", "), strings.Join(ids, ", "),
+	)
+}
+
+// purlKey returns the purl identifying a package in the purl index. The
+// qualifiers and subpath are dropped, so the same package built for
+// several architectures or distributions shares a key.
+func purlKey(p *purl.PackageURL, withVersion bool) string {
+	key := purl.PackageURL{Type: p.Type, Namespace: p.Namespace, Name: p.Name}
+	if withVersion {
+		key.Version = p.Version
+	}
+	return key.ToString()
+}
+
+// PurlIndex returns the packages in the document indexed by purl. Packages
+// without a purl are not indexed. The qualifiers and subpath of the purls
+// are dropped from the keys, so the same package built for several
+// architectures or distributions is found under the same purl.
+func (d *Document) PurlIndex() map[string][]*Package {
+	return purlIndex(d.walkRoots())
+}
+
+// PurlConflicts returns the packages in the document found at different
+// versions and the package versions declared with different licenses.
+func (d *Document) PurlConflicts() []PurlConflict {
+	return FindPurlConflicts(d.walkRoots())
+}
+
+// purlIndex indexes by purl the packages reachable from roots
+func purlIndex(roots []Object) map[string][]*Package {
+	index := map[string][]*Package{}
+	seen := map[Object]struct{}{}
+	var walk func(o Object)
+	walk = func(o Object) {
+		if _, ok := seen[o]; ok {
+			return
+		}
+		seen[o] = struct{}{}
+		if p, ok := o.(*Package); ok {
+			if pu := p.Purl(); pu != nil {
+				key := purlKey(pu, true)
+				index[key] = append(index[key], p)
+			}
+		}
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer != nil {
+				walk(rel.Peer)
+			}
+		}
+	}
+	for _, o := range roots {
+		walk(o)
+	}
+	return index
+}
+
+// packageLicense returns the license to compare between packages sharing
+// a purl: the declared license, or the concluded one when not declared.
+func packageLicense(p *Package) string {
+	for _, l := range []string{p.LicenseDeclared, p.LicenseConcluded} {
+		if l != "" && l != NOASSERTION {
+			return l
+		}
+	}
+	return ""
+}
+
+// FindPurlConflicts searches the graph reachable from roots for packages
+// with the same purl at different versions, and for packages with the
+// same purl and version but different licenses. Purls without a version
+// are not considered when looking for version conflicts. The conflicts
+// are sorted by kind and purl.
+func FindPurlConflicts(roots []Object) []PurlConflict {
+	index := purlIndex(roots)
+
+	// Group the versions found of each package
+	versions := map[string][]string{}
+	for _, key := range slices.Sorted(maps.Keys(index)) {
+		pu := index[key][0].Purl()
+		if pu.Version == "" {
+			continue
+		}
+		unversioned := purlKey(pu, false)
+		versions[unversioned] = append(versions[unversioned], key)
+	}
+
+	conflicts := []PurlConflict{}
+	for _, unversioned := range slices.Sorted(maps.Keys(versions)) {
+		keys := versions[unversioned]
+		if len(keys) < 2 {
+			continue
+		}
+		c := PurlConflict{Kind: PurlVersionConflict, Purl: unversioned}
+		for _, key := range keys {
+			c.Values = append(c.Values, index[key][0].Purl().Version)
+			c.Packages = append(c.Packages, index[key]...)
+		}
+		conflicts = append(conflicts, sortConflictPackages(c))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(index)) {
+		licenses := map[string][]*Package{}
+		for _, p := range index[key] {
+			if l := packageLicense(p); l != "" {
+				licenses[l] = append(licenses[l], p)
+			}
+		}
+		if len(licenses) < 2 {
+			continue
+		}
+		c := PurlConflict{Kind: PurlLicenseConflict, Purl: key}
+		for _, l := range slices.Sorted(maps.Keys(licenses)) {
+			c.Values = append(c.Values, l)
+			c.Packages = append(c.Packages, licenses[l]...)
+		}
+		conflicts = append(conflicts, sortConflictPackages(c))
+	}
+	return conflicts
+}
+
+// sortConflictPackages sorts the packages of a conflict by SPDX ID
+func sortConflictPackages(c PurlConflict) PurlConflict {
+	slices.SortFunc(c.Packages, func(a, b *Package) int {
+		return strings.Compare(a.SPDXID(), b.SPDXID())
+	})
+	return c
+}
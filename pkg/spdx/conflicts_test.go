@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// conflictsTestDocument returns a document merging two images that
+// ship different versions of zlib and declare openssl with different
+// licenses.
+func conflictsTestDocument(t *testing.T) *Document {
+	t.Helper()
+	newPkg := func(id, purlString, license string) *Package {
+		p := NewPackage()
+		p.ID = id
+		p.Name = id
+		p.LicenseDeclared = license
+		if purlString != "" {
+			p.ExternalRefs = []ExternalRef{{Category: CatPackageManager, Type: "purl", Locator: purlString}}
+		}
+		return p
+	}
+
+	imageA := newPkg("image-a", "", "")
+	imageB := newPkg("image-b", "", "")
+	require.NoError(t, imageA.AddPackage(newPkg("zlib-a", "pkg:apk/alpine/zlib@1.3.1-r0?arch=x86_64", "Zlib")))
+	require.NoError(t, imageB.AddPackage(newPkg("zlib-b", "pkg:apk/alpine/zlib@1.2.13-r1?arch=aarch64", "Zlib")))
+	require.NoError(t, imageA.AddPackage(newPkg("openssl-a", "pkg:apk/alpine/openssl@3.1.4-r5", "Apache-2.0")))
+	require.NoError(t, imageB.AddPackage(newPkg("openssl-b", "pkg:apk/alpine/openssl@3.1.4-r5", "OpenSSL")))
+	require.NoError(t, imageB.AddPackage(newPkg("openssl-c", "pkg:apk/alpine/openssl@3.1.4-r5", NOASSERTION)))
+
+	// The same package built for two architectures is not a conflict
+	require.NoError(t, imageA.AddPackage(newPkg("musl-a", "pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64", "MIT")))
+	require.NoError(t, imageB.AddPackage(newPkg("musl-b", "pkg:apk/alpine/musl@1.2.4-r2?arch=aarch64", "MIT")))
+	require.NoError(t, imageB.AddPackage(newPkg("busybox", "pkg:apk/alpine/busybox", "")))
+
+	doc := NewDocument()
+	require.NoError(t, doc.AddPackage(imageA))
+	require.NoError(t, doc.AddPackage(imageB))
+	return doc
+}
+
+func TestPurlIndex(t *testing.T) {
+	index := conflictsTestDocument(t).PurlIndex()
+	require.Len(t, index, 5)
+	require.Len(t, index["pkg:apk/alpine/musl@1.2.4-r2"], 2)
+	require.Len(t, index["pkg:apk/alpine/openssl@3.1.4-r5"], 3)
+	require.Len(t, index["pkg:apk/alpine/busybox"], 1)
+}
+
+func TestPurlConflicts(t *testing.T) {
+	conflicts := conflictsTestDocument(t).PurlConflicts()
+	require.Len(t, conflicts, 2)
+
+	require.Equal(t, PurlVersionConflict, conflicts[0].Kind)
+	require.Equal(t, "pkg:apk/alpine/zlib", conflicts[0].Purl)
+	require.Equal(t, []string{"1.2.13-r1", "1.3.1-r0"}, conflicts[0].Values)
+	require.Len(t, conflicts[0].Packages, 2)
+	require.Equal(t, "zlib-a", conflicts[0].Packages[0].SPDXID())
+
+	// Packages without a license are not in conflict
+	require.Equal(t, PurlLicenseConflict, conflicts[1].Kind)
+	require.Equal(t, "pkg:apk/alpine/openssl@3.1.4-r5", conflicts[1].Purl)
+	require.Equal(t, []string{"Apache-2.0", "OpenSSL"}, conflicts[1].Values)
+	require.Len(t, conflicts[1].Packages, 2)
+	require.Equal(t,
+		"license conflict: pkg:apk/alpine/openssl@3.1.4-r5 found with license Apache-2.0, OpenSSL in openssl-a, openssl-b",
+		conflicts[1].String(),
+	)
+}
+
+func TestPurlConflictsNone(t *testing.T) {
+	doc := NewDocument()
+	p := NewPackage()
+	p.ID = "SPDXRef-Package-zlib"
+	p.ExternalRefs = []ExternalRef{{Category: CatPackageManager, Type: "purl", Locator: "pkg:apk/alpine/zlib@1.3.1-r0"}}
+	require.NoError(t, doc.AddPackage(p))
+	require.Empty(t, doc.PurlConflicts())
+}
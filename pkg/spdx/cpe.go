@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+// cpeCodeHosts are the hosts of go modules where the first path element
+// names the project owner, used as the CPE vendor
+var cpeCodeHosts = map[string]struct{}{
+	"github.com": {}, "gitlab.com": {}, "bitbucket.org": {},
+}
+
+// cpeOSTypes are the purl types of distribution packages. Their versions
+// carry a distribution revision that upstream CPEs don't have.
+var cpeOSTypes = map[string]struct{}{
+	purl.TypeDebian: {}, purl.TypeRPM: {}, "apk": {}, "alpm": {},
+}
+
+// cpeSkippedTypes are the purl types that don't identify a software
+// product (image artifacts and generic downloads), no CPE is derived from
+// their names.
+var cpeSkippedTypes = map[string]struct{}{
+	purl.TypeOCI: {}, purl.TypeGeneric: {},
+}
+
+// cpeFromPurl builds a best-effort CPE 2.3 identifier for the software
+// described by a purl. The vendor is guessed from the purl namespace, so
+// the result only matches dictionaries that follow the same conventions.
+// Returns an empty string if the purl has no name or version, or is one
+// of cpeSkippedTypes.
+func cpeFromPurl(p *purl.PackageURL) string {
+	if p == nil || p.Name == "" || p.Version == "" {
+		return ""
+	}
+	if _, ok := cpeSkippedTypes[p.Type]; ok {
+		return ""
+	}
+
+	vendor, product, version := p.Name, p.Name, p.Version
+	switch p.Type {
+	case purl.TypeGolang:
+		host, owner, _ := strings.Cut(p.Namespace, "/")
+		if _, ok := cpeCodeHosts[host]; ok && owner != "" {
+			vendor, _, _ = strings.Cut(owner, "/")
+		} else if host != "" {
+			// golang.org/x/term -> golang
+			vendor, _, _ = strings.Cut(host, ".")
+		}
+		version = strings.TrimPrefix(strings.TrimSuffix(version, "+incompatible"), "v")
+	case purl.TypeMaven:
+		// org.apache.commons -> apache
+		parts := strings.Split(p.Namespace, ".")
+		switch {
+		case len(parts) > 1 && len(parts[0]) <= 3:
+			vendor = parts[1]
+		case p.Namespace != "":
+			vendor = p.Namespace
+		}
+	case purl.TypeNPM:
+		if p.Namespace != "" {
+			vendor = strings.TrimPrefix(p.Namespace, "@")
+		}
+	}
+
+	if _, ok := cpeOSTypes[p.Type]; ok {
+		// Drop the epoch and the distribution revision
+		if _, v, ok := strings.Cut(version, ":"); ok {
+			version = v
+		}
+		if i := strings.LastIndex(version, "-"); i > 0 {
+			version = version[:i]
+		}
+	}
+
+	return "cpe:2.3:a:" + cpeEscape(vendor) + ":" + cpeEscape(product) + ":" +
+		cpeEscape(version) + ":*:*:*:*:*:*:*"
+}
+
+// cpeEscape formats a value as a CPE 2.3 formatted string component.
+// Values are lowercased, spaces become underscores and punctuation other
+// than dots, dashes and underscores is quoted with a backslash.
+func cpeEscape(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == ' ':
+			b.WriteRune('_')
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			b.WriteRune(r)
+		case r < 0x80:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		default:
+			// Non ASCII characters are not valid in CPE names
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// AddCPE adds a CPE 2.3 identifier derived from the package purl to the
+// external references of the package. Returns true if the reference was
+// added, packages with a CPE already or without a purl are not modified.
+func (p *Package) AddCPE() bool {
+	for _, er := range p.ExternalRefs {
+		if er.Type == "cpe23Type" {
+			return false
+		}
+	}
+	cpe := cpeFromPurl(p.Purl())
+	if cpe == "" {
+		return false
+	}
+	p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+		Category: CatSecurity,
+		Type:     "cpe23Type",
+		Locator:  cpe,
+	})
+	return true
+}
+
+// AddCPEs adds CPE identifiers to all the packages in the document
+// that have a purl. Returns the number of packages modified.
+func (d *Document) AddCPEs() int {
+	n := 0
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		if p, ok := o.(*Package); ok && p.AddCPE() {
+			n++
+		}
+		return nil
+	})
+	return n
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPEFromPurl(t *testing.T) {
+	for _, tc := range []struct {
+		purl     string
+		expected string
+	}{
+		{"pkg:golang/github.com/sirupsen/logrus@v1.9.3", "cpe:2.3:a:sirupsen:logrus:1.9.3:*:*:*:*:*:*:*"},
+		{"pkg:golang/golang.org/x/net@v0.23.0", "cpe:2.3:a:golang:net:0.23.0:*:*:*:*:*:*:*"},
+		{"pkg:golang/github.com/docker/cli@v20.10.12+incompatible", "cpe:2.3:a:docker:cli:20.10.12:*:*:*:*:*:*:*"},
+		{"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "cpe:2.3:a:apache:log4j-core:2.14.1:*:*:*:*:*:*:*"},
+		{"pkg:npm/%40babel/core@7.24.5", "cpe:2.3:a:babel:core:7.24.5:*:*:*:*:*:*:*"},
+		{"pkg:pypi/pyyaml@6.0.1", "cpe:2.3:a:pyyaml:pyyaml:6.0.1:*:*:*:*:*:*:*"},
+		{"pkg:deb/debian/openssl@1:3.0.11-1~deb12u2?arch=amd64", "cpe:2.3:a:openssl:openssl:3.0.11:*:*:*:*:*:*:*"},
+		{"pkg:apk/wolfi/glibc@2.38-r1?arch=x86_64", "cpe:2.3:a:glibc:glibc:2.38:*:*:*:*:*:*:*"},
+		{"pkg:pypi/my%20tool@1.0+build", "cpe:2.3:a:my_tool:my_tool:1.0\\+build:*:*:*:*:*:*:*"},
+		{"pkg:generic/openssl@3.0.11", ""},
+		{"pkg:oci/nginx@sha256%3A0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31", ""},
+		{"pkg:npm/left-pad", ""},
+	} {
+		p, err := purl.FromString(tc.purl)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, cpeFromPurl(&p), tc.purl)
+	}
+	require.Empty(t, cpeFromPurl(nil))
+}
+
+func TestAddCPEs(t *testing.T) {
+	dep := NewPackage()
+	dep.BuildID("dep")
+	dep.Name = "logrus"
+	dep.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager, Type: "purl", Locator: "pkg:golang/github.com/sirupsen/logrus@v1.9.3",
+	}}
+	top := NewPackage()
+	top.BuildID("top")
+	top.Name = "top"
+	require.NoError(t, top.AddDependency(dep))
+
+	doc := NewDocument()
+	require.NoError(t, doc.AddPackage(top))
+	require.Equal(t, 1, doc.AddCPEs())
+	require.Len(t, dep.ExternalRefs, 2)
+	require.Equal(t, ExternalRef{
+		Category: CatSecurity, Type: "cpe23Type", Locator: "cpe:2.3:a:sirupsen:logrus:1.9.3:*:*:*:*:*:*:*",
+	}, dep.ExternalRefs[1])
+
+	// Packages are only modified once
+	require.Zero(t, doc.AddCPEs())
+	require.Len(t, dep.ExternalRefs, 2)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// packageIdentity returns a string that identifies the contents of a
+// package. Packages without a purl or checksums cannot be told apart
+// reliably and return an empty identity.
+func packageIdentity(p *Package) string {
+	purls := []string{}
+	for _, ref := range p.ExternalRefs {
+		if ref.Type == "purl" {
+			purls = append(purls, ref.Locator)
+		}
+	}
+
+	checksums := []string{}
+	for algo, value := range p.Checksum {
+		checksums = append(checksums, algo+":"+value)
+	}
+
+	if len(purls) == 0 && len(checksums) == 0 {
+		return ""
+	}
+
+	sort.Strings(purls)
+	sort.Strings(checksums)
+	return strings.Join([]string{
+		p.Name, p.Version, strings.Join(purls, " "), strings.Join(checksums, " "),
+	}, "|")
+}
+
+// DeduplicatePackages replaces all identical packages found in the
+// document with a single element. The relationships pointing to the
+// duplicates are rewired to the first package found and only that one
+// gets rendered. This is useful when a document describes many images
+// sharing base layers and OS packages. Returns the number of packages
+// removed from the document.
+func (d *Document) DeduplicatePackages() int {
+	canonical := map[string]*Package{}
+	rendered := map[*Package]struct{}{}
+	duplicates := map[*Package]struct{}{}
+	seen := map[Object]struct{}{}
+
+	// Top level packages are always rendered by the document. Sort them
+	// to get the same result on every run.
+	ids := []string{}
+	for id := range d.Packages {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p := d.Packages[id]
+		rendered[p] = struct{}{}
+		if key := packageIdentity(p); key != "" {
+			if _, ok := canonical[key]; !ok {
+				canonical[key] = p
+			}
+		}
+	}
+
+	var walk func(o Object)
+	walk = func(o Object) {
+		if _, ok := seen[o]; ok {
+			return
+		}
+		seen[o] = struct{}{}
+
+		for _, rel := range *o.GetRelationships() {
+			if rel.Peer == nil {
+				continue
+			}
+			p, ok := rel.Peer.(*Package)
+			if !ok {
+				walk(rel.Peer)
+				continue
+			}
+
+			if key := packageIdentity(p); key != "" {
+				if c, ok := canonical[key]; ok && c != p {
+					logrus.Debugf("Replacing duplicate package %s with %s", p.SPDXID(), c.SPDXID())
+					duplicates[p] = struct{}{}
+					rel.Peer = c
+					p = c
+				} else if !ok {
+					canonical[key] = p
+				}
+			}
+
+			// A package is fully rendered only the first time it is found
+			if rel.FullRender {
+				if _, ok := rendered[p]; ok {
+					rel.FullRender = false
+				} else {
+					rendered[p] = struct{}{}
+				}
+			}
+			walk(p)
+		}
+	}
+
+	for _, id := range ids {
+		walk(d.Packages[id])
+	}
+	for _, f := range d.Files {
+		walk(f)
+	}
+	d.InvalidateIndex()
+	return len(duplicates)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicatePackages(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "dedupe-test"
+
+	newOSPackage := func(seed string) *Package {
+		p := NewPackage()
+		p.Name = "openssl"
+		p.Version = "3.0.2"
+		p.BuildID(seed, "openssl")
+		p.ExternalRefs = []ExternalRef{{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  "pkg:deb/debian/openssl@3.0.2",
+		}}
+		return p
+	}
+
+	images := []*Package{}
+	osPackages := []*Package{}
+	for _, name := range []string{"image1", "image2"} {
+		img := NewPackage()
+		img.Name = name
+		img.BuildID(name)
+		osp := newOSPackage(name)
+		require.NoError(t, img.AddPackage(osp))
+		require.NoError(t, doc.AddPackage(img))
+		images = append(images, img)
+		osPackages = append(osPackages, osp)
+	}
+
+	// Packages without purl or checksums are never merged
+	for _, img := range images {
+		p := NewPackage()
+		p.Name = "unknown"
+		p.BuildID(img.Name, "unknown")
+		require.NoError(t, img.AddPackage(p))
+	}
+
+	require.Equal(t, 1, doc.DeduplicatePackages())
+
+	for _, img := range images {
+		require.Same(t, osPackages[0], img.Relationships[0].Peer)
+	}
+	require.True(t, images[0].Relationships[0].FullRender)
+	require.False(t, images[1].Relationships[0].FullRender)
+	require.NotSame(t, images[0].Relationships[1].Peer, images[1].Relationships[1].Peer)
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(rendered, "PackageName: openssl\n"))
+	require.Equal(t, 2, strings.Count(rendered, "CONTAINS "+osPackages[0].SPDXID()+"\n"))
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// DegradationKind classifies the conditions that make a generated
+// document less complete than it should be without failing the scan.
+type DegradationKind string
+
+const (
+	// DegradationMissingLicenses is recorded when the license of a
+	// scanned directory or module could not be determined.
+	DegradationMissingLicenses DegradationKind = "missing-licenses"
+
+	// DegradationScannerFallback is recorded when a scanner could not
+	// read some data and went on with less information.
+	DegradationScannerFallback DegradationKind = "scanner-fallback"
+
+	// DegradationNetworkError is recorded when data could not be
+	// downloaded and the scan went on without it.
+	DegradationNetworkError DegradationKind = "network-error"
+)
+
+// DegradationKinds lists the kinds of degradations recorded while
+// generating documents
+var DegradationKinds = []DegradationKind{
+	DegradationMissingLicenses, DegradationScannerFallback, DegradationNetworkError,
+}
+
+// Degradation is a condition that made a generated document incomplete
+type Degradation struct {
+	Kind    DegradationKind `json:"kind"`
+	Package string          `json:"package,omitempty"` // Package affected, by purl when it has one
+	Message string          `json:"message"`
+}
+
+// DegradationLog collects the degradations recorded while generating a
+// document. It is safe for concurrent use. Degradations recorded in a nil
+// log are only logged as warnings.
+type DegradationLog struct {
+	mtx          sync.Mutex
+	degradations []Degradation
+}
+
+// record logs a warning and records it as a degradation of the document
+// being generated.
+func (l *DegradationLog) record(kind DegradationKind, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	logrus.Warn(msg)
+	l.add(Degradation{Kind: kind, Message: msg})
+}
+
+// recordPackage records a degradation affecting a single package,
+// identified by its purl or name.
+func (l *DegradationLog) recordPackage(kind DegradationKind, pkg, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	logrus.WithField("package", pkg).Warn(msg)
+	l.add(Degradation{Kind: kind, Package: pkg, Message: msg})
+}
+
+func (l *DegradationLog) add(dg Degradation) {
+	if l == nil {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.degradations = append(l.degradations, dg)
+}
+
+// Degradations returns the degradations recorded in the log.
+func (l *DegradationLog) Degradations() []Degradation {
+	if l == nil {
+		return nil
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return slices.Clone(l.degradations)
+}
+
+// ParseDegradationKinds parses a list of degradation kind names
+func ParseDegradationKinds(names []string) ([]DegradationKind, error) {
+	kinds := []DegradationKind{}
+	for _, name := range names {
+		kind := DegradationKind(strings.TrimSpace(name))
+		if !slices.Contains(DegradationKinds, kind) {
+			return nil, fmt.Errorf("unknown degradation kind %q", name)
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds, nil
+}
+
+// AddDegradationAnnotations records degradations in the document as
+// annotations, so consumers know the document is incomplete.
+func (d *Document) AddDegradationAnnotations(degradations []Degradation) {
+	date := time.Now().UTC().Format(time.RFC3339)
+	for _, dg := range degradations {
+		d.Annotations = append(d.Annotations, Annotation{
+			Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+			Date:      date,
+			Type:      "OTHER",
+			Comment:   fmt.Sprintf("%s: %s", dg.Kind, dg.Message),
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDegradations(t *testing.T) {
+	log := &DegradationLog{}
+	log.record(DegradationMissingLicenses, "no license in %s", "/src")
+	log.record(DegradationNetworkError, "download failed")
+	log.recordPackage(DegradationNetworkError, "pkg:pypi/requests@2.28.1", "download of %s failed", "requests")
+	require.Equal(t, []Degradation{
+		{Kind: DegradationMissingLicenses, Message: "no license in /src"},
+		{Kind: DegradationNetworkError, Message: "download failed"},
+		{Kind: DegradationNetworkError, Package: "pkg:pypi/requests@2.28.1", Message: "download of requests failed"},
+	}, log.Degradations())
+
+	doc := NewDocument()
+	doc.AddDegradationAnnotations(log.Degradations())
+	require.Len(t, doc.Annotations, 3)
+	require.Equal(t, "OTHER", doc.Annotations[0].Type)
+	require.Equal(t, "missing-licenses: no license in /src", doc.Annotations[0].Comment)
+
+	// A nil log only logs the degradations
+	var none *DegradationLog
+	none.record(DegradationNetworkError, "download failed")
+	require.Empty(t, none.Degradations())
+}
+
+func TestParseDegradationKinds(t *testing.T) {
+	kinds, err := ParseDegradationKinds([]string{"missing-licenses", " network-error"})
+	require.NoError(t, err)
+	require.Equal(t, []DegradationKind{DegradationMissingLicenses, DegradationNetworkError}, kinds)
+
+	_, err = ParseDegradationKinds([]string{"everything"})
+	require.Error(t, err)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nozzle/throttler"
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// depsDevURL is the endpoint of the deps.dev API
+var depsDevURL = "https://api.deps.dev/v3"
+
+// scorecardViewerURL shows the OpenSSF Scorecard of a project
+const scorecardViewerURL = "https://scorecard.dev/viewer/?uri="
+
+// depsDevSystems are the deps.dev package systems by purl type
+var depsDevSystems = map[string]string{
+	purl.TypeGolang: "GO",
+	purl.TypeNPM:    "NPM",
+	purl.TypePyPi:   "PYPI",
+	purl.TypeMaven:  "MAVEN",
+	purl.TypeCargo:  "CARGO",
+}
+
+// depsDevVersion is the data deps.dev has about a package version
+type depsDevVersion struct {
+	Licenses []string `json:"licenses"`
+	Links    []struct {
+		Label string `json:"label"`
+		URL   string `json:"url"`
+	} `json:"links"`
+	RelatedProjects []struct {
+		ProjectKey struct {
+			ID string `json:"id"`
+		} `json:"projectKey"`
+		RelationType string `json:"relationType"`
+	} `json:"relatedProjects"`
+
+	// Scorecard of the source repository, read from its project
+	scorecard *depsDevScorecard
+	project   string
+}
+
+type depsDevScorecard struct {
+	Date         string  `json:"date"`
+	OverallScore float64 `json:"overallScore"`
+}
+
+// EnrichFromDepsDev looks up the Go, npm, PyPI, Maven and Cargo packages
+// of the document in deps.dev. The licenses it knows are compared to the
+// ones in the document: packages without a declared license get them,
+// and differences are recorded as annotations. Packages without a home
+// page get the one in deps.dev, and the OpenSSF Scorecard of their
+// source repository is added as an external reference and annotation.
+// Failed lookups are recorded as network degradations in log. Returns the
+// number of packages found in deps.dev.
+func (d *Document) EnrichFromDepsDev(ctx context.Context, log *DegradationLog) (int, error) {
+	pkgs := map[string][]*Package{}
+	purls := map[string]*purl.PackageURL{}
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		p, ok := o.(*Package)
+		if !ok {
+			return nil
+		}
+		pu := p.Purl()
+		if pu == nil || pu.Version == "" || depsDevSystems[pu.Type] == "" {
+			return nil
+		}
+		key := pu.ToString()
+		purls[key] = pu
+		pkgs[key] = append(pkgs[key], p)
+		return nil
+	})
+	logrus.Infof("Looking up %d package versions in deps.dev", len(purls))
+
+	found := map[string]*depsDevVersion{}
+	mtx := sync.Mutex{}
+	t := throttler.New(registryConcurrency, len(purls))
+	for key, pu := range purls {
+		go func() {
+			data, err := depsDevLookup(ctx, pu)
+			switch {
+			case errors.Is(err, errRegistryNotFound):
+				logrus.Debugf("%s not found in deps.dev", key)
+			case err != nil && ctx.Err() == nil:
+				log.record(DegradationNetworkError, "Unable to look up %s in deps.dev: %v", key, err)
+			case err == nil:
+				mtx.Lock()
+				found[key] = data
+				mtx.Unlock()
+			}
+			t.Done(nil)
+		}()
+		t.Throttle()
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	date := time.Now().UTC().Format(time.RFC3339)
+	for key, data := range found {
+		for _, p := range pkgs[key] {
+			p.addDepsDevData(data, date)
+		}
+	}
+	return len(found), nil
+}
+
+// depsDevLookup reads a package version and the scorecard of its source
+// repository from deps.dev
+func depsDevLookup(ctx context.Context, pu *purl.PackageURL) (*depsDevVersion, error) {
+	name := pu.Name
+	switch {
+	case pu.Namespace == "":
+	case pu.Type == purl.TypeMaven:
+		name = pu.Namespace + ":" + pu.Name
+	default:
+		name = pu.Namespace + "/" + pu.Name
+	}
+
+	data := &depsDevVersion{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/systems/%s/packages/%s/versions/%s",
+		depsDevURL, depsDevSystems[pu.Type], url.PathEscape(name), url.PathEscape(pu.Version),
+	), data); err != nil {
+		return nil, err
+	}
+
+	for _, rp := range data.RelatedProjects {
+		if rp.RelationType != "SOURCE_REPO" || rp.ProjectKey.ID == "" {
+			continue
+		}
+		project := struct {
+			Scorecard *depsDevScorecard `json:"scorecard"`
+		}{}
+		err := registryGet(ctx, fmt.Sprintf("%s/projects/%s", depsDevURL, url.PathEscape(rp.ProjectKey.ID)), &project)
+		if err != nil && !errors.Is(err, errRegistryNotFound) {
+			return nil, fmt.Errorf("reading project %s: %w", rp.ProjectKey.ID, err)
+		}
+		data.project = rp.ProjectKey.ID
+		data.scorecard = project.Scorecard
+		break
+	}
+	return data, nil
+}
+
+// addDepsDevData records the data found in deps.dev in the package
+func (p *Package) addDepsDevData(data *depsDevVersion, date string) {
+	annotate := func(comment string) {
+		p.Annotations = append(p.Annotations, Annotation{
+			Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+			Date:      date,
+			Type:      "OTHER",
+			Comment:   comment,
+		})
+	}
+
+	if len(data.Licenses) > 0 {
+		licenses := strings.Join(data.Licenses, " AND ")
+		if len(data.Licenses) > 1 {
+			licenses = "(" + strings.Join(data.Licenses, ") AND (") + ")"
+		}
+		switch {
+		case p.LicenseDeclared == "" || p.LicenseDeclared == NOASSERTION:
+			p.LicenseDeclared = licenses
+		case !strings.EqualFold(p.LicenseDeclared, licenses):
+			annotate(fmt.Sprintf("deps.dev reports the license %s, the package declares %s", licenses, p.LicenseDeclared))
+		}
+		if c := p.LicenseConcluded; c != "" && c != NOASSERTION && c != NONE && !strings.EqualFold(c, licenses) {
+			annotate(fmt.Sprintf("deps.dev reports the license %s, concluded license is %s", licenses, c))
+		}
+	}
+
+	if p.HomePage == "" {
+		for _, l := range data.Links {
+			if l.Label == "HOMEPAGE" {
+				p.HomePage = l.URL
+				break
+			}
+		}
+	}
+
+	if data.scorecard != nil {
+		p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+			Category: CatOther,
+			Type:     "openssf-scorecard",
+			Locator:  scorecardViewerURL + data.project,
+		})
+		annotate(fmt.Sprintf(
+			"OpenSSF Scorecard of %s: %.1f (%s)", data.project, data.scorecard.OverallScore, data.scorecard.Date,
+		))
+	}
+}
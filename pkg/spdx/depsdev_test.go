@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichFromDepsDev(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/systems/GO/packages/github.com/sirupsen/logrus/versions/v1.9.3":
+			w.Write([]byte(`{
+				"licenses": ["MIT"],
+				"links": [{"label": "SOURCE_REPO", "url": "https://github.com/sirupsen/logrus"}],
+				"relatedProjects": [{"projectKey": {"id": "github.com/sirupsen/logrus"}, "relationType": "SOURCE_REPO"}]
+			}`)) //nolint:errcheck
+		case "/projects/github.com/sirupsen/logrus":
+			w.Write([]byte(`{"scorecard": {"date": "2026-10-05T00:00:00Z", "overallScore": 5.3}}`)) //nolint:errcheck
+		case "/systems/MAVEN/packages/org.slf4j:slf4j-api/versions/2.0.16":
+			w.Write([]byte(`{
+				"licenses": ["MIT"],
+				"links": [{"label": "HOMEPAGE", "url": "http://www.slf4j.org"}]
+			}`)) //nolint:errcheck
+		case "/systems/NPM/packages/@babel/core/versions/7.0.0":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	oldURL := depsDevURL
+	depsDevURL = srv.URL
+	defer func() { depsDevURL = oldURL }()
+
+	doc := NewDocument()
+	pkgs := map[string]*Package{}
+	for p, license := range map[string]string{
+		"pkg:golang/github.com/sirupsen/logrus@v1.9.3": "",
+		"pkg:maven/org.slf4j/slf4j-api@2.0.16":         "Apache-2.0",
+		"pkg:npm/%40babel/core@7.0.0":                  "",
+		"pkg:pypi/private-package@1.0.0":               "",
+		"pkg:deb/debian/bash@5.2.15":                   "",
+	} {
+		pkg := NewPackage()
+		pkg.BuildID(p)
+		pkg.Name = p
+		pkg.LicenseDeclared = license
+		pkg.ExternalRefs = []ExternalRef{{Category: CatPackageManager, Type: "purl", Locator: p}}
+		require.NoError(t, doc.AddPackage(pkg))
+		pkgs[p] = pkg
+	}
+
+	log := &DegradationLog{}
+	n, err := doc.EnrichFromDepsDev(context.Background(), log)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	// The missing license is added, the scorecard is recorded
+	logrusPkg := pkgs["pkg:golang/github.com/sirupsen/logrus@v1.9.3"]
+	require.Equal(t, "MIT", logrusPkg.LicenseDeclared)
+	require.Empty(t, logrusPkg.HomePage)
+	require.Equal(t, ExternalRef{
+		Category: CatOther,
+		Type:     "openssf-scorecard",
+		Locator:  "https://scorecard.dev/viewer/?uri=github.com/sirupsen/logrus",
+	}, logrusPkg.ExternalRefs[1])
+	require.Len(t, logrusPkg.Annotations, 1)
+	require.Equal(t,
+		"OpenSSF Scorecard of github.com/sirupsen/logrus: 5.3 (2026-10-05T00:00:00Z)",
+		logrusPkg.Annotations[0].Comment,
+	)
+
+	// The declared license is kept, the difference is annotated
+	slf4j := pkgs["pkg:maven/org.slf4j/slf4j-api@2.0.16"]
+	require.Equal(t, "Apache-2.0", slf4j.LicenseDeclared)
+	require.Equal(t, "http://www.slf4j.org", slf4j.HomePage)
+	require.Len(t, slf4j.ExternalRefs, 1)
+	require.Len(t, slf4j.Annotations, 1)
+	require.Equal(t,
+		"deps.dev reports the license MIT, the package declares Apache-2.0",
+		slf4j.Annotations[0].Comment,
+	)
+
+	require.Empty(t, pkgs["pkg:pypi/private-package@1.0.0"].Annotations)
+
+	// The failed lookup is recorded as a degradation
+	degradations := log.Degradations()
+	require.Len(t, degradations, 1)
+	require.Equal(t, DegradationNetworkError, degradations[0].Kind)
+	require.Contains(t, degradations[0].Message, "pkg:npm/%40babel/core@7.0.0")
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SetDescribes sets the top level elements described by the document.
+// Each spec is the SPDX ID or the name of a top level package or file.
+// Called without specs, the document describes all its top level
+// elements, which is the default.
+func (d *Document) SetDescribes(specs ...string) error {
+	ids := []string{}
+	for _, spec := range specs {
+		id, err := d.topLevelID(spec)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	d.Describes = ids
+	return nil
+}
+
+// DescribedIDs returns the sorted IDs of the elements the document
+// describes.
+func (d *Document) DescribedIDs() []string {
+	if len(d.Describes) == 0 {
+		ids := slices.Collect(maps.Keys(d.Packages))
+		ids = append(ids, slices.Collect(maps.Keys(d.Files))...)
+		slices.Sort(ids)
+		return ids
+	}
+	ids := slices.Clone(d.Describes)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// describes returns true if the document describes the element.
+func (d *Document) describes(id string) bool {
+	return len(d.Describes) == 0 || slices.Contains(d.Describes, id)
+}
+
+// checkDescribes verifies that the elements described by the document
+// are top level packages or files.
+func (d *Document) checkDescribes() error {
+	for _, id := range d.Describes {
+		_, isPackage := d.Packages[id]
+		_, isFile := d.Files[id]
+		if !isPackage && !isFile {
+			return fmt.Errorf("described element %s is not a top level package or file", id)
+		}
+	}
+	return nil
+}
+
+// topLevelID returns the ID of the top level element identified by spec,
+// either its SPDX ID or its name.
+func (d *Document) topLevelID(spec string) (string, error) {
+	if _, ok := d.Packages[spec]; ok {
+		return spec, nil
+	}
+	if _, ok := d.Files[spec]; ok {
+		return spec, nil
+	}
+
+	matches := []string{}
+	for _, id := range slices.Sorted(maps.Keys(d.Packages)) {
+		if d.Packages[id].Name == spec {
+			matches = append(matches, id)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.Files)) {
+		if d.Files[id].FileName == spec || d.Files[id].Name == spec {
+			matches = append(matches, id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no top level package or file matches %q", spec)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%q matches several top level elements, use one of their IDs: %s", spec, strings.Join(matches, ", "))
+	}
+}
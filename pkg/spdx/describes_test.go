@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDescribesDocument(t *testing.T) *Document {
+	t.Helper()
+	doc := NewDocument()
+	doc.Name = "describes"
+	doc.Namespace = "https://example.com/describes"
+	for i, name := range []string{"app", "tools", "tools"} {
+		p := NewPackage()
+		p.Name = name
+		p.BuildID(name, strconv.Itoa(i))
+		require.NoError(t, doc.AddPackage(p))
+	}
+	f := NewFile()
+	f.Name = "LICENSE"
+	f.FileName = "LICENSE"
+	f.BuildID("LICENSE")
+	require.NoError(t, doc.AddFile(f))
+	return doc
+}
+
+func TestSetDescribes(t *testing.T) {
+	doc := testDescribesDocument(t)
+	require.Len(t, doc.Packages, 3)
+	require.Len(t, doc.DescribedIDs(), 4)
+
+	appID := ""
+	for id, p := range doc.Packages {
+		if p.Name == "app" {
+			appID = id
+		}
+	}
+
+	for _, tc := range []struct {
+		specs     []string
+		expected  int
+		shouldErr bool
+	}{
+		{[]string{"app"}, 1, false},
+		{[]string{appID}, 1, false},
+		{[]string{"app", appID}, 1, false},
+		{[]string{"app", "LICENSE"}, 2, false},
+		{[]string{}, 4, false},
+		{[]string{"tools"}, 0, true},
+		{[]string{"missing"}, 0, true},
+	} {
+		err := doc.SetDescribes(tc.specs...)
+		if tc.shouldErr {
+			require.Error(t, err, tc.specs)
+			continue
+		}
+		require.NoError(t, err, tc.specs)
+		require.Len(t, doc.DescribedIDs(), tc.expected, tc.specs)
+	}
+
+	// Described elements have to be in the top level of the document
+	doc.Describes = []string{"SPDXRef-Package-missing"}
+	_, err := doc.Render()
+	require.Error(t, err)
+}
+
+func TestDescribesRendering(t *testing.T) {
+	doc := testDescribesDocument(t)
+	require.NoError(t, doc.SetDescribes("app"))
+	appID := doc.Describes[0]
+
+	// Tag-value
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(rendered, " DESCRIBES "))
+	require.Contains(t, rendered, "Relationship: SPDXRef-DOCUMENT DESCRIBES "+appID+"\n")
+
+	parsed, err := parseTagValue(strings.NewReader(rendered), &ReadOptions{})
+	require.NoError(t, err)
+	require.Len(t, parsed.Packages, 3)
+	require.Len(t, parsed.Files, 1)
+	require.Equal(t, []string{appID}, parsed.Describes)
+
+	// JSON
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	jsonDoc := struct {
+		DocumentDescribes []string `json:"documentDescribes"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &jsonDoc))
+	require.Equal(t, []string{appID}, jsonDoc.DocumentDescribes)
+
+	parsed = &Document{}
+	require.NoError(t, json.Unmarshal(data, parsed))
+	require.Len(t, parsed.Packages, 3)
+	require.Equal(t, []string{appID}, parsed.Describes)
+}
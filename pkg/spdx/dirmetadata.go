@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+// extDocIDRe matches the characters not valid in document reference IDs
+var extDocIDRe = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// directoryMetadata describes the components found in a directory from
+// existing metadata files: SBOMs generated for them and the records of
+// installed python packages.
+type directoryMetadata struct {
+	Packages        []*Package            // Component packages to add to the directory package
+	Owners          map[string]*Package   // Files (relative to the directory) owned by a component
+	Skip            map[string]struct{}   // Files not added to the package
+	Relationships   []*Relationship       // Relationships to the components in external documents
+	ExternalDocRefs []ExternalDocumentRef // Documents referenced by the relationships
+}
+
+// readDirectoryMetadata looks for existing metadata files in a directory
+// listing. SPDX documents (*.spdx.json) are referenced as external
+// documents, CycloneDX documents (*.cdx.json) and python package records
+// (*.dist-info/RECORD) become packages owning the files they describe.
+func readDirectoryMetadata(dirPath, seed string, fileList []string, log *DegradationLog) *directoryMetadata {
+	md := &directoryMetadata{
+		Packages:        []*Package{},
+		Owners:          map[string]*Package{},
+		Skip:            map[string]struct{}{},
+		Relationships:   []*Relationship{},
+		ExternalDocRefs: []ExternalDocumentRef{},
+	}
+
+	for _, rel := range fileList {
+		var err error
+		switch {
+		case strings.HasSuffix(rel, ".spdx.json"):
+			err = md.addSPDXDocument(dirPath, rel)
+		case strings.HasSuffix(rel, ".cdx.json"):
+			err = md.addCycloneDXDocument(dirPath, seed, rel)
+		case path.Base(rel) == "RECORD" && strings.HasSuffix(path.Dir(rel), ".dist-info"):
+			err = md.addPythonRecord(dirPath, seed, rel)
+		default:
+			continue
+		}
+		if err != nil {
+			log.record(DegradationScannerFallback, "Unable to read metadata from %s, adding it as a file: %v", rel, err)
+		}
+	}
+	return md
+}
+
+// addSPDXDocument references an SPDX document found in the directory and
+// relates the directory to the elements it describes.
+func (md *directoryMetadata) addSPDXDocument(dirPath, rel string) error {
+	doc, err := OpenDoc(filepath.Join(dirPath, rel))
+	if err != nil {
+		return fmt.Errorf("parsing SPDX document: %w", err)
+	}
+	if doc.Namespace == "" || len(doc.Packages) == 0 {
+		return errors.New("document has no namespace or described packages")
+	}
+
+	ref := ExternalDocumentRef{
+		ID:  "component-" + strings.Trim(extDocIDRe.ReplaceAllString(rel, "-"), "-"),
+		URI: doc.Namespace,
+	}
+	if err := ref.ReadSourceFile(filepath.Join(dirPath, rel)); err != nil {
+		return fmt.Errorf("hashing SPDX document: %w", err)
+	}
+	md.ExternalDocRefs = append(md.ExternalDocRefs, ref)
+	for _, id := range slices.Sorted(maps.Keys(doc.Packages)) {
+		md.Relationships = append(md.Relationships, &Relationship{
+			Type:             CONTAINS,
+			PeerReference:    id,
+			PeerExtReference: ref.ID,
+			Comment:          "Described by the SBOM at " + rel,
+		})
+	}
+	md.Skip[rel] = struct{}{}
+	return nil
+}
+
+// addCycloneDXDocument adds a package for the component described by a
+// CycloneDX document found in the directory.
+func (md *directoryMetadata) addCycloneDXDocument(dirPath, seed, rel string) error {
+	data, err := os.ReadFile(filepath.Join(dirPath, rel))
+	if err != nil {
+		return fmt.Errorf("reading CycloneDX document: %w", err)
+	}
+	bom := struct {
+		BOMFormat string `json:"bomFormat"`
+		Metadata  struct {
+			Component struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+				Purl    string `json:"purl"`
+			} `json:"component"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return fmt.Errorf("parsing CycloneDX document: %w", err)
+	}
+	component := bom.Metadata.Component
+	if bom.BOMFormat != "CycloneDX" || component.Name == "" {
+		return errors.New("document does not describe a CycloneDX component")
+	}
+
+	pkg := NewPackage()
+	pkg.Name = component.Name
+	pkg.Version = component.Version
+	pkg.Comment = "Described by the CycloneDX SBOM at " + rel
+	if component.Purl != "" {
+		if _, err := purl.FromString(component.Purl); err == nil {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+				Category: CatPackageManager,
+				Type:     "purl",
+				Locator:  component.Purl,
+			})
+		}
+	}
+	pkg.BuildID(seed, rel)
+	md.Packages = append(md.Packages, pkg)
+	md.Owners[rel] = pkg
+	return nil
+}
+
+// addPythonRecord adds a package for a python distribution installed in
+// the directory. The files listed in its RECORD are owned by the package.
+func (md *directoryMetadata) addPythonRecord(dirPath, seed, rel string) error {
+	distInfo := path.Dir(rel)
+	mf, err := os.Open(filepath.Join(dirPath, distInfo, "METADATA"))
+	if err != nil {
+		return fmt.Errorf("opening package metadata: %w", err)
+	}
+	defer mf.Close()
+	pkg, err := pythonPackageFromMetadata(mf)
+	if err != nil {
+		return fmt.Errorf("reading package metadata: %w", err)
+	}
+	if pkg == nil {
+		return errors.New("package metadata has no name or version")
+	}
+
+	rf, err := os.Open(filepath.Join(dirPath, rel))
+	if err != nil {
+		return fmt.Errorf("opening RECORD: %w", err)
+	}
+	defer rf.Close()
+
+	// RECORD is a CSV file listing path,hash,size. Paths are relative to
+	// the directory holding the dist-info directory.
+	sitePackages := path.Dir(distInfo)
+	r := csv.NewReader(rf)
+	r.FieldsPerRecord = -1
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("parsing RECORD: %w", err)
+		}
+		if len(record) == 0 || record[0] == "" {
+			continue
+		}
+		owned := path.Clean(path.Join(sitePackages, record[0]))
+		if strings.HasPrefix(owned, "../") {
+			continue
+		}
+		md.Owners[owned] = pkg
+	}
+
+	pkg.Comment = "Python package installed in " + sitePackages
+	pkg.BuildID(seed, distInfo)
+	md.Packages = append(md.Packages, pkg)
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+const testCycloneDXSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "component": {
+      "type": "application",
+      "name": "frontend",
+      "version": "1.2.0",
+      "purl": "pkg:npm/frontend@1.2.0"
+    }
+  }
+}`
+
+func TestReadDirectoryMetadata(t *testing.T) {
+	files := map[string]string{
+		"vendor/glibc.spdx.json":              testMelangeSBOM("glibc", "2.38-r1"),
+		"web/frontend.cdx.json":               testCycloneDXSBOM,
+		"lib/PyYAML-6.0.1.dist-info/METADATA": testPythonMetadata,
+		"lib/PyYAML-6.0.1.dist-info/RECORD":   "yaml/__init__.py,sha256=abc,12\nPyYAML-6.0.1.dist-info/METADATA,,\n../../bin/outside,,\n",
+		"lib/yaml/__init__.py":                "",
+		"broken.spdx.json":                    "{",
+		"README.md":                           "",
+	}
+	dir := spdxtest.WriteDirectory(t, files)
+	fileList := []string{}
+	for name := range files {
+		fileList = append(fileList, name)
+	}
+
+	md := readDirectoryMetadata(dir, "test", fileList, nil)
+
+	// The SPDX document is referenced, not added as a file
+	require.Len(t, md.ExternalDocRefs, 1)
+	require.Equal(t, "component-vendor-glibc.spdx.json", md.ExternalDocRefs[0].ID)
+	require.Equal(t, "https://spdx.org/spdxdocs/chainguard/melange/glibc", md.ExternalDocRefs[0].URI)
+	require.NotEmpty(t, md.ExternalDocRefs[0].Checksums)
+	require.Len(t, md.Relationships, 1)
+	require.Equal(t, CONTAINS, md.Relationships[0].Type)
+	require.Equal(t, "SPDXRef-Package-glibc", md.Relationships[0].PeerReference)
+	require.Equal(t, "component-vendor-glibc.spdx.json", md.Relationships[0].PeerExtReference)
+	require.Contains(t, md.Skip, "vendor/glibc.spdx.json")
+	require.NotContains(t, md.Skip, "broken.spdx.json")
+
+	// CycloneDX and python records become packages owning their files
+	require.Len(t, md.Packages, 2)
+	byName := map[string]*Package{}
+	for _, p := range md.Packages {
+		byName[p.Name] = p
+	}
+	require.Contains(t, byName, "frontend")
+	require.Equal(t, "1.2.0", byName["frontend"].Version)
+	require.Equal(t, "pkg:npm/frontend@1.2.0", byName["frontend"].Purl().String())
+	require.Same(t, byName["frontend"], md.Owners["web/frontend.cdx.json"])
+
+	require.Contains(t, byName, "PyYAML")
+	require.Equal(t, "6.0.1", byName["PyYAML"].Version)
+	require.Same(t, byName["PyYAML"], md.Owners["lib/yaml/__init__.py"])
+	require.Same(t, byName["PyYAML"], md.Owners["lib/PyYAML-6.0.1.dist-info/METADATA"])
+	require.NotContains(t, md.Owners, "README.md")
+	require.Len(t, md.Owners, 3)
+}
+
+func TestAddPackageExternalDocRefs(t *testing.T) {
+	ref := ExternalDocumentRef{ID: "component", URI: "https://example.com/component"}
+	child := NewPackage()
+	child.BuildID("child")
+	child.Name = "child"
+	child.ExternalDocRefs = []ExternalDocumentRef{ref}
+	parent := NewPackage()
+	parent.BuildID("parent")
+	parent.Name = "parent"
+	parent.ExternalDocRefs = []ExternalDocumentRef{ref}
+	require.NoError(t, parent.AddPackage(child))
+
+	doc := NewDocument()
+	require.NoError(t, doc.AddPackage(parent))
+	require.Equal(t, []ExternalDocumentRef{ref}, doc.ExternalDocRefs)
+}
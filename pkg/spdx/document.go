@@ -35,6 +35,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -74,11 +75,28 @@ ExternalDocumentRef:{{ extDocFormat $value }}
 {{ end -}}
 {{- end -}}
 {{ end -}}
+{{ if .CreatorComment }}CreatorComment: <text>{{ .CreatorComment }}
+</text>
+{{ end -}}
 {{ if .LicenseListVersion }}LicenseListVersion: {{ .LicenseListVersion }}
 {{ end -}}
 {{ if .Created }}Created: {{ dateFormat .Created }}
-{{ end }}
+{{ end }}{{ if .Comment }}DocumentComment: <text>{{ .Comment }}
+</text>
+{{ end }}` + annotationsTemplate + `
+
+`
 
+var extractedLicenseTemplate = `LicenseID: {{ .ID }}
+ExtractedText: <text>{{ .Text }}
+</text>
+{{ if .Name }}LicenseName: {{ .Name }}
+{{ end -}}
+{{ range .SeeAlso }}LicenseCrossReference: {{ . }}
+{{ end -}}
+{{ if .Comment }}LicenseComment: <text>{{ .Comment }}
+</text>
+{{ end }}
 `
 
 const (
@@ -88,6 +106,11 @@ const (
 )
 
 // Document abstracts the SPDX document.
+//
+// AddPackage, AddFile and GetElementByID are safe for concurrent use,
+// scanners running in goroutines can attach their results to the same
+// document. Reading or traversing the document while it is being
+// modified is not safe.
 type Document struct {
 	Version     string // SPDX-2.2
 	DataLicense string // CC0-1.0
@@ -99,11 +122,32 @@ type Document struct {
 		Organization string
 		Tool         []string // github.com/spdx/tools-golang/builder
 	}
+	CreatorComment     string    // Free form comment about the document creation
 	Created            time.Time // 2020-11-24T01:12:27Z
 	LicenseListVersion string
+	Comment            string // General comments about the document
 	Packages           map[string]*Package
 	Files              map[string]*File      // List of files
 	ExternalDocRefs    []ExternalDocumentRef // List of related external documents
+	ExtractedLicenses  []ExtractedLicense    // Licenses not in the SPDX list referenced in the document
+	Snippets           []*Snippet            // Fragments of files with their own licensing
+	Annotations        []Annotation          // Annotations about the document itself
+
+	// Describes lists the IDs of the top level elements described by the
+	// document. When empty, all the top level packages and files are.
+	Describes []string
+
+	// danglingRelationships are the relationships dropped when parsing
+	// the document because their elements do not exist
+	danglingRelationships []GraphIssue
+
+	index           map[string]Object // Elements in the document by SPDX ID
+	generation      graphGeneration   // Changes made to the indexed elements
+	indexGeneration uint64            // Generation when the index was built
+	indexShared     uint64            // Shared generation when the index was built
+	indexRoots      int               // Number of top level elements indexed
+
+	mtx sync.Mutex // Guards the top level maps and the ID index
 }
 
 // ExternalDocumentRef is a pointer to an external, related document.
@@ -113,6 +157,16 @@ type ExternalDocumentRef struct {
 	Checksums map[string]string `yaml:"checksums"` // Document checksums
 }
 
+// ExtractedLicense is a license not found in the SPDX license list. The
+// elements in the document refer to it using its LicenseRef- identifier.
+type ExtractedLicense struct {
+	ID      string   // LicenseRef-Proprietary
+	Name    string   // Full name of the license
+	Text    string   // Verbatim license text as found in the source
+	Comment string   // Notes about the license
+	SeeAlso []string // URLs where the license text can be found
+}
+
 // Example: cpe23Type cpe:2.3:a:base-files:base-files:10.3+deb10u9:*:*:*:*:*:*:*.
 type ExternalRef struct {
 	Category string // SECURITY | PACKAGE-MANAGER | PERSISTENT-ID | OTHER
@@ -147,14 +201,25 @@ func (ed *ExternalDocumentRef) String() string {
 }
 
 // ReadSourceFile populates the external reference data (the sha256 checksum)
-// from a given path.
+// from a given path. The path can also be an http(s) URL or an OCI
+// artifact reference (oci://registry/repo@digest), which becomes the URI
+// of the reference if it is not set.
 func (ed *ExternalDocumentRef) ReadSourceFile(path string) error {
 	if ed.Checksums == nil {
 		ed.Checksums = map[string]string{}
 	}
 	// The SPDX validator tools are broken and cannot validate non SHA1 checksums
 	// ref https://github.com/spdx/tools-java/issues/21
-	val, err := hash.SHA1ForFile(path)
+	var val string
+	var err error
+	if isRemoteDocument(path) {
+		val, err = remoteDocumentSHA1(path)
+		if ed.URI == "" {
+			ed.URI = path
+		}
+	} else {
+		val, err = hash.SHA1ForFile(path)
+	}
 	if err != nil {
 		return fmt.Errorf("while calculating the sha256 checksum of the external reference: %w", err)
 	}
@@ -162,6 +227,10 @@ func (ed *ExternalDocumentRef) ReadSourceFile(path string) error {
 	return nil
 }
 
+// SupportedSPDXVersions are the versions of the SPDX specification bom
+// can read. New documents are written using the last one.
+var SupportedSPDXVersions = []string{"SPDX-2.2", "SPDX-2.3"}
+
 // NewDocument returns a new SPDX document with some defaults preloaded.
 func NewDocument() *Document {
 	return &Document{
@@ -185,13 +254,16 @@ func NewDocument() *Document {
 
 // AddPackage adds a new empty package to the document.
 func (d *Document) AddPackage(pkg *Package) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
 	if d.Packages == nil {
 		d.Packages = map[string]*Package{}
 	}
 
 	if pkg.SPDXID() == "" {
 		pkg.BuildID(pkg.Name)
-		d.ensureUniqueElementID(pkg)
+		d.uniqueElementID(pkg, d.lookupID)
 	}
 	if pkg.SPDXID() == "" {
 		return errors.New("package ID is needed to add a new package")
@@ -201,9 +273,44 @@ func (d *Document) AddPackage(pkg *Package) error {
 	}
 
 	d.Packages[pkg.SPDXID()] = pkg
+	d.indexSubgraph(pkg)
+	d.addExternalDocRefs(pkg)
 	return nil
 }
 
+// addExternalDocRefs adds to the document the external document
+// references required by the packages in the subgraph of pkg. References
+// with an ID already in the document are not added again.
+func (d *Document) addExternalDocRefs(pkg *Package) {
+	known := map[string]struct{}{}
+	for _, ref := range d.ExternalDocRefs {
+		known[ref.ID] = struct{}{}
+	}
+
+	seen := map[Object]struct{}{}
+	var walk func(o Object)
+	walk = func(o Object) {
+		if _, ok := seen[o]; ok {
+			return
+		}
+		seen[o] = struct{}{}
+		if p, ok := o.(*Package); ok {
+			for _, ref := range p.ExternalDocRefs {
+				if _, ok := known[ref.ID]; !ok {
+					known[ref.ID] = struct{}{}
+					d.ExternalDocRefs = append(d.ExternalDocRefs, ref)
+				}
+			}
+		}
+		for _, rel := range *o.GetRelationships() {
+			if rel.Peer != nil {
+				walk(rel.Peer)
+			}
+		}
+	}
+	walk(pkg)
+}
+
 // Write outputs the SPDX document into a file.
 func (d *Document) Write(path string) error {
 	content, err := d.Render()
@@ -226,6 +333,10 @@ func (d *Document) Render() (doc string, err error) {
 		"extDocFormat": func(ed ExternalDocumentRef) string { logrus.Infof("External doc: %s", ed.ID); return ed.String() },
 	}
 
+	if err := d.checkDescribes(); err != nil {
+		return "", err
+	}
+
 	if d.Name == "" {
 		d.Name = "SBOM-SPDX-" + uuid.New().String()
 		logrus.Warnf("Document has no name defined, automatically set to %s", d.Name)
@@ -257,7 +368,9 @@ func (d *Document) Render() (doc string, err error) {
 			return "", fmt.Errorf("rendering file "+file.Name+" :%w", err)
 		}
 		doc += fileDoc
-		filesDescribed += fmt.Sprintf("Relationship: %s DESCRIBES %s\n\n", d.ID, file.ID)
+		if d.describes(file.ID) {
+			filesDescribed += fmt.Sprintf("Relationship: %s DESCRIBES %s\n\n", d.ID, file.ID)
+		}
 	}
 	doc += filesDescribed
 
@@ -269,7 +382,31 @@ func (d *Document) Render() (doc string, err error) {
 		}
 
 		doc += pkgDoc
-		doc += fmt.Sprintf("Relationship: %s DESCRIBES %s\n\n", d.ID, pkg.ID)
+		if d.describes(pkg.ID) {
+			doc += fmt.Sprintf("Relationship: %s DESCRIBES %s\n\n", d.ID, pkg.ID)
+		}
+	}
+
+	for _, s := range d.Snippets {
+		snippetDoc, err := s.Render()
+		if err != nil {
+			return "", fmt.Errorf("rendering snippet: %w", err)
+		}
+		doc += snippetDoc
+	}
+
+	if len(d.ExtractedLicenses) > 0 {
+		ltmpl, err := template.New("license").Parse(extractedLicenseTemplate)
+		if err != nil {
+			return "", fmt.Errorf("parsing license template: %w", err)
+		}
+		for i := range d.ExtractedLicenses {
+			var lbuf bytes.Buffer
+			if err := ltmpl.Execute(&lbuf, &d.ExtractedLicenses[i]); err != nil {
+				return "", fmt.Errorf("rendering license %s: %w", d.ExtractedLicenses[i].ID, err)
+			}
+			doc += lbuf.String()
+		}
 	}
 
 	return doc, err
@@ -277,6 +414,9 @@ func (d *Document) Render() (doc string, err error) {
 
 // AddFile adds a file contained in the package.
 func (d *Document) AddFile(file *File) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
 	if d.Files == nil {
 		d.Files = map[string]*File{}
 	}
@@ -295,8 +435,9 @@ func (d *Document) AddFile(file *File) error {
 		}
 		file.ID = "SPDXRef-File-" + hex.EncodeToString(h.Sum(nil))
 	}
-	d.ensureUniqueElementID(file)
+	d.uniqueElementID(file, d.lookupID)
 	d.Files[file.ID] = file
+	d.indexSubgraph(file)
 	return nil
 }
 
@@ -421,23 +562,36 @@ func (d *Document) WriteProvenanceStatement(opts *ProvenanceOptions, path string
 	return nil
 }
 
-// ensureUniquePackageID takes a string and checks if
+// ensureUniqueElementID takes a string and checks if
 // there is another string with the same name in the document.
 // If there is one, it will append a digit until a unique name
 // is found.
+//
+// Unlike AddPackage and AddFile, it also searches the graph for IDs not
+// found in the index, in case the document was changed without going
+// through AddRelationship or SetSPDXID.
 func (d *Document) ensureUniqueElementID(o Object) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.uniqueElementID(o, d.findElement)
+}
+
+// uniqueElementID renames o if its ID is already taken in the document,
+// checking the IDs with the lookup function. The caller must hold the
+// document lock.
+func (d *Document) uniqueElementID(o Object, lookup func(string) Object) {
 	newID := o.SPDXID()
 	i := 0
 	for {
 		// Check if there us already an element with the same ID
-		if el := d.GetElementByID(newID); el == nil {
+		if el := lookup(newID); el == nil {
 			if o.SPDXID() != newID {
 				logrus.Infof(
 					"Element name changed from %s to %s to ensure it is unique",
 					o.SPDXID(), newID,
 				)
+				o.SetSPDXID(newID)
 			}
-			o.SetSPDXID(newID)
 			break
 		}
 		i++
@@ -448,6 +602,9 @@ func (d *Document) ensureUniqueElementID(o Object) {
 // ensureUniquePeerIDs gets a relationship collection and ensures all peers
 // have unique IDs.
 func (d *Document) ensureUniquePeerIDs(rels *[]*Relationship) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
 	// First, ensure peer names are unique among themselves
 	seen := map[string]struct{}{}
 	for _, rel := range *rels {
@@ -472,21 +629,40 @@ func (d *Document) ensureUniquePeerIDs(rels *[]*Relationship) {
 		if rel.Peer == nil {
 			continue
 		}
-		d.ensureUniqueElementID(rel.Peer)
+		d.uniqueElementID(rel.Peer, d.findElement)
 	}
 }
 
 // GetPackageByID queries the packages to search for a specific entity by name
 // note that this method returns a copy of the entity if found.
+//
+// Lookups are served from an index of the document elements, falling
+// back to searching the graph if the ID is not found in it.
 func (d *Document) GetElementByID(id string) Object {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.findElement(id)
+}
+
+// findElement returns the element with the ID from the index, searching
+// the graph when it is not found there. The caller must hold the document
+// lock.
+func (d *Document) findElement(id string) Object {
+	if o := d.lookupID(id); o != nil {
+		return o
+	}
+
 	seen := map[string]struct{}{}
 	for _, p := range d.Packages {
 		if sub := recursiveIDSearch(id, p, &seen); sub != nil {
+			// The graph changed without the index noticing
+			d.index = nil
 			return sub
 		}
 	}
 	for _, f := range d.Files {
 		if sub := recursiveIDSearch(id, f, &seen); sub != nil {
+			d.index = nil
 			return sub
 		}
 	}
@@ -528,6 +704,7 @@ type ValidationResults struct {
 	Success          bool
 	Message          string
 	FileName         string
+	PackageID        string // Package verified, when validating packages
 	FailedAlgorithms []string
 }
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/in-toto/in-toto-golang/in_toto"
@@ -187,6 +188,40 @@ func TestEnsureUniquePeerIDs(t *testing.T) {
 	}
 }
 
+func TestConcurrentAdd(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "concurrent"
+	parent := NewPackage()
+	parent.SetSPDXID("SPDXRef-Package-parent")
+	require.NoError(t, doc.AddPackage(parent))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 150)
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := NewPackage()
+			p.Name = "scanned"
+			errs <- doc.AddPackage(p)
+			errs <- parent.AddPackage(NewPackage())
+
+			f := NewFile()
+			f.Name = fmt.Sprintf("file%d.txt", i)
+			errs <- doc.AddFile(f)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, doc.Packages, 51)
+	require.Len(t, doc.Files, 50)
+	require.Len(t, parent.Relationships, 50)
+}
+
 func TestValidateFiles(t *testing.T) {
 	type fileMap struct {
 		shouldFail bool
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// maxElementPaths caps the number of paths returned by ElementPaths. In
+// dependency graphs with many shared packages, the number of paths
+// grows exponentially with their depth.
+const maxElementPaths = 1000
+
+// ElementPath is a chain of elements linked by relationships, from a top
+// level element of the document down to an element.
+type ElementPath []Object
+
+// Element returns the element at the end of the path.
+func (ep ElementPath) Element() Object {
+	if len(ep) == 0 {
+		return nil
+	}
+	return ep[len(ep)-1]
+}
+
+// String returns the path as text, eg "image → layer → package".
+func (ep ElementPath) String() string {
+	return ContainmentPath(ep).String()
+}
+
+// IDs returns the path as a chain of SPDX identifiers.
+func (ep ElementPath) IDs() string {
+	ids := []string{}
+	for _, o := range ep {
+		ids = append(ids, o.SPDXID())
+	}
+	return strings.Join(ids, " → ")
+}
+
+// GetElementsByID returns every element in the document with an ID. Unlike
+// GetElementByID, which returns the first one found, it returns all of the
+// elements sharing the ID, eg after merging documents.
+func (d *Document) GetElementsByID(id string) []Object {
+	elements := []Object{}
+	// The walk function never fails, so Walk can't return an error
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck
+		if o.SPDXID() == id {
+			elements = append(elements, o)
+		}
+		return nil
+	})
+	return elements
+}
+
+// ElementPaths returns the chains of elements leading to every element
+// with an ID, from the top level elements of the document down to it.
+// An element reachable through more than one chain produces a path for
+// each of them, up to maxElementPaths in total.
+func (d *Document) ElementPaths(id string) []ElementPath {
+	// Find the elements that lead to the targets, so the search only
+	// follows the relationships that can reach them
+	parents := map[Object][]Object{}
+	targets := []Object{}
+	// The walk function never fails, so Walk can't return an error
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck
+		if o.SPDXID() == id {
+			targets = append(targets, o)
+		}
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer != nil {
+				parents[rel.Peer] = append(parents[rel.Peer], o)
+			}
+		}
+		return nil
+	})
+	reaches := map[Object]struct{}{}
+	for len(targets) > 0 {
+		o := targets[0]
+		targets = targets[1:]
+		if _, ok := reaches[o]; ok {
+			continue
+		}
+		reaches[o] = struct{}{}
+		targets = append(targets, parents[o]...)
+	}
+
+	paths := []ElementPath{}
+	var walk func(o Object, chain ElementPath)
+	walk = func(o Object, chain ElementPath) {
+		if _, ok := reaches[o]; !ok || len(paths) >= maxElementPaths {
+			return
+		}
+		// Guard against cycles in the graph
+		if slices.Contains(chain, o) {
+			return
+		}
+		chain = append(chain[:len(chain):len(chain)], o)
+
+		if o.SPDXID() == id {
+			paths = append(paths, chain)
+		}
+
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer != nil {
+				walk(rel.Peer, chain)
+			}
+		}
+	}
+
+	for _, pid := range slices.Sorted(maps.Keys(d.Packages)) {
+		walk(d.Packages[pid], ElementPath{})
+	}
+	for _, fid := range slices.Sorted(maps.Keys(d.Files)) {
+		walk(d.Files[fid], ElementPath{})
+	}
+	return paths
+}
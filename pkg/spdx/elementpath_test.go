@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementPaths(t *testing.T) {
+	doc := NewDocument()
+	pkgs := map[string]*Package{}
+	for _, name := range []string{"app", "cli", "lib", "lib-fork"} {
+		p := NewPackage()
+		p.Name = name
+		p.SetSPDXID("SPDXRef-Package-" + name)
+		pkgs[name] = p
+	}
+	// Merged documents brought a second package with the lib ID
+	pkgs["lib-fork"].SetSPDXID("SPDXRef-Package-lib")
+
+	require.NoError(t, pkgs["app"].AddPackage(pkgs["lib"]))
+	require.NoError(t, pkgs["app"].AddPackage(pkgs["cli"]))
+	require.NoError(t, pkgs["cli"].AddDependency(pkgs["lib"]))
+	require.NoError(t, pkgs["cli"].AddDependency(pkgs["lib-fork"]))
+	require.NoError(t, doc.AddPackage(pkgs["app"]))
+
+	elements := doc.GetElementsByID("SPDXRef-Package-lib")
+	require.Len(t, elements, 2)
+	require.Equal(t, pkgs["lib"], elements[0])
+	require.Equal(t, pkgs["lib-fork"], elements[1])
+
+	paths := doc.ElementPaths("SPDXRef-Package-lib")
+	require.Len(t, paths, 3)
+	require.Equal(t, "app → lib", paths[0].String())
+	require.Equal(t, "app → cli → lib", paths[1].String())
+	require.Equal(t, "app → cli → lib-fork", paths[2].String())
+	require.Equal(t, pkgs["lib-fork"], paths[2].Element())
+	require.Equal(t, "SPDXRef-Package-app → SPDXRef-Package-cli → SPDXRef-Package-lib", paths[1].IDs())
+
+	require.Empty(t, doc.ElementPaths("SPDXRef-Package-missing"))
+}
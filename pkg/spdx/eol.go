@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"sigs.k8s.io/bom/pkg/osinfo"
+)
+
+// EndOfLifeRelease records an image based on an OS release that no
+// longer receives security updates.
+type EndOfLifeRelease struct {
+	Image     string    `json:"image"`
+	Release   string    `json:"release"`
+	EndOfLife time.Time `json:"endOfLife"`
+}
+
+// String returns a description of the end of life release
+func (r EndOfLifeRelease) String() string {
+	return fmt.Sprintf(
+		"Image %s is based on %s which reached its end of life on %s",
+		r.Image, r.Release, r.EndOfLife.Format(time.DateOnly),
+	)
+}
+
+// EndOfLifeLog collects the images based on end of life OS releases
+// found while generating a document. It is safe for concurrent use.
+// Releases recorded in a nil log are only logged as warnings.
+type EndOfLifeLog struct {
+	mtx      sync.Mutex
+	releases []EndOfLifeRelease
+}
+
+// check looks up the OS release of an image in the end of life table. If
+// the release is past its end of life, it logs a warning and records it
+// to be annotated in the document.
+func (l *EndOfLifeLog) check(image string, release *osinfo.OSRelease, now time.Time) {
+	eol, ok := release.EndOfLife()
+	if !ok {
+		logrus.Debugf("No end of life date known for %s", release)
+		return
+	}
+	if now.Before(eol) {
+		return
+	}
+	r := EndOfLifeRelease{Image: image, Release: release.String(), EndOfLife: eol}
+	logrus.Warn(r.String())
+	if l == nil {
+		return
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.releases = append(l.releases, r)
+}
+
+// Releases returns the end of life releases recorded in the log.
+func (l *EndOfLifeLog) Releases() []EndOfLifeRelease {
+	if l == nil {
+		return nil
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return slices.Clone(l.releases)
+}
+
+// AddEndOfLifeAnnotations records the images based on end of life OS
+// releases as annotations of the document.
+func (d *Document) AddEndOfLifeAnnotations(releases []EndOfLifeRelease) {
+	date := time.Now().UTC().Format(time.RFC3339)
+	for _, r := range releases {
+		d.Annotations = append(d.Annotations, Annotation{
+			Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+			Date:      date,
+			Type:      "OTHER",
+			Comment:   r.String(),
+		})
+	}
+}
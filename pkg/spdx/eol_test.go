@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/osinfo"
+)
+
+func TestCheckEndOfLife(t *testing.T) {
+	log := &EndOfLifeLog{}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	log.check("buster:latest", osinfo.ParseOSRelease(
+		"PRETTY_NAME=\"Debian GNU/Linux 10 (buster)\"\nVERSION_ID=\"10\"\nID=debian\n",
+	), now)
+	log.check("bookworm:latest", osinfo.ParseOSRelease(
+		"PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nVERSION_ID=\"12\"\nID=debian\n",
+	), now)
+	log.check("wolfi:latest", osinfo.ParseOSRelease("NAME=\"Wolfi\"\nID=wolfi\n"), now)
+
+	releases := log.Releases()
+	require.Len(t, releases, 1)
+	require.Equal(t, "buster:latest", releases[0].Image)
+	require.Equal(t, "2024-06-30", releases[0].EndOfLife.Format(time.DateOnly))
+
+	doc := NewDocument()
+	doc.AddEndOfLifeAnnotations(releases)
+	require.Len(t, doc.Annotations, 1)
+	require.Equal(t,
+		"Image buster:latest is based on Debian GNU/Linux 10 (buster) which reached its end of life on 2024-06-30",
+		doc.Annotations[0].Comment,
+	)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha1" //nolint:gosec // SHA1 is the checksum the SPDX tools validate
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/http"
+)
+
+// ociURIScheme prefixes the references to documents stored as OCI
+// artifacts, eg oci://registry.example.com/app-sbom@sha256:...
+const ociURIScheme = "oci://"
+
+var (
+	// externalDocCacheDir holds the checksums of the documents referenced
+	// by digest, which cannot change
+	externalDocCacheDir = filepath.Join(os.TempDir(), spdxTempDir, "externalDocs")
+
+	// externalDocChecksums keeps the checksums of the remote documents
+	// read in this run by URI, so each one is downloaded once
+	externalDocChecksums sync.Map
+)
+
+// isRemoteDocument returns true if the path of an external document
+// points to a web server or an OCI registry
+func isRemoteDocument(path string) bool {
+	if strings.HasPrefix(path, ociURIScheme) {
+		return true
+	}
+	return isURL(path) && (strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://"))
+}
+
+// remoteDocumentSHA1 returns the SHA1 checksum of a document served over
+// http(s) or stored in an OCI registry.
+func remoteDocumentSHA1(uri string) (string, error) {
+	if sum, ok := externalDocChecksums.Load(uri); ok {
+		return sum.(string), nil //nolint:errcheck // Only strings are stored
+	}
+
+	var sum string
+	var err error
+	if strings.HasPrefix(uri, ociURIScheme) {
+		sum, err = ociDocumentSHA1(strings.TrimPrefix(uri, ociURIScheme))
+	} else {
+		h := sha1.New()
+		if err = http.NewAgent().GetToWriter(h, uri); err != nil {
+			err = fmt.Errorf("downloading %s: %w", uri, err)
+		}
+		sum = hex.EncodeToString(h.Sum(nil))
+	}
+	if err != nil {
+		return "", err
+	}
+	externalDocChecksums.Store(uri, sum)
+	return sum, nil
+}
+
+// ociDocumentSHA1 returns the SHA1 checksum of the document stored in
+// the OCI artifact at ref. The checksums of artifacts referenced by
+// digest are cached on disk.
+func ociDocumentSHA1(refString string) (string, error) {
+	ref, err := name.ParseReference(refString)
+	if err != nil {
+		return "", fmt.Errorf("parsing OCI reference %s: %w", refString, err)
+	}
+
+	cachePath := ""
+	if digest, ok := ref.(name.Digest); ok {
+		cachePath = filepath.Join(externalDocCacheDir, strings.ReplaceAll(digest.DigestStr(), ":", "-")+".sha1")
+		if data, err := os.ReadFile(cachePath); err == nil {
+			logrus.Debugf("Using cached checksum of %s", refString)
+			return strings.TrimSpace(string(data)), nil
+		}
+	}
+
+	img, err := remote.Image(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return "", fmt.Errorf("fetching OCI artifact %s: %w", refString, err)
+	}
+	layer, err := documentLayer(img)
+	if err != nil {
+		return "", fmt.Errorf("reading OCI artifact %s: %w", refString, err)
+	}
+
+	// Artifact layers hold the document as pushed, so the checksum is
+	// computed over the blob without decompressing it
+	rc, err := layer.Compressed()
+	if err != nil {
+		return "", fmt.Errorf("fetching document from %s: %w", refString, err)
+	}
+	defer rc.Close()
+	h := sha1.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", fmt.Errorf("reading document from %s: %w", refString, err)
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	if cachePath != "" {
+		if err := os.MkdirAll(externalDocCacheDir, os.FileMode(0o755)); err == nil {
+			if err := os.WriteFile(cachePath, []byte(sum), os.FileMode(0o644)); err != nil {
+				logrus.Warnf("Unable to cache the checksum of %s: %v", refString, err)
+			}
+		}
+	}
+	return sum, nil
+}
+
+// documentLayer returns the layer of the artifact holding the SPDX
+// document: the one with an SPDX media type or the only layer.
+func documentLayer(img v1.Image) (v1.Layer, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("listing layers: %w", err)
+	}
+	for _, layer := range layers {
+		mt, err := layer.MediaType()
+		if err != nil {
+			return nil, fmt.Errorf("reading layer media type: %w", err)
+		}
+		if strings.Contains(string(mt), "spdx") {
+			return layer, nil
+		}
+	}
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+	if len(layers) == 0 {
+		return nil, errors.New("artifact has no layers")
+	}
+	return nil, fmt.Errorf("artifact has %d layers and none has an SPDX media type", len(layers))
+}
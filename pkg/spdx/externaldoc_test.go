@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha1" //nolint:gosec // Expected SPDX checksums
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func sha1Hex(data []byte) string {
+	sum := sha1.Sum(data) //nolint:gosec // Expected SPDX checksums
+	return hex.EncodeToString(sum[:])
+}
+
+func TestExternalDocumentRefLocal(t *testing.T) {
+	data := []byte(`{"spdxVersion": "SPDX-2.3"}`)
+	path := filepath.Join(t.TempDir(), "sbom.spdx.json")
+	require.NoError(t, os.WriteFile(path, data, os.FileMode(0o644)))
+
+	ref := &ExternalDocumentRef{ID: "local", URI: "https://example.com/sbom.spdx.json"}
+	require.NoError(t, ref.ReadSourceFile(path))
+	require.Equal(t, map[string]string{"SHA1": sha1Hex(data)}, ref.Checksums)
+	require.Equal(t, "https://example.com/sbom.spdx.json", ref.URI)
+}
+
+func TestExternalDocumentRefURL(t *testing.T) {
+	data := []byte(`{"spdxVersion": "SPDX-2.3", "name": "remote"}`)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Write(data) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	uri := srv.URL + "/sbom.spdx.json"
+	ref := &ExternalDocumentRef{ID: "remote"}
+	require.NoError(t, ref.ReadSourceFile(uri))
+	require.Equal(t, sha1Hex(data), ref.Checksums["SHA1"])
+	require.Equal(t, uri, ref.URI)
+
+	// The document is downloaded once
+	other := &ExternalDocumentRef{ID: "other"}
+	require.NoError(t, other.ReadSourceFile(uri))
+	require.Equal(t, sha1Hex(data), other.Checksums["SHA1"])
+	require.Equal(t, 1, requests)
+}
+
+func TestExternalDocumentRefOCI(t *testing.T) {
+	externalDocCacheDir = t.TempDir()
+
+	data := []byte(`{"spdxVersion": "SPDX-2.3", "name": "artifact"}`)
+	reg := spdxtest.NewRegistry(t)
+	img, err := mutate.AppendLayers(empty.Image, static.NewLayer(data, types.MediaType("application/spdx+json")))
+	require.NoError(t, err)
+	digestRef := reg.PushImage(t, "app-sbom:v1.0.0", img)
+
+	ref := &ExternalDocumentRef{ID: "oci"}
+	require.NoError(t, ref.ReadSourceFile(ociURIScheme+digestRef))
+	require.Equal(t, sha1Hex(data), ref.Checksums["SHA1"])
+	require.Equal(t, ociURIScheme+digestRef, ref.URI)
+
+	// Artifacts referenced by digest are cached on disk
+	entries, err := os.ReadDir(externalDocCacheDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	sum, err := ociDocumentSHA1(digestRef)
+	require.NoError(t, err)
+	require.Equal(t, sha1Hex(data), sum)
+
+	// Artifacts with more than one layer need an SPDX media type
+	img, err = mutate.AppendLayers(
+		empty.Image,
+		static.NewLayer([]byte("a"), types.MediaType("text/plain")),
+		static.NewLayer([]byte("b"), types.MediaType("text/plain")),
+	)
+	require.NoError(t, err)
+	reg.PushImage(t, "other:v1.0.0", img)
+	_, err = ociDocumentSHA1(reg.Reference("other:v1.0.0"))
+	require.Error(t, err)
+}
@@ -19,8 +19,6 @@ package spdx
 import (
 	"bytes"
 	"fmt"
-	"net/http"
-	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
@@ -51,7 +49,16 @@ LicenseConcluded: {{ if .LicenseConcluded }}{{ .LicenseConcluded }}{{ else }}NOA
 LicenseInfoInFile: {{ if .LicenseInfoInFile }}{{ .LicenseInfoInFile }}{{ else }}NOASSERTION{{ end }}
 FileCopyrightText: {{ if .CopyrightText }}<text>{{ .CopyrightText }}
 </text>{{ else }}NOASSERTION{{ end }}
-
+{{ if .Comment }}FileComment: <text>{{ .Comment }}
+</text>
+{{ end -}}
+{{ if .NoticeText }}FileNotice: <text>{{ .NoticeText }}
+</text>
+{{ end -}}
+{{ range .AttributionTexts }}FileAttributionText: <text>{{ . }}
+</text>
+{{ end -}}
+` + annotationsTemplate + `
 `
 
 // File abstracts a file contained in a package.
@@ -59,6 +66,8 @@ type File struct {
 	Entity
 	FileType          []string
 	LicenseInfoInFile string // GPL-3.0-or-later
+	Comment           string // General comments about the file
+	NoticeText        string // Notices found in the file (eg a NOTICE file)
 }
 
 func NewFile() (f *File) {
@@ -93,6 +102,16 @@ func (f *File) Render() (docFragment string, err error) {
 	}
 
 	docFragment = buf.String()
+
+	// Add the relationships of the file, eg binaries GENERATED_FROM
+	// their sources
+	for _, rel := range f.Relationships {
+		fragment, err := rel.Render(f)
+		if err != nil {
+			return "", fmt.Errorf("rendering relationship: %w", err)
+		}
+		docFragment += fragment
+	}
 	return docFragment, nil
 }
 
@@ -130,27 +149,40 @@ func (f *File) ReadSourceFile(path string) error {
 		f.BuildID()
 	}
 
-	f.FileType = getFileTypes(path)
+	var mediaType string
+	f.FileType, mediaType = getFileTypes(path)
+	if comment := "Media type: " + mediaType; mediaType != "" && !strings.Contains(f.Comment, comment) {
+		if f.Comment != "" {
+			f.Comment += "\n"
+		}
+		f.Comment += comment
+	}
+	if format := modelFormat(path); format != "" && f.Options().ModelMetadata {
+		f.addModelMetadata(path, format)
+	}
 
 	return nil
 }
 
-func getFileTypes(path string) []string {
-	fileExtension := strings.TrimLeft(filepath.Ext(path), ".")
-
-	if fileExtension == "" {
-		mineType, err := getFileContentType(path)
-		if err != nil {
-			return []string{"OTHER"}
-		}
-		splited := strings.Split(mineType, "/")
+// getFileTypes returns the SPDX types of a file from its extension.
+// When the extension is missing or unknown, the types are inferred from
+// the media type detected in the file contents, which is returned too.
+func getFileTypes(path string) (fileTypes []string, mediaType string) {
+	if fileTypes := extensionFileTypes(strings.ToLower(strings.TrimLeft(filepath.Ext(path), "."))); fileTypes != nil {
+		return fileTypes, ""
+	}
 
-		fileExtension = splited[0]
-		if splited[0] == "application" {
-			fileExtension = splited[1]
-		}
+	mediaType, err := detectMediaType(path)
+	if err != nil {
+		logrus.Debugf("Unable to detect media type of %s: %v", path, err)
+		return []string{"OTHER"}, ""
 	}
+	return mediaTypeFileTypes(mediaType), mediaType
+}
 
+// extensionFileTypes returns the SPDX types of the files with a known
+// extension, nil if the extension is not recognized.
+func extensionFileTypes(fileExtension string) []string {
 	switch fileExtension {
 	case "go", "java", "rs", "rb", "c", "cgi", "class", "cpp", "cs", "h",
 		"php", "py", "sh", "swift", "vb", "css":
@@ -160,7 +192,7 @@ func getFileTypes(path string) []string {
 		return []string{"TEXT", "DOCUMENTATION"}
 	case "yml", "yaml", "json":
 		return []string{"TEXT"}
-	case "exe", "a", "o", "octet-stream", "apk", "bat",
+	case "exe", "a", "o", "apk", "bat",
 		"bin", "pl", "com", "gadget", "jar", "msi", "wsf":
 		return []string{"BINARY", "APPLICATION"}
 	case "jpeg", "jpg", "png", "svg", "ai", "bmp", "gif", "ico",
@@ -173,25 +205,8 @@ func getFileTypes(path string) []string {
 		"deb", "pkg", "rar", "rpm", "z", "cpio":
 		return []string{"ARCHIVE"}
 	default:
-		return []string{"OTHER"}
-	}
-}
-
-func getFileContentType(path string) (string, error) {
-	// Only the first 512 bytes are used to sniff the content type.
-	file, err := os.Open(path)
-	if err != nil {
-		return "", err
+		return nil
 	}
-
-	buffer := make([]byte, 512)
-	_, err = file.Read(buffer)
-	if err != nil {
-		return "", err
-	}
-
-	contentType := http.DetectContentType(buffer)
-	return contentType, nil
 }
 
 // GetElementByID search the file and its peers looking for the
@@ -18,6 +18,8 @@ package spdx
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -40,7 +42,7 @@ func TestGetFileType(t *testing.T) {
 	file, dir, err := createTempFile("temp.*.bat")
 	require.NoError(t, err)
 
-	fileType := getFileTypes(file.Name())
+	fileType, _ := getFileTypes(file.Name())
 
 	require.Len(t, fileType, 2)
 	require.EqualValues(t, []string{"BINARY", "APPLICATION"}, fileType)
@@ -49,7 +51,7 @@ func TestGetFileType(t *testing.T) {
 	file, dir, err = createTempFile("honk.*.go")
 	require.NoError(t, err)
 
-	fileType = getFileTypes(file.Name())
+	fileType, _ = getFileTypes(file.Name())
 
 	require.Len(t, fileType, 1)
 	require.EqualValues(t, []string{"SOURCE"}, fileType)
@@ -58,7 +60,7 @@ func TestGetFileType(t *testing.T) {
 	file, dir, err = createTempFile("honk.*.mp3")
 	require.NoError(t, err)
 
-	fileType = getFileTypes(file.Name())
+	fileType, _ = getFileTypes(file.Name())
 
 	require.Len(t, fileType, 1)
 	require.EqualValues(t, []string{"AUDIO"}, fileType)
@@ -67,9 +69,30 @@ func TestGetFileType(t *testing.T) {
 	file, dir, err = createTempFile("say.*.honk")
 	require.NoError(t, err)
 
-	fileType = getFileTypes(file.Name())
+	fileType, _ = getFileTypes(file.Name())
 
 	require.Len(t, fileType, 1)
 	require.EqualValues(t, []string{"OTHER"}, fileType)
 	require.NoError(t, os.RemoveAll(dir))
 }
+
+func TestSourceFileName(t *testing.T) {
+	workDir := filepath.Join(t.TempDir(), "src")
+	for _, tc := range []struct {
+		workDir  string
+		path     string
+		expected string
+	}{
+		{workDir, filepath.Join(workDir, "main.go"), "main.go"},
+		{workDir, filepath.Join(workDir, "pkg", "lib", "lib.go"), "pkg/lib/lib.go"},
+		{workDir + string(filepath.Separator), filepath.Join(workDir, "pkg", "lib.go"), "pkg/lib.go"},
+		{workDir, filepath.Join("relative", "file.txt"), "relative/file.txt"},
+		{"", filepath.Join("dir", "file.txt"), "dir/file.txt"},
+		{
+			filepath.Join(workDir, "pkg"), filepath.Join(workDir, "cmd", "main.go"),
+			filepath.ToSlash(strings.TrimPrefix(filepath.Join(workDir, "cmd", "main.go"), filepath.VolumeName(workDir))),
+		},
+	} {
+		require.Equal(t, tc.expected, sourceFileName(tc.workDir, tc.path))
+	}
+}
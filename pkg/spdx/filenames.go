@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// NormalizeFileNames rewrites the names of the files in the document so
+// they do not depend on where they were scanned. The first of the strip
+// prefixes matching a file name is removed from it, then the add prefix
+// is prepended to the name made relative (eg "./" to follow the SPDX
+// recommendation for relative names). File names set from the path are updated too. It returns the
+// number of files renamed.
+func (d *Document) NormalizeFileNames(strip []string, add string) int {
+	prefixes := []string{}
+	for _, p := range strip {
+		if p = strings.TrimSuffix(filepath.ToSlash(p), "/"); p != "" {
+			prefixes = append(prefixes, p+"/")
+		}
+	}
+	add = filepath.ToSlash(add)
+	if add != "" && !strings.HasSuffix(add, "/") {
+		add += "/"
+	}
+
+	n := 0
+	// The walk function never fails, so Walk can't return an error
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck
+		f, ok := o.(*File)
+		if !ok || f.FileName == "" {
+			return nil
+		}
+		name := filepath.ToSlash(f.FileName)
+		for _, p := range prefixes {
+			if rest, ok := strings.CutPrefix(name, p); ok {
+				name = rest
+				break
+			}
+		}
+		if add != "" {
+			name = add + strings.TrimLeft(strings.TrimPrefix(name, "./"), "/")
+		}
+		if name == f.FileName {
+			return nil
+		}
+		if f.Name == f.FileName {
+			f.Name = name
+		}
+		f.FileName = name
+		n++
+		return nil
+	})
+	return n
+}
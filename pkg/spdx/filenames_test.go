@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeFileNames(t *testing.T) {
+	newDoc := func() (*Document, []*File) {
+		doc := NewDocument()
+		pkg := NewPackage()
+		pkg.Name = "src"
+		pkg.BuildID("src")
+		files := []*File{}
+		for _, name := range []string{"/builds/ci/app/main.go", "cmd/root.go", "/tmp/other.go"} {
+			f := NewFile()
+			f.Name = name
+			f.FileName = name
+			f.BuildID(name)
+			require.NoError(t, pkg.AddFile(f))
+			files = append(files, f)
+		}
+		files[1].Name = "root"
+		require.NoError(t, doc.AddPackage(pkg))
+		return doc, files
+	}
+
+	doc, files := newDoc()
+	require.Equal(t, 1, doc.NormalizeFileNames([]string{"/home/user", "/builds/ci/app/"}, ""))
+	require.Equal(t, "main.go", files[0].FileName)
+	require.Equal(t, "main.go", files[0].Name)
+	require.Equal(t, "cmd/root.go", files[1].FileName)
+	require.Equal(t, "/tmp/other.go", files[2].FileName)
+
+	doc, files = newDoc()
+	require.Equal(t, 3, doc.NormalizeFileNames([]string{"/builds/ci/app"}, "./"))
+	require.Equal(t, "./main.go", files[0].FileName)
+	require.Equal(t, "./cmd/root.go", files[1].FileName)
+	require.Equal(t, "root", files[1].Name)
+	require.Equal(t, "./tmp/other.go", files[2].FileName)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+)
+
+// goModulePath returns the path of the module declared in the go.mod
+// file found in dir.
+func goModulePath(dir string) (string, error) {
+	modData, err := os.ReadFile(filepath.Join(dir, GoModFileName))
+	if err != nil {
+		return "", fmt.Errorf("reading module's go.mod file: %w", err)
+	}
+	path := modfile.ModulePath(modData)
+	if path == "" {
+		return "", fmt.Errorf("no module path in %s", filepath.Join(dir, GoModFileName))
+	}
+	return path, nil
+}
+
+// relateGoBinaries looks for go binaries among the files of the package
+// and records they were GENERATED_FROM the package of the module they
+// were built from. modules maps the module paths to their packages.
+// Binaries of modules not in the map are left alone. It returns the
+// number of binaries related.
+func relateGoBinaries(pkg *Package, modules map[string]*Package) int {
+	related := 0
+	for _, f := range pkg.Files() {
+		if f.SourceFile == "" ||
+			!(slices.Contains(f.FileType, "BINARY") || slices.Contains(f.FileType, "OTHER")) {
+			continue
+		}
+		info, err := buildinfo.ReadFile(f.SourceFile)
+		if err != nil {
+			continue
+		}
+		modPkg, ok := modules[info.Main.Path]
+		if !ok {
+			logrus.Debugf("Go binary %s was built from %s, not scanned", f.FileName, info.Main.Path)
+			continue
+		}
+		logrus.Infof("Go binary %s was built from module %s", f.FileName, info.Main.Path)
+		f.AddRelationship(&Relationship{
+			Type: GENERATED_FROM,
+			Peer: modPkg,
+		})
+		related++
+	}
+	return related
+}
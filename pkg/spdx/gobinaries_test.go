@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoModulePath(t *testing.T) {
+	dir := t.TempDir()
+	_, err := goModulePath(dir)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, GoModFileName), []byte("go 1.23\n"), os.FileMode(0o644)))
+	_, err = goModulePath(dir)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, GoModFileName), []byte("module example.com/app\n\ngo 1.23\n"), os.FileMode(0o644),
+	))
+	path, err := goModulePath(dir)
+	require.NoError(t, err)
+	require.Equal(t, "example.com/app", path)
+}
+
+func TestRelateGoBinaries(t *testing.T) {
+	// The test binary is a go binary built from the bom module
+	dir := t.TempDir()
+	binary, err := os.ReadFile(os.Args[0])
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app"), binary, os.FileMode(0o755)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), os.FileMode(0o644)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.bin"), []byte{0, 1, 2, 3}, os.FileMode(0o644)))
+
+	pkg := NewPackage()
+	pkg.Name = "app"
+	pkg.BuildID(pkg.Name)
+	for _, name := range []string{"app", "main.go", "data.bin"} {
+		f := NewFile()
+		f.Options().WorkDir = dir
+		require.NoError(t, f.ReadSourceFile(filepath.Join(dir, name)))
+		require.NoError(t, pkg.AddFile(f))
+	}
+
+	other := NewPackage()
+	other.Name = "other"
+	other.BuildID(other.Name)
+	require.Zero(t, relateGoBinaries(pkg, map[string]*Package{"example.com/other": other}))
+
+	require.Equal(t, 1, relateGoBinaries(pkg, map[string]*Package{"sigs.k8s.io/bom": pkg}))
+	for _, f := range pkg.Files() {
+		if f.FileName != "app" {
+			require.Empty(t, f.Relationships, f.FileName)
+			continue
+		}
+		require.Len(t, f.Relationships, 1)
+		require.Equal(t, GENERATED_FROM, f.Relationships[0].Type)
+		require.Equal(t, pkg, f.Relationships[0].Peer)
+
+		rendered, err := f.Render()
+		require.NoError(t, err)
+		require.Contains(t, rendered, "Relationship: "+f.SPDXID()+" GENERATED_FROM "+pkg.SPDXID()+"\n")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package spdx
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,12 +25,16 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/nozzle/throttler"
 	purl "github.com/package-url/packageurl-go"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 	"golang.org/x/tools/go/vcs" //nolint:staticcheck
 
 	"sigs.k8s.io/release-utils/command"
@@ -70,9 +75,33 @@ type GoModule struct {
 }
 
 type GoModuleOptions struct {
-	Path           string // Path to the dir where go.mod resides
-	OnlyDirectDeps bool   // Only include direct dependencies from go.mod
-	ScanLicenses   bool   // Scan licenses from everypossible place unless false
+	Path           string   // Path to the dir where go.mod resides
+	OnlyDirectDeps bool     // Only include direct dependencies from go.mod
+	ScanLicenses   bool     // Scan licenses from everypossible place unless false
+	ExcludeDevDeps bool     // Skip modules only required by tests or tools
+	ClearlyDefined bool     // Look up licenses in ClearlyDefined before downloading the modules
+	GoModCache     string   // Module cache to read the modules from before downloading them
+	GOOS           string   // Target operating system to resolve the dependencies
+	GOARCH         string   // Target architecture to resolve the dependencies
+	BuildTags      []string // Build tags to use when resolving the dependencies
+
+	// LicenseScanCache keeps the license scan results of the modules
+	// across runs
+	LicenseScanCache *LicenseScanCache
+
+	// Degradations records the modules that could not be scanned
+	Degradations *DegradationLog
+}
+
+// hasBuildTarget returns true if the options define a target platform
+// or build tags to resolve the dependencies.
+func (o *GoModuleOptions) hasBuildTarget() bool {
+	return o.GOOS != "" || o.GOARCH != "" || len(o.BuildTags) > 0
+}
+
+// SetImplementation replaces the implementation driving the module.
+func (mod *GoModule) SetImplementation(impl GoModImplementation) {
+	mod.impl = impl
 }
 
 // Options returns a pointer to the module options set.
@@ -85,6 +114,7 @@ type GoPackage struct {
 	TmpDir        bool
 	ImportPath    string
 	Revision      string
+	ReleaseTime   time.Time
 	LocalDir      string
 	LocalInstall  string
 	LicenseID     string
@@ -100,6 +130,11 @@ func (pkg *GoPackage) ToSPDXPackage() (*Package, error) {
 	spdxPackage := NewPackage()
 	spdxPackage.Options().Prefix = "gomod"
 	spdxPackage.Name = pkg.ImportPath
+	spdxPackage.PrimaryPurpose = "LIBRARY"
+	if goModuleIsPublic(pkg.ImportPath) {
+		spdxPackage.HomePage = "https://pkg.go.dev/" + pkg.ImportPath
+	}
+	spdxPackage.ReleaseDate = pkg.ReleaseTime
 
 	spdxPackage.BuildID(pkg.ImportPath, pkg.Revision)
 	if strings.Contains(pkg.Revision, "+incompatible") {
@@ -149,6 +184,123 @@ func (pkg *GoPackage) PackageURL() string {
 	).ToString()
 }
 
+// goModuleIsPublic returns true if the module can be resolved from the
+// public proxy and index, ie its path starts with a domain name and it is
+// not matched by the GOPRIVATE or GONOPROXY patterns.
+func goModuleIsPublic(modPath string) bool {
+	first, _, _ := strings.Cut(modPath, "/")
+	if !strings.Contains(first, ".") {
+		return false
+	}
+	for _, env := range []string{"GOPRIVATE", "GONOPROXY"} {
+		if module.MatchPrefixPatterns(os.Getenv(env), modPath) {
+			return false
+		}
+	}
+	return true
+}
+
+// GoToolchain describes the go toolchain declared by a module.
+type GoToolchain struct {
+	Version  string // Version of go, without the go prefix (eg 1.22.3)
+	Declared bool   // True if the version comes from a toolchain directive
+	Binary   bool   // True if the version was read from the build info of a binary
+}
+
+// ReadGoToolchain parses the go.mod file in a directory and returns the
+// toolchain declared in it. If go.mod does not have a toolchain directive
+// the go version is returned as it is the minimum toolchain that can build
+// the module. Returns nil if go.mod does not specify any version.
+func ReadGoToolchain(path string) (*GoToolchain, error) {
+	modData, err := os.ReadFile(filepath.Join(path, GoModFileName))
+	if err != nil {
+		return nil, fmt.Errorf("reading module's go.mod file: %w", err)
+	}
+	gomod, err := modfile.ParseLax("file", modData, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading go.mod: %w", err)
+	}
+
+	if gomod.Toolchain != nil && strings.HasPrefix(gomod.Toolchain.Name, "go1") {
+		return &GoToolchain{
+			Version:  strings.TrimPrefix(gomod.Toolchain.Name, "go"),
+			Declared: true,
+		}, nil
+	}
+
+	if gomod.Go != nil && gomod.Go.Version != "" {
+		return &GoToolchain{Version: gomod.Go.Version}, nil
+	}
+	return nil, nil
+}
+
+// GoToolchainFromBuildInfo returns the toolchain that built a go binary
+// from the go version recorded in its build information, as printed by
+// go version -m (eg go1.22.3 or go1.22.3 X:boringcrypto). Returns nil for
+// development toolchains as they cannot be mapped to a release.
+func GoToolchainFromBuildInfo(goVersion string) *GoToolchain {
+	version, _, _ := strings.Cut(goVersion, " ")
+	if !strings.HasPrefix(version, "go1") {
+		return nil
+	}
+	return &GoToolchain{Version: strings.TrimPrefix(version, "go"), Binary: true}
+}
+
+// sourceURL returns the download location of the go sources.
+func (tc *GoToolchain) sourceURL() string {
+	return fmt.Sprintf("https://go.dev/dl/go%s.src.tar.gz", tc.Version)
+}
+
+// ToSPDXPackage returns a package describing the go toolchain.
+func (tc *GoToolchain) ToSPDXPackage() *Package {
+	pkg := NewPackage()
+	pkg.Name = "go"
+	pkg.Version = tc.Version
+	pkg.BuildID("go-toolchain", tc.Version)
+	pkg.DownloadLocation = tc.sourceURL()
+	pkg.LicenseDeclared = "BSD-3-Clause"
+	pkg.HomePage = "https://go.dev"
+	pkg.PrimaryPurpose = "APPLICATION"
+	pkg.Supplier.Organization = "Google LLC"
+	pkg.Comment = "Minimum go toolchain required by go.mod"
+	if tc.Declared {
+		pkg.Comment = "Go toolchain declared in go.mod"
+	}
+	if tc.Binary {
+		pkg.Comment = "Go toolchain that built the binary"
+	}
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  purl.NewPackageURL(purl.TypeGeneric, "", "go", tc.Version, nil, "").ToString(),
+	})
+	return pkg
+}
+
+// StdlibSPDXPackage returns a package describing the go standard library
+// distributed with the toolchain.
+func (tc *GoToolchain) StdlibSPDXPackage() *Package {
+	pkg := NewPackage()
+	pkg.Name = "stdlib"
+	pkg.Version = tc.Version
+	pkg.BuildID("go-stdlib", tc.Version)
+	pkg.DownloadLocation = tc.sourceURL()
+	pkg.LicenseDeclared = "BSD-3-Clause"
+	pkg.HomePage = "https://pkg.go.dev/std"
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.Supplier.Organization = "Google LLC"
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  purl.NewPackageURL(purl.TypeGolang, "", "stdlib", tc.Version, nil, "").ToString(),
+	})
+	return pkg
+}
+
+//counterfeiter:generate . GoModImplementation
+
+// GoModImplementation performs the go toolchain, download and license
+// scanning operations of a GoModule.
 type GoModImplementation interface {
 	OpenModule(*GoModuleOptions) (*modfile.File, error)
 	BuildPackageList(*modfile.File) ([]*GoPackage, error)
@@ -171,15 +323,82 @@ func (mod *GoModule) Open() error {
 	if mod.Options().OnlyDirectDeps {
 		pkgs, err = mod.impl.BuildPackageList(mod.GoMod)
 	} else {
+		// The full list is computed from the packages imported by the
+		// module's code, so it never includes test or tool dependencies.
 		pkgs, err = mod.BuildFullPackageList(mod.GoMod)
 	}
 	if err != nil {
 		return fmt.Errorf("building module package list: %w", err)
 	}
+
+	// go.mod lists the union of the requirements for all platforms and
+	// tests, trim it to the modules actually imported when requested.
+	if mod.Options().OnlyDirectDeps &&
+		(mod.Options().ExcludeDevDeps || mod.Options().hasBuildTarget()) {
+		pkgs, err = mod.removeUnimportedDependencies(pkgs)
+		if err != nil {
+			return fmt.Errorf("removing unused dependencies: %w", err)
+		}
+	}
 	mod.Packages = pkgs
 	return nil
 }
 
+// goListCommand returns a go list command to run in the module directory
+// configured to resolve the packages for the build target in the options.
+func (mod *GoModule) goListCommand(args ...string) (*command.Command, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, errors.New("go executable not found")
+	}
+
+	listArgs := []string{"list"}
+	if len(mod.opts.BuildTags) > 0 {
+		listArgs = append(listArgs, "-tags", strings.Join(mod.opts.BuildTags, ","))
+	}
+	listArgs = append(listArgs, args...)
+
+	cmd := command.NewWithWorkDir(mod.opts.Path, gobin, listArgs...)
+	if mod.opts.GOOS != "" {
+		cmd = cmd.Env("GOOS=" + mod.opts.GOOS)
+	}
+	if mod.opts.GOARCH != "" {
+		cmd = cmd.Env("GOARCH=" + mod.opts.GOARCH)
+	}
+	return cmd, nil
+}
+
+// removeUnimportedDependencies filters a package list, leaving only the
+// modules that provide packages imported by the non-test code of the module
+// when built for the configured target. This drops the requirements only
+// used by tests, go tool directives or other platforms.
+func (mod *GoModule) removeUnimportedDependencies(pkgs []*GoPackage) ([]*GoPackage, error) {
+	gorun, err := mod.goListCommand("-deps", "-e", "-f", "{{with .Module}}{{.Path}}{{end}}", "./...")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list module imports: %w", err)
+	}
+
+	output, err := gorun.RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("while calling go to list the module imports: %w", err)
+	}
+
+	imported := map[string]struct{}{}
+	for _, line := range strings.Split(output.OutputTrimNL(), "\n") {
+		imported[strings.TrimSpace(line)] = struct{}{}
+	}
+
+	filtered := []*GoPackage{}
+	for _, pkg := range pkgs {
+		if _, ok := imported[pkg.ImportPath]; !ok {
+			logrus.Infof("Skipping %s@%s, it is not imported by the module code", pkg.ImportPath, pkg.Revision)
+			continue
+		}
+		filtered = append(filtered, pkg)
+	}
+	return filtered, nil
+}
+
 // RemoveDownloads cleans all downloads.
 func (mod *GoModule) RemoveDownloads() error {
 	return mod.impl.RemoveDownloads(mod.Packages)
@@ -187,12 +406,21 @@ func (mod *GoModule) RemoveDownloads() error {
 
 // DownloadPackages downloads all the module's packages to the local disk.
 func (mod *GoModule) DownloadPackages() error {
+	return mod.DownloadPackagesContext(context.Background())
+}
+
+// DownloadPackagesContext downloads all the module's packages to the local
+// disk, stopping before the next download once the context is done.
+func (mod *GoModule) DownloadPackagesContext(ctx context.Context) error {
 	logrus.Infof("Downloading source code for %d packages", len(mod.Packages))
 	if mod.Packages == nil {
 		return errors.New("unable to download packages, package list is nil")
 	}
 
 	for _, pkg := range mod.Packages {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("downloading packages: %w", err)
+		}
 		if err := mod.impl.DownloadPackage(pkg, mod.opts, true); err != nil {
 			return err
 		}
@@ -202,6 +430,17 @@ func (mod *GoModule) DownloadPackages() error {
 
 // ScanLicenses scans the licenses and populats the fields.
 func (mod *GoModule) ScanLicenses() error {
+	return mod.ScanLicensesContext(context.Background())
+}
+
+// ScanLicensesContext scans the licenses and populates the fields. Once
+// the context is done no more packages are downloaded, the scans already
+// running are waited for and the context error is returned.
+//
+// Packages that can't be downloaded or scanned don't stop the scan, they
+// are recorded as degradations of the document once all packages are
+// done.
+func (mod *GoModule) ScanLicensesContext(ctx context.Context) error {
 	if mod.Packages == nil {
 		return errors.New("unable to scan lincese files, package list is nil")
 	}
@@ -211,27 +450,64 @@ func (mod *GoModule) ScanLicenses() error {
 		return fmt.Errorf("creating license scanner: %w", err)
 	}
 
-	logrus.Infof("Scanning licenses for %d go packages", len(mod.Packages))
+	known := mod.clearlyDefinedLicenses(ctx)
+
+	logrus.Infof("Scanning licenses for %d go packages", len(mod.Packages)-len(known))
+
+	cache := &SourceCaches{GoModCache: mod.opts.GoModCache}
+
+	var failuresMtx sync.Mutex
+	failures := []packageScanFailure{}
+	fail := func(kind DegradationKind, pkg *GoPackage, err error) {
+		failuresMtx.Lock()
+		defer failuresMtx.Unlock()
+		failures = append(failures, packageScanFailure{kind: kind, pkg: pkg, err: err})
+	}
 
 	// Create a new Throttler that will get parallelDownloads urls at a time
 	t := throttler.New(10, len(mod.Packages))
-	// Do a quick re-check for missing downloads
-	// todo: paralelize this. urgently.
 	for _, pkg := range mod.Packages {
-		// Launch a goroutine to fetch the package contents
+		if err := ctx.Err(); err != nil {
+			t.Done(err)
+			t.Throttle()
+			continue
+		}
+		if lic, ok := known[pkg.PackageURL()]; ok {
+			pkg.LicenseID = lic.License
+			pkg.CopyrightText = lic.Copyright
+			t.Done(nil)
+			t.Throttle()
+			continue
+		}
+		if cached := mod.opts.LicenseScanCache.Get(pkg.PackageURL()); cached != nil {
+			if cached.License == NOASSERTION {
+				fail(DegradationMissingLicenses, pkg, errNoLicenseFound)
+			} else {
+				pkg.LicenseID = cached.License
+				pkg.CopyrightText = cached.Copyright
+			}
+			t.Done(nil)
+			t.Throttle()
+			continue
+		}
+		// Launch a goroutine to fetch the package contents. Failures are
+		// collected, the throttler only gets the context errors.
 		go func(curPkg *GoPackage) {
+			defer t.Done(nil)
 			logrus.WithField(
 				"package", curPkg.ImportPath).Debugf(
 				"Downloading package (%d total)", len(mod.Packages),
 			)
-			defer t.Done(err)
+			if curPkg.LocalInstall == "" {
+				curPkg.LocalInstall = cache.GoModule(curPkg.ImportPath, curPkg.Revision)
+			}
 			if curPkg.LocalInstall == "" {
 				// Call download with no force in case local data is missing
-				if err2 := mod.impl.DownloadPackage(curPkg, mod.opts, false); err2 != nil {
+				if err := mod.impl.DownloadPackage(curPkg, mod.opts, false); err != nil {
 					// If we're unable to download the module we dont treat it as
 					// fatal, package will remain without license info but we go
 					// on scanning the rest of the packages.
-					logrus.WithField("package", curPkg.ImportPath).Error(err2)
+					fail(DegradationNetworkError, curPkg, fmt.Errorf("downloading module: %w", err))
 					return
 				}
 			} else {
@@ -240,22 +516,79 @@ func (mod *GoModule) ScanLicenses() error {
 				)
 			}
 
-			if err = mod.impl.ScanPackageLicense(curPkg, reader, mod.opts); err != nil {
-				logrus.WithField("package", curPkg.ImportPath).Errorf(
-					"scanning package %s for licensing info", curPkg.ImportPath,
-				)
+			if err := mod.impl.ScanPackageLicense(curPkg, reader, mod.opts); err != nil {
+				fail(DegradationMissingLicenses, curPkg, err)
+				return
 			}
+			if curPkg.LicenseID == "" {
+				fail(DegradationMissingLicenses, curPkg, errNoLicenseFound)
+			}
+			mod.opts.LicenseScanCache.Put(curPkg.PackageURL(), curPkg.LicenseID, curPkg.CopyrightText)
 		}(pkg)
 		t.Throttle()
 	}
 
+	// Record the failures in a stable order, the scans finish in any
+	slices.SortFunc(failures, func(a, b packageScanFailure) int {
+		return strings.Compare(a.pkg.ImportPath+"@"+a.pkg.Revision, b.pkg.ImportPath+"@"+b.pkg.Revision)
+	})
+	for _, f := range failures {
+		mod.opts.Degradations.recordPackage(
+			f.kind, f.pkg.reportID(), "Unable to scan go module %s@%s for licensing info: %v",
+			f.pkg.ImportPath, f.pkg.Revision, f.err,
+		)
+	}
+	logrus.Infof("Scanned the licenses of %d go packages, %d failed", len(mod.Packages)-len(known), len(failures))
+
+	// The throttler only gets errors when the context is done
 	if t.Err() != nil {
-		return t.Err()
+		return fmt.Errorf("scanning go module licenses: %w", ctx.Err())
 	}
 
 	return nil
 }
 
+// errNoLicenseFound is the failure of the scans that found no license
+var errNoLicenseFound = errors.New("no license found")
+
+// packageScanFailure is a go package that could not be scanned
+type packageScanFailure struct {
+	kind DegradationKind
+	pkg  *GoPackage
+	err  error
+}
+
+// reportID returns the identifier of the package in degradations, its
+// purl or its import path when it has no version
+func (pkg *GoPackage) reportID() string {
+	if p := pkg.PackageURL(); p != "" {
+		return p
+	}
+	return pkg.ImportPath
+}
+
+// clearlyDefinedLicenses looks up the licenses of the module packages in
+// ClearlyDefined when enabled in the options. If the lookup fails all
+// packages are scanned.
+func (mod *GoModule) clearlyDefinedLicenses(ctx context.Context) map[string]ClearlyDefinedLicense {
+	if !mod.opts.ClearlyDefined {
+		return nil
+	}
+	purls := []string{}
+	for _, pkg := range mod.Packages {
+		if p := pkg.PackageURL(); p != "" {
+			purls = append(purls, p)
+		}
+	}
+	known, err := ClearlyDefinedLicenses(ctx, purls)
+	if err != nil {
+		mod.opts.Degradations.record(DegradationNetworkError, "Unable to look up go module licenses in ClearlyDefined: %v", err)
+		return nil
+	}
+	logrus.Infof("Found the licenses of %d go packages in ClearlyDefined", len(known))
+	return known
+}
+
 // BuildFullPackageList return the complete of packages imported into
 // the module, instead of reading go.mod, this functions calls
 // go list and works from there.
@@ -267,12 +600,10 @@ func (mod *GoModule) BuildFullPackageList(_ *modfile.File) (packageList []*GoPac
 		return packageList, nil
 	}
 
-	gobin, err := exec.LookPath("go")
+	gorun, err := mod.goListCommand("-deps", "-e", "-json", "./...")
 	if err != nil {
-		return nil, errors.New("unable to get full list of packages, go executbale not found ")
+		return nil, fmt.Errorf("unable to get full list of packages: %w", err)
 	}
-
-	gorun := command.NewWithWorkDir(mod.opts.Path, gobin, "list", "-deps", "-e", "-json", "./...")
 	output, err := gorun.RunSilentSuccessOutput()
 	if err != nil {
 		return nil, fmt.Errorf("while calling go to get full list of deps: %w", err)
@@ -282,12 +613,13 @@ func (mod *GoModule) BuildFullPackageList(_ *modfile.File) (packageList []*GoPac
 		DepOnly bool `json:"DepOnly,omitempty"`
 		Main    bool `json:"Main,omitempty"`
 		Module  struct {
-			Path     string `json:"Path,omitempty"`    // Path is theImportPath
-			Main     bool   `json:"Main,omitempty"`    // true if its the main module (eg k/release)
-			Dir      string `json:"Dir,omitempty"`     // The source can be found here
-			GoMod    string `json:"GoMod,omitempty"`   // Or cached here
-			Version  string `json:"Version,omitempty"` // PAckage version
-			Indirect bool   `json:"Indirect,omitempty"`
+			Path     string     `json:"Path,omitempty"`    // Path is theImportPath
+			Main     bool       `json:"Main,omitempty"`    // true if its the main module (eg k/release)
+			Dir      string     `json:"Dir,omitempty"`     // The source can be found here
+			GoMod    string     `json:"GoMod,omitempty"`   // Or cached here
+			Version  string     `json:"Version,omitempty"` // PAckage version
+			Time     *time.Time `json:"Time,omitempty"`    // Version publication time, from the module proxy
+			Indirect bool       `json:"Indirect,omitempty"`
 			Replace  *struct {
 				Dir string `json:"Dir,omitempty"`
 			} `json:"Replace,omitempty"`
@@ -339,6 +671,9 @@ func (mod *GoModule) BuildFullPackageList(_ *modfile.File) (packageList []*GoPac
 				LocalDir:     "",
 				LocalInstall: "",
 			}
+			if fmod.Module.Time != nil {
+				dep.ReleaseTime = *fmod.Module.Time
+			}
 			status := ""
 			if fmod.Module.Dir != "" && util.Exists(fmod.Module.Dir) {
 				dep.LocalInstall = fmod.Module.Dir
@@ -513,7 +848,7 @@ func (di *GoModDefaultImpl) ScanPackageLicense(
 		pkg.LicenseID = licenseResult.License.LicenseID
 		pkg.CopyrightText = licenseResult.Text
 	} else {
-		logrus.Warnf("Could not find licensing information for package %s", pkg.ImportPath)
+		logrus.Debugf("Could not find licensing information for package %s", pkg.ImportPath)
 	}
 	return nil
 }
@@ -17,10 +17,17 @@ limitations under the License.
 package spdx
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/license"
 )
 
 func TestToSPDXPackage(t *testing.T) {
@@ -29,7 +36,11 @@ func TestToSPDXPackage(t *testing.T) {
 		shouldError bool
 	}{
 		// No error
-		{GoPackage{ImportPath: "golang.org/x/term", Revision: "v0.0.0-20220411215600-e5f449aeb171"}, false},
+		{GoPackage{
+			ImportPath:  "golang.org/x/term",
+			Revision:    "v0.0.0-20220411215600-e5f449aeb171",
+			ReleaseTime: time.Date(2022, 4, 11, 21, 56, 0, 0, time.UTC),
+		}, false},
 		// Invalid import path
 		{GoPackage{ImportPath: "package/name", Revision: "v1.0.0"}, true},
 		// No import path
@@ -43,6 +54,8 @@ func TestToSPDXPackage(t *testing.T) {
 
 		require.NoError(t, err)
 		require.Equal(t, tc.pkg.ImportPath, spdxPackage.Name)
+		require.Equal(t, "https://pkg.go.dev/"+tc.pkg.ImportPath, spdxPackage.HomePage)
+		require.Equal(t, tc.pkg.ReleaseTime, spdxPackage.ReleaseDate)
 		if strings.HasSuffix(tc.pkg.Revision, "+incompatible") {
 			require.NotEqual(t, tc.pkg.Revision, spdxPackage.Version)
 			require.Equal(t, strings.TrimSuffix(tc.pkg.Revision, "+incompatible"), spdxPackage.Version)
@@ -54,6 +67,26 @@ func TestToSPDXPackage(t *testing.T) {
 	}
 }
 
+func TestGoModuleIsPublic(t *testing.T) {
+	t.Setenv("GOPRIVATE", "*.corp.example.com,github.com/acme/private")
+	t.Setenv("GONOPROXY", "git.example.org")
+	for _, tc := range []struct {
+		modPath  string
+		expected bool
+	}{
+		{"golang.org/x/term", true},
+		{"github.com/acme/public", true},
+		{"github.com/acme/private", false},
+		{"github.com/acme/private/v2", false},
+		{"git.corp.example.com/team/lib", false},
+		{"git.example.org/lib", false},
+		{"mycompany/lib", false},
+		{"lib", false},
+	} {
+		require.Equal(t, tc.expected, goModuleIsPublic(tc.modPath), tc.modPath)
+	}
+}
+
 func TestPackageURL(t *testing.T) {
 	for _, tc := range []struct {
 		pkg      GoPackage
@@ -73,3 +106,159 @@ func TestPackageURL(t *testing.T) {
 		require.Equal(t, tc.expected, tc.pkg.PackageURL())
 	}
 }
+
+func TestGoListCommand(t *testing.T) {
+	mod := NewGoModule()
+	mod.Options().Path = t.TempDir()
+
+	cmd, err := mod.goListCommand("-deps", "./...")
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(cmd.String(), " list -deps ./..."))
+	require.False(t, mod.Options().hasBuildTarget())
+
+	mod.Options().GOOS = "linux"
+	mod.Options().BuildTags = []string{"netgo", "osusergo"}
+	require.True(t, mod.Options().hasBuildTarget())
+	cmd, err = mod.goListCommand("-deps", "./...")
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(cmd.String(), " list -tags netgo,osusergo -deps ./..."))
+}
+
+func TestReadGoToolchain(t *testing.T) {
+	for _, tc := range []struct {
+		gomod    string
+		expected *GoToolchain
+	}{
+		{"module example.com/test\n\ngo 1.22.0\n\ntoolchain go1.22.3\n", &GoToolchain{Version: "1.22.3", Declared: true}},
+		{"module example.com/test\n\ngo 1.21\n", &GoToolchain{Version: "1.21"}},
+		{"module example.com/test\n", nil},
+	} {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, GoModFileName), []byte(tc.gomod), os.FileMode(0o644)))
+		toolchain, err := ReadGoToolchain(dir)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, toolchain)
+	}
+
+	_, err := ReadGoToolchain(t.TempDir())
+	require.Error(t, err)
+
+	toolchain := &GoToolchain{Version: "1.22.3"}
+	require.Equal(t, "pkg:generic/go@1.22.3", toolchain.ToSPDXPackage().Purl().ToString())
+	require.Equal(t, "pkg:golang/stdlib@1.22.3", toolchain.StdlibSPDXPackage().Purl().ToString())
+}
+
+func TestAddGoToolchain(t *testing.T) {
+	s := NewSPDX()
+	s.options = &Options{GoStdlib: true}
+	modules := []*Package{}
+	for _, gomod := range []string{
+		"module example.com/a\n\ngo 1.22.0\n\ntoolchain go1.22.3\n",
+		"module example.com/b\n\ngo 1.22.3\n",
+		"module example.com/c\n\ngo 1.21\n",
+	} {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, GoModFileName), []byte(gomod), os.FileMode(0o644)))
+		pkg := NewPackage()
+		require.NoError(t, s.addGoToolchain(pkg, dir))
+		require.Len(t, pkg.Relationships, 2)
+		modules = append(modules, pkg)
+	}
+
+	// Modules requiring the same version share the packages, which are
+	// only rendered with the first one
+	for i := range 2 {
+		require.Same(t, modules[0].Relationships[i].Peer, modules[1].Relationships[i].Peer)
+		require.True(t, modules[0].Relationships[i].FullRender)
+		require.False(t, modules[1].Relationships[i].FullRender)
+		require.NotSame(t, modules[0].Relationships[i].Peer, modules[2].Relationships[i].Peer)
+		require.True(t, modules[2].Relationships[i].FullRender)
+	}
+}
+
+func TestGoToolchainFromBuildInfo(t *testing.T) {
+	for goVersion, expected := range map[string]*GoToolchain{
+		"go1.22.3":                      {Version: "1.22.3", Binary: true},
+		"go1.21.0 X:boringcrypto":       {Version: "1.21.0", Binary: true},
+		"devel go1.23-2ce3f4 Mon Jan 1": nil,
+		"":                              nil,
+	} {
+		require.Equal(t, expected, GoToolchainFromBuildInfo(goVersion), goVersion)
+	}
+	require.Equal(t, "Go toolchain that built the binary", GoToolchainFromBuildInfo("go1.22.3").ToSPDXPackage().Comment)
+}
+
+// testGoModImpl drives the module downloads and license scans in tests
+type testGoModImpl struct {
+	GoModImplementation
+	download func(*GoPackage) error
+	scan     func(*GoPackage) error
+}
+
+func (impl *testGoModImpl) LicenseReader() (*license.Reader, error) {
+	return nil, nil
+}
+
+func (impl *testGoModImpl) DownloadPackage(pkg *GoPackage, _ *GoModuleOptions, _ bool) error {
+	return impl.download(pkg)
+}
+
+func (impl *testGoModImpl) ScanPackageLicense(pkg *GoPackage, _ *license.Reader, _ *GoModuleOptions) error {
+	return impl.scan(pkg)
+}
+
+func TestScanLicensesContext(t *testing.T) {
+	mod := NewGoModule()
+	mod.Options().Degradations = &DegradationLog{}
+	mod.SetImplementation(&testGoModImpl{
+		download: func(pkg *GoPackage) error {
+			if pkg.ImportPath == "example.com/unreachable" {
+				return errors.New("repository not found")
+			}
+			return nil
+		},
+		scan: func(pkg *GoPackage) error {
+			switch pkg.ImportPath {
+			case "example.com/broken":
+				return errors.New("reading license file")
+			case "example.com/mit":
+				pkg.LicenseID = "MIT"
+			}
+			return nil
+		},
+	})
+	mod.Packages = []*GoPackage{
+		{ImportPath: "example.com/unreachable", Revision: "v1.0.0"},
+		{ImportPath: "example.com/mit", Revision: "v1.2.0"},
+		{ImportPath: "example.com/broken", Revision: "v0.1.0"},
+		{ImportPath: "example.com/unlicensed", Revision: "v2.0.0"},
+	}
+
+	// Failed packages don't fail the scan
+	require.NoError(t, mod.ScanLicensesContext(context.Background()))
+	require.Equal(t, "MIT", mod.Packages[1].LicenseID)
+
+	// Each failure is recorded once for its package, in a stable order
+	require.Equal(t, []Degradation{
+		{
+			Kind:    DegradationMissingLicenses,
+			Package: "pkg:golang/example.com/broken@v0.1.0",
+			Message: "Unable to scan go module example.com/broken@v0.1.0 for licensing info: reading license file",
+		},
+		{
+			Kind:    DegradationMissingLicenses,
+			Package: "pkg:golang/example.com/unlicensed@v2.0.0",
+			Message: "Unable to scan go module example.com/unlicensed@v2.0.0 for licensing info: no license found",
+		},
+		{
+			Kind:    DegradationNetworkError,
+			Package: "pkg:golang/example.com/unreachable@v1.0.0",
+			Message: "Unable to scan go module example.com/unreachable@v1.0.0 for licensing info: downloading module: repository not found",
+		},
+	}, mod.Options().Degradations.Degradations())
+
+	// A cancelled scan returns the context error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, mod.ScanLicensesContext(ctx), context.Canceled)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// GraphIssueKind classifies the integrity problems found in the
+// relationship graph of a document.
+type GraphIssueKind string
+
+const (
+	// GraphDanglingRelationship is a relationship pointing to an
+	// element that does not exist in the document.
+	GraphDanglingRelationship GraphIssueKind = "dangling-relationship"
+
+	// GraphContainsCycle is a chain of CONTAINS relationships leading
+	// back to the element where it started.
+	GraphContainsCycle GraphIssueKind = "contains-cycle"
+
+	// GraphOrphanedElement is an element not reachable from any of the
+	// elements the document describes.
+	GraphOrphanedElement GraphIssueKind = "orphaned-element"
+)
+
+// GraphIssue is an integrity problem in the graph of a document
+type GraphIssue struct {
+	Kind      GraphIssueKind `json:"kind"`
+	ElementID string         `json:"element"`
+	Message   string         `json:"message"`
+
+	// Element and relationship to remove when pruning the graph
+	source Object
+	rel    *Relationship
+}
+
+// String returns the issue as a readable line
+func (i GraphIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Kind, i.Message)
+}
+
+// recordDanglingRelationship notes a relationship dropped when parsing
+// because the missing element is not defined in the document.
+func (d *Document) recordDanglingRelationship(source, relType, peer, missing string) {
+	d.danglingRelationships = append(d.danglingRelationships, GraphIssue{
+		Kind:      GraphDanglingRelationship,
+		ElementID: source,
+		Message: fmt.Sprintf(
+			"relationship %s %s %s points to %s which is not in the document",
+			source, relType, peer, missing,
+		),
+	})
+}
+
+// ValidateGraph checks the relationship graph of the document and returns
+// the relationships pointing to nonexistent elements, the cycles formed
+// by CONTAINS relationships and the elements that cannot be reached from
+// the elements the document describes.
+func (d *Document) ValidateGraph() []GraphIssue {
+	issues := []GraphIssue{}
+	issues = append(issues, d.danglingRelationships...)
+	issues = append(issues, d.danglingGraphReferences()...)
+	issues = append(issues, d.containsCycles()...)
+	return append(issues, d.orphanedElements()...)
+}
+
+// PruneGraph removes the dangling relationships, the relationships that
+// close CONTAINS cycles and the orphaned top level elements from the
+// document. It returns the issues that were fixed.
+func (d *Document) PruneGraph() []GraphIssue {
+	issues := d.ValidateGraph()
+	for _, issue := range issues {
+		switch {
+		case issue.rel != nil:
+			removeRelationship(issue.source, issue.rel)
+		case issue.Kind == GraphDanglingRelationship && issue.ElementID == d.ID:
+			d.Describes = slices.DeleteFunc(d.Describes, func(id string) bool {
+				return d.Packages[id] == nil && d.Files[id] == nil
+			})
+		case issue.Kind == GraphOrphanedElement:
+			delete(d.Packages, issue.ElementID)
+			delete(d.Files, issue.ElementID)
+		}
+	}
+	// Relationships dropped when parsing are not written again
+	d.danglingRelationships = nil
+	d.InvalidateIndex()
+	return issues
+}
+
+// danglingGraphReferences returns the described IDs and relationships
+// in the graph that reference elements missing from the document.
+// Relationships to external documents are not checked.
+func (d *Document) danglingGraphReferences() []GraphIssue {
+	issues := []GraphIssue{}
+	for _, id := range d.Describes {
+		if d.Packages[id] == nil && d.Files[id] == nil {
+			issues = append(issues, GraphIssue{
+				Kind:      GraphDanglingRelationship,
+				ElementID: d.ID,
+				Message:   fmt.Sprintf("document describes %s which is not a top level element", id),
+			})
+		}
+	}
+
+	// The walk function never fails, so Walk can't return an error
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer != nil || rel.PeerExtReference != "" {
+				continue
+			}
+			if rel.PeerReference != "" && d.GetElementByID(rel.PeerReference) != nil {
+				continue
+			}
+			issues = append(issues, GraphIssue{
+				Kind:      GraphDanglingRelationship,
+				ElementID: o.SPDXID(),
+				Message: fmt.Sprintf(
+					"relationship %s %s %s points to an element not in the document",
+					o.SPDXID(), rel.Type, rel.PeerReference,
+				),
+				source: o,
+				rel:    rel,
+			})
+		}
+		return nil
+	})
+	return issues
+}
+
+// containsCycles searches the CONTAINS relationships for cycles. Each
+// cycle is reported once, at the relationship that closes it.
+func (d *Document) containsCycles() []GraphIssue {
+	issues := []GraphIssue{}
+	done := map[Object]struct{}{}
+	stack := []Object{}
+	var visit func(o Object)
+	visit = func(o Object) {
+		if _, ok := done[o]; ok {
+			return
+		}
+		stack = append(stack, o)
+		for _, rel := range relationshipsOf(o) {
+			if rel.Type != CONTAINS || rel.Peer == nil {
+				continue
+			}
+			if i := slices.Index(stack, rel.Peer); i >= 0 {
+				ids := []string{}
+				for _, el := range stack[i:] {
+					ids = append(ids, el.SPDXID())
+				}
+				ids = append(ids, rel.Peer.SPDXID())
+				issues = append(issues, GraphIssue{
+					Kind:      GraphContainsCycle,
+					ElementID: o.SPDXID(),
+					Message:   "CONTAINS cycle " + strings.Join(ids, " -> "),
+					source:    o,
+					rel:       rel,
+				})
+				continue
+			}
+			visit(rel.Peer)
+		}
+		stack = stack[:len(stack)-1]
+		done[o] = struct{}{}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(d.Packages)) {
+		visit(d.Packages[id])
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.Files)) {
+		visit(d.Files[id])
+	}
+	return issues
+}
+
+// orphanedElements returns the elements that are not reachable from the
+// elements the document describes. When the document does not list its
+// described elements, it describes all the top level ones and there are
+// no orphans.
+func (d *Document) orphanedElements() []GraphIssue {
+	if len(d.Describes) == 0 {
+		return nil
+	}
+
+	reachable := map[Object]struct{}{}
+	var reach func(o Object)
+	reach = func(o Object) {
+		if _, ok := reachable[o]; ok {
+			return
+		}
+		reachable[o] = struct{}{}
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer != nil {
+				reach(rel.Peer)
+			}
+		}
+	}
+	for _, id := range d.Describes {
+		if p, ok := d.Packages[id]; ok {
+			reach(p)
+		}
+		if f, ok := d.Files[id]; ok {
+			reach(f)
+		}
+	}
+
+	issues := []GraphIssue{}
+	// The walk function never fails, so Walk can't return an error
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck
+		if _, ok := reachable[o]; ok {
+			return ErrSkipRelationships
+		}
+		issues = append(issues, GraphIssue{
+			Kind:      GraphOrphanedElement,
+			ElementID: o.SPDXID(),
+			Message:   fmt.Sprintf("element %s is not reachable from the described elements", o.SPDXID()),
+		})
+		return nil
+	})
+	return issues
+}
+
+// removeRelationship deletes a relationship from an element
+func removeRelationship(o Object, rel *Relationship) {
+	if p, ok := o.(*Package); ok {
+		p.Lock()
+		defer p.Unlock()
+	}
+	rels := o.GetRelationships()
+	*rels = slices.DeleteFunc(*rels, func(r *Relationship) bool { return r == rel })
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGraph(t *testing.T) {
+	doc := NewDocument()
+	app := NewPackage()
+	app.Name = "app"
+	app.BuildID("app")
+	lib := NewPackage()
+	lib.Name = "lib"
+	lib.BuildID("lib")
+	require.NoError(t, app.AddPackage(lib))
+
+	// lib contains app, closing a cycle
+	lib.AddRelationship(&Relationship{Peer: app, Type: CONTAINS})
+	app.AddRelationship(&Relationship{PeerReference: "SPDXRef-Package-missing", Type: DEPENDS_ON})
+
+	stray := NewPackage()
+	stray.Name = "stray"
+	stray.BuildID("stray")
+	require.NoError(t, doc.AddPackage(app))
+	require.NoError(t, doc.AddPackage(stray))
+	doc.Describes = []string{app.SPDXID()}
+
+	kinds := []GraphIssueKind{}
+	for _, issue := range doc.ValidateGraph() {
+		kinds = append(kinds, issue.Kind)
+	}
+	require.Equal(t, []GraphIssueKind{
+		GraphDanglingRelationship, GraphContainsCycle, GraphOrphanedElement,
+	}, kinds)
+
+	require.Len(t, doc.PruneGraph(), 3)
+	require.Empty(t, doc.ValidateGraph())
+	require.NotContains(t, doc.Packages, stray.SPDXID())
+	require.Len(t, app.Relationships, 1)
+	require.Empty(t, lib.Relationships)
+}
+
+func TestParseDanglingRelationships(t *testing.T) {
+	const data = `{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "dangling",
+  "documentDescribes": ["SPDXRef-Package-app", "SPDXRef-Package-gone"],
+  "packages": [{"SPDXID": "SPDXRef-Package-app", "name": "app"}],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-Package-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-lib"}
+  ]
+}`
+	doc, err := parseJSON(strings.NewReader(data))
+	require.NoError(t, err)
+
+	issues := doc.ValidateGraph()
+	require.Len(t, issues, 2)
+	require.Equal(t, GraphDanglingRelationship, issues[0].Kind)
+	require.Equal(t, "SPDXRef-Package-app", issues[0].ElementID)
+	require.Equal(t, GraphDanglingRelationship, issues[1].Kind)
+	require.Equal(t, "SPDXRef-DOCUMENT", issues[1].ElementID)
+
+	// The dropped relationships are kept when unmarshaling too
+	unmarshaled := &Document{}
+	require.NoError(t, json.Unmarshal([]byte(data), unmarshaled))
+	require.Len(t, unmarshaled.ValidateGraph(), 2)
+	require.Equal(t, issues[0].Message, unmarshaled.ValidateGraph()[0].Message)
+
+	doc.PruneGraph()
+	require.Empty(t, doc.ValidateGraph())
+}
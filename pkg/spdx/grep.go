@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ContainmentPath is a chain of elements linked by CONTAINS
+// relationships, from a top level element of the document down to a
+// contained file or package.
+type ContainmentPath []Object
+
+// File returns the file at the end of the path, nil if the path ends
+// in a package.
+func (cp ContainmentPath) File() *File {
+	if len(cp) == 0 {
+		return nil
+	}
+	f, ok := cp[len(cp)-1].(*File)
+	if !ok {
+		return nil
+	}
+	return f
+}
+
+// String returns the path as text, eg "image → layer → file".
+func (cp ContainmentPath) String() string {
+	names := []string{}
+	for _, o := range cp {
+		switch e := o.(type) {
+		case *Package:
+			name := e.Name
+			if e.Version != "" {
+				name += "@" + e.Version
+			}
+			names = append(names, name)
+		case *File:
+			names = append(names, e.FileName)
+		default:
+			names = append(names, o.SPDXID())
+		}
+	}
+	return strings.Join(names, " → ")
+}
+
+// MatchesSpec returns true if the file path or one of the file
+// checksums match the spec. Checksums can be specified by their value
+// or prefixed with the algorithm (eg sha256:abc...), paths can be
+// specified as exact names or glob patterns.
+func (f *File) MatchesSpec(spec string) bool {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return false
+	}
+
+	if matchesChecksum(f.Checksum, spec) {
+		return true
+	}
+
+	fileName := strings.TrimPrefix(f.FileName, "./")
+	spec = strings.TrimPrefix(spec, "./")
+	if fileName == "" {
+		return false
+	}
+	if fileName == spec || strings.HasSuffix(fileName, "/"+spec) {
+		return true
+	}
+	if match, err := path.Match(spec, fileName); err == nil && match {
+		return true
+	}
+	return false
+}
+
+// matchesChecksum returns true if the spec is one of the checksums,
+// optionally prefixed with the algorithm (eg sha256:abc...).
+func matchesChecksum(checksums map[string]string, spec string) bool {
+	for algo, value := range checksums {
+		if strings.EqualFold(spec, value) || strings.EqualFold(spec, algo+":"+value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainmentPaths looks for files matching spec (see File.MatchesSpec)
+// and returns the chains of packages that contain them, from the
+// top level elements of the document down to the file. A file reachable
+// through more than one chain produces a path for each of them.
+//
+// Packages whose checksums match the spec are returned too, which finds
+// container images and layers by their digest.
+func (d *Document) ContainmentPaths(spec string) []ContainmentPath {
+	paths := []ContainmentPath{}
+
+	var walk func(o Object, chain ContainmentPath)
+	walk = func(o Object, chain ContainmentPath) {
+		// Guard against cycles in the containment graph
+		for _, e := range chain {
+			if e == o {
+				return
+			}
+		}
+		chain = append(chain[:len(chain):len(chain)], o)
+
+		switch e := o.(type) {
+		case *File:
+			if e.MatchesSpec(spec) {
+				paths = append(paths, chain)
+			}
+		case *Package:
+			if digest := strings.TrimSpace(spec); digest != "" && matchesChecksum(e.Checksum, digest) {
+				paths = append(paths, chain)
+			}
+		}
+
+		for _, rel := range *o.GetRelationships() {
+			if rel.Peer == nil || rel.Type != CONTAINS {
+				continue
+			}
+			walk(rel.Peer, chain)
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(d.Packages)) {
+		walk(d.Packages[id], ContainmentPath{})
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.Files)) {
+		walk(d.Files[id], ContainmentPath{})
+	}
+	return paths
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainmentPaths(t *testing.T) {
+	const sum = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	doc := NewDocument()
+
+	image := NewPackage()
+	image.Name = "image"
+	image.BuildID("image")
+	layer := NewPackage()
+	layer.Name = "layer"
+	layer.BuildID("layer")
+	lib := NewFile()
+	lib.FileName = "usr/lib/libssl.so.3"
+	lib.BuildID("lib")
+	lib.Checksum = map[string]string{"SHA256": sum}
+	other := NewFile()
+	other.FileName = "./etc/os-release"
+	other.BuildID("other")
+
+	require.NoError(t, layer.AddFile(lib))
+	require.NoError(t, layer.AddFile(other))
+	require.NoError(t, image.AddPackage(layer))
+	require.NoError(t, doc.AddPackage(image))
+
+	for _, tc := range []struct {
+		spec     string
+		expected []string
+	}{
+		{sum, []string{"image → layer → usr/lib/libssl.so.3"}},
+		{"SHA256:" + sum, []string{"image → layer → usr/lib/libssl.so.3"}},
+		{"usr/lib/libssl.so.3", []string{"image → layer → usr/lib/libssl.so.3"}},
+		{"libssl.so.3", []string{"image → layer → usr/lib/libssl.so.3"}},
+		{"etc/os-release", []string{"image → layer → ./etc/os-release"}},
+		{"usr/lib/*.so*", []string{"image → layer → usr/lib/libssl.so.3"}},
+		{"bin/bash", []string{}},
+	} {
+		paths := doc.ContainmentPaths(tc.spec)
+		res := []string{}
+		for _, p := range paths {
+			require.NotNil(t, p.File())
+			res = append(res, p.String())
+		}
+		require.Equal(t, tc.expected, res, tc.spec)
+	}
+}
+
+func TestContainmentPathsDigest(t *testing.T) {
+	const layerDigest = "sha256:2b4c0a1f84ba9dbbd6c6a7e2a7e7d5ee3bb4b5bd1b2e2e1fcbcd6d7c0d5f1c3a"
+	doc := NewDocument()
+
+	image := NewPackage()
+	image.Name = "registry.k8s.io/pause"
+	image.BuildID("image")
+	image.Checksum = map[string]string{"SHA256": "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}
+	layer := NewPackage()
+	layer.Name = layerDigest
+	layer.BuildID("layer")
+	checksums, err := digestChecksums(layerDigest)
+	require.NoError(t, err)
+	layer.Checksum = checksums
+	require.NoError(t, image.AddPackage(layer))
+	require.NoError(t, doc.AddPackage(image))
+
+	paths := doc.ContainmentPaths(layerDigest)
+	require.Len(t, paths, 1)
+	require.Nil(t, paths[0].File())
+	require.Equal(t, "registry.k8s.io/pause → "+layerDigest, paths[0].String())
+
+	paths = doc.ContainmentPaths("SHA256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	require.Len(t, paths, 1)
+	require.Equal(t, "registry.k8s.io/pause", paths[0].String())
+}
@@ -17,11 +17,18 @@ limitations under the License.
 package spdx
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/sirupsen/logrus"
 )
 
@@ -30,7 +37,98 @@ import (
 // written specifically for a layer type. The idea is to be able to enrich
 // common base images with more data to have the most common images covered.
 type ImageAnalyzer struct {
-	Analyzers map[string]ContainerLayerAnalyzer
+	Analyzers      map[string]ContainerLayerAnalyzer
+	ImageAnalyzers map[string]ContainerImageAnalyzer
+	Options        *ContainerLayerAnalyzerOptions // Options shared by the layer analyzers
+}
+
+// LayerAnalyzerFactory creates a layer analyzer configured with opts.
+type LayerAnalyzerFactory func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer
+
+var (
+	analyzersMtx sync.RWMutex
+
+	// layerAnalyzers are the layer analyzers, keyed by label. It holds
+	// the built in analyzers and those registered with RegisterLayerAnalyzer.
+	layerAnalyzers = map[string]LayerAnalyzerFactory{
+		"binary": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &binaryHandler{Options: opts}
+		},
+		"distroless": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &distrolessHandler{Options: opts}
+		},
+		"embedded-sbom": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &embeddedSBOMHandler{Options: opts}
+		},
+		"go-runner": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &goRunnerHandler{Options: opts}
+		},
+		"java": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &javaHandler{Options: opts}
+		},
+		"kernel": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &kernelHandler{Options: opts}
+		},
+		"node": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &nodeHandler{Options: opts}
+		},
+		"python": func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+			return &pythonHandler{Options: opts}
+		},
+	}
+
+	// imageAnalyzers are the analyzers registered with RegisterImageAnalyzer
+	imageAnalyzers = map[string]ContainerImageAnalyzer{}
+)
+
+// layerAnalyzerLanguages are the language ecosystems read by the built
+// in layer analyzers, which are not run when the ecosystem is disabled.
+var layerAnalyzerLanguages = map[string]Language{
+	"java":   LangJava,
+	"node":   LangNode,
+	"python": LangPython,
+}
+
+// RegisterLayerAnalyzer adds an analyzer that will be run on the layers
+// of the images when analyzing them. The factory is called every time
+// an image analyzer is created. Returns an error if the label is taken.
+func RegisterLayerAnalyzer(label string, factory LayerAnalyzerFactory) error {
+	if label == "" || factory == nil {
+		return errors.New("layer analyzers need a label and a factory")
+	}
+	analyzersMtx.Lock()
+	defer analyzersMtx.Unlock()
+	if _, ok := layerAnalyzers[label]; ok {
+		return fmt.Errorf("a layer analyzer labeled %q is already registered", label)
+	}
+	layerAnalyzers[label] = factory
+	return nil
+}
+
+// RegisterImageAnalyzer adds an analyzer that will be run once per image,
+// after its layers are analyzed. Image analyzers get all the layers and
+// the image metadata, which makes them suitable to recognize images built
+// from known bases or in-house agents spread over several layers. Returns
+// an error if the label is taken.
+func RegisterImageAnalyzer(label string, analyzer ContainerImageAnalyzer) error {
+	if label == "" || analyzer == nil {
+		return errors.New("image analyzers need a label and an analyzer")
+	}
+	analyzersMtx.Lock()
+	defer analyzersMtx.Unlock()
+	if _, ok := imageAnalyzers[label]; ok {
+		return fmt.Errorf("an image analyzer labeled %q is already registered", label)
+	}
+	imageAnalyzers[label] = analyzer
+	return nil
+}
+
+// RegisteredAnalyzers returns the sorted labels of the layer and image
+// analyzers that will run when analyzing images.
+func RegisteredAnalyzers() (layer, image []string) {
+	analyzersMtx.RLock()
+	defer analyzersMtx.RUnlock()
+	return slices.Sorted(maps.Keys(layerAnalyzers)), slices.Sorted(maps.Keys(imageAnalyzers))
 }
 
 func NewImageAnalyzer() *ImageAnalyzer {
@@ -39,29 +137,32 @@ func NewImageAnalyzer() *ImageAnalyzer {
 		LicenseCacheDir: filepath.Join(os.TempDir(), spdxLicenseData),
 	}
 
-	// Create the instance with all the drivers we have so far
-	return &ImageAnalyzer{
-		Analyzers: map[string]ContainerLayerAnalyzer{
-			"distroless": &distrolessHandler{
-				Options: opts,
-			},
-			"go-runner": &goRunnerHandler{
-				Options: opts,
-			},
-		},
+	// Create the instance with all the registered drivers
+	analyzersMtx.RLock()
+	defer analyzersMtx.RUnlock()
+	ia := &ImageAnalyzer{
+		Analyzers:      map[string]ContainerLayerAnalyzer{},
+		ImageAnalyzers: maps.Clone(imageAnalyzers),
+		Options:        opts,
 	}
+	for label, factory := range layerAnalyzers {
+		ia.Analyzers[label] = factory(opts)
+	}
+	return ia
 }
 
 // AnalyzeLayer is the main method of the analyzer
 //
 //	it will query each of the analyzers to see if we can
 //	extract more image from the layer and enrich the
-//	spdx package referenced by pkg
+//	spdx package referenced by pkg. All analyzers that
+//	can handle the layer are run, in label order.
 func (ia *ImageAnalyzer) AnalyzeLayer(layerPath string, pkg *Package) error {
 	if pkg == nil {
 		return errors.New("unable to analyze layer, package is null")
 	}
-	for label, handler := range ia.Analyzers {
+	for _, label := range slices.Sorted(maps.Keys(ia.Analyzers)) {
+		handler := ia.Analyzers[label]
 		logrus.Infof("Scanning layer with %s", label)
 		can, err := handler.CanHandle(layerPath)
 		if err != nil {
@@ -69,12 +170,16 @@ func (ia *ImageAnalyzer) AnalyzeLayer(layerPath string, pkg *Package) error {
 		}
 
 		if can {
-			return handler.ReadPackageData(layerPath, pkg)
+			if err := handler.ReadPackageData(layerPath, pkg); err != nil {
+				return fmt.Errorf("reading layer data with %s: %w", label, err)
+			}
 		}
 	}
 	return nil
 }
 
+//counterfeiter:generate . ContainerLayerAnalyzer
+
 // ContainerLayerAnalyzer is an interface that knows how to read a
 // known container layer and populate a SPDX package.
 type ContainerLayerAnalyzer interface {
@@ -82,6 +187,123 @@ type ContainerLayerAnalyzer interface {
 	CanHandle(layerPath string) (bool, error)
 }
 
+// ImageMetadata is the data about an image passed to image analyzers.
+type ImageMetadata struct {
+	Reference string         // Image reference, eg the first tag of the image
+	Config    *v1.ConfigFile // Image configuration, nil if it could not be read
+	Layers    []string       // Paths to the layer tarballs, base layer first
+}
+
+//counterfeiter:generate . ContainerImageAnalyzer
+
+// ContainerImageAnalyzer is an interface that knows how to recognize a
+// whole container image and populate its SPDX package. The layer
+// packages are found in the image package relationships, in the same
+// order as the image metadata layers.
+type ContainerImageAnalyzer interface {
+	CanHandleImage(image *ImageMetadata) (bool, error)
+	AnalyzeImage(image *ImageMetadata, pkg *Package) error
+}
+
+// AnalyzeImage runs the image analyzers that can handle the image to
+// enrich the image package, in label order.
+func (ia *ImageAnalyzer) AnalyzeImage(image *ImageMetadata, pkg *Package) error {
+	if pkg == nil {
+		return errors.New("unable to analyze image, package is null")
+	}
+	for _, label := range slices.Sorted(maps.Keys(ia.ImageAnalyzers)) {
+		handler := ia.ImageAnalyzers[label]
+		can, err := handler.CanHandleImage(image)
+		if err != nil {
+			return fmt.Errorf("checking if image can be handled with %s: %w", label, err)
+		}
+		if !can {
+			continue
+		}
+		logrus.Infof("Analyzing image %s with %s", image.Reference, label)
+		if err := handler.AnalyzeImage(image, pkg); err != nil {
+			return fmt.Errorf("analyzing image with %s: %w", label, err)
+		}
+	}
+	return nil
+}
+
 type ContainerLayerAnalyzerOptions struct {
 	LicenseCacheDir string
+	GoStdlib        bool            // Add the go standard library as a dependency of go binaries
+	Degradations    *DegradationLog // Records the layer data that could not be read
+}
+
+// degradations returns the degradation log of the options, which may be nil
+func (o *ContainerLayerAnalyzerOptions) degradations() *DegradationLog {
+	if o == nil {
+		return nil
+	}
+	return o.Degradations
+}
+
+// walkLayerFiles calls fn with the reader of every regular file in a
+// layer tarball whose path is accepted by the match function.
+func walkLayerFiles(
+	layerPath string, match func(filePath string) bool, fn func(filePath string, r io.Reader) error,
+) error {
+	return walkLayerEntries(layerPath, func(hdr *tar.Header) bool {
+		return match(hdr.Name)
+	}, func(hdr *tar.Header, r io.Reader) error {
+		return fn(hdr.Name, r)
+	})
+}
+
+// walkLayerEntries calls fn with the header and reader of every regular
+// file in a layer tarball whose header is accepted by the match function.
+func walkLayerEntries(
+	layerPath string, match func(hdr *tar.Header) bool, fn func(hdr *tar.Header, r io.Reader) error,
+) error {
+	f, err := os.Open(layerPath)
+	if err != nil {
+		return fmt.Errorf("opening tarball: %w", err)
+	}
+	defer f.Close()
+
+	var tr *tar.Reader
+	if filepath.Ext(layerPath) == gzExt {
+		gzf, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("creating gzip reader: %w", err)
+		}
+		tr = tar.NewReader(gzf)
+	} else {
+		tr = tar.NewReader(f)
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading the image tarfile at %s: %w", layerPath, err)
+		}
+		if !hdr.FileInfo().Mode().IsRegular() || !match(hdr) {
+			continue
+		}
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// errLayerFileFound is used to stop walking a layer once a file is found
+var errLayerFileFound = errors.New("layer file found")
+
+// layerHasFile returns true if the layer tarball contains a regular file
+// whose path is accepted by the match function.
+func layerHasFile(layerPath string, match func(filePath string) bool) (bool, error) {
+	err := walkLayerFiles(layerPath, match, func(string, io.Reader) error {
+		return errLayerFileFound
+	})
+	if errors.Is(err, errLayerFileFound) {
+		return true, nil
+	}
+	return false, err
 }
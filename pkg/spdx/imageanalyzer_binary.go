@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+)
+
+// maxBinarySize is the largest binary read from a layer to identify it
+const maxBinarySize = 512 * 1024 * 1024
+
+// elfMagic are the first bytes of an ELF file
+var elfMagic = []byte{0x7f, 'E', 'L', 'F'}
+
+const (
+	// dpkgInfoDir holds the .list files with the paths installed by
+	// each debian package
+	dpkgInfoDir = "var/lib/dpkg/info"
+
+	// apkInstalledDB is the alpine package database, which lists the
+	// files of each package
+	apkInstalledDB = "lib/apk/db/installed"
+)
+
+// binaryFingerprint describes how to identify a well known piece of
+// software in a binary installed outside of a package manager.
+type binaryFingerprint struct {
+	Name    string         // Name of the software
+	Files   *regexp.Regexp // Matches the base names of the files to check
+	Version *regexp.Regexp // Captures the version string compiled in the binary
+}
+
+// binaryFingerprints is the database of software identified by the
+// binary analyzer from the version strings compiled in the binaries.
+var binaryFingerprints = []binaryFingerprint{
+	{
+		Name:    "openssl",
+		Files:   regexp.MustCompile(`^(openssl|libssl\.so.*|libcrypto\.so.*)$`),
+		Version: regexp.MustCompile(`OpenSSL (\d+\.\d+\.\d+[a-z]*) `),
+	},
+	{
+		Name:    "busybox",
+		Files:   regexp.MustCompile(`^busybox$`),
+		Version: regexp.MustCompile(`BusyBox v(\d+\.\d+\.\d+)`),
+	},
+	{
+		Name:    "nginx",
+		Files:   regexp.MustCompile(`^nginx$`),
+		Version: regexp.MustCompile(`nginx/(\d+\.\d+\.\d+)`),
+	},
+}
+
+// binaryHandler identifies ELF binaries found in a layer, either from
+// the fingerprint database or from the build information embedded in
+// go binaries. Binaries installed by the OS package manager are left to
+// the OS package scanner.
+type binaryHandler struct {
+	Options *ContainerLayerAnalyzerOptions
+}
+
+// isBinaryCandidate returns true if the layer entry may be a binary we
+// can identify: executables (which may be go binaries) and the files
+// listed in the fingerprint database.
+func isBinaryCandidate(hdr *tar.Header) bool {
+	if hdr.Size < int64(len(elfMagic)) || hdr.Size > maxBinarySize {
+		return false
+	}
+	if hdr.Mode&0o111 != 0 {
+		return true
+	}
+	return fingerprintForFile(hdr.Name) != nil
+}
+
+// fingerprintForFile returns the fingerprint that applies to a file.
+func fingerprintForFile(filePath string) *binaryFingerprint {
+	base := path.Base(filePath)
+	for i := range binaryFingerprints {
+		if binaryFingerprints[i].Files.MatchString(base) {
+			return &binaryFingerprints[i]
+		}
+	}
+	return nil
+}
+
+// CanHandle returns true if the layer has files that may be binaries.
+func (h *binaryHandler) CanHandle(layerPath string) (bool, error) {
+	found := false
+	err := walkLayerEntries(layerPath, isBinaryCandidate, func(*tar.Header, io.Reader) error {
+		found = true
+		return errLayerFileFound
+	})
+	if err != nil && !errors.Is(err, errLayerFileFound) {
+		return false, err
+	}
+	return found, nil
+}
+
+// ReadPackageData adds the binaries identified in the layer as
+// subpackages of the layer package.
+func (h *binaryHandler) ReadPackageData(layerPath string, pkg *Package) error {
+	owned, err := packageManagerFiles(layerPath)
+	if err != nil {
+		return fmt.Errorf("reading the files installed by the package manager: %w", err)
+	}
+	seen := map[string]struct{}{}
+	toolchains := map[string]*Package{}
+	return walkLayerEntries(layerPath, isBinaryCandidate, func(hdr *tar.Header, r io.Reader) error {
+		filePath := strings.TrimPrefix(hdr.Name, "./")
+		if _, ok := owned[filePath]; ok {
+			return nil
+		}
+
+		// Check the ELF magic before reading the whole file
+		magic := make([]byte, len(elfMagic))
+		if _, err := io.ReadFull(r, magic); err != nil {
+			return fmt.Errorf("reading %s: %w", filePath, err)
+		}
+		if !bytes.Equal(magic, elfMagic) {
+			return nil
+		}
+		data := make([]byte, hdr.Size)
+		copy(data, magic)
+		if _, err := io.ReadFull(r, data[len(magic):]); err != nil {
+			return fmt.Errorf("reading %s: %w", filePath, err)
+		}
+
+		subpkg, toolchain := identifyBinary(filePath, data)
+		if subpkg == nil {
+			return nil
+		}
+
+		// Shared libraries and binaries of the same software are
+		// recorded only once per layer
+		key := subpkg.Name + "@" + subpkg.Version
+		if _, ok := seen[key]; ok {
+			return nil
+		}
+		seen[key] = struct{}{}
+
+		subpkg.BuildID(pkg.ID, filePath)
+		logrus.Infof("Identified %s %s in binary /%s", subpkg.Name, subpkg.Version, filePath)
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding binary package %s: %w", subpkg.Name, err)
+		}
+		if toolchain != nil {
+			h.addGoToolchain(pkg.ID, subpkg, toolchain, toolchains)
+		}
+		return nil
+	})
+}
+
+// packageManagerFiles returns the paths of the files installed by the
+// dpkg and apk package managers according to their databases in the
+// layer. Paths are relative to the layer root. As merged /usr systems
+// install the files in /bin, /sbin and /lib under /usr, both locations
+// are returned for those.
+func packageManagerFiles(layerPath string) (map[string]struct{}, error) {
+	owned := map[string]struct{}{}
+	add := func(filePath string) {
+		filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+		owned[filePath] = struct{}{}
+		for _, dir := range []string{"bin/", "sbin/", "lib/", "lib64/"} {
+			if strings.HasPrefix(filePath, dir) {
+				owned["usr/"+filePath] = struct{}{}
+			}
+		}
+	}
+	err := walkLayerFiles(layerPath, func(filePath string) bool {
+		filePath = strings.TrimPrefix(filePath, "./")
+		return filePath == apkInstalledDB ||
+			(path.Dir(filePath) == dpkgInfoDir && path.Ext(filePath) == ".list")
+	}, func(filePath string, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", filePath, err)
+		}
+		lines := strings.Split(string(data), "\n")
+		if strings.TrimPrefix(filePath, "./") != apkInstalledDB {
+			// dpkg lists record one absolute path per line
+			for _, line := range lines {
+				if line != "" {
+					add(line)
+				}
+			}
+			return nil
+		}
+
+		// The apk database lists the files (R:) after their directory (F:)
+		dir := ""
+		for _, line := range lines {
+			switch {
+			case strings.HasPrefix(line, "F:"):
+				dir = strings.TrimPrefix(line, "F:")
+			case strings.HasPrefix(line, "R:"):
+				add(path.Join(dir, strings.TrimPrefix(line, "R:")))
+			}
+		}
+		return nil
+	})
+	return owned, err
+}
+
+// addGoToolchain records the toolchain that built a go binary as its
+// prerequisite and, if enabled, the standard library compiled into it as
+// a dependency. The binaries of a layer built with the same go version
+// share the packages cached in known, which are rendered only once.
+func (h *binaryHandler) addGoToolchain(layerID string, binary *Package, toolchain *GoToolchain, known map[string]*Package) {
+	logrus.Infof("Go binary /%s was built with go %s", binary.FileName, toolchain.Version)
+	relate := func(relType RelationshipType, name string, build func() *Package) {
+		key := name + "@" + toolchain.Version
+		peer, ok := known[key]
+		if !ok {
+			peer = build()
+			peer.BuildID(layerID, name, toolchain.Version)
+			known[key] = peer
+		}
+		binary.AddRelationship(&Relationship{
+			FullRender: !ok,
+			Type:       relType,
+			Peer:       peer,
+		})
+	}
+
+	relate(HAS_PREREQUISITE, "go-toolchain", toolchain.ToSPDXPackage)
+	if h.Options != nil && h.Options.GoStdlib {
+		relate(DEPENDS_ON, "go-stdlib", toolchain.StdlibSPDXPackage)
+	}
+}
+
+// identifyBinary returns a package describing an ELF binary or nil if
+// the binary is unknown. For go binaries it also returns the toolchain
+// that built them, read from their build information.
+func identifyBinary(filePath string, data []byte) (*Package, *GoToolchain) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	pkg := NewPackage()
+	pkg.FileName = filePath
+	pkg.Checksum = map[string]string{"SHA256": digest}
+
+	if fp := fingerprintForFile(filePath); fp != nil {
+		m := fp.Version.FindSubmatch(data)
+		if m == nil {
+			logrus.Debugf("Unable to determine the %s version in /%s", fp.Name, filePath)
+			return nil, nil
+		}
+		version := string(m[1])
+		pkg.Name = fp.Name
+		pkg.Version = version
+		pkg.PrimaryPurpose = "APPLICATION"
+		pkg.Comment = "Binary identified by fingerprint at /" + filePath
+		pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  purl.NewPackageURL(purl.TypeGeneric, "", fp.Name, version, nil, "").ToString(),
+		})
+		return pkg, nil
+	}
+
+	info, err := buildinfo.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil
+	}
+	pkg.Name = info.Main.Path
+	if pkg.Name == "" {
+		pkg.Name = info.Path
+	}
+	if pkg.Name == "" {
+		pkg.Name = path.Base(filePath)
+	}
+	pkg.PrimaryPurpose = "APPLICATION"
+	pkg.Comment = fmt.Sprintf("Go binary at /%s built with %s", filePath, info.GoVersion)
+
+	// Binaries built from a local checkout have a (devel) version
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		pkg.Version = info.Main.Version
+		goPkg := &GoPackage{ImportPath: info.Main.Path, Revision: info.Main.Version}
+		if packageurl := goPkg.PackageURL(); packageurl != "" {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+				Category: CatPackageManager,
+				Type:     "purl",
+				Locator:  packageurl,
+			})
+		}
+	}
+	return pkg, GoToolchainFromBuildInfo(info.GoVersion)
+}
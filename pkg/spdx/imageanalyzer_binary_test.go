@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func TestBinaryHandler(t *testing.T) {
+	h := &binaryHandler{}
+	elf := "\x7fELF\x02\x01\x01"
+	layer := spdxtest.WriteLayer(t, map[string]string{
+		"bin/busybox":                  elf + "\x00BusyBox v1.36.1 (2024-06-10 07:11:47 UTC)\x00",
+		"usr/lib/libssl.so.3":          elf + "\x00OpenSSL 3.3.1 4 Jun 2024\x00",
+		"usr/lib/libcrypto.so.3":       elf + "\x00OpenSSL 3.3.1 4 Jun 2024\x00",
+		"usr/sbin/nginx":               "#!/bin/sh\necho nginx/1.25.3\n",
+		"usr/share/doc/busybox/README": "BusyBox v1.36.1",
+	})
+
+	can, err := h.CanHandle(layer)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	pkg := NewPackage()
+	pkg.BuildID("layer")
+	require.NoError(t, h.ReadPackageData(layer, pkg))
+
+	found := map[string]*Package{}
+	for _, rel := range pkg.Relationships {
+		p, ok := rel.Peer.(*Package)
+		require.True(t, ok)
+		found[p.Name] = p
+	}
+
+	// The nginx script is not an ELF binary and openssl is listed once
+	require.Len(t, found, 2)
+	require.Equal(t, "1.36.1", found["busybox"].Version)
+	require.Equal(t, "pkg:generic/busybox@1.36.1", found["busybox"].Purl().String())
+	require.Len(t, found["busybox"].Checksum["SHA256"], 64)
+	require.Equal(t, "3.3.1", found["openssl"].Version)
+
+	// Binaries installed by dpkg or apk are left to the OS package scanner
+	for _, db := range []map[string]string{
+		{"var/lib/dpkg/info/busybox.list": "/.\n/bin\n/bin/busybox\n"},
+		{"lib/apk/db/installed": "P:busybox\nV:1.36.1-r0\nF:bin\nR:busybox\n\n"},
+	} {
+		files := map[string]string{"usr/bin/busybox": elf + "\x00BusyBox v1.36.1\x00"}
+		for k, v := range db {
+			files[k] = v
+		}
+		pkg := NewPackage()
+		pkg.BuildID("layer")
+		require.NoError(t, h.ReadPackageData(spdxtest.WriteLayer(t, files), pkg))
+		require.Empty(t, pkg.Relationships)
+	}
+
+	// Non binaries are not handled
+	can, err = h.CanHandle(spdxtest.WriteLayer(t, map[string]string{"etc/hosts": "127.0.0.1 localhost\n"}))
+	require.NoError(t, err)
+	require.False(t, can)
+}
+
+func TestBinaryHandlerGoToolchain(t *testing.T) {
+	// The test binary is a go binary built with the running toolchain
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	data, err := os.ReadFile(exe)
+	require.NoError(t, err)
+	binary, toolchain := identifyBinary("usr/bin/test", data)
+	if binary == nil {
+		t.Skip("test binary has no build information")
+	}
+	require.Equal(t, GoToolchainFromBuildInfo(runtime.Version()), toolchain)
+
+	for _, stdlib := range []bool{false, true} {
+		h := &binaryHandler{Options: &ContainerLayerAnalyzerOptions{GoStdlib: stdlib}}
+		known := map[string]*Package{}
+		first, second := NewPackage(), NewPackage()
+		h.addGoToolchain("layer", first, &GoToolchain{Version: "1.22.3", Binary: true}, known)
+		h.addGoToolchain("layer", second, &GoToolchain{Version: "1.22.3", Binary: true}, known)
+
+		expected := 1
+		if stdlib {
+			expected = 2
+		}
+		require.Len(t, known, expected)
+		require.Len(t, first.Relationships, expected)
+		require.Len(t, second.Relationships, expected)
+		require.Equal(t, HAS_PREREQUISITE, first.Relationships[0].Type)
+		require.Equal(t, "pkg:generic/go@1.22.3", first.Relationships[0].Peer.(*Package).Purl().ToString())
+		if stdlib {
+			require.Equal(t, DEPENDS_ON, first.Relationships[1].Type)
+			require.Equal(t, "pkg:golang/stdlib@1.22.3", first.Relationships[1].Peer.(*Package).Purl().ToString())
+		}
+
+		// Binaries built with the same toolchain share its packages
+		for i := range first.Relationships {
+			require.True(t, first.Relationships[i].FullRender)
+			require.False(t, second.Relationships[i].FullRender)
+			require.Same(t, first.Relationships[i].Peer, second.Relationships[i].Peer)
+		}
+	}
+}
@@ -114,7 +114,7 @@ func (h *distrolessHandler) ReadPackageData(layerPath string, pkg *Package) erro
 				logrus.Infof(" distroless uses version %s of %s", packageList[subpkg.Name], subpkg.Name)
 				subpkg.Version = packageList[subpkg.Name]
 			} else {
-				logrus.Warnf("could not determine version for package %s", subpkg.Name)
+				h.Options.degradations().record(DegradationScannerFallback, "could not determine version for package %s", subpkg.Name)
 			}
 
 			// Extract the package license to a file
@@ -45,6 +45,7 @@ type goRunnerHandler struct {
 func (h *goRunnerHandler) ReadPackageData(layerPath string, pkg *Package) error { //nolint: revive
 	pkg.Supplier.Person = "Kubernetes Release Managers (release-managers@kubernetes.io)"
 	pkg.Name = "go-runner"
+	pkg.PrimaryPurpose = "APPLICATION"
 
 	// Get the go-runner version
 	// TODO: Add http retries
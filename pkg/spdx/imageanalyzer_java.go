@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// javaHandler identifies the java archives (jar, war and ear files)
+// found in a layer from their maven metadata.
+type javaHandler struct {
+	Options *ContainerLayerAnalyzerOptions
+}
+
+// CanHandle returns true if the layer has java archives.
+func (h *javaHandler) CanHandle(layerPath string) (bool, error) {
+	found, err := layerHasFile(layerPath, isJavaArchivePath)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		logrus.Infof("👍 Tarball %s has java archives", layerPath)
+	}
+	return found, nil
+}
+
+// ReadPackageData adds the java archives found in the layer as
+// subpackages of the layer package.
+func (h *javaHandler) ReadPackageData(layerPath string, pkg *Package) error {
+	return walkLayerFiles(layerPath, isJavaArchivePath, func(filePath string, r io.Reader) error {
+		filePath = strings.TrimPrefix(filePath, "./")
+		subpkg := NewPackage()
+		subpkg.Name = path.Base(filePath)
+		subpkg.FileName = path.Base(filePath)
+		subpkg.PrimaryPurpose = "LIBRARY"
+		subpkg.BuildID(pkg.ID, filePath)
+		if err := readJavaArchiveFromReader(subpkg, r, h.Options.degradations()); err != nil {
+			h.Options.degradations().record(DegradationScannerFallback, "Unable to read java archive %s: %v", filePath, err)
+			return nil
+		}
+		subpkg.Comment = "Java archive found at /" + filePath
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding java archive %s: %w", filePath, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"debug/elf"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxKernelModuleSize is the largest kernel module read for its modinfo
+const maxKernelModuleSize = 64 * 1024 * 1024
+
+var (
+	// kernelModulePathRe matches the kernel modules installed for a kernel
+	// version, capturing the version: lib/modules/6.1.0-18-amd64/.../ext4.ko
+	kernelModulePathRe = regexp.MustCompile(`^(?:usr/)?lib/modules/([^/]+)/.+\.ko(\.gz|\.xz|\.zst)?$`)
+
+	// firmwarePathRe matches the firmware blobs, capturing the path of the
+	// blob relative to the firmware directory
+	firmwarePathRe = regexp.MustCompile(`^(?:usr/)?lib/firmware/(.+)$`)
+)
+
+// kernelModuleLicenses maps the MODULE_LICENSE values to SPDX expressions
+// https://docs.kernel.org/process/license-rules.html#id1
+var kernelModuleLicenses = map[string]string{
+	"GPL":          "GPL-2.0-only",
+	"GPL v2":       "GPL-2.0-only",
+	"Dual MIT/GPL": "MIT OR GPL-2.0-only",
+	"Dual MPL/GPL": "MPL-1.1 OR GPL-2.0-only",
+}
+
+// kernelHandler recognizes the linux kernel modules and the firmware
+// blobs installed in a layer.
+type kernelHandler struct {
+	Options *ContainerLayerAnalyzerOptions
+}
+
+// isKernelFilePath returns true if the path is a kernel module or a
+// firmware blob. The documentation of the firmware is not included.
+func isKernelFilePath(filePath string) bool {
+	filePath = strings.TrimPrefix(filePath, "./")
+	if kernelModulePathRe.MatchString(filePath) {
+		return true
+	}
+	m := firmwarePathRe.FindStringSubmatch(filePath)
+	if m == nil {
+		return false
+	}
+	base := strings.ToUpper(path.Base(m[1]))
+	for _, doc := range []string{"LICENSE", "LICENCE", "WHENCE", "README", "GPL"} {
+		if strings.HasPrefix(base, doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanHandle returns true if the layer has kernel modules or firmware.
+func (h *kernelHandler) CanHandle(layerPath string) (bool, error) {
+	found, err := layerHasFile(layerPath, isKernelFilePath)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		logrus.Infof("👍 Tarball %s has kernel modules or firmware", layerPath)
+	}
+	return found, nil
+}
+
+// ReadPackageData adds the kernel modules and firmware blobs found in the
+// layer as subpackages of the layer package.
+func (h *kernelHandler) ReadPackageData(layerPath string, pkg *Package) error {
+	return walkLayerFiles(layerPath, isKernelFilePath, func(filePath string, r io.Reader) error {
+		return addKernelPackage(pkg, filePath, r, h.Options.degradations())
+	})
+}
+
+// addKernelPackages adds the kernel modules and firmware blobs installed
+// in a root filesystem as subpackages of pkg.
+func addKernelPackages(pkg *Package, rootPath string, log *DegradationLog) error {
+	for _, dir := range []string{"lib", "usr/lib"} {
+		for _, sub := range []string{"modules", "firmware"} {
+			base := filepath.Join(rootPath, dir, sub)
+			if fi, err := os.Lstat(base); err != nil || !fi.IsDir() {
+				continue
+			}
+			if err := filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.Type().IsRegular() {
+					return nil
+				}
+				rel, err := filepath.Rel(rootPath, p)
+				if err != nil {
+					return fmt.Errorf("computing relative path: %w", err)
+				}
+				rel = filepath.ToSlash(rel)
+				if !isKernelFilePath(rel) {
+					return nil
+				}
+				f, err := os.Open(p)
+				if err != nil {
+					return fmt.Errorf("opening %s: %w", rel, err)
+				}
+				defer f.Close()
+				return addKernelPackage(pkg, rel, f, log)
+			}); err != nil {
+				return fmt.Errorf("searching kernel modules and firmware: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// addKernelPackage adds a package for the kernel module or firmware blob
+// at filePath as a subpackage of pkg.
+func addKernelPackage(pkg *Package, filePath string, r io.Reader, log *DegradationLog) error {
+	filePath = strings.TrimPrefix(filePath, "./")
+	var subpkg *Package
+	if m := kernelModulePathRe.FindStringSubmatch(filePath); m != nil {
+		subpkg = kernelModulePackage(filePath, m[1], m[2], r, log)
+	} else {
+		var err error
+		subpkg, err = firmwarePackage(filePath, r)
+		if err != nil {
+			return fmt.Errorf("reading firmware %s: %w", filePath, err)
+		}
+	}
+	subpkg.BuildID(pkg.ID, filePath)
+	if err := pkg.AddPackage(subpkg); err != nil {
+		return fmt.Errorf("adding kernel package %s: %w", subpkg.Name, err)
+	}
+	return nil
+}
+
+// kernelModulePackage builds a package for a kernel module from the
+// metadata in its .modinfo section. Modules compressed with xz or zstd
+// are recorded without reading their metadata.
+func kernelModulePackage(filePath, kernelVersion, compression string, r io.Reader, log *DegradationLog) *Package {
+	pkg := NewPackage()
+	pkg.Name = strings.TrimSuffix(strings.TrimSuffix(path.Base(filePath), compression), ".ko")
+	pkg.Version = kernelVersion
+	pkg.PrimaryPurpose = "OPERATING-SYSTEM"
+	pkg.Comment = fmt.Sprintf("Linux kernel module for kernel %s installed in /%s", kernelVersion, filePath)
+
+	info, err := readKernelModinfo(r, compression)
+	if err != nil {
+		log.record(DegradationScannerFallback, "Unable to read modinfo of kernel module %s: %v", filePath, err)
+		return pkg
+	}
+	if name := firstValue(info["name"]); name != "" {
+		pkg.Name = name
+	}
+	if version := firstValue(info["version"]); version != "" {
+		pkg.Version = version
+	}
+	pkg.Summary = firstValue(info["description"])
+	pkg.Originator.Person = firstValue(info["author"])
+	if vermagic := firstValue(info["vermagic"]); vermagic != "" {
+		pkg.Comment += "\nvermagic: " + vermagic
+	}
+	if lic := firstValue(info["license"]); lic != "" {
+		if id, ok := kernelModuleLicenses[lic]; ok {
+			pkg.LicenseDeclared = id
+		} else {
+			pkg.LicenseComments = "MODULE_LICENSE: " + lic
+		}
+	}
+	return pkg
+}
+
+// readKernelModinfo reads the key=value strings in the .modinfo section
+// of a kernel module.
+func readKernelModinfo(r io.Reader, compression string) (map[string][]string, error) {
+	switch compression {
+	case "":
+	case ".gz":
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("creating gzip reader: %w", err)
+		}
+		defer gzr.Close()
+		r = gzr
+	default:
+		return nil, fmt.Errorf("%s compressed modules are not supported", compression)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxKernelModuleSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading module: %w", err)
+	}
+	if len(data) > maxKernelModuleSize {
+		return nil, fmt.Errorf("module is larger than %d bytes", maxKernelModuleSize)
+	}
+	ef, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing ELF: %w", err)
+	}
+	section := ef.Section(".modinfo")
+	if section == nil {
+		return nil, errors.New("module has no .modinfo section")
+	}
+	modinfo, err := section.Data()
+	if err != nil {
+		return nil, fmt.Errorf("reading .modinfo section: %w", err)
+	}
+
+	info := map[string][]string{}
+	for _, entry := range bytes.Split(modinfo, []byte{0}) {
+		key, value, ok := strings.Cut(string(entry), "=")
+		if ok && key != "" {
+			info[key] = append(info[key], value)
+		}
+	}
+	return info, nil
+}
+
+// firmwarePackage builds a package for a firmware blob, checksummed with
+// its contents.
+func firmwarePackage(filePath string, r io.Reader) (*Package, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, fmt.Errorf("hashing firmware: %w", err)
+	}
+	pkg := NewPackage()
+	pkg.Name = firmwarePathRe.FindStringSubmatch(filePath)[1]
+	pkg.FileName = filePath
+	pkg.PrimaryPurpose = "FIRMWARE"
+	pkg.Comment = "Firmware blob installed in /" + filePath
+	pkg.Checksum = map[string]string{"SHA256": hex.EncodeToString(h.Sum(nil))}
+	return pkg, nil
+}
+
+// firstValue returns the first value of a list or an empty string
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
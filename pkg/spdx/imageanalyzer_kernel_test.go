@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testKernelModule returns a minimal ELF object with a .modinfo section
+func testKernelModule(t *testing.T, modinfo string) []byte {
+	t.Helper()
+	shstrtab := "\x00.modinfo\x00.shstrtab\x00"
+	dataOff := uint64(binary.Size(elf.Header64{}))
+	strOff := dataOff + uint64(len(modinfo))
+	shOff := strOff + uint64(len(shstrtab))
+
+	var buf bytes.Buffer
+	hdr := elf.Header64{
+		Type:      uint16(elf.ET_REL),
+		Machine:   uint16(elf.EM_X86_64),
+		Version:   uint32(elf.EV_CURRENT),
+		Shoff:     shOff,
+		Ehsize:    uint16(binary.Size(elf.Header64{})),
+		Shentsize: uint16(binary.Size(elf.Section64{})),
+		Shnum:     3,
+		Shstrndx:  2,
+	}
+	copy(hdr.Ident[:], elf.ELFMAG)
+	hdr.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	hdr.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	hdr.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	require.NoError(t, binary.Write(&buf, binary.LittleEndian, hdr))
+	buf.WriteString(modinfo)
+	buf.WriteString(shstrtab)
+	for _, sh := range []elf.Section64{
+		{},
+		{Name: 1, Type: uint32(elf.SHT_PROGBITS), Off: dataOff, Size: uint64(len(modinfo)), Addralign: 1},
+		{Name: 10, Type: uint32(elf.SHT_STRTAB), Off: strOff, Size: uint64(len(shstrtab)), Addralign: 1},
+	} {
+		require.NoError(t, binary.Write(&buf, binary.LittleEndian, sh))
+	}
+	return buf.Bytes()
+}
+
+func TestIsKernelFilePath(t *testing.T) {
+	for p, expected := range map[string]bool{
+		"lib/modules/6.1.0-18-amd64/kernel/fs/ext4/ext4.ko":       true,
+		"./usr/lib/modules/6.8.0/kernel/drivers/net/e1000.ko.zst": true,
+		"lib/modules/6.1.0-18-amd64/modules.dep":                  false,
+		"lib/firmware/intel/ibt-17-16-1.sfi":                      true,
+		"usr/lib/firmware/LICENCE.iwlwifi_firmware":               false,
+		"lib/firmware/WHENCE":                                     false,
+		"usr/share/doc/linux-image-6.1.0-18-amd64/changelog.gz":   false,
+		"lib/modules-load.d/modules.conf":                         false,
+	} {
+		require.Equal(t, expected, isKernelFilePath(p), p)
+	}
+}
+
+func TestKernelModulePackage(t *testing.T) {
+	module := testKernelModule(t,
+		"license=GPL\x00description=Fourth Extended Filesystem\x00author=Remy Card and others\x00"+
+			"name=ext4\x00vermagic=6.1.0-18-amd64 SMP preempt mod_unload modversions \x00",
+	)
+
+	pkg := kernelModulePackage("lib/modules/6.1.0-18-amd64/kernel/fs/ext4/ext4.ko", "6.1.0-18-amd64", "", bytes.NewReader(module), nil)
+	require.Equal(t, "ext4", pkg.Name)
+	require.Equal(t, "6.1.0-18-amd64", pkg.Version)
+	require.Equal(t, "GPL-2.0-only", pkg.LicenseDeclared)
+	require.Equal(t, "Fourth Extended Filesystem", pkg.Summary)
+	require.Equal(t, "Remy Card and others", pkg.Originator.Person)
+	require.Contains(t, pkg.Comment, "\nvermagic: 6.1.0-18-amd64 SMP preempt mod_unload modversions")
+
+	var gz bytes.Buffer
+	gzw := gzip.NewWriter(&gz)
+	_, err := gzw.Write(testKernelModule(t, "license=Proprietary\x00version=1.2.3\x00"))
+	require.NoError(t, err)
+	require.NoError(t, gzw.Close())
+	pkg = kernelModulePackage("lib/modules/6.8.0/extra/vendor.ko.gz", "6.8.0", ".gz", &gz, nil)
+	require.Equal(t, "vendor", pkg.Name)
+	require.Equal(t, "1.2.3", pkg.Version)
+	require.Empty(t, pkg.LicenseDeclared)
+	require.Equal(t, "MODULE_LICENSE: Proprietary", pkg.LicenseComments)
+
+	pkg = kernelModulePackage("lib/modules/6.8.0/kernel/e1000.ko.zst", "6.8.0", ".zst", bytes.NewReader([]byte("zstd")), nil)
+	require.Equal(t, "e1000", pkg.Name)
+	require.Equal(t, "6.8.0", pkg.Version)
+}
+
+func TestAddKernelPackages(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string][]byte{
+		"lib/modules/6.1.0/kernel/fs/ext4.ko": testKernelModule(t, "license=Dual MIT/GPL\x00name=ext4\x00"),
+		"lib/firmware/regulatory.db":          []byte("firmware"),
+		"lib/firmware/LICENSE.regulatory":     []byte("license"),
+	} {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, content, os.FileMode(0o644)))
+	}
+
+	pkg := NewPackage()
+	pkg.BuildID("rootfs")
+	require.NoError(t, addKernelPackages(pkg, root, nil))
+	require.Len(t, pkg.Relationships, 2)
+
+	subpkgs := map[string]*Package{}
+	for _, rel := range pkg.Relationships {
+		sub, ok := rel.Peer.(*Package)
+		require.True(t, ok)
+		subpkgs[sub.Name] = sub
+	}
+	require.Equal(t, "MIT OR GPL-2.0-only", subpkgs["ext4"].LicenseDeclared)
+	require.Equal(t, "FIRMWARE", subpkgs["regulatory.db"].PrimaryPurpose)
+	require.Equal(t, "c3bf47ea1f4a4a605470313cacb3a44f4a461f68c6faeab07e737610cb5ac835", subpkgs["regulatory.db"].Checksum["SHA256"])
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+)
+
+// nodeModulesDir is the directory where npm installs packages
+const nodeModulesDir = "node_modules"
+
+// nodeHandler reads the npm packages installed in the node_modules
+// directories found in a layer.
+type nodeHandler struct {
+	Options *ContainerLayerAnalyzerOptions
+}
+
+// nodePackageJSON captures the fields read from an installed package.json.
+// The license and author fields have legacy object forms, so they are
+// decoded after reading the file.
+type nodePackageJSON struct {
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	Homepage    string          `json:"homepage"`
+	Description string          `json:"description"`
+	License     json.RawMessage `json:"license"`
+	Licenses    []struct {
+		Type string `json:"type"`
+	} `json:"licenses"`
+	Author json.RawMessage `json:"author"`
+
+	// Registry metadata recorded by npm versions before 7 when installing
+	// the package: the user who published it and the package maintainers.
+	NPMUser     json.RawMessage   `json:"_npmUser"`
+	Maintainers []json.RawMessage `json:"maintainers"`
+}
+
+// isNodePackagePath returns true if the path is the package.json of a
+// package installed in node_modules, including scoped packages.
+func isNodePackagePath(filePath string) bool {
+	dir, file := path.Split(strings.TrimPrefix(filePath, "./"))
+	if file != "package.json" {
+		return false
+	}
+	parent := path.Dir(strings.TrimSuffix(dir, "/"))
+	if strings.HasPrefix(path.Base(parent), "@") {
+		parent = path.Dir(parent)
+	}
+	return path.Base(parent) == nodeModulesDir
+}
+
+// CanHandle returns true if the layer has node packages installed.
+func (h *nodeHandler) CanHandle(layerPath string) (bool, error) {
+	found, err := layerHasFile(layerPath, isNodePackagePath)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		logrus.Infof("👍 Tarball %s has node packages installed", layerPath)
+	}
+	return found, nil
+}
+
+// ReadPackageData adds the npm packages found in the layer as
+// subpackages of the layer package.
+func (h *nodeHandler) ReadPackageData(layerPath string, pkg *Package) error {
+	return walkLayerFiles(layerPath, isNodePackagePath, func(filePath string, r io.Reader) error {
+		subpkg, err := nodePackageFromJSON(r)
+		if err != nil {
+			h.Options.degradations().record(DegradationScannerFallback, "Unable to read node package data from %s: %v", filePath, err)
+			return nil
+		}
+		if subpkg == nil {
+			return nil
+		}
+		installDir := path.Dir(path.Dir(strings.TrimPrefix(filePath, "./")))
+		if strings.HasPrefix(path.Base(installDir), "@") {
+			installDir = path.Dir(installDir)
+		}
+		subpkg.Comment = "Node.js package installed in /" + installDir
+		subpkg.BuildID(pkg.ID, strings.TrimPrefix(filePath, "./"))
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding node package %s: %w", subpkg.Name, err)
+		}
+		return nil
+	})
+}
+
+// nodePackageFromJSON builds a package from the package.json of an
+// installed npm package. Returns nil if it does not have a name and
+// version.
+func nodePackageFromJSON(r io.Reader) (*Package, error) {
+	data := nodePackageJSON{}
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("decoding package.json: %w", err)
+	}
+	if data.Name == "" || data.Version == "" {
+		return nil, nil
+	}
+
+	pkg := NewPackage()
+	pkg.Name = data.Name
+	pkg.Version = data.Version
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.HomePage = data.Homepage
+	pkg.Summary = strings.TrimSpace(data.Description)
+	// Values that are not SPDX expressions, like "SEE LICENSE IN <file>"
+	// or the UNLICENSED marker of private packages, are kept as a comment
+	if lic := nodeLicense(&data); lic != "" {
+		if lic != "UNLICENSED" && validLicenseExpression(lic) {
+			pkg.LicenseDeclared = lic
+		} else {
+			pkg.LicenseDeclared = NOASSERTION
+			pkg.LicenseComments = "License field in package.json: " + lic
+		}
+	}
+	pkg.Originator.Person = nodeAuthor(data.Author)
+	pkg.Supplier.Person = nodeSupplier(&data)
+
+	namespace, name := "", data.Name
+	if strings.HasPrefix(name, "@") {
+		namespace, name, _ = strings.Cut(name, "/")
+	}
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator: purl.NewPackageURL(
+			purl.TypeNPM, namespace, name, data.Version, nil, "",
+		).ToString(),
+	})
+	return pkg, nil
+}
+
+// nodeLicense returns the declared license of a package. It reads the
+// license string (an SPDX expression), the legacy {"type": ...} object
+// and the deprecated licenses list.
+func nodeLicense(data *nodePackageJSON) string {
+	if len(data.License) > 0 {
+		var expr string
+		if err := json.Unmarshal(data.License, &expr); err == nil {
+			return expr
+		}
+		obj := struct {
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(data.License, &obj); err == nil && obj.Type != "" {
+			return obj.Type
+		}
+	}
+	types := []string{}
+	for _, l := range data.Licenses {
+		if l.Type != "" {
+			types = append(types, l.Type)
+		}
+	}
+	if len(types) > 1 {
+		return "(" + strings.Join(types, " OR ") + ")"
+	}
+	return strings.Join(types, "")
+}
+
+// nodeSupplier returns the person distributing the package. The publisher
+// and maintainers from the registry metadata are preferred, packages
+// without it are assumed to be published by their author.
+func nodeSupplier(data *nodePackageJSON) string {
+	if supplier := nodeAuthor(data.NPMUser); supplier != "" {
+		return supplier
+	}
+	for _, m := range data.Maintainers {
+		if supplier := nodeAuthor(m); supplier != "" {
+			return supplier
+		}
+	}
+	return nodeAuthor(data.Author)
+}
+
+// nodeAuthor returns the package author, which can be a string or a
+// {"name": ..., "email": ...} object.
+func nodeAuthor(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var author string
+	if err := json.Unmarshal(raw, &author); err == nil {
+		return author
+	}
+	obj := struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return ""
+	}
+	if obj.Email != "" && obj.Name != "" {
+		return fmt.Sprintf("%s (%s)", obj.Name, obj.Email)
+	}
+	return obj.Name + obj.Email
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func TestNodeHandler(t *testing.T) {
+	h := &nodeHandler{}
+	layer := spdxtest.WriteLayer(t, map[string]string{
+		"app/node_modules/express/package.json": `{
+			"name": "express", "version": "4.19.2", "license": "MIT",
+			"homepage": "http://expressjs.com/",
+			"description": "Fast, unopinionated, minimalist web framework",
+			"author": {"name": "TJ Holowaychuk", "email": "tj@vision-media.ca"}
+		}`,
+		"app/node_modules/@babel/core/package.json": `{
+			"name": "@babel/core", "version": "7.24.5", "license": {"type": "MIT"},
+			"author": "The Babel Team (https://babel.dev/team)",
+			"_npmUser": {"name": "nicolo-ribaudo", "email": "hello@nicr.dev"},
+			"maintainers": [{"name": "hzoo", "email": "hi@henryzoo.com"}]
+		}`,
+		"app/node_modules/legacy/package.json": `{
+			"name": "legacy", "version": "1.0.0",
+			"licenses": [{"type": "MIT"}, {"type": "Apache-2.0"}]
+		}`,
+		"app/node_modules/private/package.json": `{
+			"name": "private", "version": "2.0.0", "license": "SEE LICENSE IN EULA.md"
+		}`,
+		"app/node_modules/express/lib/package.json": `{"name": "not-a-package", "version": "1.0.0"}`,
+		"app/package.json":                          `{"name": "my-app", "version": "0.1.0"}`,
+	})
+
+	can, err := h.CanHandle(layer)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	pkg := NewPackage()
+	pkg.BuildID("layer")
+	require.NoError(t, h.ReadPackageData(layer, pkg))
+
+	found := map[string]*Package{}
+	for _, rel := range pkg.Relationships {
+		p, ok := rel.Peer.(*Package)
+		require.True(t, ok)
+		found[p.Name] = p
+	}
+	require.Len(t, found, 4)
+
+	express := found["express"]
+	require.NotNil(t, express)
+	require.Equal(t, "4.19.2", express.Version)
+	require.Equal(t, "MIT", express.LicenseDeclared)
+	require.Equal(t, "http://expressjs.com/", express.HomePage)
+	require.Equal(t, "Fast, unopinionated, minimalist web framework", express.Summary)
+	require.Equal(t, "TJ Holowaychuk (tj@vision-media.ca)", express.Originator.Person)
+	require.Equal(t, "TJ Holowaychuk (tj@vision-media.ca)", express.Supplier.Person)
+	require.Equal(t, "pkg:npm/express@4.19.2", express.Purl().String())
+	require.Equal(t, "Node.js package installed in /app/node_modules", express.Comment)
+
+	babel := found["@babel/core"]
+	require.NotNil(t, babel)
+	require.Equal(t, "MIT", babel.LicenseDeclared)
+	require.Equal(t, "@babel", babel.Purl().Namespace)
+	require.Equal(t, "core", babel.Purl().Name)
+	require.Equal(t, "The Babel Team (https://babel.dev/team)", babel.Originator.Person)
+	require.Equal(t, "nicolo-ribaudo (hello@nicr.dev)", babel.Supplier.Person)
+	require.Equal(t, "Node.js package installed in /app/node_modules", babel.Comment)
+
+	require.Equal(t, "(MIT OR Apache-2.0)", found["legacy"].LicenseDeclared)
+
+	// Licenses that are not SPDX expressions are not declared
+	require.Equal(t, NOASSERTION, found["private"].LicenseDeclared)
+	require.Equal(t, "License field in package.json: SEE LICENSE IN EULA.md", found["private"].LicenseComments)
+}
+
+func TestNodeSupplier(t *testing.T) {
+	data := &nodePackageJSON{
+		Maintainers: []json.RawMessage{
+			json.RawMessage(`"dougwilson <doug@somethingdoug.com>"`),
+		},
+	}
+	require.Equal(t, "dougwilson <doug@somethingdoug.com>", nodeSupplier(data))
+	require.Empty(t, nodeSupplier(&nodePackageJSON{}))
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"io"
+	"net/mail"
+	"path"
+	"regexp"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+)
+
+// pythonLicenseClassifiers maps the trove license classifiers commonly
+// found in python package metadata to their SPDX identifiers. Classifiers
+// naming a family of licenses map to an empty string: they are known
+// but don't tell which license of the family applies.
+var pythonLicenseClassifiers = map[string]string{
+	"License :: OSI Approved :: Apache Software License":                             "Apache-2.0",
+	"License :: OSI Approved :: BSD License":                                         "",
+	"License :: OSI Approved :: GNU General Public License (GPL)":                    "",
+	"License :: OSI Approved :: GNU General Public License v2 (GPLv2)":               "GPL-2.0-only",
+	"License :: OSI Approved :: GNU General Public License v3 (GPLv3)":               "GPL-3.0-only",
+	"License :: OSI Approved :: GNU Lesser General Public License v3 (LGPLv3)":       "LGPL-3.0-only",
+	"License :: OSI Approved :: ISC License (ISCL)":                                  "ISC",
+	"License :: OSI Approved :: MIT License":                                         "MIT",
+	"License :: OSI Approved :: Mozilla Public License 2.0 (MPL 2.0)":                "MPL-2.0",
+	"License :: OSI Approved :: Python Software Foundation License":                  "PSF-2.0",
+	"License :: OSI Approved :: The Unlicense (Unlicense)":                           "Unlicense",
+	"License :: OSI Approved :: zlib/libpng License":                                 "Zlib",
+	"License :: OSI Approved :: GNU Library or Lesser General Public License (LGPL)": "",
+}
+
+// pythonNameRe matches the characters normalized in python package names
+var pythonNameRe = regexp.MustCompile(`[-_.]+`)
+
+// pythonHandler reads the python packages installed with pip (or any
+// other installer) from the dist-info and egg-info directories found in
+// the site-packages of a layer.
+type pythonHandler struct {
+	Options *ContainerLayerAnalyzerOptions
+}
+
+// isPythonMetadataPath returns true if the path is a python package
+// metadata file installed in site-packages or dist-packages.
+func isPythonMetadataPath(filePath string) bool {
+	dir, file := path.Split(strings.TrimPrefix(filePath, "./"))
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case file == "METADATA" && strings.HasSuffix(dir, ".dist-info"):
+	case file == "PKG-INFO" && strings.HasSuffix(dir, ".egg-info"):
+	default:
+		return false
+	}
+	parent := path.Base(path.Dir(dir))
+	return parent == "site-packages" || parent == "dist-packages"
+}
+
+// CanHandle returns true if the layer has python packages installed.
+func (h *pythonHandler) CanHandle(layerPath string) (bool, error) {
+	found, err := layerHasFile(layerPath, isPythonMetadataPath)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		logrus.Infof("👍 Tarball %s has python packages installed", layerPath)
+	}
+	return found, nil
+}
+
+// ReadPackageData adds the python packages found in the layer as
+// subpackages of the layer package.
+func (h *pythonHandler) ReadPackageData(layerPath string, pkg *Package) error {
+	return walkLayerFiles(layerPath, isPythonMetadataPath, func(filePath string, r io.Reader) error {
+		subpkg, err := pythonPackageFromMetadata(r)
+		if err != nil {
+			h.Options.degradations().record(DegradationScannerFallback, "Unable to read python package metadata from %s: %v", filePath, err)
+			return nil
+		}
+		if subpkg == nil {
+			return nil
+		}
+		subpkg.Comment = "Python package installed in /" +
+			path.Dir(path.Dir(strings.TrimPrefix(filePath, "./")))
+		subpkg.BuildID(pkg.ID)
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding python package %s: %w", subpkg.Name, err)
+		}
+		return nil
+	})
+}
+
+// pythonPackageFromMetadata builds a package from the core metadata of
+// a python distribution (METADATA or PKG-INFO). Returns nil if the
+// metadata does not have a name and version.
+func pythonPackageFromMetadata(r io.Reader) (*Package, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata headers: %w", err)
+	}
+	h := msg.Header
+	name := strings.TrimSpace(h.Get("Name"))
+	version := strings.TrimSpace(h.Get("Version"))
+	if name == "" || version == "" {
+		return nil, nil
+	}
+
+	pkg := NewPackage()
+	pkg.Name = name
+	pkg.Version = version
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.HomePage = strings.TrimSpace(h.Get("Home-Page"))
+	pkg.Summary = strings.TrimSpace(h.Get("Summary"))
+	for _, u := range h["Project-Url"] {
+		label, value, ok := strings.Cut(u, ",")
+		if ok && pkg.HomePage == "" && strings.EqualFold(strings.TrimSpace(label), "homepage") {
+			pkg.HomePage = strings.TrimSpace(value)
+		}
+	}
+	// Packages published to PyPI by their authors don't list maintainers,
+	// in that case the author is also the supplier.
+	pkg.Originator.Person = pythonPerson(h.Get("Author"), h.Get("Author-Email"))
+	pkg.Supplier.Person = pythonPerson(h.Get("Maintainer"), h.Get("Maintainer-Email"))
+	if pkg.Supplier.Person == "" {
+		pkg.Supplier.Person = pkg.Originator.Person
+	}
+
+	// The license expression is the most precise source, then the trove
+	// classifiers when they name a single license. The free text license
+	// field is only recorded as a comment.
+	if expr := strings.TrimSpace(h.Get("License-Expression")); expr != "" {
+		pkg.LicenseDeclared = expr
+	} else {
+		licenses := []string{}
+		for _, c := range h["Classifier"] {
+			if id, ok := pythonLicenseClassifiers[strings.TrimSpace(c)]; ok {
+				licenses = append(licenses, id)
+			}
+		}
+		if len(licenses) == 1 && licenses[0] != "" {
+			pkg.LicenseDeclared = licenses[0]
+		}
+	}
+	if lic := strings.TrimSpace(h.Get("License")); lic != "" && pkg.LicenseDeclared == "" {
+		pkg.LicenseComments = "License field in package metadata: " + lic
+	}
+
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator: purl.NewPackageURL(
+			purl.TypePyPi, "", pythonNormalizedName(name), version, nil, "",
+		).ToString(),
+	})
+	return pkg, nil
+}
+
+// pythonNormalizedName returns the normalized form of a python package
+// name as used in purls (PEP 503).
+func pythonNormalizedName(name string) string {
+	return strings.ToLower(pythonNameRe.ReplaceAllString(name, "-"))
+}
+
+// pythonPerson formats the name and email fields of the core metadata as
+// "Name (email)". The email fields may hold RFC 822 addresses with the
+// name included, as written by tools following PEP 621.
+func pythonPerson(name, email string) string {
+	name = strings.TrimSpace(name)
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return name
+	}
+	if addrs, err := mail.ParseAddressList(email); err == nil && len(addrs) > 0 {
+		if name == "" {
+			name = addrs[0].Name
+		}
+		email = addrs[0].Address
+	}
+	if name == "" {
+		return email
+	}
+	return fmt.Sprintf("%s (%s)", name, email)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+const testPythonMetadata = `Metadata-Version: 2.1
+Name: PyYAML
+Version: 6.0.1
+Summary: YAML parser and emitter for Python
+Home-page: https://pyyaml.org/
+Author: Kirill Simonov
+License: MIT
+Classifier: License :: OSI Approved :: MIT License
+Classifier: Programming Language :: Python :: 3
+
+PyYAML is a YAML parser and emitter for Python.
+`
+
+const testPythonPkgInfo = `Metadata-Version: 2.4
+Name: typing_extensions
+Version: 4.12.2
+Author-email: "Guido van Rossum, Jukka Lehtosalo, Łukasz Langa, Michael Lee" <levkivskyi@gmail.com>
+Maintainer-email: "CPython developers" <levkivskyi@gmail.com>
+License-Expression: PSF-2.0
+Project-URL: Homepage, https://github.com/python/typing_extensions
+`
+
+func TestPythonHandler(t *testing.T) {
+	h := &pythonHandler{}
+	layer := spdxtest.WriteLayer(t, map[string]string{
+		"usr/local/lib/python3.12/site-packages/PyYAML-6.0.1.dist-info/METADATA":                testPythonMetadata,
+		"usr/lib/python3/dist-packages/typing_extensions-4.12.2.egg-info/PKG-INFO":              testPythonPkgInfo,
+		"usr/local/lib/python3.12/site-packages/yaml/__init__.py":                               "",
+		"usr/local/lib/python3.12/site-packages/PyYAML-6.0.1.dist-info/vendored/thing/METADATA": "Name: nope\n",
+	})
+
+	can, err := h.CanHandle(layer)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	pkg := NewPackage()
+	pkg.BuildID("layer")
+	require.NoError(t, h.ReadPackageData(layer, pkg))
+
+	found := map[string]*Package{}
+	for _, rel := range pkg.Relationships {
+		p, ok := rel.Peer.(*Package)
+		require.True(t, ok)
+		found[p.Name] = p
+	}
+	require.Len(t, found, 2)
+
+	yaml := found["PyYAML"]
+	require.NotNil(t, yaml)
+	require.Equal(t, "6.0.1", yaml.Version)
+	require.Equal(t, "MIT", yaml.LicenseDeclared)
+	require.Equal(t, "https://pyyaml.org/", yaml.HomePage)
+	require.Equal(t, "YAML parser and emitter for Python", yaml.Summary)
+	require.Equal(t, "Kirill Simonov", yaml.Originator.Person)
+	require.Equal(t, "Kirill Simonov", yaml.Supplier.Person)
+	require.Equal(t, "pkg:pypi/pyyaml@6.0.1", yaml.Purl().String())
+	require.Equal(t, "Python package installed in /usr/local/lib/python3.12/site-packages", yaml.Comment)
+
+	te := found["typing_extensions"]
+	require.NotNil(t, te)
+	require.Equal(t, "PSF-2.0", te.LicenseDeclared)
+	require.Equal(t, "Guido van Rossum, Jukka Lehtosalo, Łukasz Langa, Michael Lee (levkivskyi@gmail.com)", te.Originator.Person)
+	require.Equal(t, "CPython developers (levkivskyi@gmail.com)", te.Supplier.Person)
+	require.Equal(t, "https://github.com/python/typing_extensions", te.HomePage)
+	require.Equal(t, "pkg:pypi/typing-extensions@4.12.2", te.Purl().String())
+
+	// Classifiers naming a family of licenses are not declared
+	for _, classifier := range []string{
+		"License :: OSI Approved :: BSD License",
+		"License :: OSI Approved :: GNU Library or Lesser General Public License (LGPL)",
+	} {
+		generic, err := pythonPackageFromMetadata(strings.NewReader(
+			"Name: generic\nVersion: 1.0\nLicense: BSD\nClassifier: " + classifier + "\n\n",
+		))
+		require.NoError(t, err)
+		require.Empty(t, generic.LicenseDeclared, classifier)
+		require.Equal(t, "License field in package metadata: BSD", generic.LicenseComments)
+	}
+
+	// Layers without python packages are not handled
+	can, err = h.CanHandle(spdxtest.WriteLayer(t, map[string]string{"etc/os-release": "NAME=test\n"}))
+	require.NoError(t, err)
+	require.False(t, can)
+}
+
+func TestAnalyzeImageLayerLanguages(t *testing.T) {
+	layer := spdxtest.WriteLayer(t, map[string]string{
+		"usr/local/lib/python3.12/site-packages/PyYAML-6.0.1.dist-info/METADATA": testPythonMetadata,
+	})
+	for _, tc := range []struct {
+		processPython bool
+		expected      int
+	}{
+		{true, 1},
+		{false, 0},
+	} {
+		opts := defaultSPDXOptions
+		opts.ProcessPython = tc.processPython
+		pkg := NewPackage()
+		pkg.BuildID("layer")
+		require.NoError(t, (&spdxDefaultImplementation{}).AnalyzeImageLayer(&opts, layer, pkg))
+		require.Len(t, pkg.Relationships, tc.expected, "processing python: %v", tc.processPython)
+	}
+}
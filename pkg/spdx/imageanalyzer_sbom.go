@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha1" //nolint:gosec // SHA1 is required by the SPDX file checksums
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// embeddedSBOMDir is where apko and melange install the SBOMs of the
+// packages in Wolfi and Chainguard images
+const embeddedSBOMDir = "var/lib/db/sbom/"
+
+// embeddedSBOMHandler imports the SPDX documents embedded in the image
+// layers, so the packages they describe are recorded with the data from
+// their build instead of data derived by bom.
+type embeddedSBOMHandler struct {
+	Options *ContainerLayerAnalyzerOptions
+}
+
+// isEmbeddedSBOMPath returns true if the path is an embedded SPDX SBOM.
+func isEmbeddedSBOMPath(filePath string) bool {
+	filePath = strings.TrimPrefix(filePath, "./")
+	return strings.HasPrefix(filePath, embeddedSBOMDir) && strings.HasSuffix(filePath, ".spdx.json")
+}
+
+// CanHandle returns true if the layer has embedded SBOMs.
+func (h *embeddedSBOMHandler) CanHandle(layerPath string) (bool, error) {
+	found, err := layerHasFile(layerPath, isEmbeddedSBOMPath)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		logrus.Infof("👍 Tarball %s has embedded SBOMs", layerPath)
+	}
+	return found, nil
+}
+
+// ReadPackageData adds the packages described by the embedded SBOMs as
+// subpackages of the layer package. The SBOMs are added as files of the
+// layer and the packages are DESCRIBED_BY the file they were read from.
+func (h *embeddedSBOMHandler) ReadPackageData(layerPath string, pkg *Package) error {
+	return walkLayerFiles(layerPath, isEmbeddedSBOMPath, func(filePath string, r io.Reader) error {
+		filePath = path.Clean(strings.TrimPrefix(filePath, "./"))
+		sha1sum := sha1.New() //nolint:gosec // SHA1 is required by the SPDX file checksums
+		sha256sum := sha256.New()
+		doc, err := readEmbeddedSBOM(io.TeeReader(r, io.MultiWriter(sha1sum, sha256sum)))
+		if err != nil {
+			h.Options.degradations().record(DegradationScannerFallback, "Unable to read embedded SBOM /%s: %v", filePath, err)
+			return nil
+		}
+
+		// The embedded documents reuse the same IDs, make them unique
+		reIDEmbeddedDocument(doc, pkg.ID+"/"+filePath)
+
+		f := NewFile()
+		f.FileName = filePath
+		f.Name = f.FileName
+		f.Checksum = map[string]string{
+			"SHA1":   hex.EncodeToString(sha1sum.Sum(nil)),
+			"SHA256": hex.EncodeToString(sha256sum.Sum(nil)),
+		}
+		f.BuildID(pkg.ID, f.FileName)
+		if err := pkg.AddFile(f); err != nil {
+			return fmt.Errorf("adding %s to the layer package: %w", filePath, err)
+		}
+
+		for _, id := range slices.Sorted(maps.Keys(doc.Packages)) {
+			p := doc.Packages[id]
+			p.AddRelationship(&Relationship{
+				Peer: f,
+				Type: DESCRIBED_BY,
+			})
+			if err := pkg.AddPackage(p); err != nil {
+				return fmt.Errorf("adding package from embedded SBOM: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// readEmbeddedSBOM parses an SPDX document read from a layer.
+func readEmbeddedSBOM(r io.Reader) (*Document, error) {
+	f, err := os.CreateTemp("", "embedded-sbom-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return nil, fmt.Errorf("writing temporary file: %w", err)
+	}
+	doc, err := OpenDoc(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("parsing SBOM: %w", err)
+	}
+	return doc, nil
+}
+
+// reIDEmbeddedDocument appends a suffix derived from seed to the IDs of
+// all the elements in a document.
+func reIDEmbeddedDocument(doc *Document, seed string) {
+	sum := sha1.Sum([]byte(seed)) //nolint:gosec // Not used for security
+	suffix := hex.EncodeToString(sum[:])[:8]
+
+	objects := []Object{}
+	doc.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		objects = append(objects, o)
+		return nil
+	})
+	for _, o := range objects {
+		o.SetSPDXID(o.SPDXID() + "-" + suffix)
+	}
+}
+
+// embeddedSBOMPackages returns the name@version of the packages imported
+// from embedded SBOMs found directly under the specified packages.
+func embeddedSBOMPackages(pkgs ...*Package) map[string]struct{} {
+	described := map[string]struct{}{}
+	for _, pkg := range pkgs {
+		for _, rel := range pkg.Relationships {
+			if p, ok := rel.Peer.(*Package); ok && describedByEmbeddedSBOM(p) {
+				described[p.Name+"@"+p.Version] = struct{}{}
+			}
+		}
+	}
+	return described
+}
+
+// describedByEmbeddedSBOM returns true if the package is DESCRIBED_BY an
+// embedded SBOM file.
+func describedByEmbeddedSBOM(p *Package) bool {
+	for _, rel := range p.Relationships {
+		if f, ok := rel.Peer.(*File); ok && rel.Type == DESCRIBED_BY && isEmbeddedSBOMPath(f.FileName) {
+			return true
+		}
+	}
+	return false
+}
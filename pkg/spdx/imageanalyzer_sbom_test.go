@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func testMelangeSBOM(name, version string) string {
+	return `{
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "apk-` + name + `-` + version + `",
+  "spdxVersion": "SPDX-2.3",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: melange (devel)"]},
+  "dataLicense": "CC0-1.0",
+  "documentNamespace": "https://spdx.org/spdxdocs/chainguard/melange/` + name + `",
+  "documentDescribes": ["SPDXRef-Package-` + name + `"],
+  "packages": [{
+    "SPDXID": "SPDXRef-Package-` + name + `",
+    "name": "` + name + `",
+    "versionInfo": "` + version + `",
+    "licenseDeclared": "LGPL-2.1-or-later",
+    "downloadLocation": "NOASSERTION",
+    "copyrightText": "NOASSERTION",
+    "supplier": "Organization: Wolfi",
+    "externalRefs": [{
+      "referenceCategory": "PACKAGE_MANAGER",
+      "referenceType": "purl",
+      "referenceLocator": "pkg:apk/wolfi/` + name + `@` + version + `?arch=x86_64"
+    }]
+  }],
+  "relationships": [{
+    "spdxElementId": "SPDXRef-DOCUMENT",
+    "relationshipType": "DESCRIBES",
+    "relatedSpdxElement": "SPDXRef-Package-` + name + `"
+  }]
+}`
+}
+
+func TestEmbeddedSBOMHandler(t *testing.T) {
+	h := &embeddedSBOMHandler{}
+	layer := spdxtest.WriteLayer(t, map[string]string{
+		"var/lib/db/sbom/glibc-2.38-r1.spdx.json":    testMelangeSBOM("glibc", "2.38-r1"),
+		"var/lib/db/sbom/wolfi-baselayout.spdx.json": testMelangeSBOM("wolfi-baselayout", "20230201-r7"),
+		"var/lib/db/sbom/README":                     "not an sbom",
+	})
+
+	can, err := h.CanHandle(layer)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	pkg := NewPackage()
+	pkg.BuildID("layer")
+	require.NoError(t, h.ReadPackageData(layer, pkg))
+	require.Len(t, pkg.Relationships, 4)
+
+	ids := map[string]struct{}{}
+	for _, rel := range pkg.Relationships {
+		if f, ok := rel.Peer.(*File); ok {
+			require.True(t, isEmbeddedSBOMPath(f.FileName))
+			require.Len(t, f.Checksum["SHA256"], 64)
+			continue
+		}
+		p, ok := rel.Peer.(*Package)
+		require.True(t, ok)
+		require.Empty(t, p.Comment)
+		require.True(t, describedByEmbeddedSBOM(p))
+		require.Equal(t, "LGPL-2.1-or-later", p.LicenseDeclared)
+		require.Equal(t, "Wolfi", p.Supplier.Organization)
+		require.True(t, strings.HasPrefix(p.SPDXID(), "SPDXRef-Package-"+p.Name+"-"))
+		ids[p.SPDXID()] = struct{}{}
+	}
+	require.Len(t, ids, 2)
+
+	described := embeddedSBOMPackages(pkg)
+	require.Contains(t, described, "glibc@2.38-r1")
+	require.Contains(t, described, "wolfi-baselayout@20230201-r7")
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testImageAnalyzer struct {
+	handle bool
+}
+
+func (a *testImageAnalyzer) CanHandleImage(image *ImageMetadata) (bool, error) {
+	return a.handle && len(image.Layers) > 0, nil
+}
+
+func (a *testImageAnalyzer) AnalyzeImage(image *ImageMetadata, pkg *Package) error {
+	pkg.Supplier.Organization = "Example Corp"
+	pkg.Comment = "Base image " + image.Reference
+	return nil
+}
+
+func TestRegisterAnalyzers(t *testing.T) {
+	t.Cleanup(func() {
+		analyzersMtx.Lock()
+		defer analyzersMtx.Unlock()
+		delete(layerAnalyzers, "test-layer")
+		delete(imageAnalyzers, "test-image")
+		delete(imageAnalyzers, "test-skipped")
+	})
+
+	require.NoError(t, RegisterLayerAnalyzer("test-layer", func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+		return &pythonHandler{Options: opts}
+	}))
+	require.Error(t, RegisterLayerAnalyzer("test-layer", func(opts *ContainerLayerAnalyzerOptions) ContainerLayerAnalyzer {
+		return &nodeHandler{Options: opts}
+	}))
+	require.Error(t, RegisterLayerAnalyzer("", nil))
+
+	require.NoError(t, RegisterImageAnalyzer("test-image", &testImageAnalyzer{handle: true}))
+	require.NoError(t, RegisterImageAnalyzer("test-skipped", &testImageAnalyzer{handle: false}))
+	require.Error(t, RegisterImageAnalyzer("test-image", &testImageAnalyzer{}))
+
+	layer, image := RegisteredAnalyzers()
+	require.Contains(t, layer, "test-layer")
+	require.True(t, slices.IsSorted(layer))
+	require.Equal(t, []string{"test-image", "test-skipped"}, image)
+
+	ia := NewImageAnalyzer()
+	require.Contains(t, ia.Analyzers, "test-layer")
+	require.Contains(t, ia.Analyzers, "python")
+	require.Contains(t, ia.ImageAnalyzers, "test-image")
+
+	pkg := NewPackage()
+	require.NoError(t, ia.AnalyzeImage(&ImageMetadata{
+		Reference: "registry.example.com/base:1.0",
+		Layers:    []string{"layer.tar"},
+	}, pkg))
+	require.Equal(t, "Example Corp", pkg.Supplier.Organization)
+	require.Equal(t, "Base image registry.example.com/base:1.0", pkg.Comment)
+
+	require.Error(t, ia.AnalyzeImage(&ImageMetadata{}, nil))
+}
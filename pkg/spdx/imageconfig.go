@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// Pre-defined OCI annotation keys mapped into the image package fields
+// https://github.com/opencontainers/image-spec/blob/main/annotations.md
+const (
+	ociAnnotationVersion = "org.opencontainers.image.version"
+	ociAnnotationURL     = "org.opencontainers.image.url"
+	ociAnnotationVendor  = "org.opencontainers.image.vendor"
+	ociAnnotationAuthors = "org.opencontainers.image.authors"
+	ociAnnotationCreated = "org.opencontainers.image.created"
+)
+
+// readImageConfig parses the image configuration file from an
+// extracted image archive.
+func readImageConfig(configPath string) (*v1.ConfigFile, error) {
+	f, err := os.Open(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening image config: %w", err)
+	}
+	defer f.Close()
+
+	conf, err := v1.ParseConfigFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image config: %w", err)
+	}
+	return conf, nil
+}
+
+// applyImageConfig records the image configuration data in the image
+// package. The OCI annotations found in the image labels are mapped to
+// the package version, homepage, supplier and originator, the rest of
+// the config data is recorded in the package comment. Environment
+// variables often hold credentials, so only their names are recorded
+// unless envValues is set.
+func applyImageConfig(pkg *Package, conf *v1.ConfigFile, envValues bool) {
+	if conf == nil {
+		return
+	}
+	labels := conf.Config.Labels
+
+	if v := labels[ociAnnotationVersion]; v != "" && pkg.Version == "" {
+		pkg.Version = v
+	}
+	if v := labels[ociAnnotationURL]; v != "" && pkg.HomePage == "" {
+		pkg.HomePage = v
+	}
+	if v := labels[ociAnnotationVendor]; v != "" && pkg.Supplier.Organization == "" && pkg.Supplier.Person == "" {
+		pkg.Supplier.Organization = v
+	}
+
+	author := labels[ociAnnotationAuthors]
+	if author == "" {
+		author = conf.Author
+	}
+	if author != "" && pkg.Originator.Person == "" && pkg.Originator.Organization == "" {
+		pkg.Originator.Person = author
+	}
+
+	if comment := imageConfigComment(conf, envValues); comment != "" {
+		if pkg.Comment != "" {
+			pkg.Comment += "\n"
+		}
+		pkg.Comment += comment
+	}
+}
+
+// imageConfigComment returns a text summary of the image configuration.
+func imageConfigComment(conf *v1.ConfigFile, envValues bool) string {
+	lines := []string{}
+
+	created := conf.Config.Labels[ociAnnotationCreated]
+	if !conf.Created.IsZero() {
+		created = conf.Created.UTC().Format(time.RFC3339)
+	}
+	if created != "" {
+		lines = append(lines, "Created: "+created)
+	}
+	if conf.Author != "" {
+		lines = append(lines, "Author: "+conf.Author)
+	}
+	if len(conf.Config.Entrypoint) > 0 {
+		lines = append(lines, "Entrypoint: "+strings.Join(conf.Config.Entrypoint, " "))
+	}
+	if len(conf.Config.Cmd) > 0 {
+		lines = append(lines, "Cmd: "+strings.Join(conf.Config.Cmd, " "))
+	}
+	for _, env := range conf.Config.Env {
+		if !envValues {
+			env, _, _ = strings.Cut(env, "=")
+		}
+		lines = append(lines, "Env: "+env)
+	}
+
+	keys := []string{}
+	for k := range conf.Config.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("Label: %s=%s", k, conf.Config.Labels[k]))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testImageConfig = `{
+  "architecture": "amd64",
+  "os": "linux",
+  "author": "Jane Doe",
+  "created": "2024-05-01T10:00:00Z",
+  "config": {
+    "Entrypoint": ["/bin/server"],
+    "Cmd": ["--port", "8080"],
+    "Env": ["PATH=/usr/bin", "API_TOKEN=s3cr3t"],
+    "Labels": {
+      "org.opencontainers.image.version": "v1.2.3",
+      "org.opencontainers.image.url": "https://example.com",
+      "org.opencontainers.image.vendor": "Example Inc"
+    }
+  },
+  "rootfs": {"type": "layers", "diff_ids": []}
+}`
+
+func TestApplyImageConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(testImageConfig), os.FileMode(0o644)))
+
+	conf, err := readImageConfig(configPath)
+	require.NoError(t, err)
+
+	pkg := NewPackage()
+	pkg.Comment = "Container image archive"
+	applyImageConfig(pkg, conf, false)
+
+	require.Equal(t, "v1.2.3", pkg.Version)
+	require.Equal(t, "https://example.com", pkg.HomePage)
+	require.Equal(t, "Example Inc", pkg.Supplier.Organization)
+	require.Equal(t, "Jane Doe", pkg.Originator.Person)
+	require.Contains(t, pkg.Comment, "Container image archive\nCreated: 2024-05-01T10:00:00Z\n")
+	require.Contains(t, pkg.Comment, "Entrypoint: /bin/server\n")
+	require.Contains(t, pkg.Comment, "Cmd: --port 8080\n")
+	require.Contains(t, pkg.Comment, "Env: PATH\nEnv: API_TOKEN\n")
+	require.NotContains(t, pkg.Comment, "s3cr3t")
+	require.Contains(t, pkg.Comment, "Label: org.opencontainers.image.vendor=Example Inc")
+
+	// The values are only recorded when requested
+	pkg = NewPackage()
+	applyImageConfig(pkg, conf, true)
+	require.Contains(t, pkg.Comment, "Env: PATH=/usr/bin\nEnv: API_TOKEN=s3cr3t\n")
+
+	_, err = readImageConfig(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+const (
+	// historyNopPrefix marks the instructions recorded by the classic
+	// docker builder that do not run a command
+	historyNopPrefix = "/bin/sh -c #(nop)"
+	// historyShellPrefix is the shell the RUN instructions run in
+	historyShellPrefix = "/bin/sh -c "
+	// historyBuildkitSuffix is appended by buildkit to its entries
+	historyBuildkitSuffix = "# buildkit"
+)
+
+// historyArgsRe matches the count of build arguments buildkit records
+// in front of RUN commands, eg: |2 VERSION=1.0 TARGETARCH=amd64
+var historyArgsRe = regexp.MustCompile(`^\|(\d+)\s+`)
+
+// dockerfileInstructions are the instructions recognized when an image
+// history entry is already written as a Dockerfile line.
+var dockerfileInstructions = []string{
+	"ADD", "ARG", "CMD", "COPY", "ENTRYPOINT", "ENV", "EXPOSE", "HEALTHCHECK",
+	"LABEL", "MAINTAINER", "ONBUILD", "RUN", "SHELL", "STOPSIGNAL", "USER",
+	"VOLUME", "WORKDIR",
+}
+
+// imageHistoryStep is a build step reconstructed from an entry of the
+// image configuration history.
+type imageHistoryStep struct {
+	Instruction string    // Dockerfile instruction, eg: RUN apk add curl
+	EmptyLayer  bool      // True if the step did not produce a layer
+	Created     time.Time // When the step ran
+}
+
+// imageHistorySteps returns the build steps recorded in the history of
+// an image configuration.
+func imageHistorySteps(conf *v1.ConfigFile) []imageHistoryStep {
+	if conf == nil {
+		return nil
+	}
+	steps := []imageHistoryStep{}
+	for _, h := range conf.History {
+		steps = append(steps, imageHistoryStep{
+			Instruction: historyInstruction(h.CreatedBy),
+			EmptyLayer:  h.EmptyLayer,
+			Created:     h.Created.Time,
+		})
+	}
+	return steps
+}
+
+// imageLayerSteps returns the build steps that produced the layers of an
+// image, in the order of the layers.
+func imageLayerSteps(conf *v1.ConfigFile) []imageHistoryStep {
+	steps := []imageHistoryStep{}
+	for _, step := range imageHistorySteps(conf) {
+		if !step.EmptyLayer {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// historyInstruction converts the created_by field of an image history
+// entry into a Dockerfile instruction. Commands not written by a
+// Dockerfile builder are returned as comments.
+func historyInstruction(createdBy string) string {
+	s := strings.TrimSpace(createdBy)
+	s = strings.TrimSpace(strings.TrimSuffix(s, historyBuildkitSuffix))
+	if s == "" {
+		return ""
+	}
+
+	// Classic builder: /bin/sh -c #(nop)  CMD ["nginx"]
+	if rest, ok := strings.CutPrefix(s, historyNopPrefix); ok {
+		return strings.TrimSpace(rest)
+	}
+
+	// Buildkit: RUN |1 VERSION=1.0 /bin/sh -c make
+	run := false
+	if rest, ok := strings.CutPrefix(s, "RUN "); ok {
+		s, run = strings.TrimSpace(rest), true
+	}
+	s = trimHistoryArgs(s)
+
+	// Classic builder and buildkit: /bin/sh -c apt-get update
+	if rest, ok := strings.CutPrefix(s, historyShellPrefix); ok {
+		return "RUN " + strings.TrimSpace(rest)
+	}
+	if run {
+		return "RUN " + s
+	}
+
+	instruction, _, _ := strings.Cut(s, " ")
+	for _, i := range dockerfileInstructions {
+		if instruction == i {
+			return s
+		}
+	}
+	return "# " + s
+}
+
+// trimHistoryArgs removes the build arguments buildkit records in front
+// of the RUN commands.
+func trimHistoryArgs(s string) string {
+	m := historyArgsRe.FindStringSubmatch(s)
+	if m == nil {
+		return s
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return s
+	}
+	s = s[len(m[0]):]
+	for range n {
+		_, rest, _ := strings.Cut(s, " ")
+		s = strings.TrimLeft(rest, " ")
+	}
+	return s
+}
+
+// imageDockerfile returns a pseudo-Dockerfile reconstructed from the
+// image history. It is empty when the image has no history.
+func imageDockerfile(conf *v1.ConfigFile) string {
+	lines := []string{}
+	for _, step := range imageHistorySteps(conf) {
+		if step.Instruction != "" {
+			lines = append(lines, step.Instruction)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// historyAnnotation returns an annotation by bom with the specified
+// comment, dated when the annotated step ran.
+func historyAnnotation(created time.Time, comment string) Annotation {
+	if created.IsZero() {
+		created = time.Now()
+	}
+	return Annotation{
+		Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+		Date:      created.UTC().Format(time.RFC3339),
+		Type:      "OTHER",
+		Comment:   comment,
+	}
+}
+
+// addImageHistoryAnnotation records the Dockerfile reconstructed from the
+// image history as an annotation of the image package.
+func (p *Package) addImageHistoryAnnotation(conf *v1.ConfigFile) {
+	dockerfile := imageDockerfile(conf)
+	if dockerfile == "" {
+		return
+	}
+	p.Annotations = append(p.Annotations, historyAnnotation(
+		conf.Created.Time, "Image build history (reconstructed Dockerfile):\n"+dockerfile,
+	))
+}
+
+// addLayerHistoryAnnotation records the build step that created a layer
+// as an annotation of the layer package.
+func (p *Package) addLayerHistoryAnnotation(step imageHistoryStep) {
+	if step.Instruction == "" {
+		return
+	}
+	p.Annotations = append(p.Annotations, historyAnnotation(
+		step.Created, "Layer created by: "+step.Instruction,
+	))
+}
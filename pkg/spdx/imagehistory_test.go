@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryInstruction(t *testing.T) {
+	for _, tc := range []struct {
+		createdBy string
+		expected  string
+	}{
+		{"", ""},
+		{`/bin/sh -c #(nop)  CMD ["nginx" "-g" "daemon off;"]`, `CMD ["nginx" "-g" "daemon off;"]`},
+		{"/bin/sh -c #(nop) ADD file:3a1b2c in / ", "ADD file:3a1b2c in /"},
+		{"/bin/sh -c apt-get update && apt-get install -y curl", "RUN apt-get update && apt-get install -y curl"},
+		{"RUN /bin/sh -c apk add --no-cache git # buildkit", "RUN apk add --no-cache git"},
+		{"RUN |2 VERSION=1.0 TARGETARCH=amd64 /bin/sh -c make build # buildkit", "RUN make build"},
+		{"RUN |1 VERSION=1.0 make build # buildkit", "RUN make build"},
+		{"COPY /out/server /usr/bin/server # buildkit", "COPY /out/server /usr/bin/server"},
+		{"ENV PATH=/usr/local/bin:/usr/bin", "ENV PATH=/usr/local/bin:/usr/bin"},
+		{"bazel build //cmd/server", "# bazel build //cmd/server"},
+	} {
+		require.Equal(t, tc.expected, historyInstruction(tc.createdBy), tc.createdBy)
+	}
+}
+
+func TestImageHistoryAnnotations(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	conf := &v1.ConfigFile{
+		Created: v1.Time{Time: created},
+		History: []v1.History{
+			{CreatedBy: "/bin/sh -c #(nop) ADD file:3a1b2c in / ", Created: v1.Time{Time: created}},
+			{CreatedBy: "/bin/sh -c #(nop)  ENV LANG=C.UTF-8", EmptyLayer: true},
+			{CreatedBy: "RUN /bin/sh -c apk add curl # buildkit", Created: v1.Time{Time: created}},
+			{CreatedBy: `CMD ["/bin/sh"] # buildkit`, EmptyLayer: true},
+		},
+	}
+
+	require.Equal(t, "ADD file:3a1b2c in /\nENV LANG=C.UTF-8\nRUN apk add curl\nCMD [\"/bin/sh\"]", imageDockerfile(conf))
+
+	image := NewPackage()
+	image.addImageHistoryAnnotation(conf)
+	require.Len(t, image.Annotations, 1)
+	require.Equal(t, "2024-05-01T10:00:00Z", image.Annotations[0].Date)
+	require.True(t, strings.HasPrefix(image.Annotations[0].Comment, "Image build history (reconstructed Dockerfile):\nADD file:3a1b2c in /\n"))
+
+	steps := imageLayerSteps(conf)
+	require.Len(t, steps, 2)
+	layer := NewPackage()
+	layer.addLayerHistoryAnnotation(steps[1])
+	require.Len(t, layer.Annotations, 1)
+	require.Equal(t, "Layer created by: RUN apk add curl", layer.Annotations[0].Comment)
+
+	empty := NewPackage()
+	empty.addImageHistoryAnnotation(&v1.ConfigFile{})
+	require.Empty(t, empty.Annotations)
+	require.Empty(t, imageLayerSteps(nil))
+}
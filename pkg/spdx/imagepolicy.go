@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// ImagePolicy restricts the image references that can be analyzed when
+// generating a document. Rules match the full repository of the image
+// (eg gcr.io/distroless/static or index.docker.io/library/nginx) or any
+// of its parent paths, so a rule can be a registry, a namespace or a
+// single repository. Rules are path globs.
+//
+// Rules are normalized like image references, so docker.io/nginx and
+// nginx both match index.docker.io/library/nginx. As in references, a
+// single name without a registry is a Docker Hub official image, Docker
+// Hub namespaces are written as globs (eg docker.io/bitnami/*).
+type ImagePolicy struct {
+	RequireDigest bool     `yaml:"require-digest"` // Refuse images not pinned by digest
+	Allow         []string `yaml:"allow"`          // When set, images must match one of these rules
+	Deny          []string `yaml:"deny"`           // Images matching these rules are refused
+}
+
+// Validate checks that the policy rules are well formed.
+func (ip *ImagePolicy) Validate() error {
+	for _, rule := range slices.Concat(ip.Allow, ip.Deny) {
+		if rule == "" {
+			return errors.New("image policy rule is empty")
+		}
+		if _, err := path.Match(rule, ""); err != nil {
+			return fmt.Errorf("parsing image policy rule %q: %w", rule, err)
+		}
+	}
+	return nil
+}
+
+// Check returns an error if the policy does not allow the image reference.
+// Deny rules take precedence over the allow list.
+func (ip *ImagePolicy) Check(reference string) error {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return fmt.Errorf("parsing image reference: %w", err)
+	}
+
+	if _, ok := ref.(name.Digest); ip.RequireDigest && !ok {
+		return fmt.Errorf("image %s is not pinned by digest", reference)
+	}
+
+	repo := ref.Context().Name()
+	for _, rule := range ip.Deny {
+		if matchRepositoryRule(normalizeRepositoryRule(rule), repo) {
+			return fmt.Errorf("image %s is denied by rule %q", reference, rule)
+		}
+	}
+
+	if len(ip.Allow) == 0 {
+		return nil
+	}
+	for _, rule := range ip.Allow {
+		if matchRepositoryRule(normalizeRepositoryRule(rule), repo) {
+			return nil
+		}
+	}
+	return fmt.Errorf("image %s does not match any allowed repository", reference)
+}
+
+// normalizeRepositoryRule returns the name of the registry or repository
+// of a rule as normalized in image references. Only the registry of
+// globs is normalized.
+func normalizeRepositoryRule(rule string) string {
+	rule = strings.TrimSuffix(rule, "/")
+	registry, repo, hasRepo := strings.Cut(rule, "/")
+	// As in references, the first path element is a registry only when it
+	// has a domain or a port
+	isRegistry := strings.ContainsAny(registry, ".:")
+
+	if strings.ContainsAny(rule, `*?[\`) {
+		if !isRegistry || !hasRepo || strings.ContainsAny(registry, `*?[\`) {
+			return rule
+		}
+		if reg, err := name.NewRegistry(registry); err == nil {
+			return reg.Name() + "/" + repo
+		}
+		return rule
+	}
+
+	if isRegistry && !hasRepo {
+		if reg, err := name.NewRegistry(rule); err == nil {
+			return reg.Name()
+		}
+		return rule
+	}
+	if r, err := name.NewRepository(rule); err == nil {
+		return r.Name()
+	}
+	return rule
+}
+
+// matchRepositoryRule returns true if the rule glob matches the repository
+// or any of its parent paths.
+func matchRepositoryRule(rule, repo string) bool {
+	rule = strings.TrimSuffix(rule, "/")
+	for {
+		if ok, err := path.Match(rule, repo); err == nil && ok {
+			return true
+		}
+		i := strings.LastIndex(repo, "/")
+		if i == -1 {
+			return false
+		}
+		repo = repo[:i]
+	}
+}
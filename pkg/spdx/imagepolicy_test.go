@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagePolicyValidate(t *testing.T) {
+	for _, tc := range []struct {
+		policy    ImagePolicy
+		shouldErr bool
+	}{
+		{ImagePolicy{}, false},
+		{ImagePolicy{Allow: []string{"gcr.io", "*.dkr.ecr.*.amazonaws.com"}, Deny: []string{"gcr.io/distroless/base"}}, false},
+		{ImagePolicy{Allow: []string{""}}, true},
+		{ImagePolicy{Deny: []string{"gcr.io/["}}, true},
+	} {
+		err := tc.policy.Validate()
+		if tc.shouldErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestNormalizeRepositoryRule(t *testing.T) {
+	for rule, expected := range map[string]string{
+		"nginx":                       "index.docker.io/library/nginx",
+		"docker.io/nginx":             "index.docker.io/library/nginx",
+		"docker.io/bitnami/redis":     "index.docker.io/bitnami/redis",
+		"docker.io/bitnami/*":         "index.docker.io/bitnami/*",
+		"docker.io":                   "index.docker.io",
+		"localhost:5000/":             "localhost:5000",
+		"gcr.io/distroless":           "gcr.io/distroless",
+		"*.dkr.ecr.*.amazonaws.com":   "*.dkr.ecr.*.amazonaws.com",
+		"index.docker.io/library/*":   "index.docker.io/library/*",
+		"ghcr.io/kubernetes-sigs/bo?": "ghcr.io/kubernetes-sigs/bo?",
+	} {
+		require.Equal(t, expected, normalizeRepositoryRule(rule), rule)
+	}
+}
+
+func TestImagePolicyCheck(t *testing.T) {
+	const digest = "@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57"
+	policy := ImagePolicy{
+		Allow: []string{"gcr.io/distroless", "*.dkr.ecr.*.amazonaws.com", "localhost:5000/", "docker.io/library/*", "docker.io/bitnami/*"},
+		Deny:  []string{"gcr.io/distroless/base", "ubuntu", "docker.io/library/debian"},
+	}
+	for _, tc := range []struct {
+		ref           string
+		requireDigest bool
+		shouldErr     bool
+	}{
+		{"gcr.io/distroless/static:nonroot", false, false},
+		{"gcr.io/distroless/static:nonroot", true, true},
+		{"gcr.io/distroless/static" + digest, true, false},
+		{"gcr.io/distroless/static:nonroot" + digest, true, false},
+		{"gcr.io/distroless/base" + digest, false, true},
+		{"gcr.io/distroless/base/nested:v1", false, true},
+		{"gcr.io/other/image:v1", false, true},
+		{"123456789012.dkr.ecr.us-east-1.amazonaws.com/team/app:v1", false, false},
+		{"localhost:5000/busybox:latest", false, false},
+		{"localhost:5001/busybox:latest", false, true},
+		{"nginx", false, false},
+		{"nginx", true, true},
+		{"ubuntu:24.04", false, true},
+		{"index.docker.io/library/ubuntu:24.04", false, true},
+		{"docker.io/debian:12", false, true},
+		{"bitnami/redis:7.4", false, false},
+		{"docker.io/grafana/grafana:11.0.0", false, true},
+		{"ghcr.io/kubernetes-sigs/bom:v0.6.0", false, true},
+		{"Not A Reference", false, true},
+	} {
+		policy.RequireDigest = tc.requireDigest
+		err := policy.Check(tc.ref)
+		if tc.shouldErr {
+			require.Error(t, err, tc.ref)
+		} else {
+			require.NoError(t, err, tc.ref)
+		}
+	}
+
+	// Without an allow list only the deny rules apply
+	denyOnly := ImagePolicy{Deny: []string{"docker.io"}}
+	require.NoError(t, denyOnly.Check("ghcr.io/kubernetes-sigs/bom:v0.6.0"))
+	require.Error(t, denyOnly.Check("nginx:latest"))
+}
@@ -20,17 +20,23 @@ package spdx
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	gitignore "github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -55,28 +61,36 @@ import (
 type spdxImplementation interface {
 	ExtractTarballTmp(string) (string, error)
 	ReadArchiveManifest(string) (*ArchiveManifest, error)
-	PullImagesToArchive(string, string) (*ImageReferenceInfo, error)
+	PullImagesToArchive(context.Context, string, string) (*ImageReferenceInfo, error)
 	PackageFromImageTarball(*Options, string) (*Package, error)
+	PackageFromRootfs(*Options, string) (*Package, error)
 	PackageFromTarball(*Options, *TarballOptions, string) (*Package, error)
 	PackageFromDirectory(*Options, string) (*Package, error)
 	GetDirectoryTree(string) ([]string, error)
 	IgnorePatterns(string, []string, bool) ([]gitignore.Pattern, error)
 	ApplyIgnorePatterns([]string, []gitignore.Pattern) []string
-	GetGoDependencies(string, *Options) ([]*Package, error)
+	GetGoDependencies(context.Context, string, *Options) ([]*Package, error)
 	GetDirectoryLicense(*license.Reader, string, *Options) (*license.License, error)
 	LicenseReader(*Options) (*license.Reader, error)
-	ImageRefToPackage(string, *Options) (*Package, error)
-	AnalyzeImageLayer(string, *Package) error
+	ImageRefToPackage(context.Context, string, *Options) (*Package, error)
+	AnalyzeImageLayer(*Options, string, *Package) error
 }
 
 type spdxDefaultImplementation struct{}
 
-// ExtractTarballTmp extracts a tarball to a temporary directory.
+// ExtractTarballTmp extracts a tarball to a temporary directory. The
+// directory is removed if the extraction fails.
 func (di *spdxDefaultImplementation) ExtractTarballTmp(tarPath string) (tmpDir string, err error) {
 	tmpDir, err = os.MkdirTemp(os.TempDir(), "spdx-tar-extract-")
 	if err != nil {
-		return tmpDir, fmt.Errorf("creating temporary directory for tar extraction: %w", err)
+		return "", fmt.Errorf("creating temporary directory for tar extraction: %w", err)
 	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tmpDir) //nolint:errcheck // The extraction error is returned
+			tmpDir = ""
+		}
+	}()
 
 	// Open the tar file
 	f, err := os.Open(tarPath)
@@ -85,17 +99,25 @@ func (di *spdxDefaultImplementation) ExtractTarballTmp(tarPath string) (tmpDir s
 	}
 	defer f.Close()
 
-	// Read the first bytes to determine if the file is compressed
-	var sample [3]byte
+	// Read the first bytes to determine if the file is compressed. Files
+	// shorter than the sample can't be compressed archives.
+	var sample [6]byte
 	var gzipped bool
-	if _, err := io.ReadFull(f, sample[:]); err != nil {
-		return "", fmt.Errorf("sampling bytes from file header: %w", err)
+	n, err := io.ReadFull(f, sample[:])
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return tmpDir, fmt.Errorf("sampling bytes from file header: %w", err)
 	}
+	header := sample[:n]
 	if _, err := f.Seek(0, 0); err != nil {
-		return "", fmt.Errorf("rewinding read pointer: %w", err)
+		return tmpDir, fmt.Errorf("rewinding read pointer: %w", err)
 	}
 
-	if sample[0] == 0x1f && sample[1] == 0x8b && sample[2] == 0x08 {
+	switch {
+	case bytes.HasPrefix(header, zipMagic):
+		return tmpDir, extractZipArchive(tarPath, tmpDir)
+	case bytes.HasPrefix(header, sevenZipMagic), bytes.HasPrefix(header, xzMagic):
+		return tmpDir, fmt.Errorf("unable to extract %s, 7z and xz archives are not supported yet", tarPath)
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b, 0x08}):
 		gzipped = true
 	}
 
@@ -103,7 +125,7 @@ func (di *spdxDefaultImplementation) ExtractTarballTmp(tarPath string) (tmpDir s
 	if gzipped {
 		gzipReader, err := gzip.NewReader(f)
 		if err != nil {
-			return "", err
+			return tmpDir, fmt.Errorf("opening gzip stream: %w", err)
 		}
 		tr = tar.NewReader(gzipReader)
 	} else {
@@ -157,7 +179,66 @@ func (di *spdxDefaultImplementation) ExtractTarballTmp(tarPath string) (tmpDir s
 	}
 
 	logrus.Debugf("Successfully extracted %d files from image tarball %s", numFiles, tarPath)
-	return tmpDir, err
+	return tmpDir, nil
+}
+
+// extractZipArchive extracts all the files in a zip archive to destDir.
+func extractZipArchive(zipPath, destDir string) error {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("opening zip archive: %w", err)
+	}
+	defer zr.Close()
+
+	numFiles := 0
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() {
+			continue
+		}
+
+		// Symlinks are not followed, same as when extracting tarballs
+		if zf.Mode()&os.ModeSymlink == os.ModeSymlink {
+			logrus.Debugf("Skipping extraction of symlink %s", zf.Name)
+			continue
+		}
+
+		targetFile, err := sanitizeExtractPath(destDir, zf.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(targetFile), os.FileMode(0o755)); err != nil {
+			return fmt.Errorf("creating archive directory structure: %w", err)
+		}
+
+		if err := extractZipFile(zf, targetFile); err != nil {
+			return fmt.Errorf("extracting %s from zip archive: %w", zf.Name, err)
+		}
+		numFiles++
+	}
+
+	logrus.Debugf("Successfully extracted %d files from zip archive %s", numFiles, zipPath)
+	return nil
+}
+
+// extractZipFile writes the contents of a file in a zip archive to targetPath.
+func extractZipFile(zf *zip.File, targetPath string) error {
+	src, err := zf.Open()
+	if err != nil {
+		return fmt.Errorf("opening file in archive: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(targetPath)
+	if err != nil {
+		return fmt.Errorf("creating archive file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.CopyN(dst, src, int64(zf.UncompressedSize64)); err != nil && err != io.EOF { //nolint:gosec // Size comes from the archive directory
+		return fmt.Errorf("writing file data: %w", err)
+	}
+	return nil
 }
 
 // fix gosec G305: File traversal when extracting zip/tar archive
@@ -186,7 +267,7 @@ func (di *spdxDefaultImplementation) ReadArchiveManifest(manifestPath string) (m
 		return manifest, fmt.Errorf("unable to read from tarfile: %w", err)
 	}
 	if err := json.Unmarshal(manifestJSON, &manifestData); err != nil {
-		fmt.Println(string(manifestJSON))
+		logrus.Debugf("Invalid image manifest: %s", manifestJSON)
 		return manifest, fmt.Errorf("unmarshalling image manifest: %w", err)
 	}
 	return &manifestData[0], nil
@@ -194,25 +275,41 @@ func (di *spdxDefaultImplementation) ReadArchiveManifest(manifestPath string) (m
 
 // getImageReferences gets a reference string and returns all image
 // references from it.
-func getImageReferences(referenceString string) (*ImageReferenceInfo, error) {
+func getImageReferences(ctx context.Context, referenceString string) (*ImageReferenceInfo, error) {
 	ref, err := name.ParseReference(referenceString)
 	if err != nil {
 		return nil, fmt.Errorf("parsing image reference %s: %w", referenceString, err)
 	}
 
-	descr, err := remote.Get(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	descr, err := remote.Get(
+		ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("fetching remote descriptor: %w", err)
 	}
 
 	// If the reference points to an image, return it
-	if descr.MediaType.IsImage() {
-		return refInfoFromImage(ref, descr)
-	} else if descr.MediaType.IsIndex() {
-		return refInfoFromIndex(ref, descr)
+	var refinfo *ImageReferenceInfo
+	switch {
+	case descr.MediaType.IsImage():
+		refinfo, err = refInfoFromImage(ref, descr)
+	case descr.MediaType.IsIndex():
+		refinfo, err = refInfoFromIndex(ref, descr)
+	default:
+		return nil, fmt.Errorf("unable to recognize reference mediatype (%s)", string(descr.MediaType))
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("unable to recognize reference mediatype (%s)", string(descr.MediaType))
+	// Keep the tag the images were pulled from to record it in the purls
+	if tag, ok := ref.(name.Tag); ok {
+		refinfo.Tag = tag.TagStr()
+		for i := range refinfo.Images {
+			refinfo.Images[i].Tag = refinfo.Tag
+		}
+	}
+	return refinfo, nil
 }
 
 func refInfoFromIndex(ref name.Reference, descr *remote.Descriptor) (refinfo *ImageReferenceInfo, err error) {
@@ -261,6 +358,8 @@ func refInfoFromIndex(ref name.Reference, descr *remote.Descriptor) (refinfo *Im
 		if manifest.Platform != nil {
 			arch = manifest.Platform.Architecture
 			osid = manifest.Platform.OS
+		} else {
+			logrus.Warnf("Image %s in index %s has no platform data", archImgDigest, tag)
 		}
 
 		logrus.Infof("Adding image %s (%s/%s)", archImgDigest, arch, osid)
@@ -353,12 +452,14 @@ func PullImageToArchive(referenceString, path string) error {
 }
 
 // PullImagesToArchive takes an image reference (a tag or a digest)
-// and writes it into a docker tar archive in path.
+// and writes it into a docker tar archive in path. If the context is
+// cancelled, the running downloads are aborted and the archives written
+// so far are removed.
 func (di *spdxDefaultImplementation) PullImagesToArchive(
-	referenceString, path string,
+	ctx context.Context, referenceString, path string,
 ) (references *ImageReferenceInfo, err error) {
 	// Get the image references from the index
-	references, err = getImageReferences(referenceString)
+	references, err = getImageReferences(ctx, referenceString)
 	if err != nil {
 		return nil, err
 	}
@@ -372,7 +473,7 @@ func (di *spdxDefaultImplementation) PullImagesToArchive(
 	// If we do not have any child images we download the main reference
 	// as it is not an index
 	if len(references.Images) == 0 {
-		tarPath, err := createReferenceArchive(references.Digest, path)
+		tarPath, err := createReferenceArchive(ctx, references.Digest, path)
 		if err != nil {
 			return nil, fmt.Errorf("downloading archive of image: %w", err)
 		}
@@ -388,8 +489,15 @@ func (di *spdxDefaultImplementation) PullImagesToArchive(
 	mtx := sync.Mutex{}
 
 	for _, refData := range references.Images {
+		// Once cancelled, skip the pending images. The throttler
+		// still waits for the running downloads to return.
+		if err := ctx.Err(); err != nil {
+			t.Done(err)
+			t.Throttle()
+			continue
+		}
 		go func(r ImageReferenceInfo) {
-			tarPath, err := createReferenceArchive(r.Digest, path)
+			tarPath, err := createReferenceArchive(ctx, r.Digest, path)
 			mtx.Lock()
 			r.Archive = tarPath
 			newrefs.Images = append(newrefs.Images, r)
@@ -399,12 +507,17 @@ func (di *spdxDefaultImplementation) PullImagesToArchive(
 		t.Throttle()
 	}
 	if err := t.Err(); err != nil {
+		for _, r := range newrefs.Images {
+			if r.Archive != "" {
+				os.Remove(r.Archive)
+			}
+		}
 		return nil, err
 	}
 	return &newrefs, nil
 }
 
-func createReferenceArchive(digest, path string) (tarPath string, err error) {
+func createReferenceArchive(ctx context.Context, digest, path string) (tarPath string, err error) {
 	ref, err := name.ParseReference(digest)
 	if err != nil {
 		return "", fmt.Errorf("parsing reference %s: %w", digest, err)
@@ -422,8 +535,11 @@ func createReferenceArchive(digest, path string) (tarPath string, err error) {
 	tarPath = filepath.Join(path, p[1]+".tar")
 	logrus.Debugf("Downloading %s from remote registry to %s", digest, tarPath)
 
-	// Download image from remote
-	img, err := remote.Image(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	// Download image from remote. The layers are fetched while writing
+	// the archive, the context aborts them too.
+	img, err := remote.Image(
+		ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx),
+	)
 	if err != nil {
 		return "", fmt.Errorf("getting image from remote: %w", err)
 	}
@@ -432,6 +548,7 @@ func createReferenceArchive(digest, path string) (tarPath string, err error) {
 	if err := tarball.MultiWriteToFile(
 		tarPath, map[name.Tag]v1.Image{d.Repository.Tag(p[1]): img},
 	); err != nil {
+		os.Remove(tarPath)
 		return "", fmt.Errorf("writing image to disk: %w", err)
 	}
 
@@ -455,15 +572,30 @@ func (di *spdxDefaultImplementation) PackageFromTarball(
 		if err != nil {
 			return nil, fmt.Errorf("generating package from tar contents: %w", err)
 		}
+
+		// Java archives are identified from their metadata. Archives
+		// nested in a jar are read when reading the jar itself below.
+		if opts.ProcessJava && !isJavaArchivePath(tarFile) {
+			if err := addJavaArchivePackages(pkg, tmp, opts.Degradations); err != nil {
+				return nil, fmt.Errorf("reading java archives: %w", err)
+			}
+		}
 	} else {
 		pkg = NewPackage()
 	}
+	pkg.PrimaryPurpose = "ARCHIVE"
+
 	// Set the extract dir option. This makes the package to remove
 	// the tempdir prefix from the document paths:
 	pkg.Options().WorkDir = tarOpts.ExtractDir
 	if err := pkg.ReadSourceFile(tarFile); err != nil {
 		return nil, fmt.Errorf("reading source file %s: %w", tarFile, err)
 	}
+	if opts.ProcessJava && isJavaArchivePath(tarFile) {
+		if err := readJavaArchiveFile(pkg, tarFile, opts.Degradations); err != nil {
+			return nil, fmt.Errorf("reading java archive %s: %w", tarFile, err)
+		}
+	}
 	// Build the ID and the filename from the tarball name
 	return pkg, nil
 }
@@ -492,44 +624,68 @@ func (di *spdxDefaultImplementation) GetDirectoryTree(dirPath string) ([]string,
 	return fileList, nil
 }
 
-// IgnorePatterns return a list of gitignore patterns.
+// IgnorePatterns return a list of gitignore patterns. The patterns are
+// read from the .gitignore (unless skipGitIgnore is set) and .bomignore
+// files at the root of the directory, followed by the extra patterns.
+// As in git, the last pattern matching a file decides if it is ignored,
+// so negated patterns in .bomignore can bring back files in .gitignore.
 func (di *spdxDefaultImplementation) IgnorePatterns(
 	dirPath string, extraPatterns []string, skipGitIgnore bool,
 ) ([]gitignore.Pattern, error) {
 	patterns := []gitignore.Pattern{}
-	for _, s := range extraPatterns {
-		patterns = append(patterns, gitignore.ParsePattern(s, nil))
-	}
 
 	if skipGitIgnore {
 		logrus.Debug("Not using patterns in .gitignore")
-		return patterns, nil
+	} else if util.Exists(filepath.Join(dirPath, gitIgnoreFile)) {
+		// When using .gitignore files, we alwas add the .git directory
+		// to match git's behavior
+		patterns = append(patterns, gitignore.ParsePattern(".git/", nil))
+
+		filePatterns, err := readIgnoreFile(filepath.Join(dirPath, gitIgnoreFile))
+		if err != nil {
+			return nil, fmt.Errorf("reading gitignore file: %w", err)
+		}
+		logrus.Debugf("Loaded %d patterns from .gitignore at root of directory", len(filePatterns))
+		patterns = append(patterns, filePatterns...)
 	}
 
-	if util.Exists(filepath.Join(dirPath, gitIgnoreFile)) {
-		f, err := os.Open(filepath.Join(dirPath, gitIgnoreFile))
+	if util.Exists(filepath.Join(dirPath, bomIgnoreFile)) {
+		filePatterns, err := readIgnoreFile(filepath.Join(dirPath, bomIgnoreFile))
 		if err != nil {
-			return nil, fmt.Errorf("opening gitignore file: %w", err)
+			return nil, fmt.Errorf("reading bomignore file: %w", err)
 		}
-		defer f.Close()
+		logrus.Debugf("Loaded %d patterns from .bomignore at root of directory", len(filePatterns))
+		patterns = append(patterns, filePatterns...)
+	}
 
-		// When using .gitignore files, we alwas add the .git directory
-		// to match git's behavior
-		patterns = append(patterns, gitignore.ParsePattern(".git/", nil))
+	for _, s := range extraPatterns {
+		patterns = append(patterns, gitignore.ParsePattern(s, nil))
+	}
 
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			s := scanner.Text()
-			if !strings.HasPrefix(s, "#") && strings.TrimSpace(s) != "" {
-				logrus.Debugf("Loaded .gitignore pattern: >>%s<<", s)
-				patterns = append(patterns, gitignore.ParsePattern(s, nil))
-			}
-		}
+	logrus.Debugf("Using %d ignore patterns (%d extra)", len(patterns), len(extraPatterns))
+	return patterns, nil
+}
+
+// readIgnoreFile reads the patterns in a file using the .gitignore syntax.
+func readIgnoreFile(path string) ([]gitignore.Pattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening ignore file: %w", err)
 	}
+	defer f.Close()
 
-	logrus.Debugf(
-		"Loaded %d patterns from .gitignore (+ %d extra) at root of directory", len(patterns), len(extraPatterns),
-	)
+	patterns := []gitignore.Pattern{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		s := scanner.Text()
+		if !strings.HasPrefix(s, "#") && strings.TrimSpace(s) != "" {
+			logrus.Debugf("Loaded ignore pattern: >>%s<<", s)
+			patterns = append(patterns, gitignore.ParsePattern(s, nil))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading ignore file: %w", err)
+	}
 	return patterns, nil
 }
 
@@ -549,7 +705,7 @@ func (di *spdxDefaultImplementation) ApplyIgnorePatterns(
 
 	// Cycle all files, removing those matched:
 	for _, file := range fileList {
-		if matcher.Match(strings.Split(file, string(filepath.Separator)), false) {
+		if matcher.Match(strings.Split(filepath.ToSlash(file), "/"), false) {
 			logrus.Debugf("File ignored by .gitignore: %s", file)
 		} else {
 			filteredList = append(filteredList, file)
@@ -561,7 +717,7 @@ func (di *spdxDefaultImplementation) ApplyIgnorePatterns(
 // GetGoDependencies opens a Go module and directory and returns the
 // dependencies as SPDX packages.
 func (di *spdxDefaultImplementation) GetGoDependencies(
-	path string, opts *Options,
+	ctx context.Context, path string, opts *Options,
 ) (spdxPackages []*Package, err error) {
 	// Open the directory as a go module:
 	mod, err := NewGoModuleFromPath(path)
@@ -570,6 +726,16 @@ func (di *spdxDefaultImplementation) GetGoDependencies(
 	}
 	mod.Options().OnlyDirectDeps = opts.OnlyDirectDeps
 	mod.Options().ScanLicenses = opts.ScanLicenses
+	mod.Options().ExcludeDevDeps = opts.ExcludeDevDeps
+	mod.Options().ClearlyDefined = opts.ClearlyDefined
+	if opts.SourceCaches != nil {
+		mod.Options().GoModCache = opts.SourceCaches.GoModCache
+	}
+	mod.Options().LicenseScanCache = opts.LicenseScanCache
+	mod.Options().Degradations = opts.Degradations
+	mod.Options().GOOS = opts.GoOS
+	mod.Options().GOARCH = opts.GoArch
+	mod.Options().BuildTags = opts.GoBuildTags
 
 	// Open the module
 	if err := mod.Open(); err != nil {
@@ -584,7 +750,7 @@ func (di *spdxDefaultImplementation) GetGoDependencies(
 		}
 	}()
 	if opts.ScanLicenses {
-		if errScan := mod.ScanLicenses(); err != nil {
+		if errScan := mod.ScanLicensesContext(ctx); errScan != nil {
 			return nil, errScan
 		}
 	}
@@ -619,20 +785,25 @@ func (di *spdxDefaultImplementation) LicenseReader(spdxOpts *Options) (*license.
 // GetDirectoryLicense takes a path and scans
 // the files in it to determine licensins information.
 func (di *spdxDefaultImplementation) GetDirectoryLicense(
-	reader *license.Reader, path string, _ *Options,
+	reader *license.Reader, path string, opts *Options,
 ) (*license.License, error) {
 	licenseResult, err := reader.ReadTopLicense(path)
 	if err != nil {
 		return nil, fmt.Errorf("getting directory license: %w", err)
 	}
 	if licenseResult == nil {
-		logrus.Warnf("License classifier could not find a license for directory: %v", err)
+		opts.Degradations.record(DegradationMissingLicenses, "License classifier could not find a license for directory %s", path)
 		return nil, nil
 	}
+	opts.Report.recordLicenseConfidence(path, licenseResult.Confidence)
 	return licenseResult.License, nil
 }
 
-// purlFromImage builds a purl from an image reference.
+// purlFromImage builds an oci purl from an image reference. As defined in
+// the purl spec, the name is the last segment of the repository and the
+// full repository, including the registry and its port, is recorded in
+// the repository_url qualifier. The tag the image was pulled from and
+// its platform are added as qualifiers when known.
 func (*spdxDefaultImplementation) purlFromImage(img *ImageReferenceInfo) string {
 	// OCI type urls don't have a namespace ref:
 	// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#oci
@@ -643,32 +814,28 @@ func (*spdxDefaultImplementation) purlFromImage(img *ImageReferenceInfo) string
 	}
 
 	digest := ""
-	// If we have the digest, skip checking it from the registry
-	if _, ok := imageReference.(name.Digest); ok {
-		p := strings.Split(imageReference.(name.Digest).String(), "@") //nolint: errcheck
-		if len(p) < 2 {
-			return ""
+	tag := img.Tag
+	switch ref := imageReference.(type) {
+	case name.Digest:
+		// If we have the digest, skip checking it from the registry
+		digest = ref.DigestStr()
+	case name.Tag:
+		if tag == "" {
+			tag = ref.TagStr()
 		}
-		digest = p[1]
-	} else {
-		digest, err = crane.Digest(img.Reference)
+		digest, err = crane.Digest(ref.String())
 		if err != nil {
 			logrus.Error(err)
 			return ""
 		}
 	}
 
-	url := imageReference.Context().Name()
-	parts := strings.Split(url, "/")
-	if len(parts) < 2 {
-		return ""
-	}
-	imageName := parts[len(parts)-1]
-	url = strings.TrimSuffix(url, "/"+imageName)
+	repo := imageReference.Context()
+	imageName := path.Base(repo.RepositoryStr())
 
 	// Add the purl qualifgiers:
 	mm := map[string]string{
-		"repository_url": url,
+		"repository_url": repo.Name(),
 	}
 	if img.Arch != "" {
 		mm["arch"] = img.Arch
@@ -676,8 +843,8 @@ func (*spdxDefaultImplementation) purlFromImage(img *ImageReferenceInfo) string
 	if img.OS != "" {
 		mm["os"] = img.OS
 	}
-	if tag, ok := imageReference.(name.Tag); ok {
-		mm["tag"] = tag.String()
+	if tag != "" {
+		mm["tag"] = tag
 	}
 	if img.MediaType != "" {
 		mm["mediaType"] = img.MediaType
@@ -692,14 +859,26 @@ func (*spdxDefaultImplementation) purlFromImage(img *ImageReferenceInfo) string
 }
 
 // ImageRefToPackage Returns a spdx package from an OCI image reference.
-func (di *spdxDefaultImplementation) ImageRefToPackage(ref string, opts *Options) (*Package, error) {
+func (di *spdxDefaultImplementation) ImageRefToPackage(ctx context.Context, ref string, opts *Options) (*Package, error) {
 	tmpdir, err := os.MkdirTemp("", "doc-build-")
 	if err != nil {
 		return nil, fmt.Errorf("creating temporary workdir in: %w", err)
 	}
 	defer os.RemoveAll(tmpdir)
 
-	references, err := di.PullImagesToArchive(ref, tmpdir)
+	// Images already described in the baseline are not pulled again
+	if opts.Baseline != nil {
+		references, err := getImageReferences(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		if p := opts.Baseline.imagePackage(di.purlFromImage(references)); p != nil {
+			logrus.Infof("Reusing the package of %s from the baseline", references.Digest)
+			return p, nil
+		}
+	}
+
+	references, err := di.PullImagesToArchive(ctx, ref, tmpdir)
 	if err != nil {
 		return nil, fmt.Errorf("while downloading images to archive: %w", err)
 	}
@@ -723,6 +902,7 @@ func (di *spdxDefaultImplementation) ImageRefToPackage(ref string, opts *Options
 		p.Name = topDigest.DigestStr()
 		p.BuildID(p.Name)
 
+		addImageSignatures(ctx, opts, references.Digest, p)
 		return p, nil
 	}
 
@@ -741,7 +921,12 @@ func (di *spdxDefaultImplementation) ImageRefToPackage(ref string, opts *Options
 	}
 
 	pkg.Name = refString
+	pkg.PrimaryPurpose = "CONTAINER"
 	pkg.BuildID(topDigest.DigestStr())
+	pkg.Checksum, err = digestChecksums(topDigest.DigestStr())
+	if err != nil {
+		return nil, fmt.Errorf("recording image index digest: %w", err)
+	}
 
 	if references.Digest != "" {
 		pkg.DownloadLocation = references.Digest
@@ -749,9 +934,17 @@ func (di *spdxDefaultImplementation) ImageRefToPackage(ref string, opts *Options
 
 	// Now, cycle each image in the index and generate a package from it
 	for i := range references.Images {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if plainRef != "" {
 			references.Images[i].Reference = plainRef
 		}
+		if references.Images[i].Arch == "" && references.Images[i].OS == "" {
+			opts.Degradations.record(
+				DegradationScannerFallback, "Image %s in index %s has no platform data", references.Images[i].Digest, ref,
+			)
+		}
 		subpkg, err := di.referenceInfoToPackage(opts, &references.Images[i])
 		if err != nil {
 			return nil, fmt.Errorf("generating image package: %w", err)
@@ -784,9 +977,40 @@ func (di *spdxDefaultImplementation) ImageRefToPackage(ref string, opts *Options
 			Locator:  packageurl,
 		})
 	}
+	addImageSignatures(ctx, opts, references.Digest, pkg)
 	return pkg, nil
 }
 
+// digestChecksums converts an OCI digest (eg sha256:abc...) to the
+// checksums of an SPDX package.
+func digestChecksums(digest string) (map[string]string, error) {
+	algo, value, ok := strings.Cut(digest, ":")
+	if !ok || value == "" {
+		return nil, fmt.Errorf("invalid digest %q", digest)
+	}
+	switch algo {
+	case "sha256", "sha384", "sha512":
+		return map[string]string{strings.ToUpper(algo): value}, nil
+	default:
+		return nil, fmt.Errorf("unsupported digest algorithm %q", algo)
+	}
+}
+
+// addImageSignatures records the signatures and attestations of an image
+// in its package when enabled in the options.
+func addImageSignatures(ctx context.Context, opts *Options, digestRef string, pkg *Package) {
+	if !opts.DiscoverSignatures {
+		return
+	}
+	sigs, err := discoverImageSignatures(ctx, digestRef)
+	if err != nil {
+		opts.Degradations.record(DegradationNetworkError, "looking up signatures of %s: %v", digestRef, err)
+		return
+	}
+	logrus.Infof("Found %d signatures and attestations of %s", len(sigs), digestRef)
+	pkg.addSignatureAnnotations(sigs)
+}
+
 func (di *spdxDefaultImplementation) referenceInfoToPackage(opts *Options, img *ImageReferenceInfo) (*Package, error) {
 	subpkg, err := di.PackageFromImageTarball(opts, img.Archive)
 	if err != nil {
@@ -809,8 +1033,11 @@ func (di *spdxDefaultImplementation) referenceInfoToPackage(opts *Options, img *
 		}
 	}
 
-	subpkg.Checksum = map[string]string{
-		"SHA256": strings.TrimPrefix(imageDigest.DigestStr(), "sha256:"),
+	// The image is identified by its manifest digest, not by the
+	// checksums of the archive it was read from
+	subpkg.Checksum, err = digestChecksums(imageDigest.DigestStr())
+	if err != nil {
+		return nil, fmt.Errorf("recording image digest: %w", err)
 	}
 	subpkg.FileName = ""
 
@@ -880,6 +1107,20 @@ func (di *spdxDefaultImplementation) PackageFromImageTarball(
 	imagePackage.Name = filepath.Base(tarPath)
 	imagePackage.BuildID(manifest.RepoTags[0])
 	imagePackage.Comment = "Container image archive"
+	imagePackage.PrimaryPurpose = "CONTAINER"
+
+	// Record the image configuration data in the package
+	var imageConfig *v1.ConfigFile
+	if manifest.ConfigFilename != "" {
+		conf, err := readImageConfig(filepath.Join(tarOpts.ExtractDir, manifest.ConfigFilename))
+		if err != nil {
+			spdxOpts.Degradations.record(DegradationScannerFallback, "Unable to read image configuration: %v", err)
+		} else {
+			applyImageConfig(imagePackage, conf, spdxOpts.ImageEnvValues)
+			imagePackage.addImageHistoryAnnotation(conf)
+			imageConfig = conf
+		}
+	}
 	logrus.Infof("Image manifest lists %d layers", len(manifest.LayerFiles))
 
 	// Scan the container layers for OS information:
@@ -898,6 +1139,15 @@ func (di *spdxDefaultImplementation) PackageFromImageTarball(
 		}
 	}
 
+	if spdxOpts.AnnotateKnownEOL {
+		release, err := osinfo.ReadOSRelease(layerPaths)
+		if err != nil {
+			spdxOpts.Degradations.record(DegradationScannerFallback, "Unable to read the OS release of the image: %v", err)
+		} else if release != nil {
+			spdxOpts.EndOfLife.check(manifest.RepoTags[0], release, time.Now())
+		}
+	}
+
 	if osPackageData != nil {
 		logrus.Infof(
 			"Scan of container image returned %d OS packages in layer #%d",
@@ -905,6 +1155,31 @@ func (di *spdxDefaultImplementation) PackageFromImageTarball(
 		)
 	}
 
+	// Flatpak and snap applications are installed outside of the OS
+	// package database and are scanned separately
+	var appPackageData *[]osinfo.PackageDBEntry
+	var appLayerNum int
+	if spdxOpts.ScanImages {
+		appLayerNum, appPackageData, err = osinfo.ReadAppPackages(layerPaths)
+		if err != nil {
+			return nil, fmt.Errorf("getting application data from container: %w", err)
+		}
+	}
+
+	if appPackageData != nil {
+		logrus.Infof(
+			"Scan of container image returned %d applications in layer #%d",
+			len(*appPackageData), appLayerNum,
+		)
+	}
+
+	// The history entries that produced a layer are matched to the
+	// layers only when their numbers agree
+	layerSteps := imageLayerSteps(imageConfig)
+	if len(layerSteps) != len(manifest.LayerFiles) {
+		layerSteps = nil
+	}
+
 	// Cycle all the layers from the manifest and add them as packages
 	for i, layerFile := range manifest.LayerFiles {
 		// Generate a package from a layer
@@ -913,16 +1188,29 @@ func (di *spdxDefaultImplementation) PackageFromImageTarball(
 			return nil, fmt.Errorf("building package from layer: %w", err)
 		}
 
+		// The SHA256 checksum of the layer blob is the layer digest
+		// listed in the image manifest
 		pkg.Name = "sha256:" + pkg.Checksum["SHA256"]
 		pkg.Comment = "Container image layer from archive"
+		if layerSteps != nil {
+			pkg.addLayerHistoryAnnotation(layerSteps[i])
+		}
 
 		// Regenerate the BuildID to avoid clashes when handling multiple
 		// images at the same time.
 		pkg.BuildID(manifest.RepoTags[0], pkg.Name)
 
+		// Files extracted from the layer were already scanned for
+		// secrets when reading the layer directory
+		if spdxOpts.DetectSecrets && !tarOpts.AddFiles {
+			if err := addLayerSecretFiles(pkg, filepath.Join(tarOpts.ExtractDir, layerFile)); err != nil {
+				return nil, fmt.Errorf("scanning layer for secrets: %w", err)
+			}
+		}
+
 		// If the option is enabled, scan the container layers
 		if spdxOpts.AnalyzeLayers {
-			if err := di.AnalyzeImageLayer(filepath.Join(tarOpts.ExtractDir, layerFile), pkg); err != nil {
+			if err := di.AnalyzeImageLayer(spdxOpts, filepath.Join(tarOpts.ExtractDir, layerFile), pkg); err != nil {
 				return nil, fmt.Errorf("scanning layer "+pkg.ID+" :%w", err)
 			}
 		} else {
@@ -931,40 +1219,22 @@ func (di *spdxDefaultImplementation) PackageFromImageTarball(
 
 		// If we got the OS data from the scanner, add the packages:
 		if i == layerNum && osPackageData != nil {
-			for i := range *osPackageData {
-				ospk := NewPackage()
-				ospk.Name = (*osPackageData)[i].Package
-				ospk.Version = (*osPackageData)[i].Version
-				ospk.HomePage = (*osPackageData)[i].HomePage
-				ospk.Originator = struct {
-					Person       string
-					Organization string
-				}{
-					Person: (*osPackageData)[i].MaintainerName,
-				}
-				if (*osPackageData)[i].License != "" {
-					ospk.LicenseDeclared = (*osPackageData)[i].License
-				}
-				ospk.Checksum = (*osPackageData)[i].Checksums
-
-				if (*osPackageData)[i].MaintainerName != "" {
-					ospk.Supplier.Person = (*osPackageData)[i].MaintainerName
-					if (*osPackageData)[i].MaintainerEmail != "" {
-						ospk.Supplier.Person += fmt.Sprintf(" (%s)", (*osPackageData)[i].MaintainerEmail)
-					}
+			// Packages already described by SBOMs embedded in the
+			// image are not derived again from the package database
+			layers := []*Package{pkg}
+			for _, rel := range imagePackage.Relationships {
+				if p, ok := rel.Peer.(*Package); ok {
+					layers = append(layers, p)
 				}
-				if (*osPackageData)[i].PackageURL() != "" {
-					ospk.ExternalRefs = append(ospk.ExternalRefs, ExternalRef{
-						Category: CatPackageManager,
-						Type:     "purl",
-						Locator:  (*osPackageData)[i].PackageURL(),
-					})
-				}
-
-				if (*osPackageData)[i].DownloadLocation() != "" {
-					ospk.DownloadLocation = (*osPackageData)[i].DownloadLocation()
+			}
+			described := embeddedSBOMPackages(layers...)
+			for i := range *osPackageData {
+				entry := &(*osPackageData)[i]
+				if _, ok := described[entry.Package+"@"+entry.Version]; ok {
+					logrus.Debugf("OS package %s is described by an embedded SBOM", entry.Package)
+					continue
 				}
-
+				ospk := packageFromOSEntry(entry)
 				ospk.BuildID(pkg.ID)
 				if err := pkg.AddPackage(ospk); err != nil {
 					return nil, fmt.Errorf("adding OS package to container layer: %w", err)
@@ -972,18 +1242,151 @@ func (di *spdxDefaultImplementation) PackageFromImageTarball(
 			}
 		}
 
+		if i == appLayerNum && appPackageData != nil {
+			for i := range *appPackageData {
+				apppk := packageFromOSEntry(&(*appPackageData)[i])
+				apppk.BuildID(pkg.ID)
+				if err := pkg.AddPackage(apppk); err != nil {
+					return nil, fmt.Errorf("adding application package to container layer: %w", err)
+				}
+			}
+		}
+
 		// Add the layer package to the image package
 		if err := imagePackage.AddPackage(pkg); err != nil {
 			return nil, fmt.Errorf("adding layer to image package: %w", err)
 		}
 	}
 
+	// Once the layers are in, run the analyzers that look at the whole image
+	if spdxOpts.AnalyzeLayers {
+		if err := di.AnalyzeImage(&ImageMetadata{
+			Reference: manifest.RepoTags[0],
+			Config:    imageConfig,
+			Layers:    layerPaths,
+		}, imagePackage); err != nil {
+			return nil, fmt.Errorf("analyzing image: %w", err)
+		}
+	}
+
 	// return the finished package
 	return imagePackage, nil
 }
 
-func (di *spdxDefaultImplementation) AnalyzeImageLayer(layerPath string, pkg *Package) error {
-	return NewImageAnalyzer().AnalyzeLayer(layerPath, pkg)
+// packageFromOSEntry converts an entry from an OS package database into
+// a SPDX package. The caller is expected to build the package ID.
+func packageFromOSEntry(entry *osinfo.PackageDBEntry) *Package {
+	ospk := NewPackage()
+	ospk.Name = entry.Package
+	ospk.Version = entry.Version
+	ospk.HomePage = entry.HomePage
+	ospk.Originator = struct {
+		Person       string
+		Organization string
+	}{
+		Person: entry.MaintainerName,
+	}
+	if entry.License != "" {
+		ospk.LicenseDeclared = entry.License
+	}
+	ospk.Checksum = entry.Checksums
+
+	if entry.MaintainerName != "" {
+		ospk.Supplier.Person = entry.MaintainerName
+		if entry.MaintainerEmail != "" {
+			ospk.Supplier.Person += fmt.Sprintf(" (%s)", entry.MaintainerEmail)
+		}
+	}
+	if entry.PackageURL() != "" {
+		ospk.ExternalRefs = append(ospk.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  entry.PackageURL(),
+		})
+	}
+
+	if entry.DownloadLocation() != "" {
+		ospk.DownloadLocation = entry.DownloadLocation()
+	}
+	return ospk
+}
+
+// PackageFromRootfs scans a directory holding a system root filesystem
+// and returns a package describing the operating system installed in
+// it with the OS packages found in its package database and the flatpak
+// and snap applications installed.
+func (di *spdxDefaultImplementation) PackageFromRootfs(opts *Options, rootPath string) (*Package, error) {
+	rootPath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("getting absolute root filesystem path: %w", err)
+	}
+
+	osKind, osPackageData, err := osinfo.ReadRootfsPackages(rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading OS packages from root filesystem: %w", err)
+	}
+
+	pkg := NewPackage()
+	pkg.Name = filepath.Base(rootPath)
+	pkg.PrimaryPurpose = "OPERATING-SYSTEM"
+	pkg.Comment = "System root filesystem"
+	if osKind != "" {
+		pkg.Comment += fmt.Sprintf(" (%s)", osKind)
+	}
+	pkg.BuildID("rootfs", rootPath)
+
+	appPackageData, err := osinfo.ReadRootfsAppPackages(rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading applications from root filesystem: %w", err)
+	}
+
+	if osPackageData == nil {
+		logrus.Warnf("No OS packages found in root filesystem %s", rootPath)
+	} else {
+		logrus.Infof("Scan of root filesystem returned %d OS packages", len(*osPackageData))
+		for i := range *osPackageData {
+			ospk := packageFromOSEntry(&(*osPackageData)[i])
+			ospk.BuildID(pkg.ID)
+			if err := pkg.AddPackage(ospk); err != nil {
+				return nil, fmt.Errorf("adding OS package to root filesystem: %w", err)
+			}
+		}
+	}
+
+	if appPackageData != nil {
+		logrus.Infof("Scan of root filesystem returned %d applications", len(*appPackageData))
+		for i := range *appPackageData {
+			apppk := packageFromOSEntry(&(*appPackageData)[i])
+			apppk.BuildID(pkg.ID)
+			if err := pkg.AddPackage(apppk); err != nil {
+				return nil, fmt.Errorf("adding application package to root filesystem: %w", err)
+			}
+		}
+	}
+
+	if err := addKernelPackages(pkg, rootPath, opts.Degradations); err != nil {
+		return nil, fmt.Errorf("reading kernel modules and firmware: %w", err)
+	}
+	return pkg, nil
+}
+
+// AnalyzeImageLayer runs the layer analyzers on a layer, except those
+// reading the packages of the language ecosystems disabled in the options.
+func (di *spdxDefaultImplementation) AnalyzeImageLayer(opts *Options, layerPath string, pkg *Package) error {
+	ia := NewImageAnalyzer()
+	ia.Options.GoStdlib = opts.GoStdlib
+	ia.Options.Degradations = opts.Degradations
+	for label, lang := range layerAnalyzerLanguages {
+		if !opts.processesLanguage(lang) {
+			delete(ia.Analyzers, label)
+		}
+	}
+	return ia.AnalyzeLayer(layerPath, pkg)
+}
+
+// AnalyzeImage runs the registered image analyzers on an image package.
+func (di *spdxDefaultImplementation) AnalyzeImage(image *ImageMetadata, pkg *Package) error {
+	return NewImageAnalyzer().AnalyzeImage(image, pkg)
 }
 
 // PackageFromDirectory scans a directory and returns its contents as a
@@ -1020,7 +1423,11 @@ func (di *spdxDefaultImplementation) PackageFromDirectory(opts *Options, dirPath
 	}
 
 	// Apply the ignore patterns to the list of files
+	total := len(fileList)
 	fileList = di.ApplyIgnorePatterns(fileList, patterns)
+	if ignored := total - len(fileList); ignored > 0 {
+		opts.Report.recordSkipped(dirPath, fmt.Sprintf("%d files matched the ignore patterns", ignored))
+	}
 	if len(fileList) == 0 {
 		return nil, fmt.Errorf("directory %s has no files to scan", dirPath)
 	}
@@ -1028,15 +1435,44 @@ func (di *spdxDefaultImplementation) PackageFromDirectory(opts *Options, dirPath
 
 	pkg = NewPackage()
 	pkg.FilesAnalyzed = true
+	pkg.PrimaryPurpose = "SOURCE"
 	pkg.Name = filepath.Base(dirPath)
-	if pkg.Name == "" {
+	if pkg.Name == "" || pkg.Name == string(filepath.Separator) {
+		// Scanning the root of a filesystem or a drive
 		pkg.Name = uuid.NewString()
 	}
 	pkg.LicenseConcluded = licenseTag
+	if lic != nil {
+		pkg.licenseConfidence = opts.Report.licenseConfidence(dirPath)
+	}
+
+	if opts.PersistentIDs {
+		// Software Heritage does not archive the git metadata
+		treeFiles := slices.DeleteFunc(slices.Clone(fileList), func(path string) bool {
+			return path == ".git" || strings.HasPrefix(path, ".git/")
+		})
+		treeID, err := gitTreeHash(dirPath, treeFiles)
+		if err != nil {
+			return nil, fmt.Errorf("computing directory software heritage ID: %w", err)
+		}
+		pkg.addPersistentIDRefs([]string{swhidPrefix + "dir:" + treeID})
+	}
 
 	// Set the working directory of the package:
 	pkg.Options().WorkDir = filepath.Dir(dirPath)
 
+	// Existing SBOMs and package records found in the directory describe
+	// components already, we reference them instead of the plain files
+	md := readDirectoryMetadata(dirPath, pkg.Name, fileList, opts.Degradations)
+	pkg.ExternalDocRefs = append(pkg.ExternalDocRefs, md.ExternalDocRefs...)
+	for _, rel := range md.Relationships {
+		pkg.AddRelationship(rel)
+	}
+	fileList = slices.DeleteFunc(fileList, func(path string) bool {
+		_, ok := md.Skip[path]
+		return ok
+	})
+
 	t := throttler.New(5, len(fileList))
 
 	processDirectoryFile := func(path string, pkg *Package) {
@@ -1048,27 +1484,45 @@ func (di *spdxDefaultImplementation) PackageFromDirectory(opts *Options, dirPath
 		f := NewFile()
 		f.Options().WorkDir = dirPath
 		f.Options().Prefix = pkg.Name
+		f.Options().ModelMetadata = opts.ModelMetadata
 
-		lic, err = reader.LicenseFromFile(filepath.Join(dirPath, path))
-		if err != nil {
-			t.Done(fmt.Errorf("scanning file for license: %w", err))
+		if err = f.ReadSourceFile(filepath.Join(dirPath, path)); err != nil {
+			t.Done(fmt.Errorf("checksumming file: %w", err))
 			return
 		}
 
-		// If a file does not contain a license then we assume
-		// the whole repository license applies. If it has one,
-		// the we conclude that files is released under those licenses.
-		f.LicenseInfoInFile = NONE
-		if lic == nil {
-			f.LicenseConcluded = licenseTag
-		} else {
-			f.LicenseInfoInFile = lic.LicenseID
-			f.LicenseConcluded = lic.LicenseID
+		// Files unchanged since the baseline keep the licenses found
+		// in them then. Otherwise, if a file does not contain a license
+		// then we assume the whole repository license applies. If it
+		// has one, the we conclude that files is released under those
+		// licenses.
+		if !opts.Baseline.fileLicenses(f, licenseTag) {
+			lic, err = reader.LicenseFromFile(filepath.Join(dirPath, path))
+			if err != nil {
+				t.Done(fmt.Errorf("scanning file for license: %w", err))
+				return
+			}
+			f.LicenseInfoInFile = NONE
+			if lic == nil {
+				f.LicenseConcluded = licenseTag
+			} else {
+				f.LicenseInfoInFile = lic.LicenseID
+				f.LicenseConcluded = lic.LicenseID
+			}
 		}
-
-		if err = f.ReadSourceFile(filepath.Join(dirPath, path)); err != nil {
-			t.Done(fmt.Errorf("checksumming file: %w", err))
-			return
+		if opts.DetectSecrets {
+			findings, err := detectFileSecrets(filepath.Join(dirPath, path))
+			if err != nil {
+				t.Done(fmt.Errorf("scanning file for secrets: %w", err))
+				return
+			}
+			if len(findings) > 0 {
+				logrus.Warnf("Found %d possible secrets in %s", len(findings), path)
+				f.addSecretAnnotations(findings)
+			}
+		}
+		if owner, ok := md.Owners[path]; ok {
+			pkg = owner
 		}
 		if err = pkg.AddFile(f); err != nil {
 			t.Done(fmt.Errorf("adding %s as file to the spdx package: %w", path, err))
@@ -1088,6 +1542,12 @@ func (di *spdxDefaultImplementation) PackageFromDirectory(opts *Options, dirPath
 		return nil, err
 	}
 
+	for _, component := range md.Packages {
+		if err := pkg.AddPackage(component); err != nil {
+			return nil, fmt.Errorf("adding %s to the spdx package: %w", component.Name, err)
+		}
+	}
+
 	// Add files into the package
 	return pkg, nil
 }
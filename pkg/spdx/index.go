@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import "sync/atomic"
+
+// graphGeneration counts the changes made to the elements of a document.
+// Elements indexed by a document point to its generation and bump it when
+// a relationship is added to them or they are renamed through SetSPDXID,
+// so the document knows its index may be stale. Changes to elements of
+// other documents leave the index alone.
+type graphGeneration struct {
+	atomic.Uint64
+}
+
+// sharedGeneration is bumped by the elements indexed by more than one
+// document, eg when documents are built from parts of others. All the
+// documents check it.
+var sharedGeneration graphGeneration
+
+// entityOf returns the entity of a package or file
+func entityOf(o Object) *Entity {
+	switch e := o.(type) {
+	case *Package:
+		return &e.Entity
+	case *File:
+		return &e.Entity
+	}
+	return nil
+}
+
+// bumpGeneration records a change to the element in the generation of
+// the document that indexed it, if any.
+func (e *Entity) bumpGeneration() {
+	if e.generation != nil {
+		e.generation.Add(1)
+	}
+}
+
+// InvalidateIndex discards the index of element IDs in the document, it
+// will be rebuilt on the next lookup. The index is kept up to date
+// when adding relationships with AddRelationship and elements with
+// AddPackage or AddFile. Code modifying the graph by other means (eg
+// editing the Relationships slices or the ID fields directly) must
+// call InvalidateIndex after the changes.
+func (d *Document) InvalidateIndex() {
+	d.mtx.Lock()
+	d.index = nil
+	d.mtx.Unlock()
+}
+
+// indexValid returns true if the ID index reflects the current graph.
+// Top level elements added to the document maps directly are detected
+// by comparing their number with the one recorded in the index.
+func (d *Document) indexValid() bool {
+	return d.index != nil &&
+		d.indexGeneration == d.generation.Load() &&
+		d.indexShared == sharedGeneration.Load() &&
+		d.indexRoots == len(d.Packages)+len(d.Files)
+}
+
+// rebuildIndex walks the whole document and indexes all its elements
+// by SPDX ID. When more than one element has the same ID, the first one
+// found is kept.
+func (d *Document) rebuildIndex() {
+	d.index = map[string]Object{}
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		d.indexObject(o)
+		return nil
+	})
+	d.indexGeneration = d.generation.Load()
+	d.indexShared = sharedGeneration.Load()
+	d.indexRoots = len(d.Packages) + len(d.Files)
+}
+
+// indexObject adds an element to the index, unless its ID is empty or
+// already taken, and links the element to the generation of the document.
+func (d *Document) indexObject(o Object) {
+	d.trackElement(o)
+	if o.SPDXID() == "" {
+		return
+	}
+	if _, ok := d.index[o.SPDXID()]; !ok {
+		d.index[o.SPDXID()] = o
+	}
+}
+
+// indexSubgraph adds an element and all the elements reachable from
+// it to a valid index. If the index is not valid, it is discarded to
+// get rebuilt on the next lookup.
+//
+// It must be called right after adding a top level element to the
+// document maps, the new element is not counted when checking the index.
+func (d *Document) indexSubgraph(o Object) {
+	if d.index == nil || d.indexGeneration != d.generation.Load() ||
+		d.indexShared != sharedGeneration.Load() ||
+		d.indexRoots != len(d.Packages)+len(d.Files)-1 {
+		d.index = nil
+		return
+	}
+	seen := map[Object]struct{}{}
+	var walk func(o Object)
+	walk = func(o Object) {
+		if _, ok := seen[o]; ok {
+			return
+		}
+		seen[o] = struct{}{}
+		d.indexObject(o)
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer != nil {
+				walk(rel.Peer)
+			}
+		}
+	}
+	walk(o)
+	d.indexRoots = len(d.Packages) + len(d.Files)
+}
+
+// trackElement points the element to the generation of the document, or
+// to the shared generation if another document already tracks it. Package
+// relationships may be added concurrently, so packages are locked while
+// linking them.
+func (d *Document) trackElement(o Object) {
+	e := entityOf(o)
+	if e == nil {
+		return
+	}
+	if p, ok := o.(*Package); ok {
+		p.Lock()
+		defer p.Unlock()
+	}
+	switch e.generation {
+	case &d.generation, &sharedGeneration:
+	case nil:
+		e.generation = &d.generation
+	default:
+		e.generation = &sharedGeneration
+	}
+}
+
+// lookupID returns the element indexed with an ID, rebuilding the index
+// if needed.
+func (d *Document) lookupID(id string) Object {
+	if !d.indexValid() {
+		d.rebuildIndex()
+	}
+	o, ok := d.index[id]
+	if ok && o.SPDXID() != id {
+		// The element was renamed by setting its ID directly
+		d.rebuildIndex()
+		o, ok = d.index[id]
+	}
+	if !ok {
+		return nil
+	}
+	return o
+}
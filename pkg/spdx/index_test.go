@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementIndex(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "test"
+	pkg := NewPackage()
+	pkg.ID = "SPDXRef-Package-top"
+	require.NoError(t, doc.AddPackage(pkg))
+	require.Equal(t, pkg, doc.GetElementByID("SPDXRef-Package-top"))
+
+	// Elements attached after the package was added are found
+	sub := NewPackage()
+	sub.ID = "SPDXRef-Package-sub"
+	require.NoError(t, pkg.AddPackage(sub))
+	require.Equal(t, sub, doc.GetElementByID("SPDXRef-Package-sub"))
+
+	// Renaming elements directly does not return stale results
+	sub.ID = "SPDXRef-Package-renamed"
+	require.Nil(t, doc.GetElementByID("SPDXRef-Package-sub"))
+	require.Equal(t, sub, doc.GetElementByID("SPDXRef-Package-renamed"))
+
+	// Files added in sequence get unique IDs
+	for range 3 {
+		f := NewFile()
+		f.ID = "SPDXRef-File-test"
+		f.Name = "test.txt"
+		require.NoError(t, doc.AddFile(f))
+	}
+	require.Len(t, doc.Files, 3)
+	require.Contains(t, doc.Files, "SPDXRef-File-test-0002")
+
+	// Top level elements set directly in the maps are found too
+	direct := NewPackage()
+	direct.ID = "SPDXRef-Package-direct"
+	doc.Packages[direct.ID] = direct
+	require.Equal(t, direct, doc.lookupID("SPDXRef-Package-direct"))
+
+	// Removed relationships are dropped from the index after invalidating
+	pkg.Relationships = nil
+	doc.InvalidateIndex()
+	require.Nil(t, doc.lookupID("SPDXRef-Package-renamed"))
+}
+
+func TestElementIndexGeneration(t *testing.T) {
+	doc := NewDocument()
+	top := NewPackage()
+	top.ID = "SPDXRef-Package-top"
+	require.NoError(t, doc.AddPackage(top))
+	require.NotNil(t, doc.lookupID("SPDXRef-Package-top"))
+	require.True(t, doc.indexValid())
+
+	// Changes to the elements of other documents keep the index
+	other := NewDocument()
+	otherTop := NewPackage()
+	otherTop.ID = "SPDXRef-Package-other"
+	require.NoError(t, other.AddPackage(otherTop))
+	require.NotNil(t, other.lookupID("SPDXRef-Package-other"))
+	otherTop.AddRelationship(&Relationship{Peer: NewPackage(), Type: CONTAINS})
+	otherTop.SetSPDXID("SPDXRef-Package-other-renamed")
+	require.True(t, doc.indexValid())
+
+	// A relationship added between lookups is seen by the next one
+	first := NewPackage()
+	first.ID = "SPDXRef-Package-sub"
+	doc.ensureUniqueElementID(first)
+	require.Equal(t, "SPDXRef-Package-sub", first.ID)
+	require.NoError(t, top.AddPackage(first))
+	require.False(t, doc.indexValid())
+
+	second := NewPackage()
+	second.ID = "SPDXRef-Package-sub"
+	doc.ensureUniqueElementID(second)
+	require.Equal(t, "SPDXRef-Package-sub-0001", second.ID)
+
+	// Relationships appended directly are found searching the graph
+	direct := NewPackage()
+	direct.ID = "SPDXRef-Package-direct"
+	first.Relationships = append(first.Relationships, &Relationship{Peer: direct, Type: CONTAINS})
+	third := NewPackage()
+	third.ID = "SPDXRef-Package-direct"
+	doc.ensureUniqueElementID(third)
+	require.Equal(t, "SPDXRef-Package-direct-0001", third.ID)
+
+	// Elements shared by two documents update both
+	shared := NewDocument()
+	require.NoError(t, shared.AddPackage(top))
+	require.NotNil(t, shared.lookupID("SPDXRef-Package-sub"))
+	require.NotNil(t, doc.lookupID("SPDXRef-Package-sub"))
+	top.AddRelationship(&Relationship{Peer: third, Type: CONTAINS})
+	require.False(t, doc.indexValid())
+	require.False(t, shared.indexValid())
+	require.Equal(t, third, doc.lookupID("SPDXRef-Package-direct-0001"))
+	require.Equal(t, third, shared.lookupID("SPDXRef-Package-direct-0001"))
+}
+
+// benchmarkDocument returns a document with n packages containing
+// one file each, grouped under 100 top level packages.
+func benchmarkDocument(b *testing.B, n int) *Document {
+	b.Helper()
+	doc := NewDocument()
+	doc.Name = "benchmark"
+	tops := []*Package{}
+	for i := range 100 {
+		p := NewPackage()
+		p.ID = fmt.Sprintf("SPDXRef-Package-top-%d", i)
+		tops = append(tops, p)
+	}
+	for i := range n / 2 {
+		p := NewPackage()
+		p.ID = fmt.Sprintf("SPDXRef-Package-%d", i)
+		f := NewFile()
+		f.ID = fmt.Sprintf("SPDXRef-File-%d", i)
+		p.AddRelationship(&Relationship{Peer: f, Type: CONTAINS, FullRender: true})
+		tops[i%len(tops)].AddRelationship(&Relationship{Peer: p, Type: CONTAINS, FullRender: true})
+	}
+	for _, p := range tops {
+		if err := doc.AddPackage(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return doc
+}
+
+func BenchmarkGetElementByID(b *testing.B) {
+	for _, size := range []int{1_000, 100_000, 1_000_000} {
+		b.Run(fmt.Sprintf("elements=%d", size), func(b *testing.B) {
+			doc := benchmarkDocument(b, size)
+			b.ResetTimer()
+			for i := range b.N {
+				id := fmt.Sprintf("SPDXRef-File-%d", i%(size/2))
+				if doc.GetElementByID(id) == nil {
+					b.Fatalf("element %s not found", id)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAddFile(b *testing.B) {
+	for _, size := range []int{1_000, 100_000, 1_000_000} {
+		b.Run(fmt.Sprintf("elements=%d", size), func(b *testing.B) {
+			doc := benchmarkDocument(b, size)
+			b.ResetTimer()
+			for i := range b.N {
+				f := NewFile()
+				f.ID = fmt.Sprintf("SPDXRef-File-new-%d", i)
+				if err := doc.AddFile(f); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
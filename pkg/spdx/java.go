@@ -0,0 +1,355 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+const (
+	javaManifestPath = "META-INF/MANIFEST.MF"
+	javaMavenDir     = "META-INF/maven/"
+
+	// maxJavaArchiveDepth limits how deep nested archives are read
+	maxJavaArchiveDepth = 4
+
+	// maxJavaArchiveSize is the largest nested archive read into memory
+	maxJavaArchiveSize = 256 * 1024 * 1024
+)
+
+// javaArchiveExts are the extensions of the java archive formats
+var javaArchiveExts = []string{".jar", ".war", ".ear"}
+
+// mavenCoordinates identify an artifact in a maven repository
+type mavenCoordinates struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+// purl returns the maven purl of the artifact.
+func (c *mavenCoordinates) purl() string {
+	return purl.NewPackageURL(
+		purl.TypeMaven, c.GroupID, c.ArtifactID, c.Version, nil, "",
+	).ToString()
+}
+
+// isJavaArchivePath returns true if the path has a java archive extension.
+func isJavaArchivePath(filePath string) bool {
+	return slices.Contains(javaArchiveExts, strings.ToLower(path.Ext(filePath)))
+}
+
+// readJavaArchiveFile reads the java archive at archivePath and records
+// its data in pkg. See readJavaArchive.
+func readJavaArchiveFile(pkg *Package, archivePath string, log *DegradationLog) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("opening java archive: %w", err)
+	}
+	defer zr.Close()
+	return readJavaArchive(pkg, &zr.Reader, 0, log)
+}
+
+// readJavaArchiveBytes reads a java archive from memory and records its
+// data in pkg. See readJavaArchive.
+func readJavaArchiveBytes(pkg *Package, data []byte, depth int, log *DegradationLog) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("opening java archive: %w", err)
+	}
+	return readJavaArchive(pkg, zr, depth, log)
+}
+
+// readJavaArchiveFromReader reads a java archive of unknown size from r.
+func readJavaArchiveFromReader(pkg *Package, r io.Reader, log *DegradationLog) error {
+	data, err := io.ReadAll(io.LimitReader(r, maxJavaArchiveSize+1))
+	if err != nil {
+		return fmt.Errorf("reading java archive: %w", err)
+	}
+	if len(data) > maxJavaArchiveSize {
+		return fmt.Errorf("java archive is larger than %d bytes", maxJavaArchiveSize)
+	}
+	return readJavaArchiveBytes(pkg, data, 0, log)
+}
+
+// readJavaArchive identifies a java archive from its metadata. The maven
+// coordinates are read from the pom.properties files embedded by maven,
+// falling back to the archive manifest. When an archive embeds more than
+// one pom.properties (eg shaded jars), the artifacts that are not the
+// archive itself are added as subpackages. Archives nested in the jar
+// (such as the libraries in a war) are read recursively and also added
+// as subpackages. The nested archives that can't be read are recorded in
+// the degradation log.
+func readJavaArchive(pkg *Package, zr *zip.Reader, depth int, log *DegradationLog) error {
+	manifest := map[string]string{}
+	poms := []mavenCoordinates{}
+	nested := []*zip.File{}
+
+	for _, zf := range zr.File {
+		switch {
+		case zf.Name == javaManifestPath:
+			m, err := readZipEntry(zf, parseJavaManifest)
+			if err != nil {
+				return fmt.Errorf("reading java manifest: %w", err)
+			}
+			manifest = m
+		case strings.HasPrefix(zf.Name, javaMavenDir) && path.Base(zf.Name) == "pom.properties":
+			props, err := readZipEntry(zf, parseJavaProperties)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", zf.Name, err)
+			}
+			c := mavenCoordinates{
+				GroupID: props["groupId"], ArtifactID: props["artifactId"], Version: props["version"],
+			}
+			if c.ArtifactID != "" && c.Version != "" {
+				poms = append(poms, c)
+			}
+		case !zf.FileInfo().IsDir() && isJavaArchivePath(zf.Name):
+			nested = append(nested, zf)
+		}
+	}
+	sort.Slice(poms, func(i, j int) bool { return poms[i].purl() < poms[j].purl() })
+
+	// Find which of the embedded artifacts is the archive itself
+	self := -1
+	if len(poms) == 1 {
+		self = 0
+	} else {
+		for i := range poms {
+			if poms[i].ArtifactID == manifest["Implementation-Title"] ||
+				strings.HasPrefix(path.Base(pkg.FileName), poms[i].ArtifactID+"-"+poms[i].Version) {
+				self = i
+				break
+			}
+		}
+	}
+
+	if self >= 0 {
+		pkg.Name = poms[self].ArtifactID
+		pkg.Version = poms[self].Version
+		pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  poms[self].purl(),
+		})
+	} else {
+		if name := firstNonEmpty(manifest["Implementation-Title"], manifest["Bundle-SymbolicName"]); name != "" {
+			// Bundle names may carry directives after a semicolon
+			pkg.Name, _, _ = strings.Cut(name, ";")
+		}
+		if version := firstNonEmpty(manifest["Implementation-Version"], manifest["Bundle-Version"]); version != "" {
+			pkg.Version = version
+		}
+	}
+	if summary := manifest["Bundle-Description"]; summary != "" && pkg.Summary == "" {
+		pkg.Summary = summary
+	}
+	if homepage := manifest["Bundle-DocURL"]; homepage != "" && pkg.HomePage == "" {
+		pkg.HomePage = homepage
+	}
+	if vendor := firstNonEmpty(manifest["Implementation-Vendor"], manifest["Bundle-Vendor"]); vendor != "" &&
+		pkg.Supplier.Organization == "" && pkg.Supplier.Person == "" {
+		pkg.Supplier.Organization = vendor
+	}
+
+	for i := range poms {
+		if i == self {
+			continue
+		}
+		subpkg := NewPackage()
+		subpkg.Name = poms[i].ArtifactID
+		subpkg.Version = poms[i].Version
+		subpkg.PrimaryPurpose = "LIBRARY"
+		subpkg.Comment = "Maven artifact embedded in " + pkg.Name
+		subpkg.ExternalRefs = append(subpkg.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  poms[i].purl(),
+		})
+		subpkg.BuildID(pkg.ID, poms[i].purl())
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding embedded maven artifact: %w", err)
+		}
+	}
+
+	if depth >= maxJavaArchiveDepth {
+		if len(nested) > 0 {
+			log.record(DegradationScannerFallback, "Not reading %d archives nested too deep in %s", len(nested), pkg.Name)
+		}
+		return nil
+	}
+	for _, zf := range nested {
+		if zf.UncompressedSize64 > maxJavaArchiveSize {
+			log.record(DegradationScannerFallback, "Skipping nested java archive %s, it is too large", zf.Name)
+			continue
+		}
+		data, err := readZipEntry(zf, io.ReadAll)
+		if err != nil {
+			return fmt.Errorf("reading nested archive %s: %w", zf.Name, err)
+		}
+		subpkg := NewPackage()
+		subpkg.Name = path.Base(zf.Name)
+		subpkg.FileName = zf.Name
+		subpkg.PrimaryPurpose = "LIBRARY"
+		subpkg.BuildID(pkg.ID, zf.Name)
+		if err := readJavaArchiveBytes(subpkg, data, depth+1, log); err != nil {
+			log.record(DegradationScannerFallback, "Unable to read nested java archive %s: %v", zf.Name, err)
+			continue
+		}
+		subpkg.Comment = "Java archive nested in " + pkg.Name
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding nested java archive: %w", err)
+		}
+	}
+	return nil
+}
+
+// javaPackageFromArchiveFile builds a package from a java archive found
+// at archivePath. The file name is used as package name when the archive
+// metadata does not identify it.
+func javaPackageFromArchiveFile(archivePath, seed string, log *DegradationLog) (*Package, error) {
+	pkg := NewPackage()
+	pkg.Name = filepath.Base(archivePath)
+	pkg.FileName = filepath.Base(archivePath)
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.BuildID("java", seed)
+	if err := readJavaArchiveFile(pkg, archivePath, log); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// addJavaArchivePackages searches a directory for java archives and adds
+// a package for each one found to pkg.
+func addJavaArchivePackages(pkg *Package, dirPath string, log *DegradationLog) error {
+	archives := []string{}
+	if err := filepath.WalkDir(dirPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && isJavaArchivePath(p) {
+			archives = append(archives, p)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("searching java archives: %w", err)
+	}
+
+	for _, p := range archives {
+		rel, err := filepath.Rel(dirPath, p)
+		if err != nil {
+			return fmt.Errorf("computing relative path: %w", err)
+		}
+		subpkg, err := javaPackageFromArchiveFile(p, pkg.ID+"/"+filepath.ToSlash(rel), log)
+		if err != nil {
+			log.record(DegradationScannerFallback, "Unable to read java archive %s: %v", rel, err)
+			continue
+		}
+		subpkg.Comment = "Java archive found at " + filepath.ToSlash(rel)
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return fmt.Errorf("adding java archive package: %w", err)
+		}
+	}
+	return nil
+}
+
+// readZipEntry opens a file in a zip archive and passes its reader to fn.
+func readZipEntry[T any](zf *zip.File, fn func(io.Reader) (T, error)) (T, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("opening %s: %w", zf.Name, err)
+	}
+	defer rc.Close()
+	return fn(rc)
+}
+
+// parseJavaManifest reads the main section of a jar manifest.
+func parseJavaManifest(r io.Reader) (map[string]string, error) {
+	attrs := map[string]string{}
+	key := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			// The main section ends at the first blank line
+			break
+		}
+		// Long values continue in lines starting with a space
+		if strings.HasPrefix(line, " ") {
+			if key != "" {
+				attrs[key] += line[1:]
+			}
+			continue
+		}
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(k)
+		attrs[key] = strings.TrimSpace(v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning manifest: %w", err)
+	}
+	return attrs, nil
+}
+
+// parseJavaProperties reads the key=value pairs in a properties file.
+func parseJavaProperties(r io.Reader) (map[string]string, error) {
+	props := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			k, v, ok = strings.Cut(line, ":")
+		}
+		if ok {
+			props[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning properties: %w", err)
+	}
+	return props, nil
+}
+
+// firstNonEmpty returns the first of its arguments that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
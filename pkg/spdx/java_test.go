@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func testJavaArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func testPomProperties(group, artifact, version string) []byte {
+	return []byte("#Created by Apache Maven\ngroupId=" + group + "\nartifactId=" + artifact + "\nversion=" + version + "\n")
+}
+
+func TestReadJavaArchive(t *testing.T) {
+	// A library with no maven metadata, identified from its manifest
+	lib := testJavaArchive(t, map[string][]byte{
+		javaManifestPath: []byte("Manifest-Version: 1.0\r\nImplementation-Title: tiny-lib\r\nImplementation-Version: 0.3\r\nImplementation-Vendor: Example\r\nBundle-Description: A tiny library\r\nBundle-DocURL: https://example.com/tiny-lib\r\n\r\nName: other\r\nImplementation-Title: nope\r\n"),
+	})
+
+	// A shaded application jar embedding guava and the library above
+	app := testJavaArchive(t, map[string][]byte{
+		javaManifestPath: []byte("Manifest-Version: 1.0\nImplementation-Title: my-app\n"),
+		"META-INF/maven/com.example/my-app/pom.properties":     testPomProperties("com.example", "my-app", "1.2.0"),
+		"META-INF/maven/com.google.guava/guava/pom.properties": testPomProperties("com.google.guava", "guava", "33.0.0-jre"),
+		"BOOT-INF/lib/tiny-lib.jar":                            lib,
+		"com/example/App.class":                                []byte("class"),
+	})
+
+	dir := t.TempDir()
+	appPath := filepath.Join(dir, "lib", "my-app.jar")
+	require.NoError(t, os.MkdirAll(filepath.Dir(appPath), os.FileMode(0o755)))
+	require.NoError(t, os.WriteFile(appPath, app, os.FileMode(0o644)))
+
+	pkg := NewPackage()
+	pkg.Name = "dist"
+	pkg.BuildID("dist")
+	require.NoError(t, addJavaArchivePackages(pkg, dir, nil))
+	require.Len(t, pkg.Relationships, 1)
+
+	appPkg, ok := pkg.Relationships[0].Peer.(*Package)
+	require.True(t, ok)
+	require.Equal(t, "my-app", appPkg.Name)
+	require.Equal(t, "1.2.0", appPkg.Version)
+	require.Equal(t, "pkg:maven/com.example/my-app@1.2.0", appPkg.Purl().String())
+	require.Equal(t, "Java archive found at lib/my-app.jar", appPkg.Comment)
+
+	found := map[string]*Package{}
+	for _, rel := range appPkg.Relationships {
+		p, ok := rel.Peer.(*Package)
+		require.True(t, ok)
+		found[p.Name] = p
+	}
+	require.Len(t, found, 2)
+	require.Equal(t, "pkg:maven/com.google.guava/guava@33.0.0-jre", found["guava"].Purl().String())
+	require.NotNil(t, found["tiny-lib"])
+	require.Equal(t, "0.3", found["tiny-lib"].Version)
+	require.Equal(t, "Example", found["tiny-lib"].Supplier.Organization)
+	require.Equal(t, "A tiny library", found["tiny-lib"].Summary)
+	require.Equal(t, "https://example.com/tiny-lib", found["tiny-lib"].HomePage)
+	require.Nil(t, found["tiny-lib"].Purl())
+}
+
+func TestJavaHandler(t *testing.T) {
+	h := &javaHandler{}
+	layer := spdxtest.WriteLayer(t, map[string]string{
+		"opt/app/app.war": string(testJavaArchive(t, map[string][]byte{
+			"META-INF/maven/org.example/shop/pom.properties": testPomProperties("org.example", "shop", "2.0"),
+		})),
+		"opt/app/README": "hello",
+	})
+
+	can, err := h.CanHandle(layer)
+	require.NoError(t, err)
+	require.True(t, can)
+
+	pkg := NewPackage()
+	pkg.BuildID("layer")
+	require.NoError(t, h.ReadPackageData(layer, pkg))
+	require.Len(t, pkg.Relationships, 1)
+	war, ok := pkg.Relationships[0].Peer.(*Package)
+	require.True(t, ok)
+	require.Equal(t, "shop", war.Name)
+	require.Equal(t, "pkg:maven/org.example/shop@2.0", war.Purl().String())
+	require.Equal(t, "Java archive found at /opt/app/app.war", war.Comment)
+}
@@ -28,12 +28,17 @@ type Document interface {
 	GetRelationships() []Relationship
 	GetDocumentDescribes() []string
 	GetExternalDocumentRefs() []ExternalDocumentRef
+	GetComment() string
+	GetExtractedLicensingInfos() []ExtractedLicensingInfo
+	GetAnnotations() []Annotation
+	GetSnippets() []Snippet
 }
 
 type CreationInfo interface {
 	GetCreators() []string
 	GetLicenseListVersion() string
 	GetCreated() string
+	GetComment() string
 }
 
 type File interface {
@@ -43,12 +48,19 @@ type File interface {
 	GetLicenseConcluded() string
 	GetLicenseInfoInFile() []string
 	GetChecksums() []Checksum
+	GetComment() string
+	GetNoticeText() string
+	GetLicenseComments() string
+	GetFileTypes() []string
+	GetAttributionTexts() []string
+	GetAnnotations() []Annotation
 }
 
 type Relationship interface {
 	GetElement() string
 	GetType() string
 	GetRelated() string
+	GetComment() string
 }
 
 type ExternalDocumentRef interface {
@@ -68,8 +80,22 @@ type Package interface {
 	GetVersion() string
 	GetVerificationCode() PackageVerificationCode
 	GetPrimaryPurpose() string
+	GetReleaseDate() string
+	GetBuiltDate() string
+	GetValidUntilDate() string
 	GetChecksums() []Checksum
 	GetExternalRefs() []ExternalRef
+	GetSupplier() string
+	GetOriginator() string
+	GetHomePage() string
+	GetLicenseComments() string
+	GetLicenseInfoFromFiles() []string
+	GetDescription() string
+	GetSummary() string
+	GetComment() string
+	GetSourceInfo() string
+	GetAttributionTexts() []string
+	GetAnnotations() []Annotation
 }
 
 type PackageVerificationCode interface {
@@ -86,3 +112,31 @@ type ExternalRef interface {
 	GetLocator() string
 	GetType() string
 }
+
+type ExtractedLicensingInfo interface {
+	GetLicenseID() string
+	GetExtractedText() string
+	GetName() string
+	GetComment() string
+	GetSeeAlsos() []string
+}
+
+type Annotation interface {
+	GetAnnotator() string
+	GetDate() string
+	GetType() string
+	GetComment() string
+}
+
+type Snippet interface {
+	GetID() string
+	GetName() string
+	GetComment() string
+	GetCopyrightText() string
+	GetLicenseConcluded() string
+	GetLicenseComments() string
+	GetLicenseInfoInSnippets() []string
+	GetFromFile() string
+	GetByteRange() (start, end int)
+	GetLineRange() (start, end int)
+}
@@ -35,6 +35,11 @@ type Document struct {
 	Packages             []Package             `json:"packages"`
 	Relationships        []Relationship        `json:"relationships"`
 	ExternalDocumentRefs []ExternalDocumentRef `json:"externalDocumentRefs,omitempty"`
+	Comment              string                `json:"comment,omitempty"`
+
+	HasExtractedLicensingInfos []ExtractedLicensingInfo `json:"hasExtractedLicensingInfos,omitempty"`
+	Annotations                []Annotation             `json:"annotations,omitempty"`
+	Snippets                   []Snippet                `json:"snippets,omitempty"`
 }
 
 func (d *Document) GetVersion() string                     { return d.Version }
@@ -44,6 +49,7 @@ func (d *Document) GetName() string                        { return d.Name }
 func (d *Document) GetNamespace() string                   { return d.Namespace }
 func (d *Document) GetCreationInfo() document.CreationInfo { return &d.CreationInfo }
 func (d *Document) GetDocumentDescribes() []string         { return d.DocumentDescribes }
+func (d *Document) GetComment() string                     { return d.Comment }
 
 func (d *Document) GetPackages() []document.Package {
 	packages := make([]document.Package, len(d.Packages))
@@ -77,15 +83,37 @@ func (d *Document) GetExternalDocumentRefs() []document.ExternalDocumentRef {
 	return externalDocumentRefs
 }
 
+func (d *Document) GetExtractedLicensingInfos() []document.ExtractedLicensingInfo {
+	infos := make([]document.ExtractedLicensingInfo, len(d.HasExtractedLicensingInfos))
+	for i := range d.HasExtractedLicensingInfos {
+		infos[i] = &d.HasExtractedLicensingInfos[i]
+	}
+	return infos
+}
+
+func (d *Document) GetAnnotations() []document.Annotation {
+	return annotationList(d.Annotations)
+}
+
+func (d *Document) GetSnippets() []document.Snippet {
+	snippets := make([]document.Snippet, len(d.Snippets))
+	for i := range d.Snippets {
+		snippets[i] = &d.Snippets[i]
+	}
+	return snippets
+}
+
 type CreationInfo struct {
 	Created            string   `json:"created"` // Date
 	Creators           []string `json:"creators"`
 	LicenseListVersion string   `json:"licenseListVersion,omitempty"`
+	Comment            string   `json:"comment,omitempty"`
 }
 
 func (c *CreationInfo) GetCreators() []string         { return c.Creators }
 func (c *CreationInfo) GetLicenseListVersion() string { return c.LicenseListVersion }
 func (c *CreationInfo) GetCreated() string            { return c.Created }
+func (c *CreationInfo) GetComment() string            { return c.Comment }
 
 type Package struct {
 	ID                   string                   `json:"SPDXID"`
@@ -95,6 +123,7 @@ type Package struct {
 	LicenseDeclared      string                   `json:"licenseDeclared"`
 	LicenseConcluded     string                   `json:"licenseConcluded"`
 	Description          string                   `json:"description,omitempty"`
+	Comment              string                   `json:"comment,omitempty"`
 	DownloadLocation     string                   `json:"downloadLocation"`
 	Originator           string                   `json:"originator,omitempty"`
 	Supplier             string                   `json:"supplier,omitempty"`
@@ -105,6 +134,11 @@ type Package struct {
 	Checksums            []Checksum               `json:"checksums"`
 	ExternalRefs         []ExternalRef            `json:"externalRefs,omitempty"`
 	VerificationCode     *PackageVerificationCode `json:"packageVerificationCode,omitempty"`
+	HomePage             string                   `json:"homepage,omitempty"`
+	LicenseComments      string                   `json:"licenseComments,omitempty"`
+	Summary              string                   `json:"summary,omitempty"`
+	AttributionTexts     []string                 `json:"attributionTexts,omitempty"`
+	Annotations          []Annotation             `json:"annotations,omitempty"`
 }
 
 func (p *Package) GetID() string               { return p.ID }
@@ -116,8 +150,21 @@ func (p *Package) GetFilesAnalyzed() bool      { return p.FilesAnalyzed }
 func (p *Package) GetLicenseDeclared() string  { return p.LicenseDeclared }
 func (p *Package) GetVersion() string          { return p.Version }
 func (p *Package) GetPrimaryPurpose() string   { return "" }
+func (p *Package) GetReleaseDate() string      { return "" }
+func (p *Package) GetBuiltDate() string        { return "" }
+func (p *Package) GetValidUntilDate() string   { return "" }
 func (p *Package) GetSupplier() string         { return p.Supplier }
 func (p *Package) GetOriginator() string       { return p.Originator }
+func (p *Package) GetHomePage() string         { return p.HomePage }
+func (p *Package) GetLicenseComments() string  { return p.LicenseComments }
+func (p *Package) GetDescription() string      { return p.Description }
+func (p *Package) GetSummary() string          { return p.Summary }
+func (p *Package) GetComment() string          { return p.Comment }
+func (p *Package) GetSourceInfo() string       { return p.SourceInfo }
+
+func (p *Package) GetLicenseInfoFromFiles() []string     { return p.LicenseInfoFromFiles }
+func (p *Package) GetAttributionTexts() []string         { return p.AttributionTexts }
+func (p *Package) GetAnnotations() []document.Annotation { return annotationList(p.Annotations) }
 
 func (p *Package) GetVerificationCode() document.PackageVerificationCode {
 	if p.VerificationCode == nil {
@@ -150,15 +197,19 @@ type PackageVerificationCode struct {
 func (p *PackageVerificationCode) GetValue() string { return p.Value }
 
 type File struct {
-	ID                string     `json:"SPDXID"`
-	Name              string     `json:"fileName"`
-	CopyrightText     string     `json:"copyrightText"`
-	NoticeText        string     `json:"noticeText,omitempty"`
-	LicenseConcluded  string     `json:"licenseConcluded"`
-	Description       string     `json:"description,omitempty"`
-	FileTypes         []string   `json:"fileTypes,omitempty"`
-	LicenseInfoInFile []string   `json:"licenseInfoInFiles"` // List of licenses
-	Checksums         []Checksum `json:"checksums"`
+	ID                string       `json:"SPDXID"`
+	Name              string       `json:"fileName"`
+	CopyrightText     string       `json:"copyrightText"`
+	NoticeText        string       `json:"noticeText,omitempty"`
+	LicenseConcluded  string       `json:"licenseConcluded"`
+	Description       string       `json:"description,omitempty"`
+	FileTypes         []string     `json:"fileTypes,omitempty"`
+	LicenseInfoInFile []string     `json:"licenseInfoInFiles"` // List of licenses
+	Checksums         []Checksum   `json:"checksums"`
+	Comment           string       `json:"comment,omitempty"`
+	LicenseComments   string       `json:"licenseComments,omitempty"`
+	AttributionTexts  []string     `json:"attributionTexts,omitempty"`
+	Annotations       []Annotation `json:"annotations,omitempty"`
 }
 
 func (f *File) GetID() string                  { return f.ID }
@@ -166,6 +217,13 @@ func (f *File) GetName() string                { return f.Name }
 func (f *File) GetLicenseConcluded() string    { return f.LicenseConcluded }
 func (f *File) GetLicenseInfoInFile() []string { return f.LicenseInfoInFile }
 func (f *File) GetCopyrightText() string       { return f.CopyrightText }
+func (f *File) GetComment() string             { return f.Comment }
+func (f *File) GetNoticeText() string          { return f.NoticeText }
+func (f *File) GetLicenseComments() string     { return f.LicenseComments }
+func (f *File) GetFileTypes() []string         { return f.FileTypes }
+func (f *File) GetAttributionTexts() []string  { return f.AttributionTexts }
+
+func (f *File) GetAnnotations() []document.Annotation { return annotationList(f.Annotations) }
 
 func (f *File) GetChecksums() []document.Checksum {
 	checksums := make([]document.Checksum, len(f.Checksums))
@@ -207,8 +265,96 @@ type Relationship struct {
 	Element string `json:"spdxElementId"`
 	Type    string `json:"relationshipType"`
 	Related string `json:"relatedSpdxElement"`
+	Comment string `json:"comment,omitempty"`
 }
 
 func (r *Relationship) GetElement() string { return r.Element }
 func (r *Relationship) GetType() string    { return r.Type }
 func (r *Relationship) GetRelated() string { return r.Related }
+func (r *Relationship) GetComment() string { return r.Comment }
+
+type ExtractedLicensingInfo struct {
+	LicenseID     string   `json:"licenseId"`
+	ExtractedText string   `json:"extractedText"`
+	Name          string   `json:"name,omitempty"`
+	Comment       string   `json:"comment,omitempty"`
+	SeeAlsos      []string `json:"seeAlsos,omitempty"`
+}
+
+func (e *ExtractedLicensingInfo) GetLicenseID() string     { return e.LicenseID }
+func (e *ExtractedLicensingInfo) GetExtractedText() string { return e.ExtractedText }
+func (e *ExtractedLicensingInfo) GetName() string          { return e.Name }
+func (e *ExtractedLicensingInfo) GetComment() string       { return e.Comment }
+func (e *ExtractedLicensingInfo) GetSeeAlsos() []string    { return e.SeeAlsos }
+
+type Annotation struct {
+	Annotator string `json:"annotator"`
+	Date      string `json:"annotationDate"`
+	Type      string `json:"annotationType"`
+	Comment   string `json:"comment"`
+}
+
+func (a *Annotation) GetAnnotator() string { return a.Annotator }
+func (a *Annotation) GetDate() string      { return a.Date }
+func (a *Annotation) GetType() string      { return a.Type }
+func (a *Annotation) GetComment() string   { return a.Comment }
+
+func annotationList(annotations []Annotation) []document.Annotation {
+	list := make([]document.Annotation, len(annotations))
+	for i := range annotations {
+		list[i] = &annotations[i]
+	}
+	return list
+}
+
+type Snippet struct {
+	ID                    string         `json:"SPDXID"`
+	Name                  string         `json:"name,omitempty"`
+	Comment               string         `json:"comment,omitempty"`
+	CopyrightText         string         `json:"copyrightText,omitempty"`
+	LicenseConcluded      string         `json:"licenseConcluded,omitempty"`
+	LicenseComments       string         `json:"licenseComments,omitempty"`
+	LicenseInfoInSnippets []string       `json:"licenseInfoInSnippets,omitempty"`
+	FromFile              string         `json:"snippetFromFile"`
+	Ranges                []SnippetRange `json:"ranges"`
+}
+
+func (s *Snippet) GetID() string                      { return s.ID }
+func (s *Snippet) GetName() string                    { return s.Name }
+func (s *Snippet) GetComment() string                 { return s.Comment }
+func (s *Snippet) GetCopyrightText() string           { return s.CopyrightText }
+func (s *Snippet) GetLicenseConcluded() string        { return s.LicenseConcluded }
+func (s *Snippet) GetLicenseComments() string         { return s.LicenseComments }
+func (s *Snippet) GetLicenseInfoInSnippets() []string { return s.LicenseInfoInSnippets }
+func (s *Snippet) GetFromFile() string                { return s.FromFile }
+
+// GetByteRange returns the first and last bytes of the snippet in the file.
+func (s *Snippet) GetByteRange() (start, end int) {
+	for _, r := range s.Ranges {
+		if r.StartPointer.Offset != 0 {
+			return r.StartPointer.Offset, r.EndPointer.Offset
+		}
+	}
+	return 0, 0
+}
+
+// GetLineRange returns the first and last lines of the snippet in the file.
+func (s *Snippet) GetLineRange() (start, end int) {
+	for _, r := range s.Ranges {
+		if r.StartPointer.LineNumber != 0 {
+			return r.StartPointer.LineNumber, r.EndPointer.LineNumber
+		}
+	}
+	return 0, 0
+}
+
+type SnippetRange struct {
+	StartPointer SnippetPointer `json:"startPointer"`
+	EndPointer   SnippetPointer `json:"endPointer"`
+}
+
+type SnippetPointer struct {
+	Reference  string `json:"reference"`
+	Offset     int    `json:"offset,omitempty"`
+	LineNumber int    `json:"lineNumber,omitempty"`
+}
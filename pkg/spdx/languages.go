@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// Language is a software ecosystem bom can detect in a directory.
+type Language string
+
+const (
+	LangGo     Language = "go"
+	LangPython Language = "python"
+	LangNode   Language = "node"
+	LangRust   Language = "rust"
+	LangJava   Language = "java"
+	LangBazel  Language = "bazel"
+	LangCMake  Language = "cmake"
+)
+
+// SupportedLanguages lists the ecosystems bom can analyze to add their
+// dependencies to the SBOM. Python, node and java packages are read from
+// the packages installed in images and from java archives, and the
+// dependencies declared in the manifests of the projects of python,
+// node, java and rust are listed.
+var SupportedLanguages = []Language{LangGo, LangPython, LangNode, LangRust, LangJava, LangBazel, LangCMake}
+
+// languageManifests maps the languages to the files that signal their
+// presence in a directory.
+var languageManifests = map[Language][]string{
+	LangGo:     {GoModFileName},
+	LangPython: {"requirements.txt", "pyproject.toml", "setup.py", "Pipfile"},
+	LangNode:   {"package.json"},
+	LangRust:   {"Cargo.toml"},
+	LangJava:   {"pom.xml", "build.gradle", "build.gradle.kts"},
+	LangBazel:  {BazelModuleFileName, "WORKSPACE", "WORKSPACE.bazel"},
+	LangCMake:  {CMakeListsFileName},
+}
+
+// skipManifestDirs are directories never searched when looking for
+// nested projects.
+var skipManifestDirs = []string{"vendor", "node_modules", "testdata", "third_party"}
+
+// LanguageReport lists the ecosystems detected in a directory.
+type LanguageReport struct {
+	Directory string
+	Detected  []Language
+}
+
+// Supported returns true if bom can analyze the language.
+func (l Language) Supported() bool {
+	return slices.Contains(SupportedLanguages, l)
+}
+
+// processesLanguage returns true if the options enable the analysis of
+// a language ecosystem.
+func (o *Options) processesLanguage(l Language) bool {
+	switch l {
+	case LangGo:
+		return o.ProcessGoModules
+	case LangPython:
+		return o.ProcessPython
+	case LangNode:
+		return o.ProcessNode
+	case LangJava:
+		return o.ProcessJava
+	case LangRust:
+		return o.ProcessRust
+	case LangBazel:
+		return o.ProcessBazel
+	case LangCMake:
+		return o.ProcessCMake
+	}
+	return false
+}
+
+// ParseLanguages parses a list of language names, failing if any of them
+// is not supported by bom.
+func ParseLanguages(names []string) ([]Language, error) {
+	langs := []Language{}
+	for _, name := range names {
+		l := Language(strings.ToLower(strings.TrimSpace(name)))
+		if !l.Supported() {
+			return nil, fmt.Errorf(
+				"language %q is not supported, valid values are: %v", name, SupportedLanguages,
+			)
+		}
+		langs = append(langs, l)
+	}
+	return langs, nil
+}
+
+// DetectLanguages looks for manifest files in a directory and returns
+// a report of the ecosystems found.
+func DetectLanguages(dirPath string) *LanguageReport {
+	report := &LanguageReport{
+		Directory: dirPath,
+		Detected:  []Language{},
+	}
+	for lang, manifests := range languageManifests {
+		for _, m := range manifests {
+			if util.Exists(filepath.Join(dirPath, m)) {
+				report.Detected = append(report.Detected, lang)
+				break
+			}
+		}
+	}
+	sort.Slice(report.Detected, func(i, j int) bool {
+		return report.Detected[i] < report.Detected[j]
+	})
+	return report
+}
+
+// String returns a human readable list of the detected languages.
+func (r *LanguageReport) String() string {
+	if len(r.Detected) == 0 {
+		return "none"
+	}
+	langs := []string{}
+	for _, l := range r.Detected {
+		if l.Supported() {
+			langs = append(langs, string(l))
+		} else {
+			langs = append(langs, fmt.Sprintf("%s (not analyzed)", l))
+		}
+	}
+	return strings.Join(langs, ", ")
+}
+
+// DiscoverProjects walks a directory tree looking for subdirectories
+// containing language manifests. The search descends at most maxDepth
+// levels below dirPath, a negative maxDepth means no limit. Hidden
+// directories and those in skipManifestDirs are not searched. The top
+// level directory is not included in the results and the directories
+// in the reports are relative to dirPath.
+func DiscoverProjects(dirPath string, maxDepth int) ([]*LanguageReport, error) {
+	reports := []*LanguageReport{}
+	if maxDepth == 0 {
+		return reports, nil
+	}
+	err := filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || path == dirPath {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || slices.Contains(skipManifestDirs, d.Name()) {
+			return filepath.SkipDir
+		}
+
+		rel, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return fmt.Errorf("computing relative path: %w", err)
+		}
+
+		depth := len(strings.Split(rel, string(filepath.Separator)))
+		if maxDepth > 0 && depth > maxDepth {
+			return filepath.SkipDir
+		}
+
+		if report := DetectLanguages(path); len(report.Detected) > 0 {
+			report.Directory = rel
+			reports = append(reports, report)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("searching for nested projects: %w", err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLanguages(t *testing.T) {
+	langs, err := ParseLanguages([]string{"Go"})
+	require.NoError(t, err)
+	require.Equal(t, []Language{LangGo}, langs)
+
+	langs, err = ParseLanguages(nil)
+	require.NoError(t, err)
+	require.Empty(t, langs)
+
+	langs, err = ParseLanguages([]string{"go", "python", "node", "java"})
+	require.NoError(t, err)
+	require.Equal(t, []Language{LangGo, LangPython, LangNode, LangJava}, langs)
+
+	_, err = ParseLanguages([]string{"go", "cobol"})
+	require.Error(t, err)
+}
+
+func TestDetectLanguages(t *testing.T) {
+	dir := t.TempDir()
+	report := DetectLanguages(dir)
+	require.Empty(t, report.Detected)
+	require.Equal(t, "none", report.String())
+
+	for _, f := range []string{GoModFileName, "requirements.txt", "package.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), []byte{}, os.FileMode(0o644)))
+	}
+	report = DetectLanguages(dir)
+	require.Equal(t, dir, report.Directory)
+	require.Equal(t, []Language{LangGo, LangNode, LangPython}, report.Detected)
+	require.Equal(t, "go, node, python", report.String())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte{}, os.FileMode(0o644)))
+	require.Equal(t, "go, node, python, rust", DetectLanguages(dir).String())
+}
+
+func TestDiscoverProjects(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		GoModFileName,
+		"staging/api/" + GoModFileName,
+		"web/ui/package.json",
+		"vendor/example.com/mod/" + GoModFileName,
+		".hidden/" + GoModFileName,
+	} {
+		path := filepath.Join(dir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, []byte{}, os.FileMode(0o644)))
+	}
+
+	for _, tc := range []struct {
+		depth    int
+		expected []string
+	}{
+		{0, []string{}},
+		{1, []string{}},
+		{2, []string{"staging/api", "web/ui"}},
+		{-1, []string{"staging/api", "web/ui"}},
+	} {
+		reports, err := DiscoverProjects(dir, tc.depth)
+		require.NoError(t, err)
+		dirs := []string{}
+		for _, r := range reports {
+			dirs = append(dirs, filepath.ToSlash(r.Directory))
+		}
+		require.Equal(t, tc.expected, dirs, "depth %d", tc.depth)
+	}
+}
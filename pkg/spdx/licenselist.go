@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/bom/pkg/license"
+)
+
+// LicenseCatalog loads the SPDX license list the document declares in its
+// LicenseListVersion. Lists other than the embedded one are downloaded on
+// demand unless offline is set, in which case the embedded list is used.
+func (d *Document) LicenseCatalog(offline bool) (*license.Catalog, error) {
+	opts := license.DefaultCatalogOpts
+	opts.Offline = offline
+	if tag := license.ListVersionTag(d.LicenseListVersion); tag != "" {
+		opts.Version = tag
+	}
+
+	catalog, err := license.NewCatalogWithOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating license catalog: %w", err)
+	}
+	if err := catalog.LoadLicenses(); err != nil {
+		return nil, fmt.Errorf("loading license list %s: %w", opts.Version, err)
+	}
+	return catalog, nil
+}
+
+// licenseExpressionIDs returns the license identifiers referenced in an
+// SPDX license expression. Exceptions, license refs and the special NONE
+// and NOASSERTION values are not included.
+func licenseExpressionIDs(expression string) []string {
+	ids := []string{}
+	tokens := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expression))
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i]; {
+		case tok == "AND" || tok == "OR" || tok == "and" || tok == "or":
+		case tok == "WITH" || tok == "with":
+			i++ // Skip the exception identifier
+		case tok == NONE || tok == NOASSERTION:
+		case strings.HasPrefix(tok, "LicenseRef-") || strings.HasPrefix(tok, "DocumentRef-"):
+		default:
+			ids = append(ids, strings.TrimSuffix(tok, "+"))
+		}
+	}
+	return ids
+}
+
+// licenseIDRe matches the license and exception identifiers of SPDX
+// license expressions, including license refs
+var licenseIDRe = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?[A-Za-z0-9.-]+\+?$`)
+
+// validLicenseExpression checks the syntax of an SPDX license expression.
+// The identifiers are not looked up in the license list.
+func validLicenseExpression(expression string) bool {
+	tokens := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))
+	depth := 0
+	// operand is true after a license, when an operator is expected
+	operand := false
+	for i := 0; i < len(tokens); i++ {
+		switch tok := tokens[i]; {
+		case tok == "(":
+			if operand {
+				return false
+			}
+			depth++
+		case tok == ")":
+			if !operand || depth == 0 {
+				return false
+			}
+			depth--
+		case tok == "AND" || tok == "OR" || tok == "and" || tok == "or":
+			if !operand {
+				return false
+			}
+			operand = false
+		case tok == "WITH" || tok == "with":
+			if !operand || i+1 == len(tokens) || !licenseIDRe.MatchString(tokens[i+1]) {
+				return false
+			}
+			i++
+		default:
+			if operand || !licenseIDRe.MatchString(tok) {
+				return false
+			}
+			operand = true
+		}
+	}
+	return operand && depth == 0
+}
+
+// MessageLicensesValid is the message of packages with valid licenses
+const MessageLicensesValid = "Licenses validated successfully"
+
+// ValidateLicenses checks the license identifiers in the packages of the
+// document against a license catalog. Packages without license data are
+// not included in the results.
+func (d *Document) ValidateLicenses(catalog *license.Catalog) []ValidationResults {
+	results := []ValidationResults{}
+	for _, p := range d.allPackages() {
+		expressions := append([]string{p.LicenseConcluded, p.LicenseDeclared}, p.LicenseInfoFromFiles...)
+		unknown := map[string]struct{}{}
+		deprecated := map[string]struct{}{}
+		checked := false
+		for _, expression := range expressions {
+			for _, id := range licenseExpressionIDs(expression) {
+				checked = true
+				l, ok := catalog.List.Licenses[id]
+				switch {
+				case !ok:
+					unknown[id] = struct{}{}
+				case l.IsDeprecatedLicenseID:
+					deprecated[id] = struct{}{}
+				}
+			}
+		}
+		if !checked {
+			continue
+		}
+
+		res := ValidationResults{
+			FileName:         p.Name,
+			PackageID:        p.SPDXID(),
+			FailedAlgorithms: []string{},
+			Success:          len(unknown) == 0,
+			Message:          MessageLicensesValid,
+		}
+		switch {
+		case len(unknown) > 0:
+			res.Message = "unknown license IDs in list " + catalog.List.Version + ": " + strings.Join(slices.Sorted(maps.Keys(unknown)), ", ")
+		case len(deprecated) > 0:
+			res.Message = "deprecated license IDs: " + strings.Join(slices.Sorted(maps.Keys(deprecated)), ", ")
+		}
+		results = append(results, res)
+	}
+	return results
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/license"
+)
+
+func TestLicenseExpressionIDs(t *testing.T) {
+	for _, tc := range []struct {
+		expression string
+		expected   []string
+	}{
+		{"", []string{}},
+		{NOASSERTION, []string{}},
+		{"MIT", []string{"MIT"}},
+		{"(MIT OR Apache-2.0) AND GPL-2.0+", []string{"MIT", "Apache-2.0", "GPL-2.0"}},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", []string{"GPL-2.0-only"}},
+		{"LicenseRef-Custom OR BSD-3-Clause", []string{"BSD-3-Clause"}},
+	} {
+		require.Equal(t, tc.expected, licenseExpressionIDs(tc.expression), tc.expression)
+	}
+}
+
+func TestValidLicenseExpression(t *testing.T) {
+	for expression, expected := range map[string]bool{
+		"MIT":                              true,
+		"(MIT OR Apache-2.0) AND GPL-2.0+": true,
+		"GPL-2.0-only WITH Classpath-exception-2.0": true,
+		"LicenseRef-Custom or BSD-3-Clause":         true,
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT":  true,
+		NOASSERTION:                  true,
+		"":                           false,
+		"SEE LICENSE IN LICENSE.txt": false,
+		"Apache 2.0":                 false,
+		"MIT/X11":                    false,
+		"(MIT OR Apache-2.0":         false,
+		"MIT OR":                     false,
+		"MIT WITH":                   false,
+		"() MIT":                     false,
+	} {
+		require.Equal(t, expected, validLicenseExpression(expression), expression)
+	}
+}
+
+func TestValidateLicenses(t *testing.T) {
+	catalog := &license.Catalog{List: &license.List{
+		Version: "3.22",
+		Licenses: map[string]*license.License{
+			"MIT":        {LicenseID: "MIT"},
+			"Apache-2.0": {LicenseID: "Apache-2.0"},
+			"GPL-2.0":    {LicenseID: "GPL-2.0", IsDeprecatedLicenseID: true},
+		},
+	}}
+
+	doc := NewDocument()
+	for _, p := range []*Package{
+		{Entity: Entity{ID: "SPDXRef-Package-ok", Name: "ok"}, LicenseDeclared: "MIT OR Apache-2.0"},
+		{Entity: Entity{ID: "SPDXRef-Package-old", Name: "old", LicenseConcluded: "GPL-2.0"}},
+		{Entity: Entity{ID: "SPDXRef-Package-bad", Name: "bad", LicenseConcluded: "MIT AND Bogus-1.0"}},
+		{Entity: Entity{ID: "SPDXRef-Package-none", Name: "none", LicenseConcluded: NOASSERTION}},
+	} {
+		require.NoError(t, doc.AddPackage(p))
+	}
+
+	results := map[string]ValidationResults{}
+	for _, res := range doc.ValidateLicenses(catalog) {
+		results[res.PackageID] = res
+	}
+	require.Len(t, results, 3)
+	require.True(t, results["SPDXRef-Package-ok"].Success)
+	require.True(t, results["SPDXRef-Package-old"].Success)
+	require.Equal(t, "deprecated license IDs: GPL-2.0", results["SPDXRef-Package-old"].Message)
+	require.False(t, results["SPDXRef-Package-bad"].Success)
+	require.Equal(t, "unknown license IDs in list 3.22: Bogus-1.0", results["SPDXRef-Package-bad"].Message)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+)
+
+// LicenseOverride replaces the concluded license of the packages and
+// files matching a purl or a path glob. Overrides are used to correct
+// known misclassifications of the license scanner, the reasons can be
+// recorded in the license comments of the elements.
+type LicenseOverride struct {
+	Purl    string `yaml:"purl"`    // purl of the packages to override, unset parts match anything
+	Path    string `yaml:"path"`    // Glob matching the file names of the elements
+	License string `yaml:"license"` // Corrected SPDX license expression
+	Comment string `yaml:"comment"` // Why the license was concluded, replaces the license comments
+}
+
+// Validate checks that the override is well formed.
+func (lo *LicenseOverride) Validate() error {
+	if lo.License == "" {
+		return errors.New("license override has no license expression")
+	}
+
+	if (lo.Purl == "") == (lo.Path == "") {
+		return errors.New("license override must define either a purl or a path")
+	}
+
+	if lo.Purl != "" {
+		if _, err := purl.FromString(lo.Purl); err != nil {
+			return fmt.Errorf("parsing license override purl: %w", err)
+		}
+	}
+
+	if lo.Path != "" {
+		if _, err := filepath.Match(lo.Path, ""); err != nil {
+			return fmt.Errorf("parsing license override path glob: %w", err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the override applies to the SPDX object.
+func (lo *LicenseOverride) Matches(o Object) bool {
+	if lo.Purl != "" {
+		p, ok := o.(*Package)
+		if !ok {
+			return false
+		}
+		spec, err := purl.FromString(lo.Purl)
+		if err != nil {
+			return false
+		}
+		// The subpath is only compared when the override sets one
+		for _, part := range []*string{&spec.Type, &spec.Namespace, &spec.Name, &spec.Version, &spec.Subpath} {
+			if *part == "" {
+				*part = "*"
+			}
+		}
+		return p.PurlMatches(&spec)
+	}
+
+	var fileName string
+	switch e := o.(type) {
+	case *Package:
+		fileName = e.FileName
+	case *File:
+		fileName = e.FileName
+	}
+	if fileName == "" {
+		return false
+	}
+
+	if match, _ := filepath.Match(lo.Path, fileName); match {
+		return true
+	}
+
+	// Patterns without a directory part also match the file basename
+	if !strings.Contains(lo.Path, "/") {
+		match, _ := filepath.Match(lo.Path, filepath.Base(fileName))
+		return match
+	}
+	return false
+}
+
+// ApplyLicenseOverrides walks all the elements in the document and replaces
+// the concluded license of those matching the overrides. When more than one
+// override matches an element, the last one wins. Returns the number of
+// elements that were modified.
+func (d *Document) ApplyLicenseOverrides(overrides []LicenseOverride) (int, error) {
+	for i := range overrides {
+		if err := overrides[i].Validate(); err != nil {
+			return 0, fmt.Errorf("checking license override #%d: %w", i, err)
+		}
+	}
+
+	seen := map[string]struct{}{}
+	changed := map[string]struct{}{}
+	for _, p := range d.Packages {
+		applyLicenseOverrides(overrides, p, &seen, &changed)
+	}
+	for _, f := range d.Files {
+		applyLicenseOverrides(overrides, f, &seen, &changed)
+	}
+	return len(changed), nil
+}
+
+// applyLicenseOverrides recursively applies the overrides to an object
+// and its peers.
+//
+//nolint:gocritic // seen and changed are pointers recursively populated
+func applyLicenseOverrides(overrides []LicenseOverride, o Object, seen, changed *map[string]struct{}) {
+	if _, ok := (*seen)[o.SPDXID()]; ok {
+		return
+	}
+	(*seen)[o.SPDXID()] = struct{}{}
+
+	for i := range overrides {
+		if !overrides[i].Matches(o) {
+			continue
+		}
+		var entity *Entity
+		switch e := o.(type) {
+		case *Package:
+			entity = &e.Entity
+		case *File:
+			entity = &e.Entity
+		default:
+			continue
+		}
+		entity.LicenseConcluded = overrides[i].License
+		if overrides[i].Comment != "" {
+			entity.LicenseComments = overrides[i].Comment
+		}
+		logrus.Debugf("Overriding license of %s to %s", o.SPDXID(), overrides[i].License)
+		(*changed)[o.SPDXID()] = struct{}{}
+	}
+
+	for _, rel := range *o.GetRelationships() {
+		if rel.Peer == nil {
+			continue
+		}
+		applyLicenseOverrides(overrides, rel.Peer, seen, changed)
+	}
+}
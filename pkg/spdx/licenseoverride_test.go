@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseOverrideValidate(t *testing.T) {
+	for _, tc := range []struct {
+		override  LicenseOverride
+		shouldErr bool
+	}{
+		{LicenseOverride{Purl: "pkg:golang/example.com/mod", License: "MIT"}, false},
+		{LicenseOverride{Path: "vendor/*", License: "MIT"}, false},
+		{LicenseOverride{Purl: "pkg:golang/example.com/mod"}, true},
+		{LicenseOverride{License: "MIT"}, true},
+		{LicenseOverride{Purl: "pkg:golang/example.com/mod", Path: "*.go", License: "MIT"}, true},
+		{LicenseOverride{Purl: "not a purl", License: "MIT"}, true},
+		{LicenseOverride{Path: "[", License: "MIT"}, true},
+	} {
+		err := tc.override.Validate()
+		if tc.shouldErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestApplyLicenseOverrides(t *testing.T) {
+	doc := NewDocument()
+
+	pkg := NewPackage()
+	pkg.BuildID("dual-licensed")
+	pkg.LicenseConcluded = "GPL-2.0-only"
+	pkg.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:golang/example.com/dual@v1.0.0",
+	}}
+
+	subpkg := NewPackage()
+	subpkg.BuildID("subpackage")
+	subpkg.LicenseConcluded = "GPL-2.0-only"
+	subpkg.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:golang/example.com/other@v1.0.0",
+	}}
+	require.NoError(t, pkg.AddPackage(subpkg))
+
+	f := NewFile()
+	f.BuildID("file")
+	f.FileName = "vendor/lib/code.c"
+	f.LicenseConcluded = "GPL-2.0-only"
+	require.NoError(t, pkg.AddFile(f))
+	require.NoError(t, doc.AddPackage(pkg))
+
+	n, err := doc.ApplyLicenseOverrides([]LicenseOverride{
+		{Purl: "pkg:golang/example.com/dual", License: "MIT OR GPL-2.0-only"},
+		{Path: "*.c", License: "BSD-3-Clause", Comment: "Vendored from libfoo, see LICENSE.libfoo"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "MIT OR GPL-2.0-only", pkg.LicenseConcluded)
+	require.Empty(t, pkg.LicenseComments)
+	require.Equal(t, "GPL-2.0-only", subpkg.LicenseConcluded)
+	require.Equal(t, "BSD-3-Clause", f.LicenseConcluded)
+	require.Equal(t, "Vendored from libfoo, see LICENSE.libfoo", f.LicenseComments)
+
+	_, err = doc.ApplyLicenseOverrides([]LicenseOverride{{License: "MIT"}})
+	require.Error(t, err)
+}
+
+func TestLicenseOverrideSubpath(t *testing.T) {
+	pkg := NewPackage()
+	pkg.BuildID("monorepo")
+	pkg.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:github/example/monorepo@v1.0.0#libs/parser",
+	}}
+
+	for spec, expected := range map[string]bool{
+		"pkg:github/example/monorepo":              true,
+		"pkg:github/example/monorepo#libs/parser":  true,
+		"pkg:github/example/monorepo#libs/printer": false,
+	} {
+		lo := LicenseOverride{Purl: spec, License: "MIT"}
+		require.Equal(t, expected, lo.Matches(pkg), spec)
+	}
+}
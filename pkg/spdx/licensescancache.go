@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+)
+
+// LicenseScanCache stores the results of the license scans of package
+// versions in a directory, indexed by purl. Package versions don't
+// change, so a package found in the cache is not downloaded and scanned
+// again. The qualifiers of the purls are part of the key, as they can
+// point to other artifacts (eg another repository or file) of the same
+// version. The subpath is ignored.
+type LicenseScanCache struct {
+	dir string
+}
+
+// ScannedLicense is the result of the license scan of a package version.
+// Packages where no license was found are cached with a NOASSERTION
+// license.
+type ScannedLicense struct {
+	Purl      string    `json:"purl"`
+	License   string    `json:"license"`
+	Copyright string    `json:"copyright,omitempty"`
+	Scanned   time.Time `json:"scanned"`
+}
+
+// NewLicenseScanCache returns a cache storing the scan results in dir.
+// The directory is created when the first result is stored.
+func NewLicenseScanCache(dir string) *LicenseScanCache {
+	return &LicenseScanCache{dir: dir}
+}
+
+// cachePath returns the path of the cache file of a package version or
+// an empty string if the purl has no version
+func (c *LicenseScanCache) cachePath(spec string) (key, path string) {
+	pu, err := purl.FromString(spec)
+	if err != nil || pu.Version == "" {
+		return "", ""
+	}
+	key = purl.NewPackageURL(pu.Type, pu.Namespace, pu.Name, pu.Version, pu.Qualifiers, "").ToString()
+	sum := sha256.Sum256([]byte(key))
+	return key, filepath.Join(c.dir, pu.Type, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached scan result of a package, nil if it was not
+// scanned before.
+func (c *LicenseScanCache) Get(spec string) *ScannedLicense {
+	if c == nil {
+		return nil
+	}
+	key, path := c.cachePath(spec)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	result := &ScannedLicense{}
+	if err := json.Unmarshal(data, result); err != nil || result.Purl != key {
+		logrus.Warnf("Ignoring invalid license scan cache entry %s", path)
+		return nil
+	}
+	logrus.Debugf("Using cached license scan of %s", key)
+	return result
+}
+
+// Put stores the scan result of a package. Failing to write to the cache
+// only logs a warning.
+func (c *LicenseScanCache) Put(spec, license, copyright string) {
+	if c == nil {
+		return
+	}
+	key, path := c.cachePath(spec)
+	if path == "" {
+		return
+	}
+	if license == "" {
+		license = NOASSERTION
+	}
+	data, err := json.Marshal(&ScannedLicense{
+		Purl: key, License: license, Copyright: copyright, Scanned: time.Now().UTC(),
+	})
+	if err != nil {
+		logrus.Warnf("Unable to cache the license scan of %s: %v", key, err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)); err != nil {
+		logrus.Warnf("Unable to cache the license scan of %s: %v", key, err)
+		return
+	}
+
+	// Write to a temporary file first so concurrent runs sharing the
+	// cache never read partial entries
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".scan-")
+	if err != nil {
+		logrus.Warnf("Unable to cache the license scan of %s: %v", key, err)
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		logrus.Warnf("Unable to cache the license scan of %s: %v", key, err)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseScanCache(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewLicenseScanCache(filepath.Join(dir, "cache"))
+
+	require.Nil(t, cache.Get("pkg:pypi/requests@2.28.1"))
+	cache.Put("pkg:pypi/requests@2.28.1?file_name=requests-2.28.1.tar.gz", "Apache-2.0", "")
+	cache.Put("pkg:golang/github.com/sirupsen/logrus@v1.9.3", "MIT", "Copyright (c) 2014 Simon Eskildsen")
+	cache.Put("pkg:npm/left-pad@1.3.0", "", "")
+
+	// The qualifiers are part of the key, the subpath is not
+	require.Nil(t, cache.Get("pkg:pypi/requests@2.28.1"))
+	require.Nil(t, cache.Get("pkg:pypi/requests@2.28.1?file_name=requests-2.28.1-py3-none-any.whl"))
+	cached := cache.Get("pkg:pypi/requests@2.28.1?file_name=requests-2.28.1.tar.gz#src")
+	require.NotNil(t, cached)
+	require.Equal(t, "pkg:pypi/requests@2.28.1?file_name=requests-2.28.1.tar.gz", cached.Purl)
+	require.Equal(t, "Apache-2.0", cached.License)
+	require.False(t, cached.Scanned.IsZero())
+
+	cached = cache.Get("pkg:golang/github.com/sirupsen/logrus@v1.9.3")
+	require.NotNil(t, cached)
+	require.Equal(t, "MIT", cached.License)
+	require.Equal(t, "Copyright (c) 2014 Simon Eskildsen", cached.Copyright)
+
+	// Packages without license are cached too
+	cached = cache.Get("pkg:npm/left-pad@1.3.0")
+	require.NotNil(t, cached)
+	require.Equal(t, NOASSERTION, cached.License)
+
+	require.Nil(t, cache.Get("pkg:pypi/requests@2.28.2"))
+
+	// Purls without version are never cached
+	cache.Put("pkg:pypi/urllib3", "MIT", "")
+	require.Nil(t, cache.Get("pkg:pypi/urllib3"))
+
+	// Other runs share the results
+	require.NotNil(t, NewLicenseScanCache(filepath.Join(dir, "cache")).Get("pkg:npm/left-pad@1.3.0"))
+
+	// A nil cache stores nothing
+	var noCache *LicenseScanCache
+	noCache.Put("pkg:pypi/requests@2.28.1", "Apache-2.0", "")
+	require.Nil(t, noCache.Get("pkg:pypi/requests@2.28.1"))
+}
+
+func TestLicenseScanCacheInvalidEntry(t *testing.T) {
+	cache := NewLicenseScanCache(t.TempDir())
+	cache.Put("pkg:pypi/requests@2.28.1", "Apache-2.0", "")
+
+	_, path := cache.cachePath("pkg:pypi/requests@2.28.1")
+	require.NoError(t, os.WriteFile(path, []byte("{"), os.FileMode(0o644)))
+	require.Nil(t, cache.Get("pkg:pypi/requests@2.28.1"))
+}
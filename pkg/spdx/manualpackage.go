@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+// ManualPackage describes a package that bom cannot detect by itself (eg
+// firmware blobs or proprietary SDKs) and that is added to the document
+// as defined by the user.
+type ManualPackage struct {
+	Name     string `yaml:"name"`
+	Version  string `yaml:"version"`
+	License  string `yaml:"license"`  // SPDX license expression
+	Purl     string `yaml:"purl"`     // Package URL of the package
+	Supplier string `yaml:"supplier"` // "Person: Name (email)" or "Organization: Name"
+	Comment  string `yaml:"comment"`  // General comments about the package
+
+	// LicenseComments explains how the license of the package was
+	// determined, eg the agreement it was obtained under
+	LicenseComments string `yaml:"license-comments"`
+}
+
+// ParseManualPackage parses a manual package definition from a string
+// in the form name@version,license=...,purl=...,supplier=...
+// All fields except the name are optional. The fields are read as CSV,
+// so fields with commas are written in double quotes, eg
+// "supplier=Organization: Acme, Inc.".
+func ParseManualPackage(spec string) (*ManualPackage, error) {
+	r := csv.NewReader(strings.NewReader(spec))
+	r.TrimLeadingSpace = true
+	parts, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("parsing package definition %q: %w", spec, err)
+	}
+	mp := &ManualPackage{}
+
+	mp.Name = strings.TrimSpace(parts[0])
+	if i := strings.LastIndex(mp.Name, "@"); i > 0 {
+		mp.Version = mp.Name[i+1:]
+		mp.Name = mp.Name[:i]
+	}
+
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid package field %q, must be key=value", part)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "license":
+			mp.License = value
+		case "purl":
+			mp.Purl = value
+		case "supplier":
+			mp.Supplier = value
+		default:
+			return nil, fmt.Errorf(
+				"unknown package field %q, valid fields are license, purl and supplier", key,
+			)
+		}
+	}
+
+	if err := mp.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid package definition %q: %w", spec, err)
+	}
+	return mp, nil
+}
+
+// Validate checks the package definition for errors.
+func (mp *ManualPackage) Validate() error {
+	if mp.Name == "" {
+		return errors.New("package name is required")
+	}
+
+	if mp.Purl != "" {
+		if _, err := purl.FromString(mp.Purl); err != nil {
+			return fmt.Errorf("parsing package purl: %w", err)
+		}
+	}
+	return nil
+}
+
+// ToSPDXPackage converts the manual definition into a SPDX package.
+func (mp *ManualPackage) ToSPDXPackage() (*Package, error) {
+	if err := mp.Validate(); err != nil {
+		return nil, err
+	}
+
+	pkg := NewPackage()
+	pkg.Name = mp.Name
+	pkg.Version = mp.Version
+	pkg.LicenseDeclared = mp.License
+	pkg.LicenseComments = mp.LicenseComments
+	pkg.Comment = mp.Comment
+	pkg.BuildID(mp.Name, mp.Version)
+
+	if mp.Purl != "" {
+		pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  mp.Purl,
+		})
+	}
+
+	// Suppliers without a type are assumed to be organizations
+	typ, name, ok := strings.Cut(mp.Supplier, ":")
+	switch {
+	case ok && strings.TrimSpace(typ) == entPerson:
+		pkg.Supplier.Person = strings.TrimSpace(name)
+	case ok && strings.TrimSpace(typ) == entOrganization:
+		pkg.Supplier.Organization = strings.TrimSpace(name)
+	default:
+		pkg.Supplier.Organization = strings.TrimSpace(mp.Supplier)
+	}
+
+	return pkg, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManualPackage(t *testing.T) {
+	for _, tc := range []struct {
+		spec      string
+		expected  *ManualPackage
+		shouldErr bool
+	}{
+		{"firmware", &ManualPackage{Name: "firmware"}, false},
+		{"firmware@1.0.2", &ManualPackage{Name: "firmware", Version: "1.0.2"}, false},
+		{
+			"sdk@2.1,license=Apache-2.0,purl=pkg:generic/sdk@2.1,supplier=Organization: ACME",
+			&ManualPackage{
+				Name: "sdk", Version: "2.1", License: "Apache-2.0",
+				Purl: "pkg:generic/sdk@2.1", Supplier: "Organization: ACME",
+			},
+			false,
+		},
+		{
+			`sdk@2.1,"supplier=Organization: Acme, Inc.","purl=pkg:generic/sdk@2.1?checksum=sha1:ad9503c,sha256:ab8a8d"`,
+			&ManualPackage{
+				Name: "sdk", Version: "2.1",
+				Purl: "pkg:generic/sdk@2.1?checksum=sha1:ad9503c,sha256:ab8a8d", Supplier: "Organization: Acme, Inc.",
+			},
+			false,
+		},
+		{"sdk@2.1,supplier=Organization: Acme, Inc.", nil, true},
+		{`sdk@2.1,"license=MIT`, nil, true},
+		{"", nil, true},
+		{",license=MIT", nil, true},
+		{"sdk@2.1,license", nil, true},
+		{"sdk@2.1,color=blue", nil, true},
+		{"sdk@2.1,purl=not-a-purl", nil, true},
+	} {
+		mp, err := ParseManualPackage(tc.spec)
+		if tc.shouldErr {
+			require.Error(t, err, tc.spec)
+			continue
+		}
+		require.NoError(t, err, tc.spec)
+		require.Equal(t, tc.expected, mp)
+	}
+}
+
+func TestManualPackageToSPDXPackage(t *testing.T) {
+	mp := &ManualPackage{
+		Name: "sdk", Version: "2.1", License: "Apache-2.0",
+		Purl: "pkg:generic/sdk@2.1", Supplier: "ACME Inc",
+		Comment: "Vendor SDK", LicenseComments: "Licensed under the ACME partner agreement",
+	}
+	p, err := mp.ToSPDXPackage()
+	require.NoError(t, err)
+	require.Equal(t, "sdk", p.Name)
+	require.Equal(t, "2.1", p.Version)
+	require.Equal(t, "Apache-2.0", p.LicenseDeclared)
+	require.Equal(t, "Vendor SDK", p.Comment)
+	require.Equal(t, "Licensed under the ACME partner agreement", p.LicenseComments)
+	require.Equal(t, "ACME Inc", p.Supplier.Organization)
+	require.NotNil(t, p.Purl())
+	require.NotEmpty(t, p.SPDXID())
+
+	mp.Supplier = "Person: Jane Doe (jane@example.com)"
+	p, err = mp.ToSPDXPackage()
+	require.NoError(t, err)
+	require.Equal(t, "Jane Doe (jane@example.com)", p.Supplier.Person)
+	require.Empty(t, p.Supplier.Organization)
+
+	_, err = (&ManualPackage{}).ToSPDXPackage()
+	require.Error(t, err)
+}
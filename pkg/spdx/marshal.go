@@ -0,0 +1,559 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"sigs.k8s.io/bom/pkg/spdx/json/document"
+	spdx23JSON "sigs.k8s.io/bom/pkg/spdx/json/v2.3"
+)
+
+// MarshalJSON encodes the document as an SPDX JSON document.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	jsonDoc, err := d.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonDoc)
+}
+
+// UnmarshalJSON parses an SPDX 2.2 or 2.3 JSON document into d,
+// replacing any data it had.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	doc, err := parseJSON(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.Version = doc.Version
+	d.DataLicense = doc.DataLicense
+	d.ID = doc.ID
+	d.Name = doc.Name
+	d.Namespace = doc.Namespace
+	d.Creator = doc.Creator
+	d.CreatorComment = doc.CreatorComment
+	d.Created = doc.Created
+	d.LicenseListVersion = doc.LicenseListVersion
+	d.Comment = doc.Comment
+	d.Packages = doc.Packages
+	d.Files = doc.Files
+	d.ExternalDocRefs = doc.ExternalDocRefs
+	d.ExtractedLicenses = doc.ExtractedLicenses
+	d.Snippets = doc.Snippets
+	d.Annotations = doc.Annotations
+	d.Describes = doc.Describes
+	d.danglingRelationships = doc.danglingRelationships
+	d.index = nil
+	return nil
+}
+
+// MarshalJSON encodes the package as an SPDX JSON package. Relationships
+// are not part of the package element, they are only serialized as part
+// of the document.
+func (p *Package) MarshalJSON() ([]byte, error) {
+	jsonPackage, err := p.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonPackage)
+}
+
+// UnmarshalJSON parses an SPDX JSON package into p.
+func (p *Package) UnmarshalJSON(data []byte) error {
+	jsonPackage := spdx23JSON.Package{}
+	if err := json.Unmarshal(data, &jsonPackage); err != nil {
+		return fmt.Errorf("parsing package json: %w", err)
+	}
+	p.readJSON(&jsonPackage, "2.3")
+	return nil
+}
+
+// MarshalJSON encodes the file as an SPDX JSON file.
+func (f *File) MarshalJSON() ([]byte, error) {
+	jsonFile, err := f.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonFile)
+}
+
+// UnmarshalJSON parses an SPDX JSON file into f.
+func (f *File) UnmarshalJSON(data []byte) error {
+	jsonFile := spdx23JSON.File{}
+	if err := json.Unmarshal(data, &jsonFile); err != nil {
+		return fmt.Errorf("parsing file json: %w", err)
+	}
+	f.readJSON(&jsonFile)
+	return nil
+}
+
+// MarshalJSON encodes the snippet as an SPDX JSON snippet.
+func (s *Snippet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.toJSON())
+}
+
+// UnmarshalJSON parses an SPDX JSON snippet into s.
+func (s *Snippet) UnmarshalJSON(data []byte) error {
+	jsonSnippet := spdx23JSON.Snippet{}
+	if err := json.Unmarshal(data, &jsonSnippet); err != nil {
+		return fmt.Errorf("parsing snippet json: %w", err)
+	}
+	s.readJSON(&jsonSnippet)
+	return nil
+}
+
+// toJSON builds the SPDX JSON representation of the document.
+func (d *Document) toJSON() (*spdx23JSON.Document, error) {
+	// The tag-value renderer finalizes the sbom before serializing
+	// it. We still need to call it before building the JSON struct.
+	if _, err := d.Render(); err != nil {
+		return nil, fmt.Errorf("pre-rendering the document: %w", err)
+	}
+
+	jsonDoc := &spdx23JSON.Document{
+		ID:      d.ID,
+		Name:    d.Name,
+		Version: spdx23JSON.Version,
+		CreationInfo: spdx23JSON.CreationInfo{
+			Created:            time.Now().UTC().Format("2006-01-02T15:04:05Z07:00"),
+			Creators:           d.creatorsList(),
+			LicenseListVersion: d.LicenseListVersion,
+			Comment:            d.CreatorComment,
+		},
+		DataLicense:       d.DataLicense,
+		Namespace:         d.Namespace,
+		DocumentDescribes: d.DescribedIDs(),
+		Packages:          []spdx23JSON.Package{},
+		Relationships:     []spdx23JSON.Relationship{},
+		Comment:           d.Comment,
+		Annotations:       jsonAnnotations(d.Annotations),
+	}
+
+	for _, l := range d.ExtractedLicenses {
+		jsonDoc.HasExtractedLicensingInfos = append(jsonDoc.HasExtractedLicensingInfos, spdx23JSON.ExtractedLicensingInfo{
+			LicenseID:     l.ID,
+			ExtractedText: l.Text,
+			Name:          l.Name,
+			Comment:       l.Comment,
+			SeeAlsos:      l.SeeAlso,
+		})
+	}
+
+	for _, s := range d.Snippets {
+		jsonDoc.Snippets = append(jsonDoc.Snippets, s.toJSON())
+	}
+
+	for i := range d.ExternalDocRefs {
+		jsonDoc.ExternalDocumentRefs = append(jsonDoc.ExternalDocumentRefs, d.ExternalDocRefs[i].toJSON())
+	}
+
+	err := d.Walk(func(o Object, _ []Object) error {
+		switch e := o.(type) {
+		case *Package:
+			jsonPackage, err := e.toJSON()
+			if err != nil {
+				return fmt.Errorf("serializing json package: %w", err)
+			}
+			jsonDoc.Packages = append(jsonDoc.Packages, jsonPackage)
+		case *File:
+			jsonFile, err := e.toJSON()
+			if err != nil {
+				return fmt.Errorf("serializing json file: %w", err)
+			}
+			jsonDoc.Files = append(jsonDoc.Files, jsonFile)
+		default:
+			return nil
+		}
+
+		// Add the element's relationships to the doc
+		for _, r := range *o.GetRelationships() {
+			jsonDoc.Relationships = append(jsonDoc.Relationships, spdx23JSON.Relationship{
+				Element: o.SPDXID(),
+				Type:    string(r.Type),
+				Related: r.peerID(),
+				Comment: r.Comment,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonDoc, nil
+}
+
+// toJSON converts a SPDX package struct to a json package
+// TODO(puerco): Validate package information to make sure its a valid package.
+func (p *Package) toJSON() (jsonPackage spdx23JSON.Package, err error) {
+	// Update the Verification code
+	if err := p.ComputeVerificationCode(); err != nil {
+		return jsonPackage, fmt.Errorf("computing verification code: %w", err)
+	}
+
+	// Update the license list
+	if err := p.ComputeLicenseList(); err != nil {
+		return jsonPackage, fmt.Errorf("computing license list from files: %w", err)
+	}
+
+	externalRefs := make([]spdx23JSON.ExternalRef, len(p.ExternalRefs))
+	for i, ref := range p.ExternalRefs {
+		externalRefs[i].Category = ref.Category
+		externalRefs[i].Locator = ref.Locator
+		externalRefs[i].Type = ref.Type
+	}
+	jsonPackage = spdx23JSON.Package{
+		ID:                   p.SPDXID(),
+		Name:                 p.Name,
+		Version:              p.Version,
+		FilesAnalyzed:        p.FilesAnalyzed,
+		LicenseConcluded:     p.LicenseConcluded,
+		LicenseDeclared:      p.LicenseDeclared,
+		DownloadLocation:     p.DownloadLocation,
+		LicenseInfoFromFiles: p.LicenseInfoFromFiles,
+		PrimaryPurpose:       p.PrimaryPurpose,
+		CopyrightText:        p.CopyrightText,
+		Comment:              p.Comment,
+		HomePage:             p.HomePage,
+		LicenseComments:      p.LicenseComments,
+		Summary:              p.Summary,
+		Description:          p.Description,
+		SourceInfo:           p.SourceInfo,
+		AttributionTexts:     p.AttributionTexts,
+		Annotations:          jsonAnnotations(p.Annotations),
+		HasFiles:             []string{},
+		Checksums:            []spdx23JSON.Checksum{},
+		ExternalRefs:         externalRefs,
+	}
+
+	if p.Supplier.Organization != "" {
+		jsonPackage.Supplier = "Organization: " + p.Supplier.Organization
+	}
+
+	if p.Supplier.Person != "" {
+		jsonPackage.Supplier = "Person: " + p.Supplier.Person
+	}
+
+	for _, d := range []struct {
+		date   time.Time
+		target *string
+	}{
+		{p.ReleaseDate, &jsonPackage.ReleaseDate},
+		{p.BuiltDate, &jsonPackage.BuiltDate},
+		{p.ValidUntilDate, &jsonPackage.ValidUntilDate},
+	} {
+		if !d.date.IsZero() {
+			*d.target = d.date.UTC().Format("2006-01-02T15:04:05Z")
+		}
+	}
+
+	if p.Originator.Organization != "" {
+		jsonPackage.Originator = "Organization: " + p.Originator.Organization
+	}
+
+	if p.Originator.Person != "" {
+		jsonPackage.Originator = "Person: " + p.Originator.Person
+	}
+
+	if p.VerificationCode != "" {
+		jsonPackage.VerificationCode = &spdx23JSON.PackageVerificationCode{
+			Value: p.VerificationCode,
+		}
+	}
+
+	if jsonPackage.LicenseConcluded == NOASSERTION {
+		jsonPackage.LicenseConcluded = ""
+	}
+	if jsonPackage.LicenseDeclared == NOASSERTION {
+		jsonPackage.LicenseDeclared = ""
+	}
+
+	if jsonPackage.CopyrightText == "" {
+		jsonPackage.CopyrightText = NOASSERTION
+	}
+
+	if jsonPackage.DownloadLocation == "" {
+		jsonPackage.DownloadLocation = NONE
+	}
+
+	for algo, value := range p.Checksum {
+		jsonPackage.Checksums = append(jsonPackage.Checksums, spdx23JSON.Checksum{
+			Algorithm: algo,
+			Value:     value,
+		})
+	}
+
+	// If the package has files, we need to add them top hasFiles
+	files := p.Files()
+	if len(files) > 0 {
+		for _, f := range files {
+			if f.SPDXID() == "" {
+				return jsonPackage, errors.New("unable to compute has files array, file missing SPDX ID")
+			}
+			jsonPackage.HasFiles = append(jsonPackage.HasFiles, f.SPDXID())
+		}
+	}
+	return jsonPackage, nil
+}
+
+// toJSON converts a SPDX file struct to a json file
+// TODO(pueco): Validate file information , eg check checksums are
+// enum : [ "SHA256", "SHA1", "SHA384", "MD2", "MD4", "SHA512", "MD6", "MD5", "SHA224" ]
+// "required" : [ "SPDXID", "copyrightText", "fileName", "licenseConcluded" ],.
+func (f *File) toJSON() (jsonFile spdx23JSON.File, err error) {
+	if f.SPDXID() == "" {
+		return jsonFile, errors.New("unamble to serialzie file, it has no SPDX ID defined")
+	}
+	jsonFile = spdx23JSON.File{
+		ID:                f.SPDXID(),
+		Name:              f.Name,
+		CopyrightText:     f.CopyrightText,
+		NoticeText:        f.NoticeText,
+		LicenseConcluded:  f.LicenseConcluded,
+		LicenseComments:   f.LicenseComments,
+		Comment:           f.Comment,
+		FileTypes:         f.FileType,
+		LicenseInfoInFile: []string{f.LicenseInfoInFile},
+		Checksums:         []spdx23JSON.Checksum{},
+		AttributionTexts:  f.AttributionTexts,
+		Annotations:       jsonAnnotations(f.Annotations),
+	}
+
+	if jsonFile.LicenseConcluded == NOASSERTION {
+		jsonFile.LicenseConcluded = ""
+	}
+
+	if jsonFile.CopyrightText == "" {
+		jsonFile.CopyrightText = NOASSERTION
+	}
+
+	for algo, value := range f.Checksum {
+		jsonFile.Checksums = append(jsonFile.Checksums, spdx23JSON.Checksum{
+			Algorithm: algo,
+			Value:     value,
+		})
+	}
+	return jsonFile, nil
+}
+
+// toJSON converts a SPDX snippet to its json representation.
+func (s *Snippet) toJSON() spdx23JSON.Snippet {
+	snippet := spdx23JSON.Snippet{
+		ID:                    s.ID,
+		Name:                  s.Name,
+		Comment:               s.Comment,
+		CopyrightText:         s.CopyrightText,
+		LicenseConcluded:      s.LicenseConcluded,
+		LicenseComments:       s.LicenseComments,
+		LicenseInfoInSnippets: s.LicenseInfoInSnippet,
+		FromFile:              s.FromFile,
+		Ranges:                []spdx23JSON.SnippetRange{},
+	}
+	if s.ByteRange.IsSet() {
+		snippet.Ranges = append(snippet.Ranges, spdx23JSON.SnippetRange{
+			StartPointer: spdx23JSON.SnippetPointer{Reference: s.FromFile, Offset: s.ByteRange.Start},
+			EndPointer:   spdx23JSON.SnippetPointer{Reference: s.FromFile, Offset: s.ByteRange.End},
+		})
+	}
+	if s.LineRange.IsSet() {
+		snippet.Ranges = append(snippet.Ranges, spdx23JSON.SnippetRange{
+			StartPointer: spdx23JSON.SnippetPointer{Reference: s.FromFile, LineNumber: s.LineRange.Start},
+			EndPointer:   spdx23JSON.SnippetPointer{Reference: s.FromFile, LineNumber: s.LineRange.End},
+		})
+	}
+	return snippet
+}
+
+// jsonAnnotations converts a list of annotations to json.
+func jsonAnnotations(annotations []Annotation) []spdx23JSON.Annotation {
+	if len(annotations) == 0 {
+		return nil
+	}
+	ret := []spdx23JSON.Annotation{}
+	for _, a := range annotations {
+		ret = append(ret, spdx23JSON.Annotation{
+			Annotator: a.Annotator,
+			Date:      a.Date,
+			Type:      a.Type,
+			Comment:   a.Comment,
+		})
+	}
+	return ret
+}
+
+// toJSON converts an external document reference to its JSON form. SPDX
+// JSON records a single checksum, the first algorithm in order is used.
+func (ed *ExternalDocumentRef) toJSON() spdx23JSON.ExternalDocumentRef {
+	ref := spdx23JSON.ExternalDocumentRef{
+		ExternalDocumentID: ed.ID,
+		SPDXDocument:       ed.URI,
+	}
+	if !strings.HasPrefix(ref.ExternalDocumentID, "DocumentRef-") {
+		ref.ExternalDocumentID = "DocumentRef-" + ref.ExternalDocumentID
+	}
+	if algos := slices.Sorted(maps.Keys(ed.Checksums)); len(algos) > 0 {
+		ref.Checksum = spdx23JSON.Checksum{Algorithm: algos[0], Value: ed.Checksums[algos[0]]}
+	}
+	return ref
+}
+
+// creatorsList returns the creators of the document formatted as expected
+// in the SPDX creation info. If the document does not define any creator,
+// bom is listed as the tool that produced it.
+func (d *Document) creatorsList() []string {
+	creators := []string{}
+	if d.Creator.Person != "" {
+		creators = append(creators, "Person: "+d.Creator.Person)
+	}
+	if d.Creator.Organization != "" {
+		creators = append(creators, "Organization: "+d.Creator.Organization)
+	}
+	for _, tool := range d.Creator.Tool {
+		creators = append(creators, "Tool: "+tool)
+	}
+	if len(creators) == 0 {
+		creators = append(creators, fmt.Sprintf("Tool: %s-%s", "bom", version.GetVersionInfo().GitVersion))
+	}
+	return creators
+}
+
+// readJSON populates the package from its SPDX JSON representation.
+// The spdxVersion determines which of the optional fields are read.
+func (p *Package) readJSON(pData document.Package, spdxVersion string) {
+	p.Entity = Entity{
+		ID:               pData.GetID(),
+		Opts:             p.Opts,
+		Name:             pData.GetName(),
+		DownloadLocation: pData.GetDownloadLocation(),
+		CopyrightText:    pData.GetCopyrightText(),
+		LicenseConcluded: pData.GetLicenseConcluded(),
+		LicenseComments:  pData.GetLicenseComments(),
+		Relationships:    []*Relationship{},
+		Checksum:         map[string]string{},
+		AttributionTexts: pData.GetAttributionTexts(),
+		Annotations:      parseJSONAnnotations(pData.GetAnnotations()),
+	}
+	p.FilesAnalyzed = pData.GetFilesAnalyzed()
+	p.LicenseInfoFromFiles = []string{}
+	p.LicenseDeclared = pData.GetLicenseDeclared()
+	p.Version = pData.GetVersion()
+	p.VerificationCode = pData.GetVerificationCode().GetValue()
+	p.Comment = pData.GetComment()
+	p.HomePage = pData.GetHomePage()
+	p.Summary = pData.GetSummary()
+	p.Description = pData.GetDescription()
+	p.SourceInfo = pData.GetSourceInfo()
+	p.ExternalRefs = []ExternalRef{}
+
+	if pData.GetLicenseInfoFromFiles() != nil {
+		p.LicenseInfoFromFiles = pData.GetLicenseInfoFromFiles()
+	}
+
+	p.Supplier.Person, p.Supplier.Organization = parseActor(pData.GetSupplier())
+	p.Originator.Person, p.Originator.Organization = parseActor(pData.GetOriginator())
+
+	if spdxVersion == "2.3" {
+		p.PrimaryPurpose = pData.GetPrimaryPurpose()
+		for _, d := range []struct {
+			value  string
+			target *time.Time
+		}{
+			{pData.GetReleaseDate(), &p.ReleaseDate},
+			{pData.GetBuiltDate(), &p.BuiltDate},
+			{pData.GetValidUntilDate(), &p.ValidUntilDate},
+		} {
+			if d.value == "" {
+				continue
+			}
+			t, err := parseDate(d.value)
+			if err != nil {
+				logrus.Errorf("unable to parse date of package %s: %s", p.ID, err)
+				continue
+			}
+			*d.target = t
+		}
+	}
+
+	for _, cs := range pData.GetChecksums() {
+		p.Checksum[cs.GetAlgorithm()] = cs.GetValue()
+	}
+
+	for _, eref := range pData.GetExternalRefs() {
+		p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+			Category: eref.GetCategory(),
+			Type:     eref.GetType(),
+			Locator:  eref.GetLocator(),
+		})
+	}
+}
+
+// readJSON populates the file from its SPDX JSON representation.
+func (f *File) readJSON(fData document.File) {
+	f.Entity = Entity{
+		ID:               fData.GetID(),
+		Opts:             f.Opts,
+		Name:             fData.GetName(),
+		CopyrightText:    fData.GetCopyrightText(),
+		LicenseConcluded: fData.GetLicenseConcluded(),
+		LicenseComments:  fData.GetLicenseComments(),
+		Relationships:    []*Relationship{},
+		Checksum:         map[string]string{},
+		AttributionTexts: fData.GetAttributionTexts(),
+		Annotations:      parseJSONAnnotations(fData.GetAnnotations()),
+	}
+	f.FileType = []string{}
+	f.LicenseInfoInFile = strings.Join(fData.GetLicenseInfoInFile(), " AND ")
+	f.Comment = fData.GetComment()
+	f.NoticeText = fData.GetNoticeText()
+
+	if fData.GetFileTypes() != nil {
+		f.FileType = fData.GetFileTypes()
+	}
+
+	for _, cs := range fData.GetChecksums() {
+		f.Checksum[cs.GetAlgorithm()] = cs.GetValue()
+	}
+}
+
+// readJSON populates the snippet from its SPDX JSON representation.
+func (s *Snippet) readJSON(sData document.Snippet) {
+	*s = Snippet{
+		ID:                   sData.GetID(),
+		Name:                 sData.GetName(),
+		FromFile:             sData.GetFromFile(),
+		LicenseConcluded:     sData.GetLicenseConcluded(),
+		LicenseInfoInSnippet: sData.GetLicenseInfoInSnippets(),
+		LicenseComments:      sData.GetLicenseComments(),
+		CopyrightText:        sData.GetCopyrightText(),
+		Comment:              sData.GetComment(),
+	}
+	s.ByteRange.Start, s.ByteRange.End = sData.GetByteRange()
+	s.LineRange.Start, s.LineRange.End = sData.GetLineRange()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentJSONRoundTrip(t *testing.T) {
+	file, err := os.Open("testdata/full-fields.spdx.json")
+	require.NoError(t, err)
+	defer file.Close()
+
+	doc, err := parseJSON(file)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	parsed := &Document{}
+	require.NoError(t, json.Unmarshal(data, parsed))
+	checkFullFieldsDocument(t, parsed)
+}
+
+func TestPackageJSONRoundTrip(t *testing.T) {
+	p := NewPackage()
+	p.ID = "SPDXRef-Package-test"
+	p.Name = "test"
+	p.Version = "v1.0.0"
+	p.LicenseDeclared = "Apache-2.0"
+	p.Supplier.Organization = "Example Inc"
+	p.Checksum = map[string]string{"SHA256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"}
+	p.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:golang/example.com/test@v1.0.0",
+	}}
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	parsed := NewPackage()
+	require.NoError(t, json.Unmarshal(data, parsed))
+	require.Equal(t, p.ID, parsed.ID)
+	require.Equal(t, p.Name, parsed.Name)
+	require.Equal(t, p.Version, parsed.Version)
+	require.Equal(t, p.LicenseDeclared, parsed.LicenseDeclared)
+	require.Equal(t, "Example Inc", parsed.Supplier.Organization)
+	require.Equal(t, p.Checksum, parsed.Checksum)
+	require.Equal(t, p.ExternalRefs, parsed.ExternalRefs)
+	require.Equal(t, NONE, parsed.DownloadLocation)
+
+	f := NewFile()
+	require.Error(t, json.Unmarshal([]byte(`{"SPDXID": 1}`), f))
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// sniffLength is the number of bytes read from files to detect their
+// media type, the same used by http.DetectContentType.
+const sniffLength = 512
+
+// scriptMediaTypes maps the interpreters in script shebangs to the media
+// type of the scripts.
+var scriptMediaTypes = map[string]string{
+	"sh":      "text/x-shellscript",
+	"bash":    "text/x-shellscript",
+	"dash":    "text/x-shellscript",
+	"ash":     "text/x-shellscript",
+	"ksh":     "text/x-shellscript",
+	"zsh":     "text/x-shellscript",
+	"python":  "text/x-python",
+	"python2": "text/x-python",
+	"python3": "text/x-python",
+	"perl":    "text/x-perl",
+	"ruby":    "text/x-ruby",
+	"node":    "text/javascript",
+	"php":     "text/x-php",
+}
+
+// detectMediaType reads the first bytes of a file to detect its media
+// type from its contents. Executables and scripts are recognized from
+// their magic numbers and shebangs, other formats are sniffed using the
+// algorithm in http.DetectContentType. The returned media type has no
+// parameters.
+func detectMediaType(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	data := make([]byte, sniffLength)
+	n, err := io.ReadFull(f, data)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("reading file: %w", err)
+	}
+	data = data[:n]
+
+	if mediaType := sniffExecutable(data); mediaType != "" {
+		return mediaType, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "", fmt.Errorf("parsing media type: %w", err)
+	}
+	return mediaType, nil
+}
+
+// sniffExecutable returns the media type of binaries and scripts, or an
+// empty string if the data is not from an executable file.
+func sniffExecutable(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x7fELF")):
+		return "application/x-executable"
+	case bytes.HasPrefix(data, []byte("MZ")):
+		return "application/vnd.microsoft.portable-executable"
+	case bytes.HasPrefix(data, []byte{0xfe, 0xed, 0xfa, 0xce}),
+		bytes.HasPrefix(data, []byte{0xfe, 0xed, 0xfa, 0xcf}),
+		bytes.HasPrefix(data, []byte{0xce, 0xfa, 0xed, 0xfe}),
+		bytes.HasPrefix(data, []byte{0xcf, 0xfa, 0xed, 0xfe}):
+		return "application/x-mach-binary"
+	case bytes.HasPrefix(data, []byte{0xca, 0xfe, 0xba, 0xbe}) && len(data) >= 8:
+		// Universal Mach-O binaries and java classes share the magic
+		// number. Universal binaries have a few architectures where
+		// java classes have their version, 45 and up.
+		if binary.BigEndian.Uint32(data[4:8]) < 45 {
+			return "application/x-mach-binary"
+		}
+		return "application/java-vm"
+	case bytes.HasPrefix(data, []byte("#!")):
+		return scriptMediaType(data)
+	}
+	return ""
+}
+
+// scriptMediaType returns the media type of a script from the
+// interpreter in its shebang line.
+func scriptMediaType(data []byte) string {
+	line, _, _ := bufio.NewReader(bytes.NewReader(data[2:])).ReadLine()
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return "text/x-script"
+	}
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" {
+		// #!/usr/bin/env [-S] python3
+		interpreter = ""
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") && !strings.Contains(f, "=") {
+				interpreter = path.Base(f)
+				break
+			}
+		}
+	}
+	if mediaType, ok := scriptMediaTypes[interpreter]; ok {
+		return mediaType
+	}
+	// Versioned interpreters, eg python3.12 or perl5.36
+	if mediaType, ok := scriptMediaTypes[strings.TrimRight(interpreter, "0123456789.")]; ok {
+		return mediaType
+	}
+	return "text/x-script"
+}
+
+// mediaTypeFileTypes returns the SPDX file types of a media type.
+func mediaTypeFileTypes(mediaType string) []string {
+	switch mediaType {
+	case "application/x-executable", "application/x-mach-binary",
+		"application/vnd.microsoft.portable-executable", "application/java-vm",
+		"application/wasm", "application/octet-stream":
+		return []string{"BINARY", "APPLICATION"}
+	case "text/x-shellscript", "text/x-python", "text/x-perl", "text/x-ruby",
+		"text/javascript", "text/x-php", "text/x-script":
+		return []string{"SOURCE"}
+	case "text/plain", "application/pdf":
+		return []string{"TEXT", "DOCUMENTATION"}
+	case "text/html", "text/xml", "application/json":
+		return []string{"TEXT"}
+	case "application/zip", "application/x-gzip", "application/x-rar-compressed":
+		return []string{"ARCHIVE"}
+	case "application/ogg":
+		return []string{"AUDIO"}
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "image/"):
+		return []string{"IMAGE"}
+	case strings.HasPrefix(mediaType, "audio/"):
+		return []string{"AUDIO"}
+	case strings.HasPrefix(mediaType, "video/"):
+		return []string{"VIDEO"}
+	default:
+		return []string{"OTHER"}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectMediaType(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name      string
+		data      string
+		mediaType string
+		fileTypes []string
+	}{
+		{"app", "\x7fELF\x02\x01\x01\x00", "application/x-executable", []string{"BINARY", "APPLICATION"}},
+		{"app-darwin", "\xcf\xfa\xed\xfe\x07\x00\x00\x01", "application/x-mach-binary", []string{"BINARY", "APPLICATION"}},
+		{"app-universal", "\xca\xfe\xba\xbe\x00\x00\x00\x02", "application/x-mach-binary", []string{"BINARY", "APPLICATION"}},
+		{"Main", "\xca\xfe\xba\xbe\x00\x00\x00\x41", "application/java-vm", []string{"BINARY", "APPLICATION"}},
+		{"setup", "MZ\x90\x00\x03\x00", "application/vnd.microsoft.portable-executable", []string{"BINARY", "APPLICATION"}},
+		{"build", "#!/bin/bash\nset -e\n", "text/x-shellscript", []string{"SOURCE"}},
+		{"tool", "#!/usr/bin/env -S python3.12 -u\nprint('hi')\n", "text/x-python", []string{"SOURCE"}},
+		{"awkscript", "#!/usr/bin/awk -f\n{ print }\n", "text/x-script", []string{"SOURCE"}},
+		{"logo", "\x89PNG\x0d\x0a\x1a\x0a\x00\x00\x00\x0dIHDR", "image/png", []string{"IMAGE"}},
+		{"LICENSE", "Apache License\nVersion 2.0, January 2004\n", "text/plain", []string{"TEXT", "DOCUMENTATION"}},
+		{"data", "\x00\x01\x02\x03", "application/octet-stream", []string{"BINARY", "APPLICATION"}},
+	} {
+		path := filepath.Join(dir, tc.name)
+		require.NoError(t, os.WriteFile(path, []byte(tc.data), 0o644))
+
+		mediaType, err := detectMediaType(path)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.mediaType, mediaType, tc.name)
+
+		fileTypes, mediaType := getFileTypes(path)
+		require.Equal(t, tc.fileTypes, fileTypes, tc.name)
+		require.Equal(t, tc.mediaType, mediaType, tc.name)
+	}
+
+	// Known extensions are not sniffed
+	path := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("\x7fELF"), 0o644))
+	fileTypes, mediaType := getFileTypes(path)
+	require.Equal(t, []string{"SOURCE"}, fileTypes)
+	require.Empty(t, mediaType)
+
+	// The detected media type is recorded in the file comment
+	path = filepath.Join(dir, "run")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\nexec app\n"), 0o755))
+	f := NewFile()
+	require.NoError(t, f.ReadSourceFile(path))
+	require.NoError(t, f.ReadSourceFile(path))
+	require.Equal(t, []string{"SOURCE"}, f.FileType)
+	require.Equal(t, "Media type: text/x-shellscript", f.Comment)
+}
@@ -0,0 +1,488 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Formats of machine learning model files
+const (
+	modelFormatSafetensors = "safetensors"
+	modelFormatGGUF        = "gguf"
+	modelFormatONNX        = "onnx"
+	modelFormatPickle      = "pickle"
+)
+
+const (
+	// maxModelHeaderSize is the largest model header read for metadata
+	maxModelHeaderSize = 100 << 20
+	// maxModelStringLen is the most bytes of a GGUF or ONNX string read
+	// into memory, the rest of the string is skipped
+	maxModelStringLen = 4 << 10
+	// maxModelValueLen is the longest metadata value recorded, longer
+	// values are truncated
+	maxModelValueLen = 256
+)
+
+// modelExtensions maps the extensions of model files to their format
+var modelExtensions = map[string]string{
+	".safetensors": modelFormatSafetensors,
+	".gguf":        modelFormatGGUF,
+	".onnx":        modelFormatONNX,
+	".pkl":         modelFormatPickle,
+	".pickle":      modelFormatPickle,
+	".pt":          modelFormatPickle,
+	".pth":         modelFormatPickle,
+	".joblib":      modelFormatPickle,
+}
+
+// modelFormat returns the format of a model file from its extension, or
+// an empty string if the path is not a model.
+func modelFormat(path string) string {
+	return modelExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+// addModelMetadata tags a model file and records the metadata embedded
+// in it in the file comment. Failing to read the metadata is not an
+// error, the file is still tagged as a model.
+func (f *File) addModelMetadata(path, format string) {
+	f.FileType = []string{"BINARY"}
+
+	lines := []string{fmt.Sprintf("Machine learning model (%s)", format)}
+	if format == modelFormatPickle {
+		lines[0] += ", loading it can run arbitrary code"
+	}
+
+	props, err := readModelMetadata(path, format)
+	if err != nil {
+		logrus.Warnf("Unable to read %s model metadata from %s: %v", format, path, err)
+	}
+	keys := []string{}
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %s", k, props[k]))
+	}
+
+	if f.Comment != "" {
+		f.Comment += "\n"
+	}
+	f.Comment += strings.Join(lines, "\n")
+}
+
+// readModelMetadata reads the metadata embedded in a model file.
+func readModelMetadata(path, format string) (map[string]string, error) {
+	var read func(io.Reader) (map[string]string, error)
+	switch format {
+	case modelFormatSafetensors:
+		read = readSafetensorsMetadata
+	case modelFormatGGUF:
+		read = readGGUFMetadata
+	case modelFormatONNX:
+		read = readONNXMetadata
+	default:
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening model: %w", err)
+	}
+	defer f.Close()
+	return read(bufio.NewReader(f))
+}
+
+// modelValue truncates a metadata value to maxModelValueLen
+func modelValue(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > maxModelValueLen {
+		return s[:maxModelValueLen] + "..."
+	}
+	return s
+}
+
+// readSafetensorsMetadata reads the JSON header of a safetensors file:
+// https://github.com/huggingface/safetensors#format
+func readSafetensorsMetadata(r io.Reader) (map[string]string, error) {
+	var size uint64
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, fmt.Errorf("reading header size: %w", err)
+	}
+	if size > maxModelHeaderSize {
+		return nil, fmt.Errorf("header size %d is too large", size)
+	}
+	header := map[string]json.RawMessage{}
+	if err := json.NewDecoder(io.LimitReader(r, int64(size))).Decode(&header); err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+
+	props := map[string]string{}
+	tensors := 0
+	for k, v := range header {
+		if k != "__metadata__" {
+			tensors++
+			continue
+		}
+		md := map[string]string{}
+		if err := json.Unmarshal(v, &md); err != nil {
+			return nil, fmt.Errorf("decoding metadata: %w", err)
+		}
+		for mk, mv := range md {
+			props[mk] = modelValue(mv)
+		}
+	}
+	props["tensors"] = strconv.Itoa(tensors)
+	return props, nil
+}
+
+// Types of the GGUF metadata values
+const (
+	ggufUint8 uint32 = iota
+	ggufInt8
+	ggufUint16
+	ggufInt16
+	ggufUint32
+	ggufInt32
+	ggufFloat32
+	ggufBool
+	ggufString
+	ggufArray
+	ggufUint64
+	ggufInt64
+	ggufFloat64
+)
+
+// readGGUFMetadata reads the general metadata keys of a GGUF file:
+// https://github.com/ggml-org/ggml/blob/master/docs/gguf.md
+func readGGUFMetadata(r io.Reader) (map[string]string, error) {
+	br := bufio.NewReader(r)
+	var header struct {
+		Magic   [4]byte
+		Version uint32
+		Tensors uint64
+		KVs     uint64
+	}
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	if string(header.Magic[:]) != "GGUF" {
+		return nil, errors.New("invalid GGUF magic")
+	}
+	if header.Version < 2 {
+		return nil, fmt.Errorf("unsupported GGUF version %d", header.Version)
+	}
+
+	props := map[string]string{
+		"tensors": strconv.FormatUint(header.Tensors, 10),
+	}
+	for range header.KVs {
+		key, err := readGGUFString(br, true)
+		if err != nil {
+			return nil, fmt.Errorf("reading metadata key: %w", err)
+		}
+		var vtype uint32
+		if err := binary.Read(br, binary.LittleEndian, &vtype); err != nil {
+			return nil, fmt.Errorf("reading type of %s: %w", key, err)
+		}
+		keep := strings.HasPrefix(key, "general.")
+		value, err := readGGUFValue(br, vtype, keep)
+		if err != nil {
+			return nil, fmt.Errorf("reading value of %s: %w", key, err)
+		}
+		if keep && value != "" {
+			props[key] = modelValue(value)
+		}
+	}
+	return props, nil
+}
+
+// readGGUFString reads a GGUF string. When keep is false the string is
+// skipped and an empty string is returned.
+func readGGUFString(br *bufio.Reader, keep bool) (string, error) {
+	var l uint64
+	if err := binary.Read(br, binary.LittleEndian, &l); err != nil {
+		return "", err
+	}
+	if !keep {
+		return "", discardModelBytes(br, l)
+	}
+	return readModelString(br, l)
+}
+
+// readModelString reads a string of l bytes from a model file, keeping
+// at most maxModelStringLen bytes of it.
+func readModelString(br *bufio.Reader, l uint64) (string, error) {
+	buf := make([]byte, min(l, maxModelStringLen))
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return "", err
+	}
+	return string(buf), discardModelBytes(br, l-uint64(len(buf)))
+}
+
+// readGGUFValue reads a GGUF value of the specified type. Arrays and the
+// values not kept are skipped and returned as an empty string.
+func readGGUFValue(br *bufio.Reader, vtype uint32, keep bool) (string, error) {
+	var v any
+	switch vtype {
+	case ggufString:
+		return readGGUFString(br, keep)
+	case ggufArray:
+		var elemType uint32
+		var count uint64
+		if err := binary.Read(br, binary.LittleEndian, &elemType); err != nil {
+			return "", err
+		}
+		if err := binary.Read(br, binary.LittleEndian, &count); err != nil {
+			return "", err
+		}
+		if count > maxModelHeaderSize {
+			return "", fmt.Errorf("array of %d values is too large", count)
+		}
+		if size := ggufValueSize(elemType); size > 0 {
+			return "", discardModelBytes(br, count*size)
+		}
+		for range count {
+			if _, err := readGGUFValue(br, elemType, false); err != nil {
+				return "", err
+			}
+		}
+		return "", nil
+	case ggufUint8:
+		v = new(uint8)
+	case ggufInt8:
+		v = new(int8)
+	case ggufUint16:
+		v = new(uint16)
+	case ggufInt16:
+		v = new(int16)
+	case ggufUint32:
+		v = new(uint32)
+	case ggufInt32:
+		v = new(int32)
+	case ggufFloat32:
+		v = new(float32)
+	case ggufBool:
+		v = new(bool)
+	case ggufUint64:
+		v = new(uint64)
+	case ggufInt64:
+		v = new(int64)
+	case ggufFloat64:
+		v = new(float64)
+	default:
+		return "", fmt.Errorf("unknown value type %d", vtype)
+	}
+	if err := binary.Read(br, binary.LittleEndian, v); err != nil {
+		return "", err
+	}
+	if !keep {
+		return "", nil
+	}
+	switch n := v.(type) {
+	case *uint8:
+		return fmt.Sprint(*n), nil
+	case *int8:
+		return fmt.Sprint(*n), nil
+	case *uint16:
+		return fmt.Sprint(*n), nil
+	case *int16:
+		return fmt.Sprint(*n), nil
+	case *uint32:
+		return fmt.Sprint(*n), nil
+	case *int32:
+		return fmt.Sprint(*n), nil
+	case *float32:
+		return fmt.Sprint(*n), nil
+	case *bool:
+		return fmt.Sprint(*n), nil
+	case *uint64:
+		return fmt.Sprint(*n), nil
+	case *int64:
+		return fmt.Sprint(*n), nil
+	case *float64:
+		return fmt.Sprint(*n), nil
+	}
+	return "", nil
+}
+
+// ggufValueSize returns the size of the GGUF fixed size types, or zero
+// for strings and arrays.
+func ggufValueSize(vtype uint32) uint64 {
+	switch vtype {
+	case ggufUint8, ggufInt8, ggufBool:
+		return 1
+	case ggufUint16, ggufInt16:
+		return 2
+	case ggufUint32, ggufInt32, ggufFloat32:
+		return 4
+	case ggufUint64, ggufInt64, ggufFloat64:
+		return 8
+	}
+	return 0
+}
+
+// discardModelBytes skips n bytes of a model file
+func discardModelBytes(br *bufio.Reader, n uint64) error {
+	for n > 0 {
+		chunk := min(n, 1<<30)
+		if _, err := br.Discard(int(chunk)); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// Fields of the ONNX ModelProto message:
+// https://github.com/onnx/onnx/blob/main/onnx/onnx.proto
+var onnxModelFields = map[uint64]string{
+	1: "ir_version",
+	2: "producer_name",
+	3: "producer_version",
+	4: "domain",
+	5: "model_version",
+	6: "doc_string",
+}
+
+// onnxMetadataPropsField is the field of the ModelProto metadata_props
+const onnxMetadataPropsField = 14
+
+// readONNXMetadata reads the top level fields of an ONNX model, skipping
+// the model graph.
+func readONNXMetadata(r io.Reader) (map[string]string, error) {
+	br := bufio.NewReader(r)
+	props := map[string]string{}
+	for {
+		field, wire, err := readProtoKey(br)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := onnxModelFields[field]
+		switch wire {
+		case 0: // varint
+			v, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, fmt.Errorf("reading field %d: %w", field, err)
+			}
+			if name != "" {
+				props[name] = strconv.FormatUint(v, 10)
+			}
+		case 1: // 64 bit
+			if err := discardModelBytes(br, 8); err != nil {
+				return nil, fmt.Errorf("reading field %d: %w", field, err)
+			}
+		case 2: // length delimited
+			l, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, fmt.Errorf("reading field %d: %w", field, err)
+			}
+			// Metadata properties longer than maxModelStringLen are skipped
+			if (name == "" && field != onnxMetadataPropsField) ||
+				(field == onnxMetadataPropsField && l > maxModelStringLen) {
+				if err := discardModelBytes(br, l); err != nil {
+					return nil, fmt.Errorf("reading field %d: %w", field, err)
+				}
+				continue
+			}
+			value, err := readModelString(br, l)
+			if err != nil {
+				return nil, fmt.Errorf("reading field %d: %w", field, err)
+			}
+			if field != onnxMetadataPropsField {
+				props[name] = modelValue(value)
+				continue
+			}
+			k, v, err := readONNXStringEntry([]byte(value))
+			if err != nil {
+				return nil, fmt.Errorf("reading metadata property: %w", err)
+			}
+			props[k] = modelValue(v)
+		case 5: // 32 bit
+			if err := discardModelBytes(br, 4); err != nil {
+				return nil, fmt.Errorf("reading field %d: %w", field, err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid wire type %d, file is not an ONNX model", wire)
+		}
+	}
+	if _, ok := props["ir_version"]; !ok {
+		return nil, errors.New("file is not an ONNX model, ir_version not found")
+	}
+	return props, nil
+}
+
+// readONNXStringEntry decodes a StringStringEntryProto message
+func readONNXStringEntry(data []byte) (key, value string, err error) {
+	br := bufio.NewReader(bytes.NewReader(data))
+	for {
+		field, wire, err := readProtoKey(br)
+		if errors.Is(err, io.EOF) {
+			return key, value, nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if wire != 2 {
+			return "", "", fmt.Errorf("invalid wire type %d in entry", wire)
+		}
+		l, err := binary.ReadUvarint(br)
+		if err != nil {
+			return "", "", err
+		}
+		if l > uint64(len(data)) {
+			return "", "", errors.New("entry length exceeds message size")
+		}
+		buf := make([]byte, l)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return "", "", err
+		}
+		switch field {
+		case 1:
+			key = string(buf)
+		case 2:
+			value = string(buf)
+		}
+	}
+}
+
+// readProtoKey reads the field number and wire type of a protobuf field
+func readProtoKey(br *bufio.Reader) (field, wire uint64, err error) {
+	key, err := binary.ReadUvarint(br)
+	if err != nil {
+		return 0, 0, err
+	}
+	return key >> 3, key & 7, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSafetensors() []byte {
+	header := []byte(`{"__metadata__":{"format":"pt"},"w":{"dtype":"F32","shape":[1],"data_offsets":[0,4]}}`)
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint64(len(header))) //nolint:errcheck
+	buf.Write(header)
+	buf.Write([]byte{0, 0, 0, 0})
+	return buf.Bytes()
+}
+
+func testGGUF() []byte {
+	var buf bytes.Buffer
+	w := func(v any) { binary.Write(&buf, binary.LittleEndian, v) } //nolint:errcheck
+	str := func(s string) { w(uint64(len(s))); buf.WriteString(s) }
+	buf.WriteString("GGUF")
+	w(uint32(3))  // version
+	w(uint64(10)) // tensors
+	w(uint64(4))  // kvs
+	str("general.architecture")
+	w(ggufString)
+	str("llama")
+	str("tokenizer.ggml.tokens")
+	w(ggufArray)
+	w(ggufString)
+	w(uint64(2))
+	str("a")
+	str("b")
+	str("general.file_type")
+	w(ggufUint32)
+	w(uint32(15))
+	str("llama.context_length")
+	w(ggufUint32)
+	w(uint32(4096))
+	return buf.Bytes()
+}
+
+func testONNX() []byte {
+	str := func(field byte, s string) []byte {
+		return append([]byte{field<<3 | 2, byte(len(s))}, s...)
+	}
+	data := []byte{1 << 3, 8} // ir_version: 8
+	data = append(data, str(2, "pytorch")...)
+	data = append(data, str(3, "2.1.0")...)
+	graph := []byte{0x0a, 0x01, 'x'}
+	data = append(data, 7<<3|2, byte(len(graph)))
+	data = append(data, graph...)
+	entry := append(str(1, "author"), str(2, "jane")...)
+	data = append(data, onnxMetadataPropsField<<3|2, byte(len(entry)))
+	data = append(data, entry...)
+	return data
+}
+
+func TestReadModelMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		format    string
+		data      []byte
+		expected  map[string]string
+		shouldErr bool
+	}{
+		{
+			"safetensors", modelFormatSafetensors, testSafetensors(),
+			map[string]string{"format": "pt", "tensors": "1"}, false,
+		},
+		{
+			"gguf", modelFormatGGUF, testGGUF(),
+			map[string]string{"general.architecture": "llama", "general.file_type": "15", "tensors": "10"}, false,
+		},
+		{
+			"onnx", modelFormatONNX, testONNX(),
+			map[string]string{"ir_version": "8", "producer_name": "pytorch", "producer_version": "2.1.0", "author": "jane"}, false,
+		},
+		{"pickle", modelFormatPickle, []byte{0x80, 0x04}, nil, false},
+		{"invalid safetensors", modelFormatSafetensors, []byte("short"), nil, true},
+		{"invalid gguf", modelFormatGGUF, append([]byte("GGML"), make([]byte, 20)...), nil, true},
+		{"invalid onnx", modelFormatONNX, []byte("not a model"), nil, true},
+	} {
+		path := filepath.Join(t.TempDir(), "model")
+		require.NoError(t, os.WriteFile(path, tc.data, os.FileMode(0o644)))
+		props, err := readModelMetadata(path, tc.format)
+		if tc.shouldErr {
+			require.Error(t, err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, props, tc.name)
+	}
+}
+
+func TestReadModelString(t *testing.T) {
+	// Long strings are cut to maxModelStringLen and the rest is skipped
+	long := strings.Repeat("a", 3*maxModelStringLen)
+	br := bufio.NewReader(strings.NewReader(long + "next"))
+	s, err := readModelString(br, uint64(len(long)))
+	require.NoError(t, err)
+	require.Len(t, s, maxModelStringLen)
+	rest, err := io.ReadAll(br)
+	require.NoError(t, err)
+	require.Equal(t, "next", string(rest))
+
+	_, err = readModelString(bufio.NewReader(strings.NewReader("short")), 10)
+	require.Error(t, err)
+}
+
+func TestReadModelFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "model.safetensors")
+	require.NoError(t, os.WriteFile(path, testSafetensors(), os.FileMode(0o644)))
+
+	// Model metadata is only read when enabled
+	f := NewFile()
+	f.Options().WorkDir = dir
+	require.NoError(t, f.ReadSourceFile(path))
+	require.NotContains(t, f.Comment, "Machine learning model")
+
+	f = NewFile()
+	f.Options().WorkDir = dir
+	f.Options().ModelMetadata = true
+	require.NoError(t, f.ReadSourceFile(path))
+	require.Equal(t, []string{"BINARY"}, f.FileType)
+	require.Equal(t, "Machine learning model (safetensors)\nformat: pt\ntensors: 1", f.Comment)
+	require.NotEmpty(t, f.Checksum["SHA256"])
+
+	path = filepath.Join(dir, "model.PKL")
+	require.NoError(t, os.WriteFile(path, []byte{0x80, 0x04}, os.FileMode(0o644)))
+	f = NewFile()
+	f.Options().ModelMetadata = true
+	require.NoError(t, f.ReadSourceFile(path))
+	require.Equal(t, "Machine learning model (pickle), loading it can run arbitrary code", f.Comment)
+
+	require.Empty(t, modelFormat("model.json"))
+}
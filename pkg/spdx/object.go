@@ -22,8 +22,15 @@ limitations under the License.
 package spdx
 
 import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -31,7 +38,6 @@ import (
 	purl "github.com/package-url/packageurl-go"
 	"github.com/sirupsen/logrus"
 
-	"sigs.k8s.io/release-utils/hash"
 	"sigs.k8s.io/release-utils/util"
 )
 
@@ -63,11 +69,23 @@ type Entity struct {
 	Opts             *ObjectOptions    // Entity options
 	Relationships    []*Relationship   // List of objects that have a relationship woth this package
 	Checksum         map[string]string // Colection of source file checksums
+	AttributionTexts []string          // Notices that must be reproduced when distributing the element
+	Annotations      []Annotation      // Reviews and comments about the element
+
+	// Git blob object IDs of the source file by algorithm (sha1, sha256),
+	// computed with the checksums
+	gitoids map[string]string
+
+	// generation of the document indexing the element, bumped when the
+	// element changes. Set with the document lock and, for packages, the
+	// package lock held.
+	generation *graphGeneration
 }
 
 type ObjectOptions struct {
-	Prefix  string
-	WorkDir string
+	Prefix        string
+	WorkDir       string
+	ModelMetadata bool // Read the metadata embedded in machine learning model files
 }
 
 func (e *Entity) Options() *ObjectOptions {
@@ -82,6 +100,7 @@ func (e *Entity) SPDXID() string {
 // SPDXID returns the SPDX reference string for the object.
 func (e *Entity) SetSPDXID(id string) {
 	e.ID = id
+	e.bumpGeneration()
 }
 
 // BuildID sets the file ID, optionally from a series of strings.
@@ -96,6 +115,7 @@ func (e *Entity) BuildID(seeds ...string) {
 // on the document. The exact output depends on the related obj options.
 func (e *Entity) AddRelationship(rel *Relationship) {
 	e.Relationships = append(e.Relationships, rel)
+	e.bumpGeneration()
 }
 
 // ReadChecksums receives a path to a file and calculates its checksums.
@@ -104,19 +124,46 @@ func (e *Entity) ReadChecksums(filePath string) error {
 		e.Checksum = map[string]string{}
 	}
 
-	// Hash the file contents
-	for algo, fn := range map[string]func(string) (string, error){
-		"SHA1":   hash.SHA1ForFile,
-		"SHA256": hash.SHA256ForFile,
-		"SHA512": hash.SHA512ForFile,
-	} {
-		csum, err := fn(filePath)
-		if err != nil {
-			return fmt.Errorf("hashing %s file %s: %w", algo, filePath, err)
-		}
-		e.Checksum[algo] = csum
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("opening file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("reading size of %s: %w", filePath, err)
 	}
 
+	// Hash the file contents in a single pass. The git object IDs are
+	// the digests of the contents prefixed by the blob header.
+	hashes := map[string]hash.Hash{
+		"SHA1":   sha1.New(),
+		"SHA256": sha256.New(),
+		"SHA512": sha512.New(),
+	}
+	gitHashes := map[string]hash.Hash{
+		"sha1":   sha1.New(),
+		"sha256": sha256.New(),
+	}
+	writers := []io.Writer{}
+	for _, h := range hashes {
+		writers = append(writers, h)
+	}
+	for _, h := range gitHashes {
+		fmt.Fprintf(h, "blob %d\x00", info.Size())
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), f); err != nil {
+		return fmt.Errorf("hashing file %s: %w", filePath, err)
+	}
+
+	for algo, h := range hashes {
+		e.Checksum[algo] = hex.EncodeToString(h.Sum(nil))
+	}
+	e.gitoids = map[string]string{}
+	for algo, h := range gitHashes {
+		e.gitoids[algo] = hex.EncodeToString(h.Sum(nil))
+	}
 	return nil
 }
 
@@ -135,9 +182,7 @@ func (e *Entity) ReadSourceFile(path string) error {
 	e.SourceFile = path
 
 	// If the entity name is blank, we set it to the file path
-	e.FileName = strings.TrimPrefix(
-		path, e.Options().WorkDir+string(filepath.Separator),
-	)
+	e.FileName = sourceFileName(e.Options().WorkDir, path)
 
 	if e.Name == "" {
 		e.Name = e.FileName
@@ -146,6 +191,25 @@ func (e *Entity) ReadSourceFile(path string) error {
 	return nil
 }
 
+// sourceFileName returns the name of the file at path as recorded in
+// the document. Files under workDir are named relative to it, the drive
+// letter or UNC volume of other paths is dropped. The SPDX spec requires
+// forward slashes, so names read on Windows are converted.
+func sourceFileName(workDir, path string) string {
+	name := path
+	if workDir != "" {
+		rel, err := filepath.Rel(workDir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			name = rel
+		}
+	}
+	name = strings.TrimPrefix(name, filepath.VolumeName(name))
+	if workDir == "" {
+		name = strings.TrimPrefix(name, string(filepath.Separator))
+	}
+	return filepath.ToSlash(name)
+}
+
 // Render is overridden by Package and File with their own variants.
 func (e *Entity) Render() (string, error) {
 	return "", nil
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha1" //nolint:gosec // OmniBOR identifiers are git object IDs
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// omniborAlgorithms are the hash algorithms of the gitoids written to
+// the OmniBOR store
+var omniborAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+// omniborManifest builds an OmniBOR input manifest listing the gitoids
+// of the inputs of an artifact. After the gitoid:blob:<algo> header, each
+// input is written in a "blob <gitoid>" line, sorted by gitoid.
+func omniborManifest(algo string, inputs []string) []byte {
+	inputs = slices.Compact(slices.Sorted(slices.Values(inputs)))
+	var b strings.Builder
+	b.WriteString(gitoidPrefix + algo + "\n")
+	for _, id := range inputs {
+		b.WriteString("blob " + id + "\n")
+	}
+	return []byte(b.String())
+}
+
+// writeOmniBORObject writes an object to an OmniBOR store and returns its
+// gitoid. Objects are stored in objects/gitoid_blob_<algo>/ in a directory
+// named after the first two characters of their ID.
+func writeOmniBORObject(storeDir, algo string, data []byte) (string, error) {
+	h := omniborAlgorithms[algo]()
+	fmt.Fprintf(h, "blob %d\x00", len(data))
+	h.Write(data)
+	id := hex.EncodeToString(h.Sum(nil))
+
+	dir := filepath.Join(storeDir, "objects", "gitoid_blob_"+algo, id[:2])
+	if err := os.MkdirAll(dir, os.FileMode(0o755)); err != nil {
+		return "", fmt.Errorf("creating OmniBOR store directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id[2:]), data, os.FileMode(0o644)); err != nil {
+		return "", fmt.Errorf("writing OmniBOR object: %w", err)
+	}
+	return id, nil
+}
+
+// WriteOmniBOR writes the OmniBOR artifact dependency graph of the
+// packages with analyzed files (scanned directories and archives) to a
+// store directory. The input manifest of each package lists the gitoids
+// of its files, the gitoids of the manifests are added to the package
+// external references. Returns the number of manifests written.
+func (d *Document) WriteOmniBOR(storeDir string) (int, error) {
+	n := 0
+	err := d.Walk(func(o Object, _ []Object) error {
+		p, ok := o.(*Package)
+		if !ok || !p.FilesAnalyzed {
+			return nil
+		}
+		inputs := map[string][]string{}
+		for _, f := range p.Files() {
+			if f.SourceFile == "" && f.gitoids == nil {
+				continue
+			}
+			sha1Hex, sha256Hex, err := f.gitBlobIDs()
+			if err != nil {
+				return fmt.Errorf("reading gitoid of %s: %w", f.Name, err)
+			}
+			inputs["sha1"] = append(inputs["sha1"], sha1Hex)
+			inputs["sha256"] = append(inputs["sha256"], sha256Hex)
+		}
+		if len(inputs) == 0 {
+			return nil
+		}
+
+		ids := []string{}
+		for _, algo := range slices.Sorted(maps.Keys(omniborAlgorithms)) {
+			id, err := writeOmniBORObject(storeDir, algo, omniborManifest(algo, inputs[algo]))
+			if err != nil {
+				return err
+			}
+			ids = append(ids, gitoidPrefix+algo+":"+id)
+		}
+		p.addPersistentIDRefs(ids)
+		n++
+		return nil
+	})
+	return n, err
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func TestOmniBORManifest(t *testing.T) {
+	// Input manifest of a single "hello\n" blob, its gitoid is the one
+	// git hash-object computes for the manifest
+	manifest := omniborManifest("sha1", []string{
+		"ce013625030ba8dba906f756967f9e9ca394464a", "ce013625030ba8dba906f756967f9e9ca394464a",
+	})
+	require.Equal(t, "gitoid:blob:sha1\nblob ce013625030ba8dba906f756967f9e9ca394464a\n", string(manifest))
+	id, err := writeOmniBORObject(t.TempDir(), "sha1", manifest)
+	require.NoError(t, err)
+	require.Equal(t, "ceb824c23b3879f1a0b8e09254ffaca893c782a6", id)
+}
+
+func TestWriteOmniBOR(t *testing.T) {
+	src := spdxtest.WriteDirectory(t, map[string]string{"README.md": "hello\n", "a-b": "y\n"})
+	pkg := NewPackage()
+	pkg.BuildID("src")
+	pkg.Name = "src"
+	pkg.FilesAnalyzed = true
+	for _, name := range []string{"README.md", "a-b"} {
+		f := NewFile()
+		f.Options().WorkDir = src
+		require.NoError(t, f.ReadSourceFile(filepath.Join(src, name)))
+		require.NoError(t, pkg.AddFile(f))
+	}
+
+	// The gitoids are computed when reading the files, so the graph can be
+	// written after temporary sources are removed
+	require.NoError(t, os.RemoveAll(src))
+
+	doc := NewDocument()
+	require.NoError(t, doc.AddPackage(pkg))
+	store := t.TempDir()
+	n, err := doc.WriteOmniBOR(store)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	manifest, err := os.ReadFile(filepath.Join(
+		store, "objects", "gitoid_blob_sha1", "8a", "8718e9d146f9f0df857b7e3cfbf7ad2a0ae47d",
+	))
+	require.NoError(t, err)
+	require.Equal(t, "gitoid:blob:sha1\n"+
+		"blob 975fbec8256d3e8a3797e7a3611380f27c49f4ac\n"+
+		"blob ce013625030ba8dba906f756967f9e9ca394464a\n", string(manifest))
+	require.FileExists(t, filepath.Join(
+		store, "objects", "gitoid_blob_sha256", "50", "0c057f0a8e07281bb35f81f6e047e3d2d9653584dcff9b213afa72f85ff5aa",
+	))
+
+	require.Equal(t, []ExternalRef{
+		{Category: CatPersistentID, Type: "gitoid", Locator: "gitoid:blob:sha1:8a8718e9d146f9f0df857b7e3cfbf7ad2a0ae47d"},
+		{Category: CatPersistentID, Type: "gitoid", Locator: "gitoid:blob:sha256:500c057f0a8e07281bb35f81f6e047e3d2d9653584dcff9b213afa72f85ff5aa"},
+	}, pkg.ExternalRefs)
+}
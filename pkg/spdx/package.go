@@ -33,6 +33,7 @@ import (
 	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	purl "github.com/package-url/packageurl-go"
 	"github.com/sirupsen/logrus"
@@ -60,6 +61,12 @@ FilesAnalyzed: {{ .FilesAnalyzed }}
 {{- if .Supplier.Organization }}PackageSupplier: Organization: {{ .Supplier.Organization }}
 {{ end -}}
 {{ end -}}
+{{ if .Originator -}}
+{{- if .Originator.Person }}PackageOriginator: Person: {{ .Originator.Person }}
+{{ end -}}
+{{- if .Originator.Organization }}PackageOriginator: Organization: {{ .Originator.Organization }}
+{{ end -}}
+{{ end -}}
 {{ if .VerificationCode }}PackageVerificationCode: {{ .VerificationCode }}
 {{ end -}}
 PackageLicenseConcluded: {{ if .LicenseConcluded }}{{ .LicenseConcluded }}{{ else }}NOASSERTION{{ end }}
@@ -74,30 +81,57 @@ PackageLicenseConcluded: {{ if .LicenseConcluded }}{{ .LicenseConcluded }}{{ els
 {{ end -}}
 {{ if .PrimaryPurpose }}PrimaryPackagePurpose: {{ .PrimaryPurpose }}
 {{ end -}}
+{{ if not .ReleaseDate.IsZero }}ReleaseDate: {{ .ReleaseDate.UTC.Format "2006-01-02T15:04:05Z" }}
+{{ end -}}
+{{ if not .BuiltDate.IsZero }}BuiltDate: {{ .BuiltDate.UTC.Format "2006-01-02T15:04:05Z" }}
+{{ end -}}
+{{ if not .ValidUntilDate.IsZero }}ValidUntilDate: {{ .ValidUntilDate.UTC.Format "2006-01-02T15:04:05Z" }}
+{{ end -}}
 {{ if .ExternalRefs }}{{- range $key, $value := .ExternalRefs -}}ExternalRef: {{ $value.Category }} {{ $value.Type }} {{ $value.Locator }}
 {{ end -}}
 {{ end -}}
 {{ if .LicenseComments }}PackageLicenseComments: <text>{{ .LicenseComments }}
 </text>
 {{ end -}}
+{{ if .Comment }}PackageComment: <text>{{ .Comment }}
+</text>
+{{ end -}}
+{{ if .Summary }}PackageSummary: <text>{{ .Summary }}
+</text>
+{{ end -}}
+{{ if .Description }}PackageDescription: <text>{{ .Description }}
+</text>
+{{ end -}}
+{{ if .SourceInfo }}PackageSourceInfo: <text>{{ .SourceInfo }}
+</text>
+{{ end -}}
+{{ range .AttributionTexts }}PackageAttributionText: <text>{{ . }}
+</text>
+{{ end -}}
 PackageLicenseDeclared: {{ if .LicenseDeclared }}{{ .LicenseDeclared }}{{ else }}NOASSERTION{{ end }}
 PackageCopyrightText: {{ if .CopyrightText }}<text>{{ .CopyrightText }}
 </text>{{ else }}NOASSERTION{{ end }}
-
+` + annotationsTemplate + `
 `
 
 // Package groups a set of files.
 type Package struct {
 	Entity
 	sync.RWMutex
-	FilesAnalyzed        bool     // true
-	VerificationCode     string   // 6486e016b01e9ec8a76998cefd0705144d869234
-	LicenseInfoFromFiles []string // GPL-3.0-or-later
-	LicenseDeclared      string   // GPL-3.0-or-later
-	Version              string   // Package version
-	Comment              string   // a place for the SPDX document creator to record any general comments
-	HomePage             string   // A web site that serves as the package home page
-	PrimaryPurpose       string   // Estimate of the most likely package usage
+	FilesAnalyzed        bool      // true
+	VerificationCode     string    // 6486e016b01e9ec8a76998cefd0705144d869234
+	LicenseInfoFromFiles []string  // GPL-3.0-or-later
+	LicenseDeclared      string    // GPL-3.0-or-later
+	Version              string    // Package version
+	Comment              string    // a place for the SPDX document creator to record any general comments
+	HomePage             string    // A web site that serves as the package home page
+	PrimaryPurpose       string    // Estimate of the most likely package usage
+	ReleaseDate          time.Time // Date the package was released
+	BuiltDate            time.Time // Date the package was built
+	ValidUntilDate       time.Time // End of support date of the package
+	Summary              string    // Short description of the package
+	Description          string    // Detailed description of the package
+	SourceInfo           string    // Background information about the origin of the package
 
 	// Supplier: the actual distribution source for the package/directory
 	Supplier struct {
@@ -112,6 +146,14 @@ type Package struct {
 	}
 
 	ExternalRefs []ExternalRef // List of external references
+
+	// Confidence of the license classifier in the concluded license, when
+	// it was read from the package files
+	licenseConfidence float64
+
+	// External documents referenced by the package relationships. They
+	// are added to the document when the package is added to it.
+	ExternalDocRefs []ExternalDocumentRef
 }
 
 // PackagePurposes lists the valid package purposes
@@ -138,9 +180,6 @@ func NewPackage() (p *Package) {
 
 // AddFile adds a file contained in the package.
 func (p *Package) AddFile(file *File) error {
-	p.Lock()
-	defer p.Unlock()
-
 	// If file does not have an ID, we try to build one
 	// by hashing the file name
 	if file.ID == "" {
@@ -167,6 +206,15 @@ func (p *Package) AddFile(file *File) error {
 	return nil
 }
 
+// AddRelationship adds a relationship to the package. It is safe to
+// call concurrently, eg when scanners running in goroutines attach
+// their results to the same package.
+func (p *Package) AddRelationship(rel *Relationship) {
+	p.Lock()
+	defer p.Unlock()
+	p.Entity.AddRelationship(rel)
+}
+
 // AddPackage adds a new subpackage to a package.
 func (p *Package) AddPackage(pkg *Package) error {
 	p.AddRelationship(&Relationship{
@@ -189,6 +237,8 @@ func (p *Package) AddDependency(pkg *Package) error {
 
 // Files returns all contained files in the package.
 func (p *Package) Files() []*File {
+	p.RLock()
+	defer p.RUnlock()
 	ret := []*File{}
 	for _, rel := range p.Relationships {
 		if rel.Peer != nil {
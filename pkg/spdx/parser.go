@@ -23,9 +23,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -44,10 +46,34 @@ var (
 	relationshioRegExp = regexp.MustCompile(`^*(\S+)\s+([_A-Z]+)\s+(\S+)`)
 )
 
+// ReadOptions control how OpenDocWithOptions loads a document.
+type ReadOptions struct {
+	// FileStubs loads only the ID and name of the files in the document,
+	// dropping their checksums, licensing and copyright information.
+	// Read-only commands use it to open large documents with a fraction
+	// of the memory.
+	FileStubs bool
+
+	// Progress is called as the document is read with the number of
+	// bytes read so far and the size of the document.
+	Progress func(read, total int64)
+
+	// Verify requires the document to be signed and checks its
+	// signature. Signed documents are read without verifying them
+	// when not set.
+	Verify *VerifyOptions
+}
+
 // OpenDoc opens a file, parses a SPDX tag-value file and returns a loaded
 // spdx.Document object. This functions has the cyclomatic chec disabled as
 // it spans specific cases for each of the tags it recognizes.
 func OpenDoc(path string) (doc *Document, err error) {
+	return OpenDocWithOptions(path, &ReadOptions{})
+}
+
+// OpenDocWithOptions opens and parses a document like OpenDoc, reading
+// it as defined in the options.
+func OpenDocWithOptions(path string, opts *ReadOptions) (doc *Document, err error) {
 	// support reading SBOMs from STDIN
 	var file *os.File
 	var isTemp bool
@@ -87,18 +113,46 @@ func OpenDoc(path string) (doc *Document, err error) {
 		}
 	}()
 
-	format, err := DetectSBOMEncoding(file)
+	// Documents in DSSE envelopes and sigstore bundles are read from a
+	// temporary file after checking their signature
+	src := file
+	unwrapped, err := unwrapSignedDocument(file, opts.Verify)
+	if err != nil {
+		return nil, err
+	}
+	if unwrapped != nil {
+		defer func() {
+			unwrapped.Close()
+			os.Remove(unwrapped.Name())
+		}()
+		src = unwrapped
+	}
+
+	format, err := DetectSBOMEncoding(src)
 	if err != nil {
 		return nil, fmt.Errorf("detecting sbom encoding: %w", err)
 	}
 
 	logrus.Debugf("document format is %s", format)
 
+	var r io.Reader = src
+	if opts.Progress != nil {
+		info, err := src.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("checking document size: %w", err)
+		}
+		r = &progressReader{r: src, total: info.Size(), fn: opts.Progress}
+	}
+
 	switch format {
 	case "spdx":
-		return parseTagValue(file)
+		return parseTagValue(r, opts)
 	case "spdx+json":
-		return parseJSON(file)
+		return parseJSONWithOptions(r, opts)
+	case "syft+json":
+		return ImportSyftJSON(r)
+	case "trivy+json":
+		return ImportTrivyJSON(r)
 	}
 
 	return nil, errors.New("unknown SBOM encoding")
@@ -125,17 +179,44 @@ func isURL(str string) bool {
 	return err == nil && u.Scheme != "" && u.Host != ""
 }
 
+// progressReader reports the bytes read from a document.
+type progressReader struct {
+	r     io.Reader
+	read  int64
+	total int64
+	fn    func(read, total int64)
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.read += int64(n)
+	pr.fn(pr.read, pr.total)
+	return n, err
+}
+
 // parseJSON parses an SPDX document encoded in json
+func parseJSON(r io.Reader) (doc *Document, err error) {
+	return parseJSONWithOptions(r, &ReadOptions{})
+}
+
+// parseJSONWithOptions parses an SPDX document encoded in json as
+// defined in the read options.
 //
 //nolint:gocyclo
-func parseJSON(file *os.File) (doc *Document, err error) {
+func parseJSONWithOptions(r io.Reader, opts *ReadOptions) (doc *Document, err error) {
 	var jsonDoc document.Document
 
-	// Read the SPDX doc into the json struct
+	// Read the SPDX doc into the json struct. When loading file stubs,
+	// the files are decoded as they are read and left out of the data.
 	var data []byte
-	data, err = os.ReadFile(file.Name())
+	var fileStubs []*File
+	if opts.FileStubs {
+		data, fileStubs, err = readJSONFileStubs(r)
+	} else {
+		data, err = io.ReadAll(r)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("reading SBOM file: %w", err)
+		return nil, fmt.Errorf("reading SBOM data: %w", err)
 	}
 
 	var spdxVersion string
@@ -223,6 +304,7 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 	}
 
 	doc.LicenseListVersion = creationInfo.GetLicenseListVersion()
+	doc.CreatorComment = creationInfo.GetComment()
 	createdDate := creationInfo.GetCreated()
 	if createdDate != "" {
 		t, err := time.Parse("2006-01-02T15:04:05Z", createdDate)
@@ -235,77 +317,19 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 
 	allPackages := map[string]*Package{}
 	for _, pData := range jsonDoc.GetPackages() {
-		packageID := pData.GetID()
-		allPackages[packageID] = &Package{
-			Entity: Entity{
-				ID:               pData.GetID(),
-				Name:             pData.GetName(),
-				DownloadLocation: pData.GetDownloadLocation(),
-				CopyrightText:    pData.GetCopyrightText(),
-				LicenseConcluded: pData.GetLicenseDeclared(),
-				// LicenseComments:  pData.LicenseComments,
-				Relationships: []*Relationship{},
-				Checksum:      map[string]string{},
-			},
-			FilesAnalyzed:        pData.GetFilesAnalyzed(),
-			LicenseInfoFromFiles: []string{},
-			LicenseDeclared:      pData.GetLicenseDeclared(),
-			Version:              pData.GetVersion(),
-			VerificationCode:     pData.GetVerificationCode().GetValue(),
-			// Comment:              pData.Comment,
-			// HomePage:             pData.HomePage,
-			Supplier: struct {
-				Person       string
-				Organization string
-			}{},
-			Originator: struct {
-				Person       string
-				Organization string
-			}{},
-			ExternalRefs: []ExternalRef{},
-		}
-
-		if spdxVersion == "2.3" {
-			allPackages[packageID].PrimaryPurpose = pData.GetPrimaryPurpose()
-		}
-
-		for _, cs := range pData.GetChecksums() {
-			allPackages[packageID].Checksum[cs.GetAlgorithm()] = cs.GetValue()
-		}
-
-		for _, eref := range pData.GetExternalRefs() {
-			allPackages[packageID].ExternalRefs = append(
-				allPackages[packageID].ExternalRefs, ExternalRef{
-					Category: eref.GetCategory(),
-					Type:     eref.GetType(),
-					Locator:  eref.GetLocator(),
-				},
-			)
-		}
+		p := &Package{}
+		p.readJSON(pData, spdxVersion)
+		allPackages[pData.GetID()] = p
 	}
 
 	allFiles := map[string]*File{}
 	for _, fData := range jsonDoc.GetFiles() {
-		fileID := fData.GetID()
-		allFiles[fileID] = &File{
-			Entity: Entity{
-				ID:               fileID,
-				Name:             fData.GetName(),
-				CopyrightText:    fData.GetCopyrightText(),
-				LicenseConcluded: fData.GetLicenseConcluded(),
-				// LicenseComments:  pData.LicenseComments,
-				Relationships: []*Relationship{},
-				Checksum:      map[string]string{},
-			},
-			FileType: []string{},
-			LicenseInfoInFile: strings.Join(
-				fData.GetLicenseInfoInFile(), " AND ",
-			),
-		}
-
-		for _, cs := range fData.GetChecksums() {
-			allFiles[fileID].Checksum[cs.GetAlgorithm()] = cs.GetValue()
-		}
+		f := &File{}
+		f.readJSON(fData)
+		allFiles[fData.GetID()] = f
+	}
+	for _, f := range fileStubs {
+		allFiles[f.ID] = f
 	}
 
 	seenObjects := map[string]string{}
@@ -330,6 +354,7 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 		}
 		if source == nil && elementID != jsonDoc.GetID() {
 			logrus.Warnf("Unable to find SPDX source element %s", elementID)
+			doc.recordDanglingRelationship(elementID, typeID, relatedID, elementID)
 			continue
 		}
 
@@ -352,6 +377,7 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 				doc.Files[relatedID] = f
 			} else {
 				logrus.Warnf("Unable to find SPDX source element %s", relatedID)
+				doc.recordDanglingRelationship(elementID, typeID, relatedID, relatedID)
 				continue
 			}
 			seenObjects[relatedID] = relatedID
@@ -363,6 +389,7 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 			}
 			if peer == nil {
 				logrus.Warnf("unable to find SPDX related element %s", relatedID)
+				doc.recordDanglingRelationship(elementID, typeID, relatedID, relatedID)
 				continue
 			}
 			relatedID = peer.SPDXID()
@@ -372,7 +399,7 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 			rel := Relationship{
 				PeerReference:    relatedID,
 				PeerExtReference: externalID,
-				Comment:          "",
+				Comment:          r.GetComment(),
 				Type:             RelationshipType(typeID),
 				Peer:             peer,
 			}
@@ -403,6 +430,7 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 			continue
 		}
 		logrus.Errorf("unable to find package %s described by sbom", el)
+		doc.recordDanglingRelationship(jsonDoc.GetID(), string(DESCRIBES), el, el)
 	}
 
 	// Delete everything from the all maps to see if we missed anything
@@ -411,8 +439,15 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 		delete(allFiles, id)
 	}
 
-	if l := len(allPackages); l > 0 {
-		logrus.Warnf("%d packages could not be assigned to the SBOM", l)
+	// Packages not described nor related to other elements are top level
+	// elements the document does not describe
+	if len(allPackages) > 0 {
+		doc.Describes = slices.Sorted(maps.Keys(doc.Packages))
+		doc.Describes = append(doc.Describes, slices.Sorted(maps.Keys(doc.Files))...)
+		for id, p := range allPackages {
+			doc.Packages[id] = p
+		}
+		logrus.Infof("%d top level packages are not described by the SBOM", len(allPackages))
 	}
 
 	if l := len(allFiles); l > 0 {
@@ -432,13 +467,151 @@ func parseJSON(file *os.File) (doc *Document, err error) {
 		doc.ExternalDocRefs = append(doc.ExternalDocRefs, extRef)
 	}
 
+	doc.Comment = jsonDoc.GetComment()
+	doc.Annotations = parseJSONAnnotations(jsonDoc.GetAnnotations())
+
+	for _, l := range jsonDoc.GetExtractedLicensingInfos() {
+		doc.ExtractedLicenses = append(doc.ExtractedLicenses, ExtractedLicense{
+			ID:      l.GetLicenseID(),
+			Name:    l.GetName(),
+			Text:    l.GetExtractedText(),
+			Comment: l.GetComment(),
+			SeeAlso: l.GetSeeAlsos(),
+		})
+	}
+
+	for _, sData := range jsonDoc.GetSnippets() {
+		snippet := &Snippet{}
+		snippet.readJSON(sData)
+		doc.Snippets = append(doc.Snippets, snippet)
+	}
+
 	return doc, nil
 }
 
+// readJSONFileStubs reads a json document decoding only the ID and name
+// of its files. Returns the document data without the files and the
+// file stubs.
+func readJSONFileStubs(r io.Reader) (data []byte, files []*File, err error) {
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return nil, nil, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading document field: %w", err)
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected token %v in document", tok)
+		}
+
+		if key != "files" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, nil, fmt.Errorf("decoding %s: %w", key, err)
+			}
+			fields[key] = value
+			continue
+		}
+
+		if err := expectJSONDelim(dec, '['); err != nil {
+			return nil, nil, err
+		}
+		for dec.More() {
+			stub := struct {
+				ID   string `json:"SPDXID"`
+				Name string `json:"fileName"`
+			}{}
+			if err := dec.Decode(&stub); err != nil {
+				return nil, nil, fmt.Errorf("decoding file: %w", err)
+			}
+			files = append(files, &File{Entity: Entity{ID: stub.ID, Name: stub.Name, FileName: stub.Name}})
+		}
+		if err := expectJSONDelim(dec, ']'); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := expectJSONDelim(dec, '}'); err != nil {
+		return nil, nil, err
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding document data: %w", err)
+	}
+	return data, files, nil
+}
+
+// expectJSONDelim reads the next token from the decoder and checks that
+// it is the expected delimiter.
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("reading json: %w", err)
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %q in json but got %v", delim, tok)
+	}
+	return nil
+}
+
+// parseJSONAnnotations converts the annotations of a JSON element.
+func parseJSONAnnotations(jsonAnnotations []document.Annotation) []Annotation {
+	if len(jsonAnnotations) == 0 {
+		return nil
+	}
+	annotations := []Annotation{}
+	for _, a := range jsonAnnotations {
+		annotations = append(annotations, Annotation{
+			Annotator: a.GetAnnotator(),
+			Date:      a.GetDate(),
+			Type:      a.GetType(),
+			Comment:   a.GetComment(),
+		})
+	}
+	return annotations
+}
+
+// parseDate parses a date in the SPDX format (YYYY-MM-DDThh:mm:ssZ).
+func parseDate(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing date %q: %w", value, err)
+	}
+	return t, nil
+}
+
+// parseActor parses a supplier or originator string in the SPDX
+// format (Person: Name (email) or Organization: Name) and returns
+// the person and organization names.
+func parseActor(value string) (person, organization string) {
+	typ, name, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", ""
+	}
+	switch strings.TrimSpace(typ) {
+	case entPerson:
+		return strings.TrimSpace(name), ""
+	case entOrganization:
+		return "", strings.TrimSpace(name)
+	}
+	return "", ""
+}
+
+// fileDetailTags are the tag-value tags skipped when loading file stubs
+var fileDetailTags = []string{
+	"LicenseConcluded", "LicenseInfoInFile", "LicenseComments", "FileCopyrightText",
+	"FileAttributionText", "FileChecksum", "FileType", "FileComment", "FileNotice",
+}
+
 // parseTagValue parses an SPDX SBOM in tag-value format
 //
 //nolint:gocyclo
-func parseTagValue(file *os.File) (doc *Document, err error) {
+func parseTagValue(r io.Reader, opts *ReadOptions) (doc *Document, err error) {
 	// Create a blank document
 	doc = &Document{
 		Packages:        map[string]*Package{},
@@ -446,7 +619,7 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 		ExternalDocRefs: []ExternalDocumentRef{},
 	}
 	// Scan the file, looking for tags
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	i := 0 // Line counter
 	var currentEntity *Entity
 	var currentObject Object
@@ -458,6 +631,11 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 		Relationship string
 		Peer         string
 		ExtDoc       string
+		Comment      string
+	}{}
+	annotations := []struct {
+		Target     string
+		Annotation Annotation
 	}{}
 	for scanner.Scan() {
 		// If we are capturing text for a multiline value, read and add
@@ -501,6 +679,12 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 
 		captureMultiline = false
 
+		// When loading file stubs, skip the details of the files
+		if _, isFile := currentObject.(*File); isFile && opts.FileStubs && slices.Contains(fileDetailTags, tag) {
+			i++
+			continue
+		}
+
 		switch tag {
 		case "FileName", "PackageName":
 			// Both FileName or PackageName signal the start of a new entity
@@ -559,6 +743,30 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 			currentObject.(*Package).FileName = value //nolint: errcheck
 		case "PackageHomePage":
 			currentObject.(*Package).HomePage = value //nolint: errcheck
+		case "PackageSummary":
+			currentObject.(*Package).Summary = value //nolint: errcheck
+		case "PackageDescription":
+			currentObject.(*Package).Description = value //nolint: errcheck
+		case "PackageSourceInfo":
+			currentObject.(*Package).SourceInfo = value //nolint: errcheck
+		case "PackageAttributionText", "FileAttributionText":
+			currentEntity.AttributionTexts = append(currentEntity.AttributionTexts, value)
+		case "FileType":
+			currentObject.(*File).FileType = append(currentObject.(*File).FileType, value) //nolint: errcheck
+		case "FileComment":
+			currentObject.(*File).Comment = value //nolint: errcheck
+		case "FileNotice":
+			currentObject.(*File).NoticeText = value //nolint: errcheck
+		case "PackageOriginator":
+			if value == NOASSERTION {
+				continue
+			}
+			person, org := parseActor(value)
+			if person == "" && org == "" {
+				return nil, fmt.Errorf("invalid originator tag syntax at line %d: %s", i, value)
+			}
+			currentObject.(*Package).Originator.Person = person    //nolint: errcheck
+			currentObject.(*Package).Originator.Organization = org //nolint: errcheck
 		case "PrimaryPackagePurpose":
 			purpose := ""
 			for _, pp := range PackagePurposes {
@@ -572,6 +780,19 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 				return nil, fmt.Errorf("invalid package purpose found %s", value)
 			}
 			currentObject.(*Package).PrimaryPurpose = purpose //nolint: errcheck
+		case "ReleaseDate", "BuiltDate", "ValidUntilDate":
+			t, err := parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s at line %d: %w", tag, i, err)
+			}
+			switch tag {
+			case "ReleaseDate":
+				currentObject.(*Package).ReleaseDate = t //nolint: errcheck
+			case "BuiltDate":
+				currentObject.(*Package).BuiltDate = t //nolint: errcheck
+			case "ValidUntilDate":
+				currentObject.(*Package).ValidUntilDate = t //nolint: errcheck
+			}
 		case "PackageLicenseInfoFromFiles":
 			have := false
 			// Check if we already have the license
@@ -641,9 +862,15 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 				Relationship string
 				Peer         string
 				ExtDoc       string
+				Comment      string
 			}{
-				matches[1], matches[2], matches[3], ext,
+				matches[1], matches[2], matches[3], ext, "",
 			})
+		case "RelationshipComment":
+			if len(rels) == 0 {
+				return nil, fmt.Errorf("relationship comment without relationship at line %d", i)
+			}
+			rels[len(rels)-1].Comment = value
 		case "PackageDownloadLocation":
 			if value != NONE {
 				currentEntity.DownloadLocation = value
@@ -678,6 +905,60 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 					match[1], i,
 				)
 			}
+		case "CreatorComment":
+			doc.CreatorComment = value
+		case "DocumentComment":
+			doc.Comment = value
+			// Licenses not in the SPDX list
+		case "LicenseID":
+			doc.ExtractedLicenses = append(doc.ExtractedLicenses, ExtractedLicense{ID: value})
+		case "ExtractedText", "LicenseName", "LicenseCrossReference", "LicenseComment":
+			if len(doc.ExtractedLicenses) == 0 {
+				return nil, fmt.Errorf("%s found outside of license at line %d", tag, i)
+			}
+			l := &doc.ExtractedLicenses[len(doc.ExtractedLicenses)-1]
+			switch tag {
+			case "ExtractedText":
+				l.Text = value
+			case "LicenseName":
+				l.Name = value
+			case "LicenseCrossReference":
+				l.SeeAlso = append(l.SeeAlso, value)
+			case "LicenseComment":
+				l.Comment = value
+			}
+			// Annotations
+		case "Annotator":
+			annotations = append(annotations, struct {
+				Target     string
+				Annotation Annotation
+			}{Annotation: Annotation{Annotator: value}})
+		case "AnnotationDate", "AnnotationType", "AnnotationComment", "SPDXREF":
+			if len(annotations) == 0 {
+				return nil, fmt.Errorf("%s found outside of annotation at line %d", tag, i)
+			}
+			a := &annotations[len(annotations)-1]
+			switch tag {
+			case "AnnotationDate":
+				a.Annotation.Date = value
+			case "AnnotationType":
+				a.Annotation.Type = value
+			case "AnnotationComment":
+				a.Annotation.Comment = value
+			case "SPDXREF":
+				a.Target = value
+			}
+			// Snippets
+		case "SnippetSPDXID":
+			doc.Snippets = append(doc.Snippets, &Snippet{ID: value})
+		case "SnippetFromFileSPDXID", "SnippetByteRange", "SnippetLineRange", "SnippetLicenseConcluded",
+			"LicenseInfoInSnippet", "SnippetLicenseComments", "SnippetCopyrightText", "SnippetComment", "SnippetName":
+			if len(doc.Snippets) == 0 {
+				return nil, fmt.Errorf("%s found outside of snippet at line %d", tag, i)
+			}
+			if err := setSnippetField(doc.Snippets[len(doc.Snippets)-1], tag, value); err != nil {
+				return nil, fmt.Errorf("parsing snippet at line %d: %w", i, err)
+			}
 		case "DataLicense":
 			doc.DataLicense = value
 		case "DocumentName":
@@ -727,7 +1008,7 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 	}
 
 	if currentEntity == nil {
-		return nil, fmt.Errorf("invalid file %s", file.Name())
+		return nil, errors.New("invalid document, no elements found")
 	}
 	// Add the last object from the doc
 	currentObject.SetEntity(currentEntity)
@@ -743,10 +1024,18 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 
 	// Now assign the relationships to the proper objects
 	owned := map[string]struct{}{}
+	described := []string{}
 	for _, rdata := range rels {
 		logrus.Debugf("Procesing %s %s %s", rdata.Source, rdata.Relationship, rdata.Peer)
 		// If the source is the doc. Add them
 		if rdata.Source == doc.ID {
+			if objects[rdata.Peer] == nil {
+				doc.recordDanglingRelationship(rdata.Source, rdata.Relationship, rdata.Peer, rdata.Peer)
+				continue
+			}
+			if rdata.Relationship == string(DESCRIBES) {
+				described = append(described, rdata.Peer)
+			}
 			if p, ok := objects[rdata.Peer].(*Package); ok {
 				logrus.Debugf("doc %s describes package %s", doc.ID, rdata.Peer)
 				doc.Packages[rdata.Peer] = p
@@ -781,11 +1070,28 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 			Type:             RelationshipType(rdata.Relationship),
 			Peer:             objects[rdata.Peer],
 			PeerExtReference: rdata.ExtDoc,
-			// Comment:          "",
+			Comment:          rdata.Comment,
 		})
 		owned[rdata.Peer] = struct{}{}
 	}
 
+	// Assign the annotations to their elements
+	for _, a := range annotations {
+		switch {
+		case a.Target == doc.ID:
+			doc.Annotations = append(doc.Annotations, a.Annotation)
+		case objects[a.Target] != nil:
+			switch o := objects[a.Target].(type) {
+			case *Package:
+				o.Annotations = append(o.Annotations, a.Annotation)
+			case *File:
+				o.Annotations = append(o.Annotations, a.Annotation)
+			}
+		default:
+			logrus.Warnf("Unable to find element %s of annotation", a.Target)
+		}
+	}
+
 	// Now, finally any objects not referenced should be made
 	// leafs of the document
 	for id, obj := range objects {
@@ -800,9 +1106,46 @@ func parseTagValue(file *os.File) (doc *Document, err error) {
 		}
 	}
 
+	// Record the described elements when the document does not describe
+	// all of its top level elements
+	slices.Sort(described)
+	described = slices.Compact(described)
+	if len(described) > 0 && len(described) < len(doc.Packages)+len(doc.Files) {
+		doc.Describes = described
+	}
+
 	return doc, nil
 }
 
+// setSnippetField sets the value of a snippet tag.
+func setSnippetField(snippet *Snippet, tag, value string) (err error) {
+	switch tag {
+	case "SnippetFromFileSPDXID":
+		snippet.FromFile = value
+	case "SnippetByteRange":
+		snippet.ByteRange, err = ParseSnippetRange(value)
+	case "SnippetLineRange":
+		snippet.LineRange, err = ParseSnippetRange(value)
+	case "SnippetLicenseConcluded":
+		if value != NOASSERTION {
+			snippet.LicenseConcluded = value
+		}
+	case "LicenseInfoInSnippet":
+		snippet.LicenseInfoInSnippet = append(snippet.LicenseInfoInSnippet, value)
+	case "SnippetLicenseComments":
+		snippet.LicenseComments = value
+	case "SnippetCopyrightText":
+		if value != NOASSERTION {
+			snippet.CopyrightText = value
+		}
+	case "SnippetComment":
+		snippet.Comment = value
+	case "SnippetName":
+		snippet.Name = value
+	}
+	return err
+}
+
 // detectSBOMEncoding reads a few bytes from the SBOM and returns.
 func DetectSBOMEncoding(f *os.File) (format string, err error) {
 	fileScanner := bufio.NewScanner(f)
@@ -819,6 +1162,15 @@ func DetectSBOMEncoding(f *os.File) (format string, err error) {
 			break
 		}
 
+		// Reports of other scanners that can be imported
+		if strings.Contains(fileScanner.Text(), "\"artifactRelationships\"") {
+			format = "syft+json"
+			break
+		} else if strings.Contains(fileScanner.Text(), "\"ArtifactName\"") {
+			format = "trivy+json"
+			break
+		}
+
 		if strings.Contains(fileScanner.Text(), "bomFormat") && strings.Contains(fileScanner.Text(), "CycloneDX") {
 			looksLikeCDX = true
 		}
@@ -18,7 +18,10 @@ package spdx
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -73,6 +76,22 @@ func TestDetectSBOMEncoding(t *testing.T) {
 			false,
 			"spdx+json",
 		},
+		{
+			`{
+  "artifacts": [],
+  "artifactRelationships": [],
+`,
+			false,
+			"syft+json",
+		},
+		{
+			`{
+  "SchemaVersion": 2,
+  "ArtifactName": "alpine:3.19",
+`,
+			false,
+			"trivy+json",
+		},
 		{
 			// Junk in file
 			`lskjdflksjdflkjsdf`,
@@ -154,3 +173,118 @@ func TestParseJsonExternal(t *testing.T) {
 		}
 	}
 }
+
+func TestParseJsonFullFields(t *testing.T) {
+	file, err := os.Open("testdata/full-fields.spdx.json")
+	require.NoError(t, err)
+	defer file.Close()
+
+	doc, err := parseJSON(file)
+	require.NoError(t, err)
+	checkFullFieldsDocument(t, doc)
+
+	// Round trip the document through the tag-value format
+	doc.Packages["SPDXRef-Package-app"].Relationships[0].FullRender = true
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+
+	tmp, err := os.CreateTemp(t.TempDir(), "full-fields-*.spdx")
+	require.NoError(t, err)
+	defer tmp.Close()
+	_, err = tmp.WriteString(rendered)
+	require.NoError(t, err)
+	_, err = tmp.Seek(0, 0)
+	require.NoError(t, err)
+
+	parsed, err := parseTagValue(tmp, &ReadOptions{})
+	require.NoError(t, err)
+	checkFullFieldsDocument(t, parsed)
+}
+
+func checkFullFieldsDocument(t *testing.T, doc *Document) {
+	t.Helper()
+	trim := func(s string) string { return strings.TrimSpace(s) }
+
+	require.Equal(t, "Document with all the optional fields", trim(doc.Comment))
+	require.Len(t, doc.Annotations, 1)
+	require.Equal(t, "Tool: example-tool-1.0", doc.Annotations[0].Annotator)
+	require.Equal(t, "OTHER", doc.Annotations[0].Type)
+
+	require.Len(t, doc.ExtractedLicenses, 1)
+	require.Equal(t, "LicenseRef-custom", doc.ExtractedLicenses[0].ID)
+	require.Equal(t, "Custom font license", doc.ExtractedLicenses[0].Name)
+	require.Equal(t, "You may use this font for any purpose.", trim(doc.ExtractedLicenses[0].Text))
+	require.Equal(t, []string{"https://example.com/font-license"}, doc.ExtractedLicenses[0].SeeAlso)
+
+	require.Len(t, doc.Snippets, 1)
+	require.Equal(t, "SPDXRef-File-main", doc.Snippets[0].FromFile)
+	require.Equal(t, SnippetRange{Start: 310, End: 420}, doc.Snippets[0].ByteRange)
+	require.Equal(t, SnippetRange{Start: 5, End: 23}, doc.Snippets[0].LineRange)
+	require.Equal(t, "GPL-2.0-only", doc.Snippets[0].LicenseConcluded)
+	require.Equal(t, []string{"GPL-2.0-only"}, doc.Snippets[0].LicenseInfoInSnippet)
+
+	pkg, ok := doc.Packages["SPDXRef-Package-app"]
+	require.True(t, ok)
+	require.Equal(t, "MIT AND LicenseRef-custom", pkg.LicenseConcluded)
+	require.Equal(t, "MIT", pkg.LicenseDeclared)
+	require.Equal(t, "https://example.com/app", pkg.HomePage)
+	require.Equal(t, "Example Inc", pkg.Supplier.Organization)
+	require.Equal(t, "Jane Doe (jane@example.com)", pkg.Originator.Person)
+	require.Equal(t, "An example application", trim(pkg.Summary))
+	require.Equal(t, "Built from the v1.0.0 tag", trim(pkg.SourceInfo))
+	require.Equal(t, "The bundled font has its own license", trim(pkg.LicenseComments))
+	require.Equal(t, "APPLICATION", pkg.PrimaryPurpose)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), pkg.ReleaseDate.UTC())
+	require.Equal(t, time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), pkg.BuiltDate.UTC())
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), pkg.ValidUntilDate.UTC())
+	require.Len(t, pkg.AttributionTexts, 1)
+	require.Len(t, pkg.Annotations, 1)
+	require.Equal(t, "REVIEW", pkg.Annotations[0].Type)
+	require.Equal(t, "License reviewed", trim(pkg.Annotations[0].Comment))
+
+	require.Len(t, pkg.Relationships, 1)
+	require.Equal(t, "Main file", trim(pkg.Relationships[0].Comment))
+	f, ok := pkg.Relationships[0].Peer.(*File)
+	require.True(t, ok)
+	require.Equal(t, "Main source file", trim(f.Comment))
+	require.Equal(t, "Some notice", trim(f.NoticeText))
+	require.Equal(t, []string{"SOURCE"}, f.FileType)
+}
+
+func TestOpenDocFileStubs(t *testing.T) {
+	full, err := OpenDoc("testdata/full-fields.spdx.json")
+	require.NoError(t, err)
+
+	// Write the document as tag-value to read stubs from both formats
+	rendered, err := full.Render()
+	require.NoError(t, err)
+	tvPath := filepath.Join(t.TempDir(), "full-fields.spdx")
+	require.NoError(t, os.WriteFile(tvPath, []byte(rendered), os.FileMode(0o644)))
+
+	for _, path := range []string{"testdata/full-fields.spdx.json", tvPath} {
+		var read, total int64
+		doc, err := OpenDocWithOptions(path, &ReadOptions{
+			FileStubs: true,
+			Progress:  func(n, size int64) { read, total = n, size },
+		})
+		require.NoError(t, err, path)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, info.Size(), total)
+		require.Equal(t, total, read)
+
+		require.Len(t, doc.Packages, 1)
+		pkg, ok := doc.Packages["SPDXRef-Package-app"]
+		require.True(t, ok, path)
+		require.Equal(t, full.Packages["SPDXRef-Package-app"].Version, pkg.Version)
+
+		files := pkg.Files()
+		require.Len(t, files, 1)
+		require.Equal(t, "SPDXRef-File-main", files[0].ID)
+		require.Equal(t, "./main.c", files[0].Name)
+		require.Empty(t, files[0].Checksum)
+		require.Empty(t, files[0].LicenseConcluded)
+		require.Empty(t, files[0].Comment)
+	}
+}
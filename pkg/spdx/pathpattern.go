@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// IsPathPattern returns true if a path has glob metacharacters
+func IsPathPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// ExpandPathPattern returns the sorted list of paths matching a pattern.
+// Patterns use the filepath.Match syntax, and a ** path segment matches
+// any number of directories, eg dist/**/*.tar.gz.
+func ExpandPathPattern(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("parsing pattern %s: %w", pattern, err)
+		}
+		slices.Sort(matches)
+		return matches, nil
+	}
+
+	// Walk from the longest leading path without metacharacters
+	segments := strings.Split(filepath.Clean(pattern), string(filepath.Separator))
+	i := 0
+	for i < len(segments) && !IsPathPattern(segments[i]) {
+		i++
+	}
+	root := strings.Join(segments[:i], string(filepath.Separator))
+	switch {
+	case root == filepath.VolumeName(pattern) && filepath.IsAbs(pattern):
+		// The root of the filesystem or of a Windows drive
+		root += string(filepath.Separator)
+	case root == "":
+		root = "."
+	}
+	// Check the pattern syntax before walking
+	for _, s := range segments[i:] {
+		if _, err := filepath.Match(s, ""); err != nil {
+			return nil, fmt.Errorf("parsing pattern %s: %w", pattern, err)
+		}
+	}
+
+	matches := []string{}
+	err := filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if matchPathSegments(segments[i:], strings.Split(rel, string(filepath.Separator))) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("expanding pattern %s: %w", pattern, err)
+	}
+	slices.Sort(matches)
+	return matches, nil
+}
+
+// matchPathSegments matches the segments of a path against the segments
+// of a pattern, where ** matches any number of segments
+func matchPathSegments(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(path); i++ {
+				if matchPathSegments(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(path) == 0 {
+			return false
+		}
+		// The syntax of the segments is checked before matching
+		if ok, _ := filepath.Match(pattern[0], path[0]); !ok { //nolint:errcheck
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+// expandInputPaths expands the paths and patterns given as inputs of a
+// kind (eg "archive"). Only directories are returned when dirs is true,
+// only files otherwise. Paths or patterns matching nothing of the kind
+// are an error listing what they matched.
+func expandInputPaths(kind string, patterns []string, dirs bool) ([]string, error) {
+	want := "files"
+	if dirs {
+		want = "directories"
+	}
+	paths := []string{}
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if IsPathPattern(pattern) {
+			var err error
+			matches, err = ExpandPathPattern(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("expanding %s pattern: %w", kind, err)
+			}
+		} else if !util.Exists(pattern) {
+			return nil, fmt.Errorf("%s %s not found", kind, pattern)
+		}
+
+		found, others := []string{}, []string{}
+		for _, m := range matches {
+			if util.IsDir(m) == dirs {
+				found = append(found, m)
+			} else {
+				others = append(others, m)
+			}
+		}
+		switch {
+		case len(found) == 0 && len(others) == 0:
+			return nil, fmt.Errorf("%s pattern %s did not match any path", kind, pattern)
+		case len(found) == 0:
+			return nil, fmt.Errorf(
+				"%s %s matched no %s, only: %s", kind, pattern, want, strings.Join(others, ", "),
+			)
+		}
+		if IsPathPattern(pattern) {
+			logrus.Infof("%s pattern %s matched %d %s: %s", kind, pattern, len(found), want, strings.Join(found, ", "))
+		}
+		if len(others) > 0 {
+			logrus.Debugf("Skipping paths matched by %s which are not %s: %s", pattern, want, strings.Join(others, ", "))
+		}
+		paths = append(paths, found...)
+	}
+	return paths, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPathPattern(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{
+		"dist/app.tar.gz",
+		"dist/app.zip",
+		"dist/linux/amd64/app.tar.gz",
+		"dist/linux/arm64/app.tar.gz",
+		"src/main.go",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), []byte(f), os.FileMode(0o644)))
+	}
+
+	for _, tc := range []struct {
+		pattern  string
+		expected []string
+	}{
+		{"dist/*.tar.gz", []string{"dist/app.tar.gz"}},
+		{"dist/**/*.tar.gz", []string{"dist/app.tar.gz", "dist/linux/amd64/app.tar.gz", "dist/linux/arm64/app.tar.gz"}},
+		{"**/amd64/*", []string{"dist/linux/amd64/app.tar.gz"}},
+		{"dist/linux/**", []string{"dist/linux/amd64", "dist/linux/amd64/app.tar.gz", "dist/linux/arm64", "dist/linux/arm64/app.tar.gz"}},
+		{"*", []string{"dist", "src"}},
+		{"dist/*.rpm", []string{}},
+		{"build/**/*.rpm", []string{}},
+	} {
+		matches, err := ExpandPathPattern(filepath.Join(dir, tc.pattern))
+		require.NoError(t, err, tc.pattern)
+		expected := []string{}
+		for _, e := range tc.expected {
+			expected = append(expected, filepath.Join(dir, e))
+		}
+		if len(expected) == 0 {
+			require.Empty(t, matches, tc.pattern)
+		} else {
+			require.Equal(t, expected, matches, tc.pattern)
+		}
+	}
+
+	// Relative patterns return relative paths
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+	matches, err := ExpandPathPattern("**/arm64/*.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, []string{"dist/linux/arm64/app.tar.gz"}, matches)
+
+	_, err = ExpandPathPattern("dist/**/[.tar.gz")
+	require.Error(t, err)
+}
+
+func TestExpandInputPaths(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "dist", "docs"), os.FileMode(0o755)))
+	for _, f := range []string{"dist/a.tar.gz", "dist/b.tar.gz"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, f), []byte(f), os.FileMode(0o644)))
+	}
+
+	paths, err := expandInputPaths("archive", []string{filepath.Join(dir, "dist", "*")}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "dist", "a.tar.gz"), filepath.Join(dir, "dist", "b.tar.gz")}, paths)
+
+	paths, err = expandInputPaths("directory", []string{filepath.Join(dir, "dist", "*")}, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "dist", "docs")}, paths)
+
+	// Literal paths are kept
+	paths, err = expandInputPaths("directory", []string{dir}, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{dir}, paths)
+
+	// The errors list what the patterns matched
+	_, err = expandInputPaths("archive", []string{filepath.Join(dir, "dist", "d*")}, false)
+	require.ErrorContains(t, err, "matched no files, only: "+filepath.Join(dir, "dist", "docs"))
+
+	_, err = expandInputPaths("file", []string{filepath.Join(dir, "*.rpm")}, false)
+	require.ErrorContains(t, err, "did not match any path")
+
+	_, err = expandInputPaths("file", []string{filepath.Join(dir, "missing.txt")}, false)
+	require.ErrorContains(t, err, "not found")
+}
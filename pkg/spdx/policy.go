@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/release-utils/command"
+)
+
+// DefaultPolicyQuery is the rego query that returns the violations of a
+// document. Policies add messages to the deny set of the bom package:
+//
+//	package bom
+//
+//	deny contains msg if {
+//		some pkg in input.packages
+//		not pkg.supplier
+//		msg := sprintf("package %s has no supplier", [pkg.name])
+//	}
+const DefaultPolicyQuery = "data.bom.deny"
+
+// ErrPolicyEngineNotFound is returned when the executable needed to
+// evaluate a kind of policy is not installed.
+var ErrPolicyEngineNotFound = errors.New("policy engine not found")
+
+// policyEngines are the executables evaluating each kind of policy, with
+// where to get them from.
+var policyEngines = map[string]struct{ kind, install string }{
+	"opa": {"rego", "https://www.openpolicyagent.org/docs/latest/#running-opa"},
+	"cue": {"CUE", "https://cuelang.org/docs/introduction/installation/"},
+}
+
+// PolicyOptions configure the evaluation of organizational policies
+// against a document. bom does not embed the policy engines: policies are
+// evaluated by the opa and cue executables, which have to be installed
+// in the PATH to use them.
+type PolicyOptions struct {
+	Rego  []string // Rego policy files or directories, evaluated with opa
+	CUE   []string // CUE files the document has to unify with, checked with cue vet
+	Query string   // Rego query returning the violations, DefaultPolicyQuery if empty
+}
+
+// PolicyInput returns the data policies evaluate: the document in the
+// SPDX 2.3 JSON format, with its packages, files and relationships.
+func (d *Document) PolicyInput() ([]byte, error) {
+	data, err := d.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("encoding policy input: %w", err)
+	}
+	return data, nil
+}
+
+// EvaluatePolicies checks the document against the policies in the
+// options and returns the messages of the rules it violates.
+func (d *Document) EvaluatePolicies(opts *PolicyOptions) ([]string, error) {
+	if len(opts.Rego) == 0 && len(opts.CUE) == 0 {
+		return nil, errors.New("no policies to evaluate")
+	}
+
+	// Check all the engines before evaluating any policy
+	var opa, cue string
+	var err error
+	if len(opts.Rego) > 0 {
+		if opa, err = policyEngine("opa"); err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.CUE) > 0 {
+		if cue, err = policyEngine("cue"); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := d.PolicyInput()
+	if err != nil {
+		return nil, err
+	}
+	input, err := os.CreateTemp("", "bom-policy-input-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("creating policy input file: %w", err)
+	}
+	defer os.Remove(input.Name())
+	if _, err := input.Write(data); err != nil {
+		input.Close()
+		return nil, fmt.Errorf("writing policy input: %w", err)
+	}
+	if err := input.Close(); err != nil {
+		return nil, fmt.Errorf("closing policy input: %w", err)
+	}
+
+	violations := []string{}
+	if len(opts.Rego) > 0 {
+		v, err := evaluateRego(opa, input.Name(), opts)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	if len(opts.CUE) > 0 {
+		v, err := evaluateCUE(cue, input.Name(), opts.CUE)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+	return violations, nil
+}
+
+// policyEngine returns the path of a policy engine executable
+func policyEngine(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		engine := policyEngines[name]
+		return "", fmt.Errorf(
+			"%w: %s policies are evaluated with the %s executable, which is not in the PATH. Install it from %s",
+			ErrPolicyEngineNotFound, engine.kind, name, engine.install,
+		)
+	}
+	return path, nil
+}
+
+// evaluateRego runs the policy query with opa eval
+func evaluateRego(opa, inputPath string, opts *PolicyOptions) ([]string, error) {
+	query := opts.Query
+	if query == "" {
+		query = DefaultPolicyQuery
+	}
+
+	args := []string{"eval", "--format", "json", "--input", inputPath}
+	for _, p := range opts.Rego {
+		args = append(args, "--data", p)
+	}
+	args = append(args, query)
+	output, err := command.New(opa, args...).RunSilentSuccessOutput()
+	if err != nil {
+		return nil, fmt.Errorf("evaluating rego policies: %w", err)
+	}
+	return parseRegoResult([]byte(output.Output()), query)
+}
+
+// parseRegoResult reads the violations from the output of opa eval. The
+// query can return a set or array of messages, or an object with the
+// violations as keys. Messages that are not strings are returned as JSON.
+// An undefined query has no violations.
+func parseRegoResult(data []byte, query string) ([]string, error) {
+	output := struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}{}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("decoding opa output: %w", err)
+	}
+
+	violations := []string{}
+	for _, result := range output.Result {
+		for _, expr := range result.Expressions {
+			var list []json.RawMessage
+			if err := json.Unmarshal(expr.Value, &list); err == nil {
+				for _, v := range list {
+					violations = append(violations, policyMessage(v))
+				}
+				continue
+			}
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(expr.Value, &object); err == nil {
+				violations = append(violations, slices.Sorted(maps.Keys(object))...)
+				continue
+			}
+			return nil, fmt.Errorf("query %s must return a set of violations, got %s", query, expr.Value)
+		}
+	}
+	return violations, nil
+}
+
+// policyMessage returns a violation as text
+func policyMessage(v json.RawMessage) string {
+	var msg string
+	if err := json.Unmarshal(v, &msg); err == nil {
+		return msg
+	}
+	return string(v)
+}
+
+// evaluateCUE checks the input against the CUE files with cue vet
+func evaluateCUE(cue, inputPath string, files []string) ([]string, error) {
+	args := append([]string{"vet", "-c"}, files...)
+	args = append(args, inputPath)
+	status, err := command.New(cue, args...).RunSilent()
+	if err != nil {
+		return nil, fmt.Errorf("running cue vet: %w", err)
+	}
+	if status.Success() {
+		return []string{}, nil
+	}
+	violations := parseCUEErrors(status.Error())
+	if len(violations) == 0 {
+		return nil, fmt.Errorf("cue vet failed with exit code %d", status.ExitCode())
+	}
+	return violations, nil
+}
+
+// parseCUEErrors splits the output of cue vet into its errors. Each error
+// starts on an unindented line, followed by the indented positions where
+// the conflicting values are defined.
+func parseCUEErrors(output string) []string {
+	violations := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(violations) > 0 {
+			violations[len(violations)-1] += "\n" + strings.TrimRight(line, " ")
+			continue
+		}
+		violations = append(violations, strings.TrimSpace(line))
+	}
+	return violations
+}
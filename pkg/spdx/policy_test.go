@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRegoResult(t *testing.T) {
+	for _, tc := range []struct {
+		output    string
+		expected  []string
+		shouldErr bool
+	}{
+		// Undefined query
+		{`{}`, []string{}, false},
+		// Set of messages
+		{
+			`{"result":[{"expressions":[{"value":["package a has no supplier","package b is too old"],"text":"data.bom.deny"}]}]}`,
+			[]string{"package a has no supplier", "package b is too old"}, false,
+		},
+		// Structured violations are returned as JSON
+		{
+			`{"result":[{"expressions":[{"value":[{"msg":"denied","package":"a"}]}]}]}`,
+			[]string{`{"msg":"denied","package":"a"}`}, false,
+		},
+		// Objects with the violations as keys
+		{`{"result":[{"expressions":[{"value":{"b":true,"a":true}}]}]}`, []string{"a", "b"}, false},
+		{`{"result":[{"expressions":[{"value":true}]}]}`, nil, true},
+		{`not json`, nil, true},
+	} {
+		violations, err := parseRegoResult([]byte(tc.output), DefaultPolicyQuery)
+		if tc.shouldErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, violations)
+	}
+}
+
+func TestParseCUEErrors(t *testing.T) {
+	output := `packages.0.supplier: incomplete value string:
+    ./policy.cue:4:13
+packages.1.versionInfo: invalid value "0.1.0" (out of bound !~"^0\\."):
+    ./policy.cue:5:16
+    ./input.json:12:20
+
+`
+	require.Equal(t, []string{
+		"packages.0.supplier: incomplete value string:\n    ./policy.cue:4:13",
+		"packages.1.versionInfo: invalid value \"0.1.0\" (out of bound !~\"^0\\\\.\"):\n    ./policy.cue:5:16\n    ./input.json:12:20",
+	}, parseCUEErrors(output))
+	require.Empty(t, parseCUEErrors("\n"))
+}
+
+func TestPolicyInput(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "policy-test"
+	pkg := NewPackage()
+	pkg.ID = "SPDXRef-Package-app"
+	pkg.Name = "app"
+	pkg.Version = "1.0.0"
+	pkg.Supplier.Organization = "Example"
+	require.NoError(t, doc.AddPackage(pkg))
+
+	data, err := doc.PolicyInput()
+	require.NoError(t, err)
+	input := struct {
+		Name     string `json:"name"`
+		Packages []struct {
+			ID       string `json:"SPDXID"`
+			Version  string `json:"versionInfo"`
+			Supplier string `json:"supplier"`
+		} `json:"packages"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &input))
+	require.Equal(t, "policy-test", input.Name)
+	require.Len(t, input.Packages, 1)
+	require.Equal(t, "SPDXRef-Package-app", input.Packages[0].ID)
+	require.Equal(t, "1.0.0", input.Packages[0].Version)
+	require.Equal(t, "Organization: Example", input.Packages[0].Supplier)
+
+	_, err = doc.EvaluatePolicies(&PolicyOptions{})
+	require.Error(t, err)
+}
+
+func TestPolicyEngineNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	doc := NewDocument()
+	for _, opts := range []*PolicyOptions{
+		{Rego: []string{"policy.rego"}},
+		{CUE: []string{"policy.cue"}},
+	} {
+		_, err := doc.EvaluatePolicies(opts)
+		require.ErrorIs(t, err, ErrPolicyEngineNotFound)
+		require.ErrorContains(t, err, "Install it from https://")
+	}
+}
+
+func TestEvaluateRegoPolicies(t *testing.T) {
+	if _, err := exec.LookPath("opa"); err != nil {
+		t.Skip("opa is not installed")
+	}
+	doc := NewDocument()
+	for _, name := range []string{"supplied", "unsupplied"} {
+		pkg := NewPackage()
+		pkg.ID = "SPDXRef-Package-" + name
+		pkg.Name = name
+		if name == "supplied" {
+			pkg.Supplier.Organization = "Example"
+		}
+		require.NoError(t, doc.AddPackage(pkg))
+	}
+
+	policy := filepath.Join(t.TempDir(), "policy.rego")
+	require.NoError(t, os.WriteFile(policy, []byte(`package bom
+
+import rego.v1
+
+deny contains msg if {
+	some pkg in input.packages
+	not pkg.supplier
+	msg := sprintf("package %s has no supplier", [pkg.name])
+}
+`), 0o600))
+
+	violations, err := doc.EvaluatePolicies(&PolicyOptions{Rego: []string{policy}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"package unsupplied has no supplier"}, violations)
+}
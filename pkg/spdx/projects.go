@@ -0,0 +1,554 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+// projectManifests are the manifests read to identify the nested
+// projects of the ecosystems whose dependencies are not resolved by a
+// build tool, in order of preference. Projects with a manifest that is
+// not read (nil read function) are recorded with the directory name.
+var projectManifests = []struct {
+	lang     Language
+	manifest string
+	read     func(path string) (*Package, []*manifestDependency, error)
+}{
+	{LangNode, "package.json", readNodeProject},
+	{LangPython, "pyproject.toml", readPythonProject},
+	{LangPython, "requirements.txt", readRequirementsProject},
+	{LangPython, "setup.py", nil},
+	{LangPython, "Pipfile", nil},
+	{LangJava, "pom.xml", readMavenProject},
+	{LangJava, "build.gradle", nil},
+	{LangJava, "build.gradle.kts", nil},
+	{LangRust, "Cargo.toml", readCargoProject},
+}
+
+// manifestDependency is a dependency declared in a project manifest
+type manifestDependency struct {
+	Type        string // purl type of the ecosystem
+	Namespace   string
+	Name        string
+	Version     string // only set when the manifest pins the version
+	Requirement string // the version requirement as declared
+	Dev         bool   // development, test or build only dependency
+}
+
+// fullName returns the name of the dependency including its namespace
+func (dep *manifestDependency) fullName() string {
+	if dep.Namespace == "" {
+		return dep.Name
+	}
+	return dep.Namespace + "/" + dep.Name
+}
+
+// toSPDXPackage returns a package describing the dependency of the
+// project package parent, declared in its manifest.
+func (dep *manifestDependency) toSPDXPackage(parent *Package, manifest string) *Package {
+	pkg := NewPackage()
+	pkg.Name = dep.fullName()
+	pkg.Version = dep.Version
+	pkg.PrimaryPurpose = "LIBRARY"
+	pkg.Comment = "Dependency declared in " + manifest
+	if dep.Dev {
+		pkg.Comment = "Development dependency declared in " + manifest
+	}
+	if dep.Requirement != "" && dep.Requirement != dep.Version {
+		pkg.Comment += fmt.Sprintf(" as %q", dep.Requirement)
+	}
+	pkg.BuildID(parent.ID, dep.Type, dep.Namespace, dep.Name)
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator: purl.NewPackageURL(
+			dep.Type, dep.Namespace, dep.Name, dep.Version, nil, "",
+		).ToString(),
+	})
+	return pkg
+}
+
+// manifestProjectPackages returns the packages of the projects found in
+// a subdirectory of dirPath, one per language detected in it, with the
+// dependencies declared in their manifests. Go modules are not read,
+// their dependencies are resolved by goProjectPackage.
+func (spdx *SPDX) manifestProjectPackages(parent *Package, dirPath string, project *LanguageReport) ([]*Package, error) {
+	pkgs := []*Package{}
+	for _, lang := range project.Detected {
+		if !spdx.Options().processesLanguage(lang) {
+			continue
+		}
+		for _, m := range projectManifests {
+			if m.lang != lang {
+				continue
+			}
+			path := filepath.Join(dirPath, project.Directory, m.manifest)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			var (
+				pkg  *Package
+				deps []*manifestDependency
+				err  error
+			)
+			if m.read != nil {
+				pkg, deps, err = m.read(path)
+				if err != nil {
+					return nil, fmt.Errorf("reading %s: %w", m.manifest, err)
+				}
+			}
+			if pkg == nil {
+				// Projects without a name or version are identified by
+				// their directory
+				pkg = NewPackage()
+				pkg.Name = filepath.Base(filepath.Join(dirPath, project.Directory))
+			}
+			pkg.PrimaryPurpose = "SOURCE"
+			where := "in " + filepath.ToSlash(project.Directory)
+			if project.Directory == "." {
+				where = "at the top level"
+			}
+			pkg.Comment = fmt.Sprintf("%s project %s, identified from its %s", m.lang, where, m.manifest)
+			pkg.BuildID(parent.ID, project.Directory, string(m.lang))
+			for _, dep := range deps {
+				if dep.Dev && spdx.Options().ExcludeDevDeps {
+					continue
+				}
+				if err := pkg.AddDependency(dep.toSPDXPackage(pkg, m.manifest)); err != nil {
+					return nil, fmt.Errorf("adding %s dependency: %w", m.manifest, err)
+				}
+			}
+			pkgs = append(pkgs, pkg)
+			break
+		}
+	}
+	return pkgs, nil
+}
+
+// sortManifestDependencies sorts the dependencies read from the maps of
+// a manifest, listing the runtime dependencies first.
+func sortManifestDependencies(deps []*manifestDependency) {
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Dev != deps[j].Dev {
+			return !deps[i].Dev
+		}
+		return deps[i].fullName() < deps[j].fullName()
+	})
+}
+
+// nodeProjectJSON captures the dependencies declared in a package.json.
+// Peer dependencies are provided by the projects using the package.
+type nodeProjectJSON struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+}
+
+// nodeExactVersionRe matches the npm version requirements that pin a
+// version
+var nodeExactVersionRe = regexp.MustCompile(`^=?v?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?)$`)
+
+// readNodeProject identifies a node project from its package.json
+func readNodeProject(path string) (*Package, []*manifestDependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg, err := nodePackageFromJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	project := nodeProjectJSON{}
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, nil, fmt.Errorf("decoding package.json dependencies: %w", err)
+	}
+
+	deps := []*manifestDependency{}
+	for _, list := range []struct {
+		deps map[string]string
+		dev  bool
+	}{
+		{project.Dependencies, false},
+		{project.OptionalDependencies, false},
+		{project.DevDependencies, true},
+	} {
+		for name, req := range list.deps {
+			dep := &manifestDependency{Type: purl.TypeNPM, Name: name, Requirement: req, Dev: list.dev}
+			if strings.HasPrefix(name, "@") {
+				dep.Namespace, dep.Name, _ = strings.Cut(name, "/")
+			}
+			if m := nodeExactVersionRe.FindStringSubmatch(strings.TrimSpace(req)); m != nil {
+				dep.Version = m[1]
+			}
+			deps = append(deps, dep)
+		}
+	}
+	sortManifestDependencies(deps)
+	return pkg, deps, nil
+}
+
+// pythonDevExtras are the names of the optional dependency groups of
+// python projects used for development and testing
+var pythonDevExtras = []string{
+	"dev", "develop", "development", "test", "tests", "testing",
+	"lint", "linting", "docs", "doc", "typing", "types", "style", "qa",
+}
+
+// readPythonProject identifies a python project from the [project] table
+// of its pyproject.toml, or the [tool.poetry] table of poetry projects.
+// The project dependencies, optional dependencies and dependency groups
+// are listed, extras and groups for development are marked as such.
+func readPythonProject(path string) (*Package, []*manifestDependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	tables, err := readTOML(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing pyproject.toml: %w", err)
+	}
+
+	deps := []*manifestDependency{}
+	addRequirements := func(raw string, dev bool) {
+		for _, req := range tomlStrings(raw) {
+			if dep := pythonRequirement(req); dep != nil {
+				dep.Dev = dev
+				deps = append(deps, dep)
+			}
+		}
+	}
+	addRequirements(tables["project"]["dependencies"], false)
+	for extra, raw := range tables["project.optional-dependencies"] {
+		addRequirements(raw, slices.Contains(pythonDevExtras, strings.ToLower(extra)))
+	}
+	for _, raw := range tables["dependency-groups"] {
+		addRequirements(raw, true)
+	}
+	for table, fields := range tables {
+		if table != "tool.poetry.dependencies" && table != "tool.poetry.dev-dependencies" &&
+			!(strings.HasPrefix(table, "tool.poetry.group.") && strings.HasSuffix(table, ".dependencies")) {
+			continue
+		}
+		for name, raw := range fields {
+			if name == "python" {
+				continue
+			}
+			dep := &manifestDependency{
+				Type:        purl.TypePyPi,
+				Name:        pythonNormalizedName(name),
+				Requirement: tomlString(raw),
+				Dev:         table != "tool.poetry.dependencies" && table != "tool.poetry.group.main.dependencies",
+			}
+			if strings.HasPrefix(strings.TrimSpace(raw), "{") {
+				dep.Requirement = tomlInlineString(raw, "version")
+			}
+			// Poetry requirements without an operator pin the version
+			if poetryExactVersionRe.MatchString(dep.Requirement) {
+				dep.Version = strings.TrimLeft(dep.Requirement, "=")
+			}
+			deps = append(deps, dep)
+		}
+	}
+	sortManifestDependencies(deps)
+
+	fields := tables["project"]
+	if tomlString(fields["name"]) == "" {
+		fields = tables["tool.poetry"]
+	}
+	name := tomlString(fields["name"])
+	if name == "" {
+		return nil, deps, nil
+	}
+
+	// Projects with a dynamic version are identified without it
+	pkg := NewPackage()
+	pkg.Name = name
+	pkg.Version = tomlString(fields["version"])
+	pkg.Summary = tomlString(fields["description"])
+	// PEP 639 license fields are SPDX expressions
+	pkg.LicenseDeclared = tomlString(fields["license"])
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator: purl.NewPackageURL(
+			purl.TypePyPi, "", pythonNormalizedName(pkg.Name), pkg.Version, nil, "",
+		).ToString(),
+	})
+	return pkg, deps, nil
+}
+
+// poetryExactVersionRe matches the poetry requirements pinning a version
+var poetryExactVersionRe = regexp.MustCompile(`^(?:==?)?\d[0-9A-Za-z.!+]*$`)
+
+var (
+	// pythonRequirementRe splits a PEP 508 requirement in the project
+	// name and its version specifiers, dropping the extras and markers
+	pythonRequirementRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*([^;]*)`)
+
+	// pythonExactVersionRe matches the version specifiers pinning a version
+	pythonExactVersionRe = regexp.MustCompile(`^===?\s*([0-9A-Za-z.!+_-]+)$`)
+)
+
+// pythonRequirement returns the dependency declared in a PEP 508
+// requirement, nil if it cannot be parsed.
+func pythonRequirement(req string) *manifestDependency {
+	m := pythonRequirementRe.FindStringSubmatch(strings.TrimSpace(req))
+	if m == nil {
+		return nil
+	}
+	dep := &manifestDependency{
+		Type:        purl.TypePyPi,
+		Name:        pythonNormalizedName(m[1]),
+		Requirement: strings.TrimSpace(m[2]),
+	}
+	if v := pythonExactVersionRe.FindStringSubmatch(dep.Requirement); v != nil {
+		dep.Version = v[1]
+	}
+	return dep
+}
+
+// readRequirementsProject lists the dependencies in a pip requirements
+// file. Requirements files do not name the project and the files they
+// include are not read.
+func readRequirementsProject(path string) (*Package, []*manifestDependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	deps := []*manifestDependency{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, " #")
+		line = strings.TrimSpace(line)
+		// Options like -r, -e or --index-url are not requirements
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if dep := pythonRequirement(line); dep != nil {
+			deps = append(deps, dep)
+		}
+	}
+	return nil, deps, nil
+}
+
+// mavenPOM is the subset of a pom.xml read to identify a project
+type mavenPOM struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Name       string `xml:"name"`
+	URL        string `xml:"url"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies []struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+		Scope      string `xml:"scope"`
+	} `xml:"dependencies>dependency"`
+}
+
+// mavenPropertyRe matches the property references in POM values
+var mavenPropertyRe = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// readMavenProject identifies a maven project from its pom.xml. The
+// group and version are inherited from the parent POM when not set.
+// Properties defined in other POMs are not resolved, nor the versions
+// of the dependencies managed by the parent POMs.
+func readMavenProject(path string) (*Package, []*manifestDependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	pom := &mavenPOM{}
+	if err := xml.Unmarshal(data, pom); err != nil {
+		return nil, nil, fmt.Errorf("decoding pom: %w", err)
+	}
+	c := mavenCoordinates{GroupID: pom.GroupID, ArtifactID: pom.ArtifactID, Version: pom.Version}
+	if c.GroupID == "" {
+		c.GroupID = pom.Parent.GroupID
+	}
+	if c.Version == "" {
+		c.Version = pom.Parent.Version
+	}
+
+	properties := map[string]string{}
+	for _, p := range pom.Properties.Entries {
+		properties[p.XMLName.Local] = strings.TrimSpace(p.Value)
+	}
+	properties["project.groupId"] = c.GroupID
+	properties["project.version"] = c.Version
+	properties["project.parent.version"] = pom.Parent.Version
+	// interpolate returns the value with the properties replaced, or an
+	// empty string if it references unknown properties
+	interpolate := func(value string) string {
+		value = mavenPropertyRe.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := properties[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+		if strings.Contains(value, "${") {
+			return ""
+		}
+		return strings.TrimSpace(value)
+	}
+
+	deps := []*manifestDependency{}
+	for _, d := range pom.Dependencies {
+		dep := &manifestDependency{
+			Type:        purl.TypeMaven,
+			Namespace:   interpolate(d.GroupID),
+			Name:        interpolate(d.ArtifactID),
+			Requirement: d.Version,
+			Dev:         d.Scope == "test",
+		}
+		if dep.Name == "" {
+			continue
+		}
+		// Version ranges do not pin a version
+		if v := interpolate(d.Version); !strings.ContainsAny(v, "[(,") {
+			dep.Version = v
+		}
+		deps = append(deps, dep)
+	}
+
+	c.Version = interpolate(c.Version)
+	if c.ArtifactID == "" || c.Version == "" {
+		return nil, deps, nil
+	}
+
+	pkg := NewPackage()
+	pkg.Name = c.ArtifactID
+	pkg.Version = c.Version
+	pkg.Summary = pom.Name
+	pkg.HomePage = pom.URL
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  c.purl(),
+	})
+	return pkg, deps, nil
+}
+
+// cargoExactVersionRe matches the cargo requirements pinning a version.
+// Bare versions are caret requirements in cargo.
+var cargoExactVersionRe = regexp.MustCompile(`^=\s*(\d[0-9A-Za-z.+-]*)$`)
+
+// readCargoProject identifies a rust crate from its Cargo.toml and lists
+// its dependencies, including the platform specific ones. Workspace
+// manifests without a package list the dependencies of the workspace.
+// Versions and dependencies inherited from the workspace are not read.
+func readCargoProject(path string) (*Package, []*manifestDependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	tables, err := readTOML(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing Cargo.toml: %w", err)
+	}
+
+	deps := []*manifestDependency{}
+	addDependency := func(key, raw string, dev bool) {
+		dep := &manifestDependency{Type: purl.TypeCargo, Name: key, Requirement: tomlString(raw), Dev: dev}
+		if strings.HasPrefix(strings.TrimSpace(raw), "{") {
+			// Renamed dependencies set the name of the crate in package
+			if name := tomlInlineString(raw, "package"); name != "" {
+				dep.Name = name
+			}
+			dep.Requirement = tomlInlineString(raw, "version")
+		}
+		if m := cargoExactVersionRe.FindStringSubmatch(dep.Requirement); m != nil {
+			dep.Version = m[1]
+		}
+		deps = append(deps, dep)
+	}
+	for table, fields := range tables {
+		kind := table
+		if strings.HasPrefix(table, "target.") {
+			// [target.'cfg(unix)'.dependencies]
+			kind = table[strings.LastIndex(table, ".")+1:]
+		} else if strings.HasPrefix(table, "workspace.") {
+			kind = strings.TrimPrefix(table, "workspace.")
+		}
+		dev := kind == "dev-dependencies" || kind == "build-dependencies"
+		switch {
+		case kind == "dependencies" || dev:
+			// Dotted keys set the fields of a dependency: serde.workspace = true
+			dotted := map[string]map[string]string{}
+			for key, raw := range fields {
+				name, field, ok := strings.Cut(key, ".")
+				if !ok {
+					addDependency(key, raw, dev)
+					continue
+				}
+				if dotted[name] == nil {
+					dotted[name] = map[string]string{}
+				}
+				dotted[name][field] = raw
+			}
+			for name, fields := range dotted {
+				addDependency(name, tomlInline(fields), dev)
+			}
+		case strings.HasPrefix(table, "dependencies.") || strings.HasPrefix(table, "dev-dependencies.") ||
+			strings.HasPrefix(table, "build-dependencies."):
+			// [dependencies.serde] tables describe a single dependency
+			kind, key, _ := strings.Cut(table, ".")
+			addDependency(key, tomlInline(fields), kind != "dependencies")
+		}
+	}
+	sortManifestDependencies(deps)
+
+	fields := tables["package"]
+	name, version := tomlString(fields["name"]), tomlString(fields["version"])
+	if name == "" {
+		return nil, deps, nil
+	}
+	pkg := NewPackage()
+	pkg.Name = name
+	pkg.Version = version
+	pkg.Summary = tomlString(fields["description"])
+	pkg.HomePage = tomlString(fields["homepage"])
+	// The license field of cargo manifests is an SPDX expression
+	pkg.LicenseDeclared = tomlString(fields["license"])
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  purl.NewPackageURL(purl.TypeCargo, "", name, version, nil, "").ToString(),
+	})
+	return pkg, deps, nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestProjectPackages(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"web/package.json": `{
+  "name": "@example/web", "version": "2.1.0", "license": "MIT",
+  "dependencies": {"react": "18.2.0", "@scope/lib": "^1.0.0"},
+  "devDependencies": {"jest": "~29.7.0"}
+}`,
+		"tools/pyproject.toml": `[build-system]
+requires = ["hatchling"]
+
+[project]
+name = "Example_Tools"
+version = "0.3.0"
+license = "Apache-2.0"
+dependencies = [
+    "requests>=2.31",  # HTTP client
+    "Click==8.1.7",
+]
+
+[project.optional-dependencies]
+test = ["pytest"]
+`,
+		"poetry/pyproject.toml": `[tool.poetry]
+name = "poetry-app"
+version = "1.0.0"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+flask = "2.3.3"
+pydantic = { version = "^2.0", extras = ["email"] }
+
+[tool.poetry.group.dev.dependencies]
+black = "^24.0"
+`,
+		"dynamic/pyproject.toml":   "[project]\nname = \"dynamic\"\ndynamic = [\"version\"]\n",
+		"scripts/requirements.txt": "-r base.txt\nPyYAML==6.0.1\nrich>=13 ; python_version >= \"3.8\"\n",
+		"service/pom.xml": `<project>
+  <parent><groupId>com.example</groupId><artifactId>parent</artifactId><version>4.0.0</version></parent>
+  <artifactId>service</artifactId>
+  <name>Example service</name>
+  <properties><guava.version>33.0.0-jre</guava.version></properties>
+  <dependencies>
+    <dependency><groupId>com.google.guava</groupId><artifactId>guava</artifactId><version>${guava.version}</version></dependency>
+    <dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.13.2</version><scope>test</scope></dependency>
+  </dependencies>
+</project>`,
+		"crate/Cargo.toml": `[package]
+name = "tool"
+version = "0.1.0"
+license = "MIT OR Apache-2.0"
+
+[dependencies]
+serde = { version = "=1.0.200", features = ["derive"] }
+anyhow = "1"
+tokio.workspace = true
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[dev-dependencies.tempfile]
+version = "3"
+`,
+		"gradle/build.gradle": "plugins { id 'java' }\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+	}
+
+	parent := NewPackage()
+	parent.BuildID("parent")
+	opts := defaultSPDXOptions
+	s := NewSPDX()
+	s.options = &opts
+	for _, tc := range []struct {
+		dir     string
+		lang    Language
+		name    string
+		purl    string
+		license string
+		deps    []string
+	}{
+		{
+			"web", LangNode, "@example/web", "pkg:npm/%40example/web@2.1.0", "MIT",
+			[]string{"pkg:npm/%40scope/lib", "pkg:npm/react@18.2.0", "pkg:npm/jest"},
+		},
+		{
+			"tools", LangPython, "Example_Tools", "pkg:pypi/example-tools@0.3.0", "Apache-2.0",
+			[]string{"pkg:pypi/click@8.1.7", "pkg:pypi/requests", "pkg:pypi/pytest"},
+		},
+		{
+			"poetry", LangPython, "poetry-app", "pkg:pypi/poetry-app@1.0.0", "",
+			[]string{"pkg:pypi/flask@2.3.3", "pkg:pypi/pydantic", "pkg:pypi/black"},
+		},
+		{"dynamic", LangPython, "dynamic", "pkg:pypi/dynamic", "", []string{}},
+		{"scripts", LangPython, "scripts", "", "", []string{"pkg:pypi/pyyaml@6.0.1", "pkg:pypi/rich"}},
+		{
+			"service", LangJava, "service", "pkg:maven/com.example/service@4.0.0", "",
+			[]string{"pkg:maven/com.google.guava/guava@33.0.0-jre", "pkg:maven/junit/junit@4.13.2"},
+		},
+		{
+			"crate", LangRust, "tool", "pkg:cargo/tool@0.1.0", "MIT OR Apache-2.0",
+			[]string{"pkg:cargo/anyhow", "pkg:cargo/libc", "pkg:cargo/serde@1.0.200", "pkg:cargo/tokio", "pkg:cargo/tempfile"},
+		},
+		{"gradle", LangJava, "gradle", "", "", []string{}},
+	} {
+		pkgs, err := s.manifestProjectPackages(parent, dir, &LanguageReport{Directory: tc.dir, Detected: []Language{tc.lang}})
+		require.NoError(t, err, tc.dir)
+		require.Len(t, pkgs, 1, tc.dir)
+		pkg := pkgs[0]
+		require.Equal(t, tc.name, pkg.Name, tc.dir)
+		require.Equal(t, "SOURCE", pkg.PrimaryPurpose)
+		require.Equal(t, tc.license, pkg.LicenseDeclared, tc.dir)
+		if tc.purl == "" {
+			require.Empty(t, pkg.ExternalRefs, tc.dir)
+		} else {
+			require.Len(t, pkg.ExternalRefs, 1)
+			require.Equal(t, tc.purl, pkg.ExternalRefs[0].Locator)
+		}
+		deps := []string{}
+		for _, rel := range *pkg.GetRelationships() {
+			require.Equal(t, DEPENDS_ON, rel.Type)
+			deps = append(deps, rel.Peer.(*Package).ExternalRefs[0].Locator)
+		}
+		require.Equal(t, tc.deps, deps, tc.dir)
+	}
+
+	// Directories with several manifests get a package for each
+	// ecosystem, and disabled languages are not read
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crate", "package.json"), []byte(`{"name": "tool-ui", "version": "0.1.0"}`), os.FileMode(0o644)))
+	pkgs, err := s.manifestProjectPackages(parent, dir, &LanguageReport{Directory: "crate", Detected: []Language{LangNode, LangRust}})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	require.Equal(t, "tool-ui", pkgs[0].Name)
+	require.Equal(t, "tool", pkgs[1].Name)
+	require.NotEqual(t, pkgs[0].ID, pkgs[1].ID)
+
+	// Development, test and build dependencies are skipped when
+	// excluding dev deps
+	s.Options().ExcludeDevDeps = true
+	for _, tc := range []struct {
+		dir  string
+		lang Language
+		deps int
+	}{
+		{"web", LangNode, 2}, {"tools", LangPython, 2}, {"poetry", LangPython, 2},
+		{"service", LangJava, 1}, {"crate", LangRust, 4},
+	} {
+		pkgs, err := s.manifestProjectPackages(parent, dir, &LanguageReport{Directory: tc.dir, Detected: []Language{tc.lang}})
+		require.NoError(t, err)
+		require.Len(t, pkgs, 1)
+		require.Len(t, *pkgs[0].GetRelationships(), tc.deps, tc.dir)
+	}
+	s.Options().ExcludeDevDeps = false
+
+	s.Options().ProcessNode = false
+	pkgs, err = s.manifestProjectPackages(parent, dir, &LanguageReport{Directory: "web", Detected: []Language{LangNode}})
+	require.NoError(t, err)
+	require.Empty(t, pkgs)
+}
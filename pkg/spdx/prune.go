@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// PruneFiles removes all files contained in packages. Files added
+	// directly to the document are kept.
+	PruneFiles = "files"
+
+	// PruneRelationships removes the back references (eg VARIANT_OF or
+	// CONTAINED_BY) that do not bring their peer into the document.
+	// Containment and dependency relationships to elements rendered
+	// elsewhere are kept, they record the structure of the document (eg
+	// the packages shared by images after deduplicating them).
+	// Relationships to external documents are kept.
+	PruneRelationships = "relationships"
+)
+
+// PruneTargets lists the valid values for the pruning options.
+var PruneTargets = []string{PruneFiles, PruneRelationships}
+
+// backReferenceTypes are the relationship types pointing back to an
+// element that relates to the element, removed when pruning relationships
+var backReferenceTypes = map[RelationshipType]struct{}{
+	CONTAINED_BY:          {},
+	DESCRIBED_BY:          {},
+	VARIANT_OF:            {},
+	DESCENDANT_OF:         {},
+	GENERATED_FROM:        {},
+	EXPANDED_FROM_ARCHIVE: {},
+}
+
+// Prune removes the kinds of elements listed in targets from the document
+// to reduce its size. Returns the number of elements removed.
+func (d *Document) Prune(targets []string) (int, error) {
+	files, rels := false, false
+	for _, t := range targets {
+		switch t {
+		case PruneFiles:
+			files = true
+		case PruneRelationships:
+			rels = true
+		default:
+			return 0, fmt.Errorf("invalid prune target %q, valid values are: %v", t, PruneTargets)
+		}
+	}
+
+	removed := 0
+	seen := map[Object]struct{}{}
+	var prune func(o Object)
+	prune = func(o Object) {
+		if _, ok := seen[o]; ok {
+			return
+		}
+		seen[o] = struct{}{}
+
+		relationships := o.GetRelationships()
+		kept := []*Relationship{}
+		for _, rel := range *relationships {
+			if _, ok := rel.Peer.(*File); ok && files {
+				// Packages without files can no longer claim their
+				// files were analyzed
+				if p, ok := o.(*Package); ok {
+					p.FilesAnalyzed = false
+					p.VerificationCode = ""
+				}
+				removed++
+				continue
+			}
+			if rels && isPrunableBackReference(rel) {
+				removed++
+				continue
+			}
+			kept = append(kept, rel)
+			if rel.Peer != nil {
+				prune(rel.Peer)
+			}
+		}
+		*relationships = kept
+	}
+
+	for _, p := range d.Packages {
+		prune(p)
+	}
+	for _, f := range d.Files {
+		prune(f)
+	}
+	d.InvalidateIndex()
+	return removed, nil
+}
+
+// isPrunableBackReference returns true if the relationship is a back
+// reference that does not render its peer in the document
+func isPrunableBackReference(rel *Relationship) bool {
+	if rel.FullRender || rel.PeerExtReference != "" {
+		return false
+	}
+	if _, ok := backReferenceTypes[rel.Type]; ok {
+		return true
+	}
+	_, ok := dependentTypes[rel.Type]
+	return ok
+}
+
+// SubtreeSize records the number of elements under a package.
+type SubtreeSize struct {
+	ID       string
+	Name     string
+	Elements int
+}
+
+// SizeReport summarizes the number of elements in a document.
+type SizeReport struct {
+	Packages      int
+	Files         int
+	Relationships int
+	Subtrees      []SubtreeSize // Biggest subtrees, largest first
+}
+
+// SizeReport counts the elements in the document. The subtrees of the
+// top level packages and their direct subpackages are ranked by size,
+// and the largest ones (up to maxSubtrees) are listed in the report.
+func (d *Document) SizeReport(maxSubtrees int) *SizeReport {
+	report := &SizeReport{Subtrees: []SubtreeSize{}}
+
+	seen := map[Object]struct{}{}
+	for _, p := range d.Packages {
+		countElements(p, &seen, report)
+	}
+	for _, f := range d.Files {
+		countElements(f, &seen, report)
+	}
+
+	candidates := map[*Package]struct{}{}
+	for _, p := range d.Packages {
+		candidates[p] = struct{}{}
+		for _, rel := range p.Relationships {
+			if sub, ok := rel.Peer.(*Package); ok && rel.FullRender {
+				candidates[sub] = struct{}{}
+			}
+		}
+	}
+
+	for p := range candidates {
+		subSeen := map[Object]struct{}{}
+		count := &SizeReport{}
+		countElements(p, &subSeen, count)
+		report.Subtrees = append(report.Subtrees, SubtreeSize{
+			ID:       p.SPDXID(),
+			Name:     p.Name,
+			Elements: count.Packages + count.Files,
+		})
+	}
+
+	sort.Slice(report.Subtrees, func(i, j int) bool {
+		if report.Subtrees[i].Elements == report.Subtrees[j].Elements {
+			return report.Subtrees[i].ID < report.Subtrees[j].ID
+		}
+		return report.Subtrees[i].Elements > report.Subtrees[j].Elements
+	})
+	if maxSubtrees >= 0 && len(report.Subtrees) > maxSubtrees {
+		report.Subtrees = report.Subtrees[:maxSubtrees]
+	}
+	return report
+}
+
+// countElements adds the elements rendered under o to the report counts.
+// Relationships that do not render their peer are counted but not followed.
+//
+//nolint:gocritic // seen is a pointer recursively populated
+func countElements(o Object, seen *map[Object]struct{}, report *SizeReport) {
+	if _, ok := (*seen)[o]; ok {
+		return
+	}
+	(*seen)[o] = struct{}{}
+
+	switch o.(type) {
+	case *Package:
+		report.Packages++
+	case *File:
+		report.Files++
+	}
+
+	for _, rel := range *o.GetRelationships() {
+		report.Relationships++
+		if rel.Peer != nil && rel.FullRender {
+			countElements(rel.Peer, seen, report)
+		}
+	}
+}
+
+// String returns the report as human readable text.
+func (r *SizeReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Packages: %d\n", r.Packages)
+	fmt.Fprintf(&sb, "Files: %d\n", r.Files)
+	fmt.Fprintf(&sb, "Relationships: %d\n", r.Relationships)
+	if len(r.Subtrees) > 0 {
+		sb.WriteString("Biggest subtrees:\n")
+		for _, s := range r.Subtrees {
+			fmt.Fprintf(&sb, "  %d elements: %s (%s)\n", s.Elements, s.Name, s.ID)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testPruneDocument(t *testing.T) (*Document, *Package) {
+	doc := NewDocument()
+	pkg := NewPackage()
+	pkg.Name = "image"
+	pkg.BuildID("image")
+	pkg.FilesAnalyzed = true
+	pkg.VerificationCode = "6486e016b01e9ec8a76998cefd0705144d869234"
+
+	sub := NewPackage()
+	sub.Name = "layer"
+	sub.BuildID("layer")
+	require.NoError(t, pkg.AddPackage(sub))
+	sub.AddRelationship(&Relationship{Peer: pkg, Type: VARIANT_OF})
+
+	for _, name := range []string{"file1", "file2"} {
+		f := NewFile()
+		f.Name = name
+		f.BuildID(name)
+		require.NoError(t, pkg.AddFile(f))
+	}
+	require.NoError(t, doc.AddPackage(pkg))
+	return doc, pkg
+}
+
+func TestPrune(t *testing.T) {
+	doc, pkg := testPruneDocument(t)
+	n, err := doc.Prune([]string{PruneFiles})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Empty(t, pkg.Files())
+	require.False(t, pkg.FilesAnalyzed)
+	require.Empty(t, pkg.VerificationCode)
+	require.Len(t, pkg.Relationships, 1)
+
+	doc, pkg = testPruneDocument(t)
+	n, err = doc.Prune([]string{PruneRelationships})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Len(t, pkg.Files(), 2)
+	require.Empty(t, pkg.Relationships[0].Peer.(*Package).Relationships)
+
+	_, err = doc.Prune([]string{"packages"})
+	require.Error(t, err)
+}
+
+func TestPruneKeepsDeduplicatedPackages(t *testing.T) {
+	// Two images sharing a layer, as left by DeduplicatePackages
+	doc := NewDocument()
+	layer := NewPackage()
+	layer.Name = "layer"
+	layer.BuildID("layer")
+	layer.ExternalRefs = []ExternalRef{{Category: CatPackageManager, Type: "purl", Locator: "pkg:oci/layer@sha256:abc"}}
+	for _, name := range []string{"image1", "image2"} {
+		img := NewPackage()
+		img.Name = name
+		img.BuildID(name)
+		l := NewPackage()
+		l.Name = layer.Name
+		l.BuildID("layer", name)
+		l.ExternalRefs = layer.ExternalRefs
+		require.NoError(t, img.AddPackage(l))
+		require.NoError(t, doc.AddPackage(img))
+	}
+	require.Equal(t, 1, doc.DeduplicatePackages())
+
+	_, err := doc.Prune([]string{PruneRelationships})
+	require.NoError(t, err)
+	for _, img := range doc.Packages {
+		require.Len(t, img.Relationships, 1, img.Name)
+		require.Equal(t, CONTAINS, img.Relationships[0].Type)
+	}
+}
+
+func TestSizeReport(t *testing.T) {
+	doc, pkg := testPruneDocument(t)
+	report := doc.SizeReport(1)
+	require.Equal(t, 2, report.Packages)
+	require.Equal(t, 2, report.Files)
+	require.Equal(t, 4, report.Relationships)
+	require.Len(t, report.Subtrees, 1)
+	require.Equal(t, pkg.SPDXID(), report.Subtrees[0].ID)
+	require.Equal(t, 4, report.Subtrees[0].Elements)
+	require.Contains(t, report.String(), "Biggest subtrees:\n  4 elements: image")
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/nozzle/throttler"
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+
+	"sigs.k8s.io/bom/pkg/license"
+)
+
+// ReadPurlList reads a file listing a package URL per line. Empty lines
+// and lines starting with # are ignored.
+func ReadPurlList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening purl list: %w", err)
+	}
+	defer f.Close()
+
+	purls := []string{}
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		spec := strings.TrimSpace(scanner.Text())
+		if spec == "" || strings.HasPrefix(spec, "#") {
+			continue
+		}
+		if _, err := purl.FromString(spec); err != nil {
+			return nil, fmt.Errorf("%s:%d: parsing purl: %w", path, line, err)
+		}
+		purls = append(purls, spec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading purl list: %w", err)
+	}
+	return purls, nil
+}
+
+// PackagesFromPurlList returns a package for each purl in the list in
+// path, as built by PackageFromPurl. When enabled in the options, the
+// packages are completed with the data in their registries, their
+// licenses in ClearlyDefined and the licenses found in their downloads.
+// Packages with a license in ClearlyDefined are not downloaded.
+func (spdx *SPDX) PackagesFromPurlList(ctx context.Context, path string) ([]*Package, error) {
+	purls, err := ReadPurlList(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader *license.Reader
+	if spdx.Options().PurlScanLicenses {
+		reader, err = spdx.impl.LicenseReader(spdx.Options())
+		if err != nil {
+			return nil, fmt.Errorf("creating license reader: %w", err)
+		}
+	}
+	var known map[string]ClearlyDefinedLicense
+	if spdx.Options().ClearlyDefined {
+		known, err = ClearlyDefinedLicenses(ctx, purls)
+		if err != nil {
+			spdx.Options().Degradations.record(DegradationNetworkError, "Unable to look up purl list licenses in ClearlyDefined: %v", err)
+		}
+	}
+	opts := []PurlPackageOption{WithDegradationLog(spdx.Options().Degradations)}
+	if spdx.Options().PurlRegistryData {
+		opts = append(opts, WithRegistryData())
+	}
+
+	pkgs := make([]*Package, len(purls))
+	t := throttler.New(registryConcurrency, len(purls))
+	for i, spec := range purls {
+		go func() {
+			pkg, err := PackageFromPurlContext(ctx, spec, opts...)
+			if err != nil {
+				t.Done(fmt.Errorf("creating package from %s: %w", spec, err))
+				return
+			}
+			if lic, ok := known[spec]; ok {
+				pkg.LicenseConcluded = lic.License
+				pkg.CopyrightText = lic.Copyright
+			} else if spdx.Options().PurlScanLicenses {
+				spdx.scanPurlPackageLicense(ctx, reader, spec, pkg)
+			}
+			pkgs[i] = pkg
+			t.Done(nil)
+		}()
+		t.Throttle()
+	}
+	if err := t.Err(); err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// scanPurlPackageLicense downloads a package and concludes its license
+// from the files in it. Packages scanned before are read from the
+// license scan cache and packages found in the local source caches are
+// read from them instead of downloading them. Packages that can't be
+// scanned are recorded as degradations.
+func (spdx *SPDX) scanPurlPackageLicense(ctx context.Context, reader *license.Reader, spec string, pkg *Package) {
+	cache := spdx.Options().LicenseScanCache
+	if cached := cache.Get(spec); cached != nil {
+		switch {
+		case cached.License != NOASSERTION:
+			pkg.LicenseConcluded = cached.License
+		case pkg.LicenseConcluded == "":
+			spdx.Options().Degradations.record(DegradationMissingLicenses, "No license found in the sources of %s (cached scan)", pkg.Name)
+		}
+		return
+	}
+
+	if src := spdx.Options().SourceCaches.Find(pkg.Purl()); src != "" {
+		if scanned, ok := spdx.scanPackageSources(reader, pkg, src, src); ok {
+			cache.Put(spec, scanned, "")
+		}
+		return
+	}
+
+	loc := pkg.DownloadLocation
+	if !strings.HasPrefix(loc, "https://") && !strings.HasPrefix(loc, "http://") {
+		spdx.Options().Degradations.record(DegradationMissingLicenses, "Unable to scan the license of %s, it has no download URL", pkg.Name)
+		return
+	}
+
+	archive, err := os.CreateTemp("", "bom-purl-package-")
+	if err != nil {
+		spdx.Options().Degradations.record(DegradationMissingLicenses, "Unable to scan the license of %s: %v", pkg.Name, err)
+		return
+	}
+	defer os.Remove(archive.Name())
+	err = downloadToFile(ctx, loc, archive)
+	archive.Close()
+	if err != nil {
+		spdx.Options().Degradations.record(DegradationNetworkError, "Unable to download %s to scan its license: %v", loc, err)
+		return
+	}
+	if scanned, ok := spdx.scanPackageSources(reader, pkg, archive.Name(), loc); ok {
+		cache.Put(spec, scanned, "")
+	}
+}
+
+// scanPackageSources concludes the license of a package from its
+// sources in src, a directory or an archive. origin names the sources
+// in the degradations recorded. Returns the license found by the scan,
+// empty if there was none, and true if the sources were scanned.
+func (spdx *SPDX) scanPackageSources(reader *license.Reader, pkg *Package, src, origin string) (string, bool) {
+	dir := src
+	if !util.IsDir(src) {
+		tmp, err := spdx.ExtractTarballTmp(src)
+		if tmp != "" {
+			defer os.RemoveAll(tmp)
+		}
+		if err != nil {
+			spdx.Options().Degradations.record(DegradationMissingLicenses, "Unable to extract %s to scan its license: %v", origin, err)
+			return "", false
+		}
+		dir = tmp
+	}
+	lic, err := spdx.impl.GetDirectoryLicense(reader, dir, spdx.Options())
+	if err != nil {
+		spdx.Options().Degradations.record(DegradationMissingLicenses, "Unable to scan the license of %s: %v", pkg.Name, err)
+		return "", false
+	}
+	if lic == nil {
+		if pkg.LicenseConcluded == "" {
+			spdx.Options().Degradations.record(DegradationMissingLicenses, "No license found in the sources of %s", pkg.Name)
+		}
+		return "", true
+	}
+	logrus.Debugf("Concluded license %s for %s from %s", lic.LicenseID, pkg.Name, origin)
+	pkg.LicenseConcluded = lic.LicenseID
+	return lic.LicenseID, true
+}
+
+// downloadToFile writes the contents of a URL to f
+func downloadToFile(ctx context.Context, u string, f io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading file: %s", resp.Status)
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/license"
+	"sigs.k8s.io/bom/pkg/spdx"
+	"sigs.k8s.io/bom/pkg/spdx/spdxfakes"
+)
+
+func writePurlList(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "purls.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadPurlList(t *testing.T) {
+	purls, err := spdx.ReadPurlList(writePurlList(t, `# Runtime dependencies
+pkg:npm/lodash@4.17.21
+
+  pkg:golang/github.com/sirupsen/logrus@v1.9.3
+`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"pkg:npm/lodash@4.17.21", "pkg:golang/github.com/sirupsen/logrus@v1.9.3"}, purls)
+
+	_, err = spdx.ReadPurlList(writePurlList(t, "pkg:npm/lodash@4.17.21\nlodash\n"))
+	require.ErrorContains(t, err, "purls.txt:2")
+
+	_, err = spdx.ReadPurlList(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestPackagesFromPurlList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/lib-1.0.tar.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("archive")) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	list := writePurlList(t, "pkg:generic/lib@1.0?download_url="+srv.URL+"/lib-1.0.tar.gz\npkg:npm/lodash@4.17.21\n")
+
+	// Without license scanning, the packages are built from the purls
+	sut := spdx.NewSPDX()
+	mock := &spdxfakes.FakeSpdxImplementation{}
+	sut.SetImplementation(mock)
+	pkgs, err := sut.PackagesFromPurlList(context.Background(), list)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	require.Equal(t, "lib", pkgs[0].Name)
+	require.Equal(t, "lodash", pkgs[1].Name)
+	require.Zero(t, mock.GetDirectoryLicenseCallCount())
+
+	// Scanning the licenses downloads and extracts the packages
+	sut.Options().PurlScanLicenses = true
+	mock.ExtractTarballTmpReturns(t.TempDir(), nil)
+	mock.GetDirectoryLicenseReturns(&license.License{LicenseID: "MIT"}, nil)
+	pkgs, err = sut.PackagesFromPurlList(context.Background(), writePurlList(t, "pkg:generic/lib@1.0?download_url="+srv.URL+"/lib-1.0.tar.gz\n"))
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, "MIT", pkgs[0].LicenseConcluded)
+	require.Equal(t, 1, mock.GetDirectoryLicenseCallCount())
+
+	// Failed downloads leave the license unknown
+	log := &spdx.DegradationLog{}
+	sut.Options().Degradations = log
+	pkgs, err = sut.PackagesFromPurlList(context.Background(), writePurlList(t, "pkg:generic/lib@2.0?download_url="+srv.URL+"/lib-2.0.tar.gz\n"))
+	require.NoError(t, err)
+	require.Empty(t, pkgs[0].LicenseConcluded)
+	require.Len(t, log.Degradations(), 1)
+
+	// Sources without a license are degraded, also when the scan is cached
+	sut.Options().LicenseScanCache = spdx.NewLicenseScanCache(t.TempDir())
+	mock.GetDirectoryLicenseReturns(nil, nil)
+	for _, calls := range []int{2, 2} {
+		log = &spdx.DegradationLog{}
+		sut.Options().Degradations = log
+		pkgs, err = sut.PackagesFromPurlList(context.Background(), writePurlList(t, "pkg:generic/lib@1.0?download_url="+srv.URL+"/lib-1.0.tar.gz\n"))
+		require.NoError(t, err)
+		require.Empty(t, pkgs[0].LicenseConcluded)
+		require.Equal(t, calls, mock.GetDirectoryLicenseCallCount())
+		require.Len(t, log.Degradations(), 1)
+		require.Equal(t, spdx.DegradationMissingLicenses, log.Degradations()[0].Kind)
+	}
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+)
+
+// mavenCentralURL is the repository of the maven packages without a
+// repository_url qualifier
+var mavenCentralURL = "https://repo1.maven.org/maven2"
+
+// PurlPackageOption configures how PackageFromPurl builds packages
+type PurlPackageOption func(*purlPackageSettings)
+
+type purlPackageSettings struct {
+	registry     bool
+	degradations *DegradationLog
+}
+
+// WithRegistryData looks up the declared license, description and
+// download location of the package version in its registry. PyPI, npm
+// and crates.io packages are supported.
+func WithRegistryData() PurlPackageOption {
+	return func(s *purlPackageSettings) {
+		s.registry = true
+	}
+}
+
+// WithDegradationLog records the registry lookups that fail in log.
+func WithDegradationLog(log *DegradationLog) PurlPackageOption {
+	return func(s *purlPackageSettings) {
+		s.degradations = log
+	}
+}
+
+// registryPackageData complete a package with the data of its version in
+// the registry, by purl type
+var registryPackageData = map[string]func(context.Context, *purl.PackageURL, *Package) error{
+	purl.TypePyPi:  pypiPackageData,
+	purl.TypeNPM:   npmPackageData,
+	purl.TypeCargo: cratesPackageData,
+}
+
+// PackageFromPurl returns a package described by a package URL. It has
+// the name, version and purl of the package, plus the download location,
+// home page and checksums known for its ecosystem:
+//
+//	pkg, err := spdx.PackageFromPurl("pkg:npm/lodash@4.17.21")
+//
+// The download_url, vcs_url and checksum qualifiers of the purl take
+// precedence over the locations derived from its type.
+func PackageFromPurl(spec string, opts ...PurlPackageOption) (*Package, error) {
+	return PackageFromPurlContext(context.Background(), spec, opts...)
+}
+
+// PackageFromPurlContext is like PackageFromPurl, the context aborts the
+// registry lookups.
+func PackageFromPurlContext(ctx context.Context, spec string, opts ...PurlPackageOption) (*Package, error) {
+	settings := &purlPackageSettings{}
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	pu, err := purl.FromString(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parsing package purl: %w", err)
+	}
+	if pu.Name == "" {
+		return nil, fmt.Errorf("purl %s has no package name", spec)
+	}
+
+	pkg := NewPackage()
+	pkg.Options().Prefix = pu.Type
+	pkg.Name = purlPackageName(&pu)
+	pkg.Version = pu.Version
+	pkg.PrimaryPurpose = purlPackagePurpose(pu.Type)
+	pkg.DownloadLocation = NOASSERTION
+	pkg.ExternalRefs = append(pkg.ExternalRefs, ExternalRef{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  pu.ToString(),
+	})
+	pkg.BuildID(pkg.Name, pkg.Version)
+	addPurlLocations(&pu, pkg)
+
+	qualifiers := pu.Qualifiers.Map()
+	if err := addPurlChecksums(pkg, qualifiers["checksum"]); err != nil {
+		return nil, fmt.Errorf("reading purl checksums: %w", err)
+	}
+	switch {
+	case qualifiers["download_url"] != "":
+		pkg.DownloadLocation = qualifiers["download_url"]
+	case qualifiers["vcs_url"] != "":
+		pkg.DownloadLocation = qualifiers["vcs_url"]
+	}
+
+	lookup := registryPackageData[pu.Type]
+	if !settings.registry || lookup == nil || pu.Version == "" {
+		return pkg, nil
+	}
+	switch err := lookup(ctx, &pu, pkg); {
+	case errors.Is(err, errRegistryNotFound):
+		logrus.Debugf("%s not found in its registry", spec)
+	case err != nil && ctx.Err() != nil:
+		return nil, ctx.Err()
+	case err != nil:
+		settings.degradations.record(DegradationNetworkError, "Unable to read the registry data of %s: %v", spec, err)
+	}
+	return pkg, nil
+}
+
+// purlPackageName returns the name of a package as written in its
+// ecosystem, including its namespace
+func purlPackageName(pu *purl.PackageURL) string {
+	if pu.Namespace == "" {
+		return pu.Name
+	}
+	switch pu.Type {
+	case purl.TypeMaven:
+		return pu.Namespace + ":" + pu.Name
+	case purl.TypeDebian, purl.TypeRPM, purl.TypeApk, purl.TypeAlpm, purl.TypeOCI:
+		// The namespace of OS packages is the distribution
+		return pu.Name
+	}
+	return pu.Namespace + "/" + pu.Name
+}
+
+// purlPackagePurpose returns the primary purpose of the packages of a
+// purl type
+func purlPackagePurpose(typ string) string {
+	switch typ {
+	case purl.TypeDebian, purl.TypeRPM, purl.TypeApk, purl.TypeAlpm:
+		return "INSTALL"
+	case purl.TypeOCI, purl.TypeDocker:
+		return "CONTAINER"
+	case purl.TypeGithub, purl.TypeBitbucket:
+		return "SOURCE"
+	case purl.TypeGeneric:
+		return ""
+	}
+	return "LIBRARY"
+}
+
+// addPurlLocations sets the home page and download location of the
+// package in the registry of its ecosystem
+func addPurlLocations(pu *purl.PackageURL, pkg *Package) {
+	name := purlPackageName(pu)
+	version := pu.Version
+	switch pu.Type {
+	case purl.TypeNPM:
+		pkg.HomePage = "https://www.npmjs.com/package/" + name
+		if version != "" {
+			pkg.DownloadLocation = fmt.Sprintf("%s/%s/-/%s-%s.tgz", npmRegistryURL, name, pu.Name, version)
+		}
+	case purl.TypePyPi:
+		pkg.HomePage = "https://pypi.org/project/" + name + "/"
+	case purl.TypeCargo:
+		pkg.HomePage = "https://crates.io/crates/" + name
+		if version != "" {
+			pkg.DownloadLocation = fmt.Sprintf("%s/%s/%s/download", cratesIOURL, name, version)
+		}
+	case purl.TypeGolang:
+		if goModuleIsPublic(name) {
+			pkg.HomePage = "https://pkg.go.dev/" + name
+		}
+		if version != "" {
+			pkg.DownloadLocation = fmt.Sprintf("https://proxy.golang.org/%s/@v/%s.zip", name, version)
+		}
+	case purl.TypeGem:
+		pkg.HomePage = "https://rubygems.org/gems/" + name
+		if version != "" {
+			pkg.DownloadLocation = fmt.Sprintf("https://rubygems.org/downloads/%s-%s.gem", name, version)
+		}
+	case purl.TypeNuget:
+		pkg.HomePage = "https://www.nuget.org/packages/" + name
+		if version != "" {
+			pkg.DownloadLocation = fmt.Sprintf("https://www.nuget.org/api/v2/package/%s/%s", name, version)
+		}
+	case purl.TypeMaven:
+		if pu.Namespace == "" || version == "" {
+			return
+		}
+		qualifiers := pu.Qualifiers.Map()
+		repo := mavenCentralURL
+		if qualifiers["repository_url"] != "" {
+			repo = strings.TrimSuffix(qualifiers["repository_url"], "/")
+		}
+		file := pu.Name + "-" + version
+		if qualifiers["classifier"] != "" {
+			file += "-" + qualifiers["classifier"]
+		}
+		ext := "jar"
+		if qualifiers["type"] != "" {
+			ext = qualifiers["type"]
+		}
+		pkg.DownloadLocation = fmt.Sprintf(
+			"%s/%s/%s/%s/%s.%s", repo, strings.ReplaceAll(pu.Namespace, ".", "/"), pu.Name, version, file, ext,
+		)
+	case purl.TypeGithub:
+		pkg.HomePage = "https://github.com/" + name
+		pkg.DownloadLocation = "git+https://github.com/" + name
+		if version != "" {
+			pkg.DownloadLocation += "@" + version
+		}
+	}
+}
+
+// addPurlChecksums reads the checksum qualifier of a purl, a comma
+// separated list of algorithm:value pairs
+func addPurlChecksums(pkg *Package, qualifier string) error {
+	if qualifier == "" {
+		return nil
+	}
+	for _, cs := range strings.Split(qualifier, ",") {
+		algo, value, ok := strings.Cut(cs, ":")
+		if !ok || value == "" {
+			return fmt.Errorf("invalid checksum %q, must be algorithm:value", cs)
+		}
+		if pkg.Checksum == nil {
+			pkg.Checksum = map[string]string{}
+		}
+		pkg.Checksum[strings.ToUpper(strings.ReplaceAll(algo, "-", ""))] = value
+	}
+	return nil
+}
+
+// pypiPackageData reads a python package version from the PyPI JSON API
+func pypiPackageData(ctx context.Context, pu *purl.PackageURL, pkg *Package) error {
+	data := struct {
+		Info struct {
+			Summary           string `json:"summary"`
+			HomePage          string `json:"home_page"`
+			LicenseExpression string `json:"license_expression"`
+		} `json:"info"`
+		URLs []struct {
+			PackageType string            `json:"packagetype"`
+			URL         string            `json:"url"`
+			Digests     map[string]string `json:"digests"`
+		} `json:"urls"`
+	}{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/%s/%s/json", pypiURL, url.PathEscape(pu.Name), url.PathEscape(pu.Version),
+	), &data); err != nil {
+		return err
+	}
+	pkg.Summary = data.Info.Summary
+	if data.Info.HomePage != "" {
+		pkg.HomePage = data.Info.HomePage
+	}
+	if data.Info.LicenseExpression != "" {
+		pkg.LicenseDeclared = data.Info.LicenseExpression
+	}
+	// The source distribution is the download of the package
+	for _, u := range data.URLs {
+		if u.PackageType != "sdist" {
+			continue
+		}
+		pkg.DownloadLocation = u.URL
+		if u.Digests["sha256"] != "" {
+			if pkg.Checksum == nil {
+				pkg.Checksum = map[string]string{}
+			}
+			pkg.Checksum["SHA256"] = u.Digests["sha256"]
+		}
+	}
+	return nil
+}
+
+// npmPackageData reads a node package version from the npm registry
+func npmPackageData(ctx context.Context, pu *purl.PackageURL, pkg *Package) error {
+	name := url.PathEscape(pu.Name)
+	if pu.Namespace != "" {
+		name = url.PathEscape(pu.Namespace) + "/" + name
+	}
+	data := struct {
+		Description string          `json:"description"`
+		Homepage    string          `json:"homepage"`
+		License     json.RawMessage `json:"license"`
+		Dist        struct {
+			Tarball string `json:"tarball"`
+			Shasum  string `json:"shasum"`
+		} `json:"dist"`
+	}{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/%s/%s", npmRegistryURL, name, url.PathEscape(pu.Version),
+	), &data); err != nil {
+		return err
+	}
+	pkg.Description = data.Description
+	if data.Homepage != "" {
+		pkg.HomePage = data.Homepage
+	}
+	// Old packages declare their license as an object, only SPDX
+	// expressions are used
+	var license string
+	if err := json.Unmarshal(data.License, &license); err == nil && license != "" {
+		pkg.LicenseDeclared = license
+	}
+	if data.Dist.Tarball != "" {
+		pkg.DownloadLocation = data.Dist.Tarball
+	}
+	if data.Dist.Shasum != "" {
+		if pkg.Checksum == nil {
+			pkg.Checksum = map[string]string{}
+		}
+		pkg.Checksum["SHA1"] = data.Dist.Shasum
+	}
+	return nil
+}
+
+// cratesPackageData reads a rust crate version from the crates.io API
+func cratesPackageData(ctx context.Context, pu *purl.PackageURL, pkg *Package) error {
+	data := struct {
+		Version struct {
+			License  string `json:"license"`
+			DLPath   string `json:"dl_path"`
+			Checksum string `json:"checksum"`
+		} `json:"version"`
+	}{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/%s/%s", cratesIOURL, url.PathEscape(pu.Name), url.PathEscape(pu.Version),
+	), &data); err != nil {
+		return err
+	}
+	if data.Version.License != "" {
+		pkg.LicenseDeclared = data.Version.License
+	}
+	if data.Version.DLPath != "" {
+		if u, err := url.Parse(cratesIOURL); err == nil {
+			u.Path = path.Join("/", data.Version.DLPath)
+			pkg.DownloadLocation = u.String()
+		}
+	}
+	if data.Version.Checksum != "" {
+		if pkg.Checksum == nil {
+			pkg.Checksum = map[string]string{}
+		}
+		pkg.Checksum["SHA256"] = data.Version.Checksum
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageFromPurl(t *testing.T) {
+	for _, tc := range []struct {
+		purl             string
+		name             string
+		version          string
+		purpose          string
+		downloadLocation string
+		homePage         string
+		checksum         map[string]string
+	}{
+		{
+			"pkg:npm/lodash@4.17.21", "lodash", "4.17.21", "LIBRARY",
+			"https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz", "https://www.npmjs.com/package/lodash", nil,
+		},
+		{
+			"pkg:npm/%40babel/core@7.0.0", "@babel/core", "7.0.0", "LIBRARY",
+			"https://registry.npmjs.org/@babel/core/-/core-7.0.0.tgz", "https://www.npmjs.com/package/@babel/core", nil,
+		},
+		{
+			"pkg:golang/github.com/sirupsen/logrus@v1.9.3", "github.com/sirupsen/logrus", "v1.9.3", "LIBRARY",
+			"https://proxy.golang.org/github.com/sirupsen/logrus/@v/v1.9.3.zip", "https://pkg.go.dev/github.com/sirupsen/logrus", nil,
+		},
+		{
+			"pkg:maven/org.apache.commons/commons-lang3@3.14.0?classifier=sources",
+			"org.apache.commons:commons-lang3", "3.14.0", "LIBRARY",
+			"https://repo1.maven.org/maven2/org/apache/commons/commons-lang3/3.14.0/commons-lang3-3.14.0-sources.jar", "", nil,
+		},
+		{
+			"pkg:cargo/serde@1.0.0", "serde", "1.0.0", "LIBRARY",
+			"https://crates.io/api/v1/crates/serde/1.0.0/download", "https://crates.io/crates/serde", nil,
+		},
+		{
+			"pkg:pypi/requests@2.32.0", "requests", "2.32.0", "LIBRARY",
+			NOASSERTION, "https://pypi.org/project/requests/", nil,
+		},
+		{
+			"pkg:deb/debian/curl@7.88.1?arch=amd64", "curl", "7.88.1", "INSTALL", NOASSERTION, "", nil,
+		},
+		{
+			"pkg:github/kubernetes-sigs/bom@v0.6.0", "kubernetes-sigs/bom", "v0.6.0", "SOURCE",
+			"git+https://github.com/kubernetes-sigs/bom@v0.6.0", "https://github.com/kubernetes-sigs/bom", nil,
+		},
+		{
+			"pkg:generic/openssl@3.0.13?download_url=https://www.openssl.org/source/openssl-3.0.13.tar.gz&checksum=sha256:88525753f79d3bec27d2fa7c66aa0b92b3aa9498dafd93d7cfa4b3780cdae313",
+			"openssl", "3.0.13", "", "https://www.openssl.org/source/openssl-3.0.13.tar.gz", "",
+			map[string]string{"SHA256": "88525753f79d3bec27d2fa7c66aa0b92b3aa9498dafd93d7cfa4b3780cdae313"},
+		},
+	} {
+		pkg, err := PackageFromPurl(tc.purl)
+		require.NoError(t, err, tc.purl)
+		require.Equal(t, tc.name, pkg.Name, tc.purl)
+		require.Equal(t, tc.version, pkg.Version, tc.purl)
+		require.Equal(t, tc.purpose, pkg.PrimaryPurpose, tc.purl)
+		require.Equal(t, tc.downloadLocation, pkg.DownloadLocation, tc.purl)
+		require.Equal(t, tc.homePage, pkg.HomePage, tc.purl)
+		require.Equal(t, tc.checksum, pkg.Checksum, tc.purl)
+		require.NotEmpty(t, pkg.ID, tc.purl)
+		require.NotNil(t, pkg.Purl(), tc.purl)
+	}
+
+	for _, spec := range []string{"lodash", "pkg:npm/", "pkg:generic/x@1?checksum=sha256"} {
+		_, err := PackageFromPurl(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestPackageFromPurlRegistryData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pypi/requests/2.32.0/json":
+			w.Write([]byte(`{"info": {"summary": "Python HTTP for Humans.", "license_expression": "Apache-2.0"}, "urls": [
+				{"packagetype": "bdist_wheel", "url": "https://files.example/requests-2.32.0-py3-none-any.whl"},
+				{"packagetype": "sdist", "url": "https://files.example/requests-2.32.0.tar.gz", "digests": {"sha256": "abc"}}
+			]}`)) //nolint:errcheck
+		case "/npm/lodash/4.17.21":
+			w.Write([]byte(`{"description": "Lodash modular utilities.", "license": "MIT",
+				"dist": {"tarball": "https://registry.example/lodash-4.17.21.tgz", "shasum": "def"}}`)) //nolint:errcheck
+		case "/npm/old/1.0.0":
+			w.Write([]byte(`{"license": {"type": "MIT"}}`)) //nolint:errcheck
+		case "/api/v1/crates/serde/1.0.0":
+			w.Write([]byte(`{"version": {"license": "MIT OR Apache-2.0", "dl_path": "/api/v1/crates/serde/1.0.0/download", "checksum": "123"}}`)) //nolint:errcheck
+		case "/api/v1/crates/time/0.3.0":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	oldPypi, oldNpm, oldCrates := pypiURL, npmRegistryURL, cratesIOURL
+	pypiURL, npmRegistryURL, cratesIOURL = srv.URL+"/pypi", srv.URL+"/npm", srv.URL+"/api/v1/crates"
+	defer func() { pypiURL, npmRegistryURL, cratesIOURL = oldPypi, oldNpm, oldCrates }()
+
+	pkg, err := PackageFromPurl("pkg:pypi/requests@2.32.0", WithRegistryData())
+	require.NoError(t, err)
+	require.Equal(t, "Python HTTP for Humans.", pkg.Summary)
+	require.Equal(t, "Apache-2.0", pkg.LicenseDeclared)
+	require.Equal(t, "https://files.example/requests-2.32.0.tar.gz", pkg.DownloadLocation)
+	require.Equal(t, map[string]string{"SHA256": "abc"}, pkg.Checksum)
+
+	pkg, err = PackageFromPurl("pkg:npm/lodash@4.17.21", WithRegistryData())
+	require.NoError(t, err)
+	require.Equal(t, "Lodash modular utilities.", pkg.Description)
+	require.Equal(t, "MIT", pkg.LicenseDeclared)
+	require.Equal(t, "https://registry.example/lodash-4.17.21.tgz", pkg.DownloadLocation)
+	require.Equal(t, map[string]string{"SHA1": "def"}, pkg.Checksum)
+
+	// Licenses that are not expressions are not read
+	pkg, err = PackageFromPurl("pkg:npm/old@1.0.0", WithRegistryData())
+	require.NoError(t, err)
+	require.Empty(t, pkg.LicenseDeclared)
+
+	pkg, err = PackageFromPurl("pkg:cargo/serde@1.0.0", WithRegistryData())
+	require.NoError(t, err)
+	require.Equal(t, "MIT OR Apache-2.0", pkg.LicenseDeclared)
+	require.Equal(t, srv.URL+"/api/v1/crates/serde/1.0.0/download", pkg.DownloadLocation)
+	require.Equal(t, map[string]string{"SHA256": "123"}, pkg.Checksum)
+
+	// Packages missing in the registry keep the derived data, failed
+	// lookups are recorded as degradations
+	log := &DegradationLog{}
+	pkg, err = PackageFromPurl("pkg:pypi/private-package@1.0.0", WithRegistryData(), WithDegradationLog(log))
+	require.NoError(t, err)
+	require.Equal(t, "private-package", pkg.Name)
+	require.Empty(t, log.Degradations())
+
+	_, err = PackageFromPurl("pkg:cargo/time@0.3.0", WithRegistryData(), WithDegradationLog(log))
+	require.NoError(t, err)
+	require.Len(t, log.Degradations(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = PackageFromPurlContext(ctx, "pkg:npm/lodash@4.17.21", WithRegistryData())
+	require.Error(t, err)
+}
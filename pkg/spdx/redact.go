@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	RedactPath     = "path"
+	RedactUsername = "username"
+	RedactRegistry = "registry"
+	RedactEmail    = "email"
+)
+
+var (
+	// homePathRe matches the user name in home directory paths
+	homePathRe = regexp.MustCompile(`(/home/|/Users/|[A-Za-z]:\\Users\\)([^/\\\s"':]+)`)
+
+	emailRe = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+)
+
+// RedactOptions select the sensitive data removed from a document
+type RedactOptions struct {
+	Paths      []string // Absolute build paths to replace, eg /home/ci/workspace
+	Registries []string // Hostnames of internal registries to replace
+	Usernames  bool     // Replace the user names in home directory paths
+	Emails     bool     // Replace email addresses
+}
+
+// Redaction is a value replaced when redacting a document
+type Redaction struct {
+	Kind        string `json:"kind"`
+	Original    string `json:"original"`
+	Placeholder string `json:"placeholder"`
+}
+
+// redactor replaces the sensitive values in strings, using the same
+// placeholder every time a value is found.
+type redactor struct {
+	opts         *RedactOptions
+	paths        []string
+	placeholders map[string]string
+	redactions   []Redaction
+}
+
+func newRedactor(opts *RedactOptions) *redactor {
+	paths := []string{}
+	for _, p := range opts.Paths {
+		if p = strings.TrimRight(p, `/\`); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	// Longer paths are replaced first, so nested paths get their own
+	// placeholder
+	slices.SortFunc(paths, func(a, b string) int { return len(b) - len(a) })
+	return &redactor{opts: opts, paths: paths, placeholders: map[string]string{}}
+}
+
+// placeholder returns the placeholder for a value, generating a new one
+// with the format if the value was not seen before.
+func (r *redactor) placeholder(kind, original, format string) string {
+	key := kind + "\x00" + original
+	if p, ok := r.placeholders[key]; ok {
+		return p
+	}
+	n := 1
+	for _, rd := range r.redactions {
+		if rd.Kind == kind {
+			n++
+		}
+	}
+	p := fmt.Sprintf(format, n)
+	r.placeholders[key] = p
+	r.redactions = append(r.redactions, Redaction{Kind: kind, Original: original, Placeholder: p})
+	return p
+}
+
+// text redacts a string
+func (r *redactor) text(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, p := range r.paths {
+		s = replaceBounded(s, p, pathBounded, func() string {
+			return r.placeholder(RedactPath, p, "/redacted/path-%d")
+		})
+	}
+	if r.opts.Usernames {
+		s = homePathRe.ReplaceAllStringFunc(s, func(m string) string {
+			parts := homePathRe.FindStringSubmatch(m)
+			return parts[1] + r.placeholder(RedactUsername, parts[2], "user-%d")
+		})
+	}
+	s = r.hosts(s)
+	if r.opts.Emails {
+		s = emailRe.ReplaceAllStringFunc(s, func(m string) string {
+			return r.placeholder(RedactEmail, m, "email-%d@redacted.invalid")
+		})
+	}
+	return s
+}
+
+// hosts redacts the registry hostnames in a string. It is the only
+// redaction applied to element IDs, as the rest of the values are
+// sanitized beyond recognition when building them.
+func (r *redactor) hosts(s string) string {
+	for _, host := range r.opts.Registries {
+		if host == "" {
+			continue
+		}
+		s = replaceBounded(s, host, hostBounded, func() string {
+			return r.placeholder(RedactRegistry, host, "registry-%d.redacted.invalid")
+		})
+	}
+	return s
+}
+
+// replaceBounded replaces the occurrences of old in s for which bounded
+// returns true, bounded gets the string and the position of the match.
+func replaceBounded(s, old string, bounded func(s string, start, end int) bool, replacement func() string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, old)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(old)
+		b.WriteString(s[:i])
+		if bounded(s, i, end) {
+			b.WriteString(replacement())
+		} else {
+			b.WriteString(old)
+		}
+		s = s[end:]
+	}
+}
+
+// pathBounded returns true if a match is a whole path or a path prefix
+func pathBounded(s string, start, end int) bool {
+	if start > 0 && isPathByte(s[start-1]) {
+		return false
+	}
+	return end == len(s) || s[end] == '/' || s[end] == '\\' || !isPathByte(s[end])
+}
+
+// hostBounded returns true if a match is a whole hostname. A dot after
+// it ends a sentence unless it is followed by more of a hostname.
+func hostBounded(s string, start, end int) bool {
+	if start > 0 && isHostByte(s[start-1]) {
+		return false
+	}
+	if end < len(s) && s[end] == '.' {
+		return end+1 == len(s) || !isHostByte(s[end+1])
+	}
+	return end == len(s) || !isHostByte(s[end])
+}
+
+func isHostByte(c byte) bool {
+	return c == '.' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isPathByte(c byte) bool {
+	return isHostByte(c) || c == '_' || c == '~' || c == '+'
+}
+
+// Redact replaces the sensitive data selected in the options with
+// placeholders throughout the document: in the names, paths, comments,
+// locations and annotations of its elements. It returns the values
+// replaced and their placeholders, to trace the redacted document back
+// to the original.
+func (d *Document) Redact(opts *RedactOptions) ([]Redaction, error) {
+	r := newRedactor(opts)
+
+	d.Name = r.text(d.Name)
+	d.Namespace = r.text(d.Namespace)
+	d.Comment = r.text(d.Comment)
+	d.CreatorComment = r.text(d.CreatorComment)
+	d.Creator.Person = r.text(d.Creator.Person)
+	d.Creator.Organization = r.text(d.Creator.Organization)
+	for i := range d.Creator.Tool {
+		d.Creator.Tool[i] = r.text(d.Creator.Tool[i])
+	}
+	r.annotations(d.Annotations)
+	for i := range d.ExternalDocRefs {
+		d.ExternalDocRefs[i].URI = r.text(d.ExternalDocRefs[i].URI)
+	}
+	for i := range d.ExtractedLicenses {
+		d.ExtractedLicenses[i].Text = r.text(d.ExtractedLicenses[i].Text)
+		d.ExtractedLicenses[i].Comment = r.text(d.ExtractedLicenses[i].Comment)
+	}
+	for _, s := range d.Snippets {
+		s.Name = r.text(s.Name)
+		s.Comment = r.text(s.Comment)
+		s.CopyrightText = r.text(s.CopyrightText)
+		s.LicenseComments = r.text(s.LicenseComments)
+	}
+
+	renames := map[string]string{}
+	// The walk function never fails, so Walk can't return an error
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck
+		switch e := o.(type) {
+		case *Package:
+			r.entity(&e.Entity)
+			e.Comment = r.text(e.Comment)
+			e.HomePage = r.text(e.HomePage)
+			e.Summary = r.text(e.Summary)
+			e.Description = r.text(e.Description)
+			e.SourceInfo = r.text(e.SourceInfo)
+			e.Supplier.Person = r.text(e.Supplier.Person)
+			e.Supplier.Organization = r.text(e.Supplier.Organization)
+			e.Originator.Person = r.text(e.Originator.Person)
+			e.Originator.Organization = r.text(e.Originator.Organization)
+			for i := range e.ExternalRefs {
+				e.ExternalRefs[i].Locator = r.text(e.ExternalRefs[i].Locator)
+			}
+		case *File:
+			r.entity(&e.Entity)
+			e.Comment = r.text(e.Comment)
+			e.NoticeText = r.text(e.NoticeText)
+		}
+		if id := r.hosts(o.SPDXID()); id != o.SPDXID() {
+			renames[o.SPDXID()] = id
+		}
+		return nil
+	})
+
+	for oldID, newID := range renames {
+		if err := d.RenameElement(oldID, newID); err != nil {
+			return nil, fmt.Errorf("renaming %s: %w", oldID, err)
+		}
+	}
+	return r.redactions, nil
+}
+
+// entity redacts the fields common to packages and files
+func (r *redactor) entity(e *Entity) {
+	e.Name = r.text(e.Name)
+	e.FileName = r.text(e.FileName)
+	e.DownloadLocation = r.text(e.DownloadLocation)
+	e.CopyrightText = r.text(e.CopyrightText)
+	e.LicenseComments = r.text(e.LicenseComments)
+	for i := range e.AttributionTexts {
+		e.AttributionTexts[i] = r.text(e.AttributionTexts[i])
+	}
+	r.annotations(e.Annotations)
+}
+
+func (r *redactor) annotations(annotations []Annotation) {
+	for i := range annotations {
+		annotations[i].Annotator = r.text(annotations[i].Annotator)
+		annotations[i].Comment = r.text(annotations[i].Comment)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactText(t *testing.T) {
+	r := newRedactor(&RedactOptions{
+		Paths:      []string{"/builds/ci/", "/builds/ci/app"},
+		Registries: []string{"registry.corp.example.com"},
+		Usernames:  true,
+		Emails:     true,
+	})
+	for _, tc := range []struct {
+		text     string
+		expected string
+	}{
+		{"/builds/ci/app/main.go", "/redacted/path-1/main.go"},
+		{"/builds/ci/lib", "/redacted/path-2/lib"},
+		{"file:///builds/ci/app", "file:///redacted/path-1"},
+		{"/builds/cia/lib", "/builds/cia/lib"},
+		{"/home/alice/.cache/go", "/home/user-1/.cache/go"},
+		{`C:\Users\alice\src`, `C:\Users\user-1\src`},
+		{"/Users/bob/src", "/Users/user-2/src"},
+		{"registry.corp.example.com/team/app:v1", "registry-1.redacted.invalid/team/app:v1"},
+		{"pulled from registry.corp.example.com.", "pulled from registry-1.redacted.invalid."},
+		{"registry.corp.example.com.mirror.io/app", "registry.corp.example.com.mirror.io/app"},
+		{"eu.registry.corp.example.com/app", "eu.registry.corp.example.com/app"},
+		{"Person: Alice (alice@corp.example.com)", "Person: Alice (email-1@redacted.invalid)"},
+		{"alice@corp.example.com, bob@corp.example.com", "email-1@redacted.invalid, email-2@redacted.invalid"},
+	} {
+		require.Equal(t, tc.expected, r.text(tc.text), tc.text)
+	}
+	require.Len(t, r.redactions, 7)
+	require.Equal(t, Redaction{Kind: RedactPath, Original: "/builds/ci/app", Placeholder: "/redacted/path-1"}, r.redactions[0])
+}
+
+func TestRedact(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "registry.corp.example.com/team/app"
+	doc.Creator.Person = "Alice (alice@corp.example.com)"
+
+	pkg := NewPackage()
+	pkg.Name = "registry.corp.example.com/team/app"
+	pkg.SetSPDXID("SPDXRef-Package-registry.corp.example.com-team-app")
+	pkg.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:oci/app@sha256%3Aabc?repository_url=registry.corp.example.com%2Fteam%2Fapp",
+	}}
+	f := NewFile()
+	f.SetSPDXID("SPDXRef-File-main")
+	f.Name = "/home/alice/src/app/main.go"
+	f.FileName = f.Name
+	require.NoError(t, pkg.AddFile(f))
+	require.NoError(t, doc.AddPackage(pkg))
+
+	redactions, err := doc.Redact(&RedactOptions{
+		Registries: []string{"registry.corp.example.com"},
+		Usernames:  true,
+		Emails:     true,
+	})
+	require.NoError(t, err)
+	require.Len(t, redactions, 3)
+
+	require.Equal(t, "registry-1.redacted.invalid/team/app", doc.Name)
+	require.Equal(t, "Alice (email-1@redacted.invalid)", doc.Creator.Person)
+	require.Contains(t, doc.Packages, "SPDXRef-Package-registry-1.redacted.invalid-team-app")
+	require.Equal(t, "registry-1.redacted.invalid/team/app", pkg.Name)
+	require.Equal(t,
+		"pkg:oci/app@sha256%3Aabc?repository_url=registry-1.redacted.invalid%2Fteam%2Fapp",
+		pkg.ExternalRefs[0].Locator,
+	)
+	require.Equal(t, "/home/user-1/src/app/main.go", f.FileName)
+}
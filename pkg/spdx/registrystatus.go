@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nozzle/throttler"
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// Endpoints of the registries queried for the status of the packages
+var (
+	pypiURL        = "https://pypi.org/pypi"
+	npmRegistryURL = "https://registry.npmjs.org"
+	cratesIOURL    = "https://crates.io/api/v1/crates"
+)
+
+// registryConcurrency is the number of registry lookups run at once
+const registryConcurrency = 8
+
+// errRegistryNotFound is returned when a registry does not know the
+// package version, eg because it is published in a private registry
+var errRegistryNotFound = errors.New("package version not found in registry")
+
+// RegistryStatus reports a package version withdrawn by its publisher
+type RegistryStatus struct {
+	Purl   string // Package URL of the package version
+	State  string // yanked or deprecated
+	Reason string // Reason given by the publisher, if any
+}
+
+// String returns a description of the status.
+func (rs *RegistryStatus) String() string {
+	s := fmt.Sprintf("Package version is %s in its registry", rs.State)
+	if rs.Reason != "" {
+		s += ": " + rs.Reason
+	}
+	return s
+}
+
+// registryLookups query the status of a package version, by purl type.
+// They return nil if the version is neither yanked nor deprecated.
+var registryLookups = map[string]func(context.Context, *purl.PackageURL) (*RegistryStatus, error){
+	purl.TypePyPi:  pypiStatus,
+	purl.TypeNPM:   npmStatus,
+	purl.TypeCargo: cratesStatus,
+}
+
+// CheckRegistryStatus looks up the PyPI, npm and crates.io packages in
+// their registries and annotates the versions that were yanked or
+// deprecated by their publishers. The lookups that fail are recorded as
+// network degradations in log. Returns the flagged package versions.
+func (d *Document) CheckRegistryStatus(ctx context.Context, log *DegradationLog) ([]RegistryStatus, error) {
+	pkgs := map[string][]*Package{}
+	purls := map[string]*purl.PackageURL{}
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		p, ok := o.(*Package)
+		if !ok {
+			return nil
+		}
+		pu := p.Purl()
+		if pu == nil || pu.Version == "" || registryLookups[pu.Type] == nil {
+			return nil
+		}
+		key := pu.ToString()
+		purls[key] = pu
+		pkgs[key] = append(pkgs[key], p)
+		return nil
+	})
+	logrus.Infof("Checking the registry status of %d package versions", len(purls))
+
+	results := []RegistryStatus{}
+	mtx := sync.Mutex{}
+	t := throttler.New(registryConcurrency, len(purls))
+	for key, pu := range purls {
+		go func() {
+			status, err := registryLookups[pu.Type](ctx, pu)
+			switch {
+			case errors.Is(err, errRegistryNotFound):
+				logrus.Debugf("%s not found in its registry", key)
+			case err != nil && ctx.Err() == nil:
+				log.record(DegradationNetworkError, "Unable to check the registry status of %s: %v", key, err)
+			case status != nil:
+				status.Purl = key
+				mtx.Lock()
+				results = append(results, *status)
+				mtx.Unlock()
+			}
+			t.Done(nil)
+		}()
+		t.Throttle()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Purl < results[j].Purl })
+	date := time.Now().UTC().Format(time.RFC3339)
+	for _, status := range results {
+		logrus.Warnf("%s: %s", status.Purl, status.String())
+		for _, p := range pkgs[status.Purl] {
+			p.Annotations = append(p.Annotations, Annotation{
+				Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+				Date:      date,
+				Type:      "OTHER",
+				Comment:   status.String(),
+			})
+		}
+	}
+	return results, nil
+}
+
+// registryGet fetches a JSON document from a registry API
+func registryGet(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	// crates.io refuses requests without a user agent
+	req.Header.Set("User-Agent", "bom/"+version.GetVersionInfo().GitVersion)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("querying registry: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errRegistryNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("registry returned %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding registry response: %w", err)
+	}
+	return nil
+}
+
+// pypiStatus looks up a python package version in the PyPI JSON API
+func pypiStatus(ctx context.Context, pu *purl.PackageURL) (*RegistryStatus, error) {
+	data := struct {
+		Info struct {
+			Yanked       bool   `json:"yanked"`
+			YankedReason string `json:"yanked_reason"`
+		} `json:"info"`
+	}{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/%s/%s/json", pypiURL, url.PathEscape(pu.Name), url.PathEscape(pu.Version),
+	), &data); err != nil {
+		return nil, err
+	}
+	if !data.Info.Yanked {
+		return nil, nil
+	}
+	return &RegistryStatus{State: "yanked", Reason: data.Info.YankedReason}, nil
+}
+
+// npmStatus looks up a node package version in the npm registry
+func npmStatus(ctx context.Context, pu *purl.PackageURL) (*RegistryStatus, error) {
+	name := url.PathEscape(pu.Name)
+	if pu.Namespace != "" {
+		name = url.PathEscape(pu.Namespace) + "/" + name
+	}
+	data := struct {
+		Deprecated string `json:"deprecated"`
+	}{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/%s/%s", npmRegistryURL, name, url.PathEscape(pu.Version),
+	), &data); err != nil {
+		return nil, err
+	}
+	if data.Deprecated == "" {
+		return nil, nil
+	}
+	return &RegistryStatus{State: "deprecated", Reason: data.Deprecated}, nil
+}
+
+// cratesStatus looks up a rust crate version in the crates.io API
+func cratesStatus(ctx context.Context, pu *purl.PackageURL) (*RegistryStatus, error) {
+	data := struct {
+		Version struct {
+			Yanked      bool   `json:"yanked"`
+			YankMessage string `json:"yank_message"`
+		} `json:"version"`
+	}{}
+	if err := registryGet(ctx, fmt.Sprintf(
+		"%s/%s/%s", cratesIOURL, url.PathEscape(pu.Name), url.PathEscape(pu.Version),
+	), &data); err != nil {
+		return nil, err
+	}
+	if !data.Version.Yanked {
+		return nil, nil
+	}
+	return &RegistryStatus{State: "yanked", Reason: data.Version.YankMessage}, nil
+}
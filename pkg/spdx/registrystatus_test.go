@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRegistryStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pypi/requests/2.32.0/json":
+			w.Write([]byte(`{"info": {"yanked": true, "yanked_reason": "Yanked due to conflicts with CVE-2024-35195"}}`)) //nolint:errcheck
+		case "/pypi/urllib3/2.2.2/json":
+			w.Write([]byte(`{"info": {"yanked": false, "yanked_reason": null}}`)) //nolint:errcheck
+		case "/npm/@babel/core/7.0.0":
+			w.Write([]byte(`{"name": "@babel/core", "deprecated": "Upgrade to a supported version"}`)) //nolint:errcheck
+		case "/crates/time/0.3.0":
+			w.Write([]byte(`{"version": {"yanked": true, "yank_message": null}}`)) //nolint:errcheck
+		case "/crates/serde/1.0.0":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	oldPypi, oldNpm, oldCrates := pypiURL, npmRegistryURL, cratesIOURL
+	pypiURL, npmRegistryURL, cratesIOURL = srv.URL+"/pypi", srv.URL+"/npm", srv.URL+"/crates"
+	defer func() { pypiURL, npmRegistryURL, cratesIOURL = oldPypi, oldNpm, oldCrates }()
+
+	doc := NewDocument()
+	pkgs := map[string]*Package{}
+	for _, p := range []string{
+		"pkg:pypi/requests@2.32.0",
+		"pkg:pypi/urllib3@2.2.2",
+		"pkg:pypi/private-package@1.0.0",
+		"pkg:npm/%40babel/core@7.0.0",
+		"pkg:cargo/time@0.3.0",
+		"pkg:cargo/serde@1.0.0",
+		"pkg:golang/github.com/sirupsen/logrus@v1.9.3",
+	} {
+		pkg := NewPackage()
+		pkg.BuildID(p)
+		pkg.Name = p
+		pkg.ExternalRefs = []ExternalRef{{Category: CatPackageManager, Type: "purl", Locator: p}}
+		require.NoError(t, doc.AddPackage(pkg))
+		pkgs[p] = pkg
+	}
+
+	log := &DegradationLog{}
+	flagged, err := doc.CheckRegistryStatus(context.Background(), log)
+	require.NoError(t, err)
+	require.Equal(t, []RegistryStatus{
+		{Purl: "pkg:cargo/time@0.3.0", State: "yanked"},
+		{Purl: "pkg:npm/%40babel/core@7.0.0", State: "deprecated", Reason: "Upgrade to a supported version"},
+		{Purl: "pkg:pypi/requests@2.32.0", State: "yanked", Reason: "Yanked due to conflicts with CVE-2024-35195"},
+	}, flagged)
+
+	require.Len(t, pkgs["pkg:pypi/requests@2.32.0"].Annotations, 1)
+	require.Equal(t,
+		"Package version is yanked in its registry: Yanked due to conflicts with CVE-2024-35195",
+		pkgs["pkg:pypi/requests@2.32.0"].Annotations[0].Comment,
+	)
+	require.Empty(t, pkgs["pkg:pypi/urllib3@2.2.2"].Annotations)
+	require.Empty(t, pkgs["pkg:pypi/private-package@1.0.0"].Annotations)
+
+	// The failed lookup is recorded as a degradation
+	degradations := log.Degradations()
+	require.Len(t, degradations, 1)
+	require.Equal(t, DegradationNetworkError, degradations[0].Kind)
+	require.Contains(t, degradations[0].Message, "pkg:cargo/serde@1.0.0")
+}
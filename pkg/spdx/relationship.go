@@ -19,6 +19,7 @@ package spdx
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 type RelationshipType string
@@ -127,5 +128,25 @@ func (ro *Relationship) Render(hostObject Object) (string, error) {
 			"Relationship: %s %s %s%s\n", hostObject.SPDXID(), ro.Type, peerExtRef, ro.PeerReference,
 		)
 	}
+	if ro.Comment != "" {
+		docFragment += fmt.Sprintf("RelationshipComment: <text>%s</text>\n", ro.Comment)
+	}
 	return docFragment, nil
 }
+
+// peerID returns the identifier of the relationship peer. Peers in
+// other documents are qualified with the external document reference.
+func (ro *Relationship) peerID() string {
+	id := ro.PeerReference
+	if ro.Peer != nil {
+		id = ro.Peer.SPDXID()
+	}
+	switch {
+	case ro.PeerExtReference == "":
+		return id
+	case strings.Contains(ro.PeerExtReference, ":"):
+		// Relationships read from SPDX JSON record the qualified ID
+		return ro.PeerExtReference
+	}
+	return "DocumentRef-" + ro.PeerExtReference + ":" + id
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RenameElement changes the SPDX ID of an element in the document. The
+// relationships pointing to it, the snippets of a renamed file and the
+// list of described elements are updated to use the new ID.
+func (d *Document) RenameElement(oldID, newID string) error {
+	if err := ValidateID(newID); err != nil {
+		return err
+	}
+	if oldID == newID {
+		return nil
+	}
+	if oldID == d.ID {
+		return errors.New("the document ID cannot be renamed")
+	}
+
+	o := d.GetElementByID(oldID)
+	if o == nil {
+		return fmt.Errorf("element %s not found in document", oldID)
+	}
+	if d.GetElementByID(newID) != nil {
+		return fmt.Errorf("an element with ID %s already exists in the document", newID)
+	}
+
+	o.SetSPDXID(newID)
+
+	if p, ok := d.Packages[oldID]; ok {
+		delete(d.Packages, oldID)
+		d.Packages[newID] = p
+	}
+	if f, ok := d.Files[oldID]; ok {
+		delete(d.Files, oldID)
+		d.Files[newID] = f
+	}
+	for i := range d.Describes {
+		if d.Describes[i] == oldID {
+			d.Describes[i] = newID
+		}
+	}
+
+	// Relationships loaded from documents may reference their peers
+	// only by ID, references to external documents are left alone
+	d.Walk(func(el Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		for _, rel := range relationshipsOf(el) {
+			if rel.PeerExtReference == "" && rel.PeerReference == oldID {
+				rel.PeerReference = newID
+			}
+		}
+		return nil
+	})
+
+	for _, s := range d.Snippets {
+		if s.FromFile == oldID {
+			s.FromFile = newID
+		}
+	}
+
+	d.InvalidateIndex()
+	return nil
+}
+
+// ParseRename parses a rename specification in the form OLD_ID=NEW_ID.
+func ParseRename(spec string) (oldID, newID string, err error) {
+	oldID, newID, ok := strings.Cut(spec, "=")
+	if !ok || oldID == "" || newID == "" {
+		return "", "", fmt.Errorf("invalid rename %q, must be OLD_ID=NEW_ID", spec)
+	}
+	return oldID, newID, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameElement(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "rename"
+
+	app := NewPackage()
+	app.Name = "app"
+	app.ID = "SPDXRef-Package-app"
+	lib := NewPackage()
+	lib.Name = "lib"
+	lib.ID = "SPDXRef-Package-lib"
+	tool := NewPackage()
+	tool.Name = "tool"
+	tool.ID = "SPDXRef-Package-tool"
+	file := NewFile()
+	file.Name = "main.c"
+	file.ID = "SPDXRef-File-main"
+
+	require.NoError(t, lib.AddFile(file))
+	require.NoError(t, app.AddPackage(lib))
+	// Relationships read from documents can point to the peer only by ID
+	tool.AddRelationship(&Relationship{Type: DEPENDS_ON, PeerReference: lib.ID})
+	tool.AddRelationship(&Relationship{Type: DEPENDS_ON, PeerReference: lib.ID, PeerExtReference: "other"})
+	require.NoError(t, doc.AddPackage(app))
+	require.NoError(t, doc.AddPackage(tool))
+	doc.Snippets = []*Snippet{{ID: "SPDXRef-Snippet-main", FromFile: file.ID}}
+	require.NoError(t, doc.SetDescribes(app.ID))
+
+	// Nested package
+	require.NoError(t, doc.RenameElement("SPDXRef-Package-lib", "SPDXRef-Package-acme-lib"))
+	require.Equal(t, "SPDXRef-Package-acme-lib", lib.SPDXID())
+	require.Equal(t, lib, doc.GetElementByID("SPDXRef-Package-acme-lib"))
+	require.Nil(t, doc.GetElementByID("SPDXRef-Package-lib"))
+	require.Equal(t, "SPDXRef-Package-acme-lib", tool.Relationships[0].PeerReference)
+	require.Equal(t, "SPDXRef-Package-lib", tool.Relationships[1].PeerReference)
+
+	// Top level package
+	require.NoError(t, doc.RenameElement("SPDXRef-Package-app", "SPDXRef-Package-acme-app"))
+	require.Contains(t, doc.Packages, "SPDXRef-Package-acme-app")
+	require.NotContains(t, doc.Packages, "SPDXRef-Package-app")
+	require.Equal(t, []string{"SPDXRef-Package-acme-app"}, doc.Describes)
+
+	// File
+	require.NoError(t, doc.RenameElement("SPDXRef-File-main", "SPDXRef-File-acme-main"))
+	require.Equal(t, "SPDXRef-File-acme-main", doc.Snippets[0].FromFile)
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	require.Contains(t, rendered, "Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-acme-app\n")
+	require.Contains(t, rendered, "Relationship: SPDXRef-Package-acme-lib CONTAINS SPDXRef-File-acme-main\n")
+	require.False(t, strings.Contains(rendered, "SPDXRef-Package-app\n"))
+
+	for _, tc := range []struct {
+		oldID, newID string
+	}{
+		{"SPDXRef-Package-missing", "SPDXRef-Package-new"},
+		{"SPDXRef-Package-tool", "SPDXRef-Package-acme-app"},
+		{"SPDXRef-Package-tool", "Package-tool"},
+		{"SPDXRef-Package-tool", "SPDXRef-Package tool"},
+		{"SPDXRef-DOCUMENT", "SPDXRef-DOC"},
+	} {
+		require.Error(t, doc.RenameElement(tc.oldID, tc.newID), tc.oldID+"="+tc.newID)
+	}
+	require.NoError(t, doc.RenameElement("SPDXRef-Package-tool", "SPDXRef-Package-tool"))
+}
+
+func TestParseRename(t *testing.T) {
+	for _, tc := range []struct {
+		spec      string
+		oldID     string
+		newID     string
+		shouldErr bool
+	}{
+		{"SPDXRef-a=SPDXRef-b", "SPDXRef-a", "SPDXRef-b", false},
+		{"SPDXRef-a", "", "", true},
+		{"=SPDXRef-b", "", "", true},
+		{"SPDXRef-a=", "", "", true},
+	} {
+		oldID, newID, err := ParseRename(tc.spec)
+		if tc.shouldErr {
+			require.Error(t, err, tc.spec)
+			continue
+		}
+		require.NoError(t, err, tc.spec)
+		require.Equal(t, tc.oldID, oldID)
+		require.Equal(t, tc.newID, newID)
+	}
+}
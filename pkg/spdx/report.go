@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// GenerationReport is a machine readable summary of a document
+// generation: what was scanned, what was skipped and how long it took.
+// It is meant to track the quality of the generated SBOMs over time.
+type GenerationReport struct {
+	BOMVersion string          `json:"bomVersion"`
+	Started    time.Time       `json:"started"`
+	Duration   float64         `json:"durationSeconds"`
+	Inputs     []ReportInput   `json:"inputs"`
+	Steps      []ReportStep    `json:"steps"`
+	Skipped    []ReportSkip    `json:"skipped"`
+	Degraded   []Degradation   `json:"degradations"`
+	Packages   []ReportPackage `json:"packages"`
+
+	mtx sync.Mutex
+
+	// licenseConfidences holds the confidence of the license classifier
+	// in the licenses found in directories, keyed by path
+	licenseConfidences map[string]float64
+}
+
+// ReportInput is an artifact passed to be scanned
+type ReportInput struct {
+	Type     string `json:"type"`
+	Location string `json:"location"`
+}
+
+// ReportStep records the time taken by a generation step
+type ReportStep struct {
+	Name     string  `json:"name"`
+	Duration float64 `json:"durationSeconds"`
+}
+
+// ReportSkip records data left out of the document on purpose
+type ReportSkip struct {
+	Location string `json:"location"`
+	Reason   string `json:"reason"`
+}
+
+// ReportPackage summarizes the licensing data of a package
+type ReportPackage struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	Version           string  `json:"version,omitempty"`
+	LicenseDeclared   string  `json:"licenseDeclared,omitempty"`
+	LicenseConcluded  string  `json:"licenseConcluded,omitempty"`
+	LicenseConfidence float64 `json:"licenseConfidence,omitempty"`
+}
+
+// recordSkipped records data left out of the document being generated.
+// Nothing is recorded in a nil report
+func (r *GenerationReport) recordSkipped(location, reason string) {
+	if r == nil {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.Skipped = append(r.Skipped, ReportSkip{Location: location, Reason: reason})
+}
+
+// recordLicenseConfidence records the confidence of the license found in
+// a directory. Nothing is recorded in a nil report
+func (r *GenerationReport) recordLicenseConfidence(path string, confidence float64) {
+	if r == nil {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.licenseConfidences[path] = confidence
+}
+
+// licenseConfidence returns the confidence of the license found in a
+// directory, or 0 if it was not recorded
+func (r *GenerationReport) licenseConfidence(path string) float64 {
+	if r == nil {
+		return 0
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.licenseConfidences[path]
+}
+
+// newGenerationReport starts a report of a document generation
+func newGenerationReport(genopts *DocGenerateOptions) *GenerationReport {
+	report := &GenerationReport{
+		BOMVersion: version.GetVersionInfo().GitVersion,
+		Started:    time.Now().UTC(),
+		Inputs:     []ReportInput{},
+		Steps:      []ReportStep{},
+		Skipped:    []ReportSkip{},
+		Degraded:   []Degradation{},
+		Packages:   []ReportPackage{},
+
+		licenseConfidences: map[string]float64{},
+	}
+	for _, input := range []struct {
+		Type      string
+		Locations []string
+	}{
+		{"directory", genopts.Directories},
+		{"image", genopts.Images},
+		{"image-archive", genopts.Tarballs},
+		{"archive", genopts.Archives},
+		{"rootfs", genopts.Rootfs},
+		{"file", genopts.Files},
+	} {
+		for _, l := range input.Locations {
+			report.Inputs = append(report.Inputs, ReportInput{Type: input.Type, Location: l})
+		}
+	}
+	return report
+}
+
+// addStep records the duration of a generation step
+func (r *GenerationReport) addStep(name string, d time.Duration) {
+	r.Steps = append(r.Steps, ReportStep{Name: name, Duration: d.Seconds()})
+}
+
+// finish completes the report with the contents of the generated document
+func (r *GenerationReport) finish(doc *Document, degradations []Degradation) {
+	r.Duration = time.Since(r.Started).Seconds()
+	r.Degraded = append(r.Degraded, degradations...)
+
+	doc.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		if p, ok := o.(*Package); ok {
+			r.Packages = append(r.Packages, ReportPackage{
+				ID:                p.SPDXID(),
+				Name:              p.Name,
+				Version:           p.Version,
+				LicenseDeclared:   p.LicenseDeclared,
+				LicenseConcluded:  p.LicenseConcluded,
+				LicenseConfidence: p.licenseConfidence,
+			})
+		}
+		return nil
+	})
+}
+
+// Write writes the report as JSON to a file
+func (r *GenerationReport) Write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling generation report: %w", err)
+	}
+	if err := os.WriteFile(path, data, os.FileMode(0o644)); err != nil {
+		return fmt.Errorf("writing generation report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerationReport(t *testing.T) {
+	report := newGenerationReport(&DocGenerateOptions{
+		Directories: []string{"src"},
+		Images:      []string{"registry.k8s.io/pause:3.9"},
+	})
+	report.addStep("directories", 2*time.Second)
+	report.recordSkipped("src", "3 files matched the ignore patterns")
+	report.recordLicenseConfidence("src", 0.98)
+
+	pkg := NewPackage()
+	pkg.BuildID("src")
+	pkg.Name = "src"
+	pkg.LicenseConcluded = "Apache-2.0"
+	pkg.licenseConfidence = report.licenseConfidence("src")
+	doc := NewDocument()
+	require.NoError(t, doc.AddPackage(pkg))
+	report.finish(doc, []Degradation{{Kind: DegradationNetworkError, Message: "download failed"}})
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, report.Write(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	read := &GenerationReport{}
+	require.NoError(t, json.Unmarshal(data, read))
+
+	require.Equal(t, []ReportInput{
+		{Type: "directory", Location: "src"},
+		{Type: "image", Location: "registry.k8s.io/pause:3.9"},
+	}, read.Inputs)
+	require.Equal(t, []ReportStep{{Name: "directories", Duration: 2}}, read.Steps)
+	require.Equal(t, []ReportSkip{{Location: "src", Reason: "3 files matched the ignore patterns"}}, read.Skipped)
+	require.Equal(t, []Degradation{{Kind: DegradationNetworkError, Message: "download failed"}}, read.Degraded)
+	require.Equal(t, []ReportPackage{{
+		ID: pkg.SPDXID(), Name: "src", LicenseConcluded: "Apache-2.0", LicenseConfidence: 0.98,
+	}}, read.Packages)
+
+	// Each report has its own data, a nil report records nothing
+	require.Empty(t, newGenerationReport(&DocGenerateOptions{}).Skipped)
+	var none *GenerationReport
+	none.recordSkipped("src", "3 files matched the ignore patterns")
+	none.recordLicenseConfidence("src", 0.98)
+	require.Zero(t, none.licenseConfidence("src"))
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+)
+
+// elementIDRe matches valid SPDX element identifiers
+var elementIDRe = regexp.MustCompile(`^SPDXRef-[A-Za-z0-9.-]+$`)
+
+// ValidateID checks that id is a valid SPDX element identifier.
+func ValidateID(id string) error {
+	if !elementIDRe.MatchString(id) {
+		return fmt.Errorf("invalid SPDX ID %q, must start with SPDXRef- followed by letters, numbers, . or -", id)
+	}
+	return nil
+}
+
+// SanitizeIDString returns s with the characters not allowed in SPDX IDs
+// replaced. Slashes and colons become dashes to keep the sense of the
+// string (eg in image references), other characters are encoded as C
+// followed by their unicode code point so different strings do not
+// collide (@scope/name becomes C64scope-name).
+func SanitizeIDString(s string) string {
+	for _, r := range []string{"/", ":"} {
+		s = strings.ReplaceAll(s, r, "-")
+	}
+	return validIDCharsRe.ReplaceAllStringFunc(s, func(invalid string) string {
+		var b strings.Builder
+		for _, r := range invalid {
+			fmt.Fprintf(&b, "C%d", r)
+		}
+		return b.String()
+	})
+}
+
+// SanitizeText makes a single line text field SPDX compliant. Control
+// characters (eg newlines, which break tag-value documents) and bytes
+// that are not valid UTF-8 are percent-encoded. When any character is
+// encoded, percent signs are too, so the original value can be recovered
+// by unescaping the result (eg with url.PathUnescape). Returns true if
+// the value was modified.
+func SanitizeText(s string) (sanitized string, modified bool) {
+	needsEncoding := func(r rune, size int) bool {
+		return (r == utf8.RuneError && size == 1) || unicode.IsControl(r)
+	}
+
+	clean := true
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if needsEncoding(r, size) {
+			clean = false
+			break
+		}
+		i += size
+	}
+	if clean {
+		return s, false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == '%' || needsEncoding(r, size) {
+			for j := range size {
+				fmt.Fprintf(&b, "%%%02X", s[i+j])
+			}
+		} else {
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String(), true
+}
+
+// Sanitize makes the names and IDs of all the elements in the document
+// SPDX compliant. Text fields are sanitized with SanitizeText and elements
+// with invalid IDs are renamed. A comment recording the change is added to
+// each modified element so the original values can be recovered. Returns
+// the number of elements modified.
+func (d *Document) Sanitize() int {
+	objects := []Object{}
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		objects = append(objects, o)
+		return nil
+	})
+
+	modified := 0
+	for _, o := range objects {
+		notes := []string{}
+		var entity *Entity
+		switch e := o.(type) {
+		case *Package:
+			entity = &e.Entity
+			if v, ok := SanitizeText(e.Version); ok {
+				e.Version = v
+				notes = append(notes, "PackageVersion")
+			}
+		case *File:
+			entity = &e.Entity
+		default:
+			continue
+		}
+		for _, field := range []struct {
+			tag   string
+			value *string
+		}{
+			{"Name", &entity.Name},
+			{"FileName", &entity.FileName},
+		} {
+			if v, ok := SanitizeText(*field.value); ok {
+				*field.value = v
+				notes = append(notes, field.tag)
+			}
+		}
+
+		comment := ""
+		if len(notes) > 0 {
+			comment = fmt.Sprintf(
+				"bom percent-encoded the %s to make it SPDX compliant, unescape it to get the original value.",
+				strings.Join(notes, " and "),
+			)
+		}
+		if oldID := o.SPDXID(); oldID != "" && ValidateID(oldID) != nil {
+			if err := d.RenameElement(oldID, d.sanitizedID(oldID)); err != nil {
+				logrus.Warnf("Unable to sanitize SPDX ID %s: %v", oldID, err)
+			} else {
+				original, _ := SanitizeText(oldID)
+				comment = strings.TrimSpace(comment + " Original SPDX ID: " + original)
+			}
+		}
+		if comment == "" {
+			continue
+		}
+
+		modified++
+		switch e := o.(type) {
+		case *Package:
+			e.Comment = strings.TrimSpace(e.Comment + "\n" + comment)
+		case *File:
+			e.Comment = strings.TrimSpace(e.Comment + "\n" + comment)
+		}
+	}
+	return modified
+}
+
+// sanitizedID returns a valid ID derived from an invalid one, unique in
+// the document.
+func (d *Document) sanitizedID(id string) string {
+	base := "SPDXRef-" + SanitizeIDString(strings.TrimPrefix(id, "SPDXRef-"))
+	newID := base
+	for i := 1; d.GetElementByID(newID) != nil; i++ {
+		newID = fmt.Sprintf("%s-%04d", base, i)
+	}
+	return newID
+}
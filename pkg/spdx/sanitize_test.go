@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeIDString(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{"name", "name"},
+		{"@scope/name", "C64scope-name"},
+		{"registry.k8s.io/pause:3.9", "registry.k8s.io-pause-3.9"},
+		{"año", "aC241o"},
+		{"日本", "C26085C26412"},
+	} {
+		require.Equal(t, tc.expected, SanitizeIDString(tc.input))
+	}
+
+	// Unicode seeds do not collide
+	require.NotEqual(t, buildIDString("SPDXRef-File", "日"), buildIDString("SPDXRef-File", "本"))
+}
+
+func TestSanitizeText(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+		modified bool
+	}{
+		{"name", "name", false},
+		{"100% cotton", "100% cotton", false},
+		{"日本.txt", "日本.txt", false},
+		{"evil\nname", "evil%0Aname", true},
+		{"100%\t", "100%25%09", true},
+		{"file\xff.txt", "file%FF.txt", true},
+	} {
+		res, modified := SanitizeText(tc.input)
+		require.Equal(t, tc.expected, res, tc.input)
+		require.Equal(t, tc.modified, modified, tc.input)
+
+		// The encoding is reversible
+		if modified {
+			original, err := url.PathUnescape(res)
+			require.NoError(t, err)
+			require.Equal(t, tc.input, original)
+		}
+	}
+}
+
+func TestValidateID(t *testing.T) {
+	for _, tc := range []struct {
+		id        string
+		shouldErr bool
+	}{
+		{"SPDXRef-Package-C64scope-name", false},
+		{"SPDXRef-File-1.0", false},
+		{"SPDXRef-", true},
+		{"Package-name", true},
+		{"SPDXRef-Package-@scope/name", true},
+		{"SPDXRef-Package-日本", true},
+	} {
+		err := ValidateID(tc.id)
+		if tc.shouldErr {
+			require.Error(t, err, tc.id)
+		} else {
+			require.NoError(t, err, tc.id)
+		}
+	}
+}
+
+func TestDocumentSanitize(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "sanitize"
+
+	scoped := NewPackage()
+	scoped.Name = "@scope/name"
+	scoped.Version = "1.0.0"
+	scoped.ID = "SPDXRef-Package-@scope/name"
+	evil := NewPackage()
+	evil.Name = "evil\nPackageName: injected"
+	evil.ID = "SPDXRef-Package-evil"
+	file := NewFile()
+	file.Name = "dir/file\xff.txt"
+	file.ID = "SPDXRef-File-dir-file"
+	require.NoError(t, evil.AddFile(file))
+	evil.AddRelationship(&Relationship{Type: DEPENDS_ON, PeerReference: scoped.ID})
+	require.NoError(t, doc.AddPackage(scoped))
+	require.NoError(t, doc.AddPackage(evil))
+
+	require.Equal(t, 3, doc.Sanitize())
+
+	require.Equal(t, "SPDXRef-Package-C64scope-name", scoped.SPDXID())
+	require.Contains(t, doc.Packages, scoped.SPDXID())
+	require.Equal(t, "@scope/name", scoped.Name)
+	require.Contains(t, scoped.Comment, "Original SPDX ID: SPDXRef-Package-@scope/name")
+	require.Equal(t, scoped.SPDXID(), evil.Relationships[1].PeerReference)
+
+	require.Equal(t, "evil%0APackageName: injected", evil.Name)
+	require.Contains(t, evil.Comment, "percent-encoded the Name")
+	require.Equal(t, "SPDXRef-Package-evil", evil.SPDXID())
+
+	require.Equal(t, "dir/file%FF.txt", file.Name)
+	require.Contains(t, file.Comment, "percent-encoded the Name")
+
+	// Sanitized documents are left unchanged
+	require.Zero(t, doc.Sanitize())
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	require.NotContains(t, rendered, "\nPackageName: injected")
+	require.True(t, strings.Contains(rendered, "SPDXID: SPDXRef-Package-C64scope-name\n"))
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"sigs.k8s.io/bom/pkg/sarif"
+)
+
+// Rules of the findings reported in SARIF
+const (
+	SARIFRuleLicenseUnknown    = "license/unknown-id"
+	SARIFRuleLicenseDeprecated = "license/deprecated-id"
+	SARIFRuleValidation        = "validation/failed"
+	sarifGraphRulePrefix       = "graph/"
+)
+
+var sarifRules = map[string]sarif.Rule{
+	SARIFRuleLicenseUnknown: {
+		ShortDescription:     sarif.Message{Text: "License identifier not in the SPDX license list"},
+		DefaultConfiguration: &sarif.Configuration{Level: sarif.LevelError},
+	},
+	SARIFRuleLicenseDeprecated: {
+		ShortDescription:     sarif.Message{Text: "Deprecated SPDX license identifier"},
+		DefaultConfiguration: &sarif.Configuration{Level: sarif.LevelWarning},
+	},
+	SARIFRuleValidation: {
+		ShortDescription:     sarif.Message{Text: "Artifact does not match the SBOM"},
+		DefaultConfiguration: &sarif.Configuration{Level: sarif.LevelError},
+	},
+	sarifGraphRulePrefix + string(GraphDanglingRelationship): {
+		ShortDescription:     sarif.Message{Text: "Relationship to an element not defined in the document"},
+		DefaultConfiguration: &sarif.Configuration{Level: sarif.LevelError},
+	},
+	sarifGraphRulePrefix + string(GraphContainsCycle): {
+		ShortDescription:     sarif.Message{Text: "Cycle of CONTAINS relationships"},
+		DefaultConfiguration: &sarif.Configuration{Level: sarif.LevelError},
+	},
+	sarifGraphRulePrefix + string(GraphOrphanedElement): {
+		ShortDescription:     sarif.Message{Text: "Element not reachable from the described elements"},
+		DefaultConfiguration: &sarif.Configuration{Level: sarif.LevelWarning},
+	},
+}
+
+// SARIFReport collects the problems found in a document as SARIF
+// results. The results point to the line of the SBOM defining the
+// element when the document contents are known.
+type SARIFReport struct {
+	log     *sarif.Log
+	uri     string
+	content []byte
+}
+
+// NewSARIFReport returns a report about the document at uri. The
+// content of the document is used to locate the elements, it can
+// be nil.
+func NewSARIFReport(uri string, content []byte) *SARIFReport {
+	return &SARIFReport{
+		log:     sarif.New("bom", version.GetVersionInfo().GitVersion, "https://github.com/kubernetes-sigs/bom"),
+		uri:     uri,
+		content: content,
+	}
+}
+
+// Log returns the SARIF log of the report
+func (r *SARIFReport) Log() *sarif.Log {
+	return r.log
+}
+
+// AddGraphIssues adds the integrity problems of the document graph
+func (r *SARIFReport) AddGraphIssues(issues []GraphIssue) {
+	for _, issue := range issues {
+		r.add(sarifGraphRulePrefix+string(issue.Kind), issue.Message, issue.ElementID)
+	}
+}
+
+// AddLicenseResults adds the packages with unknown or deprecated
+// license identifiers from the results of ValidateLicenses
+func (r *SARIFReport) AddLicenseResults(results []ValidationResults) {
+	for _, res := range results {
+		switch {
+		case !res.Success:
+			r.add(SARIFRuleLicenseUnknown, res.FileName+": "+res.Message, res.PackageID)
+		case res.Message != MessageLicensesValid:
+			r.add(SARIFRuleLicenseDeprecated, res.FileName+": "+res.Message, res.PackageID)
+		}
+	}
+}
+
+// AddValidationResults adds the failed checks of artifacts against
+// the document
+func (r *SARIFReport) AddValidationResults(results []ValidationResults) {
+	for _, res := range results {
+		if res.Success {
+			continue
+		}
+		message := res.FileName + ": " + res.Message
+		if len(res.FailedAlgorithms) > 0 {
+			message += " (" + strings.Join(res.FailedAlgorithms, ", ") + ")"
+		}
+		r.add(SARIFRuleValidation, message, res.PackageID)
+	}
+}
+
+func (r *SARIFReport) add(ruleID, message, elementID string) {
+	rule, ok := sarifRules[ruleID]
+	if !ok {
+		rule = sarif.Rule{ShortDescription: sarif.Message{Text: ruleID}}
+	}
+	rule.ID = ruleID
+	r.log.AddRule(rule)
+
+	location := sarif.Location{
+		PhysicalLocation: &sarif.PhysicalLocation{
+			ArtifactLocation: sarif.ArtifactLocation{URI: r.uri},
+			Region:           &sarif.Region{StartLine: elementLine(r.content, elementID)},
+		},
+	}
+	if elementID != "" {
+		location.LogicalLocations = []sarif.LogicalLocation{{
+			Name: elementID, FullyQualifiedName: elementID, Kind: "element",
+		}}
+	}
+	r.log.AddResult(sarif.Result{ //nolint:errcheck // The rule is registered above
+		RuleID:    ruleID,
+		Message:   sarif.Message{Text: message},
+		Locations: []sarif.Location{location},
+	})
+}
+
+// elementLine returns the line defining the element in the serialized
+// document, or the first line mentioning it. Elements not found point
+// to the first line of the document.
+func elementLine(content []byte, id string) int {
+	if id == "" {
+		return 1
+	}
+	mentioned := 0
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if !containsID(line, id) {
+			continue
+		}
+		if strings.Contains(line, "SPDXID") {
+			return n
+		}
+		if mentioned == 0 {
+			mentioned = n
+		}
+	}
+	if mentioned == 0 {
+		return 1
+	}
+	return mentioned
+}
+
+// containsID checks if the line has the ID not followed by more ID
+// characters, so SPDXRef-a does not match SPDXRef-ab
+func containsID(line, id string) bool {
+	for i := strings.Index(line, id); i != -1; {
+		end := i + len(id)
+		if end == len(line) || !isIDChar(line[end]) {
+			return true
+		}
+		next := strings.Index(line[i+1:], id)
+		if next == -1 {
+			return false
+		}
+		i += next + 1
+	}
+	return false
+}
+
+func isIDChar(c byte) bool {
+	return c == '-' || c == '.' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
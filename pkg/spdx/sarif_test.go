@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/sarif"
+)
+
+func TestElementLine(t *testing.T) {
+	content := []byte(`{
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-Package-ab"}
+  ],
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-Package-ab",
+      "name": "ab"
+    },
+    {
+      "SPDXID": "SPDXRef-Package-a",
+      "name": "a"
+    }
+  ]
+}`)
+	for _, tc := range []struct {
+		id       string
+		expected int
+	}{
+		{"SPDXRef-Package-ab", 7},
+		{"SPDXRef-Package-a", 11},
+		{"SPDXRef-DOCUMENT", 3},
+		{"SPDXRef-Package-missing", 1},
+		{"", 1},
+	} {
+		require.Equal(t, tc.expected, elementLine(content, tc.id), tc.id)
+	}
+	require.Equal(t, 1, elementLine(nil, "SPDXRef-Package-a"))
+}
+
+func TestSARIFReport(t *testing.T) {
+	report := NewSARIFReport("sbom.spdx.json", []byte("SPDXID: SPDXRef-Package-a\nSPDXID: SPDXRef-Package-b\n"))
+	report.AddGraphIssues([]GraphIssue{
+		{Kind: GraphOrphanedElement, ElementID: "SPDXRef-Package-b", Message: "orphaned"},
+	})
+	report.AddLicenseResults([]ValidationResults{
+		{Success: true, Message: MessageLicensesValid, FileName: "ok", PackageID: "SPDXRef-Package-a"},
+		{Success: false, Message: "unknown license IDs", FileName: "a", PackageID: "SPDXRef-Package-a"},
+		{Success: true, Message: "deprecated license IDs: GPL-2.0", FileName: "b", PackageID: "SPDXRef-Package-b"},
+	})
+	report.AddValidationResults([]ValidationResults{
+		{Success: true, FileName: "ok"},
+		{Success: false, Message: MessageHashMismatch, FileName: "main.go", FailedAlgorithms: []string{"SHA256"}},
+	})
+
+	results := report.Log().Results()
+	require.Len(t, results, 4)
+	for i, expected := range []struct {
+		rule    string
+		level   sarif.Level
+		line    int
+		message string
+	}{
+		{"graph/orphaned-element", sarif.LevelWarning, 2, "orphaned"},
+		{SARIFRuleLicenseUnknown, sarif.LevelError, 1, "a: unknown license IDs"},
+		{SARIFRuleLicenseDeprecated, sarif.LevelWarning, 2, "b: deprecated license IDs: GPL-2.0"},
+		{SARIFRuleValidation, sarif.LevelError, 1, "main.go: Hash mismatch (SHA256)"},
+	} {
+		require.Equal(t, expected.rule, results[i].RuleID)
+		require.Equal(t, expected.level, results[i].Level)
+		require.Equal(t, expected.message, results[i].Message.Text)
+		require.Equal(t, "sbom.spdx.json", results[i].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		require.Equal(t, expected.line, results[i].Locations[0].PhysicalLocation.Region.StartLine)
+	}
+	require.Len(t, report.Log().Runs[0].Tool.Driver.Rules, 4)
+}
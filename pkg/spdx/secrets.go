@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA1 is required by the SPDX file checksums
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+// maxSecretScanSize is the largest file read looking for secrets
+const maxSecretScanSize = 1 << 20
+
+// secretPatterns match well known credential formats in file contents
+var secretPatterns = []struct {
+	Kind string
+	Re   *regexp.Regexp
+}{
+	{"AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"GitLab token", regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9\-]{10,}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"Credentials in URL", regexp.MustCompile(`\b[a-z][a-z0-9+.\-]*://[^/\s:@"']+:[^/\s@"']+@[^\s"']+`)},
+}
+
+// SecretFinding is a private key, certificate or credential found in a
+// file. The secret itself is never recorded.
+type SecretFinding struct {
+	Kind   string // Kind of secret, eg: Private key
+	Line   int    // Line of the file where the secret starts
+	Detail string // Additional data, eg: the expiry of a certificate
+}
+
+// String returns a description of the finding.
+func (sf *SecretFinding) String() string {
+	s := fmt.Sprintf("Possible secret: %s at line %d", sf.Kind, sf.Line)
+	if sf.Detail != "" {
+		s += " (" + sf.Detail + ")"
+	}
+	return s
+}
+
+// detectFileSecrets reads a file looking for private keys, certificates
+// and credentials. Files larger than maxSecretScanSize are only read up
+// to that size.
+func detectFileSecrets(path string) ([]SecretFinding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSecretScanSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	return detectSecrets(data, time.Now()), nil
+}
+
+// addLayerSecretFiles scans the files of an image layer tarball for
+// secrets. The files where secrets are found are added to the layer
+// package, annotated with the findings.
+func addLayerSecretFiles(pkg *Package, layerPath string) error {
+	return walkLayerFiles(layerPath, func(string) bool { return true }, func(filePath string, r io.Reader) error {
+		sha1sum, sha256sum := sha1.New(), sha256.New() //nolint:gosec
+		r = io.TeeReader(r, io.MultiWriter(sha1sum, sha256sum))
+		data, err := io.ReadAll(io.LimitReader(r, maxSecretScanSize))
+		if err != nil {
+			return fmt.Errorf("reading %s from layer: %w", filePath, err)
+		}
+		findings := detectSecrets(data, time.Now())
+		if len(findings) == 0 {
+			return nil
+		}
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return fmt.Errorf("hashing %s from layer: %w", filePath, err)
+		}
+		logrus.Warnf("Found %d possible secrets in %s", len(findings), filePath)
+
+		f := NewFile()
+		f.FileName = path.Clean(filePath)
+		f.Name = f.FileName
+		f.Checksum = map[string]string{
+			"SHA1":   hex.EncodeToString(sha1sum.Sum(nil)),
+			"SHA256": hex.EncodeToString(sha256sum.Sum(nil)),
+		}
+		f.BuildID(pkg.ID, f.FileName)
+		f.addSecretAnnotations(findings)
+		if err := pkg.AddFile(f); err != nil {
+			return fmt.Errorf("adding %s to the layer package: %w", filePath, err)
+		}
+		return nil
+	})
+}
+
+// detectSecrets returns the secrets found in data. Certificates are
+// reported as expired when they are no longer valid at now.
+func detectSecrets(data []byte, now time.Time) []SecretFinding {
+	findings := []SecretFinding{}
+	lineAt := func(offset int) int {
+		return bytes.Count(data[:offset], []byte("\n")) + 1
+	}
+
+	// PEM encoded keys and certificates
+	rest := data
+	for {
+		start := bytes.Index(rest, []byte("-----BEGIN "))
+		if start == -1 {
+			break
+		}
+		offset := len(data) - len(rest) + start
+		block, next := pem.Decode(rest[start:])
+		if block == nil {
+			rest = rest[start+len("-----BEGIN "):]
+			continue
+		}
+		rest = next
+		switch {
+		case strings.Contains(block.Type, "PRIVATE KEY"):
+			findings = append(findings, SecretFinding{Kind: "Private key", Line: lineAt(offset), Detail: block.Type})
+		case block.Type == "CERTIFICATE":
+			findings = append(findings, SecretFinding{
+				Kind: "Certificate", Line: lineAt(offset), Detail: certificateDetail(block.Bytes, now),
+			})
+		}
+	}
+
+	for _, p := range secretPatterns {
+		for _, loc := range p.Re.FindAllIndex(data, -1) {
+			findings = append(findings, SecretFinding{Kind: p.Kind, Line: lineAt(loc[0])})
+		}
+	}
+	return findings
+}
+
+// certificateDetail describes the subject and expiry of a DER encoded
+// certificate.
+func certificateDetail(der []byte, now time.Time) string {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "unable to parse certificate"
+	}
+	detail := fmt.Sprintf(
+		"subject: %s, expires: %s", cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339),
+	)
+	if now.After(cert.NotAfter) {
+		detail += ", expired"
+	}
+	return detail
+}
+
+// addSecretAnnotations records the secrets found in a file as
+// annotations of the file.
+func (f *File) addSecretAnnotations(findings []SecretFinding) {
+	date := time.Now().UTC().Format(time.RFC3339)
+	for i := range findings {
+		f.Annotations = append(f.Annotations, Annotation{
+			Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+			Date:      date,
+			Type:      "OTHER",
+			Comment:   findings[i].String(),
+		})
+	}
+}
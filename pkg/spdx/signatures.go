@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+const (
+	// Annotations cosign adds to the layers of signatures and attestations
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	cosignPredicateAnnotation   = "predicateType"
+
+	// Kinds of image signatures
+	SignatureKindSignature   = "signature"
+	SignatureKindAttestation = "attestation"
+)
+
+var (
+	// Fulcio certificate extensions holding the OIDC issuer of the signer
+	oidFulcioIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+	oidFulcioIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+)
+
+// ImageSignature is a cosign signature or attestation attached to an
+// image in its registry.
+type ImageSignature struct {
+	Kind          string // signature | attestation
+	Digest        string // Digest of the signature layer
+	Identity      string // Subject of the signing certificate, empty when signed with a key
+	Issuer        string // OIDC issuer that certified the identity
+	PredicateType string // Type of the attestation predicate
+}
+
+// String returns a one line description of the signature.
+func (s *ImageSignature) String() string {
+	desc := "cosign " + s.Kind + " " + s.Digest
+	if s.PredicateType != "" {
+		desc += " predicate " + s.PredicateType
+	}
+	switch {
+	case s.Identity != "":
+		desc += fmt.Sprintf(" (identity: %s, issuer: %s)", s.Identity, s.Issuer)
+	case s.Kind == SignatureKindSignature:
+		desc += " (signed with a key)"
+	}
+	return desc
+}
+
+// discoverImageSignatures looks up the cosign signatures and attestations
+// of an image in the repository it was pulled from. Signatures are not
+// verified, they only record what is attached to the image.
+func discoverImageSignatures(ctx context.Context, digestRef string) ([]ImageSignature, error) {
+	ref, err := name.NewDigest(digestRef)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image digest: %w", err)
+	}
+
+	// cosign attaches signatures to the sha256-<hex>.sig and .att tags
+	tagPrefix := strings.Replace(ref.DigestStr(), ":", "-", 1)
+	sigs := []ImageSignature{}
+	for _, attached := range []struct{ kind, suffix string }{
+		{SignatureKindSignature, ".sig"},
+		{SignatureKindAttestation, ".att"},
+	} {
+		kind := attached.kind
+		tag := ref.Context().Tag(tagPrefix + attached.suffix)
+		img, err := remote.Image(tag, remote.WithContext(ctx))
+		if err != nil {
+			var terr *transport.Error
+			if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("fetching %s: %w", tag, err)
+		}
+		manifest, err := img.Manifest()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s manifest: %w", tag, err)
+		}
+		for _, layer := range manifest.Layers {
+			sig := ImageSignature{
+				Kind:          kind,
+				Digest:        layer.Digest.String(),
+				PredicateType: layer.Annotations[cosignPredicateAnnotation],
+			}
+			if certPEM := layer.Annotations[cosignCertificateAnnotation]; certPEM != "" {
+				sig.Identity, sig.Issuer, err = certificateIdentity(certPEM)
+				if err != nil {
+					return nil, fmt.Errorf("reading %s certificate: %w", kind, err)
+				}
+			}
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs, nil
+}
+
+// certificateIdentity returns the subject and issuer recorded in a
+// Fulcio signing certificate.
+func certificateIdentity(certPEM string) (identity, issuer string, err error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return "", "", errors.New("no PEM data found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", fmt.Errorf("parsing certificate: %w", err)
+	}
+
+	switch {
+	case len(cert.EmailAddresses) > 0:
+		identity = cert.EmailAddresses[0]
+	case len(cert.URIs) > 0:
+		identity = cert.URIs[0].String()
+	}
+
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidFulcioIssuer):
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err != nil {
+				return "", "", fmt.Errorf("parsing issuer extension: %w", err)
+			}
+		case ext.Id.Equal(oidFulcioIssuerV1) && issuer == "":
+			issuer = string(ext.Value)
+		}
+	}
+	return identity, issuer, nil
+}
+
+// addSignatureAnnotations records the signatures of an image in its
+// package as annotations.
+func (p *Package) addSignatureAnnotations(sigs []ImageSignature) {
+	date := time.Now().UTC().Format(time.RFC3339)
+	for i := range sigs {
+		p.Annotations = append(p.Annotations, Annotation{
+			Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+			Date:      date,
+			Type:      "OTHER",
+			Comment:   sigs[i].String(),
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+// testSigningCertificate returns a PEM certificate for email issued by
+// issuer, like the ones fulcio issues to keyless signers.
+func testSigningCertificate(t *testing.T, email, issuer string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	issuerValue, err := asn1.Marshal(issuer)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		NotBefore:       time.Now(),
+		NotAfter:        time.Now().Add(10 * time.Minute),
+		EmailAddresses:  []string{email},
+		ExtraExtensions: []pkix.Extension{{Id: oidFulcioIssuer, Value: issuerValue}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestCertificateIdentity(t *testing.T) {
+	cert := testSigningCertificate(t, "release@example.com", "https://accounts.google.com")
+	identity, issuer, err := certificateIdentity(cert)
+	require.NoError(t, err)
+	require.Equal(t, "release@example.com", identity)
+	require.Equal(t, "https://accounts.google.com", issuer)
+
+	_, _, err = certificateIdentity("not a certificate")
+	require.Error(t, err)
+}
+
+func TestDiscoverImageSignatures(t *testing.T) {
+	reg := spdxtest.NewRegistry(t)
+	signed := reg.PushImage(t, "signed:1.0", spdxtest.RandomImage(t, v1.Platform{OS: "linux", Architecture: "amd64"}, 1))
+	unsigned := reg.PushImage(t, "unsigned:1.0", spdxtest.RandomImage(t, v1.Platform{OS: "linux", Architecture: "amd64"}, 1))
+
+	// Attach a keyless signature and an attestation as cosign does
+	cert := testSigningCertificate(t, "release@example.com", "https://accounts.google.com")
+	tagPrefix := "signed:" + strings.Replace(strings.SplitN(signed, "@", 2)[1], ":", "-", 1)
+	sigLayer := static.NewLayer([]byte(`{"critical":{}}`), types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json"))
+	sigImage, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       sigLayer,
+		Annotations: map[string]string{cosignCertificateAnnotation: cert},
+	})
+	require.NoError(t, err)
+	reg.PushImage(t, tagPrefix+".sig", sigImage)
+
+	attLayer := static.NewLayer([]byte(`{"payloadType":"application/vnd.in-toto+json"}`), types.MediaType("application/vnd.dsse.envelope.v1+json"))
+	attImage, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       attLayer,
+		Annotations: map[string]string{cosignPredicateAnnotation: "https://slsa.dev/provenance/v1"},
+	})
+	require.NoError(t, err)
+	reg.PushImage(t, tagPrefix+".att", attImage)
+
+	sigs, err := discoverImageSignatures(context.Background(), signed)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+
+	sigDigest, err := sigLayer.Digest()
+	require.NoError(t, err)
+	require.Equal(t, ImageSignature{
+		Kind:     SignatureKindSignature,
+		Digest:   sigDigest.String(),
+		Identity: "release@example.com",
+		Issuer:   "https://accounts.google.com",
+	}, sigs[0])
+
+	attDigest, err := attLayer.Digest()
+	require.NoError(t, err)
+	require.Equal(t, ImageSignature{
+		Kind:          SignatureKindAttestation,
+		Digest:        attDigest.String(),
+		PredicateType: "https://slsa.dev/provenance/v1",
+	}, sigs[1])
+
+	pkg := NewPackage()
+	pkg.addSignatureAnnotations(sigs)
+	require.Len(t, pkg.Annotations, 2)
+	require.Equal(t,
+		"cosign signature "+sigDigest.String()+" (identity: release@example.com, issuer: https://accounts.google.com)",
+		pkg.Annotations[0].Comment,
+	)
+	require.Equal(t,
+		"cosign attestation "+attDigest.String()+" predicate https://slsa.dev/provenance/v1",
+		pkg.Annotations[1].Comment,
+	)
+
+	sigs, err = discoverImageSignatures(context.Background(), unsigned)
+	require.NoError(t, err)
+	require.Empty(t, sigs)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var snippetTemplate = `SnippetSPDXID: {{ .ID }}
+SnippetFromFileSPDXID: {{ .FromFile }}
+{{ if .ByteRange.IsSet }}SnippetByteRange: {{ .ByteRange }}
+{{ end -}}
+{{ if .LineRange.IsSet }}SnippetLineRange: {{ .LineRange }}
+{{ end -}}
+SnippetLicenseConcluded: {{ if .LicenseConcluded }}{{ .LicenseConcluded }}{{ else }}NOASSERTION{{ end }}
+{{ range .LicenseInfoInSnippet }}LicenseInfoInSnippet: {{ . }}
+{{ end -}}
+{{ if .LicenseComments }}SnippetLicenseComments: <text>{{ .LicenseComments }}
+</text>
+{{ end -}}
+SnippetCopyrightText: {{ if .CopyrightText }}<text>{{ .CopyrightText }}
+</text>{{ else }}NOASSERTION{{ end }}
+{{ if .Comment }}SnippetComment: <text>{{ .Comment }}
+</text>
+{{ end -}}
+{{ if .Name }}SnippetName: {{ .Name }}
+{{ end }}
+`
+
+// Snippet is a fragment of a file that may have been copied from a
+// different source and has its own licensing information.
+type Snippet struct {
+	ID                   string       // SPDXRef-Snippet
+	Name                 string       // Optional name of the snippet
+	FromFile             string       // SPDX ID of the file that contains the snippet
+	ByteRange            SnippetRange // Range of bytes of the snippet in the file
+	LineRange            SnippetRange // Range of lines of the snippet in the file
+	LicenseConcluded     string       // License of the snippet
+	LicenseInfoInSnippet []string     // Licenses found in the snippet text
+	LicenseComments      string       // Notes about the licensing of the snippet
+	CopyrightText        string       // Copyright text found in the snippet
+	Comment              string       // General comments about the snippet
+}
+
+// SnippetRange is the span of a snippet in its file, both ends included.
+type SnippetRange struct {
+	Start int
+	End   int
+}
+
+// IsSet returns true if the range has been defined.
+func (r SnippetRange) IsSet() bool {
+	return r.Start != 0 || r.End != 0
+}
+
+// String returns the range in the tag-value format (start:end).
+func (r SnippetRange) String() string {
+	return fmt.Sprintf("%d:%d", r.Start, r.End)
+}
+
+// ParseSnippetRange parses a range from its tag-value format (start:end).
+func ParseSnippetRange(value string) (SnippetRange, error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok {
+		return SnippetRange{}, fmt.Errorf("invalid snippet range %q", value)
+	}
+	s, err := strconv.Atoi(start)
+	if err != nil {
+		return SnippetRange{}, fmt.Errorf("parsing snippet range start: %w", err)
+	}
+	e, err := strconv.Atoi(end)
+	if err != nil {
+		return SnippetRange{}, fmt.Errorf("parsing snippet range end: %w", err)
+	}
+	return SnippetRange{Start: s, End: e}, nil
+}
+
+// Render renders the tag-value fragment of the snippet.
+func (s *Snippet) Render() (string, error) {
+	if s.ID == "" {
+		return "", fmt.Errorf("unable to render snippet %s, it has no SPDX ID", s.Name)
+	}
+	if s.FromFile == "" {
+		return "", fmt.Errorf("unable to render snippet %s, it does not point to a file", s.ID)
+	}
+
+	tmpl, err := template.New("snippet").Parse(snippetTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing snippet template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, s); err != nil {
+		return "", fmt.Errorf("executing spdx snippet template: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/mod/module"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// SourceCaches are local directories holding package sources. The
+// license scanners read the packages found in them instead of
+// downloading them again.
+type SourceCaches struct {
+	GoModCache  string   // Go module cache
+	CargoHome   string   // Cargo home, the sources of its registries are read
+	CargoVendor []string // Directories written by cargo vendor
+	PipCache    string   // pip cache, the wheels built in it are read
+}
+
+// DefaultSourceCaches returns the caches the go, cargo and pip tools
+// use, as configured in the environment. Caches not present in the
+// system are left empty.
+func DefaultSourceCaches() *SourceCaches {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	existing := func(paths ...string) string {
+		for _, p := range paths {
+			if p != "" && util.Exists(p) {
+				return p
+			}
+		}
+		return ""
+	}
+
+	caches := &SourceCaches{}
+	gopath := filepath.SplitList(os.Getenv("GOPATH"))
+	if len(gopath) > 0 && gopath[0] != "" {
+		caches.GoModCache = existing(os.Getenv("GOMODCACHE"), filepath.Join(gopath[0], "pkg", "mod"))
+	} else if home != "" {
+		caches.GoModCache = existing(os.Getenv("GOMODCACHE"), filepath.Join(home, "go", "pkg", "mod"))
+	}
+
+	if home != "" {
+		caches.CargoHome = existing(os.Getenv("CARGO_HOME"), filepath.Join(home, ".cargo"))
+	}
+
+	xdgCache := os.Getenv("XDG_CACHE_HOME")
+	if xdgCache == "" && home != "" {
+		xdgCache = filepath.Join(home, ".cache")
+	}
+	if xdgCache != "" {
+		caches.PipCache = existing(os.Getenv("PIP_CACHE_DIR"), filepath.Join(xdgCache, "pip"))
+	}
+	return caches
+}
+
+// Find returns the path to the sources of a package in the caches, a
+// directory or an archive. Returns an empty string if the package is not
+// in any cache.
+func (c *SourceCaches) Find(pu *purl.PackageURL) string {
+	if c == nil || pu == nil || pu.Name == "" || pu.Version == "" {
+		return ""
+	}
+	var path string
+	switch pu.Type {
+	case purl.TypeGolang:
+		// Package URLs lowercase the module paths, modules with upper case
+		// letters are only found with GoModule
+		path = c.GoModule(strings.TrimPrefix(pu.Namespace+"/"+pu.Name, "/"), pu.Version)
+	case purl.TypeCargo:
+		path = c.crate(pu.Name, pu.Version)
+	case purl.TypePyPi:
+		path = c.wheel(pu.Name, pu.Version)
+	}
+	if path != "" {
+		logrus.Debugf("Found %s in local cache %s", pu.ToString(), path)
+	}
+	return path
+}
+
+// GoModule returns the directory of a go module version in the module
+// cache or an empty string if it is not there.
+func (c *SourceCaches) GoModule(path, version string) string {
+	if c == nil || c.GoModCache == "" {
+		return ""
+	}
+	escPath, err := module.EscapePath(path)
+	if err != nil {
+		return ""
+	}
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return ""
+	}
+	dir := filepath.Join(c.GoModCache, escPath+"@"+escVersion)
+	if !util.Exists(dir) {
+		return ""
+	}
+	return dir
+}
+
+// crate looks for a crate in the vendor directories and then in the
+// registry sources of cargo home
+func (c *SourceCaches) crate(name, version string) string {
+	for _, vendor := range c.CargoVendor {
+		// cargo vendor adds the version to the directory name only when
+		// more than one version of the crate is vendored
+		if dir := filepath.Join(vendor, name+"-"+version); util.Exists(dir) {
+			return dir
+		}
+		dir := filepath.Join(vendor, name)
+		if cargoManifestVersion(filepath.Join(dir, "Cargo.toml")) == version {
+			return dir
+		}
+	}
+
+	if c.CargoHome == "" {
+		return ""
+	}
+	matches, err := filepath.Glob(filepath.Join(c.CargoHome, "registry", "src", "*", name+"-"+version))
+	if err != nil || len(matches) == 0 {
+		return ""
+	}
+	return matches[0]
+}
+
+var cargoVersionRe = regexp.MustCompile(`(?m)^version\s*=\s*"([^"]+)"`)
+
+// cargoManifestVersion returns the version in a Cargo.toml file
+func cargoManifestVersion(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	// The package table comes first in the manifests written by cargo
+	m := cargoVersionRe.FindSubmatch(data)
+	if m == nil {
+		return ""
+	}
+	return string(m[1])
+}
+
+var wheelNameRe = regexp.MustCompile(`[-_.]+`)
+
+// wheel looks for a wheel of a python package built in the pip cache
+func (c *SourceCaches) wheel(name, version string) string {
+	if c.PipCache == "" {
+		return ""
+	}
+	// Wheel file names use the normalized package name with underscores
+	prefix := strings.ToLower(wheelNameRe.ReplaceAllString(name, "_")) + "-" + version + "-"
+	found := ""
+	//nolint:errcheck // Unreadable directories are skipped
+	filepath.WalkDir(filepath.Join(c.PipCache, "wheels"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fs.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".whl") ||
+			!strings.HasPrefix(strings.ToLower(d.Name()), prefix) {
+			return nil
+		}
+		found = path
+		return fs.SkipAll
+	})
+	return found
+}
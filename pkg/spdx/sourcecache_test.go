@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	purl "github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceCachesFind(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{
+		"gomod/github.com/!burnt!sushi/toml@v1.4.0",
+		"gomod/github.com/docker/docker@v20.10.7+incompatible",
+		"vendor/serde",
+		"vendor/syn-1.0.109",
+		"cargo/registry/src/index.crates.io-6f17d22bba15001f/anyhow-1.0.86",
+		"pip/wheels/1a/2b/3c",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), os.FileMode(0o755)))
+	}
+	for path, content := range map[string]string{
+		"vendor/serde/Cargo.toml": "[package]\nname = \"serde\"\nversion = \"1.0.210\"\n",
+		"pip/wheels/1a/2b/3c/PyYAML-6.0.1-cp312-cp312-linux_x86_64.whl": "",
+		"pip/wheels/1a/2b/3c/typing_extensions-4.12.2-py3-none-any.whl": "",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), os.FileMode(0o644)))
+	}
+
+	caches := &SourceCaches{
+		GoModCache:  filepath.Join(dir, "gomod"),
+		CargoHome:   filepath.Join(dir, "cargo"),
+		CargoVendor: []string{filepath.Join(dir, "vendor")},
+		PipCache:    filepath.Join(dir, "pip"),
+	}
+	for _, tc := range []struct {
+		purl     string
+		expected string
+	}{
+		{"pkg:golang/github.com/docker/docker@v20.10.7%2Bincompatible", "gomod/github.com/docker/docker@v20.10.7+incompatible"},
+		{"pkg:golang/github.com/docker/docker@v20.10.6", ""},
+		{"pkg:cargo/serde@1.0.210", "vendor/serde"},
+		{"pkg:cargo/serde@1.0.200", ""},
+		{"pkg:cargo/syn@1.0.109", "vendor/syn-1.0.109"},
+		{"pkg:cargo/anyhow@1.0.86", "cargo/registry/src/index.crates.io-6f17d22bba15001f/anyhow-1.0.86"},
+		{"pkg:pypi/pyyaml@6.0.1", "pip/wheels/1a/2b/3c/PyYAML-6.0.1-cp312-cp312-linux_x86_64.whl"},
+		{"pkg:pypi/typing-extensions@4.12.2", "pip/wheels/1a/2b/3c/typing_extensions-4.12.2-py3-none-any.whl"},
+		{"pkg:pypi/requests@2.32.0", ""},
+		{"pkg:npm/lodash@4.17.21", ""},
+	} {
+		pu, err := purl.FromString(tc.purl)
+		require.NoError(t, err)
+		expected := ""
+		if tc.expected != "" {
+			expected = filepath.Join(dir, tc.expected)
+		}
+		require.Equal(t, expected, caches.Find(&pu), tc.purl)
+	}
+
+	// Upper case letters are escaped in the module cache
+	require.Equal(t,
+		filepath.Join(dir, "gomod/github.com/!burnt!sushi/toml@v1.4.0"),
+		caches.GoModule("github.com/BurntSushi/toml", "v1.4.0"),
+	)
+	require.Empty(t, caches.GoModule("github.com/BurntSushi/toml", "v1.3.0"))
+
+	// A nil set of caches finds nothing
+	var noCaches *SourceCaches
+	pu, err := purl.FromString("pkg:cargo/serde@1.0.210")
+	require.NoError(t, err)
+	require.Empty(t, noCaches.Find(&pu))
+}
+
+func TestDefaultSourceCaches(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"gopath/pkg/mod", "cargo", "cache/pip"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), os.FileMode(0o755)))
+	}
+	t.Setenv("GOMODCACHE", "")
+	t.Setenv("GOPATH", filepath.Join(dir, "gopath"))
+	t.Setenv("CARGO_HOME", filepath.Join(dir, "cargo"))
+	t.Setenv("PIP_CACHE_DIR", "")
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+
+	require.Equal(t, &SourceCaches{
+		GoModCache: filepath.Join(dir, "gopath", "pkg", "mod"),
+		CargoHome:  filepath.Join(dir, "cargo"),
+		PipCache:   filepath.Join(dir, "cache", "pip"),
+	}, DefaultSourceCaches())
+
+	// Caches missing in the system are not returned
+	t.Setenv("GOPATH", filepath.Join(dir, "missing"))
+	require.Empty(t, DefaultSourceCaches().GoModCache)
+}
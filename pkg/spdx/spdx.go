@@ -17,14 +17,16 @@ limitations under the License.
 package spdx
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
-	"unicode/utf8"
+	"sync"
 
 	"github.com/google/uuid"
 	purl "github.com/package-url/packageurl-go"
@@ -40,6 +42,7 @@ const (
 	spdxLicenseData         = spdxTempDir + "/licenses"
 	spdxLicenseDlCache      = spdxTempDir + "/downloadCache"
 	gitIgnoreFile           = ".gitignore"
+	bomIgnoreFile           = ".bomignore"
 
 	// Consts of some SPDX expressions.
 	NONE            = "NONE"
@@ -49,6 +52,9 @@ const (
 	entOrganization = "Organization"
 
 	CatPackageManager = "PACKAGE-MANAGER"
+	CatSecurity       = "SECURITY"
+	CatPersistentID   = "PERSISTENT-ID"
+	CatOther          = "OTHER"
 
 	termBanner = `ICAgICAgICAgICAgICAgXyAgICAgIAogX19fIF8gX18gICBfX3wgfF8gIF9fCi8gX198ICdfIFwg
 LyBfYCBcIFwvIC8KXF9fIFwgfF8pIHwgKF98IHw+ICA8IAp8X19fLyAuX18vIFxfXyxfL18vXF9c
@@ -59,17 +65,28 @@ var (
 	// https://spdx.github.io/spdx-spec/3-package-information/#32-package-spdx-identifier
 	validIDCharsRe          = regexp.MustCompile(`[^a-zA-Z0-9-.]+`)
 	SupportedHashAlgorithms = []string{"SHA1", "SHA256", "SHA25"}
+
+	// Magic bytes used to detect the archive formats
+	zipMagic      = []byte{'P', 'K', 0x03, 0x04}
+	sevenZipMagic = []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}
+	xzMagic       = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
 )
 
 type SPDX struct {
 	impl    spdxImplementation
 	options *Options
+
+	// goToolchains caches the go toolchain and standard library packages
+	// by name and version, shared by all the go modules scanned
+	goToolchains    map[string]*Package
+	goToolchainsMtx sync.Mutex
 }
 
 // ImageReferenceInfo is a type to move information about a container image reference.
 type ImageReferenceInfo struct {
 	Digest    string
 	Reference string
+	Tag       string // Tag of the reference the image was pulled from
 	Archive   string
 	Arch      string
 	OS        string
@@ -100,6 +117,56 @@ type Options struct {
 	LicenseData        string   // Directory to store the SPDX licenses
 	LicenseListVersion string   // Version of the SPDX license list to use
 	IgnorePatterns     []string // Patterns to ignore when scanning file
+	MaxManifestDepth   int      // Levels to search for nested projects in directories, -1 is unlimited
+	ExcludeDevDeps     bool     // Do not include development dependencies (eg test only deps)
+	GoOS               string   // GOOS used to resolve go dependencies
+	GoArch             string   // GOARCH used to resolve go dependencies
+	GoBuildTags        []string // Build tags used to resolve go dependencies
+	GoStdlib           bool     // Add the go standard library as a dependency of go modules and binaries
+	PersistentIDs      bool     // Compute the Software Heritage IDs of scanned directories
+	DiscoverSignatures bool     // Record the cosign signatures and attestations of images
+	DetectSecrets      bool     // Annotate files containing private keys, certificates and credentials
+	ModelMetadata      bool     // Record the metadata embedded in machine learning model files
+	ProcessBazel       bool     // Read the external dependencies declared in bazel workspaces
+	ProcessCMake       bool     // Read the external content fetched by CMake projects
+	ProcessPython      bool     // Read the python packages installed in images
+	ProcessNode        bool     // Read the node packages installed in images
+	ProcessJava        bool     // Identify java archives in images and archives
+	ProcessRust        bool     // Read the dependencies of rust crates
+	AnnotateKnownEOL   bool     // Record images based on OS releases past their end of life
+	ImageEnvValues     bool     // Record the values of the image environment variables, not only their names
+
+	// Baseline is a previous document to reuse the unchanged images
+	// and file scans from
+	Baseline *Baseline
+
+	// Degradations and EndOfLife collect the incomplete scans and the
+	// end of life OS releases found, to be recorded in the document
+	Degradations *DegradationLog
+	EndOfLife    *EndOfLifeLog
+
+	// Report records the data skipped and the confidence of the licenses
+	// found for the generation report
+	Report *GenerationReport
+
+	// PurlRegistryData and PurlScanLicenses complete the packages read
+	// from purl lists with the data in their registries and the licenses
+	// found in their downloads
+	PurlRegistryData bool
+	PurlScanLicenses bool
+
+	// ClearlyDefined enables looking up the licenses of go modules and
+	// purl list packages in ClearlyDefined before downloading them to
+	// scan their files
+	ClearlyDefined bool
+
+	// SourceCaches are the local caches the license scanners read the
+	// package sources from before downloading them
+	SourceCaches *SourceCaches
+
+	// LicenseScanCache keeps the license scan results of go modules and
+	// purl list packages across runs
+	LicenseScanCache *LicenseScanCache
 }
 
 func (spdx *SPDX) Options() *Options {
@@ -111,6 +178,12 @@ var defaultSPDXOptions = Options{
 	LicenseData:      filepath.Join(os.TempDir(), spdxLicenseData),
 	AnalyzeLayers:    true,
 	ProcessGoModules: true,
+	ProcessBazel:     true,
+	ProcessCMake:     true,
+	ProcessPython:    true,
+	ProcessNode:      true,
+	ProcessJava:      true,
+	ProcessRust:      true,
 	IgnorePatterns:   []string{},
 	ScanLicenses:     true,
 	ScanImages:       true,
@@ -135,19 +208,7 @@ func buildIDString(seeds ...string) string {
 	validSeeds := []string{}
 	numValidSeeds := 0
 	for _, s := range seeds {
-		// Replace some chars with - to keep the sense of the ID
-		for _, r := range []string{"/", ":"} {
-			s = strings.ReplaceAll(s, r, "-")
-		}
-		// Replace invalid chars with unicode numbers to avoid collisions
-		s = validIDCharsRe.ReplaceAllStringFunc(s, func(s string) string {
-			r := ""
-			for i := range s {
-				uc, _ := utf8.DecodeRuneInString(string(s[i]))
-				r = fmt.Sprintf("%sC%d", r, uc)
-			}
-			return r
-		})
+		s = SanitizeIDString(s)
 		if s != "" {
 			validSeeds = append(validSeeds, s)
 			if !strings.HasPrefix(s, "SPDXRef-") {
@@ -174,16 +235,26 @@ func buildIDString(seeds ...string) string {
 // PackageFromDirectory indexes all files in a directory and builds a
 // SPDX package describing its contents.
 func (spdx *SPDX) PackageFromDirectory(dirPath string) (pkg *Package, err error) {
+	return spdx.PackageFromDirectoryContext(context.Background(), dirPath)
+}
+
+// PackageFromDirectoryContext is like PackageFromDirectory, the context
+// aborts the downloads of the go dependencies.
+func (spdx *SPDX) PackageFromDirectoryContext(ctx context.Context, dirPath string) (pkg *Package, err error) {
 	pkg, err = spdx.impl.PackageFromDirectory(spdx.options, dirPath)
 	if err != nil {
 		return nil, fmt.Errorf("generating SPDX package from directory: %w", err)
 	}
 
+	// Packages of the go modules scanned, by module path, to connect
+	// the binaries built from them
+	goModules := map[string]*Package{}
+
 	// Scan the directory contents and if it is a go module, process the
 	// dependencies
 	if util.Exists(filepath.Join(dirPath, GoModFileName)) && spdx.Options().ProcessGoModules {
 		logrus.Info("Directory contains a go module. Scanning go packages")
-		deps, err := spdx.impl.GetGoDependencies(dirPath, spdx.Options())
+		deps, err := spdx.impl.GetGoDependencies(ctx, dirPath, spdx.Options())
 		if err != nil {
 			return nil, fmt.Errorf("scanning go packages: %w", err)
 		}
@@ -193,26 +264,199 @@ func (spdx *SPDX) PackageFromDirectory(dirPath string) (pkg *Package, err error)
 				return nil, fmt.Errorf("adding go dependency: %w", err)
 			}
 		}
+		if err := spdx.addGoToolchain(pkg, dirPath); err != nil {
+			return nil, fmt.Errorf("adding go toolchain: %w", err)
+		}
+		if modPath, err := goModulePath(dirPath); err == nil {
+			goModules[modPath] = pkg
+		}
+	}
+
+	// Bazel workspaces declare their external dependencies in their own
+	// files instead of the language manifests
+	if spdx.Options().ProcessBazel && slices.Contains(DetectLanguages(dirPath).Detected, LangBazel) {
+		logrus.Info("Directory is a bazel workspace. Reading external dependencies")
+		deps, err := ReadBazelDependencies(dirPath, spdx.Options().ExcludeDevDeps, spdx.Options().Degradations)
+		if err != nil {
+			return nil, fmt.Errorf("reading bazel dependencies: %w", err)
+		}
+		logrus.Infof("Bazel workspace declares %d external dependencies", len(deps))
+		for _, dep := range deps {
+			if err := pkg.AddDependency(dep.ToSPDXPackage()); err != nil {
+				return nil, fmt.Errorf("adding bazel dependency: %w", err)
+			}
+		}
+	}
+
+	// CMake projects fetch their C/C++ dependencies while configuring the
+	// build, record the ones we can find in the CMake files
+	if spdx.Options().ProcessCMake && slices.Contains(DetectLanguages(dirPath).Detected, LangCMake) {
+		logrus.Info("Directory is a CMake project. Reading external dependencies")
+		deps, err := ReadCMakeDependencies(dirPath, spdx.Options().Degradations)
+		if err != nil {
+			return nil, fmt.Errorf("reading cmake dependencies: %w", err)
+		}
+		logrus.Infof("CMake project fetches %d external dependencies", len(deps))
+		for _, dep := range deps {
+			if err := pkg.AddDependency(dep.ToSPDXPackage()); err != nil {
+				return nil, fmt.Errorf("adding cmake dependency: %w", err)
+			}
+		}
+	}
+
+	// Projects of other ecosystems in the directory are added as
+	// subpackages identified from their manifests
+	subpkgs, err := spdx.manifestProjectPackages(pkg, dirPath, &LanguageReport{
+		Directory: ".", Detected: DetectLanguages(dirPath).Detected,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading project manifests: %w", err)
+	}
+	for _, subpkg := range subpkgs {
+		if err := pkg.AddPackage(subpkg); err != nil {
+			return nil, fmt.Errorf("adding project package: %w", err)
+		}
+	}
+
+	// Look for nested projects and add them as subpackages. The
+	// dependencies of go modules are resolved, projects of other
+	// ecosystems are identified from their manifests. Directories with
+	// manifests of several ecosystems get a package for each of them.
+	projects, err := DiscoverProjects(dirPath, spdx.Options().MaxManifestDepth)
+	if err != nil {
+		return nil, fmt.Errorf("discovering nested projects: %w", err)
+	}
+	for _, project := range projects {
+		logrus.Infof("Found nested project in %s: %s", project.Directory, project)
+		if slices.Contains(project.Detected, LangGo) && spdx.Options().ProcessGoModules {
+			subpkg, err := spdx.goProjectPackage(ctx, pkg, dirPath, project.Directory)
+			if err != nil {
+				return nil, fmt.Errorf("scanning nested go module in %s: %w", project.Directory, err)
+			}
+			if err := pkg.AddPackage(subpkg); err != nil {
+				return nil, fmt.Errorf("adding nested go module package: %w", err)
+			}
+			if modPath, err := goModulePath(filepath.Join(dirPath, project.Directory)); err == nil {
+				goModules[modPath] = subpkg
+			}
+		}
+		subpkgs, err := spdx.manifestProjectPackages(pkg, dirPath, project)
+		if err != nil {
+			return nil, fmt.Errorf("reading nested project in %s: %w", project.Directory, err)
+		}
+		for _, subpkg := range subpkgs {
+			if err := pkg.AddPackage(subpkg); err != nil {
+				return nil, fmt.Errorf("adding nested project package: %w", err)
+			}
+		}
+	}
+
+	if len(goModules) > 0 {
+		if n := relateGoBinaries(pkg, goModules); n > 0 {
+			logrus.Infof("Connected %d go binaries to the modules they were built from", n)
+		}
 	}
 
 	return pkg, nil
 }
 
+// goProjectPackage returns a package describing the go module found in
+// subdir (relative to dirPath) with its dependencies.
+func (spdx *SPDX) goProjectPackage(ctx context.Context, parent *Package, dirPath, subdir string) (*Package, error) {
+	deps, err := spdx.impl.GetGoDependencies(ctx, filepath.Join(dirPath, subdir), spdx.Options())
+	if err != nil {
+		return nil, fmt.Errorf("scanning go packages: %w", err)
+	}
+	logrus.Infof("Go module in %s built list of %d dependencies", subdir, len(deps))
+
+	pkg := NewPackage()
+	pkg.Name = filepath.ToSlash(filepath.Join(parent.Name, subdir))
+	pkg.PrimaryPurpose = "SOURCE"
+	pkg.BuildID(pkg.Name)
+	for _, dep := range deps {
+		if err := pkg.AddDependency(dep); err != nil {
+			return nil, fmt.Errorf("adding go dependency: %w", err)
+		}
+	}
+	if err := spdx.addGoToolchain(pkg, filepath.Join(dirPath, subdir)); err != nil {
+		return nil, fmt.Errorf("adding go toolchain: %w", err)
+	}
+	return pkg, nil
+}
+
+// addGoToolchain records the go toolchain declared in the go.mod file found
+// in modDir as a prerequisite of the package and, if enabled in the options,
+// the standard library as a dependency. The modules requiring the same go
+// version share the cached packages, which are rendered only once.
+func (spdx *SPDX) addGoToolchain(pkg *Package, modDir string) error {
+	toolchain, err := ReadGoToolchain(modDir)
+	if err != nil {
+		return fmt.Errorf("reading go toolchain version: %w", err)
+	}
+	if toolchain == nil {
+		return nil
+	}
+	logrus.Infof("Go module requires toolchain version %s", toolchain.Version)
+
+	relate := func(relType RelationshipType, name string, build func() *Package) {
+		spdx.goToolchainsMtx.Lock()
+		defer spdx.goToolchainsMtx.Unlock()
+		if spdx.goToolchains == nil {
+			spdx.goToolchains = map[string]*Package{}
+		}
+		key := name + "@" + toolchain.Version
+		peer, ok := spdx.goToolchains[key]
+		if !ok {
+			peer = build()
+			spdx.goToolchains[key] = peer
+		}
+		pkg.AddRelationship(&Relationship{
+			FullRender: !ok,
+			Type:       relType,
+			Peer:       peer,
+		})
+	}
+
+	relate(HAS_PREREQUISITE, "go-toolchain", toolchain.ToSPDXPackage)
+	if spdx.Options().GoStdlib {
+		relate(DEPENDS_ON, "go-stdlib", toolchain.StdlibSPDXPackage)
+	}
+	return nil
+}
+
 // PackageFromImageTarball returns a SPDX package from a tarball.
 func (spdx *SPDX) PackageFromImageTarball(tarPath string) (imagePackage *Package, err error) {
+	pkg, err := spdx.Options().Baseline.imageArchivePackage(tarPath)
+	if err != nil {
+		return nil, fmt.Errorf("looking up image archive in baseline: %w", err)
+	}
+	if pkg != nil {
+		logrus.Infof("Reusing the package of image archive %s from the baseline", tarPath)
+		return pkg, nil
+	}
 	return spdx.impl.PackageFromImageTarball(spdx.Options(), tarPath)
 }
 
-// PackageFromArchive returns a SPDX package from a tarball.
+// PackageFromRootfs returns a SPDX package describing the operating
+// system installed in a root filesystem directory.
+func (spdx *SPDX) PackageFromRootfs(rootPath string) (*Package, error) {
+	return spdx.impl.PackageFromRootfs(spdx.Options(), rootPath)
+}
+
+// PackageFromArchive returns a SPDX package from a tarball or zip archive.
 func (spdx *SPDX) PackageFromArchive(archivePath string) (imagePackage *Package, err error) {
-	if strings.HasSuffix(archivePath, "tar") || strings.HasSuffix(archivePath, "tar.gz") {
-		return spdx.impl.PackageFromTarball(
-			spdx.Options(), &TarballOptions{
-				AddFiles: true,
-			}, archivePath,
-		)
+	for _, ext := range []string{"tar", "tar.gz", "tgz", "zip", "jar", "war", "ear"} {
+		if strings.HasSuffix(archivePath, ext) {
+			return spdx.impl.PackageFromTarball(
+				spdx.Options(), &TarballOptions{
+					AddFiles: true,
+				}, archivePath,
+			)
+		}
 	}
-	return nil, fmt.Errorf("unable to create spdx package from archive, only tar archives are supported: %w", err)
+	return nil, fmt.Errorf(
+		"unable to create spdx package from %s, only tar, zip and java archives are supported", archivePath,
+	)
 }
 
 // FileFromPath creates a File object from a path.
@@ -232,17 +476,23 @@ func (spdx *SPDX) FileFromPath(filePath string) (*File, error) {
 //	it matches a known image from which a spdx package can be
 //	enriched with more information
 func (spdx *SPDX) AnalyzeImageLayer(layerPath string, pkg *Package) error {
-	return spdx.impl.AnalyzeImageLayer(layerPath, pkg)
+	return spdx.impl.AnalyzeImageLayer(spdx.Options(), layerPath, pkg)
 }
 
-// ExtractTarballTmp extracts a tarball to a temp file.
+// ExtractTarballTmp extracts a tarball (or zip archive) to a temp file.
 func (spdx *SPDX) ExtractTarballTmp(tarPath string) (tmpDir string, err error) {
 	return spdx.impl.ExtractTarballTmp(tarPath)
 }
 
 // PullImagesToArchive downloads all the images found from a reference to disk.
 func (spdx *SPDX) PullImagesToArchive(reference, path string) (*ImageReferenceInfo, error) {
-	return spdx.impl.PullImagesToArchive(reference, path)
+	return spdx.PullImagesToArchiveContext(context.Background(), reference, path)
+}
+
+// PullImagesToArchiveContext is like PullImagesToArchive, cancelling the
+// context aborts the downloads and removes the archives written.
+func (spdx *SPDX) PullImagesToArchiveContext(ctx context.Context, reference, path string) (*ImageReferenceInfo, error) {
+	return spdx.impl.PullImagesToArchive(ctx, reference, path)
 }
 
 // ImageRefToPackage gets an image reference (tag or digest) and returns
@@ -253,7 +503,13 @@ func (spdx *SPDX) PullImagesToArchive(reference, path string) (*ImageReferenceIn
 //     package referencing each of the images, each in its own packages.
 //     All subpackages are returned with a relationship of VARIANT_OF
 func (spdx *SPDX) ImageRefToPackage(reference string) (pkg *Package, err error) {
-	return spdx.impl.ImageRefToPackage(reference, spdx.Options())
+	return spdx.ImageRefToPackageContext(context.Background(), reference)
+}
+
+// ImageRefToPackageContext is like ImageRefToPackage, cancelling the
+// context aborts the image downloads.
+func (spdx *SPDX) ImageRefToPackageContext(ctx context.Context, reference string) (pkg *Package, err error) {
+	return spdx.impl.ImageRefToPackage(ctx, reference, spdx.Options())
 }
 
 func Banner() string {
@@ -17,7 +17,10 @@ limitations under the License.
 package spdx_test
 
 import (
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -127,3 +130,165 @@ func TestPullImagesToArchive(t *testing.T) {
 		}
 	}
 }
+
+func TestPackageFromDirectoryMixedProjects(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"package.json":             `{"name": "site", "version": "1.0.0", "devDependencies": {"vite": "5.0.0"}}`,
+		"app/go.mod":               "module example.com/app\n\ngo 1.22\n",
+		"app/package.json":         `{"name": "app-ui", "version": "1.0.0", "dependencies": {"react": "18.2.0"}}`,
+		"crate/Cargo.toml":         "[package]\nname = \"tool\"\nversion = \"0.1.0\"\n\n[dependencies]\nserde = \"=1.0.200\"\n",
+		"scripts/requirements.txt": "PyYAML==6.0.1\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)))
+		require.NoError(t, os.WriteFile(path, []byte(content), os.FileMode(0o644)))
+	}
+
+	root := spdx.NewPackage()
+	root.Name = "root"
+	root.BuildID(root.Name)
+	goDep := spdx.NewPackage()
+	goDep.Name = "golang.org/x/text"
+	goDep.BuildID(goDep.Name)
+	mock := &spdxfakes.FakeSpdxImplementation{}
+	mock.PackageFromDirectoryReturns(root, nil)
+	mock.GetGoDependenciesReturns([]*spdx.Package{goDep}, nil)
+	sut := spdx.NewSPDX()
+	sut.SetImplementation(mock)
+
+	pkg, err := sut.PackageFromDirectory(dir)
+	require.NoError(t, err)
+
+	// dependencies returns the names of the dependencies of a package
+	dependencies := func(p *spdx.Package) []string {
+		names := []string{}
+		for _, rel := range *p.GetRelationships() {
+			if rel.Type == spdx.DEPENDS_ON {
+				names = append(names, rel.Peer.(*spdx.Package).Name)
+			}
+		}
+		return names
+	}
+	projects := map[string][]string{}
+	for _, rel := range *pkg.GetRelationships() {
+		if rel.Type == spdx.CONTAINS {
+			subpkg := rel.Peer.(*spdx.Package)
+			projects[subpkg.Name] = dependencies(subpkg)
+		}
+	}
+	require.Equal(t, map[string][]string{
+		"site":     {"vite"},
+		"root/app": {"golang.org/x/text"},
+		"app-ui":   {"react"},
+		"tool":     {"serde"},
+		"scripts":  {"pyyaml"},
+	}, projects)
+	require.Equal(t, 1, mock.GetGoDependenciesCallCount())
+}
+
+func TestGoModuleOpen(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*spdxfakes.FakeGoModImplementation)
+		shouldError bool
+	}{
+		{ // success
+			prepare: func(mock *spdxfakes.FakeGoModImplementation) {
+				mock.BuildPackageListReturns([]*spdx.GoPackage{{ImportPath: "example.com/a"}}, nil)
+			},
+			shouldError: false,
+		},
+		{ // OpenModule fails
+			prepare: func(mock *spdxfakes.FakeGoModImplementation) {
+				mock.OpenModuleReturns(nil, err)
+			},
+			shouldError: true,
+		},
+		{ // BuildPackageList fails
+			prepare: func(mock *spdxfakes.FakeGoModImplementation) {
+				mock.BuildPackageListReturns(nil, err)
+			},
+			shouldError: true,
+		},
+	} {
+		sut := spdx.NewGoModule()
+		sut.Options().OnlyDirectDeps = true
+		mock := &spdxfakes.FakeGoModImplementation{}
+		tc.prepare(mock)
+		sut.SetImplementation(mock)
+
+		err := sut.Open()
+		if tc.shouldError {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+			require.Len(t, sut.Packages, 1)
+		}
+	}
+}
+
+func TestDocBuilderGenerate(t *testing.T) {
+	for _, tc := range []struct {
+		prepare     func(*spdxfakes.FakeDocBuilderImplementation)
+		shouldError bool
+	}{
+		{ // success
+			prepare:     func(*spdxfakes.FakeDocBuilderImplementation) {},
+			shouldError: false,
+		},
+		{ // ValidateOptions fails
+			prepare: func(mock *spdxfakes.FakeDocBuilderImplementation) {
+				mock.ValidateOptionsReturns(err)
+			},
+			shouldError: true,
+		},
+		{ // a generation step fails
+			prepare: func(mock *spdxfakes.FakeDocBuilderImplementation) {
+				mock.ScanImagesReturns(err)
+			},
+			shouldError: true,
+		},
+	} {
+		sut := spdx.NewDocBuilder()
+		mock := &spdxfakes.FakeDocBuilderImplementation{}
+		mock.CreateSPDXClientReturns(spdx.NewSPDX(), nil)
+		mock.CreateDocumentReturns(spdx.NewDocument(), nil)
+		tc.prepare(mock)
+		sut.SetImplementation(mock)
+
+		doc, err := sut.Generate(&spdx.DocGenerateOptions{})
+		if tc.shouldError {
+			require.Error(t, err)
+			require.Zero(t, mock.PruneDocumentCallCount())
+		} else {
+			require.NoError(t, err)
+			require.NotNil(t, doc)
+			require.Equal(t, 1, mock.ScanDirectoriesCallCount())
+			require.Equal(t, 1, mock.PruneDocumentCallCount())
+		}
+	}
+}
+
+func TestDocBuilderGenerateCancelled(t *testing.T) {
+	sut := spdx.NewDocBuilder()
+	mock := &spdxfakes.FakeDocBuilderImplementation{}
+	mock.CreateSPDXClientReturns(spdx.NewSPDX(), nil)
+	mock.CreateDocumentReturns(spdx.NewDocument(), nil)
+	sut.SetImplementation(mock)
+
+	// Cancelling while scanning images stops before the next step
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mock.ScanImagesStub = func(_ context.Context, _ *spdx.DocGenerateOptions, _ *spdx.SPDX, _ *spdx.Document) error {
+		cancel()
+		return nil
+	}
+
+	_, err := sut.GenerateContext(ctx, &spdx.DocGenerateOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, mock.ScanDirectoriesCallCount())
+	require.Zero(t, mock.ScanImageArchivesCallCount())
+
+	ctxArg, _, _, _ := mock.ScanDirectoriesArgsForCall(0)
+	require.Equal(t, ctx, ctxArg)
+}
@@ -18,8 +18,10 @@ package spdx
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io"
@@ -27,9 +29,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/release-utils/util"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
 )
 
 func TestBuildIDString(t *testing.T) {
@@ -74,6 +79,48 @@ func TestUnitExtractTarballTmp(t *testing.T) {
 		require.True(t, util.Exists(filepath.Join(dir, "/subdir/text.txt")), "checking subdirectory")
 		require.True(t, util.Exists(dir), "checking directory")
 	}
+
+	// Zip archives are also supported
+	zipFile := writeTestZip(t)
+	defer os.Remove(zipFile.Name())
+
+	dir, err := sut.ExtractTarballTmp(zipFile.Name())
+	require.NoError(t, err, "extracting zip file")
+	defer os.RemoveAll(dir)
+
+	require.True(t, util.Exists(filepath.Join(dir, "/text.txt")), "checking zip directory")
+	require.True(t, util.Exists(filepath.Join(dir, "/subdir/text.txt")), "checking zip subdirectory")
+}
+
+func TestUnitExtractTarballTmpShortAndUnsupported(t *testing.T) {
+	sut := NewSPDX()
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	src := t.TempDir()
+
+	// Files shorter than the header sample are read as (empty) tarballs
+	empty := filepath.Join(src, "empty.tar")
+	require.NoError(t, os.WriteFile(empty, []byte{}, os.FileMode(0o644)))
+	dir, err := sut.ExtractTarballTmp(empty)
+	require.NoError(t, err)
+	require.True(t, util.IsDir(dir))
+	require.NoError(t, os.RemoveAll(dir))
+
+	// Unsupported and corrupt archives don't leave their directory behind
+	for name, data := range map[string][]byte{
+		"archive.xz":  {0xfd, '7', 'z', 'X', 'Z', 0x00},
+		"short.tar":   []byte("abc"),
+		"corrupt.tgz": {0x1f, 0x8b, 0x08, 0x00},
+	} {
+		path := filepath.Join(src, name)
+		require.NoError(t, os.WriteFile(path, data, os.FileMode(0o644)))
+		dir, err := sut.ExtractTarballTmp(path)
+		require.Error(t, err, name)
+		require.Empty(t, dir, name)
+	}
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	require.Empty(t, entries)
 }
 
 func TestReadArchiveManifest(t *testing.T) {
@@ -192,6 +239,25 @@ func writeTestTarball(t *testing.T, zipped bool) *os.File {
 	return tarFile
 }
 
+func writeTestZip(t *testing.T) *os.File {
+	zipFile, err := os.CreateTemp(os.TempDir(), "test-zip-*.zip")
+	require.NoError(t, err)
+	defer zipFile.Close()
+
+	zw := zip.NewWriter(zipFile)
+	for _, name := range []string{"text.txt", "subdir/", "subdir/text.txt"} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		if name == "subdir/" {
+			continue
+		}
+		_, err = w.Write([]byte("Hello world\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return zipFile
+}
+
 func TestRelationshipRender(t *testing.T) {
 	host := NewPackage()
 	host.BuildID("TestHost")
@@ -273,18 +339,26 @@ var sampleManifest = `[{"Config":"386bcf5c63de46c7066c42d4ae1c38af0689836e88fed3
 `
 
 func TestGetImageReferences(t *testing.T) {
-	references, err := getImageReferences("registry.k8s.io/kube-apiserver:v1.23.0-alpha.3")
-	images := map[string]struct {
-		arch string
-		os   string
-	}{
-		"registry.k8s.io/kube-apiserver@sha256:a82ca097e824f99bfb2b5107aa9c427633f9afb82afd002d59204f39ef81ae70": {"amd64", "linux"},
-		"registry.k8s.io/kube-apiserver@sha256:2a11e07f916b5982d9a6e3bbf5defd66ad50359c00b33862552063beb6981aec": {"arm", "linux"},
-		"registry.k8s.io/kube-apiserver@sha256:18f97b8c1c9b7b35dea7ba122d86e23066ce347aa8bb75b7346fed3f79d0ea21": {"arm64", "linux"},
-		"registry.k8s.io/kube-apiserver@sha256:1a61b61491042e2b1e659c4d57d426d01d9467fb381404bff029be4d00ead519": {"ppc64le", "linux"},
-		"registry.k8s.io/kube-apiserver@sha256:3e98f1591a5052791eec71d3c5f5d0fa913140992cb9e1d19fd80a158305c2ff": {"s390x", "linux"},
+	reg := spdxtest.NewRegistry(t)
+	platforms := []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm"},
+		{OS: "linux", Architecture: "arm64"},
+		{OS: "linux", Architecture: "ppc64le"},
+		{OS: "linux", Architecture: "s390x"},
 	}
+	index := spdxtest.RandomIndex(t, platforms...)
+	indexDigest := reg.PushIndex(t, "kube-apiserver:v1.23.0-alpha.3", index)
+	indexManifest, err := index.IndexManifest()
 	require.NoError(t, err)
+	images := map[string]v1.Platform{}
+	for _, m := range indexManifest.Manifests { //nolint: gocritic
+		images[reg.Reference("kube-apiserver@"+m.Digest.String())] = *m.Platform
+	}
+
+	references, err := getImageReferences(context.Background(), reg.Reference("kube-apiserver:v1.23.0-alpha.3"))
+	require.NoError(t, err)
+	require.Equal(t, indexDigest, references.Digest)
 	// This image should have 5 architectures
 	require.Len(t, references.Images, 5)
 	require.Equal(t, "application/vnd.docker.distribution.manifest.list.v2+json", references.MediaType)
@@ -294,13 +368,13 @@ func TestGetImageReferences(t *testing.T) {
 	for _, refData := range references.Images {
 		_, ok := images[refData.Digest]
 		require.True(t, ok, "Image not found "+refData.Digest)
-		require.Equal(t, images[refData.Digest].os, refData.OS)
-		require.Equal(t, images[refData.Digest].arch, refData.Arch)
+		require.Equal(t, images[refData.Digest].OS, refData.OS)
+		require.Equal(t, images[refData.Digest].Architecture, refData.Arch)
 	}
 
 	// Test a sha reference. This is the linux/ppc64le image
-	singleRef := "registry.k8s.io/kube-apiserver@sha256:1a61b61491042e2b1e659c4d57d426d01d9467fb381404bff029be4d00ead519"
-	references, err = getImageReferences(singleRef)
+	singleRef := reg.Reference("kube-apiserver@" + indexManifest.Manifests[3].Digest.String())
+	references, err = getImageReferences(context.Background(), singleRef)
 	require.NoError(t, err)
 	require.Empty(t, references.Images)
 	require.Equal(t, singleRef, references.Digest)
@@ -309,10 +383,11 @@ func TestGetImageReferences(t *testing.T) {
 	require.Equal(t, "linux", references.OS)
 
 	// Tag with a single image. Image 1.0 is a single image
-	references, err = getImageReferences("registry.k8s.io/pause:1.0")
+	pauseDigest := reg.PushImage(t, "pause:1.0", spdxtest.RandomImage(t, v1.Platform{OS: "linux", Architecture: "amd64"}, 2))
+	references, err = getImageReferences(context.Background(), reg.Reference("pause:1.0"))
 	require.NoError(t, err)
 	require.Empty(t, references.Images)
-	require.Equal(t, "registry.k8s.io/pause@sha256:a78c2d6208eff9b672de43f880093100050983047b7b0afe0217d3656e1b0d5f", references.Digest)
+	require.Equal(t, pauseDigest, references.Digest)
 	require.Equal(t, "application/vnd.docker.distribution.manifest.v2+json", references.MediaType)
 	require.Equal(t, "amd64", references.Arch)
 	require.Equal(t, "linux", references.OS)
@@ -320,34 +395,45 @@ func TestGetImageReferences(t *testing.T) {
 
 func TestPullImagesToArchive(t *testing.T) {
 	impl := spdxDefaultImplementation{}
+	reg := spdxtest.NewRegistry(t)
+	img := spdxtest.RandomImage(t, v1.Platform{OS: "linux", Architecture: "amd64"}, 2)
+	pauseDigest := reg.PushImage(t, "pause:1.0", img)
 
 	// First. If the tag does not represent an image, expect an error
-	_, err := impl.PullImagesToArchive("registry.k8s.io/pause:0.0", "/tmp")
+	_, err := impl.PullImagesToArchive(context.Background(), reg.Reference("pause:0.0"), t.TempDir())
 	require.Error(t, err)
 
 	// Create a temp workdir
-	dir, err := os.MkdirTemp("", "extract-image-")
-	require.NoError(t, err)
-	defer os.RemoveAll(dir)
+	dir := t.TempDir()
 
 	// The pause 1.0 image is a single image
-	images, err := impl.PullImagesToArchive("registry.k8s.io/pause:1.0", dir)
+	images, err := impl.PullImagesToArchive(context.Background(), reg.Reference("pause:1.0"), dir)
 	require.NoError(t, err)
-	require.Equal(t, "registry.k8s.io/pause@sha256:a78c2d6208eff9b672de43f880093100050983047b7b0afe0217d3656e1b0d5f", images.Digest)
+	require.Equal(t, pauseDigest, images.Digest)
 	require.Equal(t, "amd64", images.Arch)
 	require.Equal(t, "linux", images.OS)
 	require.Equal(t, "application/vnd.docker.distribution.manifest.v2+json", images.MediaType)
 	require.Empty(t, images.Images) // This is an image, so no child images
-	require.FileExists(t, filepath.Join(dir, "a78c2d6208eff9b672de43f880093100050983047b7b0afe0217d3656e1b0d5f.tar"))
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	archivePath := filepath.Join(dir, digest.Hex+".tar")
+	require.FileExists(t, archivePath)
 
-	foundFiles := []string{}
-	expectedFiles := []string{
-		"sha256:350b164e7ae1dcddeffadd65c76226c9b6dc5553f5179153fb0e36b78f2a5e06",
-		"a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4.tar.gz",
-		"4964c72cd0245a7f77da38425dc98b472b2699ba6c49d5a9221fb32b972bc06b.tar.gz",
-		"manifest.json",
+	// The archive has the image config, its layers and the manifest
+	configName, err := img.ConfigName()
+	require.NoError(t, err)
+	expectedFiles := []string{configName.String()}
+	layers, err := img.Layers()
+	require.NoError(t, err)
+	for _, l := range layers {
+		layerDigest, err := l.Digest()
+		require.NoError(t, err)
+		expectedFiles = append(expectedFiles, layerDigest.Hex+".tar.gz")
 	}
-	tarFile, err := os.Open(filepath.Join(dir, "a78c2d6208eff9b672de43f880093100050983047b7b0afe0217d3656e1b0d5f.tar"))
+	expectedFiles = append(expectedFiles, "manifest.json")
+
+	foundFiles := []string{}
+	tarFile, err := os.Open(archivePath)
 	require.NoError(t, err)
 	defer tarFile.Close()
 	tarReader := tar.NewReader(tarFile)
@@ -364,6 +450,16 @@ func TestPullImagesToArchive(t *testing.T) {
 		foundFiles = append(foundFiles, header.Name)
 	}
 	require.Equal(t, expectedFiles, foundFiles)
+
+	// A cancelled pull fails without leaving archives behind
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cancelledDir := t.TempDir()
+	_, err = impl.PullImagesToArchive(ctx, reg.Reference("pause:1.0"), cancelledDir)
+	require.Error(t, err)
+	entries, err := os.ReadDir(cancelledDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
 }
 
 func TestGetDirectoryTree(t *testing.T) {
@@ -414,6 +510,27 @@ func TestIgnorePatterns(t *testing.T) {
 	p, err = impl.IgnorePatterns(dir, nil, false)
 	require.NoError(t, err)
 	require.Len(t, p, 4)
+
+	// The .bomignore patterns are added after those in .gitignore, even
+	// when not using .gitignore
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, ".bomignore"),
+		[]byte("# Test data\ntestdata/\n!.DS_Store\n"),
+		os.FileMode(0o644),
+	))
+	p, err = impl.IgnorePatterns(dir, []string{"*.log"}, false)
+	require.NoError(t, err)
+	require.Len(t, p, 7)
+	p, err = impl.IgnorePatterns(dir, nil, true)
+	require.NoError(t, err)
+	require.Len(t, p, 2)
+
+	// Negated patterns bring back files ignored by previous ones
+	p, err = impl.IgnorePatterns(dir, []string{"*.log"}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go", ".DS_Store"}, impl.ApplyIgnorePatterns(
+		[]string{"main.go", ".DS_Store", "._main.go", "testdata/sbom.json", "debug.log"}, p,
+	))
 }
 
 func TestRecursiveSearch(t *testing.T) {
@@ -468,32 +585,126 @@ func TestRecursiveSearch(t *testing.T) {
 
 func TestPurlFromImage(t *testing.T) {
 	for _, tc := range []struct {
+		name     string
 		info     ImageReferenceInfo
 		expected string
 	}{
 		{
-			ImageReferenceInfo{
-				Digest:    "image@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
-				Reference: "",
-				Archive:   "",
-				Arch:      "",
-				OS:        "",
+			name: "docker hub short name",
+			info: ImageReferenceInfo{
+				Digest: "image@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+			},
+			expected: "pkg:oci/image@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?repository_url=index.docker.io%2Flibrary%2Fimage",
+		},
+		{
+			name: "docker hub with platform",
+			info: ImageReferenceInfo{
+				Digest: "index.docker.io/library/nginx@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Arch:   "amd64",
+				OS:     "darwin",
 			},
-			"pkg:oci/image@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?repository_url=index.docker.io%2Flibrary",
+			expected: "pkg:oci/nginx@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?arch=amd64&os=darwin&repository_url=index.docker.io%2Flibrary%2Fnginx",
 		},
 		{
-			ImageReferenceInfo{
-				Digest:    "index.docker.io/library/nginx@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
-				Reference: "index.docker.io/library/nginx@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
-				Archive:   "",
-				Arch:      "amd64",
-				OS:        "darwin",
+			name: "docker hub with tag",
+			info: ImageReferenceInfo{
+				Digest: "nginx@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Tag:    "1.27",
 			},
-			"pkg:oci/nginx@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?arch=amd64&os=darwin&repository_url=index.docker.io%2Flibrary",
+			expected: "pkg:oci/nginx@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?repository_url=index.docker.io%2Flibrary%2Fnginx&tag=1.27",
+		},
+		{
+			name: "gcr image from an index",
+			info: ImageReferenceInfo{
+				Digest:    "gcr.io/distroless/static@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Tag:       "nonroot",
+				Arch:      "arm64",
+				OS:        "linux",
+				MediaType: "application/vnd.oci.image.manifest.v1+json",
+			},
+			expected: "pkg:oci/static@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?arch=arm64&mediaType=application%2Fvnd.oci.image.manifest.v1%2Bjson&os=linux&repository_url=gcr.io%2Fdistroless%2Fstatic&tag=nonroot",
+		},
+		{
+			name: "ecr nested repository",
+			info: ImageReferenceInfo{
+				Digest: "123456789012.dkr.ecr.us-east-1.amazonaws.com/team/app@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Tag:    "v1.2.3",
+			},
+			expected: "pkg:oci/app@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?repository_url=123456789012.dkr.ecr.us-east-1.amazonaws.com%2Fteam%2Fapp&tag=v1.2.3",
+		},
+		{
+			name: "ghcr",
+			info: ImageReferenceInfo{
+				Digest: "ghcr.io/kubernetes-sigs/bom@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Tag:    "v0.6.0",
+			},
+			expected: "pkg:oci/bom@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?repository_url=ghcr.io%2Fkubernetes-sigs%2Fbom&tag=v0.6.0",
+		},
+		{
+			name: "registry with port",
+			info: ImageReferenceInfo{
+				Digest: "localhost:5000/busybox@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Tag:    "latest",
+			},
+			expected: "pkg:oci/busybox@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?repository_url=localhost%3A5000%2Fbusybox&tag=latest",
+		},
+		{
+			name: "registry with port and namespace",
+			info: ImageReferenceInfo{
+				Digest: "localhost:5000/tools/app@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+				Arch:   "s390x",
+				OS:     "linux",
+			},
+			expected: "pkg:oci/app@sha256%3Ac183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57?arch=s390x&os=linux&repository_url=localhost%3A5000%2Ftools%2Fapp",
+		},
+		{
+			name: "invalid reference",
+			info: ImageReferenceInfo{
+				Digest: "Invalid Reference@sha256:c183d71d4173c3148b73d17aba0f37c83ca8291d1f303d74a3fac4f5e1d01f57",
+			},
+			expected: "",
 		},
 	} {
-		impl := spdxDefaultImplementation{}
-		p := impl.purlFromImage(&tc.info)
-		require.Equal(t, tc.expected, p)
+		t.Run(tc.name, func(t *testing.T) {
+			impl := spdxDefaultImplementation{}
+			require.Equal(t, tc.expected, impl.purlFromImage(&tc.info))
+		})
+	}
+}
+
+func BenchmarkDirectoryHashing(b *testing.B) {
+	// Create a directory tree with 1000 files of 16 KiB
+	dir := b.TempDir()
+	data := bytes.Repeat([]byte("0123456789abcdef"), 1024)
+	for i := range 1000 {
+		sub := filepath.Join(dir, fmt.Sprintf("dir%02d", i%20))
+		if err := os.MkdirAll(sub, os.FileMode(0o755)); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, fmt.Sprintf("file%04d.txt", i)), data, os.FileMode(0o644)); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	impl := spdxDefaultImplementation{}
+	b.ResetTimer()
+	for range b.N {
+		fileList, err := impl.GetDirectoryTree(dir)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pkg := NewPackage()
+		pkg.Name = "benchmark"
+		for _, path := range fileList {
+			f := NewFile()
+			f.Options().WorkDir = dir
+			f.Options().Prefix = pkg.Name
+			if err := f.ReadSourceFile(filepath.Join(dir, path)); err != nil {
+				b.Fatal(err)
+			}
+			if err := pkg.AddFile(f); err != nil {
+				b.Fatal(err)
+			}
+		}
 	}
 }
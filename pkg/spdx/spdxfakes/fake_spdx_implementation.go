@@ -18,6 +18,7 @@ limitations under the License.
 package spdxfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
@@ -26,11 +27,12 @@ import (
 )
 
 type FakeSpdxImplementation struct {
-	AnalyzeImageLayerStub        func(string, *spdx.Package) error
+	AnalyzeImageLayerStub        func(*spdx.Options, string, *spdx.Package) error
 	analyzeImageLayerMutex       sync.RWMutex
 	analyzeImageLayerArgsForCall []struct {
-		arg1 string
-		arg2 *spdx.Package
+		arg1 *spdx.Options
+		arg2 string
+		arg3 *spdx.Package
 	}
 	analyzeImageLayerReturns struct {
 		result1 error
@@ -91,11 +93,12 @@ type FakeSpdxImplementation struct {
 		result1 []string
 		result2 error
 	}
-	GetGoDependenciesStub        func(string, *spdx.Options) ([]*spdx.Package, error)
+	GetGoDependenciesStub        func(context.Context, string, *spdx.Options) ([]*spdx.Package, error)
 	getGoDependenciesMutex       sync.RWMutex
 	getGoDependenciesArgsForCall []struct {
-		arg1 string
-		arg2 *spdx.Options
+		arg1 context.Context
+		arg2 string
+		arg3 *spdx.Options
 	}
 	getGoDependenciesReturns struct {
 		result1 []*spdx.Package
@@ -120,11 +123,12 @@ type FakeSpdxImplementation struct {
 		result1 []gitignore.Pattern
 		result2 error
 	}
-	ImageRefToPackageStub        func(string, *spdx.Options) (*spdx.Package, error)
+	ImageRefToPackageStub        func(context.Context, string, *spdx.Options) (*spdx.Package, error)
 	imageRefToPackageMutex       sync.RWMutex
 	imageRefToPackageArgsForCall []struct {
-		arg1 string
-		arg2 *spdx.Options
+		arg1 context.Context
+		arg2 string
+		arg3 *spdx.Options
 	}
 	imageRefToPackageReturns struct {
 		result1 *spdx.Package
@@ -175,6 +179,20 @@ type FakeSpdxImplementation struct {
 		result1 *spdx.Package
 		result2 error
 	}
+	PackageFromRootfsStub        func(*spdx.Options, string) (*spdx.Package, error)
+	packageFromRootfsMutex       sync.RWMutex
+	packageFromRootfsArgsForCall []struct {
+		arg1 *spdx.Options
+		arg2 string
+	}
+	packageFromRootfsReturns struct {
+		result1 *spdx.Package
+		result2 error
+	}
+	packageFromRootfsReturnsOnCall map[int]struct {
+		result1 *spdx.Package
+		result2 error
+	}
 	PackageFromTarballStub        func(*spdx.Options, *spdx.TarballOptions, string) (*spdx.Package, error)
 	packageFromTarballMutex       sync.RWMutex
 	packageFromTarballArgsForCall []struct {
@@ -190,11 +208,12 @@ type FakeSpdxImplementation struct {
 		result1 *spdx.Package
 		result2 error
 	}
-	PullImagesToArchiveStub        func(string, string) (*spdx.ImageReferenceInfo, error)
+	PullImagesToArchiveStub        func(context.Context, string, string) (*spdx.ImageReferenceInfo, error)
 	pullImagesToArchiveMutex       sync.RWMutex
 	pullImagesToArchiveArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	pullImagesToArchiveReturns struct {
 		result1 *spdx.ImageReferenceInfo
@@ -221,19 +240,20 @@ type FakeSpdxImplementation struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSpdxImplementation) AnalyzeImageLayer(arg1 string, arg2 *spdx.Package) error {
+func (fake *FakeSpdxImplementation) AnalyzeImageLayer(arg1 *spdx.Options, arg2 string, arg3 *spdx.Package) error {
 	fake.analyzeImageLayerMutex.Lock()
 	ret, specificReturn := fake.analyzeImageLayerReturnsOnCall[len(fake.analyzeImageLayerArgsForCall)]
 	fake.analyzeImageLayerArgsForCall = append(fake.analyzeImageLayerArgsForCall, struct {
-		arg1 string
-		arg2 *spdx.Package
-	}{arg1, arg2})
+		arg1 *spdx.Options
+		arg2 string
+		arg3 *spdx.Package
+	}{arg1, arg2, arg3})
 	stub := fake.AnalyzeImageLayerStub
 	fakeReturns := fake.analyzeImageLayerReturns
-	fake.recordInvocation("AnalyzeImageLayer", []interface{}{arg1, arg2})
+	fake.recordInvocation("AnalyzeImageLayer", []interface{}{arg1, arg2, arg3})
 	fake.analyzeImageLayerMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -247,17 +267,17 @@ func (fake *FakeSpdxImplementation) AnalyzeImageLayerCallCount() int {
 	return len(fake.analyzeImageLayerArgsForCall)
 }
 
-func (fake *FakeSpdxImplementation) AnalyzeImageLayerCalls(stub func(string, *spdx.Package) error) {
+func (fake *FakeSpdxImplementation) AnalyzeImageLayerCalls(stub func(*spdx.Options, string, *spdx.Package) error) {
 	fake.analyzeImageLayerMutex.Lock()
 	defer fake.analyzeImageLayerMutex.Unlock()
 	fake.AnalyzeImageLayerStub = stub
 }
 
-func (fake *FakeSpdxImplementation) AnalyzeImageLayerArgsForCall(i int) (string, *spdx.Package) {
+func (fake *FakeSpdxImplementation) AnalyzeImageLayerArgsForCall(i int) (*spdx.Options, string, *spdx.Package) {
 	fake.analyzeImageLayerMutex.RLock()
 	defer fake.analyzeImageLayerMutex.RUnlock()
 	argsForCall := fake.analyzeImageLayerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpdxImplementation) AnalyzeImageLayerReturns(result1 error) {
@@ -549,19 +569,20 @@ func (fake *FakeSpdxImplementation) GetDirectoryTreeReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeSpdxImplementation) GetGoDependencies(arg1 string, arg2 *spdx.Options) ([]*spdx.Package, error) {
+func (fake *FakeSpdxImplementation) GetGoDependencies(arg1 context.Context, arg2 string, arg3 *spdx.Options) ([]*spdx.Package, error) {
 	fake.getGoDependenciesMutex.Lock()
 	ret, specificReturn := fake.getGoDependenciesReturnsOnCall[len(fake.getGoDependenciesArgsForCall)]
 	fake.getGoDependenciesArgsForCall = append(fake.getGoDependenciesArgsForCall, struct {
-		arg1 string
-		arg2 *spdx.Options
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *spdx.Options
+	}{arg1, arg2, arg3})
 	stub := fake.GetGoDependenciesStub
 	fakeReturns := fake.getGoDependenciesReturns
-	fake.recordInvocation("GetGoDependencies", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetGoDependencies", []interface{}{arg1, arg2, arg3})
 	fake.getGoDependenciesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -575,17 +596,17 @@ func (fake *FakeSpdxImplementation) GetGoDependenciesCallCount() int {
 	return len(fake.getGoDependenciesArgsForCall)
 }
 
-func (fake *FakeSpdxImplementation) GetGoDependenciesCalls(stub func(string, *spdx.Options) ([]*spdx.Package, error)) {
+func (fake *FakeSpdxImplementation) GetGoDependenciesCalls(stub func(context.Context, string, *spdx.Options) ([]*spdx.Package, error)) {
 	fake.getGoDependenciesMutex.Lock()
 	defer fake.getGoDependenciesMutex.Unlock()
 	fake.GetGoDependenciesStub = stub
 }
 
-func (fake *FakeSpdxImplementation) GetGoDependenciesArgsForCall(i int) (string, *spdx.Options) {
+func (fake *FakeSpdxImplementation) GetGoDependenciesArgsForCall(i int) (context.Context, string, *spdx.Options) {
 	fake.getGoDependenciesMutex.RLock()
 	defer fake.getGoDependenciesMutex.RUnlock()
 	argsForCall := fake.getGoDependenciesArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpdxImplementation) GetGoDependenciesReturns(result1 []*spdx.Package, result2 error) {
@@ -685,19 +706,20 @@ func (fake *FakeSpdxImplementation) IgnorePatternsReturnsOnCall(i int, result1 [
 	}{result1, result2}
 }
 
-func (fake *FakeSpdxImplementation) ImageRefToPackage(arg1 string, arg2 *spdx.Options) (*spdx.Package, error) {
+func (fake *FakeSpdxImplementation) ImageRefToPackage(arg1 context.Context, arg2 string, arg3 *spdx.Options) (*spdx.Package, error) {
 	fake.imageRefToPackageMutex.Lock()
 	ret, specificReturn := fake.imageRefToPackageReturnsOnCall[len(fake.imageRefToPackageArgsForCall)]
 	fake.imageRefToPackageArgsForCall = append(fake.imageRefToPackageArgsForCall, struct {
-		arg1 string
-		arg2 *spdx.Options
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *spdx.Options
+	}{arg1, arg2, arg3})
 	stub := fake.ImageRefToPackageStub
 	fakeReturns := fake.imageRefToPackageReturns
-	fake.recordInvocation("ImageRefToPackage", []interface{}{arg1, arg2})
+	fake.recordInvocation("ImageRefToPackage", []interface{}{arg1, arg2, arg3})
 	fake.imageRefToPackageMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -711,17 +733,17 @@ func (fake *FakeSpdxImplementation) ImageRefToPackageCallCount() int {
 	return len(fake.imageRefToPackageArgsForCall)
 }
 
-func (fake *FakeSpdxImplementation) ImageRefToPackageCalls(stub func(string, *spdx.Options) (*spdx.Package, error)) {
+func (fake *FakeSpdxImplementation) ImageRefToPackageCalls(stub func(context.Context, string, *spdx.Options) (*spdx.Package, error)) {
 	fake.imageRefToPackageMutex.Lock()
 	defer fake.imageRefToPackageMutex.Unlock()
 	fake.ImageRefToPackageStub = stub
 }
 
-func (fake *FakeSpdxImplementation) ImageRefToPackageArgsForCall(i int) (string, *spdx.Options) {
+func (fake *FakeSpdxImplementation) ImageRefToPackageArgsForCall(i int) (context.Context, string, *spdx.Options) {
 	fake.imageRefToPackageMutex.RLock()
 	defer fake.imageRefToPackageMutex.RUnlock()
 	argsForCall := fake.imageRefToPackageArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpdxImplementation) ImageRefToPackageReturns(result1 *spdx.Package, result2 error) {
@@ -944,6 +966,71 @@ func (fake *FakeSpdxImplementation) PackageFromImageTarballReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeSpdxImplementation) PackageFromRootfs(arg1 *spdx.Options, arg2 string) (*spdx.Package, error) {
+	fake.packageFromRootfsMutex.Lock()
+	ret, specificReturn := fake.packageFromRootfsReturnsOnCall[len(fake.packageFromRootfsArgsForCall)]
+	fake.packageFromRootfsArgsForCall = append(fake.packageFromRootfsArgsForCall, struct {
+		arg1 *spdx.Options
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.PackageFromRootfsStub
+	fakeReturns := fake.packageFromRootfsReturns
+	fake.recordInvocation("PackageFromRootfs", []interface{}{arg1, arg2})
+	fake.packageFromRootfsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpdxImplementation) PackageFromRootfsCallCount() int {
+	fake.packageFromRootfsMutex.RLock()
+	defer fake.packageFromRootfsMutex.RUnlock()
+	return len(fake.packageFromRootfsArgsForCall)
+}
+
+func (fake *FakeSpdxImplementation) PackageFromRootfsCalls(stub func(*spdx.Options, string) (*spdx.Package, error)) {
+	fake.packageFromRootfsMutex.Lock()
+	defer fake.packageFromRootfsMutex.Unlock()
+	fake.PackageFromRootfsStub = stub
+}
+
+func (fake *FakeSpdxImplementation) PackageFromRootfsArgsForCall(i int) (*spdx.Options, string) {
+	fake.packageFromRootfsMutex.RLock()
+	defer fake.packageFromRootfsMutex.RUnlock()
+	argsForCall := fake.packageFromRootfsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpdxImplementation) PackageFromRootfsReturns(result1 *spdx.Package, result2 error) {
+	fake.packageFromRootfsMutex.Lock()
+	defer fake.packageFromRootfsMutex.Unlock()
+	fake.PackageFromRootfsStub = nil
+	fake.packageFromRootfsReturns = struct {
+		result1 *spdx.Package
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpdxImplementation) PackageFromRootfsReturnsOnCall(i int, result1 *spdx.Package, result2 error) {
+	fake.packageFromRootfsMutex.Lock()
+	defer fake.packageFromRootfsMutex.Unlock()
+	fake.PackageFromRootfsStub = nil
+	if fake.packageFromRootfsReturnsOnCall == nil {
+		fake.packageFromRootfsReturnsOnCall = make(map[int]struct {
+			result1 *spdx.Package
+			result2 error
+		})
+	}
+	fake.packageFromRootfsReturnsOnCall[i] = struct {
+		result1 *spdx.Package
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSpdxImplementation) PackageFromTarball(arg1 *spdx.Options, arg2 *spdx.TarballOptions, arg3 string) (*spdx.Package, error) {
 	fake.packageFromTarballMutex.Lock()
 	ret, specificReturn := fake.packageFromTarballReturnsOnCall[len(fake.packageFromTarballArgsForCall)]
@@ -1010,19 +1097,20 @@ func (fake *FakeSpdxImplementation) PackageFromTarballReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
-func (fake *FakeSpdxImplementation) PullImagesToArchive(arg1 string, arg2 string) (*spdx.ImageReferenceInfo, error) {
+func (fake *FakeSpdxImplementation) PullImagesToArchive(arg1 context.Context, arg2 string, arg3 string) (*spdx.ImageReferenceInfo, error) {
 	fake.pullImagesToArchiveMutex.Lock()
 	ret, specificReturn := fake.pullImagesToArchiveReturnsOnCall[len(fake.pullImagesToArchiveArgsForCall)]
 	fake.pullImagesToArchiveArgsForCall = append(fake.pullImagesToArchiveArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.PullImagesToArchiveStub
 	fakeReturns := fake.pullImagesToArchiveReturns
-	fake.recordInvocation("PullImagesToArchive", []interface{}{arg1, arg2})
+	fake.recordInvocation("PullImagesToArchive", []interface{}{arg1, arg2, arg3})
 	fake.pullImagesToArchiveMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1036,17 +1124,17 @@ func (fake *FakeSpdxImplementation) PullImagesToArchiveCallCount() int {
 	return len(fake.pullImagesToArchiveArgsForCall)
 }
 
-func (fake *FakeSpdxImplementation) PullImagesToArchiveCalls(stub func(string, string) (*spdx.ImageReferenceInfo, error)) {
+func (fake *FakeSpdxImplementation) PullImagesToArchiveCalls(stub func(context.Context, string, string) (*spdx.ImageReferenceInfo, error)) {
 	fake.pullImagesToArchiveMutex.Lock()
 	defer fake.pullImagesToArchiveMutex.Unlock()
 	fake.PullImagesToArchiveStub = stub
 }
 
-func (fake *FakeSpdxImplementation) PullImagesToArchiveArgsForCall(i int) (string, string) {
+func (fake *FakeSpdxImplementation) PullImagesToArchiveArgsForCall(i int) (context.Context, string, string) {
 	fake.pullImagesToArchiveMutex.RLock()
 	defer fake.pullImagesToArchiveMutex.RUnlock()
 	argsForCall := fake.pullImagesToArchiveArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeSpdxImplementation) PullImagesToArchiveReturns(result1 *spdx.ImageReferenceInfo, result2 error) {
@@ -1164,6 +1252,8 @@ func (fake *FakeSpdxImplementation) Invocations() map[string][][]interface{} {
 	defer fake.packageFromDirectoryMutex.RUnlock()
 	fake.packageFromImageTarballMutex.RLock()
 	defer fake.packageFromImageTarballMutex.RUnlock()
+	fake.packageFromRootfsMutex.RLock()
+	defer fake.packageFromRootfsMutex.RUnlock()
 	fake.packageFromTarballMutex.RLock()
 	defer fake.packageFromTarballMutex.RUnlock()
 	fake.pullImagesToArchiveMutex.RLock()
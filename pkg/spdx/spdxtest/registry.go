@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdxtest
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// RecordEnv is the environment variable that switches Fixture to
+// recording mode. When set, the images are pulled from their original
+// registries and written to the fixture layout before serving them.
+const RecordEnv = "BOM_RECORD_FIXTURES"
+
+// refNameAnnotation is the OCI layout annotation holding the original
+// reference of each recorded image.
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+// Registry is an in-memory container registry served over HTTP. bom
+// functions taking image references can be pointed to the images pushed
+// to it, without reaching the network.
+type Registry struct {
+	Host string // Address of the registry, eg 127.0.0.1:34567
+}
+
+// NewRegistry starts an empty registry that is shut down when the test
+// finishes.
+func NewRegistry(t testing.TB) *Registry {
+	t.Helper()
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parsing registry URL: %v", err)
+	}
+	return &Registry{Host: u.Host}
+}
+
+// Reference returns a reference to repoTag (eg pause:1.0) in the registry.
+func (r *Registry) Reference(repoTag string) string {
+	return r.Host + "/" + repoTag
+}
+
+// PushImage writes an image to repoTag and returns its digest reference.
+func (r *Registry) PushImage(t testing.TB, repoTag string, img v1.Image) string {
+	t.Helper()
+	ref, err := name.ParseReference(r.Reference(repoTag))
+	if err != nil {
+		t.Fatalf("parsing reference: %v", err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("pushing image to %s: %v", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("getting image digest: %v", err)
+	}
+	return ref.Context().Digest(digest.String()).String()
+}
+
+// PushIndex writes an image index to repoTag and returns its digest reference.
+func (r *Registry) PushIndex(t testing.TB, repoTag string, idx v1.ImageIndex) string {
+	t.Helper()
+	ref, err := name.ParseReference(r.Reference(repoTag))
+	if err != nil {
+		t.Fatalf("parsing reference: %v", err)
+	}
+	if err := remote.WriteIndex(ref, idx); err != nil {
+		t.Fatalf("pushing index to %s: %v", ref, err)
+	}
+	digest, err := idx.Digest()
+	if err != nil {
+		t.Fatalf("getting index digest: %v", err)
+	}
+	return ref.Context().Digest(digest.String()).String()
+}
+
+// RandomImage returns an image with random layers built for platform.
+func RandomImage(t testing.TB, platform v1.Platform, layers int64) v1.Image {
+	t.Helper()
+	img, err := random.Image(1024, layers)
+	if err != nil {
+		t.Fatalf("creating random image: %v", err)
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		t.Fatalf("reading image config: %v", err)
+	}
+	cfg.OS = platform.OS
+	cfg.Architecture = platform.Architecture
+	cfg.Variant = platform.Variant
+	img, err = mutate.ConfigFile(img, cfg)
+	if err != nil {
+		t.Fatalf("setting image platform: %v", err)
+	}
+	return img
+}
+
+// RandomIndex returns a docker manifest list with one random image for
+// each platform.
+func RandomIndex(t testing.TB, platforms ...v1.Platform) v1.ImageIndex {
+	t.Helper()
+	idx := mutate.IndexMediaType(empty.Index, types.DockerManifestList)
+	for _, p := range platforms {
+		img := RandomImage(t, p, 1)
+		mt, err := img.MediaType()
+		if err != nil {
+			t.Fatalf("getting image media type: %v", err)
+		}
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				MediaType: mt,
+				Platform:  &p,
+			},
+		})
+	}
+	return idx
+}
+
+// Fixture returns a registry serving the images recorded in the OCI
+// layout at path. Each image is pushed to the repository and tag of its
+// original reference, without the registry host, so an image recorded
+// from registry.k8s.io/pause:1.0 is found at Reference("pause:1.0").
+//
+// When the BOM_RECORD_FIXTURES environment variable is set, refs are
+// pulled from their registries and recorded in the layout first. This
+// is the only time the network is used.
+func Fixture(t testing.TB, path string, refs ...string) *Registry {
+	t.Helper()
+	if os.Getenv(RecordEnv) != "" {
+		RecordLayout(t, path, refs...)
+	}
+	r := NewRegistry(t)
+	r.LoadLayout(t, path)
+	return r
+}
+
+// RecordLayout pulls refs from their registries and writes them to a new
+// OCI layout at path, replacing any previous recording.
+func RecordLayout(t testing.TB, path string, refs ...string) {
+	t.Helper()
+	if err := os.RemoveAll(path); err != nil {
+		t.Fatalf("removing previous recording: %v", err)
+	}
+	lp, err := layout.Write(path, empty.Index)
+	if err != nil {
+		t.Fatalf("creating layout: %v", err)
+	}
+	for _, refString := range refs {
+		ref, err := name.ParseReference(refString)
+		if err != nil {
+			t.Fatalf("parsing reference %s: %v", refString, err)
+		}
+		descr, err := remote.Get(ref)
+		if err != nil {
+			t.Fatalf("fetching %s: %v", refString, err)
+		}
+		annotations := layout.WithAnnotations(map[string]string{refNameAnnotation: refString})
+		if descr.MediaType.IsIndex() {
+			idx, err := descr.ImageIndex()
+			if err != nil {
+				t.Fatalf("reading index %s: %v", refString, err)
+			}
+			if err := lp.AppendIndex(idx, annotations); err != nil {
+				t.Fatalf("recording %s: %v", refString, err)
+			}
+			continue
+		}
+		img, err := descr.Image()
+		if err != nil {
+			t.Fatalf("reading image %s: %v", refString, err)
+		}
+		if err := lp.AppendImage(img, annotations); err != nil {
+			t.Fatalf("recording %s: %v", refString, err)
+		}
+	}
+}
+
+// LoadLayout pushes the images and indexes recorded in the OCI layout at
+// path to the registry.
+func (r *Registry) LoadLayout(t testing.TB, path string) {
+	t.Helper()
+	lp, err := layout.FromPath(path)
+	if err != nil {
+		t.Fatalf("opening layout: %v", err)
+	}
+	root, err := lp.ImageIndex()
+	if err != nil {
+		t.Fatalf("reading layout index: %v", err)
+	}
+	manifest, err := root.IndexManifest()
+	if err != nil {
+		t.Fatalf("reading layout manifest: %v", err)
+	}
+	for _, desc := range manifest.Manifests { //nolint:gocritic // Descriptors are copied once per image
+		original, err := name.ParseReference(desc.Annotations[refNameAnnotation])
+		if err != nil {
+			t.Fatalf("parsing recorded reference: %v", err)
+		}
+		// Tagged references keep their tag, digests are pushed as is
+		repoTag := original.Context().RepositoryStr() + "@" + desc.Digest.String()
+		if tag, ok := original.(name.Tag); ok {
+			repoTag = original.Context().RepositoryStr() + ":" + tag.TagStr()
+		}
+
+		if desc.MediaType.IsIndex() {
+			idx, err := root.ImageIndex(desc.Digest)
+			if err != nil {
+				t.Fatalf("reading recorded index: %v", err)
+			}
+			r.PushIndex(t, repoTag, idx)
+			continue
+		}
+		img, err := root.Image(desc.Digest)
+		if err != nil {
+			t.Fatalf("reading recorded image: %v", err)
+		}
+		r.PushImage(t, repoTag, img)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdxtest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixture(t *testing.T) {
+	t.Setenv(RecordEnv, "")
+
+	// Record an image and an index from a registry, as done from their
+	// original registries when RecordEnv is set
+	src := NewRegistry(t)
+	img := RandomImage(t, v1.Platform{OS: "linux", Architecture: "amd64"}, 1)
+	idx := RandomIndex(t,
+		v1.Platform{OS: "linux", Architecture: "amd64"},
+		v1.Platform{OS: "linux", Architecture: "arm64"},
+	)
+	src.PushImage(t, "pause:1.0", img)
+	idxRef := src.PushIndex(t, "multi/arch:latest", idx)
+	path := filepath.Join(t.TempDir(), "layout")
+	RecordLayout(t, path, src.Reference("pause:1.0"), idxRef)
+
+	// The fixture serves them under their original repositories
+	r := Fixture(t, path)
+	ref, err := name.ParseReference(r.Reference("pause:1.0"))
+	require.NoError(t, err)
+	served, err := remote.Image(ref)
+	require.NoError(t, err)
+	expected, err := img.Digest()
+	require.NoError(t, err)
+	digest, err := served.Digest()
+	require.NoError(t, err)
+	require.Equal(t, expected, digest)
+
+	expected, err = idx.Digest()
+	require.NoError(t, err)
+	ref, err = name.ParseReference(r.Reference("multi/arch@" + expected.String()))
+	require.NoError(t, err)
+	servedIdx, err := remote.Index(ref)
+	require.NoError(t, err)
+	digest, err = servedIdx.Digest()
+	require.NoError(t, err)
+	require.Equal(t, expected, digest)
+}
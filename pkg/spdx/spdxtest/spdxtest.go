@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spdxtest provides helpers to write tests for code built on top
+// of the spdx package, like custom layer and image analyzers.
+package spdxtest
+
+import (
+	"archive/tar"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// WriteDirectory creates a temporary directory populated with files. The
+// map keys are the slash separated paths of the files relative to the
+// directory, the values their contents. Returns the path to the directory,
+// which is removed when the test finishes.
+func WriteDirectory(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("creating directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// WriteLayer writes a container layer tarball with files, keyed by their
+// path in the layer. Entries are written in path order. Returns the path
+// to the tarball, which is removed when the test finishes.
+func WriteLayer(t testing.TB, files map[string]string) string {
+	t.Helper()
+	layerPath := filepath.Join(t.TempDir(), "layer.tar")
+	f, err := os.Create(layerPath)
+	if err != nil {
+		t.Fatalf("creating layer: %v", err)
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		data := files[name]
+		if err := tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg,
+		}); err != nil {
+			t.Fatalf("writing header of %s: %v", name, err)
+		}
+		if _, err := tw.Write([]byte(data)); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("closing layer: %v", err)
+	}
+	return layerPath
+}
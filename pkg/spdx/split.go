@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SplitPart is a document describing one of the top level packages of a
+// split document.
+type SplitPart struct {
+	// Name is unique among the parts and can be used as a file name
+	Name string
+
+	// Document describes the package and everything it contains
+	Document *Document
+
+	// Ref points to the part from the index document. Its checksum has
+	// to be computed (eg with ReadSourceFile) once the part is written.
+	Ref ExternalDocumentRef
+}
+
+// SplitByPackage returns a document for each top level package in d,
+// sorted by package ID. Parts are named after the package they describe.
+func (d *Document) SplitByPackage() ([]SplitPart, error) {
+	if d.Namespace == "" {
+		return nil, errors.New("document has no namespace to derive the part namespaces")
+	}
+	if len(d.Packages) == 0 {
+		return nil, errors.New("document has no top level packages to split")
+	}
+
+	parts := []SplitPart{}
+	used := map[string]int{}
+	for _, id := range slices.Sorted(maps.Keys(d.Packages)) {
+		sub, err := d.Subgraph(id)
+		if err != nil {
+			return nil, fmt.Errorf("extracting package %s: %w", id, err)
+		}
+
+		name := strings.Trim(extDocIDRe.ReplaceAllString(d.Packages[id].Name, "-"), "-.")
+		if name == "" {
+			name = strings.TrimPrefix(id, "SPDXRef-")
+		}
+		used[name]++
+		if used[name] > 1 {
+			name += "-" + strconv.Itoa(used[name])
+		}
+		sub.Name = d.Packages[id].Name
+
+		parts = append(parts, SplitPart{
+			Name:     name,
+			Document: sub,
+			Ref:      ExternalDocumentRef{ID: name, URI: sub.Namespace},
+		})
+	}
+	return parts, nil
+}
+
+// SplitIndex returns the top document of a split. It describes a single
+// package, named as d, that contains the packages described by each part
+// through references to the external documents.
+func (d *Document) SplitIndex(parts []SplitPart) (*Document, error) {
+	index := NewDocument()
+	index.Version = d.Version
+	index.DataLicense = d.DataLicense
+	index.Name = d.Name
+	index.Namespace = d.Namespace
+	index.Creator = d.Creator
+	index.CreatorComment = d.CreatorComment
+	index.LicenseListVersion = d.LicenseListVersion
+
+	top := NewPackage()
+	top.Name = d.Name
+	top.BuildID("split-index", d.Name)
+	top.DownloadLocation = NOASSERTION
+	top.LicenseConcluded = NOASSERTION
+
+	for _, part := range parts {
+		if len(part.Ref.Checksums) == 0 {
+			return nil, fmt.Errorf("external document reference to %s has no checksum", part.Name)
+		}
+		for _, id := range slices.Sorted(maps.Keys(part.Document.Packages)) {
+			top.AddRelationship(&Relationship{
+				Type:             CONTAINS,
+				PeerReference:    id,
+				PeerExtReference: part.Ref.ID,
+			})
+		}
+		top.ExternalDocRefs = append(top.ExternalDocRefs, part.Ref)
+	}
+
+	if err := index.AddPackage(top); err != nil {
+		return nil, fmt.Errorf("adding package to index document: %w", err)
+	}
+	return index, nil
+}
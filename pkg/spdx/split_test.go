@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitByPackage(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "release"
+	doc.Namespace = "https://example.com/release"
+
+	for _, name := range []string{"registry.k8s.io/pause:3.9", "registry.k8s.io/kube-proxy:v1.30.0"} {
+		p := NewPackage()
+		p.Name = name
+		p.BuildID(name)
+		f := NewFile()
+		f.FileName = "bin/" + name
+		f.BuildID(name, "file")
+		require.NoError(t, p.AddFile(f))
+		require.NoError(t, doc.AddPackage(p))
+	}
+
+	parts, err := doc.SplitByPackage()
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+
+	names := map[string]struct{}{}
+	for i := range parts {
+		names[parts[i].Name] = struct{}{}
+		require.Len(t, parts[i].Document.Packages, 1)
+		require.Equal(t, parts[i].Name, parts[i].Ref.ID)
+		require.Equal(t, parts[i].Document.Namespace, parts[i].Ref.URI)
+		require.NotEqual(t, doc.Namespace, parts[i].Document.Namespace)
+	}
+	require.Equal(t, map[string]struct{}{
+		"registry.k8s.io-pause-3.9":          {},
+		"registry.k8s.io-kube-proxy-v1.30.0": {},
+	}, names)
+
+	// References must be hashed before building the index
+	_, err = doc.SplitIndex(parts)
+	require.Error(t, err)
+
+	for i := range parts {
+		parts[i].Ref.Checksums = map[string]string{"SHA1": "d6a770ba38583ed4bb4525bd96e50461655d2758"}
+	}
+	index, err := doc.SplitIndex(parts)
+	require.NoError(t, err)
+	require.Equal(t, doc.Namespace, index.Namespace)
+	require.Len(t, index.Packages, 1)
+	require.Len(t, index.ExternalDocRefs, 2)
+
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	parsed := &Document{}
+	require.NoError(t, json.Unmarshal(data, parsed))
+	require.Len(t, parsed.ExternalDocRefs, 2)
+	for _, p := range parsed.Packages {
+		require.Len(t, p.Relationships, 2)
+		for _, rel := range p.Relationships {
+			require.Equal(t, CONTAINS, rel.Type)
+			require.Contains(t, rel.PeerExtReference, "DocumentRef-registry.k8s.io-")
+		}
+	}
+
+	tv, err := index.Render()
+	require.NoError(t, err)
+	require.Contains(t, tv, "ExternalDocumentRef:DocumentRef-registry.k8s.io-pause-3.9 ")
+
+	_, err = NewDocument().SplitByPackage()
+	require.Error(t, err)
+}
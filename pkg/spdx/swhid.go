@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/sha1" //nolint:gosec // git object IDs are sha1 digests
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+)
+
+const (
+	swhidPrefix  = "swh:1:"
+	gitoidPrefix = "gitoid:blob:"
+)
+
+// gitBlobHashes returns the sha1 and sha256 git object IDs of a file,
+// the digests of its contents prefixed with the git blob header.
+func gitBlobHashes(filePath string) (sha1Hex, sha256Hex string, err error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", "", fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", "", fmt.Errorf("reading file size: %w", err)
+	}
+
+	h1 := sha1.New()
+	h256 := sha256.New()
+	w := io.MultiWriter(h1, h256)
+	fmt.Fprintf(w, "blob %d\x00", info.Size())
+	if _, err := io.Copy(w, f); err != nil {
+		return "", "", fmt.Errorf("hashing file: %w", err)
+	}
+	return hex.EncodeToString(h1.Sum(nil)), hex.EncodeToString(h256.Sum(nil)), nil
+}
+
+// gitTreeEntry is an entry in a git tree object
+type gitTreeEntry struct {
+	mode string
+	name string
+	hash []byte
+}
+
+// gitTreeHash computes the sha1 git tree ID of a directory containing
+// the listed files (relative to root, slash separated). This is the
+// directory Software Heritage ID when files lists the whole directory.
+func gitTreeHash(root string, files []string) (string, error) {
+	// Group the files by the directory that holds them
+	dirs := map[string][]string{".": {}}
+	for _, f := range files {
+		for d := path.Dir(f); ; d = path.Dir(d) {
+			if _, ok := dirs[d]; ok || d == "." {
+				break
+			}
+			dirs[d] = []string{}
+		}
+		dirs[path.Dir(f)] = append(dirs[path.Dir(f)], f)
+	}
+
+	var treeHash func(dir string) ([]byte, error)
+	treeHash = func(dir string) ([]byte, error) {
+		entries := []gitTreeEntry{}
+		for _, f := range dirs[dir] {
+			info, err := os.Stat(filepath.Join(root, filepath.FromSlash(f)))
+			if err != nil {
+				return nil, fmt.Errorf("reading file mode: %w", err)
+			}
+			mode := "100644"
+			if info.Mode()&0o100 != 0 {
+				mode = "100755"
+			}
+			sha1Hex, _, err := gitBlobHashes(filepath.Join(root, filepath.FromSlash(f)))
+			if err != nil {
+				return nil, err
+			}
+			h, err := hex.DecodeString(sha1Hex)
+			if err != nil {
+				return nil, fmt.Errorf("decoding blob hash: %w", err)
+			}
+			entries = append(entries, gitTreeEntry{mode: mode, name: path.Base(f), hash: h})
+		}
+		for d := range dirs {
+			if d == "." || path.Dir(d) != dir {
+				continue
+			}
+			h, err := treeHash(d)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, gitTreeEntry{mode: "40000", name: path.Base(d), hash: h})
+		}
+
+		// Git sorts tree entries by name, comparing directories as if
+		// their names had a trailing slash
+		sortName := func(e gitTreeEntry) string {
+			if e.mode == "40000" {
+				return e.name + "/"
+			}
+			return e.name
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return sortName(entries[i]) < sortName(entries[j])
+		})
+
+		var body strings.Builder
+		for _, e := range entries {
+			body.WriteString(e.mode + " " + e.name + "\x00")
+			body.Write(e.hash)
+		}
+		h := sha1.New()
+		fmt.Fprintf(h, "tree %d\x00%s", body.Len(), body.String())
+		return h.Sum(nil), nil
+	}
+
+	h, err := treeHash(".")
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h), nil
+}
+
+// gitBlobIDs returns the sha1 and sha256 git object IDs of the entity
+// source file. They are computed when reading its checksums, so they are
+// available after temporary source files are removed.
+func (e *Entity) gitBlobIDs() (sha1Hex, sha256Hex string, err error) {
+	if e.gitoids["sha1"] != "" && e.gitoids["sha256"] != "" {
+		return e.gitoids["sha1"], e.gitoids["sha256"], nil
+	}
+	sha1Hex, sha256Hex, err = gitBlobHashes(e.SourceFile)
+	if err != nil {
+		return "", "", err
+	}
+	e.gitoids = map[string]string{"sha1": sha1Hex, "sha256": sha256Hex}
+	return sha1Hex, sha256Hex, nil
+}
+
+// blobPersistentIDs returns the Software Heritage content ID and the
+// gitoids of the entity source file.
+func (e *Entity) blobPersistentIDs() ([]string, error) {
+	sha1Hex, sha256Hex, err := e.gitBlobIDs()
+	if err != nil {
+		return nil, fmt.Errorf("computing persistent IDs of %s: %w", e.SourceFile, err)
+	}
+	return []string{
+		swhidPrefix + "cnt:" + sha1Hex,
+		gitoidPrefix + "sha1:" + sha1Hex,
+		gitoidPrefix + "sha256:" + sha256Hex,
+	}, nil
+}
+
+// addPersistentIDRefs adds persistent identifiers to the external
+// references of a package, skipping those already present.
+func (p *Package) addPersistentIDRefs(ids []string) {
+	for _, id := range ids {
+		typ := "gitoid"
+		if strings.HasPrefix(id, swhidPrefix) {
+			typ = "swh"
+		}
+		found := false
+		for _, er := range p.ExternalRefs {
+			if er.Locator == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+				Category: CatPersistentID,
+				Type:     typ,
+				Locator:  id,
+			})
+		}
+	}
+}
+
+// AddPersistentIDs adds the Software Heritage ID and gitoids of the
+// package source file to its external references. Packages not read
+// from a file are not modified.
+func (p *Package) AddPersistentIDs() error {
+	if p.SourceFile == "" {
+		return nil
+	}
+	ids, err := p.blobPersistentIDs()
+	if err != nil {
+		return err
+	}
+	p.addPersistentIDRefs(ids)
+	return nil
+}
+
+// AddPersistentIDs records the Software Heritage ID and gitoids of the
+// file. SPDX files don't have external references, so they are added
+// in an annotation.
+func (f *File) AddPersistentIDs() error {
+	if f.SourceFile == "" {
+		return nil
+	}
+	ids, err := f.blobPersistentIDs()
+	if err != nil {
+		return err
+	}
+	comment := strings.Join(ids, "\n")
+	for _, a := range f.Annotations {
+		if a.Comment == comment {
+			return nil
+		}
+	}
+	f.Annotations = append(f.Annotations, Annotation{
+		Annotator: "Tool: bom-" + version.GetVersionInfo().GitVersion,
+		Date:      time.Now().UTC().Format(time.RFC3339),
+		Type:      "OTHER",
+		Comment:   comment,
+	})
+	return nil
+}
+
+// AddPersistentIDs computes the Software Heritage IDs and gitoids of the
+// packages and files in the document read from local files. Returns the
+// number of elements modified.
+func (d *Document) AddPersistentIDs() (int, error) {
+	n := 0
+	err := d.Walk(func(o Object, _ []Object) error {
+		var err error
+		switch e := o.(type) {
+		case *Package:
+			if e.SourceFile == "" {
+				return nil
+			}
+			err = e.AddPersistentIDs()
+		case *File:
+			if e.SourceFile == "" {
+				return nil
+			}
+			err = e.AddPersistentIDs()
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
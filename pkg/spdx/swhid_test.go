@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/bom/pkg/spdx/spdxtest"
+)
+
+func TestGitTreeHash(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows files have no executable bit to hash")
+	}
+	dir := spdxtest.WriteDirectory(t, map[string]string{
+		"README.md":  "hello\n",
+		"bin/run.sh": "#!/bin/sh\n",
+		"a/x":        "x\n",
+		"a-b":        "y\n",
+	})
+	require.NoError(t, os.Chmod(filepath.Join(dir, "bin", "run.sh"), 0o755))
+
+	// Same as git write-tree of the directory
+	hash, err := gitTreeHash(dir, []string{"README.md", "bin/run.sh", "a/x", "a-b"})
+	require.NoError(t, err)
+	require.Equal(t, "862285cf60d930eb8d676139bd9eb5fd377f82f4", hash)
+}
+
+func TestAddPersistentIDs(t *testing.T) {
+	dir := spdxtest.WriteDirectory(t, map[string]string{"README.md": "hello\n"})
+	path := filepath.Join(dir, "README.md")
+	ids := []string{
+		"swh:1:cnt:ce013625030ba8dba906f756967f9e9ca394464a",
+		"gitoid:blob:sha1:ce013625030ba8dba906f756967f9e9ca394464a",
+		"gitoid:blob:sha256:2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4",
+	}
+
+	pkg := NewPackage()
+	pkg.BuildID("readme")
+	pkg.Name = "readme"
+	pkg.SourceFile = path
+	f := NewFile()
+	f.BuildID("readme")
+	f.Name = "README.md"
+	f.SourceFile = path
+	require.NoError(t, pkg.AddFile(f))
+
+	doc := NewDocument()
+	require.NoError(t, doc.AddPackage(pkg))
+	n, err := doc.AddPersistentIDs()
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	require.Equal(t, []ExternalRef{
+		{Category: CatPersistentID, Type: "swh", Locator: ids[0]},
+		{Category: CatPersistentID, Type: "gitoid", Locator: ids[1]},
+		{Category: CatPersistentID, Type: "gitoid", Locator: ids[2]},
+	}, pkg.ExternalRefs)
+	require.Len(t, f.Annotations, 1)
+	require.Equal(t, "OTHER", f.Annotations[0].Type)
+	require.Equal(t, ids[0]+"\n"+ids[1]+"\n"+ids[2], f.Annotations[0].Comment)
+
+	// Running again does not duplicate the identifiers
+	_, err = doc.AddPersistentIDs()
+	require.NoError(t, err)
+	require.Len(t, pkg.ExternalRefs, 3)
+	require.Len(t, f.Annotations, 1)
+}
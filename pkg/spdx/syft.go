@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// syftDocument is the part of the syft JSON output read by bom.
+type syftDocument struct {
+	Artifacts             []syftArtifact     `json:"artifacts"`
+	ArtifactRelationships []syftRelationship `json:"artifactRelationships"`
+	Files                 []syftFile         `json:"files"`
+	Source                struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Type    string `json:"type"`
+	} `json:"source"`
+	Descriptor struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"descriptor"`
+}
+
+type syftArtifact struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Version  string        `json:"version"`
+	Type     string        `json:"type"`
+	FoundBy  string        `json:"foundBy"`
+	Licenses []syftLicense `json:"licenses"`
+	CPEs     []syftCPE     `json:"cpes"`
+	PURL     string        `json:"purl"`
+}
+
+// syftLicense is a license of a syft artifact. Syft schemas before
+// v8 list the licenses as plain strings.
+type syftLicense struct {
+	Value          string `json:"value"`
+	SPDXExpression string `json:"spdxExpression"`
+}
+
+func (l *syftLicense) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &l.Value)
+	}
+	type license syftLicense
+	return json.Unmarshal(data, (*license)(l))
+}
+
+// syftCPE is a CPE of a syft artifact. Syft schemas before v16 list
+// the CPEs as plain strings.
+type syftCPE struct {
+	CPE string `json:"cpe"`
+}
+
+func (c *syftCPE) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.CPE)
+	}
+	type cpe syftCPE
+	return json.Unmarshal(data, (*cpe)(c))
+}
+
+type syftFile struct {
+	ID       string `json:"id"`
+	Location struct {
+		Path string `json:"path"`
+	} `json:"location"`
+	Digests []struct {
+		Algorithm string `json:"algorithm"`
+		Value     string `json:"value"`
+	} `json:"digests"`
+}
+
+type syftRelationship struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Type   string `json:"type"`
+}
+
+// ImportSyftJSON converts the JSON output of syft (syft -o json) to an
+// SPDX document. The scanned source becomes the top level package of the
+// document, containing the artifacts and files syft found. The contains
+// and dependency-of relationships of syft are kept, other relationships
+// are recorded as OTHER.
+func ImportSyftJSON(r io.Reader) (*Document, error) {
+	sd := syftDocument{}
+	if err := json.NewDecoder(r).Decode(&sd); err != nil {
+		return nil, fmt.Errorf("parsing syft json: %w", err)
+	}
+
+	doc := NewDocument()
+	doc.Name = sd.Source.Name
+	doc.Namespace = "https://spdx.org/spdxdocs/bom-syft-" + uuid.NewString()
+	if sd.Descriptor.Name != "" {
+		doc.Creator.Tool = append(doc.Creator.Tool, sd.Descriptor.Name+"-"+sd.Descriptor.Version)
+	}
+
+	root := NewPackage()
+	root.Name = sd.Source.Name
+	root.Version = sd.Source.Version
+	root.DownloadLocation = NOASSERTION
+	root.Comment = fmt.Sprintf("Imported from the syft scan of %s %s", sd.Source.Type, sd.Source.Name)
+	switch sd.Source.Type {
+	case "image":
+		root.PrimaryPurpose = "CONTAINER"
+	case "directory":
+		root.PrimaryPurpose = "SOURCE"
+	case "file":
+		root.PrimaryPurpose = "FILE"
+	}
+	root.BuildID("syft", sd.Source.Name, sd.Source.ID)
+
+	elements := map[string]Object{}
+	order := []string{}
+	for i := range sd.Artifacts {
+		p := sd.Artifacts[i].toSPDXPackage()
+		elements[sd.Artifacts[i].ID] = p
+		order = append(order, sd.Artifacts[i].ID)
+	}
+	for i := range sd.Files {
+		f := sd.Files[i].toSPDXFile()
+		elements[sd.Files[i].ID] = f
+		order = append(order, sd.Files[i].ID)
+	}
+
+	contained := map[string]struct{}{}
+	skipped := 0
+	for _, rel := range sd.ArtifactRelationships {
+		parent, child := elements[rel.Parent], elements[rel.Child]
+		if parent == nil || child == nil {
+			skipped++
+			continue
+		}
+		switch rel.Type {
+		case "contains":
+			parent.AddRelationship(&Relationship{Peer: child, Type: CONTAINS, FullRender: true})
+			contained[rel.Child] = struct{}{}
+		case "dependency-of":
+			child.AddRelationship(&Relationship{Peer: parent, Type: DEPENDS_ON, FullRender: true})
+		default:
+			parent.AddRelationship(&Relationship{
+				Peer: child, Type: OTHER, FullRender: true, Comment: "syft relationship: " + rel.Type,
+			})
+		}
+	}
+	if skipped > 0 {
+		logrus.Warnf("Skipped %d syft relationships referencing unknown elements", skipped)
+	}
+
+	// Elements not contained by others hang from the scanned source
+	for _, id := range order {
+		if _, ok := contained[id]; ok {
+			continue
+		}
+		root.AddRelationship(&Relationship{Peer: elements[id], Type: CONTAINS, FullRender: true})
+	}
+
+	if err := doc.AddPackage(root); err != nil {
+		return nil, fmt.Errorf("adding scanned source package: %w", err)
+	}
+	return doc, nil
+}
+
+// toSPDXPackage converts a syft artifact to an SPDX package.
+func (a *syftArtifact) toSPDXPackage() *Package {
+	p := NewPackage()
+	p.Options().Prefix = a.Type
+	p.Name = a.Name
+	p.Version = a.Version
+	p.DownloadLocation = NOASSERTION
+	p.BuildID(a.Name, a.Version, a.ID)
+	if a.FoundBy != "" {
+		p.Comment = "Found by the syft " + a.FoundBy
+	}
+
+	licenses := []string{}
+	for _, l := range a.Licenses {
+		expr := l.SPDXExpression
+		if expr == "" {
+			expr = l.Value
+		}
+		if expr != "" {
+			licenses = append(licenses, expr)
+		}
+	}
+	if len(licenses) > 0 {
+		p.LicenseDeclared = joinLicenseExpressions(licenses)
+	}
+
+	if a.PURL != "" {
+		p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  a.PURL,
+		})
+	}
+	for _, cpe := range a.CPEs {
+		typ := "cpe23Type"
+		if strings.HasPrefix(cpe.CPE, "cpe:/") {
+			typ = "cpe22Type"
+		}
+		p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+			Category: CatSecurity,
+			Type:     typ,
+			Locator:  cpe.CPE,
+		})
+	}
+	return p
+}
+
+// toSPDXFile converts a syft file to an SPDX file.
+func (sf *syftFile) toSPDXFile() *File {
+	f := NewFile()
+	f.FileName = strings.TrimPrefix(sf.Location.Path, "/")
+	f.Name = f.FileName
+	f.BuildID(f.FileName, sf.ID)
+	for _, d := range sf.Digests {
+		if f.Checksum == nil {
+			f.Checksum = map[string]string{}
+		}
+		f.Checksum[strings.ToUpper(d.Algorithm)] = d.Value
+	}
+	return f
+}
+
+// joinLicenseExpressions combines the licenses of a package found by a
+// scanner into a single expression.
+func joinLicenseExpressions(licenses []string) string {
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+	parts := []string{}
+	seen := map[string]struct{}{}
+	for _, l := range licenses {
+		if _, ok := seen[l]; ok {
+			continue
+		}
+		seen[l] = struct{}{}
+		if strings.Contains(l, " ") {
+			l = "(" + l + ")"
+		}
+		parts = append(parts, l)
+	}
+	return strings.Join(parts, " AND ")
+}
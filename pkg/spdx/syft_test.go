@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// relatedByName indexes the peers of an element's relationships by name.
+func relatedByName(t *testing.T, o Object) map[string]*Relationship {
+	t.Helper()
+	rels := map[string]*Relationship{}
+	for _, rel := range *o.GetRelationships() {
+		switch peer := rel.Peer.(type) {
+		case *Package:
+			rels[peer.Name] = rel
+		case *File:
+			rels[peer.FileName] = rel
+		}
+	}
+	return rels
+}
+
+func TestImportSyftJSON(t *testing.T) {
+	f, err := os.Open("testdata/alpine.syft.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := ImportSyftJSON(f)
+	require.NoError(t, err)
+	require.Equal(t, "alpine", doc.Name)
+	require.Contains(t, doc.Creator.Tool, "syft-1.4.1")
+	require.Len(t, doc.Packages, 1)
+
+	var root *Package
+	for _, p := range doc.Packages {
+		root = p
+	}
+	require.Equal(t, "alpine", root.Name)
+	require.Equal(t, "3.19", root.Version)
+	require.Equal(t, "CONTAINER", root.PrimaryPurpose)
+
+	// The file is contained by busybox, so only the packages hang
+	// from the root
+	top := relatedByName(t, root)
+	require.Len(t, top, 2)
+	require.Contains(t, top, "busybox")
+	require.Contains(t, top, "musl")
+
+	busybox, ok := top["busybox"].Peer.(*Package)
+	require.True(t, ok)
+	require.Equal(t, "1.36.1-r15", busybox.Version)
+	require.Equal(t, "GPL-2.0-only", busybox.LicenseDeclared)
+	require.Equal(t, "pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64&distro=alpine-3.19.1", busybox.ExternalRefs[0].Locator)
+	require.Len(t, busybox.ExternalRefs, 2)
+	require.Equal(t, CatSecurity, busybox.ExternalRefs[1].Category)
+	require.Equal(t, "cpe23Type", busybox.ExternalRefs[1].Type)
+
+	// The relationship to the unknown element is dropped
+	related := relatedByName(t, busybox)
+	require.Len(t, related, 2)
+	require.Equal(t, DEPENDS_ON, related["musl"].Type)
+	require.Equal(t, CONTAINS, related["bin/busybox"].Type)
+	file, ok := related["bin/busybox"].Peer.(*File)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{
+		"SHA256": "0c8bd9f9f2e4bb8e6ad5f6a2c8d6b9e4a5c2f1e0d9c8b7a6f5e4d3c2b1a09f8e",
+	}, file.Checksum)
+
+	// musl is read from the older schema, with plain string licenses and CPEs
+	musl, ok := top["musl"].Peer.(*Package)
+	require.True(t, ok)
+	require.Equal(t, "MIT", musl.LicenseDeclared)
+	require.Len(t, musl.ExternalRefs, 2)
+	require.Equal(t, "cpe:2.3:a:musl-libc:musl:1.2.4_git20230717-r4:*:*:*:*:*:*:*", musl.ExternalRefs[1].Locator)
+}
+
+func TestImportSyftJSONInvalid(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"artifacts": "`)
+	require.NoError(t, err)
+	_, err = f.Seek(0, 0)
+	require.NoError(t, err)
+	_, err = ImportSyftJSON(f)
+	require.Error(t, err)
+}
+
+func TestSyftLicenseUnmarshal(t *testing.T) {
+	licenses := []syftLicense{}
+	require.NoError(t, json.Unmarshal(
+		[]byte(`["MIT", {"value": "Apache 2", "spdxExpression": "Apache-2.0"}]`), &licenses,
+	))
+	require.Equal(t, []syftLicense{
+		{Value: "MIT"},
+		{Value: "Apache 2", SPDXExpression: "Apache-2.0"},
+	}, licenses)
+}
+
+func TestJoinLicenseExpressions(t *testing.T) {
+	for _, tc := range []struct {
+		licenses []string
+		expected string
+	}{
+		{[]string{"MIT"}, "MIT"},
+		{[]string{"MIT", "Apache-2.0"}, "MIT AND Apache-2.0"},
+		{[]string{"MIT", "MIT", "BSD-3-Clause"}, "MIT AND BSD-3-Clause"},
+		{[]string{"GPL-2.0-only OR MIT", "Zlib"}, "(GPL-2.0-only OR MIT) AND Zlib"},
+	} {
+		require.Equal(t, tc.expected, joinLicenseExpressions(tc.licenses))
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// RenderTemplate executes a Go template over the document and writes
+// the result to w. The document is the data passed to the template (eg
+// {{ .Name }}) and these functions are available to it:
+//
+//	packages          all the packages in the document, sorted by name
+//	files             all the files in the document, sorted by name
+//	purl PKG          the package URL of a package, empty if it has none
+//	license ELEMENT   the concluded license or, if missing, the declared one
+//	byLicense         the packages in the document indexed by license
+//	extractedLicense  the license text of a LicenseRef-* identifier
+//	join, lower, upper, trim, replace, hasPrefix, hasSuffix
+//	                  the functions from the strings package
+func (d *Document) RenderTemplate(w io.Writer, name, text string) error {
+	tmpl, err := template.New(name).Funcs(d.templateFuncs()).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+	if err := tmpl.Execute(w, d); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	return nil
+}
+
+// templateFuncs returns the functions available to document templates.
+func (d *Document) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"packages":         d.sortedPackages,
+		"files":            d.sortedFiles,
+		"purl":             templatePurl,
+		"license":          templateLicense,
+		"byLicense":        d.packagesByLicense,
+		"extractedLicense": d.extractedLicense,
+		"join":             strings.Join,
+		"lower":            strings.ToLower,
+		"upper":            strings.ToUpper,
+		"trim":             strings.TrimSpace,
+		"replace":          strings.ReplaceAll,
+		"hasPrefix":        strings.HasPrefix,
+		"hasSuffix":        strings.HasSuffix,
+	}
+}
+
+// sortedPackages returns all the packages in the document sorted by
+// name and version.
+func (d *Document) sortedPackages() []*Package {
+	packages := d.allPackages()
+	slices.SortStableFunc(packages, func(a, b *Package) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Version, b.Version))
+	})
+	return packages
+}
+
+// sortedFiles returns all the files in the document sorted by name.
+func (d *Document) sortedFiles() []*File {
+	files := []*File{}
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		if f, ok := o.(*File); ok {
+			files = append(files, f)
+		}
+		return nil
+	})
+	slices.SortStableFunc(files, func(a, b *File) int {
+		return cmp.Compare(a.FileName, b.FileName)
+	})
+	return files
+}
+
+// packagesByLicense indexes the packages in the document by their
+// license. Packages without a license are listed under NOASSERTION.
+func (d *Document) packagesByLicense() map[string][]*Package {
+	index := map[string][]*Package{}
+	for _, p := range d.sortedPackages() {
+		l := templateLicense(p)
+		if l == "" {
+			l = NOASSERTION
+		}
+		index[l] = append(index[l], p)
+	}
+	return index
+}
+
+// extractedLicense returns the text of a license not in the SPDX list
+// defined in the document.
+func (d *Document) extractedLicense(id string) string {
+	for _, l := range d.ExtractedLicenses {
+		if l.ID == id {
+			return l.Text
+		}
+	}
+	return ""
+}
+
+// templatePurl returns the package URL of a package as a string.
+func templatePurl(p *Package) string {
+	if p == nil {
+		return ""
+	}
+	if u := p.Purl(); u != nil {
+		return u.ToString()
+	}
+	return ""
+}
+
+// templateLicense returns the concluded license of a package or file,
+// falling back to the license declared in packages.
+func templateLicense(o Object) string {
+	var concluded, declared string
+	switch e := o.(type) {
+	case *Package:
+		concluded, declared = e.LicenseConcluded, e.LicenseDeclared
+	case *File:
+		concluded = e.LicenseConcluded
+	default:
+		return ""
+	}
+	for _, l := range []string{concluded, declared} {
+		if l != "" && l != NOASSERTION && l != NONE {
+			return l
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	doc := NewDocument()
+	doc.Name = "test-doc"
+	doc.ExtractedLicenses = []ExtractedLicense{{ID: "LicenseRef-Custom", Text: "Custom license text"}}
+
+	image := NewPackage()
+	image.Name = "image"
+	image.BuildID("image")
+	image.LicenseConcluded = "Apache-2.0"
+	lib := NewPackage()
+	lib.Name = "lib"
+	lib.Version = "1.0"
+	lib.BuildID("lib")
+	lib.LicenseConcluded = NOASSERTION
+	lib.LicenseDeclared = "MIT"
+	lib.ExternalRefs = []ExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: "pkg:generic/lib@1.0"}}
+	file := NewFile()
+	file.FileName = "usr/lib/lib.so"
+	file.BuildID("file")
+
+	require.NoError(t, lib.AddFile(file))
+	require.NoError(t, image.AddPackage(lib))
+	require.NoError(t, doc.AddPackage(image))
+
+	for _, tc := range []struct {
+		template  string
+		expected  string
+		shouldErr bool
+	}{
+		{"{{ .Name }}", "test-doc", false},
+		{"{{ range packages }}{{ .Name }}:{{ license . }};{{ end }}", "image:Apache-2.0;lib:MIT;", false},
+		{"{{ range packages }}[{{ purl . }}]{{ end }}", "[][pkg:generic/lib@1.0]", false},
+		{"{{ range $l, $p := byLicense }}{{ $l }}={{ len $p }} {{ end }}", "Apache-2.0=1 MIT=1 ", false},
+		{"{{ range files }}{{ .FileName }}{{ end }}", "usr/lib/lib.so", false},
+		{`{{ extractedLicense "LicenseRef-Custom" }}`, "Custom license text", false},
+		{`{{ range packages }}{{ upper .Name }}{{ end }}`, "IMAGELIB", false},
+		{"{{ .Name", "", true},
+		{"{{ .Missing }}", "", true},
+	} {
+		var buf bytes.Buffer
+		err := doc.RenderTemplate(&buf, "test", tc.template)
+		if tc.shouldErr {
+			require.Error(t, err, tc.template)
+			continue
+		}
+		require.NoError(t, err, tc.template)
+		require.Equal(t, tc.expected, buf.String(), tc.template)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+)
+
+// tlogBody is the part of the dsse and intoto entries of a Rekor log
+// recording the hash of the signed payload. sigstore-go checks the
+// signature and the key of the entries, not what was signed.
+type tlogBody struct {
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion"`
+	Spec       struct {
+		PayloadHash *tlogHash `json:"payloadHash"` // dsse
+		Content     struct {
+			PayloadHash *tlogHash `json:"payloadHash"` // intoto
+		} `json:"content"`
+	} `json:"spec"`
+}
+
+type tlogHash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// checkTlogPayload checks the canonicalized body of a log entry records
+// the hash of the payload
+func checkTlogPayload(canonicalizedBody, payload []byte) error {
+	body := tlogBody{}
+	if err := json.Unmarshal(canonicalizedBody, &body); err != nil {
+		return fmt.Errorf("decoding transparency log entry: %w", err)
+	}
+	hash := body.Spec.PayloadHash
+	if hash == nil {
+		hash = body.Spec.Content.PayloadHash
+	}
+	if hash == nil {
+		return fmt.Errorf("%s transparency log entry does not record the hash of a payload", body.Kind)
+	}
+	if !strings.EqualFold(hash.Algorithm, "sha256") {
+		return fmt.Errorf("unsupported payload hash algorithm in transparency log entry: %s", hash.Algorithm)
+	}
+	digest := sha256.Sum256(payload)
+	if !strings.EqualFold(hash.Value, hex.EncodeToString(digest[:])) {
+		return errors.New("transparency log entry records another payload")
+	}
+	return nil
+}
+
+// rekorEntries looks up the entries of the payload in a Rekor log, with
+// their signed entry timestamps and inclusion proofs
+func rekorEntries(ctx context.Context, rekorURL string, payload []byte) ([]*protorekor.TransparencyLogEntry, error) {
+	digest := sha256.Sum256(payload)
+	body, err := json.Marshal(map[string]string{"hash": "sha256:" + hex.EncodeToString(digest[:])})
+	if err != nil {
+		return nil, fmt.Errorf("encoding rekor query: %w", err)
+	}
+	uuids := []string{}
+	if err := rekorRequest(
+		ctx, http.MethodPost, strings.TrimSuffix(rekorURL, "/")+"/api/v1/index/retrieve", body, &uuids,
+	); err != nil {
+		return nil, err
+	}
+
+	entries := []*protorekor.TransparencyLogEntry{}
+	for _, uuid := range uuids {
+		res := map[string]rekorLogEntry{}
+		if err := rekorRequest(
+			ctx, http.MethodGet, strings.TrimSuffix(rekorURL, "/")+"/api/v1/log/entries/"+url.PathEscape(uuid), nil, &res,
+		); err != nil {
+			return nil, err
+		}
+		for _, e := range res {
+			entry, err := e.transparencyLogEntry()
+			if err != nil {
+				return nil, fmt.Errorf("reading rekor entry %s: %w", uuid, err)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// rekorLogEntry is an entry as returned by the Rekor API
+type rekorLogEntry struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+	Verification   struct {
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+		InclusionProof       *struct {
+			Checkpoint string   `json:"checkpoint"`
+			Hashes     []string `json:"hashes"`
+			LogIndex   int64    `json:"logIndex"`
+			RootHash   string   `json:"rootHash"`
+			TreeSize   int64    `json:"treeSize"`
+		} `json:"inclusionProof"`
+	} `json:"verification"`
+}
+
+// transparencyLogEntry converts the entry to its sigstore bundle form
+func (e *rekorLogEntry) transparencyLogEntry() (*protorekor.TransparencyLogEntry, error) {
+	canonicalizedBody, err := decodeBase64(e.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decoding body: %w", err)
+	}
+	body := tlogBody{}
+	if err := json.Unmarshal(canonicalizedBody, &body); err != nil {
+		return nil, fmt.Errorf("decoding body: %w", err)
+	}
+	logID, err := hex.DecodeString(e.LogID)
+	if err != nil {
+		return nil, fmt.Errorf("decoding log ID: %w", err)
+	}
+	set, err := decodeBase64(e.Verification.SignedEntryTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signed entry timestamp: %w", err)
+	}
+
+	entry := &protorekor.TransparencyLogEntry{
+		LogIndex:          e.LogIndex,
+		LogId:             &protocommon.LogId{KeyId: logID},
+		KindVersion:       &protorekor.KindVersion{Kind: body.Kind, Version: body.APIVersion},
+		IntegratedTime:    e.IntegratedTime,
+		CanonicalizedBody: canonicalizedBody,
+	}
+	if len(set) > 0 {
+		entry.InclusionPromise = &protorekor.InclusionPromise{SignedEntryTimestamp: set}
+	}
+	if proof := e.Verification.InclusionProof; proof != nil {
+		rootHash, err := hex.DecodeString(proof.RootHash)
+		if err != nil {
+			return nil, fmt.Errorf("decoding inclusion proof root hash: %w", err)
+		}
+		entry.InclusionProof = &protorekor.InclusionProof{
+			LogIndex:   proof.LogIndex,
+			RootHash:   rootHash,
+			TreeSize:   proof.TreeSize,
+			Checkpoint: &protorekor.Checkpoint{Envelope: proof.Checkpoint},
+		}
+		for _, h := range proof.Hashes {
+			hash, err := hex.DecodeString(h)
+			if err != nil {
+				return nil, fmt.Errorf("decoding inclusion proof hash: %w", err)
+			}
+			entry.InclusionProof.Hashes = append(entry.InclusionProof.Hashes, hash)
+		}
+	}
+	return entry, nil
+}
+
+// rekorRequest calls the Rekor API and decodes its response in v
+func rekorRequest(ctx context.Context, method, endpoint string, body []byte, v any) error {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating rekor request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("querying rekor: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("querying rekor: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding rekor response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// tomlKeyRe splits the key/value lines of a TOML table
+var tomlKeyRe = regexp.MustCompile(`^("[^"]*"|'[^']*'|[A-Za-z0-9_.-]+)\s*=\s*(.*)$`)
+
+// tomlStringRe matches the basic and literal TOML strings
+var tomlStringRe = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"|'([^']*)'`)
+
+// readTOML reads the tables of the TOML manifests bom reads. It returns
+// the raw values of the keys of each table, indexed by the table name
+// (the keys before the first table are in the "" table). Arrays and
+// inline tables spanning multiple lines are joined in a single value.
+// This is not a complete TOML parser: multiline strings and arrays of
+// tables are not supported.
+func readTOML(data []byte) (map[string]map[string]string, error) {
+	tables := map[string]map[string]string{}
+	table, key, value := "", "", ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(tomlStripComment(scanner.Text()))
+		if key != "" {
+			// Continuation of an array or inline table
+			value += " " + line
+		} else {
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(line, "[") {
+				table = strings.TrimSpace(strings.Trim(line, "[]"))
+				continue
+			}
+			m := tomlKeyRe.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			key, value = strings.Trim(m[1], `"'`), m[2]
+		}
+		if tomlNesting(value) > 0 {
+			continue
+		}
+		if tables[table] == nil {
+			tables[table] = map[string]string{}
+		}
+		tables[table][key] = strings.TrimSpace(value)
+		key, value = "", ""
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return nil, fmt.Errorf("unterminated value of key %q in table %q", key, table)
+	}
+	return tables, nil
+}
+
+// tomlStripComment removes the comment at the end of a line
+func tomlStripComment(line string) string {
+	var quote rune
+	escaped := false
+	for i, c := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && c == '\\':
+			escaped = true
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// tomlNesting returns the number of arrays and inline tables left open
+// at the end of a value
+func tomlNesting(value string) int {
+	nesting := 0
+	for _, s := range tomlStringRe.Split(value, -1) {
+		nesting += strings.Count(s, "[") + strings.Count(s, "{") -
+			strings.Count(s, "]") - strings.Count(s, "}")
+	}
+	return nesting
+}
+
+// tomlString returns the string of a raw value, or an empty string if
+// the value is not a string
+func tomlString(raw string) string {
+	if !strings.HasPrefix(raw, `"`) && !strings.HasPrefix(raw, "'") {
+		return ""
+	}
+	m := tomlStringRe.FindStringSubmatch(raw)
+	if m == nil {
+		return ""
+	}
+	if strings.HasPrefix(raw, "'") {
+		return m[2]
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(m[1])
+}
+
+// tomlInlineTableRe matches the inline tables in an array
+var tomlInlineTableRe = regexp.MustCompile(`\{[^}]*\}`)
+
+// tomlStrings returns the strings in an array, skipping those in its
+// inline tables
+func tomlStrings(raw string) []string {
+	values := []string{}
+	for _, m := range tomlStringRe.FindAllString(tomlInlineTableRe.ReplaceAllString(raw, ""), -1) {
+		values = append(values, tomlString(m))
+	}
+	return values
+}
+
+// tomlInlineString returns the string value of a key of an inline table
+func tomlInlineString(raw, key string) string {
+	re := regexp.MustCompile(`(?:^|[{,])\s*"?` + regexp.QuoteMeta(key) + `"?\s*=\s*("(?:[^"\\]|\\.)*"|'[^']*')`)
+	m := re.FindStringSubmatch(raw)
+	if m == nil {
+		return ""
+	}
+	return tomlString(m[1])
+}
+
+// tomlInline returns the fields of a table as a raw inline table
+func tomlInline(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries := make([]string, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, k+" = "+fields[k])
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTOML(t *testing.T) {
+	tables, err := readTOML([]byte(`title = "top" # comment
+[a.b]
+name = "quoted \" # not a comment"
+'literal key' = 'C:\path'
+list = [
+  "one", # first
+  { include = "skipped" },
+  'two',
+]
+inline = { version = "1.2", features = ["x"] }
+`))
+	require.NoError(t, err)
+	require.Equal(t, "top", tomlString(tables[""]["title"]))
+	require.Equal(t, `quoted " # not a comment`, tomlString(tables["a.b"]["name"]))
+	require.Equal(t, `C:\path`, tomlString(tables["a.b"]["literal key"]))
+	require.Equal(t, []string{"one", "two"}, tomlStrings(tables["a.b"]["list"]))
+	require.Equal(t, "1.2", tomlInlineString(tables["a.b"]["inline"], "version"))
+	require.Empty(t, tomlInlineString(tables["a.b"]["inline"], "features"))
+	require.Empty(t, tomlString(tables["a.b"]["inline"]))
+
+	_, err = readTOML([]byte("list = [\n\"open\"\n"))
+	require.Error(t, err)
+}
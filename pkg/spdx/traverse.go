@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	purl "github.com/package-url/packageurl-go"
+)
+
+var (
+	// ErrSkipRelationships can be returned by a WalkFunc to skip the
+	// relationships of the element being visited.
+	ErrSkipRelationships = errors.New("skip relationships")
+
+	// ErrStopWalk can be returned by a WalkFunc to stop the traversal
+	// without returning an error from Walk.
+	ErrStopWalk = errors.New("stop walk")
+)
+
+// WalkFunc is the function called for each element visited by Walk.
+// The path lists the elements traversed from the top of the document to
+// reach o, not including o itself.
+type WalkFunc func(o Object, path []Object) error
+
+// Walk traverses the document depth-first, calling fn once for each
+// element reachable from the top level packages and files. Elements are
+// visited in a stable order: top level packages sorted by ID, then top
+// level files, each followed by its relationship peers in order.
+func (d *Document) Walk(fn WalkFunc) error {
+	seen := map[Object]struct{}{}
+	var walk func(o Object, path []Object) error
+	walk = func(o Object, path []Object) error {
+		if _, ok := seen[o]; ok {
+			return nil
+		}
+		seen[o] = struct{}{}
+
+		if err := fn(o, path); err != nil {
+			if errors.Is(err, ErrSkipRelationships) {
+				return nil
+			}
+			return err
+		}
+
+		path = append(path[:len(path):len(path)], o)
+		for _, rel := range relationshipsOf(o) {
+			if rel.Peer == nil {
+				continue
+			}
+			if err := walk(rel.Peer, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, o := range d.walkRoots() {
+		if err := walk(o, []Object{}); err != nil {
+			if errors.Is(err, ErrStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// walkRoots returns the top level elements of the document sorted by
+// ID, packages first and then files.
+func (d *Document) walkRoots() []Object {
+	roots := []Object{}
+	for _, id := range slices.Sorted(maps.Keys(d.Packages)) {
+		roots = append(roots, d.Packages[id])
+	}
+	for _, id := range slices.Sorted(maps.Keys(d.Files)) {
+		roots = append(roots, d.Files[id])
+	}
+	return roots
+}
+
+// relationshipsOf returns the relationships of an element. Packages are
+// read locked while getting them, so the graph can be walked while other
+// goroutines add relationships to its packages.
+func relationshipsOf(o Object) []*Relationship {
+	if p, ok := o.(*Package); ok {
+		p.RLock()
+		defer p.RUnlock()
+	}
+	return *o.GetRelationships()
+}
+
+// FindByPurl returns all the packages in the document matching a purl
+// string. Parts missing from the spec, or set to *, match any value.
+func (d *Document) FindByPurl(spec string) ([]*Package, error) {
+	purlSpec, err := purl.FromString(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parsing purl: %w", err)
+	}
+	return d.GetPackagesByPurl(&purlSpec), nil
+}
+
+// dependencyTypes are the relationship types where the element
+// depends on its peer.
+var dependencyTypes = map[RelationshipType]struct{}{
+	DEPENDS_ON: {},
+	CONTAINS:   {},
+}
+
+// dependentTypes are the relationship types where the peer depends
+// on the element.
+var dependentTypes = map[RelationshipType]struct{}{
+	DEPENDENCY_OF:          {},
+	BUILD_DEPENDENCY_OF:    {},
+	DEV_DEPENDENCY_OF:      {},
+	OPTIONAL_DEPENDENCY_OF: {},
+	PROVIDED_DEPENDENCY_OF: {},
+	TEST_DEPENDENCY_OF:     {},
+	RUNTIME_DEPENDENCY_OF:  {},
+	CONTAINED_BY:           {},
+}
+
+// ReverseDependencies searches the graph reachable from roots for the
+// elements matching the target function and returns all elements that
+// depend on them, directly or transitively. DEPENDS_ON and CONTAINS
+// relationships (and their inverses) are considered dependencies, so the
+// packages containing a target are returned too. The targets themselves
+// are not included in the result, which is sorted by SPDX ID.
+func ReverseDependencies(roots []Object, target func(Object) bool) []Object {
+	// Index the dependents of every element in the graph
+	dependents := map[Object][]Object{}
+	targets := []Object{}
+	seen := map[Object]struct{}{}
+	var index func(o Object)
+	index = func(o Object) {
+		if _, ok := seen[o]; ok {
+			return
+		}
+		seen[o] = struct{}{}
+		if target(o) {
+			targets = append(targets, o)
+		}
+		for _, rel := range *o.GetRelationships() {
+			if rel.Peer == nil {
+				continue
+			}
+			if _, ok := dependencyTypes[rel.Type]; ok {
+				dependents[rel.Peer] = append(dependents[rel.Peer], o)
+			}
+			if _, ok := dependentTypes[rel.Type]; ok {
+				dependents[o] = append(dependents[o], rel.Peer)
+			}
+			index(rel.Peer)
+		}
+	}
+	for _, o := range roots {
+		index(o)
+	}
+
+	// Walk the index up from the targets
+	isTarget := map[Object]struct{}{}
+	for _, o := range targets {
+		isTarget[o] = struct{}{}
+	}
+	found := map[Object]struct{}{}
+	visited := map[Object]struct{}{}
+	queue := targets
+	for len(queue) > 0 {
+		o := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[o]; ok {
+			continue
+		}
+		visited[o] = struct{}{}
+		for _, dep := range dependents[o] {
+			if _, ok := isTarget[dep]; !ok {
+				found[dep] = struct{}{}
+			}
+			queue = append(queue, dep)
+		}
+	}
+
+	res := slices.Collect(maps.Keys(found))
+	slices.SortFunc(res, func(a, b Object) int {
+		return strings.Compare(a.SPDXID(), b.SPDXID())
+	})
+	return res
+}
+
+// ReverseDeps returns all the elements in the document that depend on
+// the element with the specified SPDX ID, directly or transitively.
+// See ReverseDependencies for the relationships considered.
+func (d *Document) ReverseDeps(id string) []Object {
+	return ReverseDependencies(d.walkRoots(), func(o Object) bool {
+		return o.SPDXID() == id
+	})
+}
+
+// Subgraph returns a new document with the element identified by id as
+// its only top level element. The new document shares the elements
+// with the original one, so changes to either affect both.
+func (d *Document) Subgraph(id string) (*Document, error) {
+	o := d.GetElementByID(id)
+	if o == nil {
+		return nil, fmt.Errorf("element %s not found in document", id)
+	}
+
+	sub := NewDocument()
+	sub.Version = d.Version
+	sub.DataLicense = d.DataLicense
+	sub.Name = d.Name
+	sub.Creator = d.Creator
+	sub.CreatorComment = d.CreatorComment
+	sub.LicenseListVersion = d.LicenseListVersion
+	sub.ExternalDocRefs = slices.Clone(d.ExternalDocRefs)
+	sub.ExtractedLicenses = d.ExtractedLicenses
+	if d.Namespace != "" {
+		sub.Namespace = strings.TrimSuffix(d.Namespace, "/") + "/" + id
+	}
+
+	switch e := o.(type) {
+	case *Package:
+		if err := sub.AddPackage(e); err != nil {
+			return nil, fmt.Errorf("adding package to subgraph: %w", err)
+		}
+	case *File:
+		if err := sub.AddFile(e); err != nil {
+			return nil, fmt.Errorf("adding file to subgraph: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("element %s is not a package or file", id)
+	}
+	return sub, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// traverseTestDocument returns a document with an image containing
+// a layer, the layer contains an app that depends on a library.
+func traverseTestDocument(t *testing.T) *Document {
+	doc := NewDocument()
+	doc.Name = "test"
+	doc.Namespace = "https://example.com/test"
+	pkgs := map[string]*Package{}
+	for _, name := range []string{"image", "layer", "app", "lib"} {
+		p := NewPackage()
+		p.Name = name
+		p.ID = "SPDXRef-Package-" + name
+		pkgs[name] = p
+	}
+	pkgs["lib"].ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager, Type: "purl", Locator: "pkg:golang/example.com/lib@v1.0.0",
+	}}
+	require.NoError(t, pkgs["image"].AddPackage(pkgs["layer"]))
+	require.NoError(t, pkgs["layer"].AddPackage(pkgs["app"]))
+	require.NoError(t, pkgs["app"].AddDependency(pkgs["lib"]))
+	require.NoError(t, doc.AddPackage(pkgs["image"]))
+	return doc
+}
+
+func TestWalk(t *testing.T) {
+	doc := traverseTestDocument(t)
+
+	visited := []string{}
+	require.NoError(t, doc.Walk(func(o Object, path []Object) error {
+		visited = append(visited, o.SPDXID())
+		if o.SPDXID() == "SPDXRef-Package-lib" {
+			require.Len(t, path, 3)
+		}
+		return nil
+	}))
+	require.Equal(t, []string{
+		"SPDXRef-Package-image", "SPDXRef-Package-layer", "SPDXRef-Package-app", "SPDXRef-Package-lib",
+	}, visited)
+
+	// Skipping relationships prunes the walk
+	visited = []string{}
+	require.NoError(t, doc.Walk(func(o Object, _ []Object) error {
+		visited = append(visited, o.SPDXID())
+		if o.SPDXID() == "SPDXRef-Package-layer" {
+			return ErrSkipRelationships
+		}
+		return nil
+	}))
+	require.Len(t, visited, 2)
+
+	// Stopping the walk does not return an error
+	visited = []string{}
+	require.NoError(t, doc.Walk(func(o Object, _ []Object) error {
+		visited = append(visited, o.SPDXID())
+		return ErrStopWalk
+	}))
+	require.Len(t, visited, 1)
+}
+
+func TestFindByPurl(t *testing.T) {
+	doc := traverseTestDocument(t)
+	pkgs, err := doc.FindByPurl("pkg:golang/example.com/lib")
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, "lib", pkgs[0].Name)
+
+	_, err = doc.FindByPurl("not a purl")
+	require.Error(t, err)
+}
+
+func TestReverseDeps(t *testing.T) {
+	doc := traverseTestDocument(t)
+	ids := []string{}
+	for _, o := range doc.ReverseDeps("SPDXRef-Package-lib") {
+		ids = append(ids, o.SPDXID())
+	}
+	require.Equal(t, []string{
+		"SPDXRef-Package-app", "SPDXRef-Package-image", "SPDXRef-Package-layer",
+	}, ids)
+	require.Empty(t, doc.ReverseDeps("SPDXRef-Package-image"))
+}
+
+func TestSubgraph(t *testing.T) {
+	doc := traverseTestDocument(t)
+	sub, err := doc.Subgraph("SPDXRef-Package-app")
+	require.NoError(t, err)
+	require.Len(t, sub.Packages, 1)
+	require.Contains(t, sub.Packages, "SPDXRef-Package-app")
+	require.Equal(t, "https://example.com/test/SPDXRef-Package-app", sub.Namespace)
+	require.NotNil(t, sub.GetElementByID("SPDXRef-Package-lib"))
+	require.Nil(t, sub.GetElementByID("SPDXRef-Package-image"))
+
+	_, err = doc.Subgraph("SPDXRef-Package-nope")
+	require.Error(t, err)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// trivyReport is the part of the trivy JSON report read by bom.
+type trivyReport struct {
+	ArtifactName string `json:"ArtifactName"`
+	ArtifactType string `json:"ArtifactType"`
+	Metadata     struct {
+		ImageID     string   `json:"ImageID"`
+		RepoDigests []string `json:"RepoDigests"`
+	} `json:"Metadata"`
+	Results []trivyResult `json:"Results"`
+}
+
+type trivyResult struct {
+	Target          string               `json:"Target"`
+	Class           string               `json:"Class"`
+	Type            string               `json:"Type"`
+	Packages        []trivyPackage       `json:"Packages"`
+	Vulnerabilities []trivyVulnerability `json:"Vulnerabilities"`
+}
+
+type trivyPackage struct {
+	ID         string `json:"ID"`
+	Name       string `json:"Name"`
+	Identifier struct {
+		PURL string `json:"PURL"`
+	} `json:"Identifier"`
+	Version   string   `json:"Version"`
+	Release   string   `json:"Release"`
+	Epoch     int      `json:"Epoch"`
+	Licenses  []string `json:"Licenses"`
+	DependsOn []string `json:"DependsOn"`
+}
+
+type trivyVulnerability struct {
+	VulnerabilityID string `json:"VulnerabilityID"`
+	PkgID           string `json:"PkgID"`
+	PkgName         string `json:"PkgName"`
+	PrimaryURL      string `json:"PrimaryURL"`
+}
+
+// ImportTrivyJSON converts a trivy JSON report (trivy --format json) to
+// an SPDX document. The scanned artifact becomes the top level package
+// of the document, containing a package for each target trivy scanned
+// which in turn contains the packages found in it. Vulnerabilities in
+// the report are recorded as advisory external references of the
+// affected packages.
+func ImportTrivyJSON(r io.Reader) (*Document, error) {
+	report := trivyReport{}
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("parsing trivy json: %w", err)
+	}
+
+	doc := NewDocument()
+	doc.Name = report.ArtifactName
+	doc.Namespace = "https://spdx.org/spdxdocs/bom-trivy-" + uuid.NewString()
+	doc.Creator.Tool = append(doc.Creator.Tool, "trivy")
+
+	root := NewPackage()
+	root.Name = report.ArtifactName
+	root.DownloadLocation = NOASSERTION
+	root.Comment = "Imported from the trivy scan of " + report.ArtifactName
+	if report.ArtifactType == "container_image" {
+		root.PrimaryPurpose = "CONTAINER"
+	}
+	root.BuildID("trivy", report.ArtifactName)
+	for _, rd := range report.Metadata.RepoDigests {
+		_, digest, ok := strings.Cut(rd, "@")
+		if !ok {
+			continue
+		}
+		checksums, err := digestChecksums(digest)
+		if err != nil {
+			logrus.Warnf("Not recording digest of %s: %v", report.ArtifactName, err)
+			continue
+		}
+		root.Checksum = checksums
+		break
+	}
+
+	for i := range report.Results {
+		target, err := report.Results[i].toSPDXPackage()
+		if err != nil {
+			return nil, fmt.Errorf("converting trivy target %s: %w", report.Results[i].Target, err)
+		}
+		if err := root.AddPackage(target); err != nil {
+			return nil, fmt.Errorf("adding trivy target %s: %w", report.Results[i].Target, err)
+		}
+	}
+
+	if err := doc.AddPackage(root); err != nil {
+		return nil, fmt.Errorf("adding scanned artifact package: %w", err)
+	}
+	return doc, nil
+}
+
+// toSPDXPackage returns a package describing the trivy target that
+// contains the packages found in it.
+func (res *trivyResult) toSPDXPackage() (*Package, error) {
+	target := NewPackage()
+	target.Name = res.Target
+	target.DownloadLocation = NOASSERTION
+	target.Comment = fmt.Sprintf("Trivy %s target of type %s", res.Class, res.Type)
+	target.BuildID("trivy", res.Class, res.Target)
+
+	converted := make([]*Package, len(res.Packages))
+	packages := map[string]*Package{}
+	for i := range res.Packages {
+		p := res.Packages[i].toSPDXPackage(res.Type)
+		if err := target.AddPackage(p); err != nil {
+			return nil, fmt.Errorf("adding package %s: %w", res.Packages[i].Name, err)
+		}
+		converted[i] = p
+		if res.Packages[i].ID != "" {
+			packages[res.Packages[i].ID] = p
+		}
+		if _, ok := packages[res.Packages[i].Name]; !ok {
+			packages[res.Packages[i].Name] = p
+		}
+	}
+
+	for i := range res.Packages {
+		for _, id := range res.Packages[i].DependsOn {
+			dep, ok := packages[id]
+			if !ok {
+				logrus.Warnf("Dependency %s of %s not found in trivy target", id, res.Packages[i].Name)
+				continue
+			}
+			if err := converted[i].AddDependency(dep); err != nil {
+				return nil, fmt.Errorf("adding dependency %s: %w", id, err)
+			}
+		}
+	}
+
+	// Vulnerabilities reference their package by ID, older trivy
+	// versions only record the package name
+	for _, v := range res.Vulnerabilities {
+		key := v.PkgID
+		if key == "" {
+			key = v.PkgName
+		}
+		p, ok := packages[key]
+		if !ok || v.PrimaryURL == "" {
+			continue
+		}
+		p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+			Category: CatSecurity,
+			Type:     "advisory",
+			Locator:  v.PrimaryURL,
+		})
+	}
+	return target, nil
+}
+
+// toSPDXPackage converts a package found by trivy to an SPDX package.
+func (tp *trivyPackage) toSPDXPackage(pkgType string) *Package {
+	p := NewPackage()
+	p.Options().Prefix = pkgType
+	p.Name = tp.Name
+	p.Version = tp.version()
+	p.DownloadLocation = NOASSERTION
+	p.BuildID(tp.Name, p.Version, tp.ID)
+	if len(tp.Licenses) > 0 {
+		p.LicenseDeclared = joinLicenseExpressions(tp.Licenses)
+	}
+	if tp.Identifier.PURL != "" {
+		p.ExternalRefs = append(p.ExternalRefs, ExternalRef{
+			Category: CatPackageManager,
+			Type:     "purl",
+			Locator:  tp.Identifier.PURL,
+		})
+	}
+	return p
+}
+
+// version returns the full version of the package, trivy records the
+// epoch and release of OS packages separately.
+func (tp *trivyPackage) version() string {
+	v := tp.Version
+	if tp.Release != "" {
+		v += "-" + tp.Release
+	}
+	if tp.Epoch != 0 {
+		v = fmt.Sprintf("%d:%s", tp.Epoch, v)
+	}
+	return v
+}
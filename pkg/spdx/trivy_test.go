@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTrivyJSON(t *testing.T) {
+	f, err := os.Open("testdata/alpine.trivy.json")
+	require.NoError(t, err)
+	defer f.Close()
+
+	doc, err := ImportTrivyJSON(f)
+	require.NoError(t, err)
+	require.Equal(t, "alpine:3.19", doc.Name)
+	require.Len(t, doc.Packages, 1)
+
+	var root *Package
+	for _, p := range doc.Packages {
+		root = p
+	}
+	require.Equal(t, "CONTAINER", root.PrimaryPurpose)
+	require.Equal(t, map[string]string{
+		"SHA256": "c5b1261d6d3e43071626931fc004f70149baeba2c8ec672bd4f27761f8e1ad6b",
+	}, root.Checksum)
+
+	targets := relatedByName(t, root)
+	require.Len(t, targets, 2)
+	require.Contains(t, targets, "Python")
+
+	osPkgs, ok := targets["alpine:3.19 (alpine 3.19.1)"].Peer.(*Package)
+	require.True(t, ok)
+	pkgs := relatedByName(t, osPkgs)
+	require.Len(t, pkgs, 2)
+
+	busybox, ok := pkgs["busybox"].Peer.(*Package)
+	require.True(t, ok)
+	require.Equal(t, "GPL-2.0-only", busybox.LicenseDeclared)
+	require.Equal(t, "pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64&distro=3.19.1", busybox.ExternalRefs[0].Locator)
+	require.Contains(t, busybox.ExternalRefs, ExternalRef{
+		Category: CatSecurity,
+		Type:     "advisory",
+		Locator:  "https://avd.aquasec.com/nvd/cve-2023-42363",
+	})
+
+	deps := relatedByName(t, busybox)
+	require.Len(t, deps, 1)
+	require.Equal(t, DEPENDS_ON, deps["musl"].Type)
+}
+
+func TestTrivyPackageVersion(t *testing.T) {
+	for _, tc := range []struct {
+		pkg      trivyPackage
+		expected string
+	}{
+		{trivyPackage{Version: "1.36.1-r15"}, "1.36.1-r15"},
+		{trivyPackage{Version: "1.1.1k", Release: "4.el8"}, "1.1.1k-4.el8"},
+		{trivyPackage{Version: "1.1.1k", Release: "4.el8", Epoch: 1}, "1:1.1.1k-4.el8"},
+	} {
+		require.Equal(t, tc.expected, tc.pkg.version())
+	}
+}
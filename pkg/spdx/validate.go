@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/release-utils/util"
+)
+
+// remoteImageDigest returns the digest of an image reference as
+// reported by its registry.
+var remoteImageDigest = crane.Digest
+
+// allPackages returns all the packages in the document, including those
+// only reachable through relationships.
+func (d *Document) allPackages() []*Package {
+	packages := []*Package{}
+	d.Walk(func(o Object, _ []Object) error { //nolint:errcheck // The walk func never fails
+		if p, ok := o.(*Package); ok {
+			packages = append(packages, p)
+		}
+		return nil
+	})
+	return packages
+}
+
+// compareChecksums checks the hashes recorded in the document against
+// those computed from the artifact. It returns the number of matching
+// hashes and the algorithms whose values differ.
+func compareChecksums(document, artifact map[string]string) (matched int, failed []string) {
+	failed = []string{}
+	for algo, documentHashValue := range document {
+		artifactHashValue, ok := artifact[algo]
+		if !ok {
+			logrus.Warnf("document has hash in %s, which is not supported yet", algo)
+			continue
+		}
+		if artifactHashValue == documentHashValue {
+			matched++
+		} else {
+			failed = append(failed, algo)
+		}
+	}
+	sort.Strings(failed)
+	return matched, failed
+}
+
+// ValidateArchives checks archive files on disk against the packages
+// describing them in the document. Packages are matched by the archive
+// file name and verified using their recorded checksums.
+func (d *Document) ValidateArchives(archivePaths []string) ([]ValidationResults, error) {
+	results := []ValidationResults{}
+	if len(archivePaths) == 0 {
+		logrus.Warn("ValidateArchives called with 0 paths")
+	}
+
+	packages := d.allPackages()
+	var e error
+	for _, path := range archivePaths {
+		res := ValidationResults{
+			FileName:         path,
+			FailedAlgorithms: []string{},
+		}
+		if !util.Exists(path) {
+			res.Message = "File not found"
+			results = append(results, res)
+			e = errors.New("some archives were not found")
+			continue
+		}
+
+		var pkg *Package
+		for _, p := range packages {
+			if p.FileName != "" && filepath.Base(p.FileName) == filepath.Base(path) {
+				pkg = p
+				break
+			}
+		}
+		if pkg == nil {
+			res.Message = "archive not found in document packages"
+			results = append(results, res)
+			continue
+		}
+		res.PackageID = pkg.SPDXID()
+
+		if len(pkg.Checksum) == 0 {
+			res.Message = "no hashes found for package in SBOM"
+			results = append(results, res)
+			continue
+		}
+
+		artifact := &Entity{}
+		if err := artifact.ReadChecksums(path); err != nil {
+			return nil, fmt.Errorf("hashing archive %s: %w", path, err)
+		}
+
+		matched, failed := compareChecksums(pkg.Checksum, artifact.Checksum)
+		switch {
+		case len(failed) > 0:
+			res.Message = MessageHashMismatch
+			res.FailedAlgorithms = failed
+		case matched == 0:
+			res.Message = "unable to find compatible algorithm in document"
+		default:
+			res.Success = true
+			res.Message = "Archive validated successfully"
+		}
+		results = append(results, res)
+	}
+	return results, e
+}
+
+// imageDigestFromPackage returns the repository and digest of the image
+// described by a package, read from its oci purl. Returns empty strings
+// if the package does not describe an image.
+func imageDigestFromPackage(p *Package) (repo, digest string) {
+	pkgPurl := p.Purl()
+	if pkgPurl == nil || pkgPurl.Type != "oci" || pkgPurl.Version == "" {
+		return "", ""
+	}
+	repo = pkgPurl.Name
+	if url := pkgPurl.Qualifiers.Map()["repository_url"]; url != "" {
+		// The repository url includes the image name, older versions of
+		// bom recorded only its parent
+		repo = strings.TrimSuffix(url, "/")
+		if path.Base(repo) != pkgPurl.Name {
+			repo += "/" + pkgPurl.Name
+		}
+	}
+	return repo, pkgPurl.Version
+}
+
+// ValidateImages checks image references against the packages describing
+// them in the document. The digest of each image is fetched from its
+// registry and compared to the digests recorded in the image packages.
+func (d *Document) ValidateImages(refs []string) ([]ValidationResults, error) {
+	results := []ValidationResults{}
+	if len(refs) == 0 {
+		logrus.Warn("ValidateImages called with 0 references")
+	}
+
+	packages := d.allPackages()
+	var e error
+	for _, refString := range refs {
+		res := ValidationResults{
+			FileName:         refString,
+			FailedAlgorithms: []string{},
+		}
+		ref, err := name.ParseReference(refString)
+		if err != nil {
+			return nil, fmt.Errorf("parsing image reference %s: %w", refString, err)
+		}
+
+		digest, err := remoteImageDigest(refString)
+		if err != nil {
+			res.Message = fmt.Sprintf("unable to get digest from registry: %v", err)
+			results = append(results, res)
+			e = errors.New("some images could not be fetched")
+			continue
+		}
+
+		res.Message = "image not found in document packages"
+		for _, p := range packages {
+			repo, pkgDigest := imageDigestFromPackage(p)
+			if repo != ref.Context().Name() {
+				continue
+			}
+			res.PackageID = p.SPDXID()
+			if pkgDigest == digest {
+				res.Success = true
+				res.Message = "Image validated successfully"
+				break
+			}
+			res.Message = MessageHashMismatch
+			res.FailedAlgorithms = []string{"SHA256"}
+		}
+		if res.Success {
+			res.FailedAlgorithms = []string{}
+		}
+		results = append(results, res)
+	}
+	return results, e
+}
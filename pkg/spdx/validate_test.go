@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateArchives(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "test.tar.gz")
+	require.NoError(t, os.WriteFile(archive, []byte("abc"), os.FileMode(0o644)))
+	missing := filepath.Join(dir, "other.tar.gz")
+	require.NoError(t, os.WriteFile(missing, []byte("abc"), os.FileMode(0o644)))
+
+	doc := NewDocument()
+	pkg := NewPackage()
+	pkg.Name = "test"
+	pkg.FileName = "test.tar.gz"
+	pkg.Checksum = map[string]string{
+		"SHA256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+	}
+	require.NoError(t, doc.AddPackage(pkg))
+
+	res, err := doc.ValidateArchives([]string{archive, missing})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.True(t, res[0].Success)
+	require.Equal(t, pkg.SPDXID(), res[0].PackageID)
+	require.False(t, res[1].Success)
+	require.Empty(t, res[1].PackageID)
+
+	pkg.Checksum["SHA256"] = "0000"
+	res, err = doc.ValidateArchives([]string{archive})
+	require.NoError(t, err)
+	require.False(t, res[0].Success)
+	require.Equal(t, MessageHashMismatch, res[0].Message)
+	require.Equal(t, []string{"SHA256"}, res[0].FailedAlgorithms)
+
+	_, err = doc.ValidateArchives([]string{filepath.Join(dir, "notfound.tar")})
+	require.Error(t, err)
+}
+
+func TestValidateImages(t *testing.T) {
+	const digest = "sha256:ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	defer func(fn func(string, ...crane.Option) (string, error)) { remoteImageDigest = fn }(remoteImageDigest)
+	remoteImageDigest = func(ref string, _ ...crane.Option) (string, error) {
+		if ref == "registry.k8s.io/unreachable:v1" {
+			return "", errors.New("connection refused")
+		}
+		return digest, nil
+	}
+
+	doc := NewDocument()
+	pkg := NewPackage()
+	pkg.Name = "registry.k8s.io/pause@" + digest
+	pkg.ExternalRefs = []ExternalRef{{
+		Category: CatPackageManager,
+		Type:     "purl",
+		Locator:  "pkg:oci/pause@" + digest + "?repository_url=registry.k8s.io",
+	}}
+	require.NoError(t, doc.AddPackage(pkg))
+
+	res, err := doc.ValidateImages([]string{"registry.k8s.io/pause:3.9", "registry.k8s.io/kube-proxy:v1.30.0"})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.True(t, res[0].Success)
+	require.Equal(t, pkg.SPDXID(), res[0].PackageID)
+	require.False(t, res[1].Success)
+
+	// The repository url may include the image name
+	pkg.ExternalRefs[0].Locator = "pkg:oci/pause@" + digest + "?repository_url=registry.k8s.io%2Fpause&tag=3.9"
+	res, err = doc.ValidateImages([]string{"registry.k8s.io/pause:3.9"})
+	require.NoError(t, err)
+	require.True(t, res[0].Success)
+
+	pkg.ExternalRefs[0].Locator = "pkg:oci/pause@sha256:0000?repository_url=registry.k8s.io"
+	res, err = doc.ValidateImages([]string{"registry.k8s.io/pause:3.9"})
+	require.NoError(t, err)
+	require.False(t, res[0].Success)
+	require.Equal(t, MessageHashMismatch, res[0].Message)
+
+	_, err = doc.ValidateImages([]string{"registry.k8s.io/unreachable:v1"})
+	require.Error(t, err)
+}
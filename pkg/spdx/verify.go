@@ -0,0 +1,491 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultRekorURL is the public instance of the Rekor transparency log
+	DefaultRekorURL = "https://rekor.sigstore.dev"
+
+	inTotoPayloadType = "application/vnd.in-toto+json"
+
+	// Media types of the bundles wrapping bare envelopes. Log entries
+	// need an inclusion proof from v0.2 on, older ones only have a
+	// signed entry timestamp.
+	bundleMediaType    = "application/vnd.dev.sigstore.bundle.v0.3+json"
+	bundleMediaTypeV01 = "application/vnd.dev.sigstore.bundle+json;version=0.1"
+)
+
+// VerifyOptions control the verification of signed documents. Documents
+// wrapped in a DSSE envelope or a sigstore bundle (as written by cosign
+// attest-blob and sign-blob --new-bundle-format) are only read if their
+// signature checks out.
+type VerifyOptions struct {
+	// KeyPath is a PEM public key that must have signed the envelope
+	KeyPath string
+
+	// CertificateIdentity and CertificateOIDCIssuer must match the
+	// signing certificate of keyless signatures
+	CertificateIdentity   string
+	CertificateOIDCIssuer string
+
+	// TrustedRoot is a sigstore trusted_root.json with the certificate
+	// authorities, transparency logs and timestamp authorities to trust.
+	// Defaults to the root of the public sigstore instance, fetched and
+	// cached with TUF.
+	TrustedRoot string
+
+	// RekorURL is a transparency log to look up the entries of envelopes
+	// that carry none. The entry must be signed by a log of the trusted
+	// root and record the signature and the payload of the envelope. It
+	// is only checked on top of KeyPath or CertificateIdentity.
+	RekorURL string
+}
+
+// Enabled returns true if any verification is configured
+func (o *VerifyOptions) Enabled() bool {
+	return o != nil && (o.KeyPath != "" || o.CertificateIdentity != "" ||
+		o.CertificateOIDCIssuer != "" || o.RekorURL != "")
+}
+
+// validate checks the options define who must have signed the document.
+// A transparency log entry is not a signature check by itself: anyone
+// can log any payload.
+func (o *VerifyOptions) validate() error {
+	keyless := o.CertificateIdentity != "" || o.CertificateOIDCIssuer != ""
+	switch {
+	case o.RekorURL != "" && o.KeyPath == "" && !keyless:
+		return errors.New(
+			"the transparency log is only checked on top of a signature, set a key or a certificate identity",
+		)
+	case o.KeyPath != "" && keyless:
+		return errors.New("documents are signed with a key or keyless, set either a key or a certificate identity")
+	case keyless && (o.CertificateIdentity == "" || o.CertificateOIDCIssuer == ""):
+		return errors.New("keyless signatures are checked against both a certificate identity and its OIDC issuer")
+	}
+	return nil
+}
+
+// keyless returns true if the options check a signing certificate
+func (o *VerifyOptions) keyless() bool {
+	return o.CertificateIdentity != ""
+}
+
+// trustedMaterial returns the trusted root, if needed to check
+// certificates or log entries, and the public key to verify with
+func (o *VerifyOptions) trustedMaterial(needRoot bool) (root.TrustedMaterial, error) {
+	material := root.TrustedMaterialCollection{}
+	if needRoot || o.TrustedRoot != "" {
+		var (
+			trustedRoot *root.TrustedRoot
+			err         error
+		)
+		if o.TrustedRoot != "" {
+			trustedRoot, err = root.NewTrustedRootFromPath(o.TrustedRoot)
+		} else {
+			trustedRoot, err = root.FetchTrustedRoot()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("loading sigstore trusted root: %w", err)
+		}
+		material = append(material, trustedRoot)
+	}
+
+	if o.KeyPath != "" {
+		data, err := os.ReadFile(o.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading public key: %w", err)
+		}
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing public key %s: %w", o.KeyPath, err)
+		}
+		verifier, err := signature.LoadDefaultVerifier(pub)
+		if err != nil {
+			return nil, fmt.Errorf("loading public key %s: %w", o.KeyPath, err)
+		}
+		key := root.NewExpiringKey(verifier, time.Time{}, time.Time{})
+		material = append(material, root.NewTrustedPublicKeyMaterial(
+			func(string) (root.TimeConstrainedVerifier, error) { return key, nil },
+		))
+	}
+	return material, nil
+}
+
+// policy returns who must have signed the document
+func (o *VerifyOptions) policy() (verify.PolicyBuilder, error) {
+	// The signed payload is the document, there is no separate artifact
+	if !o.keyless() {
+		return verify.NewPolicy(verify.WithoutArtifactUnsafe(), verify.WithKey()), nil
+	}
+	identity, err := verify.NewShortCertificateIdentity(o.CertificateOIDCIssuer, "", o.CertificateIdentity, "")
+	if err != nil {
+		return verify.PolicyBuilder{}, fmt.Errorf("building certificate identity: %w", err)
+	}
+	return verify.NewPolicy(verify.WithoutArtifactUnsafe(), verify.WithCertificateIdentity(identity)), nil
+}
+
+// verifierOptions returns what the verifier must check besides the
+// signature. Short-lived certificates are checked at the time the log
+// or a timestamp authority saw the signature.
+func (o *VerifyOptions) verifierOptions(logged bool, trusted root.TrustedMaterial) []verify.VerifierOption {
+	opts := []verify.VerifierOption{}
+	if logged {
+		opts = append(opts, verify.WithTransparencyLog(1))
+	}
+	switch {
+	case o.keyless():
+		opts = append(opts, verify.WithObserverTimestamps(1))
+		if len(trusted.CTLogs()) > 0 {
+			opts = append(opts, verify.WithSignedCertificateTimestamps(1))
+		}
+	case logged:
+		opts = append(opts, verify.WithIntegratedTimestamps(1))
+	default:
+		opts = append(opts, verify.WithCurrentTime())
+	}
+	return opts
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// toProto converts the envelope to its sigstore bundle form
+func (e *dsseEnvelope) toProto() (*protodsse.Envelope, error) {
+	payload, err := decodeBase64(e.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("decoding envelope payload: %w", err)
+	}
+	envelope := &protodsse.Envelope{PayloadType: e.PayloadType, Payload: payload}
+	for _, s := range e.Signatures {
+		sig, err := decodeBase64(s.Sig)
+		if err != nil {
+			return nil, fmt.Errorf("decoding envelope signature: %w", err)
+		}
+		envelope.Signatures = append(envelope.Signatures, &protodsse.Signature{Sig: sig, Keyid: s.KeyID})
+	}
+	return envelope, nil
+}
+
+// signedDocument is a document read from an envelope
+type signedDocument struct {
+	// bundle has the envelope and the material to verify it. Bare
+	// envelopes are wrapped in a bundle signed by a public key.
+	bundle *protobundle.Bundle
+
+	// bare is true if the document was not in a sigstore bundle
+	bare bool
+}
+
+// envelope returns the DSSE envelope of the document
+func (sd *signedDocument) envelope() *protodsse.Envelope {
+	return sd.bundle.GetDsseEnvelope()
+}
+
+// looksSigned checks the beginning of a file for the fields of DSSE
+// envelopes and sigstore bundles
+func looksSigned(f *os.File) (bool, error) {
+	head := make([]byte, 4096)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("reading document: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, fmt.Errorf("rewinding file pointer: %w", err)
+	}
+	head = head[:n]
+	return bytes.Contains(head, []byte(`"payloadType"`)) ||
+		bytes.Contains(head, []byte(`"dsseEnvelope"`)), nil
+}
+
+// parseSignedDocument reads a DSSE envelope or a sigstore bundle
+func parseSignedDocument(data []byte) (*signedDocument, error) {
+	probe := struct {
+		DSSEEnvelope json.RawMessage `json:"dsseEnvelope"`
+	}{}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("decoding signed document: %w", err)
+	}
+
+	sd := &signedDocument{bundle: &protobundle.Bundle{}}
+	if probe.DSSEEnvelope != nil {
+		if err := protojson.Unmarshal(data, sd.bundle); err != nil {
+			return nil, fmt.Errorf("decoding sigstore bundle: %w", err)
+		}
+	} else {
+		bare := &dsseEnvelope{}
+		if err := json.Unmarshal(data, bare); err != nil {
+			return nil, fmt.Errorf("decoding DSSE envelope: %w", err)
+		}
+		envelope, err := bare.toProto()
+		if err != nil {
+			return nil, err
+		}
+		sd.bare = true
+		sd.bundle = &protobundle.Bundle{
+			MediaType: bundleMediaType,
+			VerificationMaterial: &protobundle.VerificationMaterial{
+				Content: &protobundle.VerificationMaterial_PublicKey{
+					PublicKey: &protocommon.PublicKeyIdentifier{},
+				},
+			},
+			Content: &protobundle.Bundle_DsseEnvelope{DsseEnvelope: envelope},
+		}
+	}
+
+	if env := sd.envelope(); env == nil || env.PayloadType == "" || len(env.Payload) == 0 {
+		return nil, errors.New("signed document has no payload")
+	}
+	return sd, nil
+}
+
+// document returns the SBOM in the payload. In-toto statements carry it
+// as their predicate, as a JSON document or a string.
+func (sd *signedDocument) document() ([]byte, error) {
+	env := sd.envelope()
+	if env.PayloadType != inTotoPayloadType {
+		return env.Payload, nil
+	}
+	statement := struct {
+		PredicateType string          `json:"predicateType"`
+		Predicate     json.RawMessage `json:"predicate"`
+	}{}
+	if err := json.Unmarshal(env.Payload, &statement); err != nil {
+		return nil, fmt.Errorf("decoding in-toto statement: %w", err)
+	}
+	if !strings.Contains(strings.ToLower(statement.PredicateType), "spdx") {
+		return nil, fmt.Errorf("statement predicate is %s, not an SPDX document", statement.PredicateType)
+	}
+
+	var text string
+	if err := json.Unmarshal(statement.Predicate, &text); err == nil {
+		return []byte(text), nil
+	}
+	// cosign wraps tag-value documents in a Data field
+	wrapped := struct {
+		Data string `json:"Data"`
+	}{}
+	if err := json.Unmarshal(statement.Predicate, &wrapped); err == nil && wrapped.Data != "" {
+		return []byte(wrapped.Data), nil
+	}
+	return statement.Predicate, nil
+}
+
+// verify checks the envelope against the options with sigstore-go. On
+// top of its checks, the log entries must record the hash of the payload.
+func (sd *signedDocument) verify(ctx context.Context, opts *VerifyOptions) error {
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	material := sd.bundle.GetVerificationMaterial()
+	if opts.keyless() && material.GetCertificate() == nil && material.GetX509CertificateChain() == nil {
+		return errors.New("document has no signing certificate to check its identity")
+	}
+
+	candidates, err := sd.candidates(ctx, opts)
+	if err != nil {
+		return err
+	}
+	logged := opts.RekorURL != "" || len(material.GetTlogEntries()) > 0
+	trusted, err := opts.trustedMaterial(opts.keyless() || logged)
+	if err != nil {
+		return err
+	}
+	verifier, err := verify.NewSignedEntityVerifier(trusted, opts.verifierOptions(logged, trusted)...)
+	if err != nil {
+		return fmt.Errorf("creating signature verifier: %w", err)
+	}
+	policy, err := opts.policy()
+	if err != nil {
+		return err
+	}
+
+	for i, b := range candidates {
+		_, err := verifier.Verify(b, policy)
+		if err == nil {
+			err = checkTlogPayloads(b, sd.envelope().Payload)
+		}
+		if err != nil {
+			if i < len(candidates)-1 {
+				logrus.Debugf("Trying the next signature or log entry: %v", err)
+				continue
+			}
+			return err
+		}
+
+		if opts.keyless() {
+			logrus.Infof("Document signature verified for %s (issuer: %s)", opts.CertificateIdentity, opts.CertificateOIDCIssuer)
+		} else {
+			logrus.Infof("Document signature verified with %s", opts.KeyPath)
+		}
+		if logged {
+			logrus.Info("Document signature is recorded in the transparency log")
+		}
+		return nil
+	}
+	return errors.New("envelope has no signatures")
+}
+
+// candidates returns the bundles to try, sigstore-go expects a single
+// signature and log entries that all record it. There is one bundle per
+// envelope signature and per entry looked up in the log.
+func (sd *signedDocument) candidates(ctx context.Context, opts *VerifyOptions) ([]*bundle.Bundle, error) {
+	entrySets := [][]*protorekor.TransparencyLogEntry{sd.bundle.GetVerificationMaterial().GetTlogEntries()}
+	lookedUp := opts.RekorURL != "" && len(entrySets[0]) == 0
+	if lookedUp {
+		entries, err := rekorEntries(ctx, opts.RekorURL, sd.envelope().Payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("the signed document is not recorded in %s", opts.RekorURL)
+		}
+		entrySets = nil
+		for _, e := range entries {
+			entrySets = append(entrySets, []*protorekor.TransparencyLogEntry{e})
+		}
+	}
+
+	candidates := []*bundle.Bundle{}
+	for _, sig := range sd.envelope().Signatures {
+		for _, entries := range entrySets {
+			pb, ok := proto.Clone(sd.bundle).(*protobundle.Bundle)
+			if !ok {
+				return nil, errors.New("copying sigstore bundle")
+			}
+			pb.GetDsseEnvelope().Signatures = []*protodsse.Signature{sig}
+			pb.VerificationMaterial.TlogEntries = entries
+			if lookedUp && sd.bare {
+				pb.MediaType = bundleMediaType
+				for _, e := range entries {
+					if e.GetInclusionProof() == nil {
+						pb.MediaType = bundleMediaTypeV01
+					}
+				}
+			}
+			b, err := bundle.NewBundle(pb)
+			if err != nil {
+				return nil, fmt.Errorf("reading sigstore bundle: %w", err)
+			}
+			candidates = append(candidates, b)
+		}
+	}
+	return candidates, nil
+}
+
+// checkTlogPayloads checks the log entries of the bundle record the
+// payload, sigstore-go only matches their signature and key
+func checkTlogPayloads(b *bundle.Bundle, payload []byte) error {
+	for _, entry := range b.GetVerificationMaterial().GetTlogEntries() {
+		if err := checkTlogPayload(entry.GetCanonicalizedBody(), payload); err != nil {
+			return fmt.Errorf("checking transparency log entry %d: %w", entry.GetLogIndex(), err)
+		}
+	}
+	return nil
+}
+
+// decodeBase64 decodes the standard or URL base64 encodings DSSE
+// implementations use
+func decodeBase64(s string) ([]byte, error) {
+	if data, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return data, nil
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// unwrapSignedDocument returns a temporary file with the document signed
+// in the envelope read from f, after verifying it. Files that are not
+// signed return nil, or an error if the options require a signature.
+func unwrapSignedDocument(f *os.File, opts *VerifyOptions) (*os.File, error) {
+	signed, err := looksSigned(f)
+	if err != nil {
+		return nil, err
+	}
+	if !signed {
+		if opts.Enabled() {
+			return nil, errors.New("document is not signed, expected a DSSE envelope or a sigstore bundle")
+		}
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading signed document: %w", err)
+	}
+	sd, err := parseSignedDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Enabled() {
+		if err := sd.verify(context.Background(), opts); err != nil {
+			return nil, fmt.Errorf("verifying document signature: %w", err)
+		}
+	} else {
+		logrus.Warn("Reading a signed document without verifying its signature")
+	}
+
+	doc, err := sd.document()
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp("", "signed-sbom-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp file for signed document: %w", err)
+	}
+	if _, err := tmp.Write(doc); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("writing signed document: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, fmt.Errorf("seeking to temp file start: %w", err)
+	}
+	return tmp, nil
+}
@@ -0,0 +1,416 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/testing/ca"
+	"github.com/sigstore/sigstore-go/pkg/tlog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const testSignedSBOM = `{"spdxVersion":"SPDX-2.3","SPDXID":"SPDXRef-DOCUMENT","name":"signed"}`
+
+// testSignedStatement returns an in-toto statement with the SBOM as predicate
+func testSignedStatement(t *testing.T) []byte {
+	t.Helper()
+	statement, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://spdx.dev/Document",
+		"predicate":     json.RawMessage(testSignedSBOM),
+		"subject":       []map[string]any{{"name": "sbom", "digest": map[string]string{"sha256": strings.Repeat("0", 64)}}},
+	})
+	require.NoError(t, err)
+	return statement
+}
+
+// testEnvelope signs the payload with key in a DSSE envelope
+func testEnvelope(t *testing.T, key *ecdsa.PrivateKey, payload []byte) *dsseEnvelope {
+	t.Helper()
+	pae := fmt.Sprintf("DSSEv1 %d %s %d %s", len(inTotoPayloadType), inTotoPayloadType, len(payload), payload)
+	digest := sha256.Sum256([]byte(pae))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	env := &dsseEnvelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+	}
+	env.Signatures = append(env.Signatures, struct {
+		KeyID string `json:"keyid"`
+		Sig   string `json:"sig"`
+	}{Sig: base64.StdEncoding.EncodeToString(sig)})
+	return env
+}
+
+func writeTestFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func writeTestPublicKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	return writeTestFile(t, "cosign.pub", testPublicKeyPEM(t, key))
+}
+
+func testPublicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+// testSigstore is a virtual sigstore instance and the path of its
+// trusted root
+type testSigstore struct {
+	*ca.VirtualSigstore
+	trustedRoot string
+}
+
+func newTestSigstore(t *testing.T) *testSigstore {
+	t.Helper()
+	vs, err := ca.NewVirtualSigstore()
+	require.NoError(t, err)
+
+	// The virtual logs are keyed by the hex of their ID
+	logs := map[string]*root.TransparencyLog{}
+	for id, l := range vs.RekorLogs() {
+		l.ID, err = hex.DecodeString(id)
+		require.NoError(t, err)
+		logs[id] = l
+	}
+	trustedRoot, err := root.NewTrustedRoot(root.TrustedRootMediaType01, vs.FulcioCertificateAuthorities(), nil, nil, logs)
+	require.NoError(t, err)
+	data, err := trustedRoot.MarshalJSON()
+	require.NoError(t, err)
+	return &testSigstore{VirtualSigstore: vs, trustedRoot: writeTestFile(t, "trusted_root.json", data)}
+}
+
+// tlogEntry returns a dsse entry of the log recording the payload, the
+// envelope signature and the PEM key or certificate of the signer, with
+// its signed entry timestamp and inclusion proof
+func (s *testSigstore) tlogEntry(t *testing.T, payload []byte, env *dsseEnvelope, signerPEM []byte) *protorekor.TransparencyLogEntry {
+	t.Helper()
+	payloadHash := sha256.Sum256(payload)
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "dsse",
+		"spec": map[string]any{
+			"envelopeHash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(payloadHash[:])},
+			"payloadHash":  map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(payloadHash[:])},
+			"signatures": []map[string]string{{
+				"signature": env.Signatures[0].Sig,
+				"verifier":  base64.StdEncoding.EncodeToString(signerPEM),
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	logID, err := s.RekorLogID()
+	require.NoError(t, err)
+	keyID, err := hex.DecodeString(logID)
+	require.NoError(t, err)
+	integratedTime := time.Now().Unix()
+	set, err := s.RekorSignPayload(tlog.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: integratedTime,
+		LogIndex:       25579,
+		LogID:          logID,
+	})
+	require.NoError(t, err)
+	proof, err := s.GetInclusionProof(body)
+	require.NoError(t, err)
+	rootHash, err := hex.DecodeString(*proof.RootHash)
+	require.NoError(t, err)
+
+	return &protorekor.TransparencyLogEntry{
+		LogIndex:          25579,
+		LogId:             &protocommon.LogId{KeyId: keyID},
+		KindVersion:       &protorekor.KindVersion{Kind: "dsse", Version: "0.0.1"},
+		IntegratedTime:    integratedTime,
+		InclusionPromise:  &protorekor.InclusionPromise{SignedEntryTimestamp: set},
+		CanonicalizedBody: body,
+		InclusionProof: &protorekor.InclusionProof{
+			LogIndex:   *proof.LogIndex,
+			RootHash:   rootHash,
+			TreeSize:   *proof.TreeSize,
+			Checkpoint: &protorekor.Checkpoint{Envelope: *proof.Checkpoint},
+		},
+	}
+}
+
+func readUnwrapped(t *testing.T, path string, opts *VerifyOptions) ([]byte, error) {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	doc, err := unwrapSignedDocument(f, opts)
+	if err != nil || doc == nil {
+		return nil, err
+	}
+	defer os.Remove(doc.Name())
+	defer doc.Close()
+	data, err := io.ReadAll(doc)
+	require.NoError(t, err)
+	return data, nil
+}
+
+func TestUnwrapSignedDocumentWithKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	envelope, err := json.Marshal(testEnvelope(t, key, testSignedStatement(t)))
+	require.NoError(t, err)
+	signedPath := writeTestFile(t, "sbom.intoto.json", envelope)
+	unsignedPath := writeTestFile(t, "sbom.spdx.json", []byte(testSignedSBOM))
+
+	// The right key unwraps the predicate
+	doc, err := readUnwrapped(t, signedPath, &VerifyOptions{KeyPath: writeTestPublicKey(t, key)})
+	require.NoError(t, err)
+	require.JSONEq(t, testSignedSBOM, string(doc))
+
+	// Another key does not
+	_, err = readUnwrapped(t, signedPath, &VerifyOptions{KeyPath: writeTestPublicKey(t, otherKey)})
+	require.Error(t, err)
+
+	// Without options the document is read unverified
+	doc, err = readUnwrapped(t, signedPath, nil)
+	require.NoError(t, err)
+	require.JSONEq(t, testSignedSBOM, string(doc))
+
+	// Unsigned documents are left alone unless verification is required
+	doc, err = readUnwrapped(t, unsignedPath, nil)
+	require.NoError(t, err)
+	require.Nil(t, doc)
+	_, err = readUnwrapped(t, unsignedPath, &VerifyOptions{KeyPath: writeTestPublicKey(t, key)})
+	require.Error(t, err)
+}
+
+func TestUnwrapSignedDocumentWithCertificate(t *testing.T) {
+	const identity, issuer = "release@example.com", "https://accounts.google.com"
+	vs := newTestSigstore(t)
+	leaf, signingKey, err := vs.GenerateLeafCert(identity, issuer)
+	require.NoError(t, err)
+	leafPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+	payload := testSignedStatement(t)
+	env := testEnvelope(t, signingKey, payload)
+
+	writeBundle := func(entry *protorekor.TransparencyLogEntry) string {
+		envelope, err := env.toProto()
+		require.NoError(t, err)
+		data, err := protojson.Marshal(&protobundle.Bundle{
+			MediaType: bundleMediaType,
+			VerificationMaterial: &protobundle.VerificationMaterial{
+				Content: &protobundle.VerificationMaterial_Certificate{
+					Certificate: &protocommon.X509Certificate{RawBytes: leaf.Raw},
+				},
+				TlogEntries: []*protorekor.TransparencyLogEntry{entry},
+			},
+			Content: &protobundle.Bundle_DsseEnvelope{DsseEnvelope: envelope},
+		})
+		require.NoError(t, err)
+		return writeTestFile(t, "sbom.sigstore.json", data)
+	}
+	path := writeBundle(vs.tlogEntry(t, payload, env, leafPEM))
+
+	for _, tc := range []struct {
+		opts      VerifyOptions
+		shouldErr bool
+	}{
+		{VerifyOptions{CertificateIdentity: identity, CertificateOIDCIssuer: issuer}, false},
+		{VerifyOptions{CertificateIdentity: "someone@example.com", CertificateOIDCIssuer: issuer}, true},
+		{VerifyOptions{CertificateIdentity: identity, CertificateOIDCIssuer: "https://github.com/login/oauth"}, true},
+		{VerifyOptions{CertificateIdentity: identity}, true},
+	} {
+		tc.opts.TrustedRoot = vs.trustedRoot
+		doc, err := readUnwrapped(t, path, &tc.opts)
+		if tc.shouldErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.JSONEq(t, testSignedSBOM, string(doc))
+	}
+
+	opts := &VerifyOptions{CertificateIdentity: identity, CertificateOIDCIssuer: issuer, TrustedRoot: vs.trustedRoot}
+
+	// Certificates and entries not issued by the trusted root are rejected
+	_, err = readUnwrapped(t, path, &VerifyOptions{
+		CertificateIdentity: identity, CertificateOIDCIssuer: issuer, TrustedRoot: newTestSigstore(t).trustedRoot,
+	})
+	require.Error(t, err)
+
+	// The time of entries not signed by the log is not trusted
+	tampered := vs.tlogEntry(t, payload, env, leafPEM)
+	tampered.IntegratedTime -= 3600
+	_, err = readUnwrapped(t, writeBundle(tampered), opts)
+	require.Error(t, err)
+
+	// Entries must record the signing certificate and the signed payload
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	for _, entry := range []*protorekor.TransparencyLogEntry{
+		vs.tlogEntry(t, payload, env, testPublicKeyPEM(t, otherKey)),
+		vs.tlogEntry(t, []byte("another payload"), env, leafPEM),
+	} {
+		_, err = readUnwrapped(t, writeBundle(entry), opts)
+		require.Error(t, err)
+	}
+}
+
+func TestUnwrapSignedDocumentWithRekorLookup(t *testing.T) {
+	vs := newTestSigstore(t)
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	payload := testSignedStatement(t)
+	digest := sha256.Sum256(payload)
+	env := testEnvelope(t, signingKey, payload)
+	entries := map[string]*protorekor.TransparencyLogEntry{
+		"24296fb24b8ad77a": vs.tlogEntry(t, payload, env, testPublicKeyPEM(t, otherKey)),
+		"24296fb24b8ad77b": vs.tlogEntry(t, payload, env, testPublicKeyPEM(t, signingKey)),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/index/retrieve" {
+			query := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if query["hash"] == "sha256:"+hex.EncodeToString(digest[:]) {
+				_, _ = w.Write([]byte(`["24296fb24b8ad77a", "24296fb24b8ad77b"]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		uuid := strings.TrimPrefix(r.URL.Path, "/api/v1/log/entries/")
+		entry, ok := entries[uuid]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{uuid: map[string]any{ //nolint:errcheck
+			"body":           base64.StdEncoding.EncodeToString(entry.CanonicalizedBody),
+			"integratedTime": entry.IntegratedTime,
+			"logID":          hex.EncodeToString(entry.LogId.KeyId),
+			"logIndex":       entry.LogIndex,
+			"verification": map[string]any{
+				"signedEntryTimestamp": base64.StdEncoding.EncodeToString(entry.InclusionPromise.SignedEntryTimestamp),
+				"inclusionProof": map[string]any{
+					"checkpoint": entry.InclusionProof.Checkpoint.Envelope,
+					"hashes":     []string{},
+					"logIndex":   entry.InclusionProof.LogIndex,
+					"rootHash":   hex.EncodeToString(entry.InclusionProof.RootHash),
+					"treeSize":   entry.InclusionProof.TreeSize,
+				},
+			},
+		}})
+	}))
+	defer srv.Close()
+
+	envelope, err := json.Marshal(env)
+	require.NoError(t, err)
+	signedPath := writeTestFile(t, "sbom.intoto.json", envelope)
+
+	// The entry recording the signing key is found
+	doc, err := readUnwrapped(t, signedPath, &VerifyOptions{
+		KeyPath: writeTestPublicKey(t, signingKey), RekorURL: srv.URL, TrustedRoot: vs.trustedRoot,
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, testSignedSBOM, string(doc))
+
+	// Entries recording other keys, signed by other logs or of payloads
+	// not logged fail
+	thirdKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, err = readUnwrapped(t, writeTestFile(t, "other.intoto.json", mustMarshal(t, testEnvelope(t, thirdKey, payload))),
+		&VerifyOptions{KeyPath: writeTestPublicKey(t, thirdKey), RekorURL: srv.URL, TrustedRoot: vs.trustedRoot})
+	require.Error(t, err)
+	_, err = readUnwrapped(t, signedPath, &VerifyOptions{
+		KeyPath: writeTestPublicKey(t, signingKey), RekorURL: srv.URL, TrustedRoot: newTestSigstore(t).trustedRoot,
+	})
+	require.Error(t, err)
+	unlogged := testSignedStatement(t)
+	unlogged = append(unlogged, ' ')
+	_, err = readUnwrapped(t, writeTestFile(t, "unlogged.intoto.json", mustMarshal(t, testEnvelope(t, signingKey, unlogged))),
+		&VerifyOptions{KeyPath: writeTestPublicKey(t, signingKey), RekorURL: srv.URL, TrustedRoot: vs.trustedRoot})
+	require.Error(t, err)
+
+	// The log is checked on top of a signature, never instead of one
+	_, err = readUnwrapped(t, signedPath, &VerifyOptions{RekorURL: srv.URL, TrustedRoot: vs.trustedRoot})
+	require.Error(t, err)
+}
+
+func TestCheckTlogPayload(t *testing.T) {
+	payload := []byte("payload")
+	digest := sha256.Sum256(payload)
+	value := hex.EncodeToString(digest[:])
+
+	for _, tc := range []struct {
+		body      string
+		shouldErr bool
+	}{
+		{`{"kind":"dsse","spec":{"payloadHash":{"algorithm":"sha256","value":"` + value + `"}}}`, false},
+		{`{"kind":"intoto","spec":{"content":{"payloadHash":{"algorithm":"sha256","value":"` + value + `"}}}}`, false},
+		{`{"kind":"dsse","spec":{"payloadHash":{"algorithm":"sha256","value":"` + strings.Repeat("0", 64) + `"}}}`, true},
+		{`{"kind":"dsse","spec":{"payloadHash":{"algorithm":"sha512","value":"` + value + `"}}}`, true},
+		{`{"kind":"hashedrekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"` + value + `"}}}}`, true},
+	} {
+		err := checkTlogPayload([]byte(tc.body), payload)
+		if tc.shouldErr {
+			require.Error(t, err, tc.body)
+		} else {
+			require.NoError(t, err, tc.body)
+		}
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}